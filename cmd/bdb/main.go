@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +40,8 @@ func bdbCmd() *cobra.Command {
 	}
 	cmd.AddCommand(versionCmd())
 	cmd.AddCommand(startCmd())
+	cmd.AddCommand(verifyCmd())
+	cmd.AddCommand(rebuildCmd())
 	return cmd
 }
 
@@ -104,3 +109,125 @@ func startCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&configPath, "configpath", "", "set the absolute path of config directory")
 	return cmd
 }
+
+func verifyCmd() *cobra.Command {
+	var verifyConfigPath, ledgerDir string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the integrity of the ledger of a stopped blockchain database",
+		Long: "Verifies the integrity of the ledger of a stopped blockchain database: the hash chain of the blocks, " +
+			"their skip lists and tx merkle tree roots, the state trie roots against the roots recomputed from the writes " +
+			"of the blocks, and the last state trie in the state trie store. " +
+			"The ledger directory is taken from the config, unless --ledgerdir is set.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Trailing arguments detected")
+			}
+
+			dbConf, err := readDatabaseConf(verifyConfigPath, ledgerDir)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			lg, err := newCmdLogger()
+			if err != nil {
+				return err
+			}
+
+			res, err := bcdb.VerifyLedger(dbConf, lg)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("The ledger at [%s] is consistent up to block [%d]\n", dbConf.LedgerDirectory, res.BlockHeight)
+			if res.StateTrieHeight < res.BlockHeight {
+				cmd.Printf("The state trie is at block [%d] and catches up on the next start\n", res.StateTrieHeight)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&verifyConfigPath, "configpath", "", "set the absolute path of config directory")
+	cmd.Flags().StringVar(&ledgerDir, "ledgerdir", "", "set the path of the ledger directory, which overrides the one in the config")
+	return cmd
+}
+
+func rebuildCmd() *cobra.Command {
+	var rebuildConfigPath, ledgerDir string
+	var stores []string
+
+	cmd := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuilds the derived stores of the ledger of a stopped blockchain database from its block store",
+		Long: "Rebuilds the derived stores of the ledger of a stopped blockchain database from its block store. " +
+			"The selected stores are regenerated next to the live ones by replaying the blocks, and replace them " +
+			"only once the state trie roots have been checked against the block headers. The ledger directory is taken from the config, unless --ledgerdir is set.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Trailing arguments detected")
+			}
+
+			dbConf, err := readDatabaseConf(rebuildConfigPath, ledgerDir)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			lg, err := newCmdLogger()
+			if err != nil {
+				return err
+			}
+
+			height, err := bcdb.RebuildLedger(dbConf, stores, lg)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Rebuilt %s of the ledger at [%s] up to block [%d]\n", strings.Join(stores, ","), dbConf.LedgerDirectory, height)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rebuildConfigPath, "configpath", "", "set the absolute path of config directory")
+	cmd.Flags().StringVar(&ledgerDir, "ledgerdir", "", "set the path of the ledger directory, which overrides the one in the config")
+	cmd.Flags().StringSliceVar(&stores, "stores", bcdb.RebuildableStores, "set the stores to rebuild: "+strings.Join(bcdb.RebuildableStores, ","))
+	return cmd
+}
+
+// readDatabaseConf returns the database configuration of the config in the given path, or in the path
+// held by the path environment variable. The ledger directory, if set, overrides the one in the config,
+// and is enough when no config is set.
+func readDatabaseConf(path, ledgerDir string) (*config.DatabaseConf, error) {
+	if path == "" {
+		path = os.Getenv(pathEnv)
+	}
+
+	switch {
+	case path != "":
+		conf, err := config.Read(path)
+		if err != nil {
+			return nil, err
+		}
+		dbConf := &conf.LocalConfig.Server.Database
+		if ledgerDir != "" {
+			dbConf.LedgerDirectory = ledgerDir
+		}
+		return dbConf, nil
+	case ledgerDir != "":
+		return &config.DatabaseConf{LedgerDirectory: ledgerDir}, nil
+	default:
+		return nil, fmt.Errorf("Neither --ledgerdir, --configpath, nor %s path environment is set", pathEnv)
+	}
+}
+
+func newCmdLogger() (*logger.SugarLogger, error) {
+	return logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stderr"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          "bdb",
+	})
+}
@@ -4,6 +4,8 @@ package main
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -62,6 +64,46 @@ func TestVersionCmd(t *testing.T) {
 	assert.Equal(t, "", errStr)
 }
 
+func TestVerifyCmd(t *testing.T) {
+	os.Unsetenv(pathEnv)
+
+	cmd := verifyCmd()
+	cmd.SetArgs([]string{"arg1"})
+	err, _, _ := executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "Trailing arguments detected")
+
+	cmd = verifyCmd()
+	cmd.SetArgs([]string{})
+	err, _, _ = executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "Neither --ledgerdir, --configpath, nor BCDB_CONFIG_PATH path environment is set")
+
+	ledgerDir := filepath.Join(t.TempDir(), "ledger")
+	cmd = verifyCmd()
+	cmd.SetArgs([]string{"--ledgerdir", ledgerDir})
+	err, _, _ = executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "the ledger directory ["+ledgerDir+"] does not hold a store at ["+filepath.Join(ledgerDir, "blockstore")+"]")
+}
+
+func TestRebuildCmd(t *testing.T) {
+	os.Unsetenv(pathEnv)
+
+	cmd := rebuildCmd()
+	cmd.SetArgs([]string{"arg1"})
+	err, _, _ := executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "Trailing arguments detected")
+
+	cmd = rebuildCmd()
+	cmd.SetArgs([]string{"--stores=trie"})
+	err, _, _ = executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "Neither --ledgerdir, --configpath, nor BCDB_CONFIG_PATH path environment is set")
+
+	ledgerDir := filepath.Join(t.TempDir(), "ledger")
+	cmd = rebuildCmd()
+	cmd.SetArgs([]string{"--ledgerdir", ledgerDir, "--stores=trie,blocks"})
+	err, _, _ = executeCaptureOutputs(cmd)
+	require.EqualError(t, err, "unknown store [blocks], the stores that can be rebuilt are: worldstate,provenance,trie,index")
+}
+
 func executeCaptureOutputs(cmd *cobra.Command) (error, string, string) {
 	bufOut := bytes.Buffer{}
 	bufErr := bytes.Buffer{}
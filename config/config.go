@@ -15,6 +15,12 @@ import (
 
 const (
 	defaultLocalConfigFile = "config.yml"
+
+	// DefaultMaxMessageSize is used when the `ReplicationConf.MaxMessageSize` is zero.
+	DefaultMaxMessageSize = 128 * 1024 * 1024
+
+	// DefaultMaxProofKeys is used when the `QueryProcessingConf.MaxProofKeys` is zero.
+	DefaultMaxProofKeys = 1000
 )
 
 // Configurations holds the complete configuration of a database node.
@@ -41,12 +47,41 @@ type ReplicationConf struct {
 	SnapDir string
 	// AuxDir defines the directory used to store auxiliary and temporary files during replication.
 	AuxDir string
+	// StateTransferMinBlocks defines the minimal number of missing blocks for which a node that catches up with the
+	// cluster pulls a snapshot of the state from a remote peer, instead of re-executing all the missing blocks.
+	// Zero disables the state snapshot transfer.
+	StateTransferMinBlocks uint64
+	// StateTransferAnchorTimeout defines how long a node that transfers a state snapshot waits for a quorum of the
+	// consensus members to attest the last block of the snapshot, before it falls back to pulling all the blocks.
+	// If zero, 30s is used.
+	StateTransferAnchorTimeout time.Duration
+	// AttestationInterval defines the interval at which a node signs the headers of the blocks it committed, and
+	// collects the signatures of the other consensus members into quorum certificates. If zero, 1s is used.
+	AttestationInterval time.Duration
+	// MaxMessageSize defines the maximal size, in bytes, of a message received from a peer, i.e., a replicated block or
+	// a catch-up response, once decompressed. A larger message is rejected. If zero, 128 MiB is used.
+	MaxMessageSize uint64
+	// Transport defines the protocol used for server to server communication: "http", which uses the etcd Raft HTTP
+	// transport and a separate HTTP catch-up service, or "grpc", which carries both the Raft messages and the catch-up
+	// over gRPC streams, on a single connection per peer. If empty, "http" is used.
+	Transport string
+	// FaultInjection enables the injection of network faults into the server to server communication, which are set by
+	// an admin over the debug endpoint. It is meant for testing only, and must not be enabled in production.
+	FaultInjection bool
 	// Network defines the listen address and port used for server to server communication.
 	Network NetworkConf
 	// TLS defines TLS settings for server to server communication.
 	TLS TLSConf
 }
 
+// MessageSizeLimit returns the maximal size, in bytes, of a message received from a peer once decompressed.
+func (c *ReplicationConf) MessageSizeLimit() uint64 {
+	if c.MaxMessageSize == 0 {
+		return DefaultMaxMessageSize
+	}
+	return c.MaxMessageSize
+}
+
 // TLSConf holds TLS configuration settings.
 type TLSConf struct {
 	// Require server-side TLS.
@@ -74,6 +109,10 @@ type ServerConf struct {
 	Identity IdentityConf
 	// The network interface and port used to serve client requests.
 	Network NetworkConf
+	// The network interface and port of a separate listener that serves the Prometheus metrics over plain HTTP,
+	// without authentication. It should be reachable by the monitoring system only. If omitted, the metrics are not
+	// served.
+	Metrics *NetworkConf
 	// The database configuration of the local node.
 	Database DatabaseConf
 	// The lengths of various queues that buffer between internal components.
@@ -109,8 +148,45 @@ type NetworkConf struct {
 
 // DatabaseConf holds the name of the state database and the path where the data is stored.
 type DatabaseConf struct {
+	// Name is the engine of the state database: "leveldb" (default), "pebble", or "memory". With "memory", the state
+	// database, the block, provenance, and state trie stores, and the Raft log are kept in memory only, and the
+	// LedgerDirectory is not used. Such a node starts with an empty ledger on every restart.
 	Name            string
 	LedgerDirectory string
+	// BlockStore holds the optional block compression and archival settings of the block store.
+	BlockStore BlockStoreConf
+	// StateTrie holds the optional pruning settings of the state trie store.
+	StateTrie StateTrieConf
+}
+
+// BlockStoreConf holds the configuration of the block store.
+type BlockStoreConf struct {
+	// Compression is the compression of the stored blocks: "snappy" (default), "zstd", "gzip", or "none". Blocks
+	// stored with another compression remain readable.
+	Compression string
+	// ArchiveDirectory, if set, is the directory to which the block file chunks are moved once sealed. It must not
+	// be inside the LedgerDirectory. The archived blocks are still served by the node.
+	ArchiveDirectory string
+	// ArchiveCompression is the compression of the archived chunks: "none" (default), "gzip", or "zstd". A chunk
+	// archived with compression is kept in a compressed tar.
+	ArchiveCompression string
+}
+
+// StateTrieConf holds the configuration of the state trie store.
+type StateTrieConf struct {
+	// RetainLastBlocks, if positive, enables the pruning of the state trie history: the state trie of the blocks
+	// preceding the last RetainLastBlocks blocks is removed, and the proofs of their data can no longer be served.
+	RetainLastBlocks uint64
+	// CheckpointInterval, if positive, retains the state trie of every block whose number is a multiple of it as
+	// well. It cannot be changed once the state trie is pruned.
+	CheckpointInterval uint64
+	// PruneInterval is the interval between two prunings of the state trie, one hour by default.
+	PruneInterval time.Duration
+}
+
+// InMemory returns true if the ledger is kept in memory only
+func (c *DatabaseConf) InMemory() bool {
+	return c.Name == "memory"
 }
 
 // QueueLengthConf holds the queue length of all queues within the node.
@@ -123,6 +199,17 @@ type QueueLengthConf struct {
 // QueueProcessingConf holds the configuration associated with rich and range query processing
 type QueryProcessingConf struct {
 	ResponseSizeLimitInBytes uint64
+	// MaxProofKeys defines the maximal number of keys of a query for the proof of the state of several keys. A query
+	// with more keys is rejected. If zero, 1000 is used.
+	MaxProofKeys uint64
+}
+
+// ProofKeysLimit returns the maximal number of keys of a query for the proof of the state of several keys.
+func (c *QueryProcessingConf) ProofKeysLimit() uint64 {
+	if c.MaxProofKeys == 0 {
+		return DefaultMaxProofKeys
+	}
+	return c.MaxProofKeys
 }
 
 // BlockCreationConf holds the block creation parameters.
@@ -131,6 +218,9 @@ type BlockCreationConf struct {
 	MaxBlockSize                uint64
 	MaxTransactionCountPerBlock uint32
 	BlockTimeout                time.Duration
+	// ExpiryScanInterval defines how often the leader looks up the keys whose TTL has
+	// passed and proposes an expiry transaction deleting them. Zero disables the proposals.
+	ExpiryScanInterval time.Duration
 }
 
 // BootstrapConf specifies the method of starting a new node with an empty ledger and database.
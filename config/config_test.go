@@ -142,7 +142,7 @@ func TestLocalConfig(t *testing.T) {
 
 	t.Run("unmarshal-error", func(t *testing.T) {
 		config, err := readLocalConfig("./testdata/3node-shared-config-bootstrap.yml")
-		require.EqualError(t, err, "unable to unmarshal local config file: './testdata/3node-shared-config-bootstrap.yml' into struct: 1 error(s) decoding:\n\n* '' has invalid keys: admin, caconfig, consensus, nodes")
+		require.EqualError(t, err, "unable to unmarshal local config file: './testdata/3node-shared-config-bootstrap.yml' into struct: 1 error(s) decoding:\n\n* '' has invalid keys: additionaladmins, admin, caconfig, consensus, governancepolicy, nodes")
 		require.Nil(t, config)
 	})
 }
@@ -21,6 +21,11 @@ type SharedConfiguration struct {
 	Consensus *ConsensusConf
 	CAConfig  CAConfiguration
 	Admin     AdminConf
+	// AdditionalAdmins are the initial database administrators besides Admin. Optional.
+	AdditionalAdmins []AdminConf
+	// GovernancePolicy holds the number of admins that must approve each kind of administrative transaction.
+	// Optional; when empty, the signature of the submitting admin is sufficient.
+	GovernancePolicy *GovernancePolicyConf
 }
 
 // NodeConf carry the identity, endpoint, and certificate of a database node that serves to clients.
@@ -36,14 +41,16 @@ type NodeConf struct {
 }
 
 type ConsensusConf struct {
-	// The consensus algorithm, currently only "raft" is supported.
+	// The consensus algorithm: "raft" or "bft".
 	Algorithm string
 	// Peers that take part in consensus.
 	Members []*PeerConf
-	// Peers that are allowed to connect and fetch the ledger from members, but do not take part in consensus.
+	// Peers that replicate the ledger as Raft learners and serve queries, but do not vote and cannot become leaders.
 	Observers []*PeerConf
-	// Raft protocol parameters.
+	// Raft protocol parameters. The BFT algorithm uses the compression of the catch-up responses from these as well.
 	RaftConfig *RaftConf
+	// BFT protocol parameters, used when the algorithm is "bft".
+	BFTConfig *BFTConf
 }
 
 type RaftConf struct {
@@ -63,13 +70,26 @@ type RaftConf struct {
 	MaxInflightBlocks uint32
 	// Take a snapshot when cumulative data since last snapshot exceeds a certain size in bytes.
 	SnapshotIntervalSize uint64
+	// The compression of the blocks in Raft entries and in catch-up responses: "none", "gzip" or "zstd".
+	// Empty means "none". Raft entries are compressed only if EntryFormatVersion is at least 1.
+	Compression string
+	// The format of the data in Raft entries: 0 - marshaled blocks; 1 - blocks compressed according to Compression.
+	// A cluster whose servers predate version 1 must upgrade all of them before it sets version 1.
+	EntryFormatVersion uint32
+}
+
+type BFTConf struct {
+	// The time a member waits for the primary to make progress before it asks to change the view, e.g. 2s.
+	// Empty means the default of 2s.
+	ViewChangeTimeout string
 }
 
 // PeerConf defines a server that takes part in consensus, or an observer.
 type PeerConf struct {
 	// The node ID correlates the peer definition here with the NodeConfig.ID field.
 	NodeId string
-	// Raft ID must be >0 for members, or =0 for observers.
+	// Raft ID must be >0 and unique across members and observers. An observer with Raft ID 0, as required before
+	// observers replicated the ledger as Raft learners, does not replicate until it is assigned a Raft ID.
 	RaftId uint64
 	// The host name or IP address that is used by other peers to connect to this peer.
 	PeerHost string
@@ -85,6 +105,15 @@ type AdminConf struct {
 	CertificatePath string
 }
 
+// GovernancePolicyConf holds the minimal number of admins that must sign each kind of administrative transaction.
+// A value of zero or one means that the signature of the submitting admin is sufficient. A value cannot exceed the
+// number of admins, i.e., Admin and AdditionalAdmins.
+type GovernancePolicyConf struct {
+	ConfigTxMinAdmins    uint32
+	UserAdminTxMinAdmins uint32
+	DbAdminTxMinAdmins   uint32
+}
+
 // readSharedConfig reads the shared config from the file and returns it.
 func readSharedConfig(sharedConfigFile string) (*SharedConfiguration, error) {
 	if sharedConfigFile == "" {
@@ -75,6 +75,17 @@ var expectedSharedConfig = &SharedConfiguration{
 		ID:              "admin",
 		CertificatePath: "./testdata/admin.cert",
 	},
+	AdditionalAdmins: []AdminConf{
+		{
+			ID:              "admin2",
+			CertificatePath: "./testdata/admin2.cert",
+		},
+	},
+	GovernancePolicy: &GovernancePolicyConf{
+		ConfigTxMinAdmins:    2,
+		UserAdminTxMinAdmins: 1,
+		DbAdminTxMinAdmins:   1,
+	},
 }
 
 func TestSharedConfig(t *testing.T) {
@@ -4,14 +4,18 @@
 package bcdb
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie/store"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
@@ -117,12 +121,13 @@ func newConfigQueryTestEnv(t *testing.T) *configQueryTestEnv {
 	}
 
 	ledgerProcConfig := &ledgerQueryProcessorConfig{
-		db:              db,
-		blockStore:      blockStore,
-		provenanceStore: provenanceStore,
-		trieStore:       trieStore,
-		identityQuerier: identity.NewQuerier(db),
-		logger:          logger,
+		db:                  db,
+		queryProcessingConf: &config.QueryProcessingConf{},
+		blockStore:          blockStore,
+		provenanceStore:     provenanceStore,
+		trieStore:           trieStore,
+		identityQuerier:     identity.NewQuerier(db),
+		logger:              logger,
 	}
 	stateProcConfig := &worldstateQueryProcessorConfig{
 		nodeID:          nodeID,
@@ -409,6 +414,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
+		txProcMock.On("LeadershipTransfer").Return(nil)
 		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
 
 		status, err := bcdb.GetClusterStatus(false)
@@ -425,6 +431,29 @@ func TestGetClusterStatus(t *testing.T) {
 		require.Equal(t, &types.Version{BlockNum: 10}, status.Response.Version)
 		require.Equal(t, "node1", status.Response.Leader)
 		require.Equal(t, []string{"node1", "node2"}, status.Response.Active)
+		require.Nil(t, status.Response.LeadershipTransfer)
+	})
+
+	t.Run("valid: leadership transfer", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		signerMock := &crypto_mocks.Signer{}
+		bcdb.txProcessor = txProcMock
+		bcdb.signer = signerMock
+
+		transfer := &types.LeadershipTransfer{
+			FromNodeId: "node1",
+			ToNodeId:   "node2",
+			Status:     types.LeadershipTransfer_SUCCEEDED,
+		}
+		txProcMock.On("ClusterStatus").Return("node2", []string{"node1", "node2"})
+		txProcMock.On("LeadershipTransfer").Return(transfer)
+		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+
+		status, err := bcdb.GetClusterStatus(false)
+		require.NoError(t, err)
+		require.NotNil(t, status)
+		require.Equal(t, "node2", status.Response.Leader)
+		require.Equal(t, transfer, status.Response.LeadershipTransfer)
 	})
 
 	t.Run("valid: no leader", func(t *testing.T) {
@@ -434,6 +463,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("", []string{"node1"})
+		txProcMock.On("LeadershipTransfer").Return(nil)
 		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
 		status, err := bcdb.GetClusterStatus(false)
 		require.NoError(t, err)
@@ -458,6 +488,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
+		txProcMock.On("LeadershipTransfer").Return(nil)
 		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
 		status, err := bcdb.GetClusterStatus(true)
 		require.NoError(t, err)
@@ -482,6 +513,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("bogus-node", []string{"node1", "node2", "bogus-node"})
+		txProcMock.On("LeadershipTransfer").Return(nil)
 		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
 
 		status, err := bcdb.GetClusterStatus(false)
@@ -507,9 +539,211 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
+		txProcMock.On("LeadershipTransfer").Return(nil)
 		signerMock.On("Sign", mock.Anything).Return(nil, fmt.Errorf("oops"))
 		status, err := bcdb.GetClusterStatus(false)
 		require.EqualError(t, err, "oops")
 		require.Nil(t, status)
 	})
 }
+
+func TestTransferLeadership(t *testing.T) {
+	env := newConfigQueryTestEnv(t)
+	require.NotNil(t, env)
+	setupConfigQueryTest(t, env, 10)
+
+	bcdb := &db{
+		nodeID:                   "node1",
+		worldstateQueryProcessor: env.stateQP,
+		ledgerQueryProcessor:     env.ledgerQP,
+		db:                       env.db,
+		logger:                   env.logger,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		signerMock := &crypto_mocks.Signer{}
+		bcdb.txProcessor = txProcMock
+		bcdb.signer = signerMock
+
+		transfer := &types.LeadershipTransfer{
+			FromNodeId: "node1",
+			ToNodeId:   "node2",
+			Status:     types.LeadershipTransfer_SUCCEEDED,
+		}
+		txProcMock.On("TransferLeadership", "node2", time.Second).Return(transfer, nil)
+		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+
+		resp, err := bcdb.TransferLeadership("admin1", "node2", time.Now().UnixNano(), time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "node1", resp.Response.Header.NodeId)
+		require.Equal(t, transfer, resp.Response.LeadershipTransfer)
+		require.Equal(t, []byte("bogus-sig"), resp.Signature)
+	})
+
+	t.Run("error: not an admin", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+
+		resp, err := bcdb.TransferLeadership("testUser", "node2", time.Now().UnixNano(), time.Second)
+		require.EqualError(t, err, "the user [testUser] has no permission to transfer the leadership of the cluster")
+		require.IsType(t, &interrors.PermissionErr{}, err)
+		require.Nil(t, resp)
+		txProcMock.AssertNotCalled(t, "TransferLeadership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error: transfer not started", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+
+		txProcMock.On("TransferLeadership", "node3", time.Duration(0)).Return(nil,
+			&interrors.BadRequestError{ErrMsg: "the target node [node3] is not a consensus member"})
+
+		resp, err := bcdb.TransferLeadership("admin1", "node3", time.Now().UnixNano(), 0)
+		require.EqualError(t, err, "the target node [node3] is not a consensus member")
+		require.Nil(t, resp)
+	})
+
+	t.Run("error: stale request", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+
+		for _, timestamp := range []int64{
+			time.Now().Add(-2 * time.Minute).UnixNano(),
+			time.Now().Add(2 * time.Minute).UnixNano(),
+		} {
+			resp, err := bcdb.TransferLeadership("admin1", "node2", timestamp, time.Second)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "which is more than 1m0s away from the time of the node")
+			require.IsType(t, &interrors.BadRequestError{}, err)
+			require.Nil(t, resp)
+		}
+		txProcMock.AssertNotCalled(t, "TransferLeadership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error: repeated request", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		signerMock := &crypto_mocks.Signer{}
+		bcdb.txProcessor = txProcMock
+		bcdb.signer = signerMock
+
+		transfer := &types.LeadershipTransfer{
+			FromNodeId: "node1",
+			ToNodeId:   "node2",
+			Status:     types.LeadershipTransfer_SUCCEEDED,
+		}
+		txProcMock.On("TransferLeadership", "node2", time.Second).Return(transfer, nil).Once()
+		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+
+		timestamp := time.Now().UnixNano()
+		resp, err := bcdb.TransferLeadership("admin1", "node2", timestamp, time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+
+		for _, ts := range []int64{timestamp, timestamp - 1} {
+			resp, err = bcdb.TransferLeadership("admin1", "node2", ts, time.Second)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "the leadership transfer request of the user [admin1] is not newer than the last request of the user")
+			require.IsType(t, &interrors.BadRequestError{}, err)
+			require.Nil(t, resp)
+		}
+		txProcMock.AssertNumberOfCalls(t, "TransferLeadership", 1)
+	})
+
+}
+
+func TestSetNetworkFaults(t *testing.T) {
+	env := newConfigQueryTestEnv(t)
+	require.NotNil(t, env)
+	setupConfigQueryTest(t, env, 10)
+
+	bcdb := &db{
+		nodeID:                   "node1",
+		worldstateQueryProcessor: env.stateQP,
+		ledgerQueryProcessor:     env.ledgerQP,
+		db:                       env.db,
+		logger:                   env.logger,
+	}
+
+	faults := []*types.NetworkFault{
+		{FromNodeId: "node1", ToNodeId: "node2", Partition: true},
+		{ToNodeId: "node3", DelayMs: 100},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		signerMock := &crypto_mocks.Signer{}
+		bcdb.txProcessor = txProcMock
+		bcdb.signer = signerMock
+
+		txProcMock.On("SetNetworkFaults", faults, int64(7)).Return(faults, nil)
+		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+
+		resp, err := bcdb.SetNetworkFaults("admin1", faults, 7)
+		require.NoError(t, err)
+		require.Equal(t, "node1", resp.Response.Header.NodeId)
+		require.Equal(t, faults, resp.Response.Faults)
+		require.Equal(t, []byte("bogus-sig"), resp.Signature)
+	})
+
+	t.Run("error: not an admin", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+
+		resp, err := bcdb.SetNetworkFaults("testUser", faults, 7)
+		require.EqualError(t, err, "the user [testUser] has no permission to set network faults")
+		require.IsType(t, &interrors.PermissionErr{}, err)
+		require.Nil(t, resp)
+		txProcMock.AssertNotCalled(t, "SetNetworkFaults", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error: fault injection not enabled", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+
+		txProcMock.On("SetNetworkFaults", faults, int64(7)).Return(nil,
+			&interrors.BadRequestError{ErrMsg: "network fault injection is not enabled on this node"})
+
+		resp, err := bcdb.SetNetworkFaults("admin1", faults, 7)
+		require.EqualError(t, err, "network fault injection is not enabled on this node")
+		require.Nil(t, resp)
+	})
+}
+
+func TestLinearizableRead(t *testing.T) {
+	env := newConfigQueryTestEnv(t)
+	require.NotNil(t, env)
+	defer env.cleanup(t)
+
+	bcdb := &db{
+		nodeID: "node1",
+		db:     env.db,
+		logger: env.logger,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+		txProcMock.On("LinearizableRead", mock.Anything).Return(uint64(12), nil)
+
+		require.NoError(t, bcdb.LinearizableRead(context.Background()))
+		txProcMock.AssertNumberOfCalls(t, "LinearizableRead", 1)
+	})
+
+	t.Run("error: read index timeout", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		bcdb.txProcessor = txProcMock
+		txProcMock.On("LinearizableRead", mock.Anything).Return(uint64(0),
+			&interrors.TimeoutErr{ErrMsg: "timeout has occurred while waiting for the local commit to reach the read index: context deadline exceeded"})
+
+		err := bcdb.LinearizableRead(context.Background())
+		require.EqualError(t, err, "timeout has occurred while waiting for the local commit to reach the read index: context deadline exceeded")
+		require.IsType(t, &interrors.TimeoutErr{}, err)
+	})
+
+	t.Run("response header carries the block height", func(t *testing.T) {
+		header := bcdb.responseHeaderAt(12)
+		require.Equal(t, "node1", header.NodeId)
+		require.Equal(t, uint64(12), header.BlockHeight)
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"fmt"
 	"io/ioutil"
+	"sync"
 	"time"
 
 	"github.com/hyperledger-labs/orion-server/config"
@@ -15,10 +17,14 @@ import (
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/statetransfer"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/memorydb"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/pebbledb"
 	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -26,6 +32,11 @@ import (
 	"github.com/pkg/errors"
 )
 
+// defaultStateTriePruneInterval is the interval between two prunings of the state trie store when the
+// retention of the state trie history is configured without an interval. A pruning walks the retained
+// state tries and scans the whole store, so it is not meant to run often.
+const defaultStateTriePruneInterval = time.Hour
+
 //go:generate mockery --dir . --name DB --case underscore --output mocks/
 
 // DB encapsulates functionality required to operate with database state
@@ -61,9 +72,25 @@ type DB interface {
 	// GetClusterStatus returns the cluster status:
 	// - the nodes, as defined in the ClusterConfig, without certificates if `noCert`=true;
 	// - the ID of the leader, if it exists;
-	// - the IDs of all active nodes, including the leader.
+	// - the IDs of all active nodes, including the leader;
+	// - the last leadership transfer requested on this node, if any.
 	GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnvelope, error)
 
+	// TransferLeadership hands over the leadership of the cluster to the consensus member with the given node ID.
+	// Only admin users can transfer the leadership, and only the leader can serve the request. The leader stops
+	// accepting transactions, waits for its in-flight blocks to commit, and then waits for the target to become the
+	// leader, for at most the given timeout. If the timeout is 0, twice the Raft election timeout is used.
+	// The outcome of the transfer is returned, and is also reported by `GetClusterStatus`. The timestamp, at which the
+	// request was created, must be within `maxLeadershipTransferRequestSkew` of the local clock and newer than the
+	// timestamp of the last request of the user served by the node, so that a request cannot be replayed.
+	TransferLeadership(userID, targetNodeID string, timestamp int64, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error)
+
+	// SetNetworkFaults replaces the network faults the node injects into the messages it sends to the other nodes of
+	// the cluster, for testing. Only admin users can set the faults, and only if the node is configured with
+	// `Replication.FaultInjection`. An empty list of faults heals the network. The seed makes the random drops and
+	// duplicates reproducible. The faults that are set are returned.
+	SetNetworkFaults(userID string, faults []*types.NetworkFault, seed int64) (*types.SetNetworkFaultsResponseEnvelope, error)
+
 	// GetNodeConfig returns single node subsection of database configuration
 	GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope, error)
 
@@ -74,10 +101,12 @@ type DB interface {
 	// response envelope is a JSON string.
 	GetDBIndex(dbName, querierUserID string) (*types.GetDBIndexResponseEnvelope, error)
 
-	// GetData retrieves values for given key
+	// GetData retrieves values for given key. The response header carries the height of the world state the
+	// read was served at.
 	GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error)
 
-	// GetDataRange retrieves a range of values
+	// GetDataRange retrieves a range of values. The response header carries the height of the world state the
+	// read was served at.
 	GetDataRange(dbName, querierUserID, startKey, endKey string, limit uint64) (*types.GetDataRangeResponseEnvelope, error)
 
 	// DataQuery executes a given JSON query and return key-value pairs which are matching
@@ -112,14 +141,30 @@ type DB interface {
 	// GetTxProof returns intermediate hashes to recalculate merkle tree root from tx hash
 	GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.GetTxProofResponseEnvelope, error)
 
+	// GetTxProofs returns intermediate hashes, each once, to recalculate merkle tree root from the hashes of several txs
+	GetTxProofs(userID string, blockNum uint64, txIndexes []uint64) (*types.GetTxProofsResponseEnvelope, error)
+
 	// GetDataProof returns hashes path from value to root in merkle-patricia trie
 	GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error)
 
+	// GetDataAbsenceProof returns the proof that the key was never written in the merkle-patricia trie of the block
+	GetDataAbsenceProof(userID string, blockNum uint64, dbname string, key string) (*types.GetDataProofResponseEnvelope, error)
+
+	// GetDataProofs returns the nodes of the merkle-patricia trie of the block which prove the state of several keys,
+	// whether the keys are written, deleted or were never written. It returns a BadRequestError if there are more keys
+	// than the configured limit
+	GetDataProofs(userID string, blockNum uint64, dbname string, keys []string) (*types.GetDataProofsResponseEnvelope, error)
+
 	// GetLedgerPath returns list of blocks that forms the shortest path in the skip list chain of the ledger.
 	// Parameter 'start' is the block number of the earlier block, 'end' is the block number of the last block. That is
 	// 'start'<='end'. The returned path is the shortest path from the 'end' block to the 'start' block.
 	GetLedgerPath(userID string, start, end uint64) (*types.GetLedgerPathResponseEnvelope, error)
 
+	// GetLedgerConsistencyProof returns the proof that the ledger at block 'end' extends the ledger at block 'start', i.e.,
+	// the headers of the blocks in the shortest path in the skip list chain between them, both excluded. That is
+	// 'start'<='end'.
+	GetLedgerConsistencyProof(userID string, start, end uint64) (*types.GetLedgerConsistencyProofResponseEnvelope, error)
+
 	// GetValues returns all values associated with a given key
 	GetValues(dbName, key string) (*types.GetHistoricalDataResponseEnvelope, error)
 
@@ -162,6 +207,11 @@ type DB interface {
 	// and transaction index inside the block
 	GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error)
 
+	// LinearizableRead performs a Raft ReadIndex round and waits until the local commit reaches the read index,
+	// so that the queries served after it returns reflect every block committed by the cluster before it was
+	// called. It returns a TimeoutErr if the read index cannot be reached before the context is done.
+	LinearizableRead(ctx context.Context) error
+
 	// SubmitTransaction submits transaction to the database with a timeout. If the timeout is
 	// set to 0, the submission would be treated as async while a non-zero timeout would be
 	// treated as a sync submission. When a timeout occurs with the sync submission, a
@@ -181,6 +231,10 @@ type TxProcessor interface {
 	ClusterStatus() (leader string, active []string)
 	IsLeader() *ierrors.NotLeaderError
 	SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponse, error)
+	TransferLeadership(targetNodeID string, timeout time.Duration) (*types.LeadershipTransfer, error)
+	LeadershipTransfer() *types.LeadershipTransfer
+	SetNetworkFaults(faults []*types.NetworkFault, seed int64) ([]*types.NetworkFault, error)
+	LinearizableRead(ctx context.Context) (uint64, error)
 }
 
 type db struct {
@@ -195,34 +249,59 @@ type db struct {
 	stateTrieStore           *mptrieStore.Store
 	signer                   crypto.Signer
 	logger                   *logger.SugarLogger
+	transferRequests         transferRequestLog
+}
+
+// openWorldStateDB opens the world state database of the given kind in the given directory
+func openWorldStateDB(name, dir string, logger *logger.SugarLogger) (worldstate.TransferableDB, error) {
+	switch name {
+	case "leveldb":
+		l, err := leveldb.Open(&leveldb.Config{DBRootDir: dir, Logger: logger})
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	case "pebble":
+		p, err := pebbledb.Open(&pebbledb.Config{DBRootDir: dir, Logger: logger})
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "memory":
+		m, err := memorydb.Open(&memorydb.Config{Logger: logger})
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	default:
+		return nil, errors.Errorf("unsupported state database: %s", name)
+	}
 }
 
 // NewDB creates a new database bcdb which handles both the queries and transactions.
 func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error) {
 	localConf := conf.LocalConfig
-	if localConf.Server.Database.Name != "leveldb" {
-		return nil, errors.New("only leveldb is supported as the state database")
-	}
-
 	ledgerDir := localConf.Server.Database.LedgerDirectory
-	if err := createLedgerDir(ledgerDir); err != nil {
+	inMemory := localConf.Server.Database.InMemory()
+	if inMemory {
+		logger.Warn("The ledger is kept in memory only, and is lost when the server stops")
+	} else if err := createLedgerDir(ledgerDir); err != nil {
 		return nil, err
 	}
 
-	levelDB, err := leveldb.Open(
-		&leveldb.Config{
-			DBRootDir: constructWorldStatePath(ledgerDir),
-			Logger:    logger,
-		},
-	)
+	worldStateDB, err := openWorldStateDB(localConf.Server.Database.Name, constructWorldStatePath(ledgerDir), logger)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error while creating the world state database")
 	}
 
 	blockStore, err := blockstore.Open(
 		&blockstore.Config{
-			StoreDir: constructBlockStorePath(ledgerDir),
-			Logger:   logger,
+			StoreDir:           constructBlockStorePath(ledgerDir),
+			InMemory:           inMemory,
+			Compression:        localConf.Server.Database.BlockStore.Compression,
+			ArchiveDir:         localConf.Server.Database.BlockStore.ArchiveDirectory,
+			ArchiveCompression: localConf.Server.Database.BlockStore.ArchiveCompression,
+			Logger:             logger,
 		},
 	)
 	if err != nil {
@@ -232,6 +311,7 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	provenanceStore, err := provenance.Open(
 		&provenance.Config{
 			StoreDir: constructProvenanceStorePath(ledgerDir),
+			InMemory: inMemory,
 			Logger:   logger,
 		},
 	)
@@ -242,14 +322,35 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	stateTrieStore, err := mptrieStore.Open(
 		&mptrieStore.Config{
 			StoreDir: constructStateTrieStorePath(ledgerDir),
+			InMemory: inMemory,
 			Logger:   logger,
 		},
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error while creating the state trie store")
 	}
+	if err := startStateTriePruning(&localConf.Server.Database.StateTrie, stateTrieStore, blockStore); err != nil {
+		return nil, err
+	}
 
-	querier := identity.NewQuerier(levelDB)
+	stateTransfer := statetransfer.New(
+		&statetransfer.Config{
+			StagingDir:      constructStateTransferPath(ledgerDir),
+			DB:              worldStateDB,
+			BlockStore:      blockStore,
+			ProvenanceStore: provenanceStore,
+			StateTrieStore:  stateTrieStore,
+			Logger:          logger,
+		},
+	)
+	// an in-memory ledger never has a staged state to recover
+	if !inMemory {
+		if err := stateTransfer.Recover(); err != nil {
+			return nil, errors.WithMessage(err, "error while recovering the state transfer")
+		}
+	}
+
+	querier := identity.NewQuerier(worldStateDB)
 
 	signer, err := crypto.NewSigner(&crypto.SignerOptions{KeyFilePath: localConf.Server.Identity.KeyPath})
 	if err != nil {
@@ -259,7 +360,7 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	worldstateQueryProcessor := newWorldstateQueryProcessor(
 		&worldstateQueryProcessorConfig{
 			nodeID:              localConf.Server.Identity.ID,
-			db:                  levelDB,
+			db:                  worldStateDB,
 			queryProcessingConf: &localConf.Server.QueryProcessing,
 			blockStore:          blockStore,
 			identityQuerier:     querier,
@@ -268,12 +369,13 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	)
 
 	ledgerQueryProcessorConfig := &ledgerQueryProcessorConfig{
-		db:              levelDB,
-		blockStore:      blockStore,
-		provenanceStore: provenanceStore,
-		trieStore:       stateTrieStore,
-		identityQuerier: querier,
-		logger:          logger,
+		db:                  worldStateDB,
+		queryProcessingConf: &localConf.Server.QueryProcessing,
+		blockStore:          blockStore,
+		provenanceStore:     provenanceStore,
+		trieStore:           stateTrieStore,
+		identityQuerier:     querier,
+		logger:              logger,
 	}
 	ledgerQueryProcessor := newLedgerQueryProcessor(ledgerQueryProcessorConfig)
 
@@ -287,10 +389,12 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	txProcessor, err := newTransactionProcessor(
 		&txProcessorConfig{
 			config:          conf,
-			db:              levelDB,
+			db:              worldStateDB,
 			blockStore:      blockStore,
 			provenanceStore: provenanceStore,
 			stateTrieStore:  stateTrieStore,
+			stateTransfer:   stateTransfer,
+			signer:          signer,
 			logger:          logger,
 		},
 	)
@@ -304,7 +408,7 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 		ledgerQueryProcessor:     ledgerQueryProcessor,
 		provenanceQueryProcessor: provenanceQueryProcessor,
 		txProcessor:              txProcessor,
-		db:                       levelDB,
+		db:                       worldStateDB,
 		blockStore:               blockStore,
 		provenanceStore:          provenanceStore,
 		stateTrieStore:           stateTrieStore,
@@ -313,6 +417,34 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 	}, nil
 }
 
+// startStateTriePruning starts the background pruning of the state trie store, if configured
+func startStateTriePruning(conf *config.StateTrieConf, stateTrieStore *mptrieStore.Store, blockStore *blockstore.Store) error {
+	if conf.RetainLastBlocks == 0 {
+		return nil
+	}
+
+	interval := conf.PruneInterval
+	if interval == 0 {
+		interval = defaultStateTriePruneInterval
+	}
+	policy := &mptrie.RetentionPolicy{
+		LastBlocks:         conf.RetainLastBlocks,
+		CheckpointInterval: conf.CheckpointInterval,
+	}
+	rootOf := func(blockNum uint64) ([]byte, error) {
+		header, err := blockStore.GetHeader(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		return header.GetStateMerkelTreeRootHash(), nil
+	}
+
+	if err := stateTrieStore.StartPruning(policy, interval, rootOf); err != nil {
+		return errors.WithMessage(err, "error while starting the pruning of the state trie store")
+	}
+	return nil
+}
+
 // LedgerHeight returns ledger height
 func (d *db) LedgerHeight() (uint64, error) {
 	return d.worldstateQueryProcessor.blockStore.Height()
@@ -448,6 +580,8 @@ func (d *db) GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnve
 		}
 	}
 
+	clusterStatusResponse.LeadershipTransfer = d.txProcessor.LeadershipTransfer()
+
 	if noCerts {
 		for i := 0; i < len(clusterStatusResponse.Nodes); i++ {
 			clusterStatusResponse.Nodes[i].Certificate = nil
@@ -466,6 +600,115 @@ func (d *db) GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnve
 	}, nil
 }
 
+// TransferLeadership hands over the leadership of the cluster to the consensus member with the given node ID.
+// Only admin users can transfer the leadership, and a stale or repeated request is rejected.
+func (d *db) TransferLeadership(userID, targetNodeID string, timestamp int64, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error) {
+	isAdmin, err := d.worldstateQueryProcessor.identityQuerier.HasAdministrationPrivilege(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + userID + "] has no permission to transfer the leadership of the cluster",
+		}
+	}
+
+	if err := d.transferRequests.accept(userID, timestamp, time.Now()); err != nil {
+		return nil, err
+	}
+
+	transfer, err := d.txProcessor.TransferLeadership(targetNodeID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	transferResponse := &types.TransferLeadershipResponse{
+		Header:             d.responseHeader(),
+		LeadershipTransfer: transfer,
+	}
+	sign, err := d.signature(transferResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TransferLeadershipResponseEnvelope{
+		Response:  transferResponse,
+		Signature: sign,
+	}, nil
+}
+
+// maxLeadershipTransferRequestSkew bounds the difference between the timestamp of a leadership transfer request and
+// the local clock. A request out of these bounds is rejected as stale.
+const maxLeadershipTransferRequestSkew = time.Minute
+
+// transferRequestLog holds the timestamp of the last leadership transfer request served for each user. As a request
+// is accepted only if it is newer than the last one of the user, and the timestamps are bounded by the local clock, a
+// signed request cannot be served twice.
+type transferRequestLog struct {
+	mutex          sync.Mutex
+	lastTimestamps map[string]int64
+}
+
+// accept records the timestamp of the request as the last one of the user, or returns an error if the request is
+// stale or not newer than the last request of the user
+func (l *transferRequestLog) accept(userID string, timestamp int64, now time.Time) error {
+	requestTime := time.Unix(0, timestamp)
+	if requestTime.Before(now.Add(-maxLeadershipTransferRequestSkew)) || requestTime.After(now.Add(maxLeadershipTransferRequestSkew)) {
+		return &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the leadership transfer request was created at [%s], which is more than %s away from the time of the node [%s]",
+				requestTime.UTC().Format(time.RFC3339Nano), maxLeadershipTransferRequestSkew, now.UTC().Format(time.RFC3339Nano)),
+		}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if last, ok := l.lastTimestamps[userID]; ok && timestamp <= last {
+		return &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the leadership transfer request of the user [%s] is not newer than the last request of the user, which was created at [%s]",
+				userID, time.Unix(0, last).UTC().Format(time.RFC3339Nano)),
+		}
+	}
+	if l.lastTimestamps == nil {
+		l.lastTimestamps = make(map[string]int64)
+	}
+	l.lastTimestamps[userID] = timestamp
+	return nil
+}
+
+// SetNetworkFaults replaces the network faults the node injects into the messages it sends to the other nodes.
+// Only admin users can set the network faults.
+func (d *db) SetNetworkFaults(userID string, faults []*types.NetworkFault, seed int64) (*types.SetNetworkFaultsResponseEnvelope, error) {
+	isAdmin, err := d.worldstateQueryProcessor.identityQuerier.HasAdministrationPrivilege(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + userID + "] has no permission to set network faults",
+		}
+	}
+
+	faults, err = d.txProcessor.SetNetworkFaults(faults, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	faultsResponse := &types.SetNetworkFaultsResponse{
+		Header: d.responseHeader(),
+		Faults: faults,
+	}
+	sign, err := d.signature(faultsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SetNetworkFaultsResponseEnvelope{
+		Response:  faultsResponse,
+		Signature: sign,
+	}, nil
+}
+
 // GetDBStatus returns database status
 func (d *db) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error) {
 	dbStatusResponse, err := d.worldstateQueryProcessor.getDBStatus(dbName)
@@ -528,12 +771,12 @@ func (d *db) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.Tx
 
 // GetData returns value for provided key
 func (d *db) GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error) {
-	dataResponse, err := d.worldstateQueryProcessor.getData(dbName, querierUserID, key)
+	dataResponse, height, err := d.worldstateQueryProcessor.getData(dbName, querierUserID, key)
 	if err != nil {
 		return nil, err
 	}
 
-	dataResponse.Header = d.responseHeader()
+	dataResponse.Header = d.responseHeaderAt(height)
 	sign, err := d.signature(dataResponse)
 	if err != nil {
 		return nil, err
@@ -547,12 +790,12 @@ func (d *db) GetData(dbName, querierUserID, key string) (*types.GetDataResponseE
 
 // GetDataRange returns a range of values starting from the start key and till before the end key
 func (d *db) GetDataRange(dbName, querierUserID, startKey, endKey string, limit uint64) (*types.GetDataRangeResponseEnvelope, error) {
-	dataResponse, err := d.worldstateQueryProcessor.getDataRange(dbName, querierUserID, startKey, endKey, limit)
+	dataResponse, height, err := d.worldstateQueryProcessor.getDataRange(dbName, querierUserID, startKey, endKey, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	dataResponse.Header = d.responseHeader()
+	dataResponse.Header = d.responseHeaderAt(height)
 	sign, err := d.signature(dataResponse)
 	if err != nil {
 		return nil, err
@@ -590,6 +833,17 @@ func (d *db) DataQuery(ctx context.Context, dbName, querierUserID string, query
 
 }
 
+// LinearizableRead performs a Raft ReadIndex round and waits until the local commit reaches the read index.
+func (d *db) LinearizableRead(ctx context.Context) error {
+	blockNum, err := d.txProcessor.LinearizableRead(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Debugf("linearizable read reached block [%d]", blockNum)
+	return nil
+}
+
 func (d *db) IsDBExists(name string) bool {
 	return d.worldstateQueryProcessor.isDBExists(name)
 }
@@ -648,6 +902,24 @@ func (d *db) GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.Ge
 	}, nil
 }
 
+func (d *db) GetTxProofs(userID string, blockNum uint64, txIndexes []uint64) (*types.GetTxProofsResponseEnvelope, error) {
+	proofsResponse, err := d.ledgerQueryProcessor.getTxProofs(userID, blockNum, txIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	proofsResponse.Header = d.responseHeader()
+	sign, err := d.signature(proofsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTxProofsResponseEnvelope{
+		Response:  proofsResponse,
+		Signature: sign,
+	}, nil
+}
+
 func (d *db) GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error) {
 	proofResponse, err := d.ledgerQueryProcessor.getDataProof(userID, blockNum, dbname, key, deleted)
 	if err != nil {
@@ -666,6 +938,42 @@ func (d *db) GetDataProof(userID string, blockNum uint64, dbname string, key str
 	}, nil
 }
 
+func (d *db) GetDataAbsenceProof(userID string, blockNum uint64, dbname string, key string) (*types.GetDataProofResponseEnvelope, error) {
+	proofResponse, err := d.ledgerQueryProcessor.getDataAbsenceProof(userID, blockNum, dbname, key)
+	if err != nil {
+		return nil, err
+	}
+
+	proofResponse.Header = d.responseHeader()
+	sign, err := d.signature(proofResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDataProofResponseEnvelope{
+		Response:  proofResponse,
+		Signature: sign,
+	}, nil
+}
+
+func (d *db) GetDataProofs(userID string, blockNum uint64, dbname string, keys []string) (*types.GetDataProofsResponseEnvelope, error) {
+	proofsResponse, err := d.ledgerQueryProcessor.getDataProofs(userID, blockNum, dbname, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	proofsResponse.Header = d.responseHeader()
+	sign, err := d.signature(proofsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDataProofsResponseEnvelope{
+		Response:  proofsResponse,
+		Signature: sign,
+	}, nil
+}
+
 func (d *db) GetLedgerPath(userID string, start, end uint64) (*types.GetLedgerPathResponseEnvelope, error) {
 	pathResponse, err := d.ledgerQueryProcessor.getPath(userID, start, end)
 	if err != nil {
@@ -684,6 +992,24 @@ func (d *db) GetLedgerPath(userID string, start, end uint64) (*types.GetLedgerPa
 	}, nil
 }
 
+func (d *db) GetLedgerConsistencyProof(userID string, start, end uint64) (*types.GetLedgerConsistencyProofResponseEnvelope, error) {
+	proofResponse, err := d.ledgerQueryProcessor.getConsistencyProof(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	proofResponse.Header = d.responseHeader()
+	sign, err := d.signature(proofResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetLedgerConsistencyProofResponseEnvelope{
+		Response:  proofResponse,
+		Signature: sign,
+	}, nil
+}
+
 func (d *db) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error) {
 	receiptResponse, err := d.ledgerQueryProcessor.getTxReceipt(userId, txID)
 	if err != nil {
@@ -964,6 +1290,14 @@ func (d *db) responseHeader() *types.ResponseHeader {
 	}
 }
 
+// responseHeaderAt returns the header of a response to a query served at the given height of the world state.
+func (d *db) responseHeaderAt(height uint64) *types.ResponseHeader {
+	return &types.ResponseHeader{
+		NodeId:      d.nodeID,
+		BlockHeight: height,
+	}
+}
+
 func (d *db) signature(response interface{}) ([]byte, error) {
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
@@ -975,7 +1309,7 @@ func (d *db) signature(response interface{}) ([]byte, error) {
 
 type certsInGenesisConfig struct {
 	nodeCertificates map[string][]byte
-	adminCert        []byte
+	admins           []*types.Admin
 	caCerts          *types.CAConfig
 }
 
@@ -993,13 +1327,19 @@ func readCerts(conf *config.Configurations) (*certsInGenesisConfig, error) {
 		certsInGen.nodeCertificates[node.NodeID] = nodePemCert.Bytes
 	}
 
-	adminCert, err := ioutil.ReadFile(conf.SharedConfig.Admin.CertificatePath)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error while reading admin certificate %s", conf.SharedConfig.Admin.CertificatePath)
+	for _, admin := range append([]config.AdminConf{conf.SharedConfig.Admin}, conf.SharedConfig.AdditionalAdmins...) {
+		adminCert, err := ioutil.ReadFile(admin.CertificatePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while reading admin certificate %s", admin.CertificatePath)
+		}
+		adminPemCert, _ := pem.Decode(adminCert)
+		certsInGen.admins = append(certsInGen.admins, &types.Admin{
+			Id:          admin.ID,
+			Certificate: adminPemCert.Bytes,
+		})
 	}
-	adminPemCert, _ := pem.Decode(adminCert)
-	certsInGen.adminCert = adminPemCert.Bytes
 
+	var err error
 	certsInGen.caCerts, err = certificateauthority.LoadCAConfig(&conf.SharedConfig.CAConfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while loading CA certificates from: %+v", conf.SharedConfig.CAConfig)
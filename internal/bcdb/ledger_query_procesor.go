@@ -6,6 +6,7 @@ package bcdb
 import (
 	"fmt"
 
+	"github.com/hyperledger-labs/orion-server/config"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
@@ -20,31 +21,34 @@ import (
 )
 
 type ledgerQueryProcessor struct {
-	db              worldstate.DB
-	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
-	trieStore       mptrie.Store
-	identityQuerier *identity.Querier
-	logger          *logger.SugarLogger
+	db                  worldstate.DB
+	queryProcessingConf *config.QueryProcessingConf
+	blockStore          *blockstore.Store
+	provenanceStore     *provenance.Store
+	trieStore           mptrie.Store
+	identityQuerier     *identity.Querier
+	logger              *logger.SugarLogger
 }
 
 type ledgerQueryProcessorConfig struct {
-	db              worldstate.DB
-	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
-	trieStore       mptrie.Store
-	identityQuerier *identity.Querier
-	logger          *logger.SugarLogger
+	db                  worldstate.DB
+	queryProcessingConf *config.QueryProcessingConf
+	blockStore          *blockstore.Store
+	provenanceStore     *provenance.Store
+	trieStore           mptrie.Store
+	identityQuerier     *identity.Querier
+	logger              *logger.SugarLogger
 }
 
 func newLedgerQueryProcessor(conf *ledgerQueryProcessorConfig) *ledgerQueryProcessor {
 	return &ledgerQueryProcessor{
-		db:              conf.db,
-		blockStore:      conf.blockStore,
-		provenanceStore: conf.provenanceStore,
-		trieStore:       conf.trieStore,
-		identityQuerier: conf.identityQuerier,
-		logger:          conf.logger,
+		db:                  conf.db,
+		queryProcessingConf: conf.queryProcessingConf,
+		blockStore:          conf.blockStore,
+		provenanceStore:     conf.provenanceStore,
+		trieStore:           conf.trieStore,
+		identityQuerier:     conf.identityQuerier,
+		logger:              conf.logger,
 	}
 }
 
@@ -62,8 +66,14 @@ func (p *ledgerQueryProcessor) getBlockHeader(userId string, blockNum uint64) (*
 		return nil, err
 	}
 
+	qc, err := p.getQuorumCertificate(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetBlockResponse{
-		BlockHeader: data,
+		BlockHeader:       data,
+		QuorumCertificate: qc,
 	}, nil
 }
 
@@ -81,11 +91,29 @@ func (p *ledgerQueryProcessor) getAugmentedBlockHeader(userId string, blockNum u
 		return nil, err
 	}
 
+	qc, err := p.getQuorumCertificate(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetAugmentedBlockHeaderResponse{
-		BlockHeader: data,
+		BlockHeader:       data,
+		QuorumCertificate: qc,
 	}, nil
 }
 
+// getQuorumCertificate returns the quorum certificate of the block, or nil if the block is not certified yet
+func (p *ledgerQueryProcessor) getQuorumCertificate(blockNum uint64) (*types.QuorumCertificate, error) {
+	qc, err := p.blockStore.GetQuorumCertificate(blockNum)
+	if err != nil {
+		if _, ok := err.(*interrors.NotFoundErr); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return qc, nil
+}
+
 func (p *ledgerQueryProcessor) getPath(userId string, startBlockIdx, endBlockIdx uint64) (*types.GetLedgerPathResponse, error) {
 	if startBlockIdx < 1 {
 		return nil, &interrors.BadRequestError{ErrMsg: "start block number must be >=1"}
@@ -124,6 +152,25 @@ func (p *ledgerQueryProcessor) getPath(userId string, startBlockIdx, endBlockIdx
 	}, nil
 }
 
+// getConsistencyProof returns the headers of the blocks in the skip list path from the end block down to the start
+// block, both excluded
+func (p *ledgerQueryProcessor) getConsistencyProof(userId string, startBlockIdx, endBlockIdx uint64) (*types.GetLedgerConsistencyProofResponse, error) {
+	pathResponse, err := p.getPath(userId, startBlockIdx, endBlockIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := pathResponse.GetBlockHeaders()
+	if len(headers) <= 2 {
+		headers = nil
+	} else {
+		headers = headers[1 : len(headers)-1]
+	}
+	return &types.GetLedgerConsistencyProofResponse{
+		BlockHeaders: headers,
+	}, nil
+}
+
 func (p *ledgerQueryProcessor) getTxProof(userId string, blockNum uint64, txIdx uint64) (*types.GetTxProofResponse, error) {
 	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
 	if err != nil {
@@ -147,7 +194,9 @@ func (p *ledgerQueryProcessor) getTxProof(userId string, blockNum uint64, txIdx
 	}, nil
 }
 
-func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbname string, key string, isDeleted bool) (*types.GetDataProofResponse, error) {
+// getTxProofs returns the intermediate hashes, each once, to recalculate the root of the merkle tree of the block
+// transactions from the hashes of the transactions with the given indexes
+func (p *ledgerQueryProcessor) getTxProofs(userId string, blockNum uint64, txIndexes []uint64) (*types.GetTxProofsResponse, error) {
 	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
 	if err != nil {
 		return nil, err
@@ -156,12 +205,30 @@ func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbna
 	if !hasAccess {
 		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
 	}
-	blockHeader, err := p.blockStore.GetHeader(blockNum)
+	block, err := p.blockStore.Get(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := mtree.BuildTreeForBlockTx(block)
+	if err != nil {
+		return nil, err
+	}
+	leafIndexes := make([]int, 0, len(txIndexes))
+	for _, txIdx := range txIndexes {
+		leafIndexes = append(leafIndexes, int(txIdx))
+	}
+	hashes, err := root.MultiProof(leafIndexes)
 	if err != nil {
 		return nil, err
 	}
+	return &types.GetTxProofsResponse{
+		Hashes: hashes,
+	}, nil
+}
 
-	trie, err := mptrie.NewTrie(blockHeader.StateMerkelTreeRootHash, p.trieStore)
+func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbname string, key string, isDeleted bool) (*types.GetDataProofResponse, error) {
+	trie, err := p.getStateTrie(userId, blockNum)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +253,85 @@ func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbna
 	return resp, nil
 }
 
+func (p *ledgerQueryProcessor) getDataAbsenceProof(userId string, blockNum uint64, dbname string, key string) (*types.GetDataProofResponse, error) {
+	trie, err := p.getStateTrie(userId, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	trieKey, err := state.ConstructCompositeKey(dbname, key)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := trie.GetAbsenceProof(trieKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if proof == nil {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("no absence proof for block %d, db %s, key %s found, the key was written", blockNum, dbname, key)}
+	}
+
+	return &types.GetDataProofResponse{
+		AbsenceProof: proof.ToProto(),
+	}, nil
+}
+
+// getDataProofs returns the proof of the state of the keys in the state trie of the block. As the keys of the state
+// trie are hashes, the proof can't show that no other key of the database was written. The number of keys is bounded
+// by the query processing configuration.
+func (p *ledgerQueryProcessor) getDataProofs(userId string, blockNum uint64, dbname string, keys []string) (*types.GetDataProofsResponse, error) {
+	if limit := p.queryProcessingConf.ProofKeysLimit(); uint64(len(keys)) > limit {
+		return nil, &interrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the query has [%d] keys but the proof of the state of at most [%d] keys can be requested at once", len(keys), limit),
+		}
+	}
+
+	trie, err := p.getStateTrie(userId, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	trieKeys := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		trieKey, err := state.ConstructCompositeKey(dbname, key)
+		if err != nil {
+			return nil, err
+		}
+		trieKeys = append(trieKeys, trieKey)
+	}
+
+	proof, err := trie.GetMultiProof(trieKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDataProofsResponse{
+		Proof: proof.ToProto(),
+	}, nil
+}
+
+// getStateTrie returns the state trie of the block, if the user has access to the ledger
+func (p *ledgerQueryProcessor) getStateTrie(userId string, blockNum uint64) (*mptrie.MPTrie, error) {
+	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasAccess {
+		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
+	}
+	blockHeader, err := p.blockStore.GetHeader(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if p.trieStore.IsPruned(blockNum) {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("the state trie of block %d is pruned, no proof can be provided", blockNum)}
+	}
+
+	return mptrie.NewTrie(blockHeader.StateMerkelTreeRootHash, p.trieStore)
+}
+
 func (p *ledgerQueryProcessor) getTxReceipt(userId string, txId string) (*types.TxReceiptResponse, error) {
 	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/state"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
 	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
@@ -120,12 +121,13 @@ func newLedgerProcessorTestEnv(t *testing.T) *ledgerProcessorTestEnv {
 	}
 
 	conf := &ledgerQueryProcessorConfig{
-		db:              db,
-		blockStore:      blockStore,
-		provenanceStore: provenanceStore,
-		trieStore:       trieStore,
-		identityQuerier: identity.NewQuerier(db),
-		logger:          logger,
+		db:                  db,
+		queryProcessingConf: &config.QueryProcessingConf{MaxProofKeys: 5},
+		blockStore:          blockStore,
+		provenanceStore:     provenanceStore,
+		trieStore:           trieStore,
+		identityQuerier:     identity.NewQuerier(db),
+		logger:              logger,
 	}
 
 	return &ledgerProcessorTestEnv{
@@ -365,7 +367,7 @@ func createDataUpdatesFromBlock(block *types.Block) map[string]*worldstate.DBUpd
 			TxNum:    uint64(txNum),
 		}
 
-		blockprocessor.AddDBEntriesForDataTx(tx.GetPayload(), version, dataUpdate)
+		blockprocessor.AddDBEntriesForDataTx(tx.GetPayload(), version, 0, dataUpdate)
 	}
 
 	return dataUpdate
@@ -472,6 +474,38 @@ func TestGetBlock(t *testing.T) {
 	}
 }
 
+func TestGetBlock_QuorumCertificate(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 20)
+
+	qc := &types.QuorumCertificate{
+		BlockNumber: 5,
+		HeaderHash:  []byte("hash-5"),
+		Attestations: []*types.BlockAttestation{
+			{BlockNumber: 5, NodeId: "node1", Signature: []byte("sig-1")},
+			{BlockNumber: 5, NodeId: "node2", Signature: []byte("sig-2")},
+		},
+	}
+	require.NoError(t, env.p.blockStore.CommitQuorumCertificate(qc))
+
+	payload, err := env.p.getBlockHeader("testUser", 5)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(qc, payload.GetQuorumCertificate()))
+	augmentedPayload, err := env.p.getAugmentedBlockHeader("testUser", 5)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(qc, augmentedPayload.GetQuorumCertificate()))
+
+	// not certified yet
+	payload, err = env.p.getBlockHeader("testUser", 6)
+	require.NoError(t, err)
+	require.NotNil(t, payload.GetBlockHeader())
+	require.Nil(t, payload.GetQuorumCertificate())
+	augmentedPayload, err = env.p.getAugmentedBlockHeader("testUser", 6)
+	require.NoError(t, err)
+	require.Nil(t, augmentedPayload.GetQuorumCertificate())
+}
+
 func TestGetPath(t *testing.T) {
 	env := newLedgerProcessorTestEnv(t)
 	defer env.cleanup(t)
@@ -596,6 +630,101 @@ func TestGetPath(t *testing.T) {
 	}
 }
 
+func TestGetConsistencyProof(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	testCases := []struct {
+		name              string
+		startNumber       uint64
+		endNumber         uint64
+		expectedPathBlock []uint64
+		user              string
+		expectedErr       error
+	}{
+		{
+			name:              "proof 90 6",
+			startNumber:       6,
+			endNumber:         90,
+			expectedPathBlock: []uint64{89, 81, 65, 33, 17, 9, 7},
+			user:              "testUser",
+		},
+		{
+			name:              "proof 17 2",
+			startNumber:       2,
+			endNumber:         17,
+			expectedPathBlock: []uint64{9, 5, 3},
+			user:              "testUser",
+		},
+		{
+			name:        "proof 17 1",
+			startNumber: 1,
+			endNumber:   17,
+			user:        "testUser",
+		},
+		{
+			name:        "proof 6 6",
+			startNumber: 6,
+			endNumber:   6,
+			user:        "testUser",
+		},
+		{
+			name:        "error: proof 17 2 wrong user",
+			startNumber: 2,
+			endNumber:   17,
+			user:        "userNotExist",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user userNotExist has no permission to access the ledger"},
+		},
+		{
+			name:        "error: proof 2 17 wrong direction",
+			startNumber: 17,
+			endNumber:   2,
+			user:        "testUser",
+			expectedErr: &interrors.BadRequestError{ErrMsg: "can't find path from start block 17 to end block 2, start must be <= end"},
+		},
+		{
+			name:        "error: proof 2 117 end block not in ledger",
+			startNumber: 2,
+			endNumber:   117,
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "can't find path in blocks skip list between 117 2: block not found: 117"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			payload, err := env.p.getConsistencyProof(testCase.user, testCase.startNumber, testCase.endNumber)
+			if testCase.expectedErr != nil {
+				require.EqualError(t, err, testCase.expectedErr.Error())
+				require.IsType(t, testCase.expectedErr, err)
+				require.Nil(t, payload)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, payload.GetBlockHeaders(), len(testCase.expectedPathBlock))
+			for i, blockNum := range testCase.expectedPathBlock {
+				require.True(t, proto.Equal(env.blocks[blockNum-1], payload.GetBlockHeaders()[i]))
+			}
+
+			startHeader := env.blocks[testCase.startNumber-1]
+			endHeader := env.blocks[testCase.endNumber-1]
+			proof := state.NewLedgerConsistencyProof(payload.GetBlockHeaders())
+			isValid, err := proof.Verify(startHeader, endHeader)
+			require.NoError(t, err)
+			require.True(t, isValid)
+
+			// the proof is not valid for a tampered start block
+			tamperedHeader := proto.Clone(startHeader).(*types.BlockHeader)
+			tamperedHeader.StateMerkelTreeRootHash = []byte("root")
+			isValid, err = proof.Verify(tamperedHeader, endHeader)
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+	}
+}
+
 func TestGetTxProof(t *testing.T) {
 	env := newLedgerProcessorTestEnv(t)
 	defer env.cleanup(t)
@@ -701,6 +830,86 @@ func TestGetTxProof(t *testing.T) {
 	}
 }
 
+func TestGetTxProofs(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	testCases := []struct {
+		name        string
+		blockNumber uint64
+		txIndexes   []uint64
+		user        string
+		expectedErr error
+	}{
+		{
+			name:        "Getting block 5, txs 0, 2, 3 - correct",
+			blockNumber: 5,
+			txIndexes:   []uint64{0, 2, 3},
+			user:        "testUser",
+		},
+		{
+			name:        "Getting block 98, txs 90, 5, 96 - correct",
+			blockNumber: 98,
+			txIndexes:   []uint64{90, 5, 96},
+			user:        "testUser",
+		},
+		{
+			name:        "Getting block 88, txs 3, 100 - tx not exist",
+			blockNumber: 88,
+			txIndexes:   []uint64{3, 100},
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "node with index 100 is not part of merkle tree (0, 87)"},
+		},
+		{
+			name:        "Getting block 515 - not exist",
+			blockNumber: 515,
+			txIndexes:   []uint64{0},
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "requested block number [515] cannot be greater than the last committed block number [99]"},
+		},
+		{
+			name:        "Getting block 40 - wrong user",
+			blockNumber: 40,
+			txIndexes:   []uint64{0},
+			user:        "userNotExist",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user userNotExist has no permission to access the ledger"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			payload, err := env.p.getTxProofs(testCase.user, testCase.blockNumber, testCase.txIndexes)
+			if testCase.expectedErr != nil {
+				require.EqualError(t, err, testCase.expectedErr.Error())
+				require.IsType(t, testCase.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			header := env.blocks[testCase.blockNumber-1]
+			txHashes := make(map[uint64][]byte)
+			for _, txIndex := range testCase.txIndexes {
+				txBytes, err := json.Marshal(env.blockTx[testCase.blockNumber-1].Envelopes[txIndex])
+				require.NoError(t, err)
+				valInfoBytes, err := json.Marshal(header.ValidationInfo[txIndex])
+				require.NoError(t, err)
+				txHashes[txIndex], err = crypto.ComputeSHA256Hash(append(txBytes, valInfoBytes...))
+				require.NoError(t, err)
+			}
+
+			proof := state.NewTxMultiProof(payload.GetHashes())
+			isValid, err := proof.Verify(txHashes, uint64(len(header.ValidationInfo)), header.TxMerkelTreeRootHash)
+			require.NoError(t, err)
+			require.True(t, isValid)
+
+			isValid, err = proof.Verify(txHashes, uint64(len(header.ValidationInfo)), env.blocks[0].TxMerkelTreeRootHash)
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+	}
+}
+
 func TestGetDataProof(t *testing.T) {
 	env := newLedgerProcessorTestEnv(t)
 	defer env.cleanup(t)
@@ -804,6 +1013,193 @@ func TestGetDataProof(t *testing.T) {
 	}
 }
 
+func TestGetDataAbsenceProof(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	testCases := []struct {
+		name        string
+		blockNumber uint64
+		key         string
+		user        string
+		expectedErr error
+	}{
+		{
+			name:        "key never written",
+			blockNumber: 95,
+			key:         "keyyyy13",
+			user:        "testUser",
+		},
+		{
+			name:        "key written in a later block",
+			blockNumber: 5,
+			key:         "key13",
+			user:        "testUser",
+		},
+		{
+			name:        "key written",
+			blockNumber: 95,
+			key:         "key13",
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "no absence proof for block 95, db bdb, key key13 found, the key was written"},
+		},
+		{
+			name:        "get proof from block 515 - not exist",
+			blockNumber: 515,
+			key:         "key13",
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "block not found: 515"},
+		},
+		{
+			name:        "get proof from block 40 - wrong user",
+			blockNumber: 40,
+			key:         "key13",
+			user:        "userNotExist",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user userNotExist has no permission to access the ledger"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := env.p.getDataAbsenceProof(testCase.user, testCase.blockNumber, worldstate.DefaultDBName, testCase.key)
+			if testCase.expectedErr != nil {
+				require.EqualError(t, err, testCase.expectedErr.Error())
+				require.IsType(t, testCase.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Nil(t, resp.GetPath())
+			trieKey, err := state.ConstructCompositeKey(worldstate.DefaultDBName, testCase.key)
+			require.NoError(t, err)
+			proof := state.NewAbsenceProofFromProto(resp.GetAbsenceProof())
+			isValid, err := proof.Verify(trieKey, env.blocks[testCase.blockNumber-1].StateMerkelTreeRootHash)
+			require.NoError(t, err)
+			require.True(t, isValid)
+
+			// the block in which the key is written has another root
+			isValid, err = proof.Verify(trieKey, env.blocks[98].StateMerkelTreeRootHash)
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+	}
+}
+
+func TestGetDataProofs(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	testCases := []struct {
+		name        string
+		blockNumber uint64
+		keys        []string
+		absentKeys  []string
+		user        string
+		expectedErr error
+	}{
+		{
+			name:        "written keys",
+			blockNumber: 95,
+			keys:        []string{"key3", "key13", "key94"},
+			user:        "testUser",
+		},
+		{
+			name:        "written and absent keys",
+			blockNumber: 5,
+			keys:        []string{"key0", "key4"},
+			absentKeys:  []string{"key5", "key13", "keyyyy13"},
+			user:        "testUser",
+		},
+		{
+			name:        "get proof from block 515 - not exist",
+			blockNumber: 515,
+			keys:        []string{"key13"},
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "block not found: 515"},
+		},
+		{
+			name:        "get proof from block 40 - wrong user",
+			blockNumber: 40,
+			keys:        []string{"key13"},
+			user:        "userNotExist",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user userNotExist has no permission to access the ledger"},
+		},
+		{
+			name:        "too many keys",
+			blockNumber: 5,
+			keys:        []string{"key0", "key1", "key2", "key3"},
+			absentKeys:  []string{"key5", "key13"},
+			user:        "testUser",
+			expectedErr: &interrors.BadRequestError{ErrMsg: "the query has [6] keys but the proof of the state of at most [5] keys can be requested at once"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := env.p.getDataProofs(testCase.user, testCase.blockNumber, worldstate.DefaultDBName, append(testCase.keys, testCase.absentKeys...))
+			if testCase.expectedErr != nil {
+				require.EqualError(t, err, testCase.expectedErr.Error())
+				require.IsType(t, testCase.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			proof := state.NewMultiProofFromProto(resp.GetProof())
+			rootHash := env.blocks[testCase.blockNumber-1].StateMerkelTreeRootHash
+			for _, key := range testCase.keys {
+				trieKey, err := state.ConstructCompositeKey(worldstate.DefaultDBName, key)
+				require.NoError(t, err)
+				kvHash, err := state.CalculateKeyValueHash(trieKey, []byte(fmt.Sprintf("value_%s_%d", key[3:], testCase.blockNumber)))
+				require.NoError(t, err)
+				isValid, err := proof.Verify(trieKey, kvHash, rootHash, false)
+				require.NoError(t, err)
+				require.True(t, isValid, key)
+			}
+			for _, key := range testCase.absentKeys {
+				trieKey, err := state.ConstructCompositeKey(worldstate.DefaultDBName, key)
+				require.NoError(t, err)
+				isValid, err := proof.VerifyAbsence(trieKey, rootHash)
+				require.NoError(t, err)
+				require.True(t, isValid, key)
+			}
+		})
+	}
+}
+
+func TestGetDataProof_PrunedStateTrie(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	rootOf := func(blockNum uint64) ([]byte, error) {
+		header, err := env.p.blockStore.GetHeader(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		return header.GetStateMerkelTreeRootHash(), nil
+	}
+	require.NoError(t, env.p.trieStore.Prune(&mptrie.RetentionPolicy{LastBlocks: 10, CheckpointInterval: 20}, rootOf))
+
+	for _, blockNumber := range []uint64{40, 90, 99} {
+		proof, err := env.p.getDataProof("testUser", blockNumber, worldstate.DefaultDBName, "key13", false)
+		require.NoError(t, err)
+		trieKey, err := state.ConstructCompositeKey(worldstate.DefaultDBName, "key13")
+		require.NoError(t, err)
+		kvHash, err := state.CalculateKeyValueHash(trieKey, []byte(fmt.Sprintf("value_%d_%d", 13, blockNumber)))
+		require.NoError(t, err)
+		isValid, err := state.NewProof(proof.Path).Verify(kvHash, env.blocks[blockNumber-1].StateMerkelTreeRootHash, false)
+		require.NoError(t, err)
+		require.True(t, isValid)
+	}
+
+	proof, err := env.p.getDataProof("testUser", 89, worldstate.DefaultDBName, "key13", false)
+	require.EqualError(t, err, "the state trie of block 89 is pruned, no proof can be provided")
+	require.IsType(t, &interrors.NotFoundErr{}, err)
+	require.Nil(t, proof)
+}
+
 func TestGetTxReceipt(t *testing.T) {
 	env := newLedgerProcessorTestEnv(t)
 	defer env.cleanup(t)
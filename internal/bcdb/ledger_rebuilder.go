@@ -0,0 +1,286 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+// The stores that can be rebuilt from the block store by RebuildLedger
+const (
+	WorldStateStore = "worldstate"
+	ProvenanceStore = "provenance"
+	StateTrieStore  = "trie"
+	BlockIndexStore = "index"
+)
+
+// RebuildableStores lists the stores that can be rebuilt from the block store
+var RebuildableStores = []string{WorldStateStore, ProvenanceStore, StateTrieStore, BlockIndexStore}
+
+const (
+	// rebuildWorldStateDir holds the scratch world state that a replay of the blocks needs when the
+	// world state itself is not rebuilt
+	rebuildWorldStateDir = "rebuild-worldstate"
+	// rebuildStateTrieDir holds the scratch state trie against which the replayed blocks are checked
+	// when the state trie itself is not rebuilt
+	rebuildStateTrieDir = "rebuild-statetrie"
+
+	// a store is rebuilt in the directory of the live store with the rebuildSuffix, and the live store
+	// is kept in the directory with the backupSuffix until the rebuilt store has taken its place
+	rebuildSuffix = ".rebuild"
+	backupSuffix  = ".backup"
+)
+
+// RebuildLedger regenerates the given derived stores of the ledger directory from the blocks in the
+// block store, while the server is stopped:
+//   - BlockIndexStore: the block index, headers, and transaction validation info of the block store are
+//     rebuilt from the block file chunks.
+//   - WorldStateStore, ProvenanceStore, StateTrieStore: all the blocks are replayed through the committer
+//     into new stores, next to the live ones. The state trie root after each block, and the persisted
+//     state trie of the last block, are checked against the block headers. As the entries of a block are
+//     constructed from the state left by the preceding blocks, a scratch world state and a scratch state
+//     trie are replayed when they are not rebuilt. Only once the replay has succeeded, the live stores are
+//     replaced by the new ones; otherwise, the live stores are left untouched.
+//
+// It returns the number of the last block replayed.
+func RebuildLedger(dbConf *config.DatabaseConf, stores []string, logger *logger.SugarLogger) (uint64, error) {
+	if dbConf.InMemory() {
+		return 0, errors.New("an in-memory ledger cannot be rebuilt")
+	}
+
+	rebuild := make(map[string]bool)
+	for _, s := range stores {
+		switch s {
+		case WorldStateStore, ProvenanceStore, StateTrieStore, BlockIndexStore:
+			rebuild[s] = true
+		default:
+			return 0, errors.Errorf("unknown store [%s], the stores that can be rebuilt are: %s", s, strings.Join(RebuildableStores, ","))
+		}
+	}
+	if len(rebuild) == 0 {
+		return 0, errors.New("no store to rebuild")
+	}
+
+	ledgerDir := dbConf.LedgerDirectory
+	blockStoreConf := &blockstore.Config{
+		StoreDir:           constructBlockStorePath(ledgerDir),
+		Compression:        dbConf.BlockStore.Compression,
+		ArchiveDir:         dbConf.BlockStore.ArchiveDirectory,
+		ArchiveCompression: dbConf.BlockStore.ArchiveCompression,
+		Logger:             logger,
+	}
+	exist, err := fileops.Exists(blockStoreConf.StoreDir)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, errors.Errorf("the ledger directory [%s] does not hold a block store at [%s]", ledgerDir, blockStoreConf.StoreDir)
+	}
+
+	if rebuild[BlockIndexStore] {
+		height, err := blockstore.RebuildIndex(blockStoreConf)
+		if err != nil {
+			return 0, errors.WithMessage(err, "error while rebuilding the block store index")
+		}
+		logger.Infof("rebuilt the block store index up to block [%d]", height)
+	}
+
+	blockStore, err := blockstore.Open(blockStoreConf)
+	if err != nil {
+		return 0, errors.WithMessage(err, "error while opening the block store")
+	}
+	defer blockStore.Close()
+
+	height, err := blockStore.Height()
+	if err != nil {
+		return 0, err
+	}
+	if !rebuild[WorldStateStore] && !rebuild[ProvenanceStore] && !rebuild[StateTrieStore] {
+		return height, nil
+	}
+
+	livePaths := map[string]string{
+		WorldStateStore: constructWorldStatePath(ledgerDir),
+		ProvenanceStore: constructProvenanceStorePath(ledgerDir),
+		StateTrieStore:  constructStateTrieStorePath(ledgerDir),
+	}
+	for _, livePath := range livePaths {
+		if err := recoverStoreSwap(livePath, logger); err != nil {
+			return 0, err
+		}
+	}
+
+	replayPaths := map[string]string{
+		WorldStateStore: filepath.Join(ledgerDir, rebuildWorldStateDir),
+		StateTrieStore:  filepath.Join(ledgerDir, rebuildStateTrieDir),
+	}
+	var swaps []string
+	for _, s := range []string{WorldStateStore, ProvenanceStore, StateTrieStore} {
+		if rebuild[s] {
+			replayPaths[s] = livePaths[s] + rebuildSuffix
+			swaps = append(swaps, livePaths[s])
+		}
+	}
+	defer func() {
+		for _, path := range replayPaths {
+			if err := fileops.RemoveAll(path); err != nil {
+				logger.Errorf("error while removing the directory [%s] of a replay: %s", path, err)
+			}
+		}
+	}()
+
+	dbName := dbConf.Name
+	if dbName == "" {
+		dbName = "leveldb"
+	}
+	logger.Infof("replaying [%d] blocks", height)
+	if err := replayStores(blockStore, height, dbName, replayPaths, logger); err != nil {
+		return 0, err
+	}
+
+	for _, livePath := range swaps {
+		if err := swapStore(livePath); err != nil {
+			return 0, err
+		}
+	}
+	for _, livePath := range swaps {
+		if err := fileops.RemoveAll(livePath + backupSuffix); err != nil {
+			return 0, err
+		}
+	}
+
+	return height, nil
+}
+
+// replayStores replays all the blocks of the block store into new stores created at the given paths,
+// and checks the persisted state trie against the header of the last block. All the stores are closed
+// on return.
+func replayStores(blockStore *blockstore.Store, height uint64, dbName string, paths map[string]string, logger *logger.SugarLogger) error {
+	for _, path := range paths {
+		if err := fileops.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+
+	db, err := openWorldStateDB(dbName, paths[WorldStateStore], logger)
+	if err != nil {
+		return errors.WithMessage(err, "error while creating the world state database")
+	}
+	defer db.Close()
+
+	stateTrieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: paths[StateTrieStore], Logger: logger})
+	if err != nil {
+		return errors.WithMessage(err, "error while creating the state trie store")
+	}
+	defer stateTrieStore.Close()
+
+	replayConf := &blockprocessor.ReplayConfig{
+		BlockStore:     blockStore,
+		DB:             db,
+		StateTrieStore: stateTrieStore,
+		Logger:         logger,
+	}
+
+	if path, ok := paths[ProvenanceStore]; ok {
+		provenanceStore, err := provenance.Open(&provenance.Config{StoreDir: path, Logger: logger})
+		if err != nil {
+			return errors.WithMessage(err, "error while creating the provenance store")
+		}
+		defer provenanceStore.Close()
+		replayConf.ProvenanceStore = provenanceStore
+	}
+
+	if err := blockprocessor.Replay(replayConf); err != nil {
+		return errors.WithMessage(err, "error while replaying the blocks")
+	}
+	if height == 0 {
+		return nil
+	}
+
+	dbHeight, err := db.Height()
+	if err != nil {
+		return err
+	}
+	trieHeight, err := stateTrieStore.Height()
+	if err != nil {
+		return err
+	}
+	if dbHeight != height || trieHeight != height {
+		return errors.Errorf("the replay stopped at block [%d] of the world state and block [%d] of the state trie while the block store height is [%d]", dbHeight, trieHeight, height)
+	}
+
+	header, err := blockStore.GetHeader(height)
+	if err != nil {
+		return err
+	}
+	trie, err := mptrie.NewTrie(header.GetStateMerkelTreeRootHash(), stateTrieStore)
+	if err != nil {
+		return errors.WithMessagef(err, "error while loading the replayed state trie of block [%d]", height)
+	}
+	rootHash, err := trie.Hash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rootHash, header.GetStateMerkelTreeRootHash()) {
+		return errors.Errorf("the replayed state trie root hash does not match the state merkle tree root hash in the header of the last block [%d]", height)
+	}
+	if err := trie.Verify(nil); err != nil {
+		return errors.WithMessagef(err, "the replayed state trie of block [%d] is not valid", height)
+	}
+
+	return nil
+}
+
+// swapStore moves the live store to its backup directory, and the rebuilt store in its place
+func swapStore(livePath string) error {
+	exist, err := fileops.Exists(livePath)
+	if err != nil {
+		return err
+	}
+	if exist {
+		if err := os.Rename(livePath, livePath+backupSuffix); err != nil {
+			return errors.Wrapf(err, "error while moving the store [%s] to its backup directory", livePath)
+		}
+	}
+	if err := os.Rename(livePath+rebuildSuffix, livePath); err != nil {
+		return errors.Wrapf(err, "error while moving the rebuilt store in place of [%s]", livePath)
+	}
+	return fileops.SyncDir(filepath.Dir(livePath))
+}
+
+// recoverStoreSwap restores the backup of a live store that a previous rebuild moved away, but
+// crashed before moving the rebuilt store in its place, and removes the leftovers of that rebuild
+func recoverStoreSwap(livePath string, logger *logger.SugarLogger) error {
+	exist, err := fileops.Exists(livePath)
+	if err != nil {
+		return err
+	}
+	backupExist, err := fileops.Exists(livePath + backupSuffix)
+	if err != nil {
+		return err
+	}
+	if !exist && backupExist {
+		logger.Warnf("restoring the store [%s] from the backup of an interrupted rebuild", livePath)
+		if err := os.Rename(livePath+backupSuffix, livePath); err != nil {
+			return errors.Wrapf(err, "error while restoring the store [%s] from its backup directory", livePath)
+		}
+	}
+
+	if err := fileops.RemoveAll(livePath + backupSuffix); err != nil {
+		return err
+	}
+	return fileops.RemoveAll(livePath + rebuildSuffix)
+}
@@ -0,0 +1,223 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type ledgerContent struct {
+	height     uint64
+	values     map[string]*types.ValueWithMetadata
+	provenance map[string][]*types.ValueWithMetadata
+}
+
+func readLedgerContent(t *testing.T, ledgerDir string, lg *logger.SugarLogger) *ledgerContent {
+	content := &ledgerContent{
+		values:     make(map[string]*types.ValueWithMetadata),
+		provenance: make(map[string][]*types.ValueWithMetadata),
+	}
+
+	db, err := openWorldStateDB("leveldb", constructWorldStatePath(ledgerDir), lg)
+	require.NoError(t, err)
+	defer db.Close()
+	content.height, err = db.Height()
+	require.NoError(t, err)
+
+	provenanceStore, err := provenance.Open(&provenance.Config{StoreDir: constructProvenanceStorePath(ledgerDir), Logger: lg})
+	require.NoError(t, err)
+	defer provenanceStore.Close()
+
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("key%d", i)
+		value, metadata, err := db.Get(worldstate.DefaultDBName, key)
+		require.NoError(t, err)
+		content.values[key] = &types.ValueWithMetadata{Value: value, Metadata: metadata}
+
+		content.provenance[key], err = provenanceStore.GetValues(worldstate.DefaultDBName, key)
+		require.NoError(t, err)
+	}
+
+	return content
+}
+
+func requireSameLedgerContent(t *testing.T, expected, actual *ledgerContent) {
+	require.Equal(t, expected.height, actual.height)
+	for key, value := range expected.values {
+		require.True(t, proto.Equal(value, actual.values[key]), "key %s", key)
+	}
+	for key, values := range expected.provenance {
+		require.Len(t, actual.provenance[key], len(values), "key %s", key)
+		for i := range values {
+			require.True(t, proto.Equal(values[i], actual.provenance[key][i]), "key %s", key)
+		}
+	}
+}
+
+func requireNoRebuildLeftovers(t *testing.T, ledgerDir string) {
+	require.NoDirExists(t, filepath.Join(ledgerDir, rebuildWorldStateDir))
+	require.NoDirExists(t, filepath.Join(ledgerDir, rebuildStateTrieDir))
+	for _, path := range []string{constructWorldStatePath(ledgerDir), constructProvenanceStorePath(ledgerDir), constructStateTrieStorePath(ledgerDir)} {
+		require.DirExists(t, path)
+		require.NoDirExists(t, path+rebuildSuffix)
+		require.NoDirExists(t, path+backupSuffix)
+	}
+}
+
+func TestRebuildLedger(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	storeSets := [][]string{
+		RebuildableStores,
+		{WorldStateStore},
+		{ProvenanceStore},
+		{StateTrieStore},
+		{BlockIndexStore},
+		{ProvenanceStore, StateTrieStore},
+	}
+	for _, stores := range storeSets {
+		t.Run(fmt.Sprintf("rebuild %v", stores), func(t *testing.T) {
+			ledgerDir := createTestLedger(t, 10)
+			defer os.RemoveAll(ledgerDir)
+			expected := readLedgerContent(t, ledgerDir, lg)
+
+			dbConf := &config.DatabaseConf{Name: "leveldb", LedgerDirectory: ledgerDir}
+			height, err := RebuildLedger(dbConf, stores, lg)
+			require.NoError(t, err)
+			require.Equal(t, uint64(11), height)
+			requireNoRebuildLeftovers(t, ledgerDir)
+
+			requireSameLedgerContent(t, expected, readLedgerContent(t, ledgerDir, lg))
+			res, err := VerifyLedger(dbConf, lg)
+			require.NoError(t, err)
+			require.Equal(t, &LedgerVerification{BlockHeight: 11, StateTrieHeight: 11}, res)
+		})
+	}
+
+	t.Run("rebuild a corrupted state trie", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		// removes all the trie nodes but the root of the last block
+		blockStore, err := blockstore.Open(&blockstore.Config{StoreDir: constructBlockStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		header, err := blockStore.GetHeader(11)
+		require.NoError(t, err)
+		require.NoError(t, blockStore.Close())
+
+		trieDB, err := leveldb.OpenFile(filepath.Join(constructStateTrieStorePath(ledgerDir), "triedata"), nil)
+		require.NoError(t, err)
+		rootKey := append([]byte{0}, []byte(base64.StdEncoding.EncodeToString(header.GetStateMerkelTreeRootHash()))...)
+		it := trieDB.NewIterator(nil, nil)
+		for it.Next() {
+			if it.Key()[0] == 0 && string(it.Key()) != string(rootKey) {
+				require.NoError(t, trieDB.Delete(it.Key(), nil))
+			}
+		}
+		it.Release()
+		require.NoError(t, trieDB.Close())
+
+		dbConf := &config.DatabaseConf{Name: "leveldb", LedgerDirectory: ledgerDir}
+		_, err = VerifyLedger(dbConf, lg)
+		require.Error(t, err)
+
+		_, err = RebuildLedger(dbConf, []string{StateTrieStore}, lg)
+		require.NoError(t, err)
+		res, err := VerifyLedger(dbConf, lg)
+		require.NoError(t, err)
+		require.Equal(t, &LedgerVerification{BlockHeight: 11, StateTrieHeight: 11}, res)
+
+		trieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: constructStateTrieStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		defer trieStore.Close()
+		trie, err := mptrie.NewTrie(header.GetStateMerkelTreeRootHash(), trieStore)
+		require.NoError(t, err)
+		require.NoError(t, trie.Verify(nil))
+	})
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		rewriteBlockStore(t, ledgerDir, lg, func(block *types.Block) {
+			if block.GetHeader().GetBaseHeader().GetNumber() == 4 {
+				block.Header.StateMerkelTreeRootHash = []byte("root")
+			}
+		})
+
+		for _, stores := range [][]string{{StateTrieStore}, {WorldStateStore, ProvenanceStore}, RebuildableStores} {
+			expected := readLedgerContent(t, ledgerDir, lg)
+
+			height, err := RebuildLedger(&config.DatabaseConf{Name: "leveldb", LedgerDirectory: ledgerDir}, stores, lg)
+			require.EqualError(t, err, "error while replaying the blocks: the state trie root hash after replaying block 4 does not match the state merkle tree root hash in its header")
+			require.Equal(t, uint64(0), height)
+			requireNoRebuildLeftovers(t, ledgerDir)
+
+			// the live stores are left untouched
+			requireSameLedgerContent(t, expected, readLedgerContent(t, ledgerDir, lg))
+			trieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: constructStateTrieStorePath(ledgerDir), Logger: lg})
+			require.NoError(t, err)
+			trieHeight, err := trieStore.Height()
+			require.NoError(t, err)
+			require.Equal(t, uint64(11), trieHeight)
+			require.NoError(t, trieStore.Close())
+		}
+	})
+
+	t.Run("interrupted swap", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+		expected := readLedgerContent(t, ledgerDir, lg)
+
+		// a previous rebuild moved the live world state away and crashed before moving the
+		// rebuilt world state in its place
+		worldStatePath := constructWorldStatePath(ledgerDir)
+		require.NoError(t, os.Rename(worldStatePath, worldStatePath+backupSuffix))
+		require.NoError(t, os.MkdirAll(worldStatePath+rebuildSuffix, 0755))
+
+		dbConf := &config.DatabaseConf{Name: "leveldb", LedgerDirectory: ledgerDir}
+		_, err := RebuildLedger(dbConf, []string{ProvenanceStore}, lg)
+		require.NoError(t, err)
+		requireNoRebuildLeftovers(t, ledgerDir)
+		requireSameLedgerContent(t, expected, readLedgerContent(t, ledgerDir, lg))
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		ledgerDir := filepath.Join(os.TempDir(), "no-ledger")
+
+		_, err := RebuildLedger(&config.DatabaseConf{Name: "memory"}, []string{WorldStateStore}, lg)
+		require.EqualError(t, err, "an in-memory ledger cannot be rebuilt")
+
+		_, err = RebuildLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, []string{"blocks"}, lg)
+		require.EqualError(t, err, "unknown store [blocks], the stores that can be rebuilt are: worldstate,provenance,trie,index")
+
+		_, err = RebuildLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, nil, lg)
+		require.EqualError(t, err, "no store to rebuild")
+
+		_, err = RebuildLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, []string{WorldStateStore}, lg)
+		require.EqualError(t, err, fmt.Sprintf("the ledger directory [%s] does not hold a block store at [%s]", ledgerDir, filepath.Join(ledgerDir, "blockstore")))
+		require.NoDirExists(t, ledgerDir)
+	})
+}
@@ -0,0 +1,282 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// verificationProgressInterval is the number of blocks between two progress logs of the verification
+const verificationProgressInterval = 10000
+
+// LedgerInconsistencyError is returned by VerifyLedger when the ledger is found to be inconsistent.
+// It holds the first inconsistency found while walking the chain from the genesis block.
+type LedgerInconsistencyError struct {
+	BlockNumber uint64
+	Reason      string
+}
+
+func (e *LedgerInconsistencyError) Error() string {
+	return fmt.Sprintf("ledger inconsistency at block [%d]: %s", e.BlockNumber, e.Reason)
+}
+
+// LedgerVerification holds the result of a successful ledger verification
+type LedgerVerification struct {
+	// BlockHeight is the number of the last block in the block store
+	BlockHeight uint64
+	// StateTrieHeight is the number of the last block applied on the state trie store. It might be
+	// behind the block height if the node crashed during a commit, in which case the state trie of
+	// that block is walked instead of the one of the last block. The node catches up on the next start.
+	StateTrieHeight uint64
+}
+
+// VerifyLedger opens the block store and the state trie store of the ledger directory offline, i.e.,
+// while the server is stopped, and walks the whole chain from the genesis block. Both stores are
+// opened read-only: they are neither recovered, cleaned up, nor archived, and only the indexed blocks
+// are walked. For each block, it recomputes the block hash and checks it against the stored hash,
+// checks the link to the previous block by the previous base header hash and the skip list, rebuilds
+// the tx merkle tree root, and replays the writes of the block on a scratch world state and state
+// trie, in a temporary directory, to recompute the state trie root and check it against the header.
+// Finally, the complete state trie of the last block applied on the state trie store is walked. The
+// first inconsistency found is returned as a *LedgerInconsistencyError.
+func VerifyLedger(dbConf *config.DatabaseConf, logger *logger.SugarLogger) (*LedgerVerification, error) {
+	ledgerDir := dbConf.LedgerDirectory
+	blockStorePath := constructBlockStorePath(ledgerDir)
+	stateTrieStorePath := constructStateTrieStorePath(ledgerDir)
+
+	for _, dir := range []string{blockStorePath, stateTrieStorePath} {
+		exist, err := fileops.Exists(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.Errorf("the ledger directory [%s] does not hold a store at [%s]", ledgerDir, dir)
+		}
+	}
+
+	blockStore, err := blockstore.Open(
+		&blockstore.Config{
+			StoreDir:           blockStorePath,
+			Compression:        dbConf.BlockStore.Compression,
+			ArchiveDir:         dbConf.BlockStore.ArchiveDirectory,
+			ArchiveCompression: dbConf.BlockStore.ArchiveCompression,
+			ReadOnly:           true,
+			Logger:             logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the block store")
+	}
+	defer blockStore.Close()
+
+	trieStore, err := mptrieStore.Open(
+		&mptrieStore.Config{
+			StoreDir: stateTrieStorePath,
+			ReadOnly: true,
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the state trie store")
+	}
+	defer trieStore.Close()
+
+	scratchDir, err := ioutil.TempDir("", "ledger-verification")
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating the scratch directory of the verification")
+	}
+	defer os.RemoveAll(scratchDir)
+
+	scratchDB, err := openWorldStateDB("leveldb", filepath.Join(scratchDir, "worldstate"), logger)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the scratch world state")
+	}
+	defer scratchDB.Close()
+
+	scratchTrieStore, err := mptrieStore.Open(
+		&mptrieStore.Config{
+			StoreDir: filepath.Join(scratchDir, "statetrie"),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the scratch state trie store")
+	}
+	defer scratchTrieStore.Close()
+
+	replayer, err := blockprocessor.NewReplayer(
+		&blockprocessor.ReplayConfig{
+			BlockStore:     blockStore,
+			DB:             scratchDB,
+			StateTrieStore: scratchTrieStore,
+			Logger:         logger,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &ledgerVerifier{
+		blockStore: blockStore,
+		trieStore:  trieStore,
+		replayer:   replayer,
+		logger:     logger,
+	}
+	return v.verify()
+}
+
+type ledgerVerifier struct {
+	blockStore *blockstore.Store
+	trieStore  *mptrieStore.Store
+	replayer   *blockprocessor.Replayer
+	logger     *logger.SugarLogger
+}
+
+func (v *ledgerVerifier) verify() (*LedgerVerification, error) {
+	blockHeight, err := v.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+	if blockHeight == 0 {
+		return nil, errors.New("the block store is empty")
+	}
+
+	trieHeight, err := v.trieStore.Height()
+	if err == leveldb.ErrNotFound {
+		return nil, errors.Errorf("the state trie store is empty while the block store is at block [%d]", blockHeight)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while reading the height of the state trie store")
+	}
+	if trieHeight == 0 {
+		return nil, errors.Errorf("the state trie store is at block [0] while the block store is at block [%d]", blockHeight)
+	}
+	if trieHeight > blockHeight {
+		return nil, &LedgerInconsistencyError{
+			BlockNumber: blockHeight,
+			Reason:      fmt.Sprintf("the state trie is at block [%d], beyond the last block", trieHeight),
+		}
+	}
+	if trieHeight < blockHeight {
+		v.logger.Warnf("the state trie is at block [%d] while the last block is [%d], the state trie of block [%d] is walked", trieHeight, blockHeight, trieHeight)
+	}
+
+	var prevBlock *types.Block
+	for blockNum := uint64(1); blockNum <= blockHeight; blockNum++ {
+		block, err := v.blockStore.Get(blockNum)
+		if err != nil {
+			return nil, &LedgerInconsistencyError{BlockNumber: blockNum, Reason: err.Error()}
+		}
+
+		if reason, err := v.verifyBlock(block, prevBlock); err != nil {
+			return nil, err
+		} else if reason != "" {
+			return nil, &LedgerInconsistencyError{BlockNumber: blockNum, Reason: reason}
+		}
+
+		if blockNum%verificationProgressInterval == 0 {
+			v.logger.Infof("verified %d of %d blocks", blockNum, blockHeight)
+		}
+		prevBlock = block
+	}
+
+	lastHeader, err := v.blockStore.GetHeader(trieHeight)
+	if err != nil {
+		return nil, err
+	}
+	trie, err := mptrie.NewTrie(lastHeader.GetStateMerkelTreeRootHash(), v.trieStore)
+	if err != nil {
+		return nil, err
+	}
+	if err := trie.Verify(nil); err != nil {
+		return nil, &LedgerInconsistencyError{
+			BlockNumber: trieHeight,
+			Reason:      "the state trie is corrupted: " + err.Error(),
+		}
+	}
+
+	return &LedgerVerification{
+		BlockHeight:     blockHeight,
+		StateTrieHeight: trieHeight,
+	}, nil
+}
+
+// verifyBlock returns the reason for which the block is inconsistent, if it is, and an error only if the
+// verification itself fails
+func (v *ledgerVerifier) verifyBlock(block, prevBlock *types.Block) (string, error) {
+	header := block.GetHeader()
+	blockNum := header.GetBaseHeader().GetNumber()
+
+	hash, err := blockstore.ComputeBlockHash(block)
+	if err != nil {
+		return "", err
+	}
+	storedHash, err := v.blockStore.GetHash(blockNum)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(hash, storedHash) {
+		return "the hash of the block does not match the stored hash", nil
+	}
+
+	var prevBaseHash []byte
+	if prevBlock != nil {
+		if prevBaseHash, err = blockstore.ComputeBlockBaseHash(prevBlock); err != nil {
+			return "", err
+		}
+	}
+	if !bytes.Equal(header.GetBaseHeader().GetPreviousBaseHeaderHash(), prevBaseHash) {
+		return "the previous base header hash does not match the base header of the previous block", nil
+	}
+
+	links := blockstore.CalculateSkipListLinks(blockNum)
+	if len(header.GetSkipchainHashes()) != len(links) {
+		return fmt.Sprintf("the skip list holds %d hashes while %d are expected", len(header.GetSkipchainHashes()), len(links)), nil
+	}
+	for i, linkedBlockNum := range links {
+		// the hashes of the preceding blocks were already checked against the blocks
+		linkedHash, err := v.blockStore.GetHash(linkedBlockNum)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(header.GetSkipchainHashes()[i], linkedHash) {
+			return fmt.Sprintf("the skip list hash of block [%d] does not match the block", linkedBlockNum), nil
+		}
+	}
+
+	root, err := mtree.BuildTreeForBlockTx(block)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(header.GetTxMerkelTreeRootHash(), root.Hash()) {
+		return "the tx merkle tree root hash does not match the transactions of the block", nil
+	}
+
+	// the writes of a block are constructed from the state left by the preceding blocks, which
+	// are consistent, so that a failure is caused by the block itself
+	stateRoot, err := v.replayer.ReplayBlock(block)
+	if err != nil {
+		return "error while replaying the block: " + err.Error(), nil
+	}
+	if !bytes.Equal(stateRoot, header.GetStateMerkelTreeRootHash()) {
+		return "the state merkle tree root hash does not match the root recomputed from the writes of the block", nil
+	}
+
+	return "", nil
+}
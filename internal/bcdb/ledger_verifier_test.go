@@ -0,0 +1,321 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// createTestLedger creates a ledger holding the genesis block and the given number of data blocks,
+// and returns the ledger directory once all the stores are closed.
+func createTestLedger(t *testing.T, dataBlocks int) string {
+	cryptoDir, conf := testConfiguration(t)
+	env := newTxProcessorTestEnv(t, cryptoDir, conf)
+	setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+	for i := 0; i < dataBlocks; i++ {
+		tx := testutils.SignedDataTxEnvelope(t, []crypto.Signer{env.userSigner}, &types.DataTx{
+			MustSignUserIds: []string{"testUser"},
+			TxId:            fmt.Sprintf("tx%d", i),
+			DbOperations: []*types.DBOperation{
+				{
+					DbName: worldstate.DefaultDBName,
+					DataWrites: []*types.DataWrite{
+						{
+							Key:   fmt.Sprintf("key%d", i%3),
+							Value: []byte(fmt.Sprintf("value%d", i)),
+						},
+					},
+				},
+			},
+		})
+		_, err := env.txProcessor.SubmitTransaction(tx, 5*time.Second)
+		require.NoError(t, err)
+	}
+
+	height, err := env.blockStore.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(dataBlocks+1), height)
+
+	env.close()
+	return conf.LocalConfig.Server.Database.LedgerDirectory
+}
+
+// rewriteBlockStore replaces the block store of the ledger with a copy whose blocks are modified by the
+// given function. The stored hashes are computed from the modified blocks.
+func rewriteBlockStore(t *testing.T, ledgerDir string, lg *logger.SugarLogger, modify func(block *types.Block)) {
+	orgStore, err := blockstore.Open(&blockstore.Config{StoreDir: constructBlockStorePath(ledgerDir), Logger: lg})
+	require.NoError(t, err)
+	defer orgStore.Close()
+
+	copyDir := filepath.Join(ledgerDir, "blockstore-copy")
+	copyStore, err := blockstore.Open(&blockstore.Config{StoreDir: copyDir, Logger: lg})
+	require.NoError(t, err)
+
+	height, err := orgStore.Height()
+	require.NoError(t, err)
+	for blockNum := uint64(1); blockNum <= height; blockNum++ {
+		block, err := orgStore.Get(blockNum)
+		require.NoError(t, err)
+		modify(block)
+		require.NoError(t, copyStore.Commit(block))
+	}
+	require.NoError(t, copyStore.Close())
+
+	require.NoError(t, orgStore.Replace(copyDir))
+}
+
+func TestVerifyLedger(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	t.Run("consistent ledger", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.NoError(t, err)
+		require.Equal(t, &LedgerVerification{BlockHeight: 11, StateTrieHeight: 11}, res)
+	})
+
+	t.Run("pruned state trie", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		blockStore, err := blockstore.Open(&blockstore.Config{StoreDir: constructBlockStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		trieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: constructStateTrieStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		rootOf := func(blockNum uint64) ([]byte, error) {
+			header, err := blockStore.GetHeader(blockNum)
+			if err != nil {
+				return nil, err
+			}
+			return header.GetStateMerkelTreeRootHash(), nil
+		}
+		require.NoError(t, trieStore.Prune(&mptrie.RetentionPolicy{LastBlocks: 2}, rootOf))
+		require.True(t, trieStore.IsPruned(9))
+		require.NoError(t, trieStore.Close())
+		require.NoError(t, blockStore.Close())
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.NoError(t, err)
+		require.Equal(t, &LedgerVerification{BlockHeight: 11, StateTrieHeight: 11}, res)
+	})
+
+	t.Run("the ledger is not modified", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		// a partially appended block would be truncated by the recovery of the block store, and
+		// the sealed chunks would be moved by the archiver
+		chunkPath := filepath.Join(constructBlockStorePath(ledgerDir), "filechunks", "chunk_0")
+		chunk, err := os.OpenFile(chunkPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = chunk.Write([]byte{100, 1, 2, 3})
+		require.NoError(t, err)
+		require.NoError(t, chunk.Close())
+		chunkInfo, err := os.Stat(chunkPath)
+		require.NoError(t, err)
+
+		archiveDir := filepath.Join(ledgerDir, "archive")
+		dbConf := &config.DatabaseConf{
+			LedgerDirectory: ledgerDir,
+			BlockStore:      config.BlockStoreConf{ArchiveDirectory: archiveDir},
+		}
+		res, err := VerifyLedger(dbConf, lg)
+		require.NoError(t, err)
+		require.Equal(t, &LedgerVerification{BlockHeight: 11, StateTrieHeight: 11}, res)
+
+		afterInfo, err := os.Stat(chunkPath)
+		require.NoError(t, err)
+		require.Equal(t, chunkInfo.Size(), afterInfo.Size())
+		require.NoDirExists(t, archiveDir)
+	})
+
+	t.Run("empty state trie", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		stateTrieStorePath := constructStateTrieStorePath(ledgerDir)
+		require.NoError(t, os.RemoveAll(stateTrieStorePath))
+		trieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: stateTrieStorePath, Logger: lg})
+		require.NoError(t, err)
+		require.NoError(t, trieStore.Close())
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.EqualError(t, err, "the state trie store is empty while the block store is at block [11]")
+		require.Nil(t, res)
+	})
+
+	t.Run("partially created state trie", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		stateTrieStorePath := constructStateTrieStorePath(ledgerDir)
+		underCreationFlag := filepath.Join(stateTrieStorePath, "undercreation")
+		require.NoError(t, fileops.CreateFile(underCreationFlag))
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.EqualError(t, err, fmt.Sprintf("error while opening the state trie store: the trie store [%s] was created partially", stateTrieStorePath))
+		require.Nil(t, res)
+		require.FileExists(t, underCreationFlag)
+		require.DirExists(t, filepath.Join(stateTrieStorePath, "triedata"))
+	})
+
+	t.Run("missing ledger", func(t *testing.T) {
+		ledgerDir := filepath.Join(os.TempDir(), "no-ledger")
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.EqualError(t, err, fmt.Sprintf("the ledger directory [%s] does not hold a store at [%s]", ledgerDir, filepath.Join(ledgerDir, "blockstore")))
+		require.Nil(t, res)
+		require.NoDirExists(t, ledgerDir)
+	})
+
+	tamperedBlockTests := []struct {
+		name           string
+		modify         func(block *types.Block)
+		expectedBlock  uint64
+		expectedReason string
+	}{
+		{
+			name: "tampered transaction",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 5 {
+					block.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[0].DataWrites[0].Value = []byte("tampered")
+				}
+			},
+			expectedBlock:  5,
+			expectedReason: "the tx merkle tree root hash does not match the transactions of the block",
+		},
+		{
+			name: "tampered base header",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 5 {
+					block.Header.BaseHeader.LastCommittedBlockNum++
+				}
+			},
+			expectedBlock:  6,
+			expectedReason: "the previous base header hash does not match the base header of the previous block",
+		},
+		{
+			name: "tampered validation info",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 8 {
+					block.Header.ValidationInfo[0].Flag = types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK
+				}
+			},
+			expectedBlock:  8,
+			expectedReason: "the tx merkle tree root hash does not match the transactions of the block",
+		},
+		{
+			name: "tampered skip list",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 9 {
+					block.Header.SkipchainHashes = append(block.Header.SkipchainHashes, []byte("hash"))
+				}
+			},
+			expectedBlock:  9,
+			expectedReason: "the skip list holds 5 hashes while 4 are expected",
+		},
+		{
+			name: "tampered state root",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 3 {
+					block.Header.StateMerkelTreeRootHash = []byte("root")
+				}
+			},
+			expectedBlock:  3,
+			expectedReason: "the state merkle tree root hash does not match the root recomputed from the writes of the block",
+		},
+		{
+			name: "tampered transaction with a matching tx merkle tree root",
+			modify: func(block *types.Block) {
+				if block.GetHeader().GetBaseHeader().GetNumber() == 5 {
+					block.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[0].DataWrites[0].Value = []byte("tampered")
+					root, err := mtree.BuildTreeForBlockTx(block)
+					require.NoError(t, err)
+					block.Header.TxMerkelTreeRootHash = root.Hash()
+				}
+			},
+			expectedBlock:  5,
+			expectedReason: "the state merkle tree root hash does not match the root recomputed from the writes of the block",
+		},
+	}
+
+	for _, tt := range tamperedBlockTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledgerDir := createTestLedger(t, 10)
+			defer os.RemoveAll(ledgerDir)
+
+			rewriteBlockStore(t, ledgerDir, lg, tt.modify)
+
+			res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+			require.EqualError(t, err, (&LedgerInconsistencyError{BlockNumber: tt.expectedBlock, Reason: tt.expectedReason}).Error())
+			require.Nil(t, res)
+		})
+	}
+
+	t.Run("missing state trie node", func(t *testing.T) {
+		ledgerDir := createTestLedger(t, 10)
+		defer os.RemoveAll(ledgerDir)
+
+		blockStore, err := blockstore.Open(&blockstore.Config{StoreDir: constructBlockStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		header, err := blockStore.GetHeader(11)
+		require.NoError(t, err)
+		require.NoError(t, blockStore.Close())
+
+		// removes a child of the last root
+		trieStore, err := mptrieStore.Open(&mptrieStore.Config{StoreDir: constructStateTrieStorePath(ledgerDir), Logger: lg})
+		require.NoError(t, err)
+		root, err := trieStore.GetNode(header.GetStateMerkelTreeRootHash())
+		require.NoError(t, err)
+		require.NoError(t, trieStore.Close())
+
+		var childPtr []byte
+		for _, childPtr = range root.(*mptrie.BranchNode).GetChildren() {
+			if childPtr != nil {
+				break
+			}
+		}
+		require.NotNil(t, childPtr)
+
+		trieDB, err := leveldb.OpenFile(filepath.Join(constructStateTrieStorePath(ledgerDir), "triedata"), nil)
+		require.NoError(t, err)
+		require.NoError(t, trieDB.Delete(append([]byte{0}, []byte(base64.StdEncoding.EncodeToString(childPtr))...), nil))
+		require.NoError(t, trieDB.Close())
+
+		res, err := VerifyLedger(&config.DatabaseConf{LedgerDirectory: ledgerDir}, lg)
+		require.Error(t, err)
+		require.Nil(t, res)
+		inconsistency, ok := err.(*LedgerInconsistencyError)
+		require.True(t, ok)
+		require.Equal(t, uint64(11), inconsistency.BlockNumber)
+		require.Contains(t, inconsistency.Reason, "the state trie is corrupted")
+	})
+}
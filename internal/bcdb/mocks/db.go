@@ -285,6 +285,29 @@ func (_m *DB) GetData(dbName string, querierUserID string, key string) (*types.G
 	return r0, r1
 }
 
+// GetDataAbsenceProof provides a mock function with given fields: userID, blockNum, dbname, key
+func (_m *DB) GetDataAbsenceProof(userID string, blockNum uint64, dbname string, key string) (*types.GetDataProofResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, dbname, key)
+
+	var r0 *types.GetDataProofResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, string, string) *types.GetDataProofResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, dbname, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetDataProofResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, string, string) error); ok {
+		r1 = rf(userID, blockNum, dbname, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDataProof provides a mock function with given fields: userID, blockNum, dbname, key, deleted
 func (_m *DB) GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error) {
 	ret := _m.Called(userID, blockNum, dbname, key, deleted)
@@ -308,6 +331,29 @@ func (_m *DB) GetDataProof(userID string, blockNum uint64, dbname string, key st
 	return r0, r1
 }
 
+// GetDataProofs provides a mock function with given fields: userID, blockNum, dbname, keys
+func (_m *DB) GetDataProofs(userID string, blockNum uint64, dbname string, keys []string) (*types.GetDataProofsResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, dbname, keys)
+
+	var r0 *types.GetDataProofsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, string, []string) *types.GetDataProofsResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, dbname, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetDataProofsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, string, []string) error); ok {
+		r1 = rf(userID, blockNum, dbname, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDataRange provides a mock function with given fields: dbName, querierUserID, startKey, endKey, limit
 func (_m *DB) GetDataRange(dbName string, querierUserID string, startKey string, endKey string, limit uint64) (*types.GetDataRangeResponseEnvelope, error) {
 	ret := _m.Called(dbName, querierUserID, startKey, endKey, limit)
@@ -354,6 +400,29 @@ func (_m *DB) GetDeletedValues(dbname string, key string) (*types.GetHistoricalD
 	return r0, r1
 }
 
+// GetLedgerConsistencyProof provides a mock function with given fields: userID, start, end
+func (_m *DB) GetLedgerConsistencyProof(userID string, start uint64, end uint64) (*types.GetLedgerConsistencyProofResponseEnvelope, error) {
+	ret := _m.Called(userID, start, end)
+
+	var r0 *types.GetLedgerConsistencyProofResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetLedgerConsistencyProofResponseEnvelope); ok {
+		r0 = rf(userID, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetLedgerConsistencyProofResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
+		r1 = rf(userID, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLedgerPath provides a mock function with given fields: userID, start, end
 func (_m *DB) GetLedgerPath(userID string, start uint64, end uint64) (*types.GetLedgerPathResponseEnvelope, error) {
 	ret := _m.Called(userID, start, end)
@@ -538,6 +607,29 @@ func (_m *DB) GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.G
 	return r0, r1
 }
 
+// GetTxProofs provides a mock function with given fields: userID, blockNum, txIndexes
+func (_m *DB) GetTxProofs(userID string, blockNum uint64, txIndexes []uint64) (*types.GetTxProofsResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, txIndexes)
+
+	var r0 *types.GetTxProofsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, []uint64) *types.GetTxProofsResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, txIndexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxProofsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, []uint64) error); ok {
+		r1 = rf(userID, blockNum, txIndexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxReceipt provides a mock function with given fields: userId, txID
 func (_m *DB) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error) {
 	ret := _m.Called(userId, txID)
@@ -794,6 +886,43 @@ func (_m *DB) LedgerHeight() (uint64, error) {
 	return r0, r1
 }
 
+// LinearizableRead provides a mock function with given fields: ctx
+func (_m *DB) LinearizableRead(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNetworkFaults provides a mock function with given fields: userID, faults, seed
+func (_m *DB) SetNetworkFaults(userID string, faults []*types.NetworkFault, seed int64) (*types.SetNetworkFaultsResponseEnvelope, error) {
+	ret := _m.Called(userID, faults, seed)
+
+	var r0 *types.SetNetworkFaultsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, []*types.NetworkFault, int64) *types.SetNetworkFaultsResponseEnvelope); ok {
+		r0 = rf(userID, faults, seed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SetNetworkFaultsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []*types.NetworkFault, int64) error); ok {
+		r1 = rf(userID, faults, seed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitTransaction provides a mock function with given fields: tx, timeout
 func (_m *DB) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
 	ret := _m.Called(tx, timeout)
@@ -816,3 +945,26 @@ func (_m *DB) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.T
 
 	return r0, r1
 }
+
+// TransferLeadership provides a mock function with given fields: userID, targetNodeID, timestamp, timeout
+func (_m *DB) TransferLeadership(userID string, targetNodeID string, timestamp int64, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error) {
+	ret := _m.Called(userID, targetNodeID, timestamp, timeout)
+
+	var r0 *types.TransferLeadershipResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, int64, time.Duration) *types.TransferLeadershipResponseEnvelope); ok {
+		r0 = rf(userID, targetNodeID, timestamp, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransferLeadershipResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64, time.Duration) error); ok {
+		r1 = rf(userID, targetNodeID, timestamp, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	errors "github.com/hyperledger-labs/orion-server/internal/errors"
 	mock "github.com/stretchr/testify/mock"
 
@@ -69,6 +71,66 @@ func (_m *TxProcessor) IsLeader() *errors.NotLeaderError {
 	return r0
 }
 
+// LeadershipTransfer provides a mock function with given fields:
+func (_m *TxProcessor) LeadershipTransfer() *types.LeadershipTransfer {
+	ret := _m.Called()
+
+	var r0 *types.LeadershipTransfer
+	if rf, ok := ret.Get(0).(func() *types.LeadershipTransfer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LeadershipTransfer)
+		}
+	}
+
+	return r0
+}
+
+// LinearizableRead provides a mock function with given fields: ctx
+func (_m *TxProcessor) LinearizableRead(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetNetworkFaults provides a mock function with given fields: faults, seed
+func (_m *TxProcessor) SetNetworkFaults(faults []*types.NetworkFault, seed int64) ([]*types.NetworkFault, error) {
+	ret := _m.Called(faults, seed)
+
+	var r0 []*types.NetworkFault
+	if rf, ok := ret.Get(0).(func([]*types.NetworkFault, int64) []*types.NetworkFault); ok {
+		r0 = rf(faults, seed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.NetworkFault)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*types.NetworkFault, int64) error); ok {
+		r1 = rf(faults, seed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitTransaction provides a mock function with given fields: tx, timeout
 func (_m *TxProcessor) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponse, error) {
 	ret := _m.Called(tx, timeout)
@@ -91,3 +153,26 @@ func (_m *TxProcessor) SubmitTransaction(tx interface{}, timeout time.Duration)
 
 	return r0, r1
 }
+
+// TransferLeadership provides a mock function with given fields: targetNodeID, timeout
+func (_m *TxProcessor) TransferLeadership(targetNodeID string, timeout time.Duration) (*types.LeadershipTransfer, error) {
+	ret := _m.Called(targetNodeID, timeout)
+
+	var r0 *types.LeadershipTransfer
+	if rf, ok := ret.Get(0).(func(string, time.Duration) *types.LeadershipTransfer); ok {
+		r0 = rf(targetNodeID, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LeadershipTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(targetNodeID, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
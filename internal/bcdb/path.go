@@ -19,3 +19,7 @@ func constructProvenanceStorePath(dir string) string {
 func constructStateTrieStorePath(dir string) string {
 	return filepath.Join(dir, "statetriestore")
 }
+
+func constructStateTransferPath(dir string) string {
+	return filepath.Join(dir, "statetransfer")
+}
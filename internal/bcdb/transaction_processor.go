@@ -3,6 +3,7 @@
 package bcdb
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -10,19 +11,24 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockattestor"
 	"github.com/hyperledger-labs/orion-server/internal/blockcreator"
 	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
 	internalerror "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/expiry"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft"
+	"github.com/hyperledger-labs/orion-server/internal/statetransfer"
 	"github.com/hyperledger-labs/orion-server/internal/txreorderer"
 	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -39,11 +45,14 @@ type transactionProcessor struct {
 	blockOneQueueBarrier *queue.OneQueueBarrier
 	txReorderer          *txreorderer.TxReorderer
 	blockCreator         *blockcreator.BlockCreator
-	blockReplicator      *replication.BlockReplicator
-	peerTransport        *comm.HTTPTransport
+	consenter            replication.Consenter
+	peerTransport        comm.Transport
+	faultInjector        *comm.FaultInjector // nil unless fault injection is enabled
 	blockProcessor       *blockprocessor.BlockProcessor
 	blockStore           *blockstore.Store
 	pendingTxs           *queue.PendingTxs
+	expiryProposer       *expiry.Proposer
+	blockAttestor        *blockattestor.Attestor
 	logger               *logger.SugarLogger
 	sync.Mutex
 }
@@ -54,6 +63,8 @@ type txProcessorConfig struct {
 	blockStore      *blockstore.Store
 	provenanceStore *provenance.Store
 	stateTrieStore  mptrie.Store
+	stateTransfer   *statetransfer.Manager // optional, state transfer is disabled if nil
+	signer          crypto.Signer
 	logger          *logger.SugarLogger
 }
 
@@ -142,11 +153,22 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		return nil, err
 	}
 
-	p.peerTransport, err = comm.NewHTTPTransport(&comm.Config{
-		LocalConf:    localConfig,
-		Logger:       conf.logger,
-		LedgerReader: conf.blockStore,
-	})
+	commConfig := &comm.Config{
+		LocalConf:           localConfig,
+		Logger:              conf.logger,
+		LedgerReader:        conf.blockStore,
+		AttestationProvider: conf.blockStore,
+	}
+	if localConfig.Replication.FaultInjection {
+		conf.logger.Warn("Network fault injection is enabled, for testing only")
+		p.faultInjector = comm.NewFaultInjector(p.nodeID, conf.logger)
+		commConfig.FaultInjector = p.faultInjector
+	}
+	if conf.stateTransfer != nil {
+		conf.stateTransfer.SetCommitter(p.blockProcessor)
+		commConfig.StateSnapshotProvider = conf.stateTransfer
+	}
+	p.peerTransport, err = comm.NewTransport(commConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -193,21 +215,32 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		BlockOneQueueBarrier: p.blockOneQueueBarrier,
 		PendingTxs:           p.pendingTxs,
 		ConfigValidator:      txValidator.ConfigValidator(),
+		Signer:               conf.signer,
 		Logger:               conf.logger,
 	}
+	// the stores of an in-memory ledger cannot be replaced, hence such a node serves state
+	// snapshots to the other nodes, but catches up by committing all the missing blocks
+	if conf.stateTransfer != nil && !localConfig.Server.Database.InMemory() {
+		repConfig.StateTransferer = conf.stateTransfer
+	}
 	if joinStart {
 		repConfig.JoinBlock = conf.config.JoinBlock
 	}
 
-	p.blockReplicator, err = replication.NewBlockReplicator(repConfig)
+	switch clusterConfig.GetConsensusConfig().GetAlgorithm() {
+	case replication.ConsensusAlgorithmBFT:
+		p.consenter, err = bft.NewReplicator(repConfig)
+	default:
+		p.consenter, err = replication.NewBlockReplicator(repConfig)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if err = p.peerTransport.SetConsensusListener(p.blockReplicator); err != nil {
+	if err = p.peerTransport.SetConsensusListener(p.consenter); err != nil {
 		return nil, err
 	}
-	p.blockCreator.RegisterReplicator(p.blockReplicator)
+	p.blockCreator.RegisterReplicator(p.consenter)
 
 	if err = p.blockProcessor.RegisterBlockCommitListener(commitListenerName, p); err != nil {
 		return nil, err
@@ -224,13 +257,45 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		return nil, err
 	}
 
-	p.blockReplicator.Start() // Starts internal goroutine
+	p.consenter.Start() // Starts internal goroutine
 
 	go p.blockProcessor.Start()
 	p.blockProcessor.WaitTillStart()
 
 	p.blockStore = conf.blockStore
 
+	if interval := localConfig.BlockCreation.ExpiryScanInterval; interval > 0 {
+		p.expiryProposer = expiry.NewProposer(
+			&expiry.ProposerConfig{
+				NodeID:       p.nodeID,
+				Signer:       conf.signer,
+				DB:           conf.db,
+				Ledger:       conf.blockStore,
+				Submitter:    p,
+				PendingTxs:   p.pendingTxs,
+				Interval:     interval,
+				MaxKeysPerTx: expiry.DefaultMaxKeysPerTx,
+				Logger:       conf.logger,
+			},
+		)
+		go p.expiryProposer.Start()
+		p.expiryProposer.WaitTillStart()
+	}
+
+	p.blockAttestor = blockattestor.New(
+		&blockattestor.Config{
+			NodeID:       p.nodeID,
+			Signer:       conf.signer,
+			BlockStore:   conf.blockStore,
+			ConfigReader: conf.db,
+			Puller:       p.peerTransport,
+			Interval:     localConfig.Replication.AttestationInterval,
+			Logger:       conf.logger,
+		},
+	)
+	go p.blockAttestor.Start()
+	p.blockAttestor.WaitTillStart()
+
 	return p, nil
 }
 
@@ -243,12 +308,16 @@ func (t *transactionProcessor) SubmitTransaction(tx interface{}, timeout time.Du
 	switch tx.(type) {
 	case *types.DataTxEnvelope:
 		txID = tx.(*types.DataTxEnvelope).Payload.TxId
+	case *types.ProcedureTxEnvelope:
+		txID = tx.(*types.ProcedureTxEnvelope).Payload.TxId
 	case *types.UserAdministrationTxEnvelope:
 		txID = tx.(*types.UserAdministrationTxEnvelope).Payload.TxId
 	case *types.DBAdministrationTxEnvelope:
 		txID = tx.(*types.DBAdministrationTxEnvelope).Payload.TxId
 	case *types.ConfigTxEnvelope:
 		txID = tx.(*types.ConfigTxEnvelope).Payload.TxId
+	case *types.ExpiryTxEnvelope:
+		txID = tx.(*types.ExpiryTxEnvelope).Payload.TxId
 	default:
 		return nil, errors.Errorf("unexpected transaction type")
 	}
@@ -315,6 +384,12 @@ func (t *transactionProcessor) PostBlockCommitProcessing(block *types.Block) err
 			txIDs = append(txIDs, tx.Payload.TxId)
 		}
 
+	case *types.Block_ProcedureTxEnvelopes:
+		procedureTxEnvs := block.GetProcedureTxEnvelopes().Envelopes
+		for _, tx := range procedureTxEnvs {
+			txIDs = append(txIDs, tx.Payload.TxId)
+		}
+
 	case *types.Block_UserAdministrationTxEnvelope:
 		userTxEnv := block.GetUserAdministrationTxEnvelope()
 		txIDs = append(txIDs, userTxEnv.Payload.TxId)
@@ -327,6 +402,10 @@ func (t *transactionProcessor) PostBlockCommitProcessing(block *types.Block) err
 		configTxEnv := block.GetConfigTxEnvelope()
 		txIDs = append(txIDs, configTxEnv.Payload.TxId)
 
+	case *types.Block_ExpiryTxEnvelope:
+		expiryTxEnv := block.GetExpiryTxEnvelope()
+		txIDs = append(txIDs, expiryTxEnv.Payload.TxId)
+
 	default:
 		return errors.Errorf("unexpected transaction envelope in the block")
 	}
@@ -349,12 +428,21 @@ func (t *transactionProcessor) isTxIDDuplicate(txID string) (bool, error) {
 }
 
 func (t *transactionProcessor) Close() error {
+	// the proposer submits transactions and hence, must be
+	// stopped before the transaction queue is closed
+	if t.expiryProposer != nil {
+		t.expiryProposer.Stop()
+	}
+	if t.blockAttestor != nil {
+		t.blockAttestor.Stop()
+	}
+
 	t.Lock()
 	defer t.Unlock()
 
 	t.txReorderer.Stop()
 	t.blockCreator.Stop()
-	t.blockReplicator.Close()
+	t.consenter.Close()
 	t.peerTransport.Close()
 	t.blockProcessor.Stop()
 
@@ -365,7 +453,7 @@ func (t *transactionProcessor) IsLeader() *internalerror.NotLeaderError {
 	t.Lock()
 	defer t.Unlock()
 
-	return t.blockReplicator.IsLeader()
+	return t.consenter.IsLeader()
 }
 
 // ClusterStatus returns the leader NodeID, and the active nodes NodeIDs.
@@ -374,7 +462,7 @@ func (t *transactionProcessor) ClusterStatus() (leader string, active []string)
 	t.Lock()
 	defer t.Unlock()
 
-	leaderID, activePeers := t.blockReplicator.GetClusterStatus()
+	leaderID, activePeers := t.consenter.GetClusterStatus()
 	for _, peer := range activePeers {
 		active = append(active, peer.NodeId)
 		if peer.RaftId == leaderID {
@@ -385,6 +473,35 @@ func (t *transactionProcessor) ClusterStatus() (leader string, active []string)
 	return
 }
 
+// TransferLeadership hands over the leadership of the cluster to the consensus member with the given node ID, and
+// returns the outcome of the transfer. The transfer may take a while, so the lock is not held while waiting for it.
+func (t *transactionProcessor) TransferLeadership(targetNodeID string, timeout time.Duration) (*types.LeadershipTransfer, error) {
+	return t.consenter.TransferLeadership(targetNodeID, timeout)
+}
+
+// SetNetworkFaults replaces the network faults injected into the messages the node sends to the other nodes, see
+// comm.FaultInjector, and returns the faults that are set.
+func (t *transactionProcessor) SetNetworkFaults(faults []*types.NetworkFault, seed int64) ([]*types.NetworkFault, error) {
+	if t.faultInjector == nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: "network fault injection is not enabled on this node"}
+	}
+	if err := t.faultInjector.SetFaults(faults, seed); err != nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: err.Error()}
+	}
+	return t.faultInjector.Faults(), nil
+}
+
+// LeadershipTransfer returns the last leadership transfer requested on this node, or nil if there was none.
+func (t *transactionProcessor) LeadershipTransfer() *types.LeadershipTransfer {
+	return t.consenter.LeadershipTransfer()
+}
+
+// LinearizableRead performs a Raft ReadIndex round, and waits for the local commit to reach the read index. It returns
+// the number of the last block committed at that point.
+func (t *transactionProcessor) LinearizableRead(ctx context.Context) (uint64, error) {
+	return t.consenter.LinearizableRead(ctx)
+}
+
 func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvelope, error) {
 	certs, err := readCerts(conf)
 	if err != nil {
@@ -420,15 +537,15 @@ func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvel
 			maxRaftID = m.RaftId
 		}
 	}
+	for _, o := range conf.SharedConfig.Consensus.Observers {
+		if o.RaftId > maxRaftID {
+			maxRaftID = o.RaftId
+		}
+	}
 
 	clusterConfig := &types.ClusterConfig{
-		Nodes: nodes,
-		Admins: []*types.Admin{
-			{
-				Id:          conf.SharedConfig.Admin.ID,
-				Certificate: certs.adminCert,
-			},
-		},
+		Nodes:          nodes,
+		Admins:         certs.admins,
 		CertAuthConfig: certs.caCerts,
 		ConsensusConfig: &types.ConsensusConfig{
 			Algorithm: conf.SharedConfig.Consensus.Algorithm,
@@ -440,10 +557,24 @@ func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvel
 				HeartbeatTicks:       conf.SharedConfig.Consensus.RaftConfig.HeartbeatTicks,
 				MaxInflightBlocks:    conf.SharedConfig.Consensus.RaftConfig.MaxInflightBlocks,
 				SnapshotIntervalSize: conf.SharedConfig.Consensus.RaftConfig.SnapshotIntervalSize,
+				Compression:          conf.SharedConfig.Consensus.RaftConfig.Compression,
+				EntryFormatVersion:   conf.SharedConfig.Consensus.RaftConfig.EntryFormatVersion,
 				MaxRaftId:            maxRaftID,
 			},
 		},
 	}
+	if policy := conf.SharedConfig.GovernancePolicy; policy != nil {
+		clusterConfig.GovernancePolicy = &types.GovernancePolicy{
+			ConfigTxMinAdmins:    policy.ConfigTxMinAdmins,
+			UserAdminTxMinAdmins: policy.UserAdminTxMinAdmins,
+			DbAdminTxMinAdmins:   policy.DbAdminTxMinAdmins,
+		}
+	}
+	if bftConf := conf.SharedConfig.Consensus.BFTConfig; bftConf != nil {
+		clusterConfig.ConsensusConfig.BftConfig = &types.BFTConfig{
+			ViewChangeTimeout: bftConf.ViewChangeTimeout,
+		}
+	}
 
 	inMembers := false
 	for i, m := range conf.SharedConfig.Consensus.Members {
@@ -479,10 +610,11 @@ func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvel
 		return nil, errors.Errorf("local Server.Identity.ID [%s] cannot be in SharedConfig.Consensus both Members and Observers: %v",
 			conf.LocalConfig.Server.Identity.ID, conf.SharedConfig.Consensus)
 	}
-	// TODO add support for observers, see issue: https://github.ibm.com/blockchaindb/server/issues/403
-	if inObservers {
-		return nil, errors.Errorf("not supported yet: local Server.Identity.ID [%s] is in SharedConfig.Consensus.Observers: %v",
-			conf.LocalConfig.Server.Identity.ID, conf.SharedConfig.Consensus)
+	for _, o := range conf.SharedConfig.Consensus.Observers {
+		if o.NodeId == conf.LocalConfig.Server.Identity.ID && o.RaftId == 0 {
+			return nil, errors.Errorf("local Server.Identity.ID [%s] is an observer with Raft ID 0, which does not replicate the ledger: an observer must have a unique Raft ID >0",
+				conf.LocalConfig.Server.Identity.ID)
+		}
 	}
 
 	return &types.ConfigTxEnvelope{
@@ -42,6 +42,7 @@ type txProcessorTestEnv struct {
 	userID         string
 	userCert       *x509.Certificate
 	userSigner     crypto.Signer
+	close          func()
 	cleanup        func()
 }
 
@@ -115,6 +116,7 @@ func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configur
 	}
 
 	userCert, userSigner := testutils.LoadTestCrypto(t, cryptoDir, "testUser")
+	_, nodeSigner := testutils.LoadTestCrypto(t, cryptoDir, conf.LocalConfig.Server.Identity.ID)
 
 	txProcConf := &txProcessorConfig{
 		config:          conf,
@@ -122,12 +124,13 @@ func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configur
 		blockStore:      blockStore,
 		provenanceStore: provenanceStore,
 		stateTrieStore:  stateTrieStore,
+		signer:          nodeSigner,
 		logger:          lg,
 	}
 	txProcessor, err := newTransactionProcessor(txProcConf)
 	require.NoError(t, err)
 
-	cleanup := func() {
+	closeAll := func() {
 		if err := txProcessor.Close(); err != nil {
 			t.Errorf("error while closing the transaction processor")
 		}
@@ -147,6 +150,10 @@ func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configur
 		if err := blockStore.Close(); err != nil {
 			t.Errorf("error while closing blockstore, %v", err)
 		}
+	}
+
+	cleanup := func() {
+		closeAll()
 
 		if err := os.RemoveAll(dir); err != nil {
 			t.Fatalf("error while removing directory %s, %v", dir, err)
@@ -171,6 +178,7 @@ func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configur
 		userID:         "testUser",
 		userCert:       userCert,
 		userSigner:     userSigner,
+		close:          closeAll,
 		cleanup:        cleanup,
 	}
 }
@@ -320,6 +328,8 @@ func TestTransactionProcessor(t *testing.T) {
 		require.True(t, block.GetConsensusMetadata().GetRaftTerm() > 0)
 		require.True(t, block.GetConsensusMetadata().GetRaftIndex() > 0)
 		block.ConsensusMetadata = nil
+		require.True(t, block.GetHeader().GetBaseHeader().GetTimestamp() > 0)
+		expectedBlock.Header.BaseHeader.Timestamp = block.GetHeader().GetBaseHeader().GetTimestamp()
 		require.True(t, proto.Equal(expectedBlock, block), "expected: %+v, actual: %+v", expectedBlock, block)
 
 		noPendingTxs := func() bool {
@@ -411,6 +421,8 @@ func TestTransactionProcessor(t *testing.T) {
 		require.True(t, block.GetConsensusMetadata().GetRaftTerm() > 0)
 		require.True(t, block.GetConsensusMetadata().GetRaftIndex() > 0)
 		block.ConsensusMetadata = nil
+		require.True(t, block.GetHeader().GetBaseHeader().GetTimestamp() > 0)
+		expectedBlock.Header.BaseHeader.Timestamp = block.GetHeader().GetBaseHeader().GetTimestamp()
 		require.True(t, proto.Equal(expectedBlock, block))
 
 		expectedRespPayload := &types.TxReceiptResponse{
@@ -585,6 +597,63 @@ func TestTransactionProcessor(t *testing.T) {
 	})
 }
 
+func TestPrepareBootstrapConfigTx(t *testing.T) {
+	cryptoDir, conf := testConfiguration(t)
+	defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+
+	admin2CryptoDir := testutils.GenerateTestCrypto(t, []string{"admin2"})
+	adminCert, _ := testutils.LoadTestCrypto(t, cryptoDir, "admin")
+	admin2Cert, _ := testutils.LoadTestCrypto(t, admin2CryptoDir, "admin2")
+
+	t.Run("single admin without a governance policy", func(t *testing.T) {
+		configTx, err := PrepareBootstrapConfigTx(conf)
+		require.NoError(t, err)
+		require.Equal(t, []*types.Admin{{Id: "admin", Certificate: adminCert.Raw}}, configTx.Payload.NewConfig.Admins)
+		require.Nil(t, configTx.Payload.NewConfig.GovernancePolicy)
+	})
+
+	t.Run("additional admins and a governance policy", func(t *testing.T) {
+		sharedConf := *conf.SharedConfig
+		sharedConf.AdditionalAdmins = []config.AdminConf{
+			{
+				ID:              "admin2",
+				CertificatePath: path.Join(admin2CryptoDir, "admin2.pem"),
+			},
+		}
+		sharedConf.GovernancePolicy = &config.GovernancePolicyConf{
+			ConfigTxMinAdmins:    2,
+			UserAdminTxMinAdmins: 2,
+			DbAdminTxMinAdmins:   1,
+		}
+
+		configTx, err := PrepareBootstrapConfigTx(&config.Configurations{LocalConfig: conf.LocalConfig, SharedConfig: &sharedConf})
+		require.NoError(t, err)
+		require.Equal(t, []*types.Admin{
+			{Id: "admin", Certificate: adminCert.Raw},
+			{Id: "admin2", Certificate: admin2Cert.Raw},
+		}, configTx.Payload.NewConfig.Admins)
+		require.True(t, proto.Equal(&types.GovernancePolicy{
+			ConfigTxMinAdmins:    2,
+			UserAdminTxMinAdmins: 2,
+			DbAdminTxMinAdmins:   1,
+		}, configTx.Payload.NewConfig.GovernancePolicy))
+	})
+
+	t.Run("additional admin certificate is missing", func(t *testing.T) {
+		sharedConf := *conf.SharedConfig
+		sharedConf.AdditionalAdmins = []config.AdminConf{
+			{
+				ID:              "admin2",
+				CertificatePath: "/does-not-exist/admin2.pem",
+			},
+		}
+
+		configTx, err := PrepareBootstrapConfigTx(&config.Configurations{LocalConfig: conf.LocalConfig, SharedConfig: &sharedConf})
+		require.EqualError(t, err, "error while reading admin certificate /does-not-exist/admin2.pem: open /does-not-exist/admin2.pem: no such file or directory")
+		require.Nil(t, configTx)
+	})
+}
+
 func testConfiguration(t *testing.T) (string, *config.Configurations) {
 	ledgerDir, err := ioutil.TempDir("/tmp", "server")
 	require.NoError(t, err)
@@ -91,10 +91,10 @@ func (q *worldstateQueryProcessor) getDBIndex(dbName, querierUserID string) (*ty
 	}, nil
 }
 
-// getState return the state associated with a given key
-func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*types.GetDataResponse, error) {
+// getState return the state associated with a given key along with the height of the world state it was read at
+func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*types.GetDataResponse, uint64, error) {
 	if worldstate.IsSystemDB(dbName) {
-		return nil, &errors.PermissionErr{
+		return nil, 0, &errors.PermissionErr{
 			ErrMsg: "no user can directly read from a system database [" + dbName + "]. " +
 				"To read from a system database, use /config, /user, /db rest endpoints instead of /data",
 		}
@@ -102,23 +102,34 @@ func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*
 
 	hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if !hasPerm {
-		return nil, &errors.PermissionErr{
+		return nil, 0, &errors.PermissionErr{
 			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
 		}
 	}
 
-	value, metadata, err := q.db.Get(dbName, key)
+	snapshots, err := q.db.GetDBsSnapshot([]string{worldstate.MetadataDBName, dbName})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	defer snapshots.Release()
+
+	height, err := snapshots.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value, metadata, err := snapshots.Get(dbName, key)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	acl := metadata.GetAccessControl()
 	if acl != nil {
 		if !acl.ReadUsers[querierUserID] && !acl.ReadWriteUsers[querierUserID] {
-			return nil, &errors.PermissionErr{
+			return nil, 0, &errors.PermissionErr{
 				ErrMsg: "the user [" + querierUserID + "] has no permission to read key [" + key + "] from database [" + dbName + "]",
 			}
 		}
@@ -127,13 +138,14 @@ func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*
 	return &types.GetDataResponse{
 		Value:    value,
 		Metadata: metadata,
-	}, nil
+	}, height, nil
 }
 
-// getDataRange return the state associated with a given key
-func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey, endKey string, limit uint64) (*types.GetDataRangeResponse, error) {
+// getDataRange return the state associated with a given range of keys along with the height of the world state it
+// was read at
+func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey, endKey string, limit uint64) (*types.GetDataRangeResponse, uint64, error) {
 	if worldstate.IsSystemDB(dbName) {
-		return nil, &errors.PermissionErr{
+		return nil, 0, &errors.PermissionErr{
 			ErrMsg: "no user can directly read from a system database [" + dbName + "]. " +
 				"To read from a system database, use /config, /user, /db rest endpoints instead of /data",
 		}
@@ -141,10 +153,10 @@ func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey,
 
 	hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if !hasPerm {
-		return nil, &errors.PermissionErr{
+		return nil, 0, &errors.PermissionErr{
 			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
 		}
 	}
@@ -155,17 +167,28 @@ func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey,
 	var pendingResult bool
 	var nextStartKey string
 
-	itr, err := q.db.GetIterator(dbName, startKey, endKey)
-	defer itr.Release()
+	snapshots, err := q.db.GetDBsSnapshot([]string{worldstate.MetadataDBName, dbName})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	defer snapshots.Release()
+
+	height, err := snapshots.Height()
+	if err != nil {
+		return nil, 0, err
 	}
 
+	itr, err := snapshots.GetIterator(dbName, startKey, endKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer itr.Release()
+
 	for itr.Next() {
 		k := string(itr.Key())
 		v := &types.ValueWithMetadata{}
 		if err := proto.Unmarshal(itr.Value(), v); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		acl := v.GetMetadata().GetAccessControl()
@@ -192,7 +215,7 @@ func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey,
 				break
 			}
 
-			return nil, &errors.ServerRestrictionError{
+			return nil, 0, &errors.ServerRestrictionError{
 				ErrMsg: fmt.Sprintf("response size limit for queries is configured as %d bytes but a single record size itself is %d bytes. Increase the query response size limit at the server", q.queryProcessingConf.ResponseSizeLimitInBytes, size),
 			}
 		}
@@ -208,7 +231,7 @@ func (q *worldstateQueryProcessor) getDataRange(dbName, querierUserID, startKey,
 		KVs:           kvs,
 		PendingResult: pendingResult,
 		NextStartKey:  nextStartKey,
-	}, nil
+	}, height, nil
 }
 
 func (q *worldstateQueryProcessor) getUser(querierUserID, targetUserID string) (*types.GetUserResponse, error) {
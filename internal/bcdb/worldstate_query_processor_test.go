@@ -689,9 +689,10 @@ func TestGetDataRange(t *testing.T) {
 		for _, testCase := range testCases {
 			var actualKVs []*types.KVWithMetadata
 			for {
-				payload, err := env.q.getDataRange("test-db", testCase.user, testCase.startKey, testCase.endKey, testCase.limit)
+				payload, height, err := env.q.getDataRange("test-db", testCase.user, testCase.startKey, testCase.endKey, testCase.limit)
 				require.NoError(t, err)
 				require.NotNil(t, payload)
+				require.Equal(t, uint64(10), height)
 				actualKVs = append(actualKVs, payload.GetKVs()...)
 				if !payload.PendingResult {
 					break
@@ -710,7 +711,7 @@ func TestGetDataRange(t *testing.T) {
 		setup(env.db, "alice", "test-db")
 		setup(env.db, "bob", "another-test-db")
 
-		actualVal, err := env.q.getDataRange("another-test-db", "alice", "", "", 0)
+		actualVal, _, err := env.q.getDataRange("another-test-db", "alice", "", "", 0)
 		require.EqualError(t, err, "the user [alice] has no permission to read from database [another-test-db]")
 		require.Nil(t, actualVal)
 	})
@@ -722,7 +723,7 @@ func TestGetDataRange(t *testing.T) {
 
 		setup(env.db, "alice", "test-db")
 
-		actualVal, err := env.q.getDataRange("test-db", "alice", "key1", "key2", 10)
+		actualVal, _, err := env.q.getDataRange("test-db", "alice", "key1", "key2", 10)
 		require.EqualError(t, err, "response size limit for queries is configured as 10 bytes but a single record size itself is 33 bytes. Increase the query response size limit at the server")
 		require.Nil(t, actualVal)
 	})
@@ -767,7 +768,7 @@ func TestGetDataRange(t *testing.T) {
 		for _, tt := range tests {
 			tt := tt
 			t.Run(tt.name, func(t *testing.T) {
-				actualVal, err := env.q.getDataRange(tt.dbName, tt.user, tt.startKey, tt.endKey, 0)
+				actualVal, _, err := env.q.getDataRange(tt.dbName, tt.user, tt.startKey, tt.endKey, 0)
 				require.EqualError(t, err, "no user can directly read from a system database ["+tt.dbName+"]. "+
 					"To read from a system database, use /config, /user, /db rest endpoints instead of /data")
 				require.Nil(t, actualVal)
@@ -887,9 +888,10 @@ func TestGetData(t *testing.T) {
 		}
 
 		for _, testCase := range testCases {
-			payload, err := env.q.getData("test-db", "testUser", testCase.key)
+			payload, height, err := env.q.getData("test-db", "testUser", testCase.key)
 			require.NoError(t, err)
 			require.NotNil(t, payload)
+			require.Equal(t, uint64(2), height)
 			require.Equal(t, testCase.expectedValue, payload.Value)
 			require.True(t, proto.Equal(testCase.expectedMetadata, payload.Metadata))
 		}
@@ -930,7 +932,7 @@ func TestGetData(t *testing.T) {
 		}
 		require.NoError(t, env.db.Commit(dbsUpdates, 2))
 
-		actualVal, err := env.q.getData("test-db", "testUser", "key1")
+		actualVal, _, err := env.q.getData("test-db", "testUser", "key1")
 		require.EqualError(t, err, "the user [testUser] has no permission to read key [key1] from database [test-db]")
 		require.Nil(t, actualVal)
 	})
@@ -970,7 +972,7 @@ func TestGetData(t *testing.T) {
 		for _, tt := range tests {
 			tt := tt
 			t.Run(tt.name, func(t *testing.T) {
-				actualVal, err := env.q.getData(tt.dbName, tt.user, tt.key)
+				actualVal, _, err := env.q.getData(tt.dbName, tt.user, tt.key)
 				require.EqualError(t, err, "no user can directly read from a system database ["+tt.dbName+"]. "+
 					"To read from a system database, use /config, /user, /db rest endpoints instead of /data")
 				require.Nil(t, actualVal)
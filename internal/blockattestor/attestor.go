@@ -0,0 +1,351 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockattestor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/attestation"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultInterval is the interval between two rounds of the attestor when no interval is configured
+	DefaultInterval = time.Second
+	// DefaultWindow is the number of the most recent blocks the attestor collects attestations on. Blocks that fall
+	// out of the window before they are certified by a quorum are not certified.
+	DefaultWindow = uint64(1000)
+
+	pullTimeout = 10 * time.Second
+)
+
+// BlockStore holds the blocks, the attestations of the local node on them, and their quorum certificates
+type BlockStore interface {
+	Height() (uint64, error)
+	GetHeader(blockNumber uint64) (*types.BlockHeader, error)
+	GetAttestation(blockNumber uint64) (*types.BlockAttestation, error)
+	CommitAttestation(attestation *types.BlockAttestation) error
+	GetQuorumCertificate(blockNumber uint64) (*types.QuorumCertificate, error)
+	CommitQuorumCertificate(qc *types.QuorumCertificate) error
+}
+
+// ConfigReader provides the current cluster config
+type ConfigReader interface {
+	GetConfig() (*types.ClusterConfig, *types.Metadata, error)
+}
+
+// Puller pulls the attestations of a remote consensus member, identified by its Raft ID
+type Puller interface {
+	PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error)
+}
+
+// Attestor periodically signs the headers of the blocks committed by the local node, if it is a consensus member,
+// and collects the attestations of the other consensus members on the same headers. Once a block header is attested
+// by a majority of the consensus members, the attestations are stored as a quorum certificate next to the block.
+//
+// The attestations are verified against the header committed by the local node, so a quorum certificate is made only
+// of attestations on that exact header. The consensus members are taken from the current cluster config.
+type Attestor struct {
+	nodeID       string
+	signer       crypto.Signer
+	blockStore   BlockStore
+	configReader ConfigReader
+	puller       Puller
+	interval     time.Duration
+	window       uint64
+
+	// the lowest block that may still be uncertified
+	low uint64
+	// the verified attestations of the blocks that are not certified yet, by block number
+	pending map[uint64]*pendingBlock
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+
+	logger *logger.SugarLogger
+}
+
+type pendingBlock struct {
+	headerHash   []byte
+	attestations map[string]*types.BlockAttestation
+}
+
+// Config holds the configuration of the attestor
+type Config struct {
+	NodeID       string
+	Signer       crypto.Signer
+	BlockStore   BlockStore
+	ConfigReader ConfigReader
+	Puller       Puller
+	Interval     time.Duration
+	Window       uint64
+	Logger       *logger.SugarLogger
+}
+
+// New creates a new attestor
+func New(conf *Config) *Attestor {
+	interval := conf.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	window := conf.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Attestor{
+		nodeID:       conf.NodeID,
+		signer:       conf.Signer,
+		blockStore:   conf.BlockStore,
+		configReader: conf.ConfigReader,
+		puller:       conf.Puller,
+		interval:     interval,
+		window:       window,
+		low:          1,
+		pending:      make(map[uint64]*pendingBlock),
+		ctx:          ctx,
+		cancel:       cancel,
+		started:      make(chan struct{}),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		logger:       conf.Logger,
+	}
+}
+
+// Start runs the attestor till it is stopped
+func (a *Attestor) Start() {
+	defer close(a.stopped)
+
+	a.logger.Info("starting the block attestor")
+	close(a.started)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			a.logger.Info("stopping the block attestor")
+			return
+
+		case <-ticker.C:
+			if err := a.Attest(); err != nil {
+				a.logger.Errorf("error while attesting the blocks: %s", err)
+			}
+		}
+	}
+}
+
+// WaitTillStart waits till the attestor is started
+func (a *Attestor) WaitTillStart() {
+	<-a.started
+}
+
+// Stop stops the attestor
+func (a *Attestor) Stop() {
+	a.cancel()
+	close(a.stop)
+	<-a.stopped
+}
+
+// Attest runs a single round of the attestor: it signs the blocks that the local node did not attest yet, pulls the
+// attestations of the other consensus members on the blocks that are not certified yet, and stores a quorum
+// certificate for every block that is attested by a quorum.
+func (a *Attestor) Attest() error {
+	height, err := a.blockStore.Height()
+	if err != nil {
+		return err
+	}
+	if height == 0 {
+		return nil
+	}
+	if height >= a.window && a.low < height-a.window+1 {
+		for n := a.low; n < height-a.window+1; n++ {
+			if _, ok := a.pending[n]; ok {
+				a.logger.Warnf("block [%d] fell out of the attestation window before it was certified", n)
+				delete(a.pending, n)
+			}
+		}
+		a.low = height - a.window + 1
+	}
+
+	clusterConfig, _, err := a.configReader.GetConfig()
+	if err != nil {
+		return err
+	}
+	verifiers, err := attestation.Verifiers(clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	if err = a.load(height, verifiers); err != nil {
+		return err
+	}
+	if a.low > height {
+		return nil
+	}
+
+	for _, member := range clusterConfig.GetConsensusConfig().GetMembers() {
+		if member.NodeId == a.nodeID {
+			continue
+		}
+		a.pull(member, height, verifiers)
+	}
+
+	return a.certify(attestation.Quorum(clusterConfig), height)
+}
+
+// load adds the blocks up to the height to the pending blocks, skipping the blocks that are already certified, and
+// signs the blocks that the local node did not attest yet, if it is a consensus member.
+func (a *Attestor) load(height uint64, verifiers map[string]*crypto.Verifier) error {
+	_, isMember := verifiers[a.nodeID]
+
+	for n := a.low; n <= height; n++ {
+		p, ok := a.pending[n]
+		if !ok {
+			_, err := a.blockStore.GetQuorumCertificate(n)
+			if err == nil {
+				continue
+			}
+			if _, notFound := err.(*ierrors.NotFoundErr); !notFound {
+				return err
+			}
+
+			header, err := a.blockStore.GetHeader(n)
+			if err != nil {
+				return err
+			}
+			headerHash, err := attestation.HeaderHash(header)
+			if err != nil {
+				return err
+			}
+			p = &pendingBlock{
+				headerHash:   headerHash,
+				attestations: make(map[string]*types.BlockAttestation),
+			}
+			a.pending[n] = p
+		}
+
+		if !isMember || p.attestations[a.nodeID] != nil {
+			continue
+		}
+		own, err := a.ownAttestation(n, p.headerHash)
+		if err != nil {
+			return err
+		}
+		p.attestations[a.nodeID] = own
+	}
+
+	return nil
+}
+
+// ownAttestation returns the attestation of the local node on the block, signing the block if it is not attested yet.
+func (a *Attestor) ownAttestation(blockNumber uint64, headerHash []byte) (*types.BlockAttestation, error) {
+	own, err := a.blockStore.GetAttestation(blockNumber)
+	if err == nil {
+		return own, nil
+	}
+	if _, notFound := err.(*ierrors.NotFoundErr); !notFound {
+		return nil, err
+	}
+
+	own, err = attestation.Sign(a.signer, a.nodeID, blockNumber, headerHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.blockStore.CommitAttestation(own); err != nil {
+		return nil, err
+	}
+	a.logger.Debugf("attested block [%d]", blockNumber)
+	return own, nil
+}
+
+// pull pulls the attestations of the member on the pending blocks, and adds the valid ones. A member that cannot be
+// reached is skipped till the next round.
+func (a *Attestor) pull(member *types.PeerConfig, height uint64, verifiers map[string]*crypto.Verifier) {
+	start := a.low
+	for start <= height && a.attestedBy(start, member.NodeId) {
+		start++
+	}
+	if start > height {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, pullTimeout)
+	defer cancel()
+
+	attestations, err := a.puller.PullAttestations(ctx, member.RaftId, start, height)
+	if err != nil {
+		a.logger.Debugf("failed to pull attestations from member [%s]: %s", member.NodeId, err)
+		return
+	}
+
+	for _, att := range attestations {
+		if att.GetNodeId() != member.NodeId {
+			a.logger.Warnf("member [%s] sent an attestation of [%s]", member.NodeId, att.GetNodeId())
+			continue
+		}
+		n := att.GetBlockNumber()
+		p, ok := a.pending[n]
+		if !ok || n < start || n > height || p.attestations[member.NodeId] != nil {
+			continue
+		}
+		if err := verifiers[member.NodeId].Verify(attestation.SigningBytes(n, p.headerHash), att.GetSignature()); err != nil {
+			// the member attested a different header, or the attestation is not valid
+			a.logger.Warnf("member [%s] sent an invalid attestation on block [%d]: %s", member.NodeId, n, err)
+			continue
+		}
+		p.attestations[member.NodeId] = att
+	}
+}
+
+func (a *Attestor) attestedBy(blockNumber uint64, nodeID string) bool {
+	p, ok := a.pending[blockNumber]
+	return !ok || p.attestations[nodeID] != nil
+}
+
+// certify stores a quorum certificate of every pending block that is attested by a quorum, and advances the lowest
+// uncertified block.
+func (a *Attestor) certify(quorum int, height uint64) error {
+	for n := a.low; n <= height; n++ {
+		p, ok := a.pending[n]
+		if !ok || len(p.attestations) < quorum {
+			continue
+		}
+
+		qc := &types.QuorumCertificate{
+			BlockNumber: n,
+			HeaderHash:  p.headerHash,
+		}
+		for _, att := range p.attestations {
+			qc.Attestations = append(qc.Attestations, att)
+		}
+		sort.Slice(qc.Attestations, func(i, j int) bool {
+			return qc.Attestations[i].NodeId < qc.Attestations[j].NodeId
+		})
+		if err := a.blockStore.CommitQuorumCertificate(qc); err != nil {
+			return errors.WithMessagef(err, "error while storing the quorum certificate of block [%d]", n)
+		}
+		a.logger.Debugf("certified block [%d] by %d attestations", n, len(qc.Attestations))
+		delete(a.pending, n)
+	}
+
+	for a.low <= height {
+		if _, ok := a.pending[a.low]; ok {
+			break
+		}
+		a.low++
+	}
+	return nil
+}
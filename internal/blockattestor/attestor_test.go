@@ -0,0 +1,356 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockattestor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/attestation"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlockStore struct {
+	mu           sync.Mutex
+	headers      []*types.BlockHeader
+	attestations map[uint64]*types.BlockAttestation
+	qcs          map[uint64]*types.QuorumCertificate
+}
+
+func newFakeBlockStore(height uint64, fork uint64) *fakeBlockStore {
+	s := &fakeBlockStore{
+		attestations: make(map[uint64]*types.BlockAttestation),
+		qcs:          make(map[uint64]*types.QuorumCertificate),
+	}
+	s.append(height, fork)
+	return s
+}
+
+// append appends blocks up to the height, where the header of block `fork` differs from the other stores
+func (s *fakeBlockStore) append(height uint64, fork uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for n := uint64(len(s.headers)) + 1; n <= height; n++ {
+		header := &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}
+		if n == fork {
+			header.TxMerkelTreeRootHash = []byte("fork")
+		}
+		s.headers = append(s.headers, header)
+	}
+}
+
+func (s *fakeBlockStore) Height() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uint64(len(s.headers)), nil
+}
+
+func (s *fakeBlockStore) GetHeader(blockNumber uint64) (*types.BlockHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers[blockNumber-1], nil
+}
+
+func (s *fakeBlockStore) GetAttestation(blockNumber uint64) (*types.BlockAttestation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attestations[blockNumber]; ok {
+		return a, nil
+	}
+	return nil, &ierrors.NotFoundErr{Message: fmt.Sprintf("attestation not found: %d", blockNumber)}
+}
+
+func (s *fakeBlockStore) GetAttestations(start, end uint64) ([]*types.BlockAttestation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var attestations []*types.BlockAttestation
+	for n := start; n <= end; n++ {
+		if a, ok := s.attestations[n]; ok {
+			attestations = append(attestations, a)
+		}
+	}
+	return attestations, nil
+}
+
+func (s *fakeBlockStore) CommitAttestation(a *types.BlockAttestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attestations[a.BlockNumber] = a
+	return nil
+}
+
+func (s *fakeBlockStore) GetQuorumCertificate(blockNumber uint64) (*types.QuorumCertificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if qc, ok := s.qcs[blockNumber]; ok {
+		return qc, nil
+	}
+	return nil, &ierrors.NotFoundErr{Message: fmt.Sprintf("quorum certificate not found: %d", blockNumber)}
+}
+
+func (s *fakeBlockStore) CommitQuorumCertificate(qc *types.QuorumCertificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.qcs[qc.BlockNumber] = qc
+	return nil
+}
+
+func (s *fakeBlockStore) certified() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var certified []uint64
+	for n := uint64(1); n <= uint64(len(s.headers)); n++ {
+		if _, ok := s.qcs[n]; ok {
+			certified = append(certified, n)
+		}
+	}
+	return certified
+}
+
+type fakeConfigReader struct {
+	clusterConfig *types.ClusterConfig
+}
+
+func (r *fakeConfigReader) GetConfig() (*types.ClusterConfig, *types.Metadata, error) {
+	return r.clusterConfig, nil, nil
+}
+
+// fakePuller pulls the attestations from the block stores of the members, by Raft ID
+type fakePuller struct {
+	mu          sync.Mutex
+	stores      map[uint64]*fakeBlockStore
+	unreachable map[uint64]bool
+}
+
+func (p *fakePuller) PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.unreachable[targetID] {
+		return nil, errors.Errorf("member [%d] is unreachable", targetID)
+	}
+	return p.stores[targetID].GetAttestations(start, end)
+}
+
+type testEnv struct {
+	clusterConfig *types.ClusterConfig
+	signers       map[string]crypto.Signer
+	stores        map[string]*fakeBlockStore
+	puller        *fakePuller
+	logger        *logger.SugarLogger
+}
+
+// newTestEnv creates a cluster of 3 members and an observer, with a block store of `height` blocks each
+func newTestEnv(t *testing.T, height uint64) *testEnv {
+	names := []string{"node1", "node2", "node3", "observer"}
+	cryptoDir := testutils.GenerateTestCrypto(t, names)
+
+	env := &testEnv{
+		clusterConfig: &types.ClusterConfig{ConsensusConfig: &types.ConsensusConfig{}},
+		signers:       make(map[string]crypto.Signer),
+		stores:        make(map[string]*fakeBlockStore),
+		puller:        &fakePuller{stores: make(map[uint64]*fakeBlockStore), unreachable: make(map[uint64]bool)},
+	}
+	for i, name := range names {
+		cert, signer := testutils.LoadTestCrypto(t, cryptoDir, name)
+		env.signers[name] = signer
+		env.stores[name] = newFakeBlockStore(height, 0)
+		env.puller.stores[uint64(i+1)] = env.stores[name]
+		env.clusterConfig.Nodes = append(env.clusterConfig.Nodes, &types.NodeConfig{Id: name, Certificate: cert.Raw})
+		peer := &types.PeerConfig{NodeId: name, RaftId: uint64(i + 1)}
+		if name == "observer" {
+			env.clusterConfig.ConsensusConfig.Observers = append(env.clusterConfig.ConsensusConfig.Observers, peer)
+		} else {
+			env.clusterConfig.ConsensusConfig.Members = append(env.clusterConfig.ConsensusConfig.Members, peer)
+		}
+	}
+
+	var err error
+	env.logger, err = logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	return env
+}
+
+func (e *testEnv) newAttestor(nodeID string, window uint64) *Attestor {
+	return New(&Config{
+		NodeID:       nodeID,
+		Signer:       e.signers[nodeID],
+		BlockStore:   e.stores[nodeID],
+		ConfigReader: &fakeConfigReader{clusterConfig: e.clusterConfig},
+		Puller:       e.puller,
+		Interval:     10 * time.Millisecond,
+		Window:       window,
+		Logger:       e.logger,
+	})
+}
+
+func (e *testEnv) requireCertified(t *testing.T, nodeID string, expected ...uint64) {
+	store := e.stores[nodeID]
+	require.Equal(t, expected, store.certified())
+	for _, n := range expected {
+		qc, err := store.GetQuorumCertificate(n)
+		require.NoError(t, err)
+		header, err := store.GetHeader(n)
+		require.NoError(t, err)
+		require.NoError(t, attestation.VerifyQuorumCertificate(qc, header, e.clusterConfig))
+	}
+}
+
+func TestAttestor_Attest(t *testing.T) {
+	t.Run("quorum", func(t *testing.T) {
+		env := newTestEnv(t, 3)
+		a1 := env.newAttestor("node1", 0)
+		a2 := env.newAttestor("node2", 0)
+
+		// node1 alone is not a quorum
+		require.NoError(t, a1.Attest())
+		require.Len(t, env.stores["node1"].attestations, 3)
+		env.requireCertified(t, "node1")
+
+		// node2 pulls the attestations of node1
+		require.NoError(t, a2.Attest())
+		env.requireCertified(t, "node2", 1, 2, 3)
+
+		// node1 pulls the attestations of node2
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 2, 3)
+
+		// new blocks are attested as well
+		env.stores["node1"].append(5, 0)
+		env.stores["node2"].append(5, 0)
+		require.NoError(t, a1.Attest())
+		require.NoError(t, a2.Attest())
+		env.requireCertified(t, "node2", 1, 2, 3, 4, 5)
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 2, 3, 4, 5)
+		require.Len(t, env.stores["node3"].attestations, 0)
+	})
+
+	t.Run("observer", func(t *testing.T) {
+		env := newTestEnv(t, 3)
+		a1 := env.newAttestor("node1", 0)
+		a3 := env.newAttestor("node3", 0)
+		ao := env.newAttestor("observer", 0)
+
+		// the observer does not attest, but collects the attestations of the members
+		require.NoError(t, ao.Attest())
+		require.Len(t, env.stores["observer"].attestations, 0)
+		require.NoError(t, a1.Attest())
+		require.NoError(t, a3.Attest())
+		require.NoError(t, ao.Attest())
+		env.requireCertified(t, "observer", 1, 2, 3)
+	})
+
+	t.Run("forked header", func(t *testing.T) {
+		env := newTestEnv(t, 0)
+		env.stores["node2"].append(3, 2)
+		for _, name := range []string{"node1", "node3"} {
+			env.stores[name].append(3, 0)
+		}
+		a1 := env.newAttestor("node1", 0)
+		a2 := env.newAttestor("node2", 0)
+
+		// the attestation of node2 on block 2 does not match the header of node1
+		require.NoError(t, a2.Attest())
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 3)
+
+		// node3 completes the quorum on block 2
+		require.NoError(t, env.newAttestor("node3", 0).Attest())
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 2, 3)
+		qc, err := env.stores["node1"].GetQuorumCertificate(2)
+		require.NoError(t, err)
+		require.Len(t, qc.Attestations, 2)
+		require.Equal(t, "node1", qc.Attestations[0].NodeId)
+		require.Equal(t, "node3", qc.Attestations[1].NodeId)
+	})
+
+	t.Run("unreachable member", func(t *testing.T) {
+		env := newTestEnv(t, 2)
+		a1 := env.newAttestor("node1", 0)
+		require.NoError(t, env.newAttestor("node2", 0).Attest())
+		env.puller.unreachable[2] = true
+
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1")
+
+		env.puller.unreachable[2] = false
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 2)
+	})
+
+	t.Run("window", func(t *testing.T) {
+		env := newTestEnv(t, 5)
+		a1 := env.newAttestor("node1", 2)
+		a2 := env.newAttestor("node2", 2)
+
+		require.NoError(t, a1.Attest())
+		require.NoError(t, a2.Attest())
+		env.requireCertified(t, "node2", 4, 5)
+		require.Len(t, env.stores["node1"].attestations, 2)
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		env := newTestEnv(t, 3)
+		require.NoError(t, env.newAttestor("node1", 0).Attest())
+		attestations, err := env.stores["node1"].GetAttestations(1, 3)
+		require.NoError(t, err)
+
+		// the restarted attestor does not sign the blocks again
+		a1 := env.newAttestor("node1", 0)
+		require.NoError(t, env.newAttestor("node2", 0).Attest())
+		require.NoError(t, a1.Attest())
+		env.requireCertified(t, "node1", 1, 2, 3)
+		for _, a := range attestations {
+			stored, err := env.stores["node1"].GetAttestation(a.BlockNumber)
+			require.NoError(t, err)
+			require.Same(t, a, stored)
+		}
+	})
+
+	t.Run("empty ledger", func(t *testing.T) {
+		env := newTestEnv(t, 0)
+		require.NoError(t, env.newAttestor("node1", 0).Attest())
+		env.requireCertified(t, "node1")
+	})
+}
+
+func TestAttestor_StartStop(t *testing.T) {
+	env := newTestEnv(t, 3)
+
+	var attestors []*Attestor
+	for _, name := range []string{"node1", "node2", "node3", "observer"} {
+		a := env.newAttestor(name, 0)
+		go a.Start()
+		a.WaitTillStart()
+		attestors = append(attestors, a)
+	}
+
+	for _, name := range []string{"node1", "node2", "node3", "observer"} {
+		require.Eventually(t, func() bool {
+			return len(env.stores[name].certified()) == 3
+		}, 10*time.Second, 10*time.Millisecond)
+		env.requireCertified(t, name, 1, 2, 3)
+	}
+
+	for _, a := range attestors {
+		a.Stop()
+	}
+}
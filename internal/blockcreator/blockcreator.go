@@ -114,6 +114,13 @@ func (b *BlockCreator) Start() {
 					len(batch.DataTxEnvelopes.Envelopes),
 				)
 
+			case *types.Block_ProcedureTxEnvelopes:
+				block.Payload = batch
+				b.logger.Debugf("created block %d with %d procedure transactions\n",
+					blkNum,
+					len(batch.ProcedureTxEnvelopes.Envelopes),
+				)
+
 			case *types.Block_UserAdministrationTxEnvelope:
 				block.Payload = batch
 				b.logger.Debugf("created block %d with an user administrative transaction", blkNum)
@@ -125,6 +132,10 @@ func (b *BlockCreator) Start() {
 			case *types.Block_DbAdministrationTxEnvelope:
 				block.Payload = batch
 				b.logger.Debugf("created block %d with a DB administrative transaction", blkNum)
+
+			case *types.Block_ExpiryTxEnvelope:
+				block.Payload = batch
+				b.logger.Debugf("created block %d with an expiry transaction of %d keys", blkNum, len(batch.ExpiryTxEnvelope.GetPayload().GetExpiredKeys()))
 			}
 
 			err := b.blockReplicator.Submit(block)
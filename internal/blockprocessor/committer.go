@@ -7,9 +7,11 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/expiry"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -22,6 +24,7 @@ const (
 	userAdminTxIndex = 0
 	dbAdminTxIndex   = 0
 	configTxIndex    = 0
+	expiryTxIndex    = 0
 )
 
 type committer struct {
@@ -94,6 +97,23 @@ func (c *committer) commitToDBs(dbsUpdates map[string]*worldstate.DBUpdates, pro
 		return errors.WithMessagef(err, "error while committing block %d to the block store", blockNum)
 	}
 
+	return c.commitToWorldState(dbsUpdates, block)
+}
+
+func (c *committer) commitToWorldState(dbsUpdates map[string]*worldstate.DBUpdates, block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	quotaUpdates, err := c.constructQuotaUsageEntries(block)
+	if err != nil {
+		return errors.WithMessagef(err, "error while constructing quota usage entries for block %d", blockNum)
+	}
+	if quotaUpdates != nil {
+		if dbsUpdates == nil {
+			dbsUpdates = make(map[string]*worldstate.DBUpdates)
+		}
+		dbsUpdates[worldstate.QuotasDBName] = quotaUpdates
+	}
+
 	return c.commitToStateDB(blockNum, dbsUpdates)
 }
 
@@ -111,10 +131,18 @@ func (c *committer) commitToStateDB(blockNum uint64, dbsUpdates map[string]*worl
 		return errors.WithMessage(err, "failed to create index updates")
 	}
 
+	expiryUpdates, err := expiry.ConstructIndexEntries(dbsUpdates, c.db)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create expiry index updates")
+	}
+
 	for indexDB, updates := range indexUpdates {
 		// note that dbsUpdates will not contain any existing indexDB entries
 		dbsUpdates[indexDB] = updates
 	}
+	if expiryUpdates != nil {
+		dbsUpdates[worldstate.ExpiryDBName] = expiryUpdates
+	}
 
 	if err := c.db.Commit(dbsUpdates, blockNum); err != nil {
 		return errors.WithMessagef(err, "failed to commit block %d to state database", blockNum)
@@ -127,6 +155,8 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 	dbsUpdates := make(map[string]*worldstate.DBUpdates)
 	var provenanceData []*provenance.TxDataForProvenance
 	blockValidationInfo := block.Header.ValidationInfo
+	// the expiry of keys written with a TTL is relative to the timestamp of the block
+	blockTimestamp := block.GetHeader().GetBaseHeader().GetTimestamp()
 
 	c.logger.Debugf("committing to the state changes from the block number %d", block.GetHeader().GetBaseHeader().GetNumber())
 	switch block.Payload.(type) {
@@ -152,18 +182,59 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 
 			tx := txsEnvelopes[txNum].Payload
 
-			pData, err := constructProvenanceEntriesForDataTx(c.db, tx, version)
+			pData, err := constructProvenanceEntriesForDataTx(c.db, tx, version, blockTimestamp)
 			if err != nil {
 				return nil, nil, err
 			}
 			provenanceData = append(provenanceData, pData...)
 
-			AddDBEntriesForDataTx(tx, version, dbsUpdates)
+			AddDBEntriesForDataTx(tx, version, blockTimestamp, dbsUpdates)
 		}
 		c.logger.Debugf("constructed %d, updates for data transactions, block number %d",
 			len(blockValidationInfo),
 			block.GetHeader().GetBaseHeader().GetNumber())
 
+	case *types.Block_ProcedureTxEnvelopes:
+		txsEnvelopes := block.GetProcedureTxEnvelopes().Envelopes
+		procedureResults := block.GetHeader().GetProcedureResults()
+
+		for txNum, txValidationInfo := range blockValidationInfo {
+			if txValidationInfo.Flag != types.Flag_VALID {
+				provenanceData = append(
+					provenanceData,
+					&provenance.TxDataForProvenance{
+						IsValid: false,
+						TxID:    txsEnvelopes[txNum].Payload.TxId,
+					},
+				)
+				continue
+			}
+
+			version := &types.Version{
+				BlockNum: block.GetHeader().GetBaseHeader().GetNumber(),
+				TxNum:    uint64(txNum),
+			}
+
+			// the operation produced by the stored procedure during the validation
+			// is committed in the same way as the operation of a data transaction
+			tx := &types.DataTx{
+				MustSignUserIds: txsEnvelopes[txNum].Payload.MustSignUserIds,
+				TxId:            txsEnvelopes[txNum].Payload.TxId,
+				DbOperations:    []*types.DBOperation{procedureResults[txNum]},
+			}
+
+			pData, err := constructProvenanceEntriesForDataTx(c.db, tx, version, blockTimestamp)
+			if err != nil {
+				return nil, nil, err
+			}
+			provenanceData = append(provenanceData, pData...)
+
+			AddDBEntriesForDataTx(tx, version, blockTimestamp, dbsUpdates)
+		}
+		c.logger.Debugf("constructed %d, updates for procedure transactions, block number %d",
+			len(blockValidationInfo),
+			block.GetHeader().GetBaseHeader().GetNumber())
+
 	case *types.Block_UserAdministrationTxEnvelope:
 		if blockValidationInfo[userAdminTxIndex].Flag != types.Flag_VALID {
 			return nil, []*provenance.TxDataForProvenance{
@@ -214,6 +285,35 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 		c.logger.Debugf("constructed db admin update, block number %d",
 			block.GetHeader().GetBaseHeader().GetNumber())
 
+	case *types.Block_ExpiryTxEnvelope:
+		if blockValidationInfo[expiryTxIndex].Flag != types.Flag_VALID {
+			return nil, []*provenance.TxDataForProvenance{
+				{
+					IsValid: false,
+					TxID:    block.GetExpiryTxEnvelope().GetPayload().GetTxId(),
+				},
+			}, nil
+		}
+
+		version := &types.Version{
+			BlockNum: block.GetHeader().GetBaseHeader().GetNumber(),
+			TxNum:    expiryTxIndex,
+		}
+
+		// the expired keys are deleted in the same way as the keys deleted by
+		// a data transaction so that the deletion is recorded in the provenance
+		// store along with the node which proposed the expiry
+		tx := dataTxForExpiryTx(block.GetExpiryTxEnvelope().GetPayload())
+		pData, err := constructProvenanceEntriesForDataTx(c.db, tx, version, blockTimestamp)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while creating provenance entries for the expiry transaction")
+		}
+		provenanceData = append(provenanceData, pData...)
+
+		AddDBEntriesForDataTx(tx, version, blockTimestamp, dbsUpdates)
+		c.logger.Debugf("constructed expiry update, block number %d",
+			block.GetHeader().GetBaseHeader().GetNumber())
+
 	case *types.Block_ConfigTxEnvelope:
 		if blockValidationInfo[configTxIndex].Flag != types.Flag_VALID {
 			return nil, []*provenance.TxDataForProvenance{
@@ -261,6 +361,111 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 	return dbsUpdates, provenanceData, nil
 }
 
+// constructQuotaUsageEntries constructs the entries which apply the usage change caused by
+// the valid transactions in the block. Similar to the index entries, the usage entries are
+// derived from the committed state and hence, they are not part of the state trie. Only the usage
+// of the databases and users with a quota is tracked. When a quota is defined on a database, its
+// usage is computed from the committed keys, and when the quota is removed, so is the usage. As
+// the keys are not indexed by the owner, the quota of a user applies to the keys written after
+// the quota has been defined.
+func (c *committer) constructQuotaUsageEntries(block *types.Block) (*worldstate.DBUpdates, error) {
+	usage := quota.NewDelta()
+	blockValidationInfo := block.GetHeader().GetValidationInfo()
+
+	addDelta := func(userID string, ops []*types.DBOperation) error {
+		delta, err := quota.ComputeDelta(c.db, userID, ops)
+		if err != nil {
+			return errors.WithMessage(err, "error while computing the quota usage")
+		}
+		usage.Merge(delta)
+		return nil
+	}
+
+	switch block.Payload.(type) {
+	case *types.Block_DataTxEnvelopes:
+		for txNum, txEnv := range block.GetDataTxEnvelopes().Envelopes {
+			if blockValidationInfo[txNum].Flag != types.Flag_VALID {
+				continue
+			}
+			if err := addDelta(txEnv.Payload.MustSignUserIds[0], txEnv.Payload.DbOperations); err != nil {
+				return nil, err
+			}
+		}
+
+	case *types.Block_ProcedureTxEnvelopes:
+		procedureResults := block.GetHeader().GetProcedureResults()
+		for txNum, txEnv := range block.GetProcedureTxEnvelopes().Envelopes {
+			if blockValidationInfo[txNum].Flag != types.Flag_VALID {
+				continue
+			}
+			if err := addDelta(txEnv.Payload.MustSignUserIds[0], []*types.DBOperation{procedureResults[txNum]}); err != nil {
+				return nil, err
+			}
+		}
+
+	case *types.Block_ExpiryTxEnvelope:
+		if blockValidationInfo[expiryTxIndex].Flag != types.Flag_VALID {
+			return nil, nil
+		}
+		tx := dataTxForExpiryTx(block.GetExpiryTxEnvelope().GetPayload())
+		if err := addDelta(tx.MustSignUserIds[0], tx.DbOperations); err != nil {
+			return nil, err
+		}
+
+	case *types.Block_DbAdministrationTxEnvelope:
+		if blockValidationInfo[dbAdminTxIndex].Flag != types.Flag_VALID {
+			return nil, nil
+		}
+		tx := block.GetDbAdministrationTxEnvelope().GetPayload()
+		for dbName, dbQuota := range tx.GetDbsQuota() {
+			committedQuota, err := quota.GetDBQuota(c.db, dbName)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "error while fetching the quota of the database [%s]", dbName)
+			}
+
+			switch {
+			case quota.IsTracked(dbQuota) && !quota.IsTracked(committedQuota):
+				if !c.db.Exist(dbName) {
+					// the database is created by the transaction and holds no keys
+					continue
+				}
+				dbUsage, err := quota.ComputeDBUsage(c.db, dbName)
+				if err != nil {
+					return nil, errors.WithMessagef(err, "error while computing the quota usage of the database [%s]", dbName)
+				}
+				usage.DBs[dbName] = dbUsage
+			case !quota.IsTracked(dbQuota) && quota.IsTracked(committedQuota):
+				usage.DBs[dbName] = nil
+			}
+		}
+
+		for _, dbName := range tx.GetDeleteDbs() {
+			delta, err := quota.ComputeDeltaForDeletedDB(c.db, dbName)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "error while computing the quota usage of the deleted database [%s]", dbName)
+			}
+			usage.Merge(delta)
+		}
+
+	default:
+		return nil, nil
+	}
+
+	version := &types.Version{
+		BlockNum: block.GetHeader().GetBaseHeader().GetNumber(),
+		TxNum:    uint64(len(blockValidationInfo)),
+	}
+	updates, err := quota.ConstructDBEntries(c.db, usage, version)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while constructing the quota usage entries")
+	}
+	if len(updates.Writes) == 0 && len(updates.Deletes) == 0 {
+		return nil, nil
+	}
+
+	return updates, nil
+}
+
 func (c *committer) applyBlockOnStateTrie(worldStateUpdates map[string]*worldstate.DBUpdates) error {
 	return ApplyBlockOnStateTrie(c.stateTrie, worldStateUpdates)
 }
@@ -297,7 +502,7 @@ func ApplyBlockOnStateTrie(trie *mptrie.MPTrie, worldStateUpdates map[string]*wo
 	return nil
 }
 
-func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates map[string]*worldstate.DBUpdates) {
+func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, blockTimestamp int64, dbsUpdates map[string]*worldstate.DBUpdates) {
 	for _, ops := range tx.DbOperations {
 		updates, ok := dbsUpdates[ops.DbName]
 		if !ok {
@@ -312,6 +517,7 @@ func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates
 				Metadata: &types.Metadata{
 					Version:       version,
 					AccessControl: write.Acl,
+					Expiry:        expiry.FromTTL(write.Ttl, version, blockTimestamp),
 				},
 			}
 			updates.Writes = append(updates.Writes, kv)
@@ -323,6 +529,31 @@ func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates
 	}
 }
 
+// dataTxForExpiryTx returns a data transaction which deletes the keys expired
+// by the given expiry transaction. The proposing node is recorded as the
+// submitter of the transaction.
+func dataTxForExpiryTx(tx *types.ExpiryTx) *types.DataTx {
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{tx.NodeId},
+		TxId:            tx.TxId,
+	}
+
+	opsIndex := make(map[string]*types.DBOperation)
+	for _, k := range tx.ExpiredKeys {
+		op, ok := opsIndex[k.DbName]
+		if !ok {
+			op = &types.DBOperation{
+				DbName: k.DbName,
+			}
+			opsIndex[k.DbName] = op
+			dataTx.DbOperations = append(dataTx.DbOperations, op)
+		}
+		op.DataDeletes = append(op.DataDeletes, &types.DataDelete{Key: k.Key})
+	}
+
+	return dataTx
+}
+
 func constructDBEntriesForDBAdminTx(tx *types.DBAdministrationTx, version *types.Version, db worldstate.DB) (*worldstate.DBUpdates, error) {
 	var indexForExistingDBs []*worldstate.KVWithMetadata
 
@@ -336,9 +567,31 @@ func constructDBEntriesForDBAdminTx(tx *types.DBAdministrationTx, version *types
 		return nil, err
 	}
 
+	schemaUpdates, toDeleteSchemas, err := createEntriesForSchemaUpdates(tx.DbsSchema, tx.DeleteDbs, db, version)
+	if err != nil {
+		return nil, err
+	}
+
+	procedureUpdates, toDeleteProcedures, err := createEntriesForProcedureUpdates(tx.DbsProcedures, tx.DeleteDbs, db, version)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaUpdates, toDeleteQuotas, err := createEntriesForQuotaUpdates(tx.DbsQuota, tx.DeleteDbs, db, version)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := append(toCreateDBs, indexForExistingDBs...)
+	writes = append(writes, schemaUpdates...)
+	writes = append(writes, procedureUpdates...)
+	deletes := append(tx.DeleteDbs, toDeleteIndexDBs...)
+	deletes = append(deletes, toDeleteSchemas...)
+	deletes = append(deletes, toDeleteProcedures...)
+
 	return &worldstate.DBUpdates{
-		Writes:  append(toCreateDBs, indexForExistingDBs...),
-		Deletes: append(tx.DeleteDbs, toDeleteIndexDBs...),
+		Writes:  append(writes, quotaUpdates...),
+		Deletes: append(deletes, toDeleteQuotas...),
 	}, nil
 }
 
@@ -432,6 +685,155 @@ func createEntriesForIndexUpdates(
 	return indexForExistingDBs, toDeleteDBs, nil
 }
 
+func createEntriesForSchemaUpdates(
+	dbsSchema map[string]*types.DBSchema,
+	toDeleteDBs []string,
+	db worldstate.DB,
+	version *types.Version,
+) ([]*worldstate.KVWithMetadata, []string, error) {
+	var schemaUpdates []*worldstate.KVWithMetadata
+	var toDeleteSchemas []string
+
+	for dbName, dbSchema := range dbsSchema {
+		if dbSchema.GetJsonSchema() != "" {
+			schemaUpdates = append(schemaUpdates, &worldstate.KVWithMetadata{
+				Key:   worldstate.SchemaKey(dbName),
+				Value: []byte(dbSchema.GetJsonSchema()),
+				Metadata: &types.Metadata{
+					Version: version,
+				},
+			})
+			continue
+		}
+
+		schemaExist, err := db.Has(worldstate.DatabasesDBName, worldstate.SchemaKey(dbName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if schemaExist {
+			toDeleteSchemas = append(toDeleteSchemas, worldstate.SchemaKey(dbName))
+		}
+	}
+
+	// the schema of a deleted database must not be applied
+	// to a database created later with the same name
+	for _, dbName := range toDeleteDBs {
+		schemaExist, err := db.Has(worldstate.DatabasesDBName, worldstate.SchemaKey(dbName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if schemaExist {
+			toDeleteSchemas = append(toDeleteSchemas, worldstate.SchemaKey(dbName))
+		}
+	}
+
+	return schemaUpdates, toDeleteSchemas, nil
+}
+
+func createEntriesForProcedureUpdates(
+	dbsProcedures map[string]*types.DBProcedures,
+	toDeleteDBs []string,
+	db worldstate.DB,
+	version *types.Version,
+) ([]*worldstate.KVWithMetadata, []string, error) {
+	var procedureUpdates []*worldstate.KVWithMetadata
+	var toDeleteProcedures []string
+
+	for dbName, dbProcedures := range dbsProcedures {
+		for name, source := range dbProcedures.GetProcedures() {
+			key := worldstate.ProcedureKey(dbName, name)
+			if source != "" {
+				procedureUpdates = append(procedureUpdates, &worldstate.KVWithMetadata{
+					Key:   key,
+					Value: []byte(source),
+					Metadata: &types.Metadata{
+						Version: version,
+					},
+				})
+				continue
+			}
+
+			procedureExist, err := db.Has(worldstate.DatabasesDBName, key)
+			if err != nil {
+				return nil, nil, err
+			}
+			if procedureExist {
+				toDeleteProcedures = append(toDeleteProcedures, key)
+			}
+		}
+	}
+
+	// the stored procedures of a deleted database must not be
+	// available to a database created later with the same name
+	for _, dbName := range toDeleteDBs {
+		startKey, endKey := worldstate.ProcedureKeyRange(dbName)
+		itr, err := db.GetIterator(worldstate.DatabasesDBName, startKey, endKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for itr.Next() {
+			toDeleteProcedures = append(toDeleteProcedures, string(itr.Key()))
+		}
+		err = itr.Error()
+		itr.Release()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return procedureUpdates, toDeleteProcedures, nil
+}
+
+func createEntriesForQuotaUpdates(
+	dbsQuota map[string]*types.Quota,
+	toDeleteDBs []string,
+	db worldstate.DB,
+	version *types.Version,
+) ([]*worldstate.KVWithMetadata, []string, error) {
+	var quotaUpdates []*worldstate.KVWithMetadata
+	var toDeleteQuotas []string
+
+	for dbName, dbQuota := range dbsQuota {
+		if !quota.IsUnlimited(dbQuota) {
+			value, err := proto.Marshal(dbQuota)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "error while marshaling the quota of the database [%s]", dbName)
+			}
+			quotaUpdates = append(quotaUpdates, &worldstate.KVWithMetadata{
+				Key:   worldstate.QuotaKey(dbName),
+				Value: value,
+				Metadata: &types.Metadata{
+					Version: version,
+				},
+			})
+			continue
+		}
+
+		quotaExist, err := db.Has(worldstate.DatabasesDBName, worldstate.QuotaKey(dbName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if quotaExist {
+			toDeleteQuotas = append(toDeleteQuotas, worldstate.QuotaKey(dbName))
+		}
+	}
+
+	// the quota of a deleted database must not be applied
+	// to a database created later with the same name
+	for _, dbName := range toDeleteDBs {
+		quotaExist, err := db.Has(worldstate.DatabasesDBName, worldstate.QuotaKey(dbName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if quotaExist {
+			toDeleteQuotas = append(toDeleteQuotas, worldstate.QuotaKey(dbName))
+		}
+	}
+
+	return quotaUpdates, toDeleteQuotas, nil
+}
+
 type dbEntriesForConfigTx struct {
 	adminUpdates  *worldstate.DBUpdates
 	nodeUpdates   *worldstate.DBUpdates
@@ -458,6 +860,11 @@ func constructDBEntriesForConfigTx(tx *types.ConfigTx, oldConfig *types.ClusterC
 			maxID = id
 		}
 	}
+	for _, o := range newConfigClone.GetConsensusConfig().GetObservers() {
+		if id := o.GetRaftId(); id > maxID {
+			maxID = id
+		}
+	}
 	newConfigClone.ConsensusConfig.RaftConfig.MaxRaftId = maxID
 
 	newConfigSerialized, err := proto.Marshal(newConfigClone)
@@ -484,7 +891,7 @@ func constructDBEntriesForConfigTx(tx *types.ConfigTx, oldConfig *types.ClusterC
 	}, nil
 }
 
-func constructProvenanceEntriesForDataTx(db worldstate.DB, tx *types.DataTx, version *types.Version) ([]*provenance.TxDataForProvenance, error) {
+func constructProvenanceEntriesForDataTx(db worldstate.DB, tx *types.DataTx, version *types.Version, blockTimestamp int64) ([]*provenance.TxDataForProvenance, error) {
 	txpData := make([]*provenance.TxDataForProvenance, len(tx.DbOperations))
 
 	for i, ops := range tx.DbOperations {
@@ -512,6 +919,7 @@ func constructProvenanceEntriesForDataTx(db worldstate.DB, tx *types.DataTx, ver
 				Metadata: &types.Metadata{
 					Version:       version,
 					AccessControl: write.Acl,
+					Expiry:        expiry.FromTTL(write.Ttl, version, blockTimestamp),
 				},
 			}
 			pData.Writes = append(pData.Writes, kv)
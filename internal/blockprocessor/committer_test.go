@@ -12,9 +12,11 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/expiry"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
@@ -1129,6 +1131,600 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 	}
 }
 
+func TestStateDBCommitterForDBBlockWithSchema(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDBs := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+				{
+					Key: "db2",
+				},
+				{
+					Key:   worldstate.SchemaKey("db2"),
+					Value: []byte(`{"type": "string"}`),
+				},
+				{
+					Key: "db3",
+				},
+				{
+					Key:   worldstate.SchemaKey("db3"),
+					Value: []byte(`{"type": "number"}`),
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDBs, 1))
+	require.False(t, env.db.Exist(worldstate.SchemaKey("db2")))
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 2,
+			},
+			ValidationInfo: []*types.ValidationInfo{
+				{
+					Flag: types.Flag_VALID,
+				},
+			},
+		},
+		Payload: &types.Block_DbAdministrationTxEnvelope{
+			DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+				Payload: &types.DBAdministrationTx{
+					CreateDbs: []string{"db4"},
+					DeleteDbs: []string{"db3"},
+					DbsSchema: map[string]*types.DBSchema{
+						"db1": {
+							JsonSchema: `{"type": "object"}`,
+						},
+						"db2": {},
+						"db4": {
+							JsonSchema: `{"type": "array"}`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+	require.NoError(t, err)
+	require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+
+	expectedSchemas := map[string][]byte{
+		"db1": []byte(`{"type": "object"}`),
+		"db2": nil,
+		"db3": nil,
+		"db4": []byte(`{"type": "array"}`),
+	}
+	for dbName, expectedSchema := range expectedSchemas {
+		schema, metadata, err := env.db.GetSchemaDefinition(dbName)
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, schema)
+		if expectedSchema != nil {
+			require.True(t, proto.Equal(&types.Version{BlockNum: 2}, metadata.GetVersion()))
+		}
+	}
+
+	require.True(t, env.db.Exist("db4"))
+	require.False(t, env.db.Exist("db3"))
+	require.False(t, env.db.Exist(worldstate.SchemaKey("db1")))
+	require.False(t, env.db.Exist(worldstate.SchemaKey("db4")))
+}
+
+func TestStateDBCommitterForDBBlockWithProcedures(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDBs := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+				{
+					Key:   worldstate.ProcedureKey("db1", "obsolete"),
+					Value: []byte("def main():\n  pass"),
+				},
+				{
+					Key: "db2",
+				},
+				{
+					Key:   worldstate.ProcedureKey("db2", "p1"),
+					Value: []byte("def main():\n  pass"),
+				},
+				{
+					Key:   worldstate.ProcedureKey("db2", "p2"),
+					Value: []byte("def main():\n  pass"),
+				},
+				{
+					Key: "db22",
+				},
+				{
+					Key:   worldstate.ProcedureKey("db22", "p1"),
+					Value: []byte("def main():\n  pass"),
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDBs, 1))
+	require.False(t, env.db.Exist(worldstate.ProcedureKey("db1", "obsolete")))
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 2,
+			},
+			ValidationInfo: []*types.ValidationInfo{
+				{
+					Flag: types.Flag_VALID,
+				},
+			},
+		},
+		Payload: &types.Block_DbAdministrationTxEnvelope{
+			DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+				Payload: &types.DBAdministrationTx{
+					CreateDbs: []string{"db3"},
+					DeleteDbs: []string{"db2"},
+					DbsProcedures: map[string]*types.DBProcedures{
+						"db1": {
+							Procedures: map[string]string{
+								"obsolete": "",
+								"noop":     "def main():\n  pass",
+							},
+						},
+						"db3": {
+							Procedures: map[string]string{
+								"copy": "def main(a, b):\n  put(b, get(a))",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+	require.NoError(t, err)
+	require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+
+	expectedProcedures := []struct {
+		dbName    string
+		name      string
+		source    []byte
+		committed bool
+	}{
+		{dbName: "db1", name: "obsolete"},
+		{dbName: "db1", name: "noop", source: []byte("def main():\n  pass"), committed: true},
+		{dbName: "db2", name: "p1"},
+		{dbName: "db2", name: "p2"},
+		{dbName: "db22", name: "p1", source: []byte("def main():\n  pass")},
+		{dbName: "db3", name: "copy", source: []byte("def main(a, b):\n  put(b, get(a))"), committed: true},
+	}
+	for _, expected := range expectedProcedures {
+		source, metadata, err := env.db.GetProcedure(expected.dbName, expected.name)
+		require.NoError(t, err)
+		require.Equal(t, expected.source, source)
+		if expected.committed {
+			require.True(t, proto.Equal(&types.Version{BlockNum: 2}, metadata.GetVersion()))
+		}
+	}
+
+	require.True(t, env.db.Exist("db3"))
+	require.False(t, env.db.Exist("db2"))
+	require.False(t, env.db.Exist(worldstate.ProcedureKey("db3", "copy")))
+}
+
+func TestStateDBCommitterForProcedureBlock(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	data := map[string]*worldstate.DBUpdates{
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				constructDataEntryForTest("key1", []byte("value1"), &types.Metadata{
+					Version: &types.Version{BlockNum: 1, TxNum: 0},
+				}),
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(data, 1))
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 2,
+			},
+			ValidationInfo: []*types.ValidationInfo{
+				{
+					Flag:            types.Flag_INVALID_PROCEDURE_EXECUTION,
+					ReasonIfInvalid: "execution of the stored procedure [move] failed",
+				},
+				{
+					Flag: types.Flag_VALID,
+				},
+			},
+			ProcedureResults: []*types.DBOperation{
+				{},
+				{
+					DbName: worldstate.DefaultDBName,
+					DataReads: []*types.DataRead{
+						{
+							Key:     "key1",
+							Version: &types.Version{BlockNum: 1, TxNum: 0},
+						},
+					},
+					DataWrites: []*types.DataWrite{
+						{
+							Key:   "key2",
+							Value: []byte("value1"),
+						},
+					},
+					DataDeletes: []*types.DataDelete{
+						{
+							Key: "key1",
+						},
+					},
+				},
+			},
+		},
+		Payload: &types.Block_ProcedureTxEnvelopes{
+			ProcedureTxEnvelopes: &types.ProcedureTxEnvelopes{
+				Envelopes: []*types.ProcedureTxEnvelope{
+					{
+						Payload: &types.ProcedureTx{
+							MustSignUserIds: []string{"testUser"},
+							TxId:            "tx1",
+							DbName:          worldstate.DefaultDBName,
+							ProcedureName:   "move",
+							Args:            []string{"key3", "key4"},
+						},
+					},
+					{
+						Payload: &types.ProcedureTx{
+							MustSignUserIds: []string{"testUser"},
+							TxId:            "tx2",
+							DbName:          worldstate.DefaultDBName,
+							ProcedureName:   "move",
+							Args:            []string{"key1", "key2"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+	require.NoError(t, err)
+	require.Len(t, provenanceData, 2)
+	require.False(t, provenanceData[0].IsValid)
+	require.Equal(t, "tx1", provenanceData[0].TxID)
+	require.True(t, provenanceData[1].IsValid)
+	require.Equal(t, "tx2", provenanceData[1].TxID)
+	require.Equal(t, "testUser", provenanceData[1].UserID)
+	require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+
+	val, metadata, err := env.db.Get(worldstate.DefaultDBName, "key2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+	require.True(t, proto.Equal(&types.Version{BlockNum: 2, TxNum: 1}, metadata.GetVersion()))
+
+	exist, err := env.db.Has(worldstate.DefaultDBName, "key1")
+	require.NoError(t, err)
+	require.False(t, exist)
+}
+
+func TestStateDBCommitterForQuotaUsage(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	userWithQuota := func(userID string, q *types.Quota) []byte {
+		user, err := proto.Marshal(&types.User{
+			Id: userID,
+			Privilege: &types.Privilege{
+				Quota: q,
+			},
+		})
+		require.NoError(t, err)
+		return user
+	}
+	dbQuota, err := proto.Marshal(&types.Quota{MaxKeys: 10})
+	require.NoError(t, err)
+
+	// carol has no quota and hence, the usage of carol is not tracked
+	createDB := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+				{
+					Key:   worldstate.QuotaKey("db1"),
+					Value: dbQuota,
+				},
+			},
+		},
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: string(identity.UserNamespace) + "alice", Value: userWithQuota("alice", &types.Quota{MaxKeys: 10})},
+				{Key: string(identity.UserNamespace) + "bob", Value: userWithQuota("bob", &types.Quota{MaxTotalValueBytes: 100})},
+				{Key: string(identity.UserNamespace) + "carol", Value: userWithQuota("carol", nil)},
+			},
+		},
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("abcd"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDB, 1))
+
+	dataBlock := func(number uint64, userID string, op *types.DBOperation) *types.Block {
+		return &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: number,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{userID},
+								TxId:            fmt.Sprintf("tx%d", number),
+								DbOperations:    []*types.DBOperation{op},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	commit := func(block *types.Block) {
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
+
+	requireUsage := func(usageKey string, expected *types.QuotaUsage) {
+		usage, err := quota.GetUsage(env.db, usageKey)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, usage), "usage of [%s]: %v", usageKey, usage)
+	}
+
+	requireOwner := func(key, expected string) {
+		owner, _, err := env.db.Get(worldstate.QuotasDBName, quota.OwnerKey("db1", key))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(owner))
+	}
+
+	commit(dataBlock(2, "alice", &types.DBOperation{
+		DbName: "db1",
+		DataWrites: []*types.DataWrite{
+			{Key: "key1", Value: []byte("abc")},
+			{Key: "key2", Value: []byte("de")},
+		},
+	}))
+	requireUsage(quota.DBUsageKey("db1"), &types.QuotaUsage{Keys: 2, TotalValueBytes: 5})
+	requireUsage(quota.UserUsageKey("alice"), &types.QuotaUsage{Keys: 2, TotalValueBytes: 5})
+	requireOwner("key1", "alice")
+	requireOwner("key2", "alice")
+
+	commit(dataBlock(3, "bob", &types.DBOperation{
+		DbName:      "db1",
+		DataWrites:  []*types.DataWrite{{Key: "key1", Value: []byte("x")}},
+		DataDeletes: []*types.DataDelete{{Key: "key2"}},
+	}))
+	requireUsage(quota.DBUsageKey("db1"), &types.QuotaUsage{Keys: 1, TotalValueBytes: 1})
+	requireUsage(quota.UserUsageKey("alice"), &types.QuotaUsage{})
+	requireUsage(quota.UserUsageKey("bob"), &types.QuotaUsage{Keys: 1, TotalValueBytes: 1})
+	requireOwner("key1", "bob")
+	requireOwner("key2", "")
+
+	commit(dataBlock(4, "carol", &types.DBOperation{
+		DbName:     "db1",
+		DataWrites: []*types.DataWrite{{Key: "key2", Value: []byte("xy")}},
+	}))
+	requireUsage(quota.DBUsageKey("db1"), &types.QuotaUsage{Keys: 2, TotalValueBytes: 3})
+	requireOwner("key2", "")
+	requireNoUsage := func(usageKey string) {
+		exist, err := env.db.Has(worldstate.QuotasDBName, usageKey)
+		require.NoError(t, err)
+		require.False(t, exist)
+	}
+	requireNoUsage(quota.UserUsageKey("carol"))
+
+	dbAdminBlock := func(number uint64, tx *types.DBAdministrationTx) *types.Block {
+		return &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: number,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DbAdministrationTxEnvelope{
+				DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+					Payload: tx,
+				},
+			},
+		}
+	}
+
+	// the usage of a database is computed from its keys once a quota is
+	// defined on it and is removed along with the quota
+	commit(dbAdminBlock(5, &types.DBAdministrationTx{
+		UserId: "admin",
+		DbsQuota: map[string]*types.Quota{
+			"db1":                    {},
+			worldstate.DefaultDBName: {MaxKeys: 100},
+		},
+	}))
+	requireNoUsage(quota.DBUsageKey("db1"))
+	requireUsage(quota.DBUsageKey(worldstate.DefaultDBName), &types.QuotaUsage{Keys: 1, TotalValueBytes: 4})
+
+	commit(dbAdminBlock(6, &types.DBAdministrationTx{
+		UserId:    "admin",
+		DeleteDbs: []string{"db1"},
+	}))
+	requireUsage(quota.UserUsageKey("bob"), &types.QuotaUsage{})
+	requireOwner("key1", "")
+	requireNoUsage(quota.DBUsageKey("db1"))
+
+	committedQuota, err := quota.GetDBQuota(env.db, worldstate.DefaultDBName)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.Quota{MaxKeys: 100}, committedQuota))
+}
+
+func TestStateDBCommitterForExpiry(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDB := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDB, 1))
+
+	commit := func(block *types.Block) {
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
+
+	dataBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:    2,
+				Timestamp: 900,
+			},
+			ValidationInfo: []*types.ValidationInfo{
+				{
+					Flag: types.Flag_VALID,
+				},
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"alice"},
+							TxId:            "tx1",
+							DbOperations: []*types.DBOperation{
+								{
+									DbName: "db1",
+									DataWrites: []*types.DataWrite{
+										{Key: "key1", Value: []byte("value1"), Ttl: &types.TTL{Blocks: 2}},
+										{Key: "key2", Value: []byte("value2"), Ttl: &types.TTL{Seconds: 100}},
+										{Key: "key3", Value: []byte("value3")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	commit(dataBlock)
+
+	version := &types.Version{BlockNum: 2, TxNum: 0}
+	_, metadata, err := env.db.Get("db1", "key1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.Metadata{Version: version, Expiry: &types.Expiry{BlockNum: 4}}, metadata))
+	// the expiry in seconds is relative to the timestamp of the block which writes the key
+	_, metadata, err = env.db.Get("db1", "key2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.Metadata{Version: version, Expiry: &types.Expiry{UnixTime: 1000}}, metadata))
+	_, metadata, err = env.db.Get("db1", "key3")
+	require.NoError(t, err)
+	require.Nil(t, metadata.GetExpiry())
+
+	expiredKeys, err := expiry.ExpiredKeys(env.db, 4, 1000, 10)
+	require.NoError(t, err)
+	require.Len(t, expiredKeys, 2)
+
+	expiryBlock := func(validationInfo *types.ValidationInfo) *types.Block {
+		return &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:    4,
+					Timestamp: 1000,
+				},
+				ValidationInfo: []*types.ValidationInfo{validationInfo},
+			},
+			Payload: &types.Block_ExpiryTxEnvelope{
+				ExpiryTxEnvelope: &types.ExpiryTxEnvelope{
+					Payload: &types.ExpiryTx{
+						TxId:        "tx2",
+						NodeId:      "node1",
+						Timestamp:   1000,
+						ExpiredKeys: expiredKeys,
+					},
+				},
+			},
+		}
+	}
+
+	// an invalid expiry transaction does not delete any key
+	commit(expiryBlock(&types.ValidationInfo{Flag: types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE}))
+	exist, err := env.db.Has("db1", "key1")
+	require.NoError(t, err)
+	require.True(t, exist)
+
+	commit(expiryBlock(&types.ValidationInfo{Flag: types.Flag_VALID}))
+	for _, key := range []string{"key1", "key2"} {
+		exist, err := env.db.Has("db1", key)
+		require.NoError(t, err)
+		require.False(t, exist)
+
+		deletedValues, err := env.committer.provenanceStore.GetDeletedValues("db1", key)
+		require.NoError(t, err)
+		require.Len(t, deletedValues, 1)
+	}
+	exist, err = env.db.Has("db1", "key3")
+	require.NoError(t, err)
+	require.True(t, exist)
+
+	expiredKeys, err = expiry.ExpiredKeys(env.db, 100, 2000, 10)
+	require.NoError(t, err)
+	require.Empty(t, expiredKeys)
+	itr, err := env.db.GetIterator(worldstate.ExpiryDBName, "", "")
+	require.NoError(t, err)
+	defer itr.Release()
+	require.False(t, itr.Next())
+}
+
 func TestStateDBCommitterForConfigBlock(t *testing.T) {
 	t.Parallel()
 
@@ -2851,7 +3447,7 @@ func TestConstructProvenanceEntriesForDataTx(t *testing.T) {
 			defer env.cleanup()
 			tt.setup(env.db)
 
-			provenanceData, err := constructProvenanceEntriesForDataTx(env.db, tt.tx, tt.version)
+			provenanceData, err := constructProvenanceEntriesForDataTx(env.db, tt.tx, tt.version, 0)
 			require.NoError(t, err)
 			require.Equal(t, tt.expectedProvenanceData, provenanceData)
 		})
@@ -25,6 +25,7 @@ type BlockProcessor struct {
 	blockStore           *blockstore.Store
 	validator            *txvalidation.Validator
 	committer            *committer
+	commitMutex          sync.Mutex
 	listeners            *blockCommitListeners
 	started              chan struct{}
 	stop                 chan struct{}
@@ -154,7 +155,10 @@ func (b *BlockProcessor) validateAndCommit(block *types.Block) error {
 	}
 	block.Header.TxMerkelTreeRootHash = root.Hash()
 
-	if err = b.committer.commitBlock(block); err != nil {
+	b.commitMutex.Lock()
+	err = b.committer.commitBlock(block)
+	b.commitMutex.Unlock()
+	if err != nil {
 		panic(err)
 	}
 
@@ -167,6 +171,33 @@ func (b *BlockProcessor) WaitTillStart() {
 	<-b.started
 }
 
+// RunExclusively runs f while no block is being committed, e.g., to take a consistent snapshot of all the stores
+func (b *BlockProcessor) RunExclusively(f func() error) error {
+	b.commitMutex.Lock()
+	defer b.commitMutex.Unlock()
+
+	return f()
+}
+
+// ReloadStateTrie reloads the state trie of the last block from the state trie store. It is used after the stores
+// were replaced by a state transfer, and must be called from RunExclusively.
+func (b *BlockProcessor) ReloadStateTrie() error {
+	trieStoreHeight, blockStoreHeight, stateTrie, err := loadStateTrie(b.committer.stateTrieStore, b.blockStore)
+	if err != nil {
+		return err
+	}
+	if trieStoreHeight != blockStoreHeight {
+		return errors.Errorf(
+			"the height of the state trie store [%d] is different from the height of the block store [%d]",
+			trieStoreHeight,
+			blockStoreHeight,
+		)
+	}
+
+	b.committer.stateTrie = stateTrie
+	return nil
+}
+
 // Stop stops the block processor
 func (b *BlockProcessor) Stop() {
 	if err := b.blockOneQueueBarrier.Close(); err != nil {
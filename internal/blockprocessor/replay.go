@@ -0,0 +1,138 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockprocessor
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// replayProgressInterval is the number of blocks between two progress logs of a replay
+const replayProgressInterval = 10000
+
+// ReplayConfig holds the stores a replay of the block store regenerates
+type ReplayConfig struct {
+	BlockStore *blockstore.Store
+	// DB must be empty, as the entries of a block are constructed from the state left by the
+	// preceding blocks
+	DB worldstate.DB
+	// ProvenanceStore, if not nil, must be empty and is filled with the provenance data
+	ProvenanceStore *provenance.Store
+	// StateTrieStore, if not nil, must be empty and is filled with the state trie. The root
+	// of the trie after each block is checked against the state trie root in the block header
+	StateTrieStore mptrie.Store
+	Logger         *logger.SugarLogger
+}
+
+// Replay commits all the blocks of the block store, in order, to the world state, and to the
+// provenance store and the state trie store if they are set. The blocks are not validated again,
+// as the validation info is part of the stored blocks, and are not committed to the block store.
+func Replay(conf *ReplayConfig) error {
+	height, err := conf.BlockStore.Height()
+	if err != nil {
+		return err
+	}
+
+	r, err := NewReplayer(conf)
+	if err != nil {
+		return err
+	}
+
+	for blockNum := uint64(1); blockNum <= height; blockNum++ {
+		block, err := conf.BlockStore.Get(blockNum)
+		if err != nil {
+			return err
+		}
+
+		rootHash, err := r.ReplayBlock(block)
+		if err != nil {
+			return err
+		}
+		if conf.StateTrieStore != nil && !bytes.Equal(rootHash, block.GetHeader().GetStateMerkelTreeRootHash()) {
+			return errors.Errorf("the state trie root hash after replaying block %d does not match the state merkle tree root hash in its header", blockNum)
+		}
+
+		if blockNum%replayProgressInterval == 0 {
+			conf.Logger.Infof("replayed %d of %d blocks", blockNum, height)
+		}
+	}
+
+	return nil
+}
+
+// Replayer commits blocks, one at a time and in order, to the stores of a ReplayConfig. The block
+// store of the config is not used.
+type Replayer struct {
+	c *committer
+}
+
+// NewReplayer creates a replayer whose first block is the genesis block
+func NewReplayer(conf *ReplayConfig) (*Replayer, error) {
+	stateDBHeight, err := conf.DB.Height()
+	if err != nil {
+		return nil, err
+	}
+	if stateDBHeight != 0 {
+		return nil, errors.Errorf("the state database is at block [%d] while a replay starts from an empty state database", stateDBHeight)
+	}
+
+	c := &committer{
+		db:              conf.DB,
+		blockStore:      conf.BlockStore,
+		provenanceStore: conf.ProvenanceStore,
+		stateTrieStore:  conf.StateTrieStore,
+		logger:          conf.Logger,
+	}
+	if c.stateTrieStore != nil {
+		if c.stateTrie, err = mptrie.NewTrie(nil, c.stateTrieStore); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Replayer{c: c}, nil
+}
+
+// ReplayBlock commits the entries of the block, which must follow the last replayed block, and
+// returns the root hash of the state trie after the block, if the state trie store is set. The
+// caller checks the root hash against the state merkle tree root hash in the block header.
+func (r *Replayer) ReplayBlock(block *types.Block) ([]byte, error) {
+	c := r.c
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	dbsUpdates, provenanceData, err := c.constructDBAndProvenanceEntries(block)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while constructing database and provenance entries for block %d", blockNum)
+	}
+
+	var rootHash []byte
+	if c.stateTrie != nil {
+		if err := c.applyBlockOnStateTrie(dbsUpdates); err != nil {
+			return nil, err
+		}
+		if rootHash, err = c.stateTrie.Hash(); err != nil {
+			return nil, err
+		}
+		if err := c.commitTrie(blockNum); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.provenanceStore != nil {
+		if err := c.commitToProvenanceStore(blockNum, provenanceData); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.commitToWorldState(dbsUpdates, block); err != nil {
+		return nil, err
+	}
+
+	return rootHash, nil
+}
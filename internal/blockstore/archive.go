@@ -0,0 +1,483 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// archiveExtensions maps the compression of the archived chunks to the extension of the tar
+// that holds an archived chunk. A chunk archived without compression is a plain copy of the chunk.
+var archiveExtensions = map[string]string{
+	compression.Gzip: ".tar.gz",
+	compression.Zstd: ".tar.zst",
+}
+
+// archiveFormats lists the compressions an archived chunk might be found with
+var archiveFormats = []string{"", compression.Zstd, compression.Gzip}
+
+// archiveFrameSize is the number of bytes of the tar that are compressed independently of the
+// rest, so that a block can be read by decompressing only the frames from the one holding the
+// block. The offsets of the frames in the compressed tar are kept in an index file next to it.
+var archiveFrameSize = int64(1024 * 1024)
+
+const archiveIndexExtension = ".idx"
+
+// archiver moves the sealed block file chunks, i.e., the chunks that precede the chunk holding
+// the last committed block, from the store to the archive directory. A chunk is archived either
+// as is, or in a compressed tar. The archival runs in the background so that it does not delay
+// the commit of blocks.
+type archiver struct {
+	chunksDir   string
+	archiveDir  string
+	compression string
+	// next is the lowest chunk number that is not archived yet,
+	// and is accessed only by the archival go-routine
+	next     uint64
+	limit    uint64
+	limitMu  sync.Mutex
+	signalCh chan struct{}
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	logger   *logger.SugarLogger
+}
+
+func newArchiver(chunksDir, archiveDir, archiveCompression string, next uint64, logger *logger.SugarLogger) (*archiver, error) {
+	if err := fileops.CreateDir(archiveDir); err != nil {
+		return nil, errors.WithMessagef(err, "error while creating the archive directory [%s]", archiveDir)
+	}
+
+	if archiveCompression == compression.None {
+		archiveCompression = ""
+	}
+
+	a := &archiver{
+		chunksDir:   chunksDir,
+		archiveDir:  archiveDir,
+		compression: archiveCompression,
+		next:        next,
+		limit:       next,
+		signalCh:    make(chan struct{}, 1),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+		logger:      logger,
+	}
+	go a.run()
+
+	return a, nil
+}
+
+// seal marks all the chunks preceding the given chunk as sealed, so that they get archived
+func (a *archiver) seal(chunkNum uint64) {
+	a.limitMu.Lock()
+	if chunkNum <= a.limit {
+		a.limitMu.Unlock()
+		return
+	}
+	a.limit = chunkNum
+	a.limitMu.Unlock()
+
+	select {
+	case a.signalCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop stops the archival after the chunk being archived, if any
+func (a *archiver) stop() {
+	close(a.stopCh)
+	<-a.doneCh
+}
+
+func (a *archiver) run() {
+	defer close(a.doneCh)
+
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case <-a.signalCh:
+		}
+
+		a.limitMu.Lock()
+		limit := a.limit
+		a.limitMu.Unlock()
+
+		for ; a.next < limit; a.next++ {
+			select {
+			case <-a.stopCh:
+				return
+			default:
+			}
+
+			if err := a.archive(a.next); err != nil {
+				// the chunk remains in the store and is archived on the next seal
+				a.logger.Errorf("failed to archive the block file chunk [%d]: %s", a.next, err)
+				break
+			}
+			a.logger.Infof("archived the block file chunk [%d] to [%s]", a.next, a.archiveDir)
+		}
+	}
+}
+
+func (a *archiver) archivePath(chunkNum uint64, archiveCompression string) string {
+	return constructArchivePath(a.archiveDir, chunkNum, archiveCompression)
+}
+
+func constructArchivePath(archiveDir string, chunkNum uint64, archiveCompression string) string {
+	return constructBlockFileChunkPath(archiveDir, chunkNum) + archiveExtensions[archiveCompression]
+}
+
+// archive copies the chunk to the archive directory and then removes it from the store. The copy
+// is written to a temporary file, which is renamed once synced, so that a chunk is never removed
+// before it is fully archived.
+func (a *archiver) archive(chunkNum uint64) error {
+	chunkPath := constructBlockFileChunkPath(a.chunksDir, chunkNum)
+	chunk, err := os.Open(chunkPath)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening the file chunk [%s]", chunkPath)
+	}
+	defer chunk.Close()
+
+	chunkInfo, err := chunk.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error while getting the metadata of file [%s]", chunkPath)
+	}
+
+	archivePath := a.archivePath(chunkNum, a.compression)
+	tmpPath := archivePath + ".tmp"
+	archived, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the file [%s]", tmpPath)
+	}
+	defer archived.Close()
+
+	index, err := a.write(archived, chunk, chunkInfo)
+	if err != nil {
+		return errors.WithMessagef(err, "error while writing the file [%s]", tmpPath)
+	}
+	if err := archived.Sync(); err != nil {
+		return errors.Wrapf(err, "error while syncing the file [%s]", tmpPath)
+	}
+	if index != nil {
+		if err := writeArchiveIndex(archivePath+archiveIndexExtension, index); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		return errors.Wrapf(err, "error while renaming the file [%s] to [%s]", tmpPath, archivePath)
+	}
+	// a stale archive of the chunk in another format, e.g., left by a store that was replaced,
+	// would otherwise shadow this archive on read
+	for _, archiveCompression := range archiveFormats {
+		if archiveCompression == a.compression {
+			continue
+		}
+		stalePath := a.archivePath(chunkNum, archiveCompression)
+		for _, path := range []string{stalePath, stalePath + archiveIndexExtension} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "error while removing the stale archive [%s]", path)
+			}
+		}
+	}
+	if err := fileops.SyncDir(a.archiveDir); err != nil {
+		return err
+	}
+
+	if err := os.Remove(chunkPath); err != nil {
+		return errors.Wrapf(err, "error while removing the archived file chunk [%s]", chunkPath)
+	}
+	return fileops.SyncDir(a.chunksDir)
+}
+
+// write writes the chunk either as is, or as a compressed tar made of independently compressed
+// frames, in which case the index of the frames is returned
+func (a *archiver) write(w io.Writer, chunk io.Reader, chunkInfo os.FileInfo) (*archiveIndex, error) {
+	if a.compression == "" {
+		_, err := io.Copy(w, chunk)
+		return nil, err
+	}
+
+	fw := &frameWriter{
+		compression: a.compression,
+		w:           &countingWriter{w: w},
+		index:       &archiveIndex{frameSize: archiveFrameSize},
+	}
+	tw := tar.NewWriter(fw)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    chunkInfo.Name(),
+		Mode:    0644,
+		Size:    chunkInfo.Size(),
+		ModTime: chunkInfo.ModTime(),
+	}); err != nil {
+		return nil, err
+	}
+	// the header is written as soon as it is set, hence the chunk follows what is written so far
+	fw.index.dataOffset = fw.written
+	if _, err := io.Copy(tw, chunk); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := fw.Close(); err != nil {
+		return nil, err
+	}
+	return fw.index, nil
+}
+
+// archiveIndex locates the chunk in a compressed tar. The chunk starts at dataOffset of the tar,
+// and the i-th frame, which holds the bytes of the tar from i*frameSize, starts at frameOffsets[i]
+// of the compressed tar.
+type archiveIndex struct {
+	frameSize    int64
+	dataOffset   int64
+	frameOffsets []int64
+}
+
+func (i *archiveIndex) marshal() []byte {
+	values := append([]int64{i.frameSize, i.dataOffset, int64(len(i.frameOffsets))}, i.frameOffsets...)
+	buf := make([]byte, len(values)*binary.MaxVarintLen64)
+	n := 0
+	for _, v := range values {
+		n += binary.PutUvarint(buf[n:], uint64(v))
+	}
+	return buf[:n]
+}
+
+func (i *archiveIndex) unmarshal(data []byte) error {
+	r := bytes.NewReader(data)
+	var values [3]uint64
+	for n := range values {
+		v, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(err, "error while reading the archive index")
+		}
+		values[n] = v
+	}
+	if values[0] == 0 || values[2] > uint64(len(data)) {
+		return errors.New("the archive index is corrupted")
+	}
+
+	i.frameSize = int64(values[0])
+	i.dataOffset = int64(values[1])
+	i.frameOffsets = make([]int64, values[2])
+	for n := range i.frameOffsets {
+		offset, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(err, "error while reading the archive index")
+		}
+		i.frameOffsets[n] = int64(offset)
+	}
+	return nil
+}
+
+func writeArchiveIndex(path string, index *archiveIndex) error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the file [%s]", tmpPath)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(index.marshal()); err != nil {
+		return errors.Wrapf(err, "error while writing the file [%s]", tmpPath)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "error while syncing the file [%s]", tmpPath)
+	}
+	return errors.Wrapf(os.Rename(tmpPath, path), "error while renaming the file [%s] to [%s]", tmpPath, path)
+}
+
+// frameWriter compresses every frameSize bytes written to it into a separate gzip member or zstd
+// frame. As both formats allow concatenated members, the result is still a regular compressed tar.
+type frameWriter struct {
+	compression string
+	w           *countingWriter
+	cw          io.WriteCloser
+	index       *archiveIndex
+	// written is the number of uncompressed bytes written so far
+	written int64
+}
+
+func (f *frameWriter) Write(p []byte) (int, error) {
+	total := 0
+	for len(p) > 0 {
+		if f.cw == nil {
+			cw, err := compression.NewWriter(f.compression, f.w)
+			if err != nil {
+				return total, err
+			}
+			f.cw = cw
+			f.index.frameOffsets = append(f.index.frameOffsets, f.w.written)
+		}
+
+		n := f.index.frameSize - f.written%f.index.frameSize
+		if n > int64(len(p)) {
+			n = int64(len(p))
+		}
+		written, err := f.cw.Write(p[:n])
+		total += written
+		f.written += int64(written)
+		if err != nil {
+			return total, err
+		}
+		p = p[n:]
+
+		if f.written%f.index.frameSize == 0 {
+			if err := f.closeFrame(); err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, nil
+}
+
+func (f *frameWriter) closeFrame() error {
+	cw := f.cw
+	f.cw = nil
+	return cw.Close()
+}
+
+func (f *frameWriter) Close() error {
+	if f.cw == nil {
+		return nil
+	}
+	return f.closeFrame()
+}
+
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+// readArchivedBlock reads a block from an archived chunk. As the archive compression might have changed
+// since the chunk was archived, all the formats are looked up. A block in a compressed tar is read
+// by decompressing the frames from the one holding the block. A compressed tar that has no index,
+// i.e., one archived as a single frame, is decompressed from its start up to the block.
+func readArchivedBlock(archiveDir string, location *BlockLocation) (*types.Block, error) {
+	for _, archiveCompression := range archiveFormats {
+		archivePath := constructArchivePath(archiveDir, location.FileChunkNum, archiveCompression)
+		f, err := os.Open(archivePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while opening the archived file chunk [%s]", archivePath)
+		}
+		defer f.Close()
+
+		if archiveCompression == "" {
+			return readBlockFromFile(f, location.Offset)
+		}
+
+		index, err := readArchiveIndex(archivePath + archiveIndexExtension)
+		if err != nil {
+			return nil, err
+		}
+		if index != nil {
+			return readFramedBlock(f, archiveCompression, index, location.Offset)
+		}
+
+		r, err := compression.NewReader(archiveCompression, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while decompressing the archived file chunk [%s]", archivePath)
+		}
+		defer r.Close()
+
+		tr := tar.NewReader(r)
+		if _, err := tr.Next(); err != nil {
+			return nil, errors.Wrapf(err, "error while reading the archived file chunk [%s]", archivePath)
+		}
+		if _, err := io.CopyN(ioutil.Discard, tr, location.Offset); err != nil {
+			return nil, errors.Wrapf(err, "error while reading the archived file chunk [%s]", archivePath)
+		}
+		return readBlock(bufio.NewReader(tr))
+	}
+
+	return nil, errors.Errorf("the file chunk [%d] is found neither in the store nor in the archive [%s]", location.FileChunkNum, archiveDir)
+}
+
+// readArchiveIndex returns the index of a compressed tar, or nil if it has none
+func readArchiveIndex(path string) (*archiveIndex, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the archive index [%s]", path)
+	}
+
+	index := &archiveIndex{}
+	if err := index.unmarshal(data); err != nil {
+		return nil, errors.WithMessagef(err, "error while reading the archive index [%s]", path)
+	}
+	return index, nil
+}
+
+func readFramedBlock(f *os.File, archiveCompression string, index *archiveIndex, offset int64) (*types.Block, error) {
+	tarOffset := index.dataOffset + offset
+	frame := tarOffset / index.frameSize
+	if frame >= int64(len(index.frameOffsets)) {
+		return nil, errors.Errorf("the offset [%d] is beyond the archived file chunk [%s]", offset, f.Name())
+	}
+	if _, err := f.Seek(index.frameOffsets[frame], io.SeekStart); err != nil {
+		return nil, errors.Wrapf(err, "error while seeking in the archived file chunk [%s]", f.Name())
+	}
+
+	r, err := compression.NewReader(archiveCompression, f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while decompressing the archived file chunk [%s]", f.Name())
+	}
+	defer r.Close()
+
+	if _, err := io.CopyN(ioutil.Discard, r, tarOffset-frame*index.frameSize); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the archived file chunk [%s]", f.Name())
+	}
+	return readBlock(bufio.NewReader(r))
+}
+
+// listFileChunks returns the numbers of the file chunks in the given directory in increasing order
+func listFileChunks(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while listing file chunks in [%s]", dir)
+	}
+
+	var chunkNums []uint64
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), chunkPrefix) {
+			continue
+		}
+		chunkNum, err := strconv.ParseUint(strings.TrimPrefix(f.Name(), chunkPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		chunkNums = append(chunkNums, chunkNum)
+	}
+	sort.Slice(chunkNums, func(i, j int) bool { return chunkNums[i] < chunkNums[j] })
+
+	return chunkNums, nil
+}
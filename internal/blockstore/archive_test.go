@@ -0,0 +1,189 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	t.Parallel()
+
+	lc := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(lc)
+	require.NoError(t, err)
+
+	commitBlocks := func(t *testing.T, s *Store, from, to uint64) []*types.Block {
+		var blocks []*types.Block
+		var prevBlockBaseHash, prevBlockHash []byte
+		var err error
+		for blockNumber := from; blockNumber <= to; blockNumber++ {
+			if blockNumber > 1 {
+				prevBlockBaseHash, err = s.GetBaseHeaderHash(blockNumber - 1)
+				require.NoError(t, err)
+				prevBlockHash, err = s.GetHash(blockNumber - 1)
+				require.NoError(t, err)
+			}
+
+			b := createSampleDataTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash, 5)
+			require.NoError(t, s.AddSkipListLinks(b))
+			require.NoError(t, s.Commit(b))
+			blocks = append(blocks, b)
+		}
+		return blocks
+	}
+
+	assertArchived := func(t *testing.T, s *Store, archiveDir, extension string) {
+		require.Eventually(t, func() bool {
+			chunkNums, err := listFileChunks(s.fileChunksDirPath)
+			require.NoError(t, err)
+			return len(chunkNums) == 1 && chunkNums[0] == s.currentChunkNum
+		}, 30*time.Second, 10*time.Millisecond)
+
+		for chunkNum := uint64(0); chunkNum < s.currentChunkNum; chunkNum++ {
+			archivePath := constructBlockFileChunkPath(archiveDir, chunkNum) + extension
+			require.FileExists(t, archivePath)
+			if extension == "" {
+				require.NoFileExists(t, archivePath+archiveIndexExtension)
+				continue
+			}
+
+			index, err := readArchiveIndex(archivePath + archiveIndexExtension)
+			require.NoError(t, err)
+			require.NotNil(t, index)
+			require.Equal(t, archiveFrameSize, index.frameSize)
+			require.Greater(t, len(index.frameOffsets), 1)
+		}
+	}
+
+	assertBlocks := func(t *testing.T, s *Store, blocks []*types.Block) {
+		for _, expected := range blocks {
+			block, err := s.Get(expected.GetHeader().GetBaseHeader().GetNumber())
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, block))
+		}
+	}
+
+	for _, tt := range []struct {
+		archiveCompression string
+		extension          string
+	}{
+		{archiveCompression: "", extension: ""},
+		{archiveCompression: compression.None, extension: ""},
+		{archiveCompression: compression.Gzip, extension: ".tar.gz"},
+		{archiveCompression: compression.Zstd, extension: ".tar.zst"},
+	} {
+		tt := tt
+		t.Run("archive compression "+tt.archiveCompression, func(t *testing.T) {
+			t.Parallel()
+
+			testDir, err := ioutil.TempDir("", "archivetest")
+			require.NoError(t, err)
+			defer os.RemoveAll(testDir)
+
+			c := &Config{
+				StoreDir:           filepath.Join(testDir, "store"),
+				ArchiveDir:         filepath.Join(testDir, "archive"),
+				ArchiveCompression: tt.archiveCompression,
+				Logger:             logger,
+			}
+			s, err := Open(c)
+			require.NoError(t, err)
+
+			blocks := commitBlocks(t, s, 1, 100)
+			require.Greater(t, s.currentChunkNum, uint64(2))
+			assertArchived(t, s, c.ArchiveDir, tt.extension)
+			assertBlocks(t, s, blocks)
+
+			require.NoError(t, s.Close())
+			s, err = Open(c)
+			require.NoError(t, err)
+			defer s.Close()
+
+			assertBlocks(t, s, blocks)
+			blocks = append(blocks, commitBlocks(t, s, 101, 150)...)
+			assertArchived(t, s, c.ArchiveDir, tt.extension)
+			assertBlocks(t, s, blocks)
+
+			if tt.extension != "" {
+				// a compressed tar without an index is still a valid archive
+				for chunkNum := uint64(0); chunkNum < s.currentChunkNum; chunkNum++ {
+					require.NoError(t, os.Remove(constructBlockFileChunkPath(c.ArchiveDir, chunkNum)+tt.extension+archiveIndexExtension))
+				}
+				assertBlocks(t, s, blocks)
+			}
+		})
+	}
+
+	t.Run("archive compression changed on reopen", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "archivetest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		c := &Config{
+			StoreDir:           filepath.Join(testDir, "store"),
+			ArchiveDir:         filepath.Join(testDir, "archive"),
+			ArchiveCompression: compression.Gzip,
+			Logger:             logger,
+		}
+		s, err := Open(c)
+		require.NoError(t, err)
+
+		blocks := commitBlocks(t, s, 1, 100)
+		assertArchived(t, s, c.ArchiveDir, ".tar.gz")
+		lastGzipChunkNum := s.currentChunkNum
+		require.NoError(t, s.Close())
+
+		c.ArchiveCompression = compression.Zstd
+		s, err = Open(c)
+		require.NoError(t, err)
+		defer s.Close()
+
+		blocks = append(blocks, commitBlocks(t, s, 101, 200)...)
+		require.Eventually(t, func() bool {
+			chunkNums, err := listFileChunks(s.fileChunksDirPath)
+			require.NoError(t, err)
+			return len(chunkNums) == 1
+		}, 30*time.Second, 10*time.Millisecond)
+		require.FileExists(t, constructBlockFileChunkPath(c.ArchiveDir, lastGzipChunkNum)+".tar.zst")
+		require.NoFileExists(t, constructBlockFileChunkPath(c.ArchiveDir, lastGzipChunkNum)+".tar.gz"+archiveIndexExtension)
+		assertBlocks(t, s, blocks)
+	})
+
+	t.Run("archive of an in-memory store is not used", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "archivetest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		s, err := Open(&Config{
+			InMemory:   true,
+			ArchiveDir: filepath.Join(testDir, "archive"),
+			Logger:     logger,
+		})
+		require.NoError(t, err)
+		defer s.Close()
+
+		blocks := commitBlocks(t, s, 1, 50)
+		assertBlocks(t, s, blocks)
+		require.NoDirExists(t, filepath.Join(testDir, "archive"))
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// CommitAttestation stores the attestation of this node on the header of a committed block
+func (s *Store) CommitAttestation(attestation *types.BlockAttestation) error {
+	return s.putBlockMetadata(constructAttestationKey(attestation.GetBlockNumber()), attestation)
+}
+
+// GetAttestation returns the attestation of this node on the header of the block
+func (s *Store) GetAttestation(blockNumber uint64) (*types.BlockAttestation, error) {
+	attestation := &types.BlockAttestation{}
+	if err := s.getBlockMetadata(constructAttestationKey(blockNumber), attestation); err != nil {
+		if _, ok := err.(*interrors.NotFoundErr); ok {
+			return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("attestation not found: %d", blockNumber)}
+		}
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// GetAttestations returns the attestations of this node on the headers of the blocks from start to end (inclusive).
+// Blocks which this node has not attested yet are skipped.
+func (s *Store) GetAttestations(start, end uint64) ([]*types.BlockAttestation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	itr := s.blockHeaderDB.NewIterator(&util.Range{
+		Start: constructAttestationKey(start),
+		Limit: constructAttestationKey(end + 1),
+	}, nil)
+	defer itr.Release()
+
+	var attestations []*types.BlockAttestation
+	for itr.Next() {
+		attestation := &types.BlockAttestation{}
+		if err := proto.Unmarshal(itr.Value(), attestation); err != nil {
+			return nil, errors.Wrap(err, "error while unmarshalling attestation")
+		}
+		attestations = append(attestations, attestation)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the attestations")
+	}
+	return attestations, nil
+}
+
+// CommitQuorumCertificate stores the quorum certificate of the header of a committed block
+func (s *Store) CommitQuorumCertificate(qc *types.QuorumCertificate) error {
+	return s.putBlockMetadata(constructQuorumCertificateKey(qc.GetBlockNumber()), qc)
+}
+
+// GetQuorumCertificate returns the quorum certificate of the header of the block
+func (s *Store) GetQuorumCertificate(blockNumber uint64) (*types.QuorumCertificate, error) {
+	qc := &types.QuorumCertificate{}
+	if err := s.getBlockMetadata(constructQuorumCertificateKey(blockNumber), qc); err != nil {
+		if _, ok := err.(*interrors.NotFoundErr); ok {
+			return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("quorum certificate not found: %d", blockNumber)}
+		}
+		return nil, err
+	}
+	return qc, nil
+}
+
+func (s *Store) putBlockMetadata(key []byte, m proto.Message) error {
+	value, err := proto.Marshal(m)
+	if err != nil {
+		return errors.Wrapf(err, "error while marshaling %T", m)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.readOnly {
+		return errReadOnly
+	}
+	return s.blockHeaderDB.Put(key, value, &opt.WriteOptions{Sync: true})
+}
+
+func (s *Store) getBlockMetadata(key []byte, m proto.Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, err := s.blockHeaderDB.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return &interrors.NotFoundErr{}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error while fetching %T", m)
+	}
+	if err := proto.Unmarshal(value, m); err != nil {
+		return errors.Wrapf(err, "error while unmarshalling %T", m)
+	}
+	return nil
+}
+
+func constructAttestationKey(blockNum uint64) []byte {
+	return append(attestationNs, encodeOrderPreservingVarUint64(blockNum)...)
+}
+
+func constructQuorumCertificateKey(blockNum uint64) []byte {
+	return append(quorumCertificateNs, encodeOrderPreservingVarUint64(blockNum)...)
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationsAndQuorumCertificates(t *testing.T) {
+	env := newTestEnv(t)
+	defer func() {
+		env.cleanup(true)
+	}()
+
+	attestation := func(blockNumber uint64) *types.BlockAttestation {
+		return &types.BlockAttestation{
+			BlockNumber: blockNumber,
+			NodeId:      "node1",
+			Signature:   []byte(fmt.Sprintf("sig-%d", blockNumber)),
+		}
+	}
+
+	for _, n := range []uint64{1, 2, 3, 5, 8} {
+		require.NoError(t, env.s.CommitAttestation(attestation(n)))
+	}
+	qc := &types.QuorumCertificate{
+		BlockNumber:  2,
+		HeaderHash:   []byte("hash-2"),
+		Attestations: []*types.BlockAttestation{attestation(2), {BlockNumber: 2, NodeId: "node2", Signature: []byte("sig")}},
+	}
+	require.NoError(t, env.s.CommitQuorumCertificate(qc))
+
+	assertStored := func() {
+		a, err := env.s.GetAttestation(3)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(attestation(3), a))
+
+		_, err = env.s.GetAttestation(4)
+		require.EqualError(t, err, "attestation not found: 4")
+		require.IsType(t, &errors.NotFoundErr{}, err)
+
+		attestations, err := env.s.GetAttestations(2, 6)
+		require.NoError(t, err)
+		require.Len(t, attestations, 3)
+		for i, n := range []uint64{2, 3, 5} {
+			require.True(t, proto.Equal(attestation(n), attestations[i]))
+		}
+
+		attestations, err = env.s.GetAttestations(9, 20)
+		require.NoError(t, err)
+		require.Len(t, attestations, 0)
+
+		storedQC, err := env.s.GetQuorumCertificate(2)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(qc, storedQC))
+
+		_, err = env.s.GetQuorumCertificate(3)
+		require.EqualError(t, err, "quorum certificate not found: 3")
+		require.IsType(t, &errors.NotFoundErr{}, err)
+	}
+
+	assertStored()
+	env.closeAndReOpenStore(t)
+	assertStored()
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/golang/protobuf/proto"
-	"github.com/golang/snappy"
 	"github.com/pkg/errors"
 )
 
@@ -104,9 +103,9 @@ func (s *blockfileStream) nextBlockWithLocation() (*blockAndLocation, error) {
 	s.currentOffset += blockSize
 	s.remainingBytes -= blockSize
 
-	marshaledBlock, err := snappy.Decode(nil, blockBytes)
+	marshaledBlock, err := decompressBlock(blockBytes)
 	if err != nil {
-		return nil, errors.Wrap(err, "error while decoding the block using snappy compression")
+		return nil, err
 	}
 
 	block := &types.Block{}
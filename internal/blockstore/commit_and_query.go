@@ -4,6 +4,7 @@ package blockstore
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -11,7 +12,6 @@ import (
 	"sync"
 
 	"github.com/golang/protobuf/proto"
-	"github.com/golang/snappy"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
@@ -27,6 +27,8 @@ const (
 	nonDataTxIndex = 0
 )
 
+var errReadOnly = errors.New("the block store is opened read-only")
+
 // Commit commits the block to the block store
 func (s *Store) Commit(block *types.Block) error {
 	if block == nil {
@@ -36,6 +38,10 @@ func (s *Store) Commit(block *types.Block) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.readOnly {
+		return errReadOnly
+	}
+
 	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
 	if blockNumber != s.lastCommittedBlockNum+1 {
 		return errors.Errorf(
@@ -50,7 +56,10 @@ func (s *Store) Commit(block *types.Block) error {
 		return errors.Wrapf(err, "error while marshaling block, %v", block)
 	}
 
-	encodedBlock := snappy.Encode(nil, b)
+	encodedBlock, err := compressBlock(s.compression, b)
+	if err != nil {
+		return err
+	}
 	n := binary.PutUvarint(s.reusableBuffer, uint64(len(encodedBlock)))
 	content := append(s.reusableBuffer[:n], encodedBlock...)
 
@@ -65,7 +74,14 @@ func (s *Store) Commit(block *types.Block) error {
 		return err
 	}
 
-	return s.storeMetadataInDB(block, blockLocation)
+	if err := s.storeMetadataInDB(block, blockLocation); err != nil {
+		return err
+	}
+
+	if s.archiver != nil {
+		s.archiver.seal(s.currentChunkNum)
+	}
+	return nil
 }
 
 func (s *Store) canCurrentFileChunkHold(toBeAddedBytesLength int) bool {
@@ -73,6 +89,13 @@ func (s *Store) canCurrentFileChunkHold(toBeAddedBytesLength int) bool {
 }
 
 func (s *Store) moveToNextFileChunk() error {
+	if s.inMemory {
+		s.memChunks = append(s.memChunks, nil)
+		s.currentChunkNum++
+		s.currentOffset = 0
+		return nil
+	}
+
 	f, err := openFileChunk(s.fileChunksDirPath, s.currentChunkNum+1)
 	if err != nil {
 		return err
@@ -91,6 +114,17 @@ func (s *Store) moveToNextFileChunk() error {
 func (s *Store) appendBlock(number uint64, content []byte) (*BlockLocation, error) {
 	offsetBeforeWrite := s.currentOffset
 
+	if s.inMemory {
+		s.memChunks[s.currentChunkNum] = append(s.memChunks[s.currentChunkNum], content...)
+		s.currentOffset += int64(len(content))
+		s.lastCommittedBlockNum = number
+		return &BlockLocation{
+			FileChunkNum: s.currentChunkNum,
+			Offset:       offsetBeforeWrite,
+			Length:       int64(len(content)),
+		}, nil
+	}
+
 	n, err := s.currentFileChunk.Write(content)
 	if err == nil {
 		s.currentOffset += int64(len(content))
@@ -225,6 +259,22 @@ func (s *Store) storeBlockValidationInfo(block *types.Block) error {
 
 		return s.txValidationInfoDB.Write(updateBatch, &opt.WriteOptions{Sync: true})
 
+	case *types.Block_ProcedureTxEnvelopes:
+		procedureTxs := block.GetProcedureTxEnvelopes().Envelopes
+		updateBatch := &leveldb.Batch{}
+
+		for txNum, tx := range procedureTxs {
+			key := []byte(tx.Payload.TxId)
+			value, err := proto.Marshal(block.Header.ValidationInfo[txNum])
+			if err != nil {
+				return errors.Wrapf(err, "error while marshaling validation info of transaction %d in block %d", txNum, blockNum)
+			}
+
+			updateBatch.Put(key, value)
+		}
+
+		return s.txValidationInfoDB.Write(updateBatch, &opt.WriteOptions{Sync: true})
+
 	case *types.Block_ConfigTxEnvelope:
 		txID = block.GetConfigTxEnvelope().Payload.TxId
 
@@ -234,6 +284,9 @@ func (s *Store) storeBlockValidationInfo(block *types.Block) error {
 	case *types.Block_UserAdministrationTxEnvelope:
 		txID = block.GetUserAdministrationTxEnvelope().Payload.TxId
 
+	case *types.Block_ExpiryTxEnvelope:
+		txID = block.GetExpiryTxEnvelope().Payload.TxId
+
 	default:
 		return errors.Errorf("unknown block payload")
 	}
@@ -320,6 +373,10 @@ func (s *Store) Get(blockNumber uint64) (*types.Block, error) {
 		return nil, err
 	}
 
+	if s.inMemory {
+		return readBlockFromFile(bytes.NewReader(s.memChunks[location.FileChunkNum]), location.Offset)
+	}
+
 	var f *os.File
 
 	switch {
@@ -330,9 +387,13 @@ func (s *Store) Get(blockNumber uint64) (*types.Block, error) {
 			s.currentOffset = offSet
 		}()
 	default:
-		f, err = openFileChunk(s.fileChunksDirPath, location.FileChunkNum)
+		// a sealed chunk is opened read-only, as it might have been archived
+		f, err = os.Open(constructBlockFileChunkPath(s.fileChunksDirPath, location.FileChunkNum))
+		if os.IsNotExist(err) && s.archiveDir != "" {
+			return readArchivedBlock(s.archiveDir, location)
+		}
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "error while opening the file chunk")
 		}
 		defer func() {
 			if err := f.Close(); err != nil {
@@ -510,12 +571,16 @@ func (s *Store) getLocation(blockNumber uint64) (*BlockLocation, error) {
 	return blockLocation, nil
 }
 
-func readBlockFromFile(f *os.File, offset int64) (*types.Block, error) {
+func readBlockFromFile(f io.ReadSeeker, offset int64) (*types.Block, error) {
 	if _, err := f.Seek(offset, 0); err != nil {
 		return nil, errors.Wrap(err, "error while seeking")
 	}
 
-	bufReader := bufio.NewReader(f)
+	return readBlock(bufio.NewReader(f))
+}
+
+// readBlock reads the block at the current position of the reader
+func readBlock(bufReader *bufio.Reader) (*types.Block, error) {
 	blockSize, err := binary.ReadUvarint(bufReader)
 	if err != nil {
 		return nil, errors.Wrap(err, "error while reading the length of the stored block")
@@ -526,9 +591,9 @@ func readBlockFromFile(f *os.File, offset int64) (*types.Block, error) {
 		return nil, errors.Wrap(err, "error while reading block from the file")
 	}
 
-	marshaledBlock, err := snappy.Decode(nil, buf)
+	marshaledBlock, err := decompressBlock(buf)
 	if err != nil {
-		return nil, errors.Wrap(err, "error while decoding the block using snappy compression")
+		return nil, err
 	}
 
 	block := &types.Block{}
@@ -179,6 +179,58 @@ func TestCommitAndQuery(t *testing.T) {
 		env.s.Close()
 	})
 
+	t.Run("commit blocks and query an in-memory store", func(t *testing.T) {
+		t.Parallel()
+
+		lc := &logger.Config{
+			Level:         "debug",
+			OutputPath:    []string{"stdout"},
+			ErrOutputPath: []string{"stderr"},
+			Encoding:      "console",
+		}
+		logger, err := logger.New(lc)
+		require.NoError(t, err)
+
+		s, err := Open(&Config{InMemory: true, Logger: logger})
+		require.NoError(t, err)
+		defer s.Close()
+
+		totalBlocks := uint64(100)
+		var prevBlockBaseHash, prevBlockHash []byte
+		var blocks []*types.Block
+
+		for blockNumber := uint64(1); blockNumber < totalBlocks; blockNumber++ {
+			b := createSampleUserTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash)
+			require.NoError(t, s.AddSkipListLinks(b))
+			require.NoError(t, s.Commit(b))
+			blocks = append(blocks, b)
+
+			prevBlockBaseHash, err = ComputeBlockBaseHash(b)
+			require.NoError(t, err)
+			prevBlockHash, err = ComputeBlockHash(b)
+			require.NoError(t, err)
+		}
+
+		// the blocks span multiple chunks
+		require.Greater(t, s.currentChunkNum, uint64(0))
+		require.Len(t, s.memChunks, int(s.currentChunkNum)+1)
+
+		for _, expectedBlock := range blocks {
+			blockNumber := expectedBlock.GetHeader().GetBaseHeader().GetNumber()
+			block, err := s.Get(blockNumber)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedBlock, block))
+
+			blockHash, err := s.GetHash(blockNumber)
+			require.NoError(t, err)
+			expectedHash, err := ComputeBlockHash(expectedBlock)
+			require.NoError(t, err)
+			require.Equal(t, expectedHash, blockHash)
+		}
+
+		require.EqualError(t, s.Replace("/tmp"), "an in-memory block store cannot be replaced")
+	})
+
 	t.Run("expected block is not received during commit", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"github.com/golang/snappy"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/pkg/errors"
+)
+
+// CompressionSnappy is the default compression of the blocks appended to the block file chunks.
+// Besides, the blocks can be compressed with compression.Gzip or compression.Zstd, or not be
+// compressed at all with compression.None.
+const CompressionSnappy = "snappy"
+
+// A snappy-encoded block starts with the uvarint of its decoded length, which is a zero byte only
+// for an empty block, i.e., a single zero byte. Hence, the blocks that are not snappy-encoded are
+// prefixed by a zero byte followed by the id of their compression, so that the blocks written with
+// different compressions can be read from the same chunk.
+const (
+	compressionMarker byte = 0
+	compressionIDNone byte = 1
+	compressionIDGzip byte = 2
+	compressionIDZstd byte = 3
+)
+
+// IsSupportedCompression returns true if the blocks can be compressed with the given algorithm.
+// An empty algorithm is the same as CompressionSnappy.
+func IsSupportedCompression(algorithm string) bool {
+	switch algorithm {
+	case "", CompressionSnappy, compression.None, compression.Gzip, compression.Zstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// compressBlock compresses the marshaled block with the given algorithm
+func compressBlock(algorithm string, marshaledBlock []byte) ([]byte, error) {
+	var id byte
+	switch algorithm {
+	case "", CompressionSnappy:
+		return snappy.Encode(nil, marshaledBlock), nil
+	case compression.None:
+		return append([]byte{compressionMarker, compressionIDNone}, marshaledBlock...), nil
+	case compression.Gzip:
+		id = compressionIDGzip
+	case compression.Zstd:
+		id = compressionIDZstd
+	default:
+		return nil, errors.Errorf("unsupported block compression: %s", algorithm)
+	}
+
+	compressed, err := compression.Compress(algorithm, marshaledBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while compressing the block using %s compression", algorithm)
+	}
+	return append([]byte{compressionMarker, id}, compressed...), nil
+}
+
+// decompressBlock returns the marshaled block, whatever the compression it was written with
+func decompressBlock(compressed []byte) ([]byte, error) {
+	if len(compressed) < 2 || compressed[0] != compressionMarker {
+		marshaledBlock, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while decoding the block using snappy compression")
+		}
+		return marshaledBlock, nil
+	}
+
+	var algorithm string
+	switch compressed[1] {
+	case compressionIDNone:
+		return compressed[2:], nil
+	case compressionIDGzip:
+		algorithm = compression.Gzip
+	case compressionIDZstd:
+		algorithm = compression.Zstd
+	default:
+		return nil, errors.Errorf("unknown block compression id: %d", compressed[1])
+	}
+
+	marshaledBlock, err := compression.Decompress(algorithm, compressed[2:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while decoding the block using %s compression", algorithm)
+	}
+	return marshaledBlock, nil
+}
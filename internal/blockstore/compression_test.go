@@ -0,0 +1,134 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressBlock(t *testing.T) {
+	t.Parallel()
+
+	b, err := proto.Marshal(createSampleDataTxBlock(1, nil, nil, 10))
+	require.NoError(t, err)
+
+	for _, algorithm := range []string{"", CompressionSnappy, compression.None, compression.Gzip, compression.Zstd} {
+		t.Run("algorithm "+algorithm, func(t *testing.T) {
+			require.True(t, IsSupportedCompression(algorithm))
+
+			compressed, err := compressBlock(algorithm, b)
+			require.NoError(t, err)
+
+			decompressed, err := decompressBlock(compressed)
+			require.NoError(t, err)
+			require.Equal(t, b, decompressed)
+		})
+	}
+
+	t.Run("legacy snappy-encoded block", func(t *testing.T) {
+		decompressed, err := decompressBlock(snappy.Encode(nil, b))
+		require.NoError(t, err)
+		require.Equal(t, b, decompressed)
+	})
+
+	t.Run("unsupported compression", func(t *testing.T) {
+		require.False(t, IsSupportedCompression("lz4"))
+
+		_, err := compressBlock("lz4", b)
+		require.EqualError(t, err, "unsupported block compression: lz4")
+
+		_, err = decompressBlock([]byte{compressionMarker, 9, 1, 2})
+		require.EqualError(t, err, "unknown block compression id: 9")
+	})
+}
+
+func TestCommitAndQueryWithCompression(t *testing.T) {
+	t.Parallel()
+
+	lc := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(lc)
+	require.NoError(t, err)
+
+	t.Run("unsupported compression", func(t *testing.T) {
+		t.Parallel()
+
+		storeDir, err := ioutil.TempDir("", "blockstore")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		s, err := Open(&Config{StoreDir: storeDir, Compression: "lz4", Logger: logger})
+		require.EqualError(t, err, "unsupported block compression: lz4")
+		require.Nil(t, s)
+
+		s, err = Open(&Config{StoreDir: storeDir, ArchiveCompression: "lz4", Logger: logger})
+		require.EqualError(t, err, "unsupported archive compression: lz4")
+		require.Nil(t, s)
+	})
+
+	t.Run("blocks committed with different compressions", func(t *testing.T) {
+		t.Parallel()
+
+		storeDir, err := ioutil.TempDir("", "blockstore")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		algorithms := []string{CompressionSnappy, compression.Zstd, compression.None, compression.Gzip}
+		blocksPerAlgorithm := uint64(25)
+		var prevBlockBaseHash, prevBlockHash []byte
+		blockNumber := uint64(1)
+
+		for _, algorithm := range algorithms {
+			s, err := Open(&Config{StoreDir: storeDir, Compression: algorithm, Logger: logger})
+			require.NoError(t, err)
+
+			for i := uint64(0); i < blocksPerAlgorithm; i++ {
+				b := createSampleDataTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash, 5)
+				require.NoError(t, s.AddSkipListLinks(b))
+				require.NoError(t, s.Commit(b))
+
+				prevBlockBaseHash, err = s.GetBaseHeaderHash(blockNumber)
+				require.NoError(t, err)
+				prevBlockHash, err = s.GetHash(blockNumber)
+				require.NoError(t, err)
+				blockNumber++
+			}
+			require.NoError(t, s.Close())
+		}
+
+		s, err := Open(&Config{StoreDir: storeDir, Logger: logger})
+		require.NoError(t, err)
+		defer s.Close()
+
+		height, err := s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(algorithms))*blocksPerAlgorithm, height)
+
+		for blockNumber := uint64(1); blockNumber <= height; blockNumber++ {
+			block, err := s.Get(blockNumber)
+			require.NoError(t, err)
+			require.Equal(t, blockNumber, block.GetHeader().GetBaseHeader().GetNumber())
+
+			hash, err := s.GetHash(blockNumber)
+			require.NoError(t, err)
+			blockHeaderBytes, err := proto.Marshal(block.GetHeader())
+			require.NoError(t, err)
+			expectedHash, err := crypto.ComputeSHA256Hash(blockHeaderBytes)
+			require.NoError(t, err)
+			require.Equal(t, expectedHash, hash)
+		}
+	})
+}
@@ -5,17 +5,18 @@ package blockstore
 import (
 	"encoding/binary"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -53,13 +54,25 @@ var (
 	headerBaseHashNs = []byte{3}
 	// number -> block tx ids array
 	blockTxsIDNs = []byte{4}
+	// number -> attestation of this node on the block header
+	attestationNs = []byte{5}
+	// number -> quorum certificate of the block header
+	quorumCertificateNs = []byte{6}
 )
 
 // Store maintains a chain of blocks in an append-only
 // filesystem
 type Store struct {
+	storeDir              string
 	fileChunksDirPath     string
 	currentFileChunk      *os.File
+	compression           string
+	archiveDir            string
+	archiveCompression    string
+	archiver              *archiver
+	inMemory              bool
+	readOnly              bool
+	memChunks             [][]byte
 	currentOffset         int64
 	currentChunkNum       uint64
 	lastCommittedBlockNum uint64
@@ -74,11 +87,45 @@ type Store struct {
 // Config holds the configuration of a block store
 type Config struct {
 	StoreDir string
+	// InMemory keeps the blocks and the indexes in memory instead of StoreDir,
+	// which is not used. The store is empty on every open.
+	InMemory bool
+	// Compression is the compression of the blocks appended to the file chunks:
+	// CompressionSnappy (default), compression.Zstd, compression.Gzip, or
+	// compression.None. The blocks remain readable if the compression changes.
+	Compression string
+	// ArchiveDir, if set, is the directory to which the sealed file chunks are
+	// moved. It must not be inside StoreDir. The archived blocks are read back
+	// transparently. Not used by an in-memory store.
+	ArchiveDir string
+	// ArchiveCompression is the compression of the archived chunks: with
+	// compression.Gzip or compression.Zstd, each chunk is archived in a
+	// compressed tar, otherwise, it is archived as is.
+	ArchiveCompression string
+	// ReadOnly opens an existing store for reads only, e.g., to inspect the ledger of
+	// a stopped server: the store is neither recovered from a crash nor archived, and
+	// a block appended to the file chunks but not indexed is ignored. Not used by an
+	// in-memory store.
+	ReadOnly bool
 	Logger   *logger.SugarLogger
 }
 
 // Open opens the store to maintains a chain of blocks
 func Open(c *Config) (*Store, error) {
+	if !IsSupportedCompression(c.Compression) {
+		return nil, errors.Errorf("unsupported block compression: %s", c.Compression)
+	}
+	if !compression.IsSupported(c.ArchiveCompression) {
+		return nil, errors.Errorf("unsupported archive compression: %s", c.ArchiveCompression)
+	}
+
+	if c.InMemory {
+		return openInMemoryStore(c)
+	}
+	if c.ReadOnly {
+		return openReadOnlyStore(c)
+	}
+
 	exist, err := fileops.Exists(c.StoreDir)
 	if err != nil {
 		return nil, err
@@ -156,9 +203,13 @@ func openNewStore(c *Config) (*Store, error) {
 		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
 	}
 
-	return &Store{
+	s := &Store{
+		storeDir:              c.StoreDir,
 		fileChunksDirPath:     fileChunksDirPath,
 		currentFileChunk:      file,
+		compression:           c.Compression,
+		archiveDir:            c.ArchiveDir,
+		archiveCompression:    c.ArchiveCompression,
 		currentOffset:         0,
 		currentChunkNum:       0,
 		lastCommittedBlockNum: 0,
@@ -167,6 +218,37 @@ func openNewStore(c *Config) (*Store, error) {
 		txValidationInfoDB:    txValidationInfoDB,
 		reusableBuffer:        make([]byte, binary.MaxVarintLen64),
 		logger:                c.Logger,
+	}
+	return s, s.startArchiver(0)
+}
+
+// openInMemoryStore opens a new store whose block file chunks are byte slices and
+// whose indexes are in-memory leveldb instances, hence nothing is written to disk
+func openInMemoryStore(c *Config) (*Store, error) {
+	indexDB, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating an index database")
+	}
+
+	headersDB, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the block headers")
+	}
+
+	txValidationInfoDB, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the transaction validation info")
+	}
+
+	return &Store{
+		inMemory:           true,
+		compression:        c.Compression,
+		memChunks:          [][]byte{nil},
+		blockIndexDB:       indexDB,
+		blockHeaderDB:      headersDB,
+		txValidationInfoDB: txValidationInfoDB,
+		reusableBuffer:     make([]byte, binary.MaxVarintLen64),
+		logger:             c.Logger,
 	}, nil
 }
 
@@ -176,7 +258,15 @@ func openExistingStore(c *Config) (*Store, error) {
 	blockHeaderDBPath := filepath.Join(c.StoreDir, blockHeaderDBName)
 	txValidationInfoDBPath := filepath.Join(c.StoreDir, txValidationInfoDBName)
 
-	currentFileChunk, currentChunkNum, err := findAndOpenLastFileChunk(fileChunksDirPath)
+	chunkNums, err := listFileChunks(fileChunksDirPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunkNums) == 0 {
+		return nil, errors.Errorf("no file chunk found in [%s]", fileChunksDirPath)
+	}
+	currentChunkNum := chunkNums[len(chunkNums)-1]
+	currentFileChunk, err := openFileChunk(fileChunksDirPath, currentChunkNum)
 	if err != nil {
 		return nil, err
 	}
@@ -202,8 +292,12 @@ func openExistingStore(c *Config) (*Store, error) {
 	}
 
 	s := &Store{
+		storeDir:           c.StoreDir,
 		fileChunksDirPath:  fileChunksDirPath,
 		currentFileChunk:   currentFileChunk,
+		compression:        c.Compression,
+		archiveDir:         c.ArchiveDir,
+		archiveCompression: c.ArchiveCompression,
 		currentOffset:      chunkFileInfo.Size(),
 		currentChunkNum:    currentChunkNum,
 		blockIndexDB:       indexDB,
@@ -212,7 +306,101 @@ func openExistingStore(c *Config) (*Store, error) {
 		reusableBuffer:     make([]byte, binary.MaxVarintLen64),
 		logger:             c.Logger,
 	}
-	return s, s.recover()
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+
+	return s, s.startArchiver(chunkNums[0])
+}
+
+// openReadOnlyStore opens an existing store without modifying it. The height of the store is the
+// last block in the block index.
+func openReadOnlyStore(c *Config) (*Store, error) {
+	exist, err := fileops.Exists(c.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Errorf("the block store [%s] does not exist", c.StoreDir)
+	}
+	partialStoreExist, err := isExistingStoreCreatedPartially(c.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+	if partialStoreExist {
+		return nil, errors.Errorf("the block store [%s] was created partially", c.StoreDir)
+	}
+
+	fileChunksDirPath := filepath.Join(c.StoreDir, fileChunksDirName)
+	chunkNums, err := listFileChunks(fileChunksDirPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunkNums) == 0 {
+		return nil, errors.Errorf("no file chunk found in [%s]", fileChunksDirPath)
+	}
+	currentChunkNum := chunkNums[len(chunkNums)-1]
+	currentChunkPath := constructBlockFileChunkPath(fileChunksDirPath, currentChunkNum)
+	currentFileChunk, err := os.Open(currentChunkPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the file chunk [%s]", currentChunkPath)
+	}
+
+	dbOptions := &opt.Options{ErrorIfMissing: true, ReadOnly: true}
+	indexDB, err := leveldb.OpenFile(filepath.Join(c.StoreDir, blockIndexDBName), dbOptions)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the block index")
+	}
+
+	headersDB, err := leveldb.OpenFile(filepath.Join(c.StoreDir, blockHeaderDBName), dbOptions)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the block headers")
+	}
+
+	txValidationInfoDB, err := leveldb.OpenFile(filepath.Join(c.StoreDir, txValidationInfoDBName), dbOptions)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the transaction validation info")
+	}
+
+	s := &Store{
+		storeDir:           c.StoreDir,
+		fileChunksDirPath:  fileChunksDirPath,
+		currentFileChunk:   currentFileChunk,
+		compression:        c.Compression,
+		archiveDir:         c.ArchiveDir,
+		archiveCompression: c.ArchiveCompression,
+		readOnly:           true,
+		currentChunkNum:    currentChunkNum,
+		blockIndexDB:       indexDB,
+		blockHeaderDB:      headersDB,
+		txValidationInfoDB: txValidationInfoDB,
+		reusableBuffer:     make([]byte, binary.MaxVarintLen64),
+		logger:             c.Logger,
+	}
+	if s.lastCommittedBlockNum, _, err = s.getLastBlockLocationInIndex(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// startArchiver starts the archival of the sealed chunks, from the given chunk on, if an archive
+// directory is configured. All the chunks preceding the current chunk are sealed once a block
+// is committed to the current chunk.
+func (s *Store) startArchiver(firstChunkNum uint64) error {
+	if s.archiveDir == "" {
+		return nil
+	}
+
+	var err error
+	if s.archiver, err = newArchiver(s.fileChunksDirPath, s.archiveDir, s.archiveCompression, firstChunkNum, s.logger); err != nil {
+		return err
+	}
+	if s.currentOffset > 0 {
+		s.archiver.seal(s.currentChunkNum)
+	}
+
+	return nil
 }
 
 func (s *Store) recover() error {
@@ -373,8 +561,15 @@ func (s *Store) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if err := s.currentFileChunk.Close(); err != nil {
-		return errors.WithMessage(err, "error while closing the store")
+	if s.archiver != nil {
+		s.archiver.stop()
+		s.archiver = nil
+	}
+
+	if s.currentFileChunk != nil {
+		if err := s.currentFileChunk.Close(); err != nil {
+			return errors.WithMessage(err, "error while closing the store")
+		}
 	}
 
 	if err := s.blockIndexDB.Close(); err != nil {
@@ -422,18 +617,3 @@ func constructBlockFileChunkPath(dir string, chunkNum uint64) string {
 	chunkName := fmt.Sprintf("%s%d", chunkPrefix, chunkNum)
 	return filepath.Join(dir, chunkName)
 }
-
-func findAndOpenLastFileChunk(fileChunksDirPath string) (*os.File, uint64, error) {
-	files, err := ioutil.ReadDir(fileChunksDirPath)
-	if err != nil {
-		return nil, 0, errors.Wrapf(err, "error while listing file chunks in [%s]", fileChunksDirPath)
-	}
-
-	lastChunkNum := uint64(len(files) - 1)
-	lastFileChunk, err := openFileChunk(fileChunksDirPath, lastChunkNum)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return lastFileChunk, lastChunkNum, nil
-}
@@ -23,6 +23,7 @@ import (
 
 func TestMain(t *testing.M) {
 	chunkSizeLimit = 4096
+	archiveFrameSize = 512
 	os.Exit(t.Run())
 }
 
@@ -216,6 +217,75 @@ func TestOpenStore(t *testing.T) {
 		require.Equal(t, offset, s.currentOffset)
 		require.Equal(t, uint64(1000), s.lastCommittedBlockNum)
 	})
+
+	t.Run("open read-only", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "opentest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		storeDir := filepath.Join(testDir, "read-only-store")
+		archiveDir := filepath.Join(testDir, "archive")
+		c := &Config{
+			StoreDir:   storeDir,
+			ArchiveDir: archiveDir,
+			ReadOnly:   true,
+			Logger:     logger,
+		}
+		_, err = Open(c)
+		require.EqualError(t, err, fmt.Sprintf("the block store [%s] does not exist", storeDir))
+		require.NoDirExists(t, storeDir)
+
+		s, err := Open(&Config{StoreDir: storeDir, Logger: logger})
+		require.NoError(t, err)
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{Number: 1},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_UserAdministrationTxEnvelope{
+				UserAdministrationTxEnvelope: &types.UserAdministrationTxEnvelope{
+					Payload: &types.UserAdministrationTx{UserId: "user1", TxId: "tx1"},
+				},
+			},
+		}
+		require.NoError(t, s.Commit(block))
+		require.NoError(t, s.Close())
+
+		// a partially appended block is not truncated, as the store is not recovered
+		chunkPath := filepath.Join(storeDir, "filechunks", "chunk_0")
+		chunk, err := os.OpenFile(chunkPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = chunk.Write([]byte{100, 1, 2, 3})
+		require.NoError(t, err)
+		require.NoError(t, chunk.Close())
+		chunkInfo, err := os.Stat(chunkPath)
+		require.NoError(t, err)
+
+		s, err = Open(c)
+		require.NoError(t, err)
+		height, err := s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
+		storedBlock, err := s.Get(1)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(block, storedBlock))
+
+		block.Header.BaseHeader.Number = 2
+		require.EqualError(t, s.Commit(block), "the block store is opened read-only")
+		require.EqualError(t, s.CommitAttestation(&types.BlockAttestation{BlockNumber: 1}), "the block store is opened read-only")
+		require.NoError(t, s.Close())
+
+		afterInfo, err := os.Stat(chunkPath)
+		require.NoError(t, err)
+		require.Equal(t, chunkInfo.Size(), afterInfo.Size())
+		require.NoDirExists(t, archiveDir)
+	})
 }
 
 func TestRecovery(t *testing.T) {
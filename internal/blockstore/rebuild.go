@@ -0,0 +1,139 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// RebuildIndex removes the block index, the block headers, and the transaction validation info of the
+// existing store in c.StoreDir, which must not be open, and rebuilds them from the blocks in the file
+// chunks. It returns the number of the last indexed block. As the blocks are read from the file chunks,
+// the index cannot be rebuilt once a chunk is archived. A partially written last block is left to the
+// recovery done by Open.
+func RebuildIndex(c *Config) (uint64, error) {
+	fileChunksDirPath := filepath.Join(c.StoreDir, fileChunksDirName)
+	chunkNums, err := listFileChunks(fileChunksDirPath)
+	if err != nil {
+		return 0, err
+	}
+	if len(chunkNums) == 0 {
+		return 0, errors.Errorf("no file chunk found in [%s]", fileChunksDirPath)
+	}
+	if chunkNums[0] != 0 {
+		return 0, errors.Errorf("the file chunks preceding chunk [%d] are archived, the index cannot be rebuilt", chunkNums[0])
+	}
+
+	s := &Store{
+		storeDir:          c.StoreDir,
+		fileChunksDirPath: fileChunksDirPath,
+		logger:            c.Logger,
+	}
+	for _, d := range []struct {
+		name string
+		db   **leveldb.DB
+	}{
+		{name: blockIndexDBName, db: &s.blockIndexDB},
+		{name: txValidationInfoDBName, db: &s.txValidationInfoDB},
+	} {
+		dbPath := filepath.Join(c.StoreDir, d.name)
+		if err := fileops.RemoveAll(dbPath); err != nil {
+			return 0, errors.WithMessagef(err, "error while removing [%s]", dbPath)
+		}
+		if *d.db, err = leveldb.OpenFile(dbPath, nil); err != nil {
+			return 0, errors.WithMessagef(err, "error while creating the leveldb file [%s]", dbPath)
+		}
+		defer (*d.db).Close()
+	}
+
+	// the block headers DB also holds the attestations and the quorum certificates, which cannot be
+	// rebuilt from the blocks, so that only the entries derived from the blocks are removed
+	if s.blockHeaderDB, err = openHeadersDBForRebuild(filepath.Join(c.StoreDir, blockHeaderDBName)); err != nil {
+		return 0, err
+	}
+	defer s.blockHeaderDB.Close()
+
+	chunkFileStream, err := newBlockfileStream(c.Logger, fileChunksDirPath, &BlockLocation{})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := chunkFileStream.close(); err != nil {
+			c.Logger.Warn(err.Error())
+		}
+	}()
+
+	var lastBlockNum uint64
+	for {
+		next, err := chunkFileStream.nextBlockWithLocation()
+		if err == ErrUnexpectedEndOfBlockfile {
+			c.Logger.Warnf("the block following block [%d] is partially written", lastBlockNum)
+			break
+		}
+		if err != nil {
+			return 0, errors.WithMessagef(err, "error while reading the block following block [%d]", lastBlockNum)
+		}
+		if next == nil {
+			break
+		}
+
+		blockNum := next.block.GetHeader().GetBaseHeader().GetNumber()
+		if blockNum != lastBlockNum+1 {
+			return 0, errors.Errorf("block [%d] is found in chunk [%d] at offset [%d] while block [%d] is expected",
+				blockNum, next.fileChunkNum, next.blockStartOffset, lastBlockNum+1)
+		}
+
+		location := &BlockLocation{
+			FileChunkNum: next.fileChunkNum,
+			Offset:       next.blockStartOffset,
+			Length:       next.blockEndOffset - next.blockStartOffset,
+		}
+		if err := s.storeMetadataInDB(next.block, location); err != nil {
+			return 0, err
+		}
+		lastBlockNum = blockNum
+	}
+
+	return lastBlockNum, nil
+}
+
+func openHeadersDBForRebuild(dbPath string) (*leveldb.DB, error) {
+	exist, err := fileops.Exists(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		db, err := leveldb.OpenFile(dbPath, nil)
+		return db, errors.WithMessagef(err, "error while creating the leveldb file [%s]", dbPath)
+	}
+
+	db, err := leveldb.RecoverFile(dbPath, nil)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while recovering the leveldb file [%s]", dbPath)
+	}
+
+	batch := &leveldb.Batch{}
+	for _, ns := range [][]byte{headerBytesNs, headerHashNs, headerHashToBlockNumNs, headerBaseHashNs, blockTxsIDNs} {
+		itr := db.NewIterator(util.BytesPrefix(ns), nil)
+		for itr.Next() {
+			batch.Delete(append([]byte{}, itr.Key()...))
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			db.Close()
+			return nil, errors.Wrapf(err, "error while iterating over the leveldb file [%s]", dbPath)
+		}
+	}
+	if err := db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "error while removing the block headers from [%s]", dbPath)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,126 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildIndex(t *testing.T) {
+	t.Parallel()
+
+	lc := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(lc)
+	require.NoError(t, err)
+
+	createStore := func(t *testing.T, c *Config, totalBlocks uint64) []*types.Block {
+		s, err := Open(c)
+		require.NoError(t, err)
+		defer s.Close()
+
+		var blocks []*types.Block
+		var prevBlockBaseHash, prevBlockHash []byte
+		for blockNumber := uint64(1); blockNumber <= totalBlocks; blockNumber++ {
+			b := createSampleDataTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash, 3)
+			require.NoError(t, s.AddSkipListLinks(b))
+			require.NoError(t, s.Commit(b))
+			require.NoError(t, s.CommitAttestation(&types.BlockAttestation{BlockNumber: blockNumber, NodeId: "node1", Signature: []byte("sig")}))
+			blocks = append(blocks, b)
+
+			prevBlockBaseHash, err = s.GetBaseHeaderHash(blockNumber)
+			require.NoError(t, err)
+			prevBlockHash, err = s.GetHash(blockNumber)
+			require.NoError(t, err)
+		}
+
+		if c.ArchiveDir != "" {
+			require.Eventually(t, func() bool {
+				chunkNums, err := listFileChunks(s.fileChunksDirPath)
+				require.NoError(t, err)
+				return len(chunkNums) == 1
+			}, 30*time.Second, 10*time.Millisecond)
+		}
+		return blocks
+	}
+
+	t.Run("index removed", func(t *testing.T) {
+		t.Parallel()
+
+		storeDir, err := ioutil.TempDir("", "rebuildtest")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		c := &Config{StoreDir: storeDir, Logger: logger}
+		blocks := createStore(t, c, 100)
+
+		require.NoError(t, os.RemoveAll(filepath.Join(storeDir, blockIndexDBName)))
+		require.NoError(t, os.RemoveAll(filepath.Join(storeDir, txValidationInfoDBName)))
+
+		height, err := RebuildIndex(c)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), height)
+
+		s, err := Open(c)
+		require.NoError(t, err)
+		defer s.Close()
+
+		height, err = s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), height)
+
+		for _, expected := range blocks {
+			blockNumber := expected.GetHeader().GetBaseHeader().GetNumber()
+			block, err := s.Get(blockNumber)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected, block))
+
+			header, err := s.GetHeader(blockNumber)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected.GetHeader(), header))
+
+			valInfo, err := s.GetValidationInfo(expected.GetDataTxEnvelopes().Envelopes[0].Payload.TxId)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expected.GetHeader().GetValidationInfo()[0], valInfo))
+
+			attestation, err := s.GetAttestation(blockNumber)
+			require.NoError(t, err)
+			require.Equal(t, "node1", attestation.GetNodeId())
+		}
+	})
+
+	t.Run("chunks archived", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "rebuildtest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		c := &Config{
+			StoreDir:   filepath.Join(testDir, "store"),
+			ArchiveDir: filepath.Join(testDir, "archive"),
+			Logger:     logger,
+		}
+		createStore(t, c, 100)
+		chunkNums, err := listFileChunks(filepath.Join(c.StoreDir, fileChunksDirName))
+		require.NoError(t, err)
+
+		height, err := RebuildIndex(c)
+		require.EqualError(t, err, fmt.Sprintf("the file chunks preceding chunk [%d] are archived, the index cannot be rebuilt", chunkNums[0]))
+		require.Equal(t, uint64(0), height)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Replace replaces the content of the store with the store in the given directory, e.g., a store
+// that was filled with blocks pulled from a remote peer during a state transfer. The directory
+// is moved into the place of the store, and the store is reopened.
+func (s *Store) Replace(dir string) error {
+	if s.inMemory {
+		return errors.New("an in-memory block store cannot be replaced")
+	}
+	if s.readOnly {
+		return errReadOnly
+	}
+
+	if err := s.Close(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.RemoveAll(s.storeDir); err != nil {
+		return errors.Wrapf(err, "error while removing the directory [%s]", s.storeDir)
+	}
+	if err := os.Rename(dir, s.storeDir); err != nil {
+		return errors.Wrapf(err, "error while moving the directory [%s] to [%s]", dir, s.storeDir)
+	}
+
+	replaced, err := openExistingStore(&Config{
+		StoreDir:           s.storeDir,
+		Compression:        s.compression,
+		ArchiveDir:         s.archiveDir,
+		ArchiveCompression: s.archiveCompression,
+		Logger:             s.logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.fileChunksDirPath = replaced.fileChunksDirPath
+	s.currentFileChunk = replaced.currentFileChunk
+	s.currentOffset = replaced.currentOffset
+	s.currentChunkNum = replaced.currentChunkNum
+	s.lastCommittedBlockNum = replaced.lastCommittedBlockNum
+	s.blockIndexDB = replaced.blockIndexDB
+	s.blockHeaderDB = replaced.blockHeaderDB
+	s.txValidationInfoDB = replaced.txValidationInfoDB
+	s.archiver = replaced.archiver
+
+	return nil
+}
@@ -4,10 +4,12 @@
 package comm
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"mime"
@@ -20,6 +22,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -29,13 +33,29 @@ import (
 var RetryIntervalMin = 10 * time.Millisecond
 var RetryIntervalMax = 10 * time.Second
 
+// CatchUpChunkSize is the number of blocks in a chunk pulled from a single member by PullBlocksConcurrently
+var CatchUpChunkSize = uint64(64)
+
+// CatchUpChunksPerMember is the number of chunks per member that are pulled concurrently by PullBlocksConcurrently
+var CatchUpChunksPerMember = 2
+
+// catchUpRemote fetches blocks, state snapshots and block attestations from a single remote member.
+type catchUpRemote interface {
+	GetBlocks(ctx context.Context, targetID, start, end uint64) ([]*types.Block, error)
+	GetStateSnapshot(ctx context.Context, targetID uint64, receive func(r io.Reader) error) error
+	GetAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error)
+}
+
 type catchUpClient struct {
 	httpClient *http.Client
 	logger     *logger.SugarLogger
 	tlsConfig  *tls.Config
+	remote     catchUpRemote // the members are reached over HTTP by the client itself, unless set otherwise
 
-	mutex   sync.Mutex
-	members map[uint64]*url.URL
+	mutex                sync.Mutex
+	members              map[uint64]*url.URL
+	compression          string
+	maxDecompressedBytes uint64 // unbounded if zero
 }
 
 func NewCatchUpClient(lg *logger.SugarLogger, tlsConfig *tls.Config) *catchUpClient {
@@ -45,6 +65,7 @@ func NewCatchUpClient(lg *logger.SugarLogger, tlsConfig *tls.Config) *catchUpCli
 		logger:     lg,
 		members:    make(map[uint64]*url.URL),
 	}
+	c.remote = c
 	return c
 }
 
@@ -77,6 +98,37 @@ func (c *catchUpClient) UpdateMembers(memberList []*types.PeerConfig) error {
 	return nil
 }
 
+// SetCompression sets the compression algorithm the client asks the members to compress the responses with.
+func (c *catchUpClient) SetCompression(algorithm string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.compression = algorithm
+}
+
+// SetMaxDecompressedBytes sets the maximal size of a compressed response once decompressed. A larger response is
+// rejected. The size is not bounded if zero.
+func (c *catchUpClient) SetMaxDecompressedBytes(max uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.maxDecompressedBytes = max
+}
+
+func (c *catchUpClient) getMaxDecompressedBytes() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.maxDecompressedBytes
+}
+
+func (c *catchUpClient) getCompression() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.compression
+}
+
 func (c *catchUpClient) PullBlocks(ctx context.Context, start, end uint64, leaderHint uint64) ([]*types.Block, error) {
 	curRetryInterval := RetryIntervalMin
 
@@ -95,7 +147,7 @@ func (c *catchUpClient) PullBlocks(ctx context.Context, start, end uint64, leade
 				c.logger.Infof("PulledBlocks canceled: %s", ctx.Err())
 				return nil, errors.WithMessage(ctx.Err(), "PullBlocks canceled")
 			default:
-				blocks, err := c.GetBlocks(ctx, id, start, end)
+				blocks, err := c.remote.GetBlocks(ctx, id, start, end)
 				if err != nil {
 					c.logger.Debugf("failed to get blocks from member [%d], error: %s", id, err)
 					continue
@@ -128,6 +180,411 @@ func (c *catchUpClient) PullBlocks(ctx context.Context, start, end uint64, leade
 	}
 }
 
+// PullBlocksConcurrently pulls the blocks that follow `lastBlock` up to `end` (inclusive), and passes them in order to
+// `deliver`. If `lastBlock` is nil, the pull starts from the genesis block. The range is split into chunks of
+// `CatchUpChunkSize` blocks which are pulled concurrently, each from a different member in turn, starting from the
+// leader hint (if exists). At most `CatchUpChunksPerMember` chunks per member are in-flight.
+//
+// Every block is verified to be linked to the block that precedes it, by the `previous_base_header_hash` and, if the
+// block carries skip-chain hashes, by the hash of the preceding block. As a block is linked only to the blocks before
+// it, a chain that is linked to `lastBlock` may still be forged, unless it is anchored by a hash that is trusted:
+//   - If `endBaseHash`, the base header hash of block `end`, is given, the chunks are verified against it walking
+//     backwards, before any of them is delivered: the last chunk must match the hash and each chunk must be linked to
+//     the chunk that follows it. Either side of a link that does not hold may be the forged one, so the chunks on both
+//     sides are pulled again from the members that did not serve any of their blocks, until none is left, see
+//     verifyChunks. Hence, the blocks are held until the whole range is verified.
+//   - Otherwise, the chunks are delivered as they arrive and the caller must verify the blocks by other means, e.g.,
+//     their commit signatures. A chunk that is not linked to the blocks delivered so far is pulled again from the
+//     members that did not serve any of its blocks, until none is left.
+//
+// Members that are not reachable, or that do not have the blocks of a chunk, are retried with exponential back-off,
+// until the context `ctx` is canceled. An error returned by `deliver` stops the pull and is returned.
+func (c *catchUpClient) PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, end uint64, endBaseHash []byte, leaderHint uint64, deliver func(blocks []*types.Block) error) error {
+	// the pulling go-routines are canceled and waited for, so that none of them outlives the call
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	start := lastBlock.GetHeader().GetBaseHeader().GetNumber() + 1
+	if start > end {
+		return nil
+	}
+
+	var memberIDs []uint64
+	if leaderHint != 0 {
+		memberIDs = append(memberIDs, leaderHint)
+	}
+	for _, id := range c.memberIDs() {
+		if id != leaderHint {
+			memberIDs = append(memberIDs, id)
+		}
+	}
+	if len(memberIDs) == 0 {
+		return errors.New("no members to pull blocks from")
+	}
+	c.logger.Debugf("going to pull blocks [%d,%d] concurrently from members: %v", start, end, memberIDs)
+
+	// the chunks are queued in order, the size of the queue bounds the number of chunks in-flight
+	chunkSize := CatchUpChunkSize
+	chunks := make(chan *blockChunk, len(memberIDs)*CatchUpChunksPerMember)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(chunks)
+		for i, chunkStart := 0, start; chunkStart <= end; i, chunkStart = i+1, chunkStart+chunkSize {
+			chunk := &blockChunk{
+				index:    i,
+				start:    chunkStart,
+				end:      chunkStart + chunkSize - 1,
+				excluded: make(map[uint64]bool),
+				done:     make(chan struct{}),
+			}
+			if chunk.end > end || chunk.end < chunkStart {
+				chunk.end = end
+			}
+
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(chunk.done)
+				chunk.blocks, chunk.servedBy, chunk.err = c.pullChunk(ctx, memberIDs, chunk.index, chunk.start, chunk.end)
+			}()
+		}
+	}()
+
+	prevBlock := lastBlock
+	var pulled []*blockChunk
+	for chunk := range chunks {
+		select {
+		case <-chunk.done:
+		case <-ctx.Done():
+			return errors.WithMessage(ctx.Err(), "PullBlocksConcurrently canceled")
+		}
+		if chunk.err != nil {
+			return chunk.err
+		}
+
+		if endBaseHash != nil {
+			pulled = append(pulled, chunk)
+			prevBlock = chunk.blocks[len(chunk.blocks)-1]
+			continue
+		}
+
+		// the chunk is linked internally by pullChunk, but it may be served by members with a different chain, which
+		// are excluded from the pulls of the chunk that follow
+		for verifyBlockLink(prevBlock, chunk.blocks[0]) != nil {
+			repulled, err := c.repullChunk(ctx, memberIDs, chunk)
+			if err != nil {
+				return err
+			}
+			if !repulled {
+				return errors.Errorf("blocks [%d,%d] pulled from all members are not linked to block [%d]",
+					chunk.start, chunk.end, chunk.start-1)
+			}
+		}
+
+		if err := deliver(chunk.blocks); err != nil {
+			return err
+		}
+		prevBlock = chunk.blocks[len(chunk.blocks)-1]
+	}
+
+	// the chunks stop being queued only if the context is canceled
+	if last := prevBlock.GetHeader().GetBaseHeader().GetNumber(); last < end {
+		return errors.Errorf("PullBlocksConcurrently canceled: %v", ctx.Err())
+	}
+	if endBaseHash == nil {
+		return nil
+	}
+
+	if err := c.verifyChunks(ctx, memberIDs, lastBlock, pulled, endBaseHash); err != nil {
+		return err
+	}
+	for _, chunk := range pulled {
+		if err := deliver(chunk.blocks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyChunks verifies the chunks, which cover the range that follows `lastBlock`, against the base header hash of
+// the last block of the range, walking backwards: the last chunk must match the hash, each chunk must be linked to the
+// chunk that follows it, and the first chunk must be linked to `lastBlock`. When a link does not hold, the earlier
+// chunk is pulled again first, as the base header of the later one is already anchored. If no other member is left to
+// pull the earlier chunk from, the later chunk, whose skip-chain hashes may be the forged ones, is pulled again, and
+// the walk resumes from it.
+func (c *catchUpClient) verifyChunks(ctx context.Context, memberIDs []uint64, lastBlock *types.Block, chunks []*blockChunk, endBaseHash []byte) error {
+	for k := len(chunks) - 1; k >= -1; {
+		var err error
+		switch {
+		case k == -1:
+			err = verifyBlockLink(lastBlock, chunks[0].blocks[0])
+		case k == len(chunks)-1:
+			err = verifyBlockBaseHash(chunks[k].blocks[len(chunks[k].blocks)-1], endBaseHash)
+		default:
+			err = verifyBlockLink(chunks[k].blocks[len(chunks[k].blocks)-1], chunks[k+1].blocks[0])
+		}
+		if err == nil {
+			k--
+			continue
+		}
+		c.logger.Warnf("blocks pulled from members do not match the blocks that follow them, error: %s", err)
+
+		if k == -1 {
+			repulled, err := c.repullChunk(ctx, memberIDs, chunks[0])
+			if err != nil {
+				return err
+			}
+			if !repulled {
+				return errors.Errorf("blocks [%d,%d] pulled from all members are not linked to block [%d]",
+					chunks[0].start, chunks[0].end, chunks[0].start-1)
+			}
+			k = 0
+			continue
+		}
+
+		repulled, err := c.repullChunk(ctx, memberIDs, chunks[k])
+		if err != nil {
+			return err
+		}
+		if repulled {
+			continue
+		}
+		if k == len(chunks)-1 {
+			return errors.Errorf("blocks [%d,%d] pulled from all members do not match the hash of block [%d]",
+				chunks[k].start, chunks[k].end, chunks[k].end)
+		}
+
+		if repulled, err = c.repullChunk(ctx, memberIDs, chunks[k+1]); err != nil {
+			return err
+		}
+		if !repulled {
+			return errors.Errorf("blocks [%d,%d] pulled from all members are not linked to block [%d]",
+				chunks[k+1].start, chunks[k+1].end, chunks[k+1].start-1)
+		}
+		// the earlier chunk may have been rejected because of the later one, so all members are candidates again
+		chunks[k].excluded = make(map[uint64]bool)
+		k++
+	}
+
+	return nil
+}
+
+// repullChunk pulls the blocks of the chunk again from the members that did not serve any of its blocks so far. It
+// returns false if no such member is left.
+func (c *catchUpClient) repullChunk(ctx context.Context, memberIDs []uint64, chunk *blockChunk) (bool, error) {
+	for _, id := range chunk.servedBy {
+		chunk.excluded[id] = true
+	}
+	var candidateIDs []uint64
+	for _, id := range memberIDs {
+		if !chunk.excluded[id] {
+			candidateIDs = append(candidateIDs, id)
+		}
+	}
+	if len(candidateIDs) == 0 {
+		return false, nil
+	}
+
+	c.logger.Warnf("blocks [%d,%d] pulled from members %v are not valid, pulling them from members %v",
+		chunk.start, chunk.end, chunk.servedBy, candidateIDs)
+	blocks, servedBy, err := c.pullChunk(ctx, candidateIDs, chunk.index, chunk.start, chunk.end)
+	if err != nil {
+		return false, err
+	}
+	chunk.blocks, chunk.servedBy = blocks, servedBy
+	return true, nil
+}
+
+type blockChunk struct {
+	index    int // the position of the chunk in the range, the member the chunk is pulled from first rotates with it
+	start    uint64
+	end      uint64
+	blocks   []*types.Block
+	servedBy []uint64        // the members that served the blocks
+	excluded map[uint64]bool // the members that served blocks of the chunk which turned out to be invalid
+	err      error
+	done     chan struct{}
+}
+
+// pullChunk pulls the blocks [start,end] (inclusive), trying the members in turn starting from memberIDs[i], in rounds,
+// with exponential back-off between rounds. Blocks that are not linked to each other are discarded. It returns the
+// blocks along with the members that served them, and an error only if the context `ctx` is canceled.
+func (c *catchUpClient) pullChunk(ctx context.Context, memberIDs []uint64, i int, start, end uint64) ([]*types.Block, []uint64, error) {
+	curRetryInterval := RetryIntervalMin
+
+	var chunk []*types.Block
+	var servedBy []uint64
+	for next := start; next <= end; {
+		var progress bool
+		for j := range memberIDs {
+			select {
+			case <-ctx.Done():
+				return nil, nil, errors.WithMessage(ctx.Err(), "PullBlocksConcurrently canceled")
+			default:
+			}
+
+			id := memberIDs[(i+j)%len(memberIDs)]
+			blocks, err := c.remote.GetBlocks(ctx, id, next, end)
+			if err != nil {
+				c.logger.Debugf("failed to get blocks from member [%d], error: %s", id, err)
+				continue
+			}
+			if err = verifyBlocks(chunk, blocks, next); err != nil {
+				c.logger.Warnf("blocks pulled from member [%d] are not valid, error: %s", id, err)
+				continue
+			}
+
+			c.logger.Debugf("Pulled blocks [%d,%d] from member [%d]", next, next+uint64(len(blocks))-1, id)
+			chunk = append(chunk, blocks...)
+			if len(servedBy) == 0 || servedBy[len(servedBy)-1] != id {
+				servedBy = append(servedBy, id)
+			}
+			next += uint64(len(blocks))
+			progress = true
+			break
+		}
+
+		if progress {
+			curRetryInterval = RetryIntervalMin
+			continue
+		}
+
+		c.logger.Debugf("failed to get blocks [%d,%d] from members, will try again in %s", next, end, curRetryInterval)
+		select {
+		case <-ctx.Done():
+			return nil, nil, errors.WithMessage(ctx.Err(), "PullBlocksConcurrently canceled")
+		case <-time.After(curRetryInterval):
+			// double the retry interval up to a max, to implement exponential back-off
+			curRetryInterval = 2 * curRetryInterval
+			if curRetryInterval > RetryIntervalMax {
+				curRetryInterval = RetryIntervalMax
+			}
+		}
+	}
+
+	return chunk, servedBy, nil
+}
+
+// verifyBlocks checks that the pulled blocks start with block number `next`, and that each of them is linked to the
+// previous one, starting from the last block of the chunk pulled so far.
+func verifyBlocks(chunk, blocks []*types.Block, next uint64) error {
+	if len(blocks) == 0 {
+		return errors.New("no blocks")
+	}
+
+	var prevBlock *types.Block
+	if len(chunk) > 0 {
+		prevBlock = chunk[len(chunk)-1]
+	}
+	for k, block := range blocks {
+		if num := block.GetHeader().GetBaseHeader().GetNumber(); num != next+uint64(k) {
+			return errors.Errorf("expected block number [%d] but received [%d]", next+uint64(k), num)
+		}
+		if prevBlock != nil {
+			if err := verifyBlockLink(prevBlock, block); err != nil {
+				return err
+			}
+		}
+		prevBlock = block
+	}
+
+	return nil
+}
+
+// verifyBlockBaseHash checks that the base header hash of the block matches the given hash.
+func verifyBlockBaseHash(block *types.Block, baseHash []byte) error {
+	blockBaseHash, err := blockstore.ComputeBlockBaseHash(block)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(blockBaseHash, baseHash) {
+		return errors.Errorf("the base header hash of block [%d] does not match the expected hash", block.GetHeader().GetBaseHeader().GetNumber())
+	}
+	return nil
+}
+
+// verifyBlockLink checks that the block is linked to the previous block, if the previous block is known.
+func verifyBlockLink(prevBlock, block *types.Block) error {
+	if prevBlock == nil {
+		return nil
+	}
+
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	prevBaseHash, err := blockstore.ComputeBlockBaseHash(prevBlock)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(block.GetHeader().GetBaseHeader().GetPreviousBaseHeaderHash(), prevBaseHash) {
+		return errors.Errorf("the previous base header hash of block [%d] does not match block [%d]", blockNum, blockNum-1)
+	}
+
+	if skipchainHashes := block.GetHeader().GetSkipchainHashes(); len(skipchainHashes) > 0 {
+		prevHash, err := blockstore.ComputeBlockHash(prevBlock)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(skipchainHashes[0], prevHash) {
+			return errors.Errorf("the skip-chain hash of block [%d] does not match block [%d]", blockNum, blockNum-1)
+		}
+	}
+
+	return nil
+}
+
+// PullStateSnapshot tries the members once, starting from the leader hint, until one of them sends a state snapshot
+// which is received by `receive` without an error. Otherwise, the last error is returned.
+func (c *catchUpClient) PullStateSnapshot(ctx context.Context, leaderHint uint64, receive func(r io.Reader) error) error {
+	var memberIDs []uint64
+	if leaderHint != 0 {
+		memberIDs = append(memberIDs, leaderHint)
+	}
+	for _, id := range c.memberIDs() {
+		if id != leaderHint {
+			memberIDs = append(memberIDs, id)
+		}
+	}
+	c.logger.Debugf("going to try getting a state snapshot from members: %v, in that order", memberIDs)
+
+	err := errors.New("no members to pull a state snapshot from")
+	for _, id := range memberIDs {
+		select {
+		case <-ctx.Done():
+			c.logger.Infof("PullStateSnapshot canceled: %s", ctx.Err())
+			return errors.WithMessage(ctx.Err(), "PullStateSnapshot canceled")
+		default:
+			if err = c.remote.GetStateSnapshot(ctx, id, receive); err != nil {
+				c.logger.Warnf("failed to get a state snapshot from member [%d], error: %s", id, err)
+				continue
+			}
+
+			c.logger.Infof("Pulled a state snapshot from member [%d]", id)
+			return nil
+		}
+	}
+
+	return err
+}
+
+// PullAttestations pulls the attestations of the member `targetID` on the blocks [start,end] (inclusive). The member
+// returns only the attestations it has, which may be fewer than requested.
+func (c *catchUpClient) PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	attestations, err := c.remote.GetAttestations(ctx, targetID, start, end)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get attestations [%d,%d] from member [%d]", start, end, targetID)
+	}
+	return attestations, nil
+}
+
 func (c *catchUpClient) memberIDs() []uint64 {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -170,6 +627,9 @@ func (c *catchUpClient) GetBlocks(ctx context.Context, targetID, start, end uint
 		return nil, err
 	}
 	req.Header.Add("Accept", utils.MultiPartFormData)
+	if algorithm := c.getCompression(); compression.Enabled(algorithm) {
+		req.Header.Add("Accept-Encoding", algorithm)
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -184,7 +644,7 @@ func (c *catchUpClient) GetBlocks(ctx context.Context, targetID, start, end uint
 		return nil, eRes
 	}
 
-	return multipartResponseToBlocks(c.logger, resp)
+	return multipartResponseToBlocks(c.logger, resp, c.getMaxDecompressedBytes())
 }
 
 func (c *catchUpClient) GetHeight(ctx context.Context, targetID uint64) (uint64, error) {
@@ -221,6 +681,91 @@ func (c *catchUpClient) GetHeight(ctx context.Context, targetID uint64) (uint64,
 	return hRes.Height, nil
 }
 
+func (c *catchUpClient) GetStateSnapshot(ctx context.Context, targetID uint64, receive func(r io.Reader) error) error {
+	baseURL := c.getMemberURL(targetID)
+	if baseURL == nil {
+		return errors.Errorf("target ID [%d] not found", targetID)
+	}
+
+	url := baseURL.ResolveReference(&url.URL{Path: GetStateSnapshotPath})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/octet-stream")
+	if algorithm := c.getCompression(); compression.Enabled(algorithm) {
+		req.Header.Add("Accept-Encoding", algorithm)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		body, err := decompressedBody(resp, c.getMaxDecompressedBytes())
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		return receive(body)
+	case http.StatusNotFound:
+		return errors.Errorf("member [%d] does not serve state snapshots", targetID)
+	default:
+		eRes := &types.HttpResponseErr{}
+		if err = json.NewDecoder(resp.Body).Decode(eRes); err != nil {
+			return err
+		}
+		return eRes
+	}
+}
+
+func (c *catchUpClient) GetAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	baseURL := c.getMemberURL(targetID)
+	if baseURL == nil {
+		return nil, errors.Errorf("target ID [%d] not found", targetID)
+	}
+
+	q := make(url.Values)
+	q.Add("start", strconv.FormatUint(start, 10))
+	q.Add("end", strconv.FormatUint(end, 10))
+	url := baseURL.ResolveReference(
+		&url.URL{
+			Path:     GetAttestationsPath,
+			RawQuery: q.Encode(),
+		},
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/octet-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read attestations")
+		}
+		return unmarshalAttestations(respBytes)
+	case http.StatusNotFound:
+		return nil, errors.Errorf("member [%d] does not serve attestations", targetID)
+	default:
+		eRes := &types.HttpResponseErr{}
+		if err = json.NewDecoder(resp.Body).Decode(eRes); err != nil {
+			return nil, err
+		}
+		return nil, eRes
+	}
+}
+
 func newHTTPClient(tlsConfig *tls.Config) *http.Client {
 	//TODO expose some transport parameters
 	httpClient := &http.Client{
@@ -237,12 +782,13 @@ func newHTTPClient(tlsConfig *tls.Config) *http.Client {
 			IdleConnTimeout:       90 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
 			ForceAttemptHTTP2:     true,
+			DisableCompression:    true, // compression is negotiated according to the cluster config
 		},
 	}
 	return httpClient
 }
 
-func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*types.Block, error) {
+func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response, maxSize uint64) ([]*types.Block, error) {
 	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse Content-Type header")
@@ -255,7 +801,13 @@ func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*
 		return nil, errors.Errorf("%s boundary not found", utils.MultiPartFormData)
 	}
 
-	mr := multipart.NewReader(resp.Body, boundary)
+	body, err := decompressedBody(resp, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	mr := multipart.NewReader(body, boundary)
 	var blocks []*types.Block
 	var totalBytes int
 	for part, errP := mr.NextPart(); errP == nil; part, errP = mr.NextPart() {
@@ -280,3 +832,24 @@ func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*
 
 	return blocks, nil
 }
+
+// decompressedBody returns a reader of the response body that decompresses it according to the Content-Encoding
+// header, and fails once the decompressed body exceeds maxSize bytes, unless maxSize is zero.
+func decompressedBody(resp *http.Response, maxSize uint64) (io.ReadCloser, error) {
+	algorithm := resp.Header.Get("Content-Encoding")
+	if algorithm == "" {
+		return ioutil.NopCloser(resp.Body), nil
+	}
+
+	var body io.ReadCloser
+	var err error
+	if maxSize == 0 {
+		body, err = compression.NewReader(algorithm, resp.Body)
+	} else {
+		body, err = compression.NewLimitedReader(algorithm, resp.Body, maxSize)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to decompress response")
+	}
+	return body, nil
+}
@@ -5,6 +5,8 @@ package comm_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -15,10 +17,13 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
 	"github.com/hyperledger-labs/orion-server/internal/comm/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -213,6 +218,423 @@ func TestCatchUpClient_PullBlocks(t *testing.T) {
 	require.Equal(t, 4, len(blocks))
 }
 
+func TestCatchUpClient_PullBlocksConcurrently(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	chunkSize := comm.CatchUpChunkSize
+	comm.CatchUpChunkSize = 7
+	defer func() { comm.CatchUpChunkSize = chunkSize }()
+
+	baseHash := func(t *testing.T, ledger *memLedger, blockNum uint64) []byte {
+		block, err := ledger.Get(blockNum)
+		require.NoError(t, err)
+		hash, err := blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
+		return hash
+	}
+
+	pullAll := func(t *testing.T, cc interface {
+		PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, end uint64, endBaseHash []byte, leaderHint uint64, deliver func(blocks []*types.Block) error) error
+	}, lastBlock *types.Block, end uint64, endBaseHash []byte, leaderHint uint64) []*types.Block {
+		var pulled []*types.Block
+		err := cc.PullBlocksConcurrently(context.Background(), lastBlock, end, endBaseHash, leaderHint, func(blocks []*types.Block) error {
+			pulled = append(pulled, blocks...)
+			return nil
+		})
+		require.NoError(t, err)
+		return pulled
+	}
+
+	requireBlocks := func(t *testing.T, expected *memLedger, start uint64, blocks []*types.Block) {
+		for i, block := range blocks {
+			expectedBlock, err := expected.Get(start + uint64(i))
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedBlock, block), "block: %d", start+uint64(i))
+		}
+	}
+
+	t.Run("members with different heights", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 4)
+
+		ledger := linkedLedger(t, 100, "")
+		tr1, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 0, ledger)
+		require.NoError(t, err)
+		defer tr1.Close()
+		tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 100)
+		require.NoError(t, err)
+		defer tr2.Close()
+		tr3, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 2, 30)
+		require.NoError(t, err)
+		defer tr3.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:3])
+		require.NoError(t, err)
+
+		blocks := pullAll(t, cc, nil, 100, nil, 3)
+		require.Len(t, blocks, 100)
+		requireBlocks(t, ledger, 1, blocks)
+
+		lastBlock, err := ledger.Get(50)
+		require.NoError(t, err)
+		blocks = pullAll(t, cc, lastBlock, 90, baseHash(t, ledger, 90), 0)
+		require.Len(t, blocks, 40)
+		requireBlocks(t, ledger, 51, blocks)
+
+		require.Len(t, pullAll(t, cc, lastBlock, 50, nil, 0), 0)
+	})
+
+	t.Run("member on a different chain", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 3)
+
+		ledger := linkedLedger(t, 60, "")
+		tr1, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 0, ledger)
+		require.NoError(t, err)
+		defer tr1.Close()
+		tr2, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 1, linkedLedger(t, 60, "fork"))
+		require.NoError(t, err)
+		defer tr2.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:2])
+		require.NoError(t, err)
+
+		lastBlock, err := ledger.Get(10)
+		require.NoError(t, err)
+		blocks := pullAll(t, cc, lastBlock, 60, nil, 2)
+		require.Len(t, blocks, 50)
+		requireBlocks(t, ledger, 11, blocks)
+
+		blocks = pullAll(t, cc, lastBlock, 60, baseHash(t, ledger, 60), 2)
+		require.Len(t, blocks, 50)
+		requireBlocks(t, ledger, 11, blocks)
+	})
+
+	t.Run("byzantine member serves the retry", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 4)
+
+		// the first pull from the honest member fails, so the chunk is served by a byzantine member, which must not
+		// be pulled from again when the chunk is retried
+		ledger := linkedLedger(t, 30, "")
+		tr1, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 0, &failingLedger{memLedger: ledger, failures: 1})
+		require.NoError(t, err)
+		defer tr1.Close()
+		tr2, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 1, linkedLedger(t, 30, "fork"))
+		require.NoError(t, err)
+		defer tr2.Close()
+		tr3, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 2, linkedLedger(t, 30, "fork"))
+		require.NoError(t, err)
+		defer tr3.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:3])
+		require.NoError(t, err)
+
+		lastBlock, err := ledger.Get(10)
+		require.NoError(t, err)
+		blocks := pullAll(t, cc, lastBlock, 17, nil, 1)
+		require.Len(t, blocks, 7)
+		requireBlocks(t, ledger, 11, blocks)
+	})
+
+	t.Run("all members on a different chain", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 3)
+
+		ledger := linkedLedger(t, 30, "")
+		tr1, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 0, linkedLedger(t, 30, "fork"))
+		require.NoError(t, err)
+		defer tr1.Close()
+		tr2, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 1, linkedLedger(t, 30, "fork"))
+		require.NoError(t, err)
+		defer tr2.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:2])
+		require.NoError(t, err)
+
+		lastBlock, err := ledger.Get(10)
+		require.NoError(t, err)
+		err = cc.PullBlocksConcurrently(context.Background(), lastBlock, 17, nil, 0, func(blocks []*types.Block) error {
+			return nil
+		})
+		require.EqualError(t, err, "blocks [11,17] pulled from all members are not linked to block [10]")
+
+		err = cc.PullBlocksConcurrently(context.Background(), lastBlock, 17, baseHash(t, ledger, 17), 0, func(blocks []*types.Block) error {
+			return nil
+		})
+		require.EqualError(t, err, "blocks [11,17] pulled from all members do not match the hash of block [17]")
+	})
+
+	t.Run("forged chain linked to the last block", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 3)
+
+		// the byzantine member serves a chain that is linked to the last block of the honest one but differs after it,
+		// so that only the hash of the end block reveals it
+		ledger := linkedLedger(t, 30, "")
+		forged := forkedLedger(t, ledger, 10, 30, "fork")
+		tr1, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 0, forged)
+		require.NoError(t, err)
+		defer tr1.Close()
+		tr2, _, err := startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, 1, ledger)
+		require.NoError(t, err)
+		defer tr2.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:2])
+		require.NoError(t, err)
+
+		lastBlock, err := ledger.Get(10)
+		require.NoError(t, err)
+
+		// the first chunk is pulled from the byzantine member and the second one from the honest member, so that the
+		// link between them does not hold, while the earlier chunk is the forged one
+		blocks := pullAll(t, cc, lastBlock, 24, baseHash(t, ledger, 24), 1)
+		require.Len(t, blocks, 14)
+		requireBlocks(t, ledger, 11, blocks)
+
+		// all chunks are pulled from the byzantine member first
+		blocks = pullAll(t, cc, lastBlock, 30, baseHash(t, ledger, 30), 1)
+		require.Len(t, blocks, 20)
+		requireBlocks(t, ledger, 11, blocks)
+	})
+
+	t.Run("deliver error", func(t *testing.T) {
+		localConfigs, sharedConfig := newTestSetup(t, 2)
+
+		tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 50)
+		require.NoError(t, err)
+		defer tr1.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:1])
+		require.NoError(t, err)
+
+		var delivered int
+		err = cc.PullBlocksConcurrently(context.Background(), nil, 50, nil, 0, func(blocks []*types.Block) error {
+			delivered++
+			return errors.New("oops")
+		})
+		require.EqualError(t, err, "oops")
+		require.Equal(t, 1, delivered)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		mn := comm.RetryIntervalMin
+		mx := comm.RetryIntervalMax
+		comm.RetryIntervalMin = 100 * time.Microsecond
+		comm.RetryIntervalMax = time.Millisecond
+		defer func() {
+			comm.RetryIntervalMin = mn
+			comm.RetryIntervalMax = mx
+		}()
+
+		localConfigs, sharedConfig := newTestSetup(t, 2)
+
+		tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 50)
+		require.NoError(t, err)
+		defer tr1.Close()
+
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members[:1])
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var delivered uint64
+		errCh := make(chan error)
+		go func() {
+			errCh <- cc.PullBlocksConcurrently(ctx, nil, 100, nil, 0, func(blocks []*types.Block) error {
+				delivered += uint64(len(blocks))
+				return nil
+			})
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		require.EqualError(t, <-errCh, "PullBlocksConcurrently canceled: context canceled")
+		require.Equal(t, uint64(49), delivered) // the last chunk is [50,56], of which block 50 is available
+	})
+}
+
+func TestCatchUpClient_PullStateSnapshot(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+
+	tr1, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf:    localConfigs[0],
+		Logger:       lg,
+		LedgerReader: &memLedger{},
+		StateSnapshotProvider: stateSnapshotProvider(func(w io.Writer) error {
+			_, err := w.Write([]byte("state snapshot"))
+			return err
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr1.SetConsensusListener(&mocks.ConsensusListener{}))
+	require.NoError(t, tr1.SetClusterConfig(sharedConfig))
+	require.NoError(t, tr1.Start())
+	defer tr1.Close()
+
+	tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 5)
+	require.NoError(t, err)
+	defer tr2.Close()
+
+	cc := comm.NewCatchUpClient(lg, nil)
+	require.NotNil(t, cc)
+	err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+	require.NoError(t, err)
+
+	receive := func(snapshot *[]byte) func(r io.Reader) error {
+		return func(r io.Reader) error {
+			var err error
+			*snapshot, err = ioutil.ReadAll(r)
+			return err
+		}
+	}
+
+	//member 2 does not serve snapshots, member 1 is tried next
+	var snapshot []byte
+	err = cc.PullStateSnapshot(context.Background(), 2, receive(&snapshot))
+	require.NoError(t, err)
+	require.Equal(t, "state snapshot", string(snapshot))
+
+	err = cc.GetStateSnapshot(context.Background(), 2, receive(&snapshot))
+	require.EqualError(t, err, "member [2] does not serve state snapshots")
+
+	//the receiver rejects the snapshot of every member
+	err = cc.PullStateSnapshot(context.Background(), 1, func(r io.Reader) error {
+		return errors.New("bad snapshot")
+	})
+	require.EqualError(t, err, "member [2] does not serve state snapshots")
+}
+
+func TestCatchUpClient_PullAttestations(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+
+	tr1, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf:           localConfigs[0],
+		Logger:              lg,
+		LedgerReader:        &memLedger{},
+		AttestationProvider: memAttestations("node1", 5),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr1.SetConsensusListener(&mocks.ConsensusListener{}))
+	require.NoError(t, tr1.SetClusterConfig(sharedConfig))
+	require.NoError(t, tr1.Start())
+	defer tr1.Close()
+
+	tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 5)
+	require.NoError(t, err)
+	defer tr2.Close()
+
+	attestations, err := tr2.PullAttestations(context.Background(), 1, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, attestations, 4)
+	for i, a := range attestations {
+		require.Equal(t, uint64(2+i), a.BlockNumber)
+		require.Equal(t, "node1", a.NodeId)
+		require.Equal(t, []byte(fmt.Sprintf("sig-node1-%d", 2+i)), a.Signature)
+	}
+
+	attestations, err = tr2.PullAttestations(context.Background(), 1, 6, 10)
+	require.NoError(t, err)
+	require.Len(t, attestations, 0)
+
+	//member 2 does not serve attestations
+	_, err = tr1.PullAttestations(context.Background(), 2, 1, 5)
+	require.EqualError(t, err, "failed to get attestations [1,5] from member [2]: member [2] does not serve attestations")
+
+	_, err = tr2.PullAttestations(context.Background(), 3, 1, 5)
+	require.EqualError(t, err, "failed to get attestations [1,5] from member [3]: target ID [3] not found")
+}
+
+func TestCatchUpClient_Compression(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 1)
+
+	ledger := &memLedger{}
+	for n := uint64(1); n < 6; n++ {
+		ledger.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
+	}
+	tr1, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf:    localConfigs[0],
+		Logger:       lg,
+		LedgerReader: ledger,
+		StateSnapshotProvider: stateSnapshotProvider(func(w io.Writer) error {
+			_, err := w.Write([]byte(strings.Repeat("state snapshot", 1000)))
+			return err
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr1.SetConsensusListener(&mocks.ConsensusListener{}))
+	require.NoError(t, tr1.SetClusterConfig(sharedConfig))
+	require.NoError(t, tr1.Start())
+	defer tr1.Close()
+
+	cc := comm.NewCatchUpClient(lg, nil)
+	require.NotNil(t, cc)
+	err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+	require.NoError(t, err)
+
+	for _, algorithm := range []string{compression.None, compression.Gzip, compression.Zstd} {
+		cc.SetCompression(algorithm)
+
+		blocks, err := cc.GetBlocks(context.Background(), 1, 2, 4)
+		require.NoError(t, err, algorithm)
+		require.Len(t, blocks, 3)
+		for i, block := range blocks {
+			require.Equal(t, uint64(2+i), block.GetHeader().GetBaseHeader().GetNumber())
+		}
+
+		var snapshot []byte
+		err = cc.GetStateSnapshot(context.Background(), 1, func(r io.Reader) error {
+			var err error
+			snapshot, err = ioutil.ReadAll(r)
+			return err
+		})
+		require.NoError(t, err, algorithm)
+		require.Equal(t, strings.Repeat("state snapshot", 1000), string(snapshot))
+	}
+
+	cc.SetMaxDecompressedBytes(1000)
+	for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+		cc.SetCompression(algorithm)
+
+		err = cc.GetStateSnapshot(context.Background(), 1, func(r io.Reader) error {
+			_, err := ioutil.ReadAll(r)
+			return err
+		})
+		require.Error(t, err, algorithm)
+	}
+}
+
 func TestCatchUpClient_PullBlocksLoop(t *testing.T) {
 	lg, err := logger.New(&logger.Config{
 		Level:         "info",
@@ -478,10 +900,77 @@ func TestCatchUpClient_PullBlocksWithTLS(t *testing.T) {
 }
 
 func startTransportWithLedger(t *testing.T, lg *logger.SugarLogger, localConfigs []*config.LocalConfiguration, sharedConfig *types.ClusterConfig, index, height uint64) (*comm.HTTPTransport, *mocks.ConsensusListener, error) {
+	return startTransportWithMemLedger(t, lg, localConfigs, sharedConfig, index, linkedLedger(t, height, ""))
+}
+
+// failingLedger fails the first reads of blocks from the ledger
+type failingLedger struct {
+	*memLedger
+	mutex    sync.Mutex
+	failures int
+}
+
+func (l *failingLedger) Get(blockNum uint64) (*types.Block, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.failures > 0 {
+		l.failures--
+		return nil, errors.Errorf("failed to read block [%d]", blockNum)
+	}
+	return l.memLedger.Get(blockNum)
+}
+
+// linkedLedger creates a ledger of blocks that are linked by the previous base header hash; the data of the blocks
+// differs between ledgers created with a different fork label.
+func linkedLedger(t *testing.T, height uint64, fork string) *memLedger {
 	ledger := &memLedger{}
+	var prevBaseHash []byte
 	for n := uint64(1); n <= height; n++ {
-		ledger.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                 n,
+					PreviousBaseHeaderHash: prevBaseHash,
+					LastCommittedBlockHash: []byte(fork),
+				},
+			},
+		}
+		require.NoError(t, ledger.Append(block))
+		var err error
+		prevBaseHash, err = blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
+	}
+	return ledger
+}
+
+// forkedLedger creates a ledger which holds the blocks of the given ledger up to block `forkFrom`, followed by blocks
+// that are linked to them, and whose data differs by the fork label.
+func forkedLedger(t *testing.T, ledger *memLedger, forkFrom, height uint64, fork string) *memLedger {
+	forked := &memLedger{}
+	var prevBaseHash []byte
+	for n := uint64(1); n <= height; n++ {
+		block, err := ledger.Get(n)
+		require.NoError(t, err)
+		if n > forkFrom {
+			block = &types.Block{
+				Header: &types.BlockHeader{
+					BaseHeader: &types.BlockHeaderBase{
+						Number:                 n,
+						PreviousBaseHeaderHash: prevBaseHash,
+						LastCommittedBlockHash: []byte(fork),
+					},
+				},
+			}
+		}
+		require.NoError(t, forked.Append(block))
+		prevBaseHash, err = blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
 	}
+	return forked
+}
+
+func startTransportWithMemLedger(t *testing.T, lg *logger.SugarLogger, localConfigs []*config.LocalConfiguration, sharedConfig *types.ClusterConfig, index uint64, ledger comm.LedgerReader) (*comm.HTTPTransport, *mocks.ConsensusListener, error) {
 	cl := &mocks.ConsensusListener{}
 	tr, err := comm.NewHTTPTransport(&comm.Config{
 		LocalConf:    localConfigs[index],
@@ -5,14 +5,20 @@ package comm
 
 import (
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
+	"github.com/hyperledger-labs/orion-server/internal/comm/peerpb"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -20,6 +26,9 @@ const (
 	GetBlocksPath    = BCDBPeerEndpoint + "blocks"
 	GetHeightPath    = BCDBPeerEndpoint + "height"
 
+	GetStateSnapshotPath = BCDBPeerEndpoint + "state-snapshot"
+	GetAttestationsPath  = BCDBPeerEndpoint + "attestations"
+
 	maxResponseBytesDefault = 100 * 1024 * 1024 // protects the server against huge requests from a client
 )
 
@@ -30,19 +39,40 @@ type LedgerReader interface {
 	Get(blockNumber uint64) (*types.Block, error)
 }
 
+// StateSnapshotProvider provides snapshots of the state to remote peers that are far behind the cluster.
+type StateSnapshotProvider interface {
+	// WriteStateSnapshot takes a snapshot of the state and writes it to w. If the snapshot cannot be taken, an error
+	// is returned before anything is written.
+	WriteStateSnapshot(w io.Writer) error
+}
+
+// AttestationProvider provides the attestations of the local node on the headers of the committed blocks to remote
+// peers, which collect them into quorum certificates.
+type AttestationProvider interface {
+	// GetAttestations returns the attestations of the local node on the blocks from start to end (inclusive). Blocks
+	// that are not attested yet are skipped.
+	GetAttestations(start, end uint64) ([]*types.BlockAttestation, error)
+}
+
 type catchupHandler struct {
-	router           *mux.Router
-	lg               *logger.SugarLogger
-	ledgerReader     LedgerReader
-	maxResponseBytes int
+	router                *mux.Router
+	lg                    *logger.SugarLogger
+	ledgerReader          LedgerReader
+	stateSnapshotProvider StateSnapshotProvider
+	attestationProvider   AttestationProvider
+	maxResponseBytes      int
 }
 
-func NewCatchupHandler(lg *logger.SugarLogger, ledgerReader LedgerReader, maxResponseBytes int) *catchupHandler {
+// NewCatchupHandler creates a handler that serves blocks to remote peers. If stateSnapshotProvider is not nil, the
+// handler serves state snapshots as well, and if attestationProvider is not nil, it serves block attestations.
+func NewCatchupHandler(lg *logger.SugarLogger, ledgerReader LedgerReader, stateSnapshotProvider StateSnapshotProvider, attestationProvider AttestationProvider, maxResponseBytes int) *catchupHandler {
 	h := &catchupHandler{
-		router:           mux.NewRouter(),
-		lg:               lg,
-		ledgerReader:     ledgerReader,
-		maxResponseBytes: maxResponseBytesDefault,
+		router:                mux.NewRouter(),
+		lg:                    lg,
+		ledgerReader:          ledgerReader,
+		stateSnapshotProvider: stateSnapshotProvider,
+		attestationProvider:   attestationProvider,
+		maxResponseBytes:      maxResponseBytesDefault,
 	}
 
 	if maxResponseBytes > 0 {
@@ -51,6 +81,12 @@ func NewCatchupHandler(lg *logger.SugarLogger, ledgerReader LedgerReader, maxRes
 
 	h.router.HandleFunc(GetBlocksPath, h.blocksRequest).Methods(http.MethodGet).Headers("Accept", "multipart/form-data").Queries("start", "{startId:[0-9]+}", "end", "{endId:[0-9]+}")
 	h.router.HandleFunc(GetHeightPath, h.heightRequest).Methods(http.MethodGet)
+	if stateSnapshotProvider != nil {
+		h.router.HandleFunc(GetStateSnapshotPath, h.stateSnapshotRequest).Methods(http.MethodGet)
+	}
+	if attestationProvider != nil {
+		h.router.HandleFunc(GetAttestationsPath, h.attestationsRequest).Methods(http.MethodGet).Queries("start", "{startId:[0-9]+}", "end", "{endId:[0-9]+}")
+	}
 
 	return h
 }
@@ -114,11 +150,24 @@ func (h *catchupHandler) blocksRequest(response http.ResponseWriter, request *ht
 		blocks = append(blocks, blockBytes)
 	}
 
-	sendHTTPMultiPartResponse(response, blocks, startBlockNum)
+	sendHTTPMultiPartResponse(response, blocks, startBlockNum, negotiateCompression(request.Header.Get("Accept-Encoding")))
 }
 
-func sendHTTPMultiPartResponse(w http.ResponseWriter, blocks [][]byte, startBlockNum uint64) {
-	mw := multipart.NewWriter(w)
+// sendHTTPMultiPartResponse sends the blocks in a multipart response, compressed with the algorithm if it is not empty.
+func sendHTTPMultiPartResponse(w http.ResponseWriter, blocks [][]byte, startBlockNum uint64, algorithm string) {
+	var body io.Writer = w
+	var zw io.WriteCloser
+	if algorithm != "" {
+		var err error
+		if zw, err = compression.NewObservedWriter(compression.PathCatchUp, algorithm, w); err != nil {
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+		w.Header().Set("Content-Encoding", algorithm)
+		body = zw
+	}
+
+	mw := multipart.NewWriter(body)
 	w.Header().Set("Content-Type", mw.FormDataContentType())
 	for i, blockBytes := range blocks {
 		fw, err := mw.CreateFormFile(
@@ -137,6 +186,37 @@ func sendHTTPMultiPartResponse(w http.ResponseWriter, blocks [][]byte, startBloc
 		utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
 		return
 	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+	}
+}
+
+// negotiateCompression picks the first compression algorithm in the Accept-Encoding header of the request that is
+// supported, or returns an empty string if the response should not be compressed.
+func negotiateCompression(acceptEncoding string) string {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(coding, ";")
+		algorithm := strings.TrimSpace(params[0])
+		if !compression.Enabled(algorithm) {
+			continue
+		}
+
+		refused := false
+		for _, p := range params[1:] {
+			if q := strings.TrimSpace(p); strings.HasPrefix(q, "q=") {
+				if weight, err := strconv.ParseFloat(q[2:], 64); err == nil && weight == 0 {
+					refused = true
+				}
+			}
+		}
+		if !refused {
+			return algorithm
+		}
+	}
+	return ""
 }
 
 type HeightResponse struct {
@@ -153,3 +233,108 @@ func (h *catchupHandler) heightRequest(w http.ResponseWriter, r *http.Request) {
 
 	utils.SendHTTPResponse(w, http.StatusOK, HeightResponse{Height: height})
 }
+
+func (h *catchupHandler) stateSnapshotRequest(w http.ResponseWriter, r *http.Request) {
+	h.lg.Infof("state snapshot request: %s", r.URL)
+	w.Header().Set("Content-Type", "application/octet-stream")
+
+	tw := &trackingWriter{w: w}
+	var zw io.WriteCloser
+	if algorithm := negotiateCompression(r.Header.Get("Accept-Encoding")); algorithm != "" {
+		var err error
+		if zw, err = compression.NewObservedWriter(compression.PathCatchUp, algorithm, w); err != nil {
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+		w.Header().Set("Content-Encoding", algorithm)
+		tw.w = zw
+	}
+
+	err := h.stateSnapshotProvider.WriteStateSnapshot(tw)
+	if err == nil && zw != nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		if !tw.written {
+			w.Header().Del("Content-Encoding")
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+		// the response is already partially sent, the client detects the truncated stream
+		h.lg.Errorf("error while sending the state snapshot: %s", err)
+		return
+	}
+
+	h.lg.Infof("state snapshot sent, %d bytes", tw.n)
+}
+
+func (h *catchupHandler) attestationsRequest(w http.ResponseWriter, r *http.Request) {
+	startBlockNum, endBlockNum, err := utils.GetStartAndEndBlockNum(mux.Vars(r))
+	if err != nil {
+		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	respBytes, err := marshalAttestations(h.attestationProvider, startBlockNum, endBlockNum)
+	if err != nil {
+		utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(respBytes); err != nil {
+		h.lg.Errorf("error while sending the attestations: %s", err)
+	}
+}
+
+// marshalAttestations returns a marshaled peerpb.Attestations of the attestations on the blocks [start,end] which the
+// provider has.
+func marshalAttestations(provider AttestationProvider, start, end uint64) ([]byte, error) {
+	attestations, err := provider.GetAttestations(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &peerpb.Attestations{}
+	for _, a := range attestations {
+		aBytes, err := proto.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		resp.Attestations = append(resp.Attestations, aBytes)
+	}
+	return proto.Marshal(resp)
+}
+
+// unmarshalAttestations returns the attestations of a marshaled peerpb.Attestations.
+func unmarshalAttestations(respBytes []byte) ([]*types.BlockAttestation, error) {
+	resp := &peerpb.Attestations{}
+	if err := proto.Unmarshal(respBytes, resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal attestations")
+	}
+
+	var attestations []*types.BlockAttestation
+	for _, aBytes := range resp.Attestations {
+		a := &types.BlockAttestation{}
+		if err := proto.Unmarshal(aBytes, a); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal attestation")
+		}
+		attestations = append(attestations, a)
+	}
+	return attestations, nil
+}
+
+// trackingWriter tracks whether anything was written to the response
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+	n       int64
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	n, err := t.w.Write(p)
+	t.n += int64(n)
+	return n, err
+}
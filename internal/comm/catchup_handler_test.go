@@ -12,11 +12,14 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
 	"github.com/hyperledger-labs/orion-server/internal/comm/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/comm/peerpb"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -34,7 +37,7 @@ func TestNewCatchupHandler(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	h := comm.NewCatchupHandler(lg, nil, 0)
+	h := comm.NewCatchupHandler(lg, nil, nil, nil, 0)
 	require.NotNil(t, h)
 }
 
@@ -49,7 +52,7 @@ func TestCatchupHandler_ServeHTTP_Height(t *testing.T) {
 
 	t.Run("height ok", func(t *testing.T) {
 		ledgerReader := &mocks.LedgerReader{}
-		h := comm.NewCatchupHandler(lg, ledgerReader, 0)
+		h := comm.NewCatchupHandler(lg, ledgerReader, nil, nil, 0)
 		require.NotNil(t, h)
 
 		resp := httptest.NewRecorder()
@@ -70,7 +73,7 @@ func TestCatchupHandler_ServeHTTP_Height(t *testing.T) {
 
 	t.Run("height error", func(t *testing.T) {
 		ledgerReader := &mocks.LedgerReader{}
-		h := comm.NewCatchupHandler(lg, ledgerReader, 0)
+		h := comm.NewCatchupHandler(lg, ledgerReader, nil, nil, 0)
 		require.NotNil(t, h)
 
 		resp := httptest.NewRecorder()
@@ -104,7 +107,7 @@ func TestCatchupHandler_ServeHTTP_Blocks(t *testing.T) {
 		ledger1.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
 	}
 
-	h := comm.NewCatchupHandler(lg, ledger1, 0)
+	h := comm.NewCatchupHandler(lg, ledger1, nil, nil, 0)
 	require.NotNil(t, h)
 
 	t.Run("bad: no parameters", func(t *testing.T) {
@@ -280,7 +283,7 @@ func TestCatchupHandler_ServeHTTP_LargeResponse(t *testing.T) {
 	}
 
 	t.Run("too many blocks in request", func(t *testing.T) {
-		h := comm.NewCatchupHandler(lg, ledger1, b5Size) // 5 blocks in response
+		h := comm.NewCatchupHandler(lg, ledger1, nil, nil, b5Size) // 5 blocks in response
 		require.NotNil(t, h)
 
 		resp := httptest.NewRecorder()
@@ -321,7 +324,7 @@ func TestCatchupHandler_ServeHTTP_LargeResponse(t *testing.T) {
 	})
 
 	t.Run("blocks are bigger than max-response-size", func(t *testing.T) {
-		h := comm.NewCatchupHandler(lg, ledger1, b1Size/2) // 1 block in response
+		h := comm.NewCatchupHandler(lg, ledger1, nil, nil, b1Size/2) // 1 block in response
 		require.NotNil(t, h)
 
 		resp := httptest.NewRecorder()
@@ -361,3 +364,273 @@ func TestCatchupHandler_ServeHTTP_LargeResponse(t *testing.T) {
 		require.Equal(t, uint64(3), bNum) // block 2 in response
 	})
 }
+
+type stateSnapshotProvider func(w io.Writer) error
+
+func (p stateSnapshotProvider) WriteStateSnapshot(w io.Writer) error {
+	return p(w)
+}
+
+type attestationProvider func(start, end uint64) ([]*types.BlockAttestation, error)
+
+func (p attestationProvider) GetAttestations(start, end uint64) ([]*types.BlockAttestation, error) {
+	return p(start, end)
+}
+
+// memAttestations provides the attestations of the node on the blocks [1,height]
+func memAttestations(nodeID string, height uint64) attestationProvider {
+	return func(start, end uint64) ([]*types.BlockAttestation, error) {
+		var attestations []*types.BlockAttestation
+		for n := start; n <= end && n <= height; n++ {
+			attestations = append(attestations, &types.BlockAttestation{
+				BlockNumber: n,
+				NodeId:      nodeID,
+				Signature:   []byte(fmt.Sprintf("sig-%s-%d", nodeID, n)),
+			})
+		}
+		return attestations, nil
+	}
+}
+
+func TestCatchupHandler_ServeHTTP_Attestations(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	attestationsURL := func(start, end uint64) string {
+		return fmt.Sprintf("%s?start=%d&end=%d", comm.GetAttestationsPath, start, end)
+	}
+
+	t.Run("attestations ok", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, nil, memAttestations("node1", 5), 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, attestationsURL(3, 10), nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+		body, err := ioutil.ReadAll(resp.Result().Body)
+		require.NoError(t, err)
+		attestations := &peerpb.Attestations{}
+		require.NoError(t, proto.Unmarshal(body, attestations))
+		require.Len(t, attestations.Attestations, 3)
+		for i, aBytes := range attestations.Attestations {
+			a := &types.BlockAttestation{}
+			require.NoError(t, proto.Unmarshal(aBytes, a))
+			require.Equal(t, uint64(3+i), a.BlockNumber)
+			require.Equal(t, "node1", a.NodeId)
+		}
+	})
+
+	t.Run("bad range", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, nil, memAttestations("node1", 5), 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, attestationsURL(4, 3), nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Result().StatusCode)
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		require.Equal(t, &types.HttpResponseErr{ErrMsg: "query error: startId=4 > endId=3"}, errResp)
+	})
+
+	t.Run("attestations error", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, nil, attestationProvider(func(start, end uint64) ([]*types.BlockAttestation, error) {
+			return nil, errors.New("oops")
+		}), 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, attestationsURL(1, 3), nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusInternalServerError, resp.Result().StatusCode)
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		require.Equal(t, &types.HttpResponseErr{ErrMsg: "oops"}, errResp)
+	})
+
+	t.Run("attestations not served", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, nil, nil, 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, attestationsURL(1, 3), nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+	})
+}
+
+func TestCatchupHandler_ServeHTTP_StateSnapshot(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	t.Run("snapshot ok", func(t *testing.T) {
+		provider := stateSnapshotProvider(func(w io.Writer) error {
+			_, err := w.Write([]byte("state snapshot"))
+			return err
+		})
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, provider, nil, 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetStateSnapshotPath, nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		body, err := ioutil.ReadAll(resp.Result().Body)
+		require.NoError(t, err)
+		require.Equal(t, "state snapshot", string(body))
+	})
+
+	t.Run("snapshot error", func(t *testing.T) {
+		provider := stateSnapshotProvider(func(w io.Writer) error {
+			return errors.New("oops")
+		})
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, provider, nil, 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetStateSnapshotPath, nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusInternalServerError, resp.Result().StatusCode)
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		require.Equal(t, &types.HttpResponseErr{ErrMsg: "oops"}, errResp)
+	})
+
+	t.Run("snapshots not served", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, nil, nil, 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetStateSnapshotPath, nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+	})
+}
+
+func TestCatchupHandler_ServeHTTP_Compression(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	ledger1 := &memLedger{}
+	for n := uint64(1); n < 6; n++ {
+		ledger1.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
+	}
+	provider := stateSnapshotProvider(func(w io.Writer) error {
+		_, err := w.Write([]byte(strings.Repeat("state snapshot ", 100)))
+		return err
+	})
+	h := comm.NewCatchupHandler(lg, ledger1, provider, nil, 0)
+
+	blocksRequest := func(acceptEncoding string) *http.Response {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetBlocksPath, nil)
+		q := req.URL.Query()
+		q.Add("start", "2")
+		q.Add("end", "4")
+		req.URL.RawQuery = q.Encode()
+		req.Header.Set("Accept", utils.MultiPartFormData)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		return resp.Result()
+	}
+
+	requireBlocks := func(body io.Reader, contentType string) {
+		_, params, err := mime.ParseMediaType(contentType)
+		require.NoError(t, err)
+		mr := multipart.NewReader(body, params["boundary"])
+		num := uint64(2)
+		for part, errP := mr.NextPart(); errP != io.EOF; part, errP = mr.NextPart() {
+			require.NoError(t, errP)
+			blockBytes, err := ioutil.ReadAll(part)
+			require.NoError(t, err)
+			block := &types.Block{}
+			require.NoError(t, proto.Unmarshal(blockBytes, block))
+			require.Equal(t, num, block.GetHeader().GetBaseHeader().GetNumber())
+			num++
+		}
+		require.Equal(t, uint64(5), num)
+	}
+
+	for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+		t.Run("blocks "+algorithm, func(t *testing.T) {
+			resp := blocksRequest(algorithm)
+			require.Equal(t, algorithm, resp.Header.Get("Content-Encoding"))
+			body, err := compression.NewReader(algorithm, resp.Body)
+			require.NoError(t, err)
+			defer body.Close()
+			requireBlocks(body, resp.Header.Get("Content-Type"))
+		})
+
+		t.Run("snapshot "+algorithm, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, comm.GetStateSnapshotPath, nil)
+			req.Header.Set("Accept-Encoding", algorithm)
+			h.ServeHTTP(resp, req)
+			require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+			require.Equal(t, algorithm, resp.Result().Header.Get("Content-Encoding"))
+			require.Less(t, resp.Body.Len(), 1500)
+
+			body, err := compression.NewReader(algorithm, resp.Result().Body)
+			require.NoError(t, err)
+			defer body.Close()
+			snapshot, err := ioutil.ReadAll(body)
+			require.NoError(t, err)
+			require.Equal(t, strings.Repeat("state snapshot ", 100), string(snapshot))
+		})
+	}
+
+	t.Run("negotiation", func(t *testing.T) {
+		for acceptEncoding, expected := range map[string]string{
+			"":                      "",
+			"br, deflate":           "",
+			"br, zstd;q=0.5, gzip":  compression.Zstd,
+			"zstd;q=0, gzip;q=0.1":  compression.Gzip,
+			"gzip;q=0, zstd;q=0":    "",
+			" GZIP , gzip ":         compression.Gzip,
+			"identity, none, zstd ": compression.Zstd,
+		} {
+			resp := blocksRequest(acceptEncoding)
+			require.Equal(t, expected, resp.Header.Get("Content-Encoding"), acceptEncoding)
+			if expected == "" {
+				requireBlocks(resp.Body, resp.Header.Get("Content-Type"))
+			}
+		}
+	})
+
+	t.Run("snapshot error", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, ledger1, stateSnapshotProvider(func(w io.Writer) error {
+			return errors.New("oops")
+		}), nil, 0)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetStateSnapshotPath, nil)
+		req.Header.Set("Accept-Encoding", compression.Zstd)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusInternalServerError, resp.Result().StatusCode)
+		require.Equal(t, "", resp.Result().Header.Get("Content-Encoding"))
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		require.Equal(t, &types.HttpResponseErr{ErrMsg: "oops"}, errResp)
+	})
+}
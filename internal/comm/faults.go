@@ -0,0 +1,230 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+// FaultInjector injects network faults into the Raft messages a transport sends to the remote peers, and into the
+// catch-up requests it makes to them, in order to test the cluster under partitions, message loss, duplication and
+// delay. It is meant for testing only.
+//
+// A fault applies to the messages sent from one node to another, and is injected by the sending node; hence, in order
+// to produce a consistent view of the network, the same faults should be set in all the nodes. A partition is
+// asymmetric unless faults are set in both directions. A catch-up request to a remote peer fails if either direction
+// between the local node and the peer is dropped, and is delayed by the larger delay of the two directions.
+//
+// The drops and duplicates are random, drawn from a source that is re-seeded every time the faults are set, so that a
+// test may reproduce them. The component is thread safe.
+type FaultInjector struct {
+	localID string
+
+	mutex   sync.Mutex
+	faults  []*types.NetworkFault
+	rand    *rand.Rand
+	nodeIDs map[uint64]string // Raft ID to node ID
+
+	logger *logger.SugarLogger
+}
+
+// NewFaultInjector creates a FaultInjector of the node `localID`, with no faults.
+func NewFaultInjector(localID string, lg *logger.SugarLogger) *FaultInjector {
+	return &FaultInjector{
+		localID: localID,
+		rand:    rand.New(rand.NewSource(0)),
+		nodeIDs: make(map[uint64]string),
+		logger:  lg,
+	}
+}
+
+// SetFaults replaces the injected faults, and re-seeds the random source of the drops and duplicates. An empty list of
+// faults heals the network.
+func (f *FaultInjector) SetFaults(faults []*types.NetworkFault, seed int64) error {
+	var copied []*types.NetworkFault
+	for _, fault := range faults {
+		if fault == nil {
+			return errors.New("network fault is nil")
+		}
+		if fault.DropRate < 0 || fault.DropRate > 1 {
+			return errors.Errorf("network fault drop rate must be in [0,1], but is: %v", fault.DropRate)
+		}
+		if fault.DuplicateRate < 0 || fault.DuplicateRate > 1 {
+			return errors.Errorf("network fault duplicate rate must be in [0,1], but is: %v", fault.DuplicateRate)
+		}
+		copied = append(copied, proto.Clone(fault).(*types.NetworkFault))
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.faults = copied
+	f.rand = rand.New(rand.NewSource(seed))
+	f.logger.Infof("network faults set, seed: %d, faults: %v", seed, copied)
+
+	return nil
+}
+
+// Faults returns the injected faults.
+func (f *FaultInjector) Faults() []*types.NetworkFault {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var faults []*types.NetworkFault
+	for _, fault := range f.faults {
+		faults = append(faults, proto.Clone(fault).(*types.NetworkFault))
+	}
+	return faults
+}
+
+// updatePeers refreshes the mapping from Raft IDs to node IDs from the cluster config. It is safe to call on a nil
+// injector.
+func (f *FaultInjector) updatePeers(clusterConfig *types.ClusterConfig) {
+	if f == nil {
+		return
+	}
+
+	nodeIDs := make(map[uint64]string)
+	for _, peer := range consensusPeers(clusterConfig) {
+		nodeIDs[peer.RaftId] = peer.NodeId
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.nodeIDs = nodeIDs
+}
+
+// judge decides the fate of a single message from node `from` to node `to`. The delay is the largest delay of all the
+// matching faults.
+func (f *FaultInjector) judge(from, to string) (drop, duplicate bool, delay time.Duration) {
+	for _, fault := range f.faults {
+		if (fault.FromNodeId != "" && fault.FromNodeId != from) || (fault.ToNodeId != "" && fault.ToNodeId != to) {
+			continue
+		}
+		if fault.Partition {
+			return true, false, 0
+		}
+		if fault.DropRate > 0 && f.rand.Float64() < fault.DropRate {
+			return true, false, 0
+		}
+		if fault.DuplicateRate > 0 && f.rand.Float64() < fault.DuplicateRate {
+			duplicate = true
+		}
+		if d := time.Duration(fault.DelayMs) * time.Millisecond; d > delay {
+			delay = d
+		}
+	}
+	return false, duplicate, delay
+}
+
+// injectConsensus injects the faults into the Raft messages sent by the local node. It returns the messages that should
+// be sent immediately; the delayed messages are passed to `send` later on, unless `stopCh` is closed by then.
+func (f *FaultInjector) injectConsensus(msgs []raftpb.Message, stopCh <-chan struct{}, send func(msgs []raftpb.Message)) []raftpb.Message {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.faults) == 0 {
+		return msgs
+	}
+
+	var immediate []raftpb.Message
+	for _, m := range msgs {
+		drop, duplicate, delay := f.judge(f.localID, f.nodeIDs[m.To])
+		if drop {
+			f.logger.Debugf("fault injection: dropped message, Type: %s, To: %d", m.Type, m.To)
+			continue
+		}
+
+		copies := []raftpb.Message{m}
+		if duplicate {
+			f.logger.Debugf("fault injection: duplicated message, Type: %s, To: %d", m.Type, m.To)
+			copies = append(copies, m)
+		}
+
+		if delay == 0 {
+			immediate = append(immediate, copies...)
+			continue
+		}
+
+		time.AfterFunc(delay, func() {
+			select {
+			case <-stopCh:
+			default:
+				send(copies)
+			}
+		})
+	}
+
+	return immediate
+}
+
+// injectCatchUp injects the faults into a catch-up request of the local node to the remote peer `targetID`, and into
+// its response. It waits for the delay, and returns an error if the request or the response is dropped.
+func (f *FaultInjector) injectCatchUp(ctx context.Context, targetID uint64) error {
+	f.mutex.Lock()
+	targetNodeID := f.nodeIDs[targetID]
+	dropRequest, _, requestDelay := f.judge(f.localID, targetNodeID)
+	dropResponse, _, responseDelay := f.judge(targetNodeID, f.localID)
+	f.mutex.Unlock()
+
+	if dropRequest || dropResponse {
+		return errors.Errorf("catch-up request to member [%d] dropped by fault injection", targetID)
+	}
+
+	delay := requestDelay
+	if responseDelay > delay {
+		delay = responseDelay
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// faultyCatchUpRemote injects the faults of a FaultInjector into the requests of a catchUpRemote.
+type faultyCatchUpRemote struct {
+	remote   catchUpRemote
+	injector *FaultInjector
+}
+
+func (r *faultyCatchUpRemote) GetBlocks(ctx context.Context, targetID, start, end uint64) ([]*types.Block, error) {
+	if err := r.injector.injectCatchUp(ctx, targetID); err != nil {
+		return nil, err
+	}
+	return r.remote.GetBlocks(ctx, targetID, start, end)
+}
+
+func (r *faultyCatchUpRemote) GetStateSnapshot(ctx context.Context, targetID uint64, receive func(r io.Reader) error) error {
+	if err := r.injector.injectCatchUp(ctx, targetID); err != nil {
+		return err
+	}
+	return r.remote.GetStateSnapshot(ctx, targetID, receive)
+}
+
+func (r *faultyCatchUpRemote) GetAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	if err := r.injector.injectCatchUp(ctx, targetID); err != nil {
+		return nil, err
+	}
+	return r.remote.GetAttestations(ctx, targetID, start, end)
+}
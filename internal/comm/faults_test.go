@@ -0,0 +1,169 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/internal/comm/mocks"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+func TestFaultInjector_SetFaults(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	f := comm.NewFaultInjector("node1", lg)
+	require.Len(t, f.Faults(), 0)
+
+	faults := []*types.NetworkFault{
+		{FromNodeId: "node1", ToNodeId: "node2", Partition: true},
+		{ToNodeId: "node3", DropRate: 0.5, DuplicateRate: 1, DelayMs: 100},
+	}
+	require.NoError(t, f.SetFaults(faults, 7))
+	require.Len(t, f.Faults(), 2)
+	for i, fault := range f.Faults() {
+		require.True(t, proto.Equal(faults[i], fault))
+	}
+
+	err = f.SetFaults([]*types.NetworkFault{{DropRate: 1.5}}, 7)
+	require.EqualError(t, err, "network fault drop rate must be in [0,1], but is: 1.5")
+	err = f.SetFaults([]*types.NetworkFault{{DuplicateRate: -1}}, 7)
+	require.EqualError(t, err, "network fault duplicate rate must be in [0,1], but is: -1")
+	err = f.SetFaults([]*types.NetworkFault{nil}, 7)
+	require.EqualError(t, err, "network fault is nil")
+	require.Len(t, f.Faults(), 2, "invalid faults are not set")
+
+	require.NoError(t, f.SetFaults(nil, 0))
+	require.Len(t, f.Faults(), 0)
+}
+
+// Scenario: two peers with fault injection, over both transports.
+// - an asymmetric partition drops the messages in one direction only;
+// - duplication sends every message twice;
+// - delay holds the messages back;
+// - healing the network delivers the messages again;
+// - a catch-up request fails if either direction is partitioned.
+func TestTransport_FaultInjection(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	for _, transport := range []string{comm.TransportHTTP, comm.TransportGRPC} {
+		t.Run(transport, func(t *testing.T) {
+			localConfigs, sharedConfig := newTestSetup(t, 2)
+
+			var trs []comm.Transport
+			var cls []*mocks.ConsensusListener
+			var injectors []*comm.FaultInjector
+			for i, localConf := range localConfigs {
+				localConf.Replication.Transport = transport
+				injector := comm.NewFaultInjector(localConf.Server.Identity.ID, lg)
+				tr, err := comm.NewTransport(&comm.Config{
+					LocalConf:     localConf,
+					Logger:        lg,
+					LedgerReader:  linkedLedger(t, uint64(5*(i+1)), ""),
+					FaultInjector: injector,
+				})
+				require.NoError(t, err)
+				cl := &mocks.ConsensusListener{}
+				require.NoError(t, tr.SetConsensusListener(cl))
+				require.NoError(t, tr.SetClusterConfig(sharedConfig))
+				require.NoError(t, tr.Start())
+				defer tr.Close()
+
+				trs = append(trs, tr)
+				cls = append(cls, cl)
+				injectors = append(injectors, injector)
+			}
+
+			setFaults := func(faults ...*types.NetworkFault) {
+				for _, injector := range injectors {
+					require.NoError(t, injector.SetFaults(faults, 1))
+				}
+			}
+
+			require.Eventually(t,
+				func() bool {
+					return len(trs[0].ActivePeers(10*time.Millisecond, false)) == 1 &&
+						len(trs[1].ActivePeers(10*time.Millisecond, false)) == 1
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+
+			// asymmetric partition: node1 cannot reach node2, node2 reaches node1
+			setFaults(&types.NetworkFault{FromNodeId: "node1", ToNodeId: "node2", Partition: true})
+			require.NoError(t, trs[0].SendConsensus([]raftpb.Message{{To: 2, From: 1}}))
+			require.NoError(t, trs[1].SendConsensus([]raftpb.Message{{To: 1, From: 2}}))
+			require.Eventually(t,
+				func() bool {
+					return cls[0].ProcessCallCount() == 1
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+
+			_, err = trs[1].PullBlocks(ctxWithTimeout(t, time.Second), 1, 5, 1)
+			require.Error(t, err, "the response of node1 to node2 is dropped")
+			_, err = trs[0].PullBlocks(ctxWithTimeout(t, time.Second), 1, 5, 2)
+			require.Error(t, err, "the request of node1 to node2 is dropped")
+
+			// duplication
+			setFaults(&types.NetworkFault{DuplicateRate: 1})
+			require.NoError(t, trs[0].SendConsensus([]raftpb.Message{{To: 2, From: 1}}))
+			require.Eventually(t,
+				func() bool {
+					return cls[1].ProcessCallCount() == 2
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+
+			// delay
+			setFaults(&types.NetworkFault{ToNodeId: "node1", DelayMs: 500})
+			sent := time.Now()
+			require.NoError(t, trs[1].SendConsensus([]raftpb.Message{{To: 1, From: 2}}))
+			require.Eventually(t,
+				func() bool {
+					return cls[0].ProcessCallCount() == 2
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+			require.True(t, time.Since(sent) >= 500*time.Millisecond)
+
+			// healed: only the messages sent after healing arrive
+			setFaults()
+			require.NoError(t, trs[0].SendConsensus([]raftpb.Message{{To: 2, From: 1}}))
+			require.Eventually(t,
+				func() bool {
+					return cls[1].ProcessCallCount() == 3
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+			blocks, err := trs[0].PullBlocks(context.Background(), 6, 10, 2)
+			require.NoError(t, err)
+			require.Len(t, blocks, 5)
+		})
+	}
+}
+
+func ctxWithTimeout(t *testing.T, timeout time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+	return ctx
+}
@@ -0,0 +1,346 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm/peerpb"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft/raftpb"
+	"google.golang.org/grpc"
+)
+
+// the size of the chunks a state snapshot is sent in
+const stateSnapshotChunkBytes = 1024 * 1024
+
+// grpcPeerServer serves the Raft streams and the catch-up streams of the remote peers.
+type grpcPeerServer struct {
+	raftID                uint64
+	consensusListener     ConsensusListener
+	ledgerReader          LedgerReader
+	stateSnapshotProvider StateSnapshotProvider
+	attestationProvider   AttestationProvider
+	maxResponseBytes      int
+	logger                *logger.SugarLogger
+}
+
+// Step identifies the local peer, and passes the Raft messages received on the stream to the consensus listener.
+func (s *grpcPeerServer) Step(stream peerpb.Peer_StepServer) error {
+	if err := stream.Send(&peerpb.StepResponse{RaftId: s.raftID}); err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		m := raftpb.Message{}
+		if err := m.Unmarshal(req.Message); err != nil {
+			return errors.Wrap(err, "failed to unmarshal Raft message")
+		}
+		if s.consensusListener.IsIDRemoved(m.From) {
+			return errors.Errorf("peer [%d] was removed from the cluster", m.From)
+		}
+		if err := s.consensusListener.Process(stream.Context(), m); err != nil {
+			s.logger.Warnf("failed to process Raft message from peer [%d]: %s", m.From, err)
+		}
+	}
+}
+
+// CatchUp answers the requests received on the stream, one after the other.
+func (s *grpcPeerServer) CatchUp(stream peerpb.Peer_CatchUpServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch r := req.Request.(type) {
+		case *peerpb.CatchUpRequest_Blocks:
+			err = s.sendBlocks(stream, r.Blocks)
+		case *peerpb.CatchUpRequest_StateSnapshot:
+			err = s.sendStateSnapshot(stream)
+		case *peerpb.CatchUpRequest_Attestations:
+			err = s.sendAttestations(stream, r.Attestations)
+		default:
+			err = sendCatchUpError(stream, "unknown catch-up request")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *grpcPeerServer) sendBlocks(stream peerpb.Peer_CatchUpServer, req *peerpb.BlocksRequest) error {
+	s.logger.Debugf("blocks request: [%d,%d]", req.StartBlock, req.EndBlock)
+
+	height, err := s.ledgerReader.Height()
+	if err != nil {
+		return sendCatchUpError(stream, err.Error())
+	}
+
+	if req.StartBlock < 1 {
+		return sendCatchUpError(stream, fmt.Sprintf("requested start block [%d] must be greater than 0", req.StartBlock))
+	}
+	if req.StartBlock > height {
+		return sendCatchUpError(stream, fmt.Sprintf("requested start block [%d] is out of range, height is [%d]", req.StartBlock, height))
+	}
+	if req.EndBlock < req.StartBlock {
+		return sendCatchUpError(stream, fmt.Sprintf("requested end block [%d] is smaller than the start block [%d]", req.EndBlock, req.StartBlock))
+	}
+
+	var blocksSize int
+	for i := req.StartBlock; i <= req.EndBlock && i <= height; i++ {
+		block, err := s.ledgerReader.Get(i)
+		if err != nil {
+			return sendCatchUpError(stream, err.Error())
+		}
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return sendCatchUpError(stream, err.Error())
+		}
+
+		// no more than maxResponseBytes but at least one block
+		blocksSize += len(blockBytes)
+		if blocksSize > s.maxResponseBytes && i > req.StartBlock {
+			break
+		}
+		if err := stream.Send(&peerpb.CatchUpResponse{Block: blockBytes}); err != nil {
+			return err
+		}
+	}
+
+	return stream.Send(&peerpb.CatchUpResponse{Last: true})
+}
+
+func (s *grpcPeerServer) sendStateSnapshot(stream peerpb.Peer_CatchUpServer) error {
+	if s.stateSnapshotProvider == nil {
+		return sendCatchUpError(stream, "state snapshots are not served")
+	}
+	s.logger.Info("state snapshot request")
+
+	cw := &stateSnapshotChunkWriter{stream: stream}
+	bw := bufio.NewWriterSize(cw, stateSnapshotChunkBytes)
+	err := s.stateSnapshotProvider.WriteStateSnapshot(bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		// the client detects a partially sent state snapshot by the error
+		s.logger.Errorf("error while sending the state snapshot: %s", err)
+		return sendCatchUpError(stream, err.Error())
+	}
+
+	s.logger.Infof("state snapshot sent, %d bytes", cw.n)
+	return stream.Send(&peerpb.CatchUpResponse{Last: true})
+}
+
+func (s *grpcPeerServer) sendAttestations(stream peerpb.Peer_CatchUpServer, req *peerpb.AttestationsRequest) error {
+	if s.attestationProvider == nil {
+		return sendCatchUpError(stream, "attestations are not served")
+	}
+	s.logger.Debugf("attestations request: [%d,%d]", req.StartBlock, req.EndBlock)
+
+	if req.EndBlock < req.StartBlock {
+		return sendCatchUpError(stream, fmt.Sprintf("requested end block [%d] is smaller than the start block [%d]", req.EndBlock, req.StartBlock))
+	}
+	respBytes, err := marshalAttestations(s.attestationProvider, req.StartBlock, req.EndBlock)
+	if err != nil {
+		return sendCatchUpError(stream, err.Error())
+	}
+
+	return stream.Send(&peerpb.CatchUpResponse{Attestations: respBytes, Last: true})
+}
+
+func sendCatchUpError(stream peerpb.Peer_CatchUpServer, errMsg string) error {
+	return stream.Send(&peerpb.CatchUpResponse{Error: errMsg, Last: true})
+}
+
+// stateSnapshotChunkWriter sends the state snapshot in chunks of at most stateSnapshotChunkBytes
+type stateSnapshotChunkWriter struct {
+	stream peerpb.Peer_CatchUpServer
+	n      int64
+}
+
+func (w *stateSnapshotChunkWriter) Write(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		chunk := p[n:]
+		if len(chunk) > stateSnapshotChunkBytes {
+			chunk = chunk[:stateSnapshotChunkBytes]
+		}
+		if err := w.stream.Send(&peerpb.CatchUpResponse{StateSnapshotChunk: chunk}); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		w.n += int64(len(chunk))
+	}
+	return n, nil
+}
+
+// grpcCatchUpRemote fetches blocks, state snapshots and block attestations from the remote peers of a GRPCTransport, on streams that are
+// compressed according to the cluster config.
+type grpcCatchUpRemote struct {
+	transport *GRPCTransport
+}
+
+func (r *grpcCatchUpRemote) GetBlocks(ctx context.Context, targetID, start, end uint64) ([]*types.Block, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.openStream(ctx, targetID, &peerpb.CatchUpRequest{
+		Request: &peerpb.CatchUpRequest_Blocks{
+			Blocks: &peerpb.BlocksRequest{StartBlock: start, EndBlock: end},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*types.Block
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		if len(resp.Block) > 0 {
+			block := &types.Block{}
+			if err := proto.Unmarshal(resp.Block, block); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal block")
+			}
+			blocks = append(blocks, block)
+		}
+		if resp.Last {
+			break
+		}
+	}
+
+	if len(blocks) == 0 {
+		return nil, errors.New("empty response, no blocks found")
+	}
+	return blocks, nil
+}
+
+func (r *grpcCatchUpRemote) GetStateSnapshot(ctx context.Context, targetID uint64, receive func(r io.Reader) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.openStream(ctx, targetID, &peerpb.CatchUpRequest{
+		Request: &peerpb.CatchUpRequest_StateSnapshot{
+			StateSnapshot: &peerpb.StateSnapshotRequest{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// an error in the first response means that the state snapshot could not be taken
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return errors.Errorf("member [%d] failed to send a state snapshot: %s", targetID, resp.Error)
+	}
+
+	return receive(&stateSnapshotChunkReader{stream: stream, chunk: resp.StateSnapshotChunk, last: resp.Last})
+}
+
+func (r *grpcCatchUpRemote) GetAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.openStream(ctx, targetID, &peerpb.CatchUpRequest{
+		Request: &peerpb.CatchUpRequest_Attestations{
+			Attestations: &peerpb.AttestationsRequest{StartBlock: start, EndBlock: end},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return unmarshalAttestations(resp.Attestations)
+}
+
+func (r *grpcCatchUpRemote) openStream(ctx context.Context, targetID uint64, req *peerpb.CatchUpRequest) (peerpb.Peer_CatchUpClient, error) {
+	conn, err := r.transport.peerConn(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []grpc.CallOption
+	if algorithm := r.transport.compression(); compression.Enabled(algorithm) {
+		opts = append(opts, grpc.UseCompressor(algorithm))
+	}
+
+	stream, err := peerpb.NewPeerClient(conn).CatchUp(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = stream.Send(req); err != nil {
+		return nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// stateSnapshotChunkReader reads the state snapshot from the chunks received on the stream
+type stateSnapshotChunkReader struct {
+	stream peerpb.Peer_CatchUpClient
+	chunk  []byte
+	last   bool
+}
+
+func (r *stateSnapshotChunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.last {
+			return 0, io.EOF
+		}
+
+		resp, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		if resp.Error != "" {
+			return 0, errors.Errorf("error while receiving the state snapshot: %s", resp.Error)
+		}
+		r.chunk, r.last = resp.StateSnapshotChunk, resp.Last
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
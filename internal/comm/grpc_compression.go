@@ -0,0 +1,59 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"io"
+
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCompressor(&grpcCompressor{algorithm: compression.Gzip})
+	encoding.RegisterCompressor(&grpcCompressor{algorithm: compression.Zstd})
+}
+
+// grpcCompressor compresses the catch-up streams of the GRPCTransport, and records the compression ratio of the
+// messages it compresses. The client picks the compressor according to the cluster config, and the server answers with
+// the same compressor. The size of a decompressed message is bounded by gRPC itself, according to the maximal size of a
+// received message.
+type grpcCompressor struct {
+	algorithm string
+}
+
+func (c *grpcCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return compression.NewObservedWriter(compression.PathCatchUp, c.algorithm, w)
+}
+
+func (c *grpcCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	rc, err := compression.NewReader(c.algorithm, r)
+	if err != nil {
+		return nil, err
+	}
+	return &closingReader{rc: rc}, nil
+}
+
+func (c *grpcCompressor) Name() string {
+	return c.algorithm
+}
+
+// closingReader closes the decompressing reader once it is read to the end, as gRPC does not close it
+type closingReader struct {
+	rc     io.ReadCloser
+	closed bool
+}
+
+func (r *closingReader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, io.EOF
+	}
+
+	n, err := r.rc.Read(p)
+	if err != nil {
+		r.rc.Close()
+		r.closed = true
+	}
+	return n, err
+}
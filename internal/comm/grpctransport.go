@@ -0,0 +1,648 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/comm/peerpb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/raftpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// the size of the buffer of outgoing Raft messages per peer; messages are dropped when it is full
+	grpcSendBufferSize = 4096
+	// the interval between attempts to re-open a broken Raft stream to a peer
+	grpcStreamRetryInterval = 100 * time.Millisecond
+	// the sent Raft messages and blocks are not bounded by the transport, the received ones are bounded by the
+	// ReplicationConf.MaxMessageSize once decompressed
+	grpcMaxMessageBytes = math.MaxInt32
+)
+
+// grpcMaxRecvMessageBytes returns the maximal size of a received message, which gRPC enforces on the decompressed message
+func grpcMaxRecvMessageBytes(localConf *config.LocalConfiguration) int {
+	limit := localConf.Replication.MessageSizeLimit()
+	if limit > grpcMaxMessageBytes {
+		return grpcMaxMessageBytes
+	}
+	return int(limit)
+}
+
+// GRPCTransport provides gRPC-based transport to send and receive messages from remote peers that run the Raft
+// cluster. It also provides a gRPC-based "catch-up" service to pull blocks and state snapshots from remote peers.
+//
+// Both services are served by a single gRPC server, and every peer holds a single client connection to every remote
+// peer. Raft messages are sent on a long-lived bidirectional stream, while blocks and state snapshots are pulled on
+// short-lived streams, which are compressed. The connections provide flow control, keep-alive, and mutual TLS when
+// `Replication.TLS.ClientAuthRequired` is set.
+//
+// The GRPCTransport is operated in the same way as the HTTPTransport, see Transport. The component is thread safe.
+type GRPCTransport struct {
+	localConf *config.LocalConfiguration
+
+	mutex             sync.Mutex
+	consensusListener ConsensusListener
+	clusterConfig     *types.ClusterConfig
+	peers             map[uint64]*grpcPeer
+
+	raftID uint64
+
+	tlsServerConfig       *tls.Config // nil if TLS is not enabled
+	tlsClientConfig       *tls.Config // nil if TLS is not enabled
+	grpcServer            *grpc.Server
+	catchUpClient         *catchUpClient
+	ledgerReader          LedgerReader
+	stateSnapshotProvider StateSnapshotProvider
+	attestationProvider   AttestationProvider
+	faultInjector         *FaultInjector // nil unless fault injection is enabled
+
+	stopCh chan struct{} // signals GRPCTransport to shut-down
+	doneCh chan struct{} // signals GRPCTransport shutdown complete
+
+	logger *logger.SugarLogger
+}
+
+// NewGRPCTransport creates a new instance of GRPCTransport.
+func NewGRPCTransport(config *Config) (*GRPCTransport, error) {
+	tr := &GRPCTransport{
+		localConf:             config.LocalConf,
+		peers:                 make(map[uint64]*grpcPeer),
+		catchUpClient:         NewCatchUpClient(config.Logger, nil),
+		ledgerReader:          config.LedgerReader,
+		stateSnapshotProvider: config.StateSnapshotProvider,
+		attestationProvider:   config.AttestationProvider,
+		faultInjector:         config.FaultInjector,
+		stopCh:                make(chan struct{}),
+		doneCh:                make(chan struct{}),
+		logger:                config.Logger,
+	}
+	tr.catchUpClient.SetMaxDecompressedBytes(config.LocalConf.Replication.MessageSizeLimit())
+	tr.catchUpClient.remote = &grpcCatchUpRemote{transport: tr}
+	if tr.faultInjector != nil {
+		tr.catchUpClient.remote = &faultyCatchUpRemote{remote: tr.catchUpClient.remote, injector: tr.faultInjector}
+	}
+
+	tlsConf := config.LocalConf.Replication.TLS
+	if !tlsConf.Enabled {
+		return tr, nil
+	}
+
+	caCertPool, err := loadCACertPool(&tlsConf.CaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serverKeyPair, err := tls.LoadX509KeyPair(tlsConf.ServerCertificatePath, tlsConf.ServerKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load local config Replication.TLS.ServerCertificatePath and Replication.TLS.ServerKeyPath")
+	}
+	tr.tlsServerConfig = &tls.Config{
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientCAs:    caCertPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if tlsConf.ClientAuthRequired {
+		tr.tlsServerConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	tr.tlsClientConfig = &tls.Config{
+		RootCAs:    caCertPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if tlsConf.ClientCertificatePath != "" || tlsConf.ClientKeyPath != "" {
+		clientKeyPair, err := tls.LoadX509KeyPair(tlsConf.ClientCertificatePath, tlsConf.ClientKeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load local config Replication.TLS.ClientCertificatePath and Replication.TLS.ClientKeyPath")
+		}
+		tr.tlsClientConfig.Certificates = []tls.Certificate{clientKeyPair}
+	} else if tlsConf.ClientAuthRequired {
+		return nil, errors.New("TLS client authentication requires local config Replication.TLS.ClientCertificatePath and Replication.TLS.ClientKeyPath")
+	}
+
+	return tr, nil
+}
+
+// SetConsensusListener sets the consensus listener which is an interface that is implemented by the replication
+// component that is running the Raft state machine.
+//
+// This must be called before the call to Start().
+func (t *GRPCTransport) SetConsensusListener(l ConsensusListener) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.consensusListener != nil {
+		return errors.New("ConsensusListener already set")
+	}
+	t.consensusListener = l
+
+	return nil
+}
+
+// SetClusterConfig sets the initial types.ClusterConfig into the GRPCTransport for the first time, and detects the
+// local RaftID.
+//
+// This must be called before the call to Start().
+func (t *GRPCTransport) SetClusterConfig(clusterConfig *types.ClusterConfig) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.clusterConfig != nil {
+		return errors.New("cluster config already exists")
+	}
+
+	raftID, err := MemberRaftID(t.localConf.Server.Identity.ID, clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	t.raftID = raftID
+	t.clusterConfig = clusterConfig
+	t.faultInjector.updatePeers(clusterConfig)
+
+	return nil
+}
+
+// Start binds to the listening port, starts serving requests, and connects to the remote peers.
+// SetClusterConfig and SetConsensusListener must be called before start.
+func (t *GRPCTransport) Start() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.consensusListener == nil {
+		t.logger.Panic("Must set ConsensusListener before Start()")
+	}
+
+	if t.clusterConfig == nil {
+		t.logger.Panic("Must update ClusterConfig before Start()")
+	}
+
+	netConf := t.localConf.Replication.Network
+	addr := fmt.Sprintf("%s:%d", netConf.Address, netConf.Port)
+	netListener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, "error while creating a tcp listener")
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(grpcMaxRecvMessageBytes(t.localConf)),
+		grpc.MaxSendMsgSize(grpcMaxMessageBytes),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+	if t.tlsServerConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(t.tlsServerConfig)))
+	}
+	t.grpcServer = grpc.NewServer(serverOpts...)
+	peerpb.RegisterPeerServer(t.grpcServer, &grpcPeerServer{
+		raftID:                t.raftID,
+		consensusListener:     t.consensusListener,
+		ledgerReader:          t.ledgerReader,
+		stateSnapshotProvider: t.stateSnapshotProvider,
+		attestationProvider:   t.attestationProvider,
+		maxResponseBytes:      maxResponseBytesDefault,
+		logger:                t.logger,
+	})
+
+	// observers replicate as raft learners, hence they are raft peers as well; blocks are pulled from them too
+	var membersList []*types.PeerConfig
+	for _, peer := range consensusPeers(t.clusterConfig) {
+		if peer.RaftId != t.raftID {
+			membersList = append(membersList, peer)
+			if err = t.addPeer(peer); err != nil {
+				return err
+			}
+		}
+	}
+	if err = t.catchUpClient.UpdateMembers(membersList); err != nil {
+		return err
+	}
+
+	go t.servePeers(netListener)
+
+	return nil
+}
+
+// UpdatePeers adds, removes and updates changed peers; it also refreshes the member list of the catchup client.
+func (t *GRPCTransport) UpdatePeers(added, removed, changed []*types.PeerConfig, updatedClusterConfig *types.ClusterConfig) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, addedPeer := range added {
+		if addedPeer.RaftId != t.raftID {
+			if err := t.addPeer(addedPeer); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, removedPeer := range removed {
+		if removedPeer.RaftId != t.raftID {
+			t.removePeer(removedPeer.RaftId)
+		}
+	}
+
+	for _, changedPeer := range changed {
+		if changedPeer.RaftId != t.raftID {
+			t.removePeer(changedPeer.RaftId)
+			if err := t.addPeer(changedPeer); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(added)+len(removed)+len(changed) > 0 {
+		var membersList []*types.PeerConfig
+		for _, peer := range consensusPeers(updatedClusterConfig) {
+			if peer.RaftId != t.raftID {
+				membersList = append(membersList, peer)
+			}
+		}
+		if err := t.catchUpClient.UpdateMembers(membersList); err != nil {
+			return err
+		}
+	}
+
+	t.clusterConfig = updatedClusterConfig
+	t.faultInjector.updatePeers(updatedClusterConfig)
+
+	return nil
+}
+
+// addPeer must be called with the mutex held.
+func (t *GRPCTransport) addPeer(peerConfig *types.PeerConfig) error {
+	if _, exists := t.peers[peerConfig.RaftId]; exists {
+		t.logger.Warnf("peer [%d] already exists", peerConfig.RaftId)
+		return nil
+	}
+
+	creds := grpc.WithInsecure()
+	if t.tlsClientConfig != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(t.tlsClientConfig.Clone()))
+	}
+
+	peer, err := newGRPCPeer(peerConfig, creds, grpcMaxRecvMessageBytes(t.localConf), t.consensusListener, t.logger)
+	if err != nil {
+		return err
+	}
+	t.peers[peerConfig.RaftId] = peer
+
+	return nil
+}
+
+// removePeer must be called with the mutex held. It does not wait for the peer to stop, as the peer may be
+// reporting to the consensus listener.
+func (t *GRPCTransport) removePeer(raftID uint64) {
+	if peer, exists := t.peers[raftID]; exists {
+		peer.stop()
+		delete(t.peers, raftID)
+	}
+}
+
+func (t *GRPCTransport) servePeers(l net.Listener) {
+	t.logger.Infof("grpc transport starting to serve peers on: %s", l.Addr().String())
+	err := t.grpcServer.Serve(l)
+
+	select {
+	case <-t.stopCh:
+		t.logger.Info("grpc transport stopping to serve peers")
+	default:
+		t.logger.Errorf("grpc transport failed to serve peers (%v)", err)
+	}
+	close(t.doneCh)
+}
+
+func (t *GRPCTransport) Close() {
+	t.logger.Info("closing grpc transport")
+	close(t.stopCh)
+
+	t.mutex.Lock()
+	peers := t.peers
+	t.peers = make(map[uint64]*grpcPeer)
+	t.mutex.Unlock()
+
+	for _, peer := range peers {
+		peer.stop()
+		<-peer.doneCh
+	}
+
+	t.grpcServer.Stop()
+
+	select {
+	case <-t.doneCh:
+		t.logger.Info("grpc transport closed")
+	case <-time.After(10 * time.Second):
+		t.logger.Info("grpc transport Close() timed-out waiting for grpc server to complete shutdown")
+	}
+}
+
+// SendConsensus queues the messages to the streams of the respective peers. A message to a peer whose queue is full
+// is dropped, and the peer is reported unreachable.
+func (t *GRPCTransport) SendConsensus(msgs []raftpb.Message) error {
+	if t.faultInjector != nil {
+		msgs = t.faultInjector.injectConsensus(msgs, t.stopCh, t.send)
+	}
+
+	t.send(msgs)
+
+	return nil
+}
+
+func (t *GRPCTransport) send(msgs []raftpb.Message) {
+	var dropped []raftpb.Message
+
+	t.mutex.Lock()
+	for i, m := range msgs {
+		t.logger.Debugf("SendConsensus (%d/%d): Type: %s, From: %d, To: %d", i+1, len(msgs), m.Type, t.raftID, m.To)
+
+		peer, exists := t.peers[m.To]
+		if !exists {
+			t.logger.Debugf("SendConsensus: peer [%d] not found, message dropped", m.To)
+			continue
+		}
+
+		select {
+		case peer.sendCh <- m:
+		default:
+			t.logger.Warnf("SendConsensus: the send buffer to peer [%d] is full, message dropped", m.To)
+			dropped = append(dropped, m)
+		}
+	}
+	t.mutex.Unlock()
+
+	// the listener is called without the mutex, which it may hold while it updates the peers
+	for _, m := range dropped {
+		reportSendFailure(t.consensusListener, m)
+	}
+}
+
+// PullBlocks tries to pull as many blocks as possible from startBlock to endBlock (inclusive).
+//
+// The calling go-routine will block until some blocks are retrieved, depending on the availability of remote peers.
+// The `leaderID` is a hint to the leader's Raft ID, and can be 0. The call maybe canceled using the context `ctx`.
+func (t *GRPCTransport) PullBlocks(ctx context.Context, startBlock, endBlock, leaderID uint64) ([]*types.Block, error) {
+	return t.catchUpClient.PullBlocks(ctx, startBlock, endBlock, leaderID)
+}
+
+// PullBlocksConcurrently pulls the blocks that follow `lastBlock` up to `endBlock` (inclusive) concurrently from the
+// cluster members and observers, and passes them in order to `deliver`, see HTTPTransport.PullBlocksConcurrently.
+func (t *GRPCTransport) PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, endBlock uint64, endBaseHash []byte, leaderID uint64, deliver func(blocks []*types.Block) error) error {
+	return t.catchUpClient.PullBlocksConcurrently(ctx, lastBlock, endBlock, endBaseHash, leaderID, deliver)
+}
+
+// PullStateSnapshot tries to pull a state snapshot from the cluster members, starting from the leader hint (if
+// exists), and passes the snapshot stream to `receive`, see HTTPTransport.PullStateSnapshot.
+func (t *GRPCTransport) PullStateSnapshot(ctx context.Context, leaderID uint64, receive func(r io.Reader) error) error {
+	return t.catchUpClient.PullStateSnapshot(ctx, leaderID, receive)
+}
+
+// PullAttestations pulls the attestations of the peer `targetID` on the blocks [start,end] (inclusive), see
+// HTTPTransport.PullAttestations.
+func (t *GRPCTransport) PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	return t.catchUpClient.PullAttestations(ctx, targetID, start, end)
+}
+
+// ActivePeers returns the peers whose Raft stream is active for more than `minDuration`.
+// The returned peers  include the self node if includeSelf==true.
+func (t *GRPCTransport) ActivePeers(minDuration time.Duration, includeSelf bool) map[string]*types.PeerConfig {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var activePeers = make(map[string]*types.PeerConfig)
+	for _, m := range consensusPeers(t.clusterConfig) {
+		if includeSelf && m.RaftId == t.raftID {
+			activePeers[m.NodeId] = m
+			continue
+		}
+
+		peer, exists := t.peers[m.RaftId]
+		if !exists {
+			continue
+		}
+		since := peer.getActiveSince()
+		if since.IsZero() {
+			continue
+		}
+
+		if time.Since(since) >= minDuration {
+			activePeers[m.NodeId] = m
+		}
+	}
+
+	return activePeers
+}
+
+// peerConn returns the client connection to a remote peer.
+func (t *GRPCTransport) peerConn(raftID uint64) (*grpc.ClientConn, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	peer, exists := t.peers[raftID]
+	if !exists {
+		return nil, errors.Errorf("target ID [%d] not found", raftID)
+	}
+	return peer.conn, nil
+}
+
+// compression returns the compression algorithm of the catch-up streams, from the cluster config.
+func (t *GRPCTransport) compression() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.clusterConfig.GetConsensusConfig().GetRaftConfig().GetCompression()
+}
+
+// grpcPeer holds the client connection to a remote peer, and sends Raft messages to it on a stream, which is
+// re-opened when it breaks.
+type grpcPeer struct {
+	raftID   uint64
+	address  string
+	conn     *grpc.ClientConn
+	listener ConsensusListener
+	sendCh   chan raftpb.Message
+	logger   *logger.SugarLogger
+
+	mutex       sync.Mutex
+	activeSince time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func newGRPCPeer(peerConfig *types.PeerConfig, creds grpc.DialOption, maxRecvMessageBytes int, listener ConsensusListener, lg *logger.SugarLogger) (*grpcPeer, error) {
+	address := fmt.Sprintf("%s:%d", peerConfig.PeerHost, peerConfig.PeerPort)
+	conn, err := grpc.Dial(address,
+		creds,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMessageBytes), grpc.MaxCallSendMsgSize(grpcMaxMessageBytes)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  100 * time.Millisecond,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   time.Second,
+			},
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create a connection to peer [%d] at %s", peerConfig.RaftId, address)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &grpcPeer{
+		raftID:   peerConfig.RaftId,
+		address:  address,
+		conn:     conn,
+		listener: listener,
+		sendCh:   make(chan raftpb.Message, grpcSendBufferSize),
+		logger:   lg,
+		ctx:      ctx,
+		cancel:   cancel,
+		doneCh:   make(chan struct{}),
+	}
+	go p.run()
+
+	return p, nil
+}
+
+// run keeps a Raft stream open to the peer, and sends the queued messages on it. While the stream is broken, the
+// queued messages are dropped and the peer is reported unreachable.
+func (p *grpcPeer) run() {
+	defer close(p.doneCh)
+
+	for {
+		err := p.stream()
+		p.setActiveSince(time.Time{})
+
+		select {
+		case <-p.ctx.Done():
+			return
+		default:
+		}
+		p.logger.Debugf("Raft stream to peer [%d] at %s is broken, will try again in %s: %s", p.raftID, p.address, grpcStreamRetryInterval, err)
+
+		retry := time.After(grpcStreamRetryInterval)
+		for waiting := true; waiting; {
+			select {
+			case <-p.ctx.Done():
+				return
+			case m := <-p.sendCh:
+				reportSendFailure(p.listener, m)
+			case <-retry:
+				waiting = false
+			}
+		}
+	}
+}
+
+// stream opens a Raft stream to the peer and sends the queued messages on it until it breaks.
+func (p *grpcPeer) stream() error {
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+
+	stream, err := peerpb.NewPeerClient(p.conn).Step(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if resp.RaftId != p.raftID {
+		return errors.Errorf("expected peer with Raft ID [%d] but found [%d]", p.raftID, resp.RaftId)
+	}
+
+	p.logger.Debugf("Raft stream to peer [%d] at %s is active", p.raftID, p.address)
+	p.setActiveSince(time.Now())
+
+	// the peer sends nothing after the first response, hence Recv returns only when the stream breaks
+	brokenCh := make(chan error, 1)
+	go func() {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			err = errors.New("the stream was closed by the peer")
+		}
+		brokenCh <- err
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-brokenCh:
+			return err
+		case m := <-p.sendCh:
+			if err := p.send(stream, m); err != nil {
+				reportSendFailure(p.listener, m)
+				return err
+			}
+		}
+	}
+}
+
+func (p *grpcPeer) send(stream peerpb.Peer_StepClient, m raftpb.Message) error {
+	msgBytes, err := m.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal Raft message")
+	}
+	if err := stream.Send(&peerpb.StepRequest{Message: msgBytes}); err != nil {
+		return err
+	}
+
+	if m.Type == raftpb.MsgSnap {
+		p.listener.ReportSnapshot(m.To, raft.SnapshotFinish)
+	}
+	return nil
+}
+
+// stop closes the connection to the peer. The peer go-routine exits soon after, signaling doneCh.
+func (p *grpcPeer) stop() {
+	p.cancel()
+	if err := p.conn.Close(); err != nil {
+		p.logger.Warnf("failed to close the connection to peer [%d]: %s", p.raftID, err)
+	}
+}
+
+func (p *grpcPeer) getActiveSince() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.activeSince
+}
+
+func (p *grpcPeer) setActiveSince(t time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.activeSince = t
+}
+
+func reportSendFailure(listener ConsensusListener, m raftpb.Message) {
+	listener.ReportUnreachable(m.To)
+	if m.Type == raftpb.MsgSnap {
+		listener.ReportSnapshot(m.To, raft.SnapshotFailure)
+	}
+}
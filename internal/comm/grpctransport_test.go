@@ -0,0 +1,429 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/internal/comm/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+func TestNewTransport(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, _ := newTestSetup(t, 1)
+
+	tr, err := comm.NewTransport(&comm.Config{LocalConf: localConfigs[0], Logger: lg})
+	require.NoError(t, err)
+	require.IsType(t, &comm.HTTPTransport{}, tr)
+
+	localConfigs[0].Replication.Transport = comm.TransportHTTP
+	tr, err = comm.NewTransport(&comm.Config{LocalConf: localConfigs[0], Logger: lg})
+	require.NoError(t, err)
+	require.IsType(t, &comm.HTTPTransport{}, tr)
+
+	localConfigs[0].Replication.Transport = comm.TransportGRPC
+	tr, err = comm.NewTransport(&comm.Config{LocalConf: localConfigs[0], Logger: lg})
+	require.NoError(t, err)
+	require.IsType(t, &comm.GRPCTransport{}, tr)
+
+	localConfigs[0].Replication.Transport = "udp"
+	tr, err = comm.NewTransport(&comm.Config{LocalConf: localConfigs[0], Logger: lg})
+	require.EqualError(t, err, "unsupported replication transport: udp")
+	require.Nil(t, tr)
+
+	// client authentication requires a client certificate
+	localConfigs[0].Replication.Transport = comm.TransportGRPC
+	localConfigs[0].Replication.TLS.Enabled = true
+	localConfigs[0].Replication.TLS.ClientAuthRequired = true
+	localConfigs[0].Replication.TLS.ClientCertificatePath = ""
+	localConfigs[0].Replication.TLS.ClientKeyPath = ""
+	tr, err = comm.NewTransport(&comm.Config{LocalConf: localConfigs[0], Logger: lg})
+	require.EqualError(t, err, "TLS client authentication requires local config Replication.TLS.ClientCertificatePath and Replication.TLS.ClientKeyPath")
+	require.Nil(t, tr)
+}
+
+// Scenario: send consensus messages from one peer to the next, with and without TLS.
+func TestGRPCTransport_SendConsensus(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		configure func(c *config.LocalConfiguration)
+	}{
+		{
+			name:      "no TLS",
+			configure: func(c *config.LocalConfiguration) {},
+		},
+		{
+			name: "TLS",
+			configure: func(c *config.LocalConfiguration) {
+				c.Replication.TLS.Enabled = true
+				c.Replication.TLS.ClientCertificatePath = ""
+				c.Replication.TLS.ClientKeyPath = ""
+			},
+		},
+		{
+			name: "mutual TLS",
+			configure: func(c *config.LocalConfiguration) {
+				c.Replication.TLS.Enabled = true
+				c.Replication.TLS.ClientAuthRequired = true
+				c.Replication.TLS.ClientCertificatePath = c.Replication.TLS.ServerCertificatePath
+				c.Replication.TLS.ClientKeyPath = c.Replication.TLS.ServerKeyPath
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			localConfigs, sharedConfig := newTestSetup(t, 2)
+			for _, c := range localConfigs {
+				tt.configure(c)
+			}
+
+			tr1, cl1 := startGRPCTransport(t, lg, localConfigs[0], sharedConfig, &memLedger{}, nil)
+			defer tr1.Close()
+			tr2, cl2 := startGRPCTransport(t, lg, localConfigs[1], sharedConfig, &memLedger{}, nil)
+			defer tr2.Close()
+
+			require.Eventually(t,
+				func() bool {
+					return len(tr1.ActivePeers(10*time.Millisecond, true)) == 2 &&
+						len(tr2.ActivePeers(10*time.Millisecond, false)) == 1
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+
+			require.NoError(t, tr1.SendConsensus([]raftpb.Message{{To: 2, From: 1, Type: raftpb.MsgHeartbeat}}))
+			require.Eventually(t,
+				func() bool {
+					return cl2.ProcessCallCount() == 1
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+			_, m := cl2.ProcessArgsForCall(0)
+			require.Equal(t, raftpb.Message{To: 2, From: 1, Type: raftpb.MsgHeartbeat}, m)
+
+			require.NoError(t, tr2.SendConsensus([]raftpb.Message{{To: 1, From: 2}, {To: 1, From: 2}}))
+			require.Eventually(t,
+				func() bool {
+					return cl1.ProcessCallCount() == 2
+				},
+				10*time.Second, 10*time.Millisecond,
+			)
+		})
+	}
+}
+
+// Scenario: one peer requires client authentication, the other does not have a client certificate.
+// Messages from the peer without a certificate do not arrive, the node is reported unreachable.
+func TestGRPCTransport_SendConsensus_ClientAuthFailure(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+	for _, c := range localConfigs {
+		c.Replication.TLS.Enabled = true
+		c.Replication.TLS.ClientCertificatePath = c.Replication.TLS.ServerCertificatePath
+		c.Replication.TLS.ClientKeyPath = c.Replication.TLS.ServerKeyPath
+	}
+	localConfigs[0].Replication.TLS.ClientAuthRequired = true
+	localConfigs[1].Replication.TLS.ClientCertificatePath = ""
+	localConfigs[1].Replication.TLS.ClientKeyPath = ""
+
+	tr1, cl1 := startGRPCTransport(t, lg, localConfigs[0], sharedConfig, &memLedger{}, nil)
+	defer tr1.Close()
+	tr2, cl2 := startGRPCTransport(t, lg, localConfigs[1], sharedConfig, &memLedger{}, nil)
+	defer tr2.Close()
+
+	// node1 reaches node2, which does not require client authentication
+	require.NoError(t, tr1.SendConsensus([]raftpb.Message{{To: 2, From: 1}}))
+	require.Eventually(t,
+		func() bool {
+			return cl2.ProcessCallCount() == 1
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+
+	require.Eventually(t,
+		func() bool {
+			require.NoError(t, tr2.SendConsensus([]raftpb.Message{{To: 1, From: 2}}))
+			return cl2.ReportUnreachableCallCount() > 0
+		},
+		10*time.Second, 100*time.Millisecond,
+	)
+	require.Equal(t, uint64(1), cl2.ReportUnreachableArgsForCall(0))
+	require.Equal(t, 0, cl1.ProcessCallCount())
+	require.Len(t, tr2.ActivePeers(0, false), 0)
+}
+
+// Scenario: a third peer is added to a cluster of two, and then removed.
+func TestGRPCTransport_UpdatePeers(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 3)
+	sharedConfig2 := &types.ClusterConfig{ConsensusConfig: &types.ConsensusConfig{
+		Algorithm: "raft",
+		Members:   sharedConfig.ConsensusConfig.Members[0:2],
+	}}
+
+	tr1, _ := startGRPCTransport(t, lg, localConfigs[0], sharedConfig2, &memLedger{}, nil)
+	defer tr1.Close()
+	tr2, _ := startGRPCTransport(t, lg, localConfigs[1], sharedConfig2, &memLedger{}, nil)
+	defer tr2.Close()
+	tr3, cl3 := startGRPCTransport(t, lg, localConfigs[2], sharedConfig, &memLedger{}, nil)
+	defer tr3.Close()
+
+	added := sharedConfig.ConsensusConfig.Members[2:]
+	require.NoError(t, tr1.UpdatePeers(added, nil, nil, sharedConfig))
+	require.NoError(t, tr2.UpdatePeers(added, nil, nil, sharedConfig))
+
+	require.NoError(t, tr1.SendConsensus([]raftpb.Message{{To: 3, From: 1}}))
+	require.NoError(t, tr2.SendConsensus([]raftpb.Message{{To: 3, From: 2}}))
+	require.Eventually(t,
+		func() bool {
+			return cl3.ProcessCallCount() == 2
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+	require.Eventually(t,
+		func() bool {
+			return len(tr1.ActivePeers(10*time.Millisecond, true)) == 3
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+
+	require.NoError(t, tr1.UpdatePeers(nil, added, nil, sharedConfig2))
+	require.NoError(t, tr1.SendConsensus([]raftpb.Message{{To: 3, From: 1}}))
+	require.Never(t,
+		func() bool {
+			return cl3.ProcessCallCount() > 2
+		},
+		time.Second, 10*time.Millisecond,
+	)
+	require.Len(t, tr1.ActivePeers(0, true), 2)
+}
+
+// Scenario: pull blocks and state snapshots from peers that serve different ledgers.
+func TestGRPCTransport_CatchUp(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 3)
+
+	// the snapshot is large enough to be sent in several chunks
+	snapshot := bytes.Repeat([]byte("state snapshot"), 200000)
+	tr1, _ := startGRPCTransport(t, lg, localConfigs[0], sharedConfig, linkedLedger(t, 10, ""), stateSnapshotProvider(func(w io.Writer) error {
+		_, err := w.Write(snapshot)
+		return err
+	}))
+	defer tr1.Close()
+	tr2, _ := startGRPCTransport(t, lg, localConfigs[1], sharedConfig, linkedLedger(t, 5, ""), stateSnapshotProvider(func(w io.Writer) error {
+		return errors.New("oops")
+	}))
+	defer tr2.Close()
+	tr3, _ := startGRPCTransport(t, lg, localConfigs[2], sharedConfig, &memLedger{}, nil)
+	defer tr3.Close()
+
+	t.Run("pull blocks", func(t *testing.T) {
+		blocks, err := tr3.PullBlocks(context.Background(), 3, 8, 1)
+		require.NoError(t, err)
+		require.Len(t, blocks, 6)
+		for i, block := range blocks {
+			require.Equal(t, uint64(3+i), block.GetHeader().GetBaseHeader().GetNumber())
+		}
+
+		// member 2 has only 5 blocks
+		blocks, err = tr3.PullBlocks(context.Background(), 3, 8, 2)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("pull blocks concurrently", func(t *testing.T) {
+		var delivered []*types.Block
+		err := tr3.PullBlocksConcurrently(context.Background(), nil, 10, nil, 0, func(blocks []*types.Block) error {
+			delivered = append(delivered, blocks...)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, delivered, 10)
+		for i, block := range delivered {
+			require.Equal(t, uint64(1+i), block.GetHeader().GetBaseHeader().GetNumber())
+		}
+	})
+
+	t.Run("pull blocks canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		blocks, err := tr3.PullBlocks(ctx, 11, 12, 0)
+		require.EqualError(t, err, "PullBlocks canceled: context deadline exceeded")
+		require.Nil(t, blocks)
+	})
+
+	t.Run("pull state snapshot", func(t *testing.T) {
+		var received []byte
+		// member 2 fails to take a snapshot, member 1 is tried next
+		err := tr3.PullStateSnapshot(context.Background(), 2, func(r io.Reader) error {
+			var err error
+			received, err = ioutil.ReadAll(r)
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, snapshot, received)
+
+		// member 3 does not serve snapshots, member 2 fails
+		err = tr1.PullStateSnapshot(context.Background(), 3, func(r io.Reader) error {
+			return nil
+		})
+		require.EqualError(t, err, "member [2] failed to send a state snapshot: oops")
+	})
+}
+
+func TestGRPCTransport_CatchUp_Compression(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			localConfigs, sharedConfig := newTestSetup(t, 2)
+			sharedConfig.ConsensusConfig.RaftConfig = &types.RaftConfig{Compression: algorithm}
+
+			snapshot := bytes.Repeat([]byte("state snapshot"), 200000)
+			tr1, _ := startGRPCTransport(t, lg, localConfigs[0], sharedConfig, linkedLedger(t, 10, ""), stateSnapshotProvider(func(w io.Writer) error {
+				_, err := w.Write(snapshot)
+				return err
+			}))
+			defer tr1.Close()
+			tr2, _ := startGRPCTransport(t, lg, localConfigs[1], sharedConfig, &memLedger{}, nil)
+			defer tr2.Close()
+
+			blocks, err := tr2.PullBlocks(context.Background(), 3, 8, 1)
+			require.NoError(t, err)
+			require.Len(t, blocks, 6)
+			for i, block := range blocks {
+				require.Equal(t, uint64(3+i), block.GetHeader().GetBaseHeader().GetNumber())
+			}
+
+			var received []byte
+			err = tr2.PullStateSnapshot(context.Background(), 1, func(r io.Reader) error {
+				var err error
+				received, err = ioutil.ReadAll(r)
+				return err
+			})
+			require.NoError(t, err)
+			require.Equal(t, snapshot, received)
+		})
+	}
+}
+
+func TestGRPCTransport_PullAttestations(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+
+	localConfigs[0].Replication.Transport = comm.TransportGRPC
+	tr1, err := comm.NewGRPCTransport(&comm.Config{
+		LocalConf:           localConfigs[0],
+		Logger:              lg,
+		LedgerReader:        &memLedger{},
+		AttestationProvider: memAttestations("node1", 5),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr1.SetConsensusListener(&mocks.ConsensusListener{}))
+	require.NoError(t, tr1.SetClusterConfig(sharedConfig))
+	require.NoError(t, tr1.Start())
+	defer tr1.Close()
+	tr2, _ := startGRPCTransport(t, lg, localConfigs[1], sharedConfig, &memLedger{}, nil)
+	defer tr2.Close()
+
+	attestations, err := tr2.PullAttestations(context.Background(), 1, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, attestations, 4)
+	for i, a := range attestations {
+		require.Equal(t, uint64(2+i), a.BlockNumber)
+		require.Equal(t, "node1", a.NodeId)
+	}
+
+	_, err = tr2.PullAttestations(context.Background(), 1, 4, 3)
+	require.EqualError(t, err, "failed to get attestations [4,3] from member [1]: requested end block [3] is smaller than the start block [4]")
+
+	// member 2 does not serve attestations
+	_, err = tr1.PullAttestations(context.Background(), 2, 1, 5)
+	require.EqualError(t, err, "failed to get attestations [1,5] from member [2]: attestations are not served")
+}
+
+func startGRPCTransport(t *testing.T, lg *logger.SugarLogger, localConf *config.LocalConfiguration, sharedConfig *types.ClusterConfig, ledger *memLedger, provider comm.StateSnapshotProvider) (*comm.GRPCTransport, *mocks.ConsensusListener) {
+	localConf.Replication.Transport = comm.TransportGRPC
+	conf := &comm.Config{
+		LocalConf:    localConf,
+		Logger:       lg,
+		LedgerReader: ledger,
+	}
+	if provider != nil {
+		conf.StateSnapshotProvider = provider
+	}
+
+	tr, err := comm.NewGRPCTransport(conf)
+	require.NoError(t, err)
+	require.NotNil(t, tr)
+
+	cl := &mocks.ConsensusListener{}
+	require.NoError(t, tr.SetConsensusListener(cl))
+	require.NoError(t, tr.SetClusterConfig(sharedConfig))
+	require.NoError(t, tr.Start())
+
+	return tr, cl
+}
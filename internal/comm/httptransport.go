@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -17,7 +18,6 @@ import (
 	"time"
 
 	"github.com/hyperledger-labs/orion-server/config"
-	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -64,6 +64,7 @@ type HTTPTransport struct {
 	catchUpClient   *catchUpClient
 	catchupHandler  *catchupHandler
 	httpServer      *http.Server
+	faultInjector   *FaultInjector // nil unless fault injection is enabled
 
 	stopCh chan struct{} // signals HTTPTransport to shut-down
 	doneCh chan struct{} // signals HTTPTransport shutdown complete
@@ -72,9 +73,12 @@ type HTTPTransport struct {
 }
 
 type Config struct {
-	LocalConf    *config.LocalConfiguration
-	Logger       *logger.SugarLogger
-	LedgerReader LedgerReader
+	LocalConf             *config.LocalConfiguration
+	Logger                *logger.SugarLogger
+	LedgerReader          LedgerReader
+	StateSnapshotProvider StateSnapshotProvider
+	AttestationProvider   AttestationProvider
+	FaultInjector         *FaultInjector // optional, injects network faults for testing
 }
 
 // NewHTTPTransport creates a new instance of HTTPTransport.
@@ -87,28 +91,19 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 		logger:         config.Logger,
 		localConf:      config.LocalConf,
 		catchUpClient:  NewCatchUpClient(config.Logger, nil),
-		catchupHandler: NewCatchupHandler(config.Logger, config.LedgerReader, 0), //TODO make max-response-bytes configurable
+		catchupHandler: NewCatchupHandler(config.Logger, config.LedgerReader, config.StateSnapshotProvider, config.AttestationProvider, 0), //TODO make max-response-bytes configurable
+		faultInjector:  config.FaultInjector,
 		stopCh:         make(chan struct{}),
 		doneCh:         make(chan struct{}),
 	}
+	tr.catchUpClient.SetMaxDecompressedBytes(config.LocalConf.Replication.MessageSizeLimit())
 
 	if config.LocalConf.Replication.TLS.Enabled {
-		// load and check the CA certificates
-		caCerts, err := certificateauthority.LoadCAConfig(&tr.localConf.Replication.TLS.CaConfig)
+		caCertPool, err := loadCACertPool(&tr.localConf.Replication.TLS.CaConfig)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error while loading CA certificates from local configuration Replication.TLS.CaConfig: %+v", tr.localConf.Replication.TLS.CaConfig)
-		}
-		caColl, err := certificateauthority.NewCACertCollection(caCerts.GetRoots(), caCerts.GetIntermediates())
-		if err != nil {
-			return nil, errors.Wrap(err, "error while creating a CA certificate collection")
-		}
-		if err := caColl.VerifyCollection(); err != nil {
-			return nil, errors.Wrap(err, "error while verifying the CA certificate collection")
+			return nil, err
 		}
 
-		// get a x509.CertPool of all the CA crtificates for tls.Config
-		caCertPool := caColl.GetCertPool()
-
 		// combine all the root & intermediate CA certificates we have into a single file for Raft TLSInfo
 		caBundleFile := path.Join(tr.localConf.Replication.AuxDir, "ca-bundle.pem")
 		if err := tr.localConf.Replication.TLS.CaConfig.WriteBundle(caBundleFile); err != nil {
@@ -136,6 +131,7 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 		}
 
 		tr.catchUpClient = NewCatchUpClient(config.Logger, tr.tlsClientConfig)
+		tr.catchUpClient.SetMaxDecompressedBytes(config.LocalConf.Replication.MessageSizeLimit())
 
 		// server tls.Config
 		serverKeyBytes, err := os.ReadFile(tr.localConf.Replication.TLS.ServerKeyPath)
@@ -159,6 +155,10 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 		}
 	}
 
+	if tr.faultInjector != nil {
+		tr.catchUpClient.remote = &faultyCatchUpRemote{remote: tr.catchUpClient.remote, injector: tr.faultInjector}
+	}
+
 	return tr, nil
 }
 
@@ -182,7 +182,7 @@ func (p *HTTPTransport) SetConsensusListener(l ConsensusListener) error {
 
 // SetClusterConfig sets the initial types.ClusterConfig into the HTTPTransport for the first time.
 // In this invocation the  HTTPTransport detects what is its local RaftID by collating its local ID (string) with
-// the member set in the ClusterConfig.
+// the member and observer sets in the ClusterConfig.
 //
 // This must be called before the call to Start().
 func (p *HTTPTransport) SetClusterConfig(clusterConfig *types.ClusterConfig) error {
@@ -200,6 +200,8 @@ func (p *HTTPTransport) SetClusterConfig(clusterConfig *types.ClusterConfig) err
 
 	p.raftID = raftID
 	p.clusterConfig = clusterConfig
+	p.catchUpClient.SetCompression(clusterConfig.GetConsensusConfig().GetRaftConfig().GetCompression())
+	p.faultInjector.updatePeers(clusterConfig)
 
 	return nil
 }
@@ -240,8 +242,9 @@ func (p *HTTPTransport) Start() error {
 		return errors.Wrapf(err, "failed to start rafthttp transport")
 	}
 
+	// observers replicate as raft learners, hence they are raft peers as well; blocks are pulled from them too
 	var membersList []*types.PeerConfig
-	for _, peer := range p.clusterConfig.ConsensusConfig.Members {
+	for _, peer := range consensusPeers(p.clusterConfig) {
 		if peer.RaftId != p.raftID {
 			membersList = append(membersList, peer)
 			schema := "http"
@@ -315,7 +318,7 @@ func (p *HTTPTransport) UpdatePeers(added, removed, changed []*types.PeerConfig,
 
 	if len(added)+len(removed)+len(changed) > 0 {
 		var membersList []*types.PeerConfig
-		for _, peer := range updatedClusterConfig.ConsensusConfig.Members {
+		for _, peer := range consensusPeers(updatedClusterConfig) {
 			if peer.RaftId != p.raftID {
 				membersList = append(membersList, peer)
 			}
@@ -326,6 +329,8 @@ func (p *HTTPTransport) UpdatePeers(added, removed, changed []*types.PeerConfig,
 	}
 
 	p.clusterConfig = updatedClusterConfig
+	p.catchUpClient.SetCompression(updatedClusterConfig.GetConsensusConfig().GetRaftConfig().GetCompression())
+	p.faultInjector.updatePeers(updatedClusterConfig)
 
 	return nil
 }
@@ -367,13 +372,21 @@ func (p *HTTPTransport) Close() {
 }
 
 func (p *HTTPTransport) SendConsensus(msgs []raftpb.Message) error {
+	if p.faultInjector != nil {
+		msgs = p.faultInjector.injectConsensus(msgs, p.stopCh, p.send)
+	}
+
+	p.send(msgs)
+
+	return nil
+}
+
+func (p *HTTPTransport) send(msgs []raftpb.Message) {
 	for i, m := range msgs {
 		p.logger.Debugf("SendConsensus (%d/%d): Type: %s, From: %d, To: %d", i+1, len(msgs), m.Type, p.raftID, m.To)
 	}
 
 	p.transport.Send(msgs)
-
-	return nil
 }
 
 func (p *HTTPTransport) ClientTLSConfig() *tls.Config {
@@ -390,11 +403,35 @@ func (p *HTTPTransport) PullBlocks(ctx context.Context, startBlock, endBlock, le
 	return p.catchUpClient.PullBlocks(ctx, startBlock, endBlock, leaderID)
 }
 
+// PullBlocksConcurrently pulls the blocks that follow `lastBlock` up to `endBlock` (inclusive) concurrently from the
+// cluster members and observers, and passes them in order to `deliver`, after verifying that they are linked to each
+// other and to `lastBlock`. If `lastBlock` is nil, the pull starts from the genesis block. If `endBaseHash`, the base
+// header hash of block `endBlock`, is given, the blocks are verified against it before any of them is delivered. The
+// `leaderID` is a hint to the leader's Raft ID, and can be 0. The call maybe canceled using the context `ctx`.
+func (p *HTTPTransport) PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, endBlock uint64, endBaseHash []byte, leaderID uint64, deliver func(blocks []*types.Block) error) error {
+	return p.catchUpClient.PullBlocksConcurrently(ctx, lastBlock, endBlock, endBaseHash, leaderID, deliver)
+}
+
+// PullStateSnapshot tries to pull a state snapshot from the cluster members, starting from the leader hint (if
+// exists), and passes the snapshot stream to `receive`. The members are tried once, in turn, until `receive` succeeds;
+// otherwise the last error is returned. The `leaderID` is a hint to the leader's Raft ID, and can be 0. The call maybe
+// canceled using the context `ctx`.
+func (p *HTTPTransport) PullStateSnapshot(ctx context.Context, leaderID uint64, receive func(r io.Reader) error) error {
+	return p.catchUpClient.PullStateSnapshot(ctx, leaderID, receive)
+}
+
+// PullAttestations pulls the attestations of the peer `targetID` on the headers of the blocks [start,end] (inclusive).
+// The peer returns only the attestations it has, which may be fewer than requested, or none. The `targetID` is the
+// Raft ID of a cluster member. The call maybe canceled using the context `ctx`.
+func (p *HTTPTransport) PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error) {
+	return p.catchUpClient.PullAttestations(ctx, targetID, start, end)
+}
+
 // ActivePeers returns the peers that are active for more than `minDuration`.
 // The returned peers  include the self node if includeSelf==true.
 func (p *HTTPTransport) ActivePeers(minDuration time.Duration, includeSelf bool) map[string]*types.PeerConfig {
 	var activePeers = make(map[string]*types.PeerConfig)
-	for _, m := range p.clusterConfig.GetConsensusConfig().GetMembers() {
+	for _, m := range consensusPeers(p.clusterConfig) {
 		if includeSelf && m.RaftId == p.raftID {
 			activePeers[m.NodeId] = m
 			continue
@@ -415,12 +452,24 @@ func (p *HTTPTransport) ActivePeers(minDuration time.Duration, includeSelf bool)
 	return activePeers
 }
 
+// MemberRaftID returns the Raft ID of a node, which may be either a consensus member or an observer.
 func MemberRaftID(memberID string, clusterConfig *types.ClusterConfig) (uint64, error) {
-	for _, member := range clusterConfig.ConsensusConfig.Members {
-		if member.NodeId == memberID {
-			return member.RaftId, nil
+	for _, peer := range consensusPeers(clusterConfig) {
+		if peer.NodeId == memberID {
+			return peer.RaftId, nil
 		}
 	}
 
-	return 0, errors.Errorf("node ID '%s' is not in Consensus members: %v", memberID, clusterConfig.ConsensusConfig.Members)
+	return 0, errors.Errorf("node ID '%s' is not in Consensus members or observers: %v, %v",
+		memberID, clusterConfig.ConsensusConfig.Members, clusterConfig.ConsensusConfig.Observers)
+}
+
+// consensusPeers returns all the Raft peers: the members, which vote, followed by the observers, which are learners.
+func consensusPeers(clusterConfig *types.ClusterConfig) []*types.PeerConfig {
+	members := clusterConfig.GetConsensusConfig().GetMembers()
+	observers := clusterConfig.GetConsensusConfig().RaftObservers()
+
+	peers := make([]*types.PeerConfig, 0, len(members)+len(observers))
+	peers = append(peers, members...)
+	return append(peers, observers...)
 }
@@ -0,0 +1,679 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer.proto
+
+package peerpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type StepRequest struct {
+	// A marshaled raftpb.Message.
+	Message              []byte   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StepRequest) Reset()         { *m = StepRequest{} }
+func (m *StepRequest) String() string { return proto.CompactTextString(m) }
+func (*StepRequest) ProtoMessage()    {}
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{0}
+}
+
+func (m *StepRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StepRequest.Unmarshal(m, b)
+}
+func (m *StepRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StepRequest.Marshal(b, m, deterministic)
+}
+func (m *StepRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepRequest.Merge(m, src)
+}
+func (m *StepRequest) XXX_Size() int {
+	return xxx_messageInfo_StepRequest.Size(m)
+}
+func (m *StepRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StepRequest proto.InternalMessageInfo
+
+func (m *StepRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type StepResponse struct {
+	// The Raft ID of the called peer.
+	RaftId               uint64   `protobuf:"varint,1,opt,name=raft_id,json=raftId,proto3" json:"raft_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StepResponse) Reset()         { *m = StepResponse{} }
+func (m *StepResponse) String() string { return proto.CompactTextString(m) }
+func (*StepResponse) ProtoMessage()    {}
+func (*StepResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{1}
+}
+
+func (m *StepResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StepResponse.Unmarshal(m, b)
+}
+func (m *StepResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StepResponse.Marshal(b, m, deterministic)
+}
+func (m *StepResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepResponse.Merge(m, src)
+}
+func (m *StepResponse) XXX_Size() int {
+	return xxx_messageInfo_StepResponse.Size(m)
+}
+func (m *StepResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StepResponse proto.InternalMessageInfo
+
+func (m *StepResponse) GetRaftId() uint64 {
+	if m != nil {
+		return m.RaftId
+	}
+	return 0
+}
+
+type CatchUpRequest struct {
+	// Types that are valid to be assigned to Request:
+	//	*CatchUpRequest_Blocks
+	//	*CatchUpRequest_StateSnapshot
+	//	*CatchUpRequest_Attestations
+	Request              isCatchUpRequest_Request `protobuf_oneof:"request"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *CatchUpRequest) Reset()         { *m = CatchUpRequest{} }
+func (m *CatchUpRequest) String() string { return proto.CompactTextString(m) }
+func (*CatchUpRequest) ProtoMessage()    {}
+func (*CatchUpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{2}
+}
+
+func (m *CatchUpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CatchUpRequest.Unmarshal(m, b)
+}
+func (m *CatchUpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CatchUpRequest.Marshal(b, m, deterministic)
+}
+func (m *CatchUpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CatchUpRequest.Merge(m, src)
+}
+func (m *CatchUpRequest) XXX_Size() int {
+	return xxx_messageInfo_CatchUpRequest.Size(m)
+}
+func (m *CatchUpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CatchUpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CatchUpRequest proto.InternalMessageInfo
+
+type isCatchUpRequest_Request interface {
+	isCatchUpRequest_Request()
+}
+
+type CatchUpRequest_Blocks struct {
+	Blocks *BlocksRequest `protobuf:"bytes,1,opt,name=blocks,proto3,oneof"`
+}
+
+type CatchUpRequest_StateSnapshot struct {
+	StateSnapshot *StateSnapshotRequest `protobuf:"bytes,2,opt,name=state_snapshot,json=stateSnapshot,proto3,oneof"`
+}
+
+type CatchUpRequest_Attestations struct {
+	Attestations *AttestationsRequest `protobuf:"bytes,3,opt,name=attestations,proto3,oneof"`
+}
+
+func (*CatchUpRequest_Blocks) isCatchUpRequest_Request() {}
+
+func (*CatchUpRequest_StateSnapshot) isCatchUpRequest_Request() {}
+
+func (*CatchUpRequest_Attestations) isCatchUpRequest_Request() {}
+
+func (m *CatchUpRequest) GetRequest() isCatchUpRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *CatchUpRequest) GetBlocks() *BlocksRequest {
+	if x, ok := m.GetRequest().(*CatchUpRequest_Blocks); ok {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (m *CatchUpRequest) GetStateSnapshot() *StateSnapshotRequest {
+	if x, ok := m.GetRequest().(*CatchUpRequest_StateSnapshot); ok {
+		return x.StateSnapshot
+	}
+	return nil
+}
+
+func (m *CatchUpRequest) GetAttestations() *AttestationsRequest {
+	if x, ok := m.GetRequest().(*CatchUpRequest_Attestations); ok {
+		return x.Attestations
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CatchUpRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CatchUpRequest_Blocks)(nil),
+		(*CatchUpRequest_StateSnapshot)(nil),
+		(*CatchUpRequest_Attestations)(nil),
+	}
+}
+
+// BlocksRequest requests the blocks [start_block, end_block]. The response may hold fewer blocks than requested.
+type BlocksRequest struct {
+	StartBlock           uint64   `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlocksRequest) Reset()         { *m = BlocksRequest{} }
+func (m *BlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*BlocksRequest) ProtoMessage()    {}
+func (*BlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{3}
+}
+
+func (m *BlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlocksRequest.Unmarshal(m, b)
+}
+func (m *BlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *BlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlocksRequest.Merge(m, src)
+}
+func (m *BlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_BlocksRequest.Size(m)
+}
+func (m *BlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlocksRequest proto.InternalMessageInfo
+
+func (m *BlocksRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *BlocksRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+type StateSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateSnapshotRequest) Reset()         { *m = StateSnapshotRequest{} }
+func (m *StateSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*StateSnapshotRequest) ProtoMessage()    {}
+func (*StateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{4}
+}
+
+func (m *StateSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSnapshotRequest.Unmarshal(m, b)
+}
+func (m *StateSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *StateSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSnapshotRequest.Merge(m, src)
+}
+func (m *StateSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_StateSnapshotRequest.Size(m)
+}
+func (m *StateSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSnapshotRequest proto.InternalMessageInfo
+
+// AttestationsRequest requests the attestations of the called peer on the blocks [start_block, end_block]. The
+// response holds the attestations the peer has, which may be fewer than requested.
+type AttestationsRequest struct {
+	StartBlock           uint64   `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttestationsRequest) Reset()         { *m = AttestationsRequest{} }
+func (m *AttestationsRequest) String() string { return proto.CompactTextString(m) }
+func (*AttestationsRequest) ProtoMessage()    {}
+func (*AttestationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{5}
+}
+
+func (m *AttestationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttestationsRequest.Unmarshal(m, b)
+}
+func (m *AttestationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttestationsRequest.Marshal(b, m, deterministic)
+}
+func (m *AttestationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttestationsRequest.Merge(m, src)
+}
+func (m *AttestationsRequest) XXX_Size() int {
+	return xxx_messageInfo_AttestationsRequest.Size(m)
+}
+func (m *AttestationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttestationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttestationsRequest proto.InternalMessageInfo
+
+func (m *AttestationsRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *AttestationsRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// Attestations is the response to an AttestationsRequest, over gRPC as well as HTTP.
+type Attestations struct {
+	// Each is a marshaled types.BlockAttestation.
+	Attestations         [][]byte `protobuf:"bytes,1,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attestations) Reset()         { *m = Attestations{} }
+func (m *Attestations) String() string { return proto.CompactTextString(m) }
+func (*Attestations) ProtoMessage()    {}
+func (*Attestations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{6}
+}
+
+func (m *Attestations) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attestations.Unmarshal(m, b)
+}
+func (m *Attestations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attestations.Marshal(b, m, deterministic)
+}
+func (m *Attestations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attestations.Merge(m, src)
+}
+func (m *Attestations) XXX_Size() int {
+	return xxx_messageInfo_Attestations.Size(m)
+}
+func (m *Attestations) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attestations.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attestations proto.InternalMessageInfo
+
+func (m *Attestations) GetAttestations() [][]byte {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+type CatchUpResponse struct {
+	// A marshaled types.Block, in response to a BlocksRequest.
+	Block []byte `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// The next chunk of the state snapshot stream, in response to a StateSnapshotRequest.
+	StateSnapshotChunk []byte `protobuf:"bytes,2,opt,name=state_snapshot_chunk,json=stateSnapshotChunk,proto3" json:"state_snapshot_chunk,omitempty"`
+	// An error which ends the response.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Marks the last response to the request.
+	Last bool `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
+	// A marshaled Attestations, in response to an AttestationsRequest.
+	Attestations         []byte   `protobuf:"bytes,5,opt,name=attestations,proto3" json:"attestations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CatchUpResponse) Reset()         { *m = CatchUpResponse{} }
+func (m *CatchUpResponse) String() string { return proto.CompactTextString(m) }
+func (*CatchUpResponse) ProtoMessage()    {}
+func (*CatchUpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_055ae5a865fc1c9e, []int{7}
+}
+
+func (m *CatchUpResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CatchUpResponse.Unmarshal(m, b)
+}
+func (m *CatchUpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CatchUpResponse.Marshal(b, m, deterministic)
+}
+func (m *CatchUpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CatchUpResponse.Merge(m, src)
+}
+func (m *CatchUpResponse) XXX_Size() int {
+	return xxx_messageInfo_CatchUpResponse.Size(m)
+}
+func (m *CatchUpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CatchUpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CatchUpResponse proto.InternalMessageInfo
+
+func (m *CatchUpResponse) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *CatchUpResponse) GetStateSnapshotChunk() []byte {
+	if m != nil {
+		return m.StateSnapshotChunk
+	}
+	return nil
+}
+
+func (m *CatchUpResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CatchUpResponse) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
+}
+
+func (m *CatchUpResponse) GetAttestations() []byte {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StepRequest)(nil), "peerpb.StepRequest")
+	proto.RegisterType((*StepResponse)(nil), "peerpb.StepResponse")
+	proto.RegisterType((*CatchUpRequest)(nil), "peerpb.CatchUpRequest")
+	proto.RegisterType((*BlocksRequest)(nil), "peerpb.BlocksRequest")
+	proto.RegisterType((*StateSnapshotRequest)(nil), "peerpb.StateSnapshotRequest")
+	proto.RegisterType((*AttestationsRequest)(nil), "peerpb.AttestationsRequest")
+	proto.RegisterType((*Attestations)(nil), "peerpb.Attestations")
+	proto.RegisterType((*CatchUpResponse)(nil), "peerpb.CatchUpResponse")
+}
+
+func init() { proto.RegisterFile("peer.proto", fileDescriptor_055ae5a865fc1c9e) }
+
+var fileDescriptor_055ae5a865fc1c9e = []byte{
+	// 454 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0xc5, 0xad, 0x9b, 0x34, 0x13, 0xb7, 0x48, 0xd3, 0xd0, 0x5a, 0x2d, 0x12, 0x91, 0x2f, 0xcd,
+	0xa5, 0x71, 0x15, 0xc4, 0x11, 0x50, 0x53, 0x21, 0xc1, 0x01, 0x09, 0x39, 0xe2, 0xc2, 0x25, 0x5a,
+	0xdb, 0x43, 0x6c, 0xe1, 0xec, 0x9a, 0xdd, 0x0d, 0x12, 0x07, 0xbe, 0x87, 0x3f, 0xe2, 0x7b, 0x90,
+	0x77, 0x9d, 0x38, 0x4b, 0x7b, 0xe3, 0xe6, 0x79, 0xef, 0xcd, 0x9b, 0x99, 0x9d, 0x31, 0x40, 0x4d,
+	0x24, 0xa7, 0xb5, 0x14, 0x5a, 0x60, 0xaf, 0xf9, 0xae, 0xd3, 0xe8, 0x1a, 0x86, 0x0b, 0x4d, 0x75,
+	0x42, 0xdf, 0x37, 0xa4, 0x34, 0x86, 0xd0, 0x5f, 0x93, 0x52, 0x6c, 0x45, 0xa1, 0x37, 0xf6, 0x26,
+	0x41, 0xb2, 0x0d, 0xa3, 0x6b, 0x08, 0xac, 0x50, 0xd5, 0x82, 0x2b, 0xc2, 0x0b, 0xe8, 0x4b, 0xf6,
+	0x55, 0x2f, 0xcb, 0xdc, 0x28, 0xfd, 0xa4, 0xd7, 0x84, 0x1f, 0xf2, 0xe8, 0x8f, 0x07, 0xa7, 0xf7,
+	0x4c, 0x67, 0xc5, 0xe7, 0x9d, 0x6b, 0x0c, 0xbd, 0xb4, 0x12, 0xd9, 0x37, 0x65, 0xa4, 0xc3, 0xd9,
+	0xb3, 0xa9, 0xad, 0x3e, 0x9d, 0x1b, 0xb4, 0x95, 0xbd, 0x7f, 0x92, 0xb4, 0x32, 0x7c, 0x07, 0xa7,
+	0x4a, 0x33, 0x4d, 0x4b, 0xc5, 0x59, 0xad, 0x0a, 0xa1, 0xc3, 0x03, 0x93, 0xf8, 0x7c, 0x9b, 0xb8,
+	0x68, 0xd8, 0x45, 0x4b, 0x76, 0xf9, 0x27, 0x6a, 0x1f, 0xc7, 0x3b, 0x08, 0x98, 0xd6, 0xd4, 0x80,
+	0xa5, 0xe0, 0x2a, 0x3c, 0x34, 0x26, 0x57, 0x5b, 0x93, 0xbb, 0x3d, 0xae, 0xf3, 0x70, 0x52, 0xe6,
+	0x03, 0xe8, 0x4b, 0x4b, 0x45, 0x1f, 0xe1, 0xc4, 0xe9, 0x17, 0x5f, 0xc0, 0x50, 0x69, 0x26, 0xf5,
+	0xd2, 0x74, 0xdd, 0x3e, 0x03, 0x18, 0xc8, 0x08, 0xf1, 0x0a, 0x06, 0xc4, 0xf3, 0x96, 0x3e, 0x30,
+	0xf4, 0x31, 0xf1, 0xdc, 0x90, 0xd1, 0x39, 0x8c, 0x1e, 0x9b, 0x22, 0x5a, 0xc0, 0xd9, 0x23, 0x8d,
+	0xfd, 0x67, 0xb1, 0x19, 0x04, 0xfb, 0xa6, 0x18, 0xfd, 0xf3, 0x32, 0xde, 0xf8, 0x70, 0x12, 0xb8,
+	0xa3, 0x47, 0xbf, 0x3d, 0x78, 0xba, 0x5b, 0x64, 0xbb, 0xf5, 0x11, 0x1c, 0x75, 0xf5, 0x83, 0xc4,
+	0x06, 0x78, 0x0b, 0x23, 0x77, 0x5d, 0xcb, 0xac, 0xd8, 0x70, 0xdb, 0x45, 0x90, 0xa0, 0xb3, 0x94,
+	0xfb, 0x86, 0x69, 0x7c, 0x48, 0x4a, 0x21, 0xcd, 0x4a, 0x06, 0x89, 0x0d, 0x10, 0xc1, 0xaf, 0x98,
+	0xd2, 0xa1, 0x3f, 0xf6, 0x26, 0xc7, 0x89, 0xf9, 0x7e, 0xd0, 0xe9, 0x91, 0xf1, 0x74, 0xb0, 0xd9,
+	0x2f, 0xf0, 0x3f, 0x11, 0x49, 0x7c, 0x05, 0x7e, 0x73, 0xa3, 0x78, 0xd6, 0x9d, 0xc9, 0xee, 0xb4,
+	0x2f, 0x47, 0x2e, 0x68, 0x07, 0x9a, 0x78, 0xb7, 0x1e, 0xbe, 0x81, 0x7e, 0x3b, 0x27, 0x9e, 0x6f,
+	0x45, 0xee, 0x05, 0x5f, 0x5e, 0x3c, 0xc0, 0xbb, 0xfc, 0xf9, 0xdb, 0x2f, 0xaf, 0x57, 0xa5, 0x2e,
+	0x36, 0xe9, 0x34, 0x13, 0xeb, 0xb8, 0xf8, 0x59, 0x93, 0xac, 0x28, 0x5f, 0x91, 0xbc, 0xa9, 0x58,
+	0xaa, 0x62, 0x21, 0x4b, 0xc1, 0x6f, 0x14, 0xc9, 0x1f, 0x24, 0xe3, 0x92, 0x6b, 0x92, 0x9c, 0x55,
+	0x71, 0x26, 0xd6, 0xeb, 0xd8, 0x9a, 0xa6, 0x3d, 0xf3, 0x4f, 0xbe, 0xfc, 0x3b, 0x00, 0xa4, 0xd1,
+	0x5c, 0xab, 0xa1, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PeerClient is the client API for Peer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PeerClient interface {
+	// Step carries Raft messages from the calling peer. The called peer sends a single StepResponse as soon as the
+	// stream is opened, which identifies it, and nothing after that.
+	Step(ctx context.Context, opts ...grpc.CallOption) (Peer_StepClient, error)
+	// CatchUp serves blocks and state snapshots. Every request is answered by a sequence of responses, the last of
+	// which is marked by `last`.
+	CatchUp(ctx context.Context, opts ...grpc.CallOption) (Peer_CatchUpClient, error)
+}
+
+type peerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPeerClient(cc *grpc.ClientConn) PeerClient {
+	return &peerClient{cc}
+}
+
+func (c *peerClient) Step(ctx context.Context, opts ...grpc.CallOption) (Peer_StepClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Peer_serviceDesc.Streams[0], "/peerpb.Peer/Step", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &peerStepClient{stream}
+	return x, nil
+}
+
+type Peer_StepClient interface {
+	Send(*StepRequest) error
+	Recv() (*StepResponse, error)
+	grpc.ClientStream
+}
+
+type peerStepClient struct {
+	grpc.ClientStream
+}
+
+func (x *peerStepClient) Send(m *StepRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *peerStepClient) Recv() (*StepResponse, error) {
+	m := new(StepResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *peerClient) CatchUp(ctx context.Context, opts ...grpc.CallOption) (Peer_CatchUpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Peer_serviceDesc.Streams[1], "/peerpb.Peer/CatchUp", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &peerCatchUpClient{stream}
+	return x, nil
+}
+
+type Peer_CatchUpClient interface {
+	Send(*CatchUpRequest) error
+	Recv() (*CatchUpResponse, error)
+	grpc.ClientStream
+}
+
+type peerCatchUpClient struct {
+	grpc.ClientStream
+}
+
+func (x *peerCatchUpClient) Send(m *CatchUpRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *peerCatchUpClient) Recv() (*CatchUpResponse, error) {
+	m := new(CatchUpResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PeerServer is the server API for Peer service.
+type PeerServer interface {
+	// Step carries Raft messages from the calling peer. The called peer sends a single StepResponse as soon as the
+	// stream is opened, which identifies it, and nothing after that.
+	Step(Peer_StepServer) error
+	// CatchUp serves blocks and state snapshots. Every request is answered by a sequence of responses, the last of
+	// which is marked by `last`.
+	CatchUp(Peer_CatchUpServer) error
+}
+
+// UnimplementedPeerServer can be embedded to have forward compatible implementations.
+type UnimplementedPeerServer struct {
+}
+
+func (*UnimplementedPeerServer) Step(srv Peer_StepServer) error {
+	return status.Errorf(codes.Unimplemented, "method Step not implemented")
+}
+func (*UnimplementedPeerServer) CatchUp(srv Peer_CatchUpServer) error {
+	return status.Errorf(codes.Unimplemented, "method CatchUp not implemented")
+}
+
+func RegisterPeerServer(s *grpc.Server, srv PeerServer) {
+	s.RegisterService(&_Peer_serviceDesc, srv)
+}
+
+func _Peer_Step_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PeerServer).Step(&peerStepServer{stream})
+}
+
+type Peer_StepServer interface {
+	Send(*StepResponse) error
+	Recv() (*StepRequest, error)
+	grpc.ServerStream
+}
+
+type peerStepServer struct {
+	grpc.ServerStream
+}
+
+func (x *peerStepServer) Send(m *StepResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *peerStepServer) Recv() (*StepRequest, error) {
+	m := new(StepRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Peer_CatchUp_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PeerServer).CatchUp(&peerCatchUpServer{stream})
+}
+
+type Peer_CatchUpServer interface {
+	Send(*CatchUpResponse) error
+	Recv() (*CatchUpRequest, error)
+	grpc.ServerStream
+}
+
+type peerCatchUpServer struct {
+	grpc.ServerStream
+}
+
+func (x *peerCatchUpServer) Send(m *CatchUpResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *peerCatchUpServer) Recv() (*CatchUpRequest, error) {
+	m := new(CatchUpRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Peer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "peerpb.Peer",
+	HandlerType: (*PeerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Step",
+			Handler:       _Peer_Step_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CatchUp",
+			Handler:       _Peer_CatchUp_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer.proto",
+}
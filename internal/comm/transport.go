@@ -0,0 +1,84 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+const (
+	// TransportHTTP selects the HTTPTransport
+	TransportHTTP = "http"
+	// TransportGRPC selects the GRPCTransport
+	TransportGRPC = "grpc"
+)
+
+// Transport sends and receives messages to and from the remote peers that run the Raft cluster, pulls blocks and
+// state snapshots from them in order to catch-up, and pulls their attestations on the blocks. It is implemented over HTTP by HTTPTransport and over gRPC by
+// GRPCTransport, and both are operated in the same way:
+// - Set an initial cluster configuration with SetClusterConfig;
+// - Register a listener to receive incoming messages with SetConsensusListener; and finally,
+// - Start the component with Start. Messages can now be sent and received.
+// - Configuration changes to the cluster's peers are applied using UpdatePeers.
+// - To stop the component call Close.
+type Transport interface {
+	SetConsensusListener(l ConsensusListener) error
+	SetClusterConfig(clusterConfig *types.ClusterConfig) error
+	Start() error
+	UpdatePeers(added, removed, changed []*types.PeerConfig, updatedClusterConfig *types.ClusterConfig) error
+	Close()
+	SendConsensus(msgs []raftpb.Message) error
+	PullBlocks(ctx context.Context, startBlock, endBlock, leaderID uint64) ([]*types.Block, error)
+	PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, endBlock uint64, endBaseHash []byte, leaderID uint64, deliver func(blocks []*types.Block) error) error
+	PullStateSnapshot(ctx context.Context, leaderID uint64, receive func(r io.Reader) error) error
+	PullAttestations(ctx context.Context, targetID, start, end uint64) ([]*types.BlockAttestation, error)
+	ActivePeers(minDuration time.Duration, includeSelf bool) map[string]*types.PeerConfig
+}
+
+// NewTransport creates the Transport selected by the `Replication.Transport` local configuration parameter.
+func NewTransport(conf *Config) (Transport, error) {
+	switch conf.LocalConf.Replication.Transport {
+	case "", TransportHTTP:
+		tr, err := NewHTTPTransport(conf)
+		if err != nil {
+			return nil, err
+		}
+		return tr, nil
+	case TransportGRPC:
+		tr, err := NewGRPCTransport(conf)
+		if err != nil {
+			return nil, err
+		}
+		return tr, nil
+	default:
+		return nil, errors.Errorf("unsupported replication transport: %s", conf.LocalConf.Replication.Transport)
+	}
+}
+
+// loadCACertPool loads and checks the CA certificates, and returns a x509.CertPool of all the CA certificates for
+// tls.Config.
+func loadCACertPool(caConfig *config.CAConfiguration) (*x509.CertPool, error) {
+	caCerts, err := certificateauthority.LoadCAConfig(caConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while loading CA certificates from local configuration Replication.TLS.CaConfig: %+v", *caConfig)
+	}
+	caColl, err := certificateauthority.NewCACertCollection(caCerts.GetRoots(), caCerts.GetIntermediates())
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating a CA certificate collection")
+	}
+	if err := caColl.VerifyCollection(); err != nil {
+		return nil, errors.Wrap(err, "error while verifying the CA certificate collection")
+	}
+
+	return caColl.GetCertPool(), nil
+}
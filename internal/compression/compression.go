@@ -0,0 +1,192 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	// None disables compression, an empty algorithm is the same as None
+	None = "none"
+	// Gzip selects gzip compression
+	Gzip = "gzip"
+	// Zstd selects zstd compression
+	Zstd = "zstd"
+)
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// IsSupported returns true if the algorithm is one of the supported compression algorithms, or is empty.
+func IsSupported(algorithm string) bool {
+	switch algorithm {
+	case "", None, Gzip, Zstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// Enabled returns true if the algorithm actually compresses.
+func Enabled(algorithm string) bool {
+	return algorithm == Gzip || algorithm == Zstd
+}
+
+// Compress compresses data with the algorithm.
+func Compress(algorithm string, data []byte) ([]byte, error) {
+	switch algorithm {
+	case Gzip:
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case Zstd:
+		enc, _, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(data, nil), nil
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm: %s", algorithm)
+	}
+}
+
+// Decompress decompresses data that was compressed with the algorithm. The size of the decompressed data is not
+// bounded, hence it is meant for trusted local data only, see DecompressLimit.
+func Decompress(algorithm string, data []byte) ([]byte, error) {
+	switch algorithm {
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case Zstd:
+		_, dec, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(data, nil)
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm: %s", algorithm)
+	}
+}
+
+// DecompressLimit decompresses data that was compressed with the algorithm, and returns an error as soon as the
+// decompressed data exceeds maxSize bytes.
+func DecompressLimit(algorithm string, data []byte, maxSize uint64) ([]byte, error) {
+	r, err := NewLimitedReader(algorithm, bytes.NewReader(data), maxSize)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// NewWriter returns a writer that compresses into w with the algorithm. The writer must be closed in order to flush
+// the compressed stream.
+func NewWriter(algorithm string, w io.Writer) (io.WriteCloser, error) {
+	switch algorithm {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm: %s", algorithm)
+	}
+}
+
+// NewReader returns a reader that decompresses what it reads from r with the algorithm. The size of the decompressed
+// data is not bounded, hence it is meant for trusted local data only, see NewLimitedReader. The reader must be closed
+// in order to release its resources.
+func NewReader(algorithm string, r io.Reader) (io.ReadCloser, error) {
+	return newReader(algorithm, r, 0)
+}
+
+// NewLimitedReader returns a reader that decompresses what it reads from r with the algorithm, and fails as soon as
+// the decompressed data exceeds maxSize bytes. The reader must be closed in order to release its resources.
+func NewLimitedReader(algorithm string, r io.Reader, maxSize uint64) (io.ReadCloser, error) {
+	rc, err := newReader(algorithm, r, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &limitedReader{
+		r:       io.LimitReader(rc, int64(maxSize)+1),
+		closer:  rc,
+		maxSize: maxSize,
+	}, nil
+}
+
+// newReader returns a decompressing reader, whose zstd decoder refuses frames that need more than maxSize bytes of
+// memory, unless maxSize is zero
+func newReader(algorithm string, r io.Reader, maxSize uint64) (io.ReadCloser, error) {
+	switch algorithm {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		opts := []zstd.DOption{zstd.WithDecoderConcurrency(1)}
+		if maxSize > 0 {
+			opts = append(opts, zstd.WithDecoderMaxMemory(maxSize))
+		}
+		dec, err := zstd.NewReader(r, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm: %s", algorithm)
+	}
+}
+
+// limitedReader fails once more than maxSize bytes are read, instead of truncating the data silently
+type limitedReader struct {
+	r       io.Reader
+	closer  io.Closer
+	maxSize uint64
+	read    uint64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += uint64(n)
+	if l.read > l.maxSize {
+		return n - int(l.read-l.maxSize), errors.Errorf("the decompressed data exceeds the limit of %d bytes", l.maxSize)
+	}
+	return n, err
+}
+
+func (l *limitedReader) Close() error {
+	return l.closer.Close()
+}
+
+// zstdCodec returns the zstd encoder and decoder shared by Compress and Decompress, which are safe for concurrent use
+// with EncodeAll and DecodeAll.
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		if zstdEncoder, zstdErr = zstd.NewWriter(nil); zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
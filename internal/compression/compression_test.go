@@ -0,0 +1,129 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupported(t *testing.T) {
+	for _, algorithm := range []string{"", None, Gzip, Zstd} {
+		require.True(t, IsSupported(algorithm), algorithm)
+	}
+	require.False(t, IsSupported("lz4"))
+
+	require.False(t, Enabled(""))
+	require.False(t, Enabled(None))
+	require.True(t, Enabled(Gzip))
+	require.True(t, Enabled(Zstd))
+}
+
+func TestCompressDecompress(t *testing.T) {
+	data := []byte(strings.Repeat(`{"name":"alice","balance":100,"tags":["a","b","c"]}`, 100))
+
+	for _, algorithm := range []string{Gzip, Zstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			compressed, err := Compress(algorithm, data)
+			require.NoError(t, err)
+			require.Less(t, len(compressed), len(data)/5)
+
+			decompressed, err := Decompress(algorithm, compressed)
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+
+			decompressed, err = DecompressLimit(algorithm, compressed, uint64(len(data)))
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+
+			_, err = Decompress(algorithm, data)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Compress("lz4", data)
+		require.EqualError(t, err, "unsupported compression algorithm: lz4")
+		_, err = Decompress(None, data)
+		require.EqualError(t, err, "unsupported compression algorithm: none")
+		_, err = NewWriter("", &bytes.Buffer{})
+		require.EqualError(t, err, "unsupported compression algorithm: ")
+		_, err = NewReader("lz4", &bytes.Buffer{})
+		require.EqualError(t, err, "unsupported compression algorithm: lz4")
+	})
+}
+
+func TestDecompressLimit(t *testing.T) {
+	data := bytes.Repeat([]byte{0}, 1024*1024)
+
+	tests := []struct {
+		algorithm   string
+		expectedErr string
+	}{
+		{
+			algorithm:   Gzip,
+			expectedErr: "the decompressed data exceeds the limit of 1048575 bytes",
+		},
+		{
+			// the decoder rejects a frame that declares a window larger than the limit before decompressing it
+			algorithm:   Zstd,
+			expectedErr: "window size exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			compressed, err := Compress(tt.algorithm, data)
+			require.NoError(t, err)
+
+			decompressed, err := DecompressLimit(tt.algorithm, compressed, 8*uint64(len(data)))
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+
+			_, err = DecompressLimit(tt.algorithm, compressed, uint64(len(data)-1))
+			require.EqualError(t, err, tt.expectedErr)
+
+			r, err := NewLimitedReader(tt.algorithm, bytes.NewReader(compressed), uint64(len(data)-1))
+			require.NoError(t, err)
+			_, err = ioutil.ReadAll(r)
+			require.EqualError(t, err, tt.expectedErr)
+			require.NoError(t, r.Close())
+		})
+	}
+}
+
+func TestObservedWriter(t *testing.T) {
+	data := []byte(strings.Repeat(`{"name":"bob","balance":200}`, 200))
+
+	for _, algorithm := range []string{Gzip, Zstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			uncompressedBefore := testutil.ToFloat64(uncompressedBytes.WithLabelValues(PathCatchUp, algorithm))
+			compressedBefore := testutil.ToFloat64(compressedBytes.WithLabelValues(PathCatchUp, algorithm))
+
+			buf := &bytes.Buffer{}
+			w, err := NewObservedWriter(PathCatchUp, algorithm, buf)
+			require.NoError(t, err)
+			_, err = w.Write(data[:1000])
+			require.NoError(t, err)
+			_, err = w.Write(data[1000:])
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			require.Equal(t, float64(len(data)), testutil.ToFloat64(uncompressedBytes.WithLabelValues(PathCatchUp, algorithm))-uncompressedBefore)
+			require.Equal(t, float64(buf.Len()), testutil.ToFloat64(compressedBytes.WithLabelValues(PathCatchUp, algorithm))-compressedBefore)
+
+			r, err := NewReader(algorithm, buf)
+			require.NoError(t, err)
+			decompressed, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			require.Equal(t, data, decompressed)
+		})
+	}
+}
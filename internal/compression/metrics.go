@@ -0,0 +1,109 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// PathRaftEntry labels the compression of the blocks proposed in Raft entries
+	PathRaftEntry = "raft_entry"
+	// PathCatchUp labels the compression of the catch-up responses
+	PathCatchUp = "catch_up"
+)
+
+var (
+	uncompressedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "orion",
+			Subsystem: "compression",
+			Name:      "uncompressed_bytes_total",
+			Help:      "The number of bytes that were compressed, before compression.",
+		},
+		[]string{"path", "algorithm"},
+	)
+
+	compressedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "orion",
+			Subsystem: "compression",
+			Name:      "compressed_bytes_total",
+			Help:      "The number of bytes that were compressed, after compression.",
+		},
+		[]string{"path", "algorithm"},
+	)
+
+	compressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "orion",
+			Subsystem: "compression",
+			Name:      "ratio",
+			Help:      "The ratio between the uncompressed and the compressed size of each compressed payload.",
+			Buckets:   []float64{1, 1.5, 2, 3, 5, 7.5, 10, 15, 20},
+		},
+		[]string{"path", "algorithm"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(uncompressedBytes, compressedBytes, compressionRatio)
+}
+
+// Observe records the compression of a payload of `uncompressed` bytes into `compressed` bytes, on one of the paths
+// payloads are compressed on.
+func Observe(path, algorithm string, uncompressed, compressed int) {
+	uncompressedBytes.WithLabelValues(path, algorithm).Add(float64(uncompressed))
+	compressedBytes.WithLabelValues(path, algorithm).Add(float64(compressed))
+	if compressed > 0 {
+		compressionRatio.WithLabelValues(path, algorithm).Observe(float64(uncompressed) / float64(compressed))
+	}
+}
+
+// NewObservedWriter returns a writer that compresses into w with the algorithm, like NewWriter, and records the
+// compression with Observe when it is closed.
+func NewObservedWriter(path, algorithm string, w io.Writer) (io.WriteCloser, error) {
+	cw := &countingWriter{w: w}
+	zw, err := NewWriter(algorithm, cw)
+	if err != nil {
+		return nil, err
+	}
+	return &observedWriter{path: path, algorithm: algorithm, zw: zw, cw: cw}, nil
+}
+
+type observedWriter struct {
+	path      string
+	algorithm string
+	zw        io.WriteCloser
+	cw        *countingWriter
+	n         int
+}
+
+func (w *observedWriter) Write(p []byte) (int, error) {
+	n, err := w.zw.Write(p)
+	w.n += n
+	return n, err
+}
+
+func (w *observedWriter) Close() error {
+	if err := w.zw.Close(); err != nil {
+		return err
+	}
+	Observe(w.path, w.algorithm, w.n, w.cw.n)
+	return nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
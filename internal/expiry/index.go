@@ -0,0 +1,265 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BlockNamespace is the namespace of keys in the ExpiryDBName
+	// indexing the keys which expire at a block number
+	BlockNamespace = "b~"
+	// TimeNamespace is the namespace of keys in the ExpiryDBName
+	// indexing the keys which expire at a wall clock time
+	TimeNamespace = "t~"
+
+	// the points of expiry are zero padded so that the lexicographic
+	// order of the index keys matches the order of expiry
+	pointFormat = "%020d"
+	pointLength = 20
+)
+
+// FromTTL returns the expiry of a key written with the given TTL at the given
+// version, in a block with the given timestamp in its header. As the expiry is
+// derived from the block rather than from the clock of a node, all the nodes
+// derive the same expiry. It returns nil if the TTL does not set any expiry.
+func FromTTL(ttl *types.TTL, version *types.Version, blockTimestamp int64) *types.Expiry {
+	if ttl.GetBlocks() == 0 && ttl.GetSeconds() == 0 {
+		return nil
+	}
+
+	e := &types.Expiry{}
+	if ttl.GetBlocks() > 0 {
+		e.BlockNum = version.GetBlockNum() + ttl.GetBlocks()
+	}
+	if ttl.GetSeconds() > 0 {
+		// a TTL beyond the range of the unix time never expires in practice
+		if ttl.GetSeconds() > uint64(math.MaxInt64-blockTimestamp) {
+			e.UnixTime = math.MaxInt64
+		} else {
+			e.UnixTime = blockTimestamp + int64(ttl.GetSeconds())
+		}
+	}
+	return e
+}
+
+// IsExpired returns true if a key with the given expiry has expired
+// in the block with the given number committed at the given unix time
+func IsExpired(e *types.Expiry, blockNum uint64, unixTime int64) bool {
+	if e.GetBlockNum() > 0 && blockNum >= e.GetBlockNum() {
+		return true
+	}
+	return e.GetUnixTime() > 0 && unixTime >= e.GetUnixTime()
+}
+
+func blockIndexKey(blockNum uint64, dbName, key string) string {
+	return BlockNamespace + fmt.Sprintf(pointFormat, blockNum) + "~" + dbName + "~" + key
+}
+
+func timeIndexKey(unixTime int64, dbName, key string) string {
+	return TimeNamespace + fmt.Sprintf(pointFormat, unixTime) + "~" + dbName + "~" + key
+}
+
+func indexKeys(dbName, key string, e *types.Expiry) []string {
+	var keys []string
+	if e.GetBlockNum() > 0 {
+		keys = append(keys, blockIndexKey(e.GetBlockNum(), dbName, key))
+	}
+	if e.GetUnixTime() > 0 {
+		keys = append(keys, timeIndexKey(e.GetUnixTime(), dbName, key))
+	}
+	return keys
+}
+
+// splitIndexKey returns the database name and the key indexed by the given index key
+func splitIndexKey(indexKey string) (string, string, error) {
+	prefixLength := len(BlockNamespace) + pointLength + 1
+	if len(indexKey) <= prefixLength {
+		return "", "", errors.Errorf("invalid expiry index key [%s]", indexKey)
+	}
+
+	// a database name cannot contain the separator while a key can
+	dbAndKey := strings.SplitN(indexKey[prefixLength:], "~", 2)
+	if len(dbAndKey) != 2 {
+		return "", "", errors.Errorf("invalid expiry index key [%s]", indexKey)
+	}
+	return dbAndKey[0], dbAndKey[1], nil
+}
+
+// ConstructIndexEntries constructs the entries of the ExpiryDBName which keep the
+// expiry index in sync with the given updates of user databases. Similar to the
+// index entries of the stateindex, the expiry index is derived from the committed
+// state and hence, the entries must be constructed before committing the updates.
+func ConstructIndexEntries(dbsUpdates map[string]*worldstate.DBUpdates, db worldstate.DB) (*worldstate.DBUpdates, error) {
+	writes := make(map[string]bool)
+	deletes := make(map[string]bool)
+
+	removeOldEntries := func(dbName, key string, exclude []string) error {
+		_, metadata, err := db.Get(dbName, key)
+		if err != nil {
+			return err
+		}
+
+	oldKeys:
+		for _, oldKey := range indexKeys(dbName, key, metadata.GetExpiry()) {
+			for _, k := range exclude {
+				if k == oldKey {
+					continue oldKeys
+				}
+			}
+			deletes[oldKey] = true
+		}
+		return nil
+	}
+
+	for dbName, updates := range dbsUpdates {
+		if worldstate.IsSystemDB(dbName) {
+			continue
+		}
+
+		for _, w := range updates.Writes {
+			newKeys := indexKeys(dbName, w.Key, w.Metadata.GetExpiry())
+			for _, k := range newKeys {
+				writes[k] = true
+			}
+			if err := removeOldEntries(dbName, w.Key, newKeys); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, key := range updates.Deletes {
+			if err := removeOldEntries(dbName, key, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the expiry of keys of a deleted database must not be applied
+	// to a database created later with the same name
+	var deletedDBs []string
+	if dbUpdates, ok := dbsUpdates[worldstate.DatabasesDBName]; ok {
+		for _, key := range dbUpdates.Deletes {
+			if worldstate.IsDatabaseKey(key) {
+				deletedDBs = append(deletedDBs, key)
+			}
+		}
+	}
+	if len(deletedDBs) > 0 {
+		if err := addEntriesOfDeletedDBs(db, deletedDBs, deletes); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(writes) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+
+	updates := &worldstate.DBUpdates{}
+	for _, k := range sortedKeys(writes) {
+		updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+			Key: k,
+		})
+	}
+	updates.Deletes = sortedKeys(deletes)
+
+	return updates, nil
+}
+
+func addEntriesOfDeletedDBs(db worldstate.DB, deletedDBs []string, deletes map[string]bool) error {
+	isDeleted := make(map[string]bool)
+	for _, dbName := range deletedDBs {
+		isDeleted[dbName] = true
+	}
+
+	itr, err := db.GetIterator(worldstate.ExpiryDBName, "", "")
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	for itr.Next() {
+		indexKey := string(itr.Key())
+		dbName, _, err := splitIndexKey(indexKey)
+		if err != nil {
+			return err
+		}
+		if isDeleted[dbName] {
+			deletes[indexKey] = true
+		}
+	}
+
+	return itr.Error()
+}
+
+// ExpiredKeys returns up to the given number of keys which have expired in the block
+// with the given number committed at the given unix time. Each key is returned along
+// with its committed version.
+func ExpiredKeys(db worldstate.DB, blockNum uint64, unixTime int64, limit int) ([]*types.ExpiredKey, error) {
+	var expiredKeys []*types.ExpiredKey
+	found := make(map[string]bool)
+
+	collect := func(startKey, endKey string) error {
+		itr, err := db.GetIterator(worldstate.ExpiryDBName, startKey, endKey)
+		if err != nil {
+			return err
+		}
+		defer itr.Release()
+
+		for len(expiredKeys) < limit && itr.Next() {
+			dbName, key, err := splitIndexKey(string(itr.Key()))
+			if err != nil {
+				return err
+			}
+			if found[dbName+"~"+key] {
+				// the key expires both at a block and at a wall clock time
+				continue
+			}
+
+			_, metadata, err := db.Get(dbName, key)
+			if err != nil {
+				return err
+			}
+			if metadata == nil || !IsExpired(metadata.GetExpiry(), blockNum, unixTime) {
+				continue
+			}
+
+			found[dbName+"~"+key] = true
+			expiredKeys = append(expiredKeys, &types.ExpiredKey{
+				DbName:  dbName,
+				Key:     key,
+				Version: metadata.GetVersion(),
+			})
+		}
+
+		return itr.Error()
+	}
+
+	if err := collect(BlockNamespace, BlockNamespace+fmt.Sprintf(pointFormat, blockNum+1)); err != nil {
+		return nil, errors.WithMessage(err, "error while collecting keys expiring at a block")
+	}
+	if unixTime >= 0 {
+		if err := collect(TimeNamespace, TimeNamespace+fmt.Sprintf(pointFormat, unixTime+1)); err != nil {
+			return nil, errors.WithMessage(err, "error while collecting keys expiring at a wall clock time")
+		}
+	}
+
+	return expiredKeys, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,388 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	db      *leveldb.LevelDB
+	logger  *logger.SugarLogger
+	cleanup func()
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "expiry")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir: filepath.Join(dir, "leveldb"),
+			Logger:    logger,
+		},
+	)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			t.Errorf("error while removing directory %s, %v", dir, rmErr)
+		}
+		t.Fatalf("error while creating leveldb, %v", err)
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("error while closing the db instance, %v", err)
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("error while removing directory %s, %v", dir, err)
+		}
+	}
+
+	return &testEnv{
+		db:      db,
+		logger:  logger,
+		cleanup: cleanup,
+	}
+}
+
+func TestFromTTLAndIsExpired(t *testing.T) {
+	t.Parallel()
+
+	version := &types.Version{BlockNum: 5, TxNum: 1}
+	blockTimestamp := int64(900)
+
+	tests := []struct {
+		name           string
+		ttl            *types.TTL
+		expectedExpiry *types.Expiry
+		expiredAt      [][2]int64
+		notExpiredAt   [][2]int64
+	}{
+		{
+			name:           "no ttl",
+			ttl:            nil,
+			expectedExpiry: nil,
+			notExpiredAt:   [][2]int64{{100, 100}},
+		},
+		{
+			name:           "empty ttl",
+			ttl:            &types.TTL{},
+			expectedExpiry: nil,
+			notExpiredAt:   [][2]int64{{100, 100}},
+		},
+		{
+			name:           "ttl in blocks",
+			ttl:            &types.TTL{Blocks: 3},
+			expectedExpiry: &types.Expiry{BlockNum: 8},
+			expiredAt:      [][2]int64{{8, 0}, {9, 0}},
+			notExpiredAt:   [][2]int64{{7, 1000}},
+		},
+		{
+			name:           "ttl in seconds from the block timestamp",
+			ttl:            &types.TTL{Seconds: 100},
+			expectedExpiry: &types.Expiry{UnixTime: 1000},
+			expiredAt:      [][2]int64{{1, 1000}, {1, 1001}},
+			notExpiredAt:   [][2]int64{{100, 999}},
+		},
+		{
+			name:           "ttl in both blocks and seconds",
+			ttl:            &types.TTL{Blocks: 3, Seconds: 100},
+			expectedExpiry: &types.Expiry{BlockNum: 8, UnixTime: 1000},
+			expiredAt:      [][2]int64{{8, 0}, {1, 1000}},
+			notExpiredAt:   [][2]int64{{7, 999}},
+		},
+		{
+			name:           "ttl in seconds beyond the range of the unix time",
+			ttl:            &types.TTL{Seconds: math.MaxUint64},
+			expectedExpiry: &types.Expiry{UnixTime: math.MaxInt64},
+			expiredAt:      [][2]int64{{1, math.MaxInt64}},
+			notExpiredAt:   [][2]int64{{100, math.MaxInt64 - 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := FromTTL(tt.ttl, version, blockTimestamp)
+			require.Equal(t, tt.expectedExpiry, e)
+
+			for _, point := range tt.expiredAt {
+				require.True(t, IsExpired(e, uint64(point[0]), point[1]))
+			}
+			for _, point := range tt.notExpiredAt {
+				require.False(t, IsExpired(e, uint64(point[0]), point[1]))
+			}
+		})
+	}
+}
+
+func TestSplitIndexKey(t *testing.T) {
+	t.Parallel()
+
+	dbName, key, err := splitIndexKey(blockIndexKey(10, "db1", "key~with~separators"))
+	require.NoError(t, err)
+	require.Equal(t, "db1", dbName)
+	require.Equal(t, "key~with~separators", key)
+
+	dbName, key, err = splitIndexKey(timeIndexKey(1000, "db2", "key1"))
+	require.NoError(t, err)
+	require.Equal(t, "db2", dbName)
+	require.Equal(t, "key1", key)
+
+	_, _, err = splitIndexKey("b~0001")
+	require.EqualError(t, err, "invalid expiry index key [b~0001]")
+}
+
+func TestConstructIndexEntries(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	// key1 expires at block 10, key2 at time 1000, key3 at block 20 and at time 2000
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1"},
+				{Key: "db2"},
+			},
+		},
+	}, 1))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("v"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}, Expiry: &types.Expiry{BlockNum: 10}}},
+				{Key: "key2", Value: []byte("v"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}, Expiry: &types.Expiry{UnixTime: 1000}}},
+				{Key: "key4", Value: []byte("v"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}}},
+			},
+		},
+		"db2": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key3", Value: []byte("v"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}, Expiry: &types.Expiry{BlockNum: 20, UnixTime: 2000}}},
+			},
+		},
+		worldstate.ExpiryDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: blockIndexKey(10, "db1", "key1")},
+				{Key: timeIndexKey(1000, "db1", "key2")},
+				{Key: blockIndexKey(20, "db2", "key3")},
+				{Key: timeIndexKey(2000, "db2", "key3")},
+			},
+		},
+	}, 2))
+
+	tests := []struct {
+		name            string
+		dbsUpdates      map[string]*worldstate.DBUpdates
+		expectedUpdates *worldstate.DBUpdates
+	}{
+		{
+			name: "no updates to the expiry",
+			dbsUpdates: map[string]*worldstate.DBUpdates{
+				"db1": {
+					Writes: []*worldstate.KVWithMetadata{
+						{Key: "key4", Value: []byte("v2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}}},
+					},
+				},
+				worldstate.UsersDBName: {
+					Deletes: []string{"user1"},
+				},
+			},
+			expectedUpdates: nil,
+		},
+		{
+			name: "key updated with the same expiry",
+			dbsUpdates: map[string]*worldstate.DBUpdates{
+				"db1": {
+					Writes: []*worldstate.KVWithMetadata{
+						{Key: "key1", Value: []byte("v2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}, Expiry: &types.Expiry{BlockNum: 10}}},
+					},
+				},
+			},
+			expectedUpdates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: blockIndexKey(10, "db1", "key1")},
+				},
+			},
+		},
+		{
+			name: "keys written, updated and deleted",
+			dbsUpdates: map[string]*worldstate.DBUpdates{
+				"db1": {
+					Writes: []*worldstate.KVWithMetadata{
+						{Key: "key1", Value: []byte("v2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}}},
+						{Key: "key4", Value: []byte("v2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}, Expiry: &types.Expiry{UnixTime: 500}}},
+						{Key: "key5", Value: []byte("v1"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}, Expiry: &types.Expiry{BlockNum: 4}}},
+					},
+					Deletes: []string{"key2"},
+				},
+				"db2": {
+					Writes: []*worldstate.KVWithMetadata{
+						{Key: "key3", Value: []byte("v2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}, Expiry: &types.Expiry{BlockNum: 30, UnixTime: 2000}}},
+					},
+				},
+			},
+			expectedUpdates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: blockIndexKey(4, "db1", "key5")},
+					{Key: blockIndexKey(30, "db2", "key3")},
+					{Key: timeIndexKey(500, "db1", "key4")},
+					{Key: timeIndexKey(2000, "db2", "key3")},
+				},
+				Deletes: []string{
+					blockIndexKey(10, "db1", "key1"),
+					blockIndexKey(20, "db2", "key3"),
+					timeIndexKey(1000, "db1", "key2"),
+				},
+			},
+		},
+		{
+			name: "database deleted",
+			dbsUpdates: map[string]*worldstate.DBUpdates{
+				worldstate.DatabasesDBName: {
+					Deletes: []string{"db2"},
+				},
+			},
+			expectedUpdates: &worldstate.DBUpdates{
+				Deletes: []string{
+					blockIndexKey(20, "db2", "key3"),
+					timeIndexKey(2000, "db2", "key3"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := ConstructIndexEntries(tt.dbsUpdates, env.db)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedUpdates, updates)
+		})
+	}
+}
+
+func TestExpiredKeys(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1"},
+			},
+		},
+	}, 1))
+
+	v2 := &types.Version{BlockNum: 2}
+	v3 := &types.Version{BlockNum: 3}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("v"), Metadata: &types.Metadata{Version: v2, Expiry: &types.Expiry{BlockNum: 5}}},
+				{Key: "key2", Value: []byte("v"), Metadata: &types.Metadata{Version: v2, Expiry: &types.Expiry{UnixTime: 1000}}},
+				{Key: "key3", Value: []byte("v"), Metadata: &types.Metadata{Version: v3, Expiry: &types.Expiry{BlockNum: 4, UnixTime: 900}}},
+				{Key: "key4", Value: []byte("v"), Metadata: &types.Metadata{Version: v3, Expiry: &types.Expiry{BlockNum: 50}}},
+				// key5 has been updated without a TTL but the stale index entry is still present
+				{Key: "key5", Value: []byte("v"), Metadata: &types.Metadata{Version: v3}},
+			},
+		},
+		worldstate.ExpiryDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: blockIndexKey(5, "db1", "key1")},
+				{Key: timeIndexKey(1000, "db1", "key2")},
+				{Key: blockIndexKey(4, "db1", "key3")},
+				{Key: timeIndexKey(900, "db1", "key3")},
+				{Key: blockIndexKey(50, "db1", "key4")},
+				{Key: blockIndexKey(3, "db1", "key5")},
+			},
+		},
+	}, 3))
+
+	tests := []struct {
+		name         string
+		blockNum     uint64
+		unixTime     int64
+		limit        int
+		expectedKeys []*types.ExpiredKey
+	}{
+		{
+			name:         "nothing expired",
+			blockNum:     3,
+			unixTime:     800,
+			limit:        10,
+			expectedKeys: nil,
+		},
+		{
+			name:     "expired at a block",
+			blockNum: 5,
+			unixTime: 800,
+			limit:    10,
+			expectedKeys: []*types.ExpiredKey{
+				{DbName: "db1", Key: "key3", Version: v3},
+				{DbName: "db1", Key: "key1", Version: v2},
+			},
+		},
+		{
+			name:     "expired at a block and at a wall clock time",
+			blockNum: 5,
+			unixTime: 1000,
+			limit:    10,
+			expectedKeys: []*types.ExpiredKey{
+				{DbName: "db1", Key: "key3", Version: v3},
+				{DbName: "db1", Key: "key1", Version: v2},
+				{DbName: "db1", Key: "key2", Version: v2},
+			},
+		},
+		{
+			name:     "limited number of keys",
+			blockNum: 5,
+			unixTime: 1000,
+			limit:    2,
+			expectedKeys: []*types.ExpiredKey{
+				{DbName: "db1", Key: "key3", Version: v3},
+				{DbName: "db1", Key: "key1", Version: v2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ExpiredKeys(env.db, tt.blockNum, tt.unixTime, tt.limit)
+			require.NoError(t, err)
+			require.Len(t, keys, len(tt.expectedKeys))
+			for i := range keys {
+				require.Equal(t, tt.expectedKeys[i].DbName, keys[i].DbName)
+				require.Equal(t, tt.expectedKeys[i].Key, keys[i].Key)
+				require.True(t, proto.Equal(tt.expectedKeys[i].Version, keys[i].Version))
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxKeysPerTx is the maximum number of keys deleted by a
+// single expiry transaction when no limit is configured
+const DefaultMaxKeysPerTx = 1000
+
+// Submitter submits a transaction to the ordering pipeline
+type Submitter interface {
+	SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponse, error)
+}
+
+// PendingTxs tracks the transactions which have been submitted but not yet committed
+type PendingTxs interface {
+	Has(txID string) bool
+}
+
+// LedgerHeight provides the height of the ledger
+type LedgerHeight interface {
+	Height() (uint64, error)
+}
+
+// Proposer periodically looks up the keys which have expired and, when the
+// local node is the leader, proposes an expiry transaction deleting them.
+// Every node validates the transaction against the block which holds it
+// and hence, the clock of the proposer only decides when keys expiring at
+// a wall clock time are proposed for deletion.
+type Proposer struct {
+	nodeID       string
+	signer       crypto.Signer
+	db           worldstate.DB
+	ledger       LedgerHeight
+	submitter    Submitter
+	pendingTxs   PendingTxs
+	interval     time.Duration
+	maxKeysPerTx int
+	lastTxID     string
+
+	started chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+
+	logger *logger.SugarLogger
+}
+
+// ProposerConfig holds the configuration of the expiry proposer. The expiry
+// transactions are signed by the Signer of the node.
+type ProposerConfig struct {
+	NodeID       string
+	Signer       crypto.Signer
+	DB           worldstate.DB
+	Ledger       LedgerHeight
+	Submitter    Submitter
+	PendingTxs   PendingTxs
+	Interval     time.Duration
+	MaxKeysPerTx int
+	Logger       *logger.SugarLogger
+}
+
+// NewProposer creates a new expiry proposer
+func NewProposer(conf *ProposerConfig) *Proposer {
+	maxKeysPerTx := conf.MaxKeysPerTx
+	if maxKeysPerTx <= 0 {
+		maxKeysPerTx = DefaultMaxKeysPerTx
+	}
+
+	return &Proposer{
+		nodeID:       conf.NodeID,
+		signer:       conf.Signer,
+		db:           conf.DB,
+		ledger:       conf.Ledger,
+		submitter:    conf.Submitter,
+		pendingTxs:   conf.PendingTxs,
+		interval:     conf.Interval,
+		maxKeysPerTx: maxKeysPerTx,
+		started:      make(chan struct{}),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		logger:       conf.Logger,
+	}
+}
+
+// Start runs the proposer till it is stopped
+func (p *Proposer) Start() {
+	defer close(p.stopped)
+
+	p.logger.Info("starting the expiry proposer")
+	close(p.started)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			p.logger.Info("stopping the expiry proposer")
+			return
+
+		case <-ticker.C:
+			if err := p.ProposeExpiredKeys(time.Now()); err != nil {
+				p.logger.Errorf("error while proposing the expired keys: %s", err)
+			}
+		}
+	}
+}
+
+// WaitTillStart waits till the proposer is started
+func (p *Proposer) WaitTillStart() {
+	<-p.started
+}
+
+// Stop stops the proposer
+func (p *Proposer) Stop() {
+	close(p.stop)
+	<-p.stopped
+}
+
+// ProposeExpiredKeys submits an expiry transaction for the keys which have
+// expired at the given time in the next block. No transaction is submitted
+// while the previously submitted one is pending or when the local node is
+// not the leader.
+func (p *Proposer) ProposeExpiredKeys(now time.Time) error {
+	if p.lastTxID != "" && p.pendingTxs.Has(p.lastTxID) {
+		return nil
+	}
+
+	height, err := p.ledger.Height()
+	if err != nil {
+		return err
+	}
+
+	timestamp := now.Unix()
+	expiredKeys, err := ExpiredKeys(p.db, height+1, timestamp, p.maxKeysPerTx)
+	if err != nil {
+		return err
+	}
+	if len(expiredKeys) == 0 {
+		return nil
+	}
+
+	tx := &types.ExpiryTx{
+		TxId:        uuid.New().String(),
+		NodeId:      p.nodeID,
+		Timestamp:   timestamp,
+		ExpiredKeys: expiredKeys,
+	}
+	sig, err := cryptoservice.SignTx(p.signer, tx)
+	if err != nil {
+		return errors.WithMessage(err, "error while signing the expiry transaction")
+	}
+	txEnv := &types.ExpiryTxEnvelope{
+		Payload:   tx,
+		Signature: sig,
+	}
+
+	// the transaction is submitted asynchronously and its outcome
+	// is observed through the expiry index in the next rounds
+	_, err = p.submitter.SubmitTransaction(txEnv, 0)
+	switch err.(type) {
+	case nil:
+		p.lastTxID = txEnv.Payload.TxId
+		p.logger.Debugf("submitted the expiry transaction [%s] for %d keys", txEnv.Payload.TxId, len(expiredKeys))
+		return nil
+	case *ierrors.NotLeaderError:
+		return nil
+	default:
+		return errors.WithMessage(err, "error while submitting the expiry transaction")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package expiry
+
+import (
+	"testing"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSubmitter struct {
+	submitted []*types.ExpiryTxEnvelope
+	err       error
+}
+
+func (s *fakeSubmitter) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.submitted = append(s.submitted, tx.(*types.ExpiryTxEnvelope))
+	return nil, nil
+}
+
+type fakePendingTxs map[string]bool
+
+func (p fakePendingTxs) Has(txID string) bool {
+	return p[txID]
+}
+
+type fakeLedger uint64
+
+func (l fakeLedger) Height() (uint64, error) {
+	return uint64(l), nil
+}
+
+func TestProposeExpiredKeys(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1"},
+			},
+		},
+	}, 1))
+
+	version := &types.Version{BlockNum: 2}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("v"), Metadata: &types.Metadata{Version: version, Expiry: &types.Expiry{BlockNum: 5}}},
+			},
+		},
+		worldstate.ExpiryDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: blockIndexKey(5, "db1", "key1")},
+			},
+		},
+	}, 2))
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"node1"})
+	nodeCert, nodeSigner := testutils.LoadTestCrypto(t, cryptoDir, "node1")
+
+	newProposer := func(submitter Submitter, pendingTxs PendingTxs, height uint64) *Proposer {
+		return NewProposer(&ProposerConfig{
+			NodeID:     "node1",
+			Signer:     nodeSigner,
+			DB:         env.db,
+			Ledger:     fakeLedger(height),
+			Submitter:  submitter,
+			PendingTxs: pendingTxs,
+			Interval:   time.Second,
+			Logger:     env.logger,
+		})
+	}
+	now := time.Unix(1000, 0)
+
+	t.Run("nothing expired", func(t *testing.T) {
+		submitter := &fakeSubmitter{}
+		p := newProposer(submitter, fakePendingTxs{}, 3)
+		require.NoError(t, p.ProposeExpiredKeys(now))
+		require.Empty(t, submitter.submitted)
+	})
+
+	t.Run("expired key is proposed once while pending", func(t *testing.T) {
+		submitter := &fakeSubmitter{}
+		pendingTxs := fakePendingTxs{}
+		p := newProposer(submitter, pendingTxs, 4)
+
+		require.NoError(t, p.ProposeExpiredKeys(now))
+		require.Len(t, submitter.submitted, 1)
+		tx := submitter.submitted[0].Payload
+		require.NotEmpty(t, tx.TxId)
+		require.Equal(t, "node1", tx.NodeId)
+		require.Equal(t, int64(1000), tx.Timestamp)
+		require.Len(t, tx.ExpiredKeys, 1)
+		require.Equal(t, "db1", tx.ExpiredKeys[0].DbName)
+		require.Equal(t, "key1", tx.ExpiredKeys[0].Key)
+		testutils.VerifyPayloadSignature(t, nodeCert.Raw, tx, submitter.submitted[0].Signature)
+
+		pendingTxs[tx.TxId] = true
+		require.NoError(t, p.ProposeExpiredKeys(now))
+		require.Len(t, submitter.submitted, 1)
+
+		delete(pendingTxs, tx.TxId)
+		require.NoError(t, p.ProposeExpiredKeys(now))
+		require.Len(t, submitter.submitted, 2)
+	})
+
+	t.Run("not a leader", func(t *testing.T) {
+		submitter := &fakeSubmitter{err: &ierrors.NotLeaderError{LeaderID: 2, LeaderHostPort: "127.0.0.1:7051"}}
+		p := newProposer(submitter, fakePendingTxs{}, 4)
+		require.NoError(t, p.ProposeExpiredKeys(now))
+	})
+
+	t.Run("submission failure", func(t *testing.T) {
+		submitter := &fakeSubmitter{err: errors.New("queue is full")}
+		p := newProposer(submitter, fakePendingTxs{}, 4)
+		require.EqualError(t, p.ProposeExpiredKeys(now), "error while submitting the expiry transaction: queue is full")
+	})
+}
@@ -43,6 +43,7 @@ func NewConfigRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handle
 	handler.router.HandleFunc(constants.GetLastConfigBlock, handler.configBlockQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetNodeConfig, handler.nodeQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.PostConfigTx, handler.configTransaction).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostClusterLeader, handler.transferLeadership).Methods(http.MethodPost)
 	// HTTP GET "/config/cluster?nocert=true" returns nodes without certificates
 	handler.router.HandleFunc(constants.GetClusterStatus, handler.clusterStatusQuery).Methods(http.MethodGet).Queries("nocert", "{noCertificates:true|false}")
 	// HTTP GET "/config/cluster" returns nodes with certificates
@@ -189,5 +190,88 @@ func (c *configRequestHandler) configTransaction(response http.ResponseWriter, r
 		return
 	}
 
+	if err, code := VerifyApprovalSignatures(c.sigVerifier, txEnv.Signatures, txEnv.Payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
 	c.txHandler.handleTransaction(response, request, txEnv, timeout)
 }
+
+func (c *configRequestHandler) transferLeadership(response http.ResponseWriter, request *http.Request) {
+	timeout, err := validateAndParseTxPostHeader(&request.Header)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	d := json.NewDecoder(request.Body)
+	d.DisallowUnknownFields()
+
+	requestEnv := &types.TransferLeadershipRequestEnvelope{}
+	if err := d.Decode(requestEnv); err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	if requestEnv.Payload == nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if requestEnv.Payload.UserId == "" {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if requestEnv.Payload.TargetNodeId == "" {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing TargetNodeID in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if requestEnv.Payload.Timestamp == 0 {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Timestamp in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if len(requestEnv.Signature) == 0 {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Signature in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if err, code := VerifyRequestSignature(c.sigVerifier, requestEnv.Payload.UserId, requestEnv.Signature, requestEnv.Payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	transferResponseEnvelope, err := c.db.TransferLeadership(requestEnv.Payload.UserId, requestEnv.Payload.TargetNodeId, requestEnv.Payload.Timestamp, timeout)
+	if err != nil {
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			utils.SendHTTPResponse(response, http.StatusForbidden, &types.HttpResponseErr{ErrMsg: err.Error()})
+		case *ierrors.BadRequestError:
+			utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		case *ierrors.NotLeaderError:
+			leaderErr := err.(*ierrors.NotLeaderError)
+			if leaderErr.GetLeaderID() == 0 {
+				utils.SendHTTPResponse(response, http.StatusServiceUnavailable, &types.HttpResponseErr{ErrMsg: "Cluster leader unavailable"})
+			} else {
+				utils.SendHTTPRedirectServer(response, request, leaderErr.GetLeaderHostPort())
+			}
+		default:
+			utils.SendHTTPResponse(
+				response,
+				http.StatusInternalServerError,
+				&types.HttpResponseErr{ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error()},
+			)
+		}
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, transferResponseEnvelope)
+}
@@ -216,8 +216,10 @@ func TestConfigRequestHandler_GetConfig(t *testing.T) {
 
 func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 	submittingUserName := "admin"
-	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin"})
+	approvingUserName := "admin2"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin", "admin2"})
 	adminCert, adminSigner := testutils.LoadTestCrypto(t, cryptoDir, "admin")
+	admin2Cert, admin2Signer := testutils.LoadTestCrypto(t, cryptoDir, "admin2")
 
 	configTx := &types.ConfigTx{
 		UserId: submittingUserName,
@@ -244,6 +246,29 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 		},
 	}
 	sigAdmin := testutils.SignatureFromTx(t, adminSigner, configTx)
+	sigAdmin2 := testutils.SignatureFromTx(t, admin2Signer, configTx)
+
+	unapprovedTxRespEnv := &types.TxReceiptResponseEnvelope{
+		Response: &types.TxReceiptResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "node1",
+			},
+			Receipt: &types.TxReceipt{
+				Header: &types.BlockHeader{
+					BaseHeader: &types.BlockHeaderBase{
+						Number: 1,
+					},
+					ValidationInfo: []*types.ValidationInfo{
+						{
+							Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+							ReasonIfInvalid: "the governance policy requires [2] admins to approve cluster administrative operations but only [1] admins have signed the transaction",
+						},
+					},
+				},
+				TxIndex: 0,
+			},
+		},
+	}
 
 	type testCase struct {
 		name                    string
@@ -281,6 +306,79 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			timeoutStr:   "1s",
 			expectedCode: http.StatusOK,
 		},
+		{
+			name: "submit configuration update approved by another admin",
+			txEnvFactory: func() *types.ConfigTxEnvelope {
+				return &types.ConfigTxEnvelope{
+					Payload:    configTx,
+					Signature:  sigAdmin,
+					Signatures: map[string][]byte{approvingUserName: sigAdmin2},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return correctTxRespEnv
+			},
+			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("GetCertificate", approvingUserName).Return(admin2Cert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					config := args[0].(*types.ConfigTxEnvelope)
+					require.Equal(t, configTx, config)
+					require.Equal(t, timeout, args[1].(time.Duration))
+				}).Return(txRespEnv, nil)
+
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "submit configuration update without the approvals required by the governance policy",
+			txEnvFactory: func() *types.ConfigTxEnvelope {
+				return &types.ConfigTxEnvelope{
+					Payload:   configTx,
+					Signature: sigAdmin,
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return unapprovedTxRespEnv
+			},
+			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					config := args[0].(*types.ConfigTxEnvelope)
+					require.Equal(t, configTx, config)
+				}).Return(txRespEnv, nil)
+
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bad approval signature",
+			txEnvFactory: func() *types.ConfigTxEnvelope {
+				return &types.ConfigTxEnvelope{
+					Payload:    configTx,
+					Signature:  sigAdmin,
+					Signatures: map[string][]byte{approvingUserName: sigAdmin},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return nil
+			},
+			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("GetCertificate", approvingUserName).Return(admin2Cert, nil)
+
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed for the approving user [admin2]",
+		},
 		{
 			name: "transaction timeout",
 			txEnvFactory: func() *types.ConfigTxEnvelope {
@@ -1221,3 +1319,181 @@ func TestConfigRequestHandler_GetClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigRequestHandler_TransferLeadership(t *testing.T) {
+	submittingUserName := "admin"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin"})
+	adminCert, adminSigner := testutils.LoadTestCrypto(t, cryptoDir, "admin")
+
+	transferRequest := &types.TransferLeadershipRequest{
+		UserId:       submittingUserName,
+		TargetNodeId: "node2",
+		Timestamp:    time.Now().UnixNano(),
+	}
+	sigAdmin := testutils.SignatureFromQuery(t, adminSigner, transferRequest)
+
+	transferResponse := &types.TransferLeadershipResponseEnvelope{
+		Response: &types.TransferLeadershipResponse{
+			Header: &types.ResponseHeader{NodeId: "node1"},
+			LeadershipTransfer: &types.LeadershipTransfer{
+				FromNodeId: "node1",
+				ToNodeId:   "node2",
+				Status:     types.LeadershipTransfer_SUCCEEDED,
+			},
+		},
+		Signature: []byte("signature"),
+	}
+
+	testCases := []struct {
+		name                    string
+		requestEnv              *types.TransferLeadershipRequestEnvelope
+		createMockAndInstrument func(t *testing.T) bcdb.DB
+		timeoutStr              string
+		expectedCode            int
+		expectedErr             string
+	}{
+		{
+			name:       "valid request",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", transferRequest.Timestamp, 5*time.Second).Return(transferResponse, nil)
+				return db
+			},
+			timeoutStr:   "5s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:       "missing target node",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: &types.TransferLeadershipRequest{UserId: submittingUserName}, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing TargetNodeID in request envelope payload (*types.TransferLeadershipRequest)",
+		},
+		{
+			name:       "missing timestamp",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: &types.TransferLeadershipRequest{UserId: submittingUserName, TargetNodeId: "node2"}, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing Timestamp in request envelope payload (*types.TransferLeadershipRequest)",
+		},
+		{
+			name:       "missing signature",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing Signature in request envelope payload (*types.TransferLeadershipRequest)",
+		},
+		{
+			name:       "bad signature",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: []byte("bad-sig")},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name:       "not an admin",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", transferRequest.Timestamp, time.Duration(0)).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "the user [admin] has no permission to transfer the leadership of the cluster"})
+				return db
+			},
+			expectedCode: http.StatusForbidden,
+			expectedErr:  "the user [admin] has no permission to transfer the leadership of the cluster",
+		},
+		{
+			name:       "target is not a member",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", transferRequest.Timestamp, time.Duration(0)).
+					Return(nil, &interrors.BadRequestError{ErrMsg: "the target node [node2] is not a consensus member"})
+				return db
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "the target node [node2] is not a consensus member",
+		},
+		{
+			name:       "leader unavailable",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", transferRequest.Timestamp, time.Duration(0)).Return(nil, &interrors.NotLeaderError{})
+				return db
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedErr:  "Cluster leader unavailable",
+		},
+		{
+			name:       "not a leader",
+			requestEnv: &types.TransferLeadershipRequestEnvelope{Payload: transferRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", transferRequest.Timestamp, time.Duration(0)).Return(nil, &interrors.NotLeaderError{
+					LeaderID:       3,
+					LeaderHostPort: "server3.example.com:6091",
+				})
+				return db
+			},
+			expectedCode: http.StatusTemporaryRedirect,
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBytes, err := json.Marshal(tt.requestEnv)
+			require.NoError(t, err)
+
+			reqUrl := &url.URL{
+				Scheme: "http",
+				Host:   "server1.example.com:6091",
+				Path:   constants.PostClusterLeader,
+			}
+			req, err := http.NewRequest(http.MethodPost, reqUrl.String(), bytes.NewReader(reqBytes))
+			require.NoError(t, err)
+			if len(tt.timeoutStr) != 0 {
+				req.Header.Set(constants.TimeoutHeader, tt.timeoutStr)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(tt.createMockAndInstrument(t), logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				resp := &types.TransferLeadershipResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(resp)
+				require.NoError(t, err)
+				require.Equal(t, transferResponse, resp)
+			} else if tt.expectedCode == http.StatusTemporaryRedirect {
+				require.Equal(t, "http://server3.example.com:6091/config/cluster/leader", rr.Header().Get("Location"))
+			} else {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+		})
+	}
+}
@@ -48,9 +48,14 @@ func NewDataRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler
 		"limit", "{limit}",
 	}
 
+	// HTTP GET "/data/{dbname}?startkey=..&consistency=linearizable" and "/data/{dbname}/{key}?consistency=linearizable"
+	// serve the query after a Raft ReadIndex round
+	handler.router.HandleFunc(constants.GetDataRange, handler.dataRangeQuery).Methods(http.MethodGet).Queries(append(rangeKeys, "consistency", "{consistency}")...)
 	handler.router.HandleFunc(constants.GetDataRange, handler.dataRangeQuery).Methods(http.MethodGet).Queries(rangeKeys...)
+	handler.router.HandleFunc(constants.GetData, handler.dataQuery).Methods(http.MethodGet).Queries("consistency", "{consistency}")
 	handler.router.HandleFunc(constants.GetData, handler.dataQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.PostDataTx, handler.dataTransaction).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostDataProcedureTx, handler.procedureTransaction).Methods(http.MethodPost)
 	handler.router.HandleFunc(constants.PostDataQuery, handler.dataJSONQuery).Methods(http.MethodPost)
 
 	return handler
@@ -74,6 +79,10 @@ func (d *dataRequestHandler) dataQuery(response http.ResponseWriter, request *ht
 		return
 	}
 
+	if query.Linearizable && !d.linearizableRead(response, request) {
+		return
+	}
+
 	data, err := d.db.GetData(query.DbName, query.UserId, query.Key)
 	if err != nil {
 		var status int
@@ -111,6 +120,10 @@ func (d *dataRequestHandler) dataRangeQuery(response http.ResponseWriter, reques
 		return
 	}
 
+	if query.Linearizable && !d.linearizableRead(response, request) {
+		return
+	}
+
 	data, err := d.db.GetDataRange(query.DbName, query.UserId, query.StartKey, query.EndKey, query.Limit)
 	if err != nil {
 		var status int
@@ -134,6 +147,31 @@ func (d *dataRequestHandler) dataRangeQuery(response http.ResponseWriter, reques
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+// linearizableRead waits until the local commit reaches the leader's commit, as determined by a Raft ReadIndex round.
+// If the wait fails, an error is sent in the response and false is returned.
+func (d *dataRequestHandler) linearizableRead(response http.ResponseWriter, request *http.Request) bool {
+	err := d.db.LinearizableRead(request.Context())
+	if err == nil {
+		return true
+	}
+
+	var status int
+	switch err.(type) {
+	case *errors.TimeoutErr, *errors.ServerRestrictionError:
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	utils.SendHTTPResponse(
+		response,
+		status,
+		&types.HttpResponseErr{
+			ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+		})
+	return false
+}
+
 func (d *dataRequestHandler) dataTransaction(response http.ResponseWriter, request *http.Request) {
 	timeout, err := validateAndParseTxPostHeader(&request.Header)
 	if err != nil {
@@ -156,21 +194,66 @@ func (d *dataRequestHandler) dataTransaction(response http.ResponseWriter, reque
 		return
 	}
 
-	if len(txEnv.Payload.MustSignUserIds) == 0 {
+	if !d.verifyTxSignatures(response, txEnv.Payload.MustSignUserIds, txEnv.Signatures, txEnv.Payload) {
+		return
+	}
+
+	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+}
+
+func (d *dataRequestHandler) procedureTransaction(response http.ResponseWriter, request *http.Request) {
+	timeout, err := validateAndParseTxPostHeader(&request.Header)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	requestData := json.NewDecoder(request.Body)
+	requestData.DisallowUnknownFields()
+
+	txEnv := &types.ProcedureTxEnvelope{}
+	if err := requestData.Decode(txEnv); err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	if txEnv.Payload == nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)})
+		return
+	}
+
+	if txEnv.Payload.DbName == "" || txEnv.Payload.ProcedureName == "" {
 		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)})
+			&types.HttpResponseErr{ErrMsg: "missing database name or procedure name in transaction envelope payload"})
 		return
 	}
 
+	if !d.verifyTxSignatures(response, txEnv.Payload.MustSignUserIds, txEnv.Signatures, txEnv.Payload) {
+		return
+	}
+
+	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+}
+
+// verifyTxSignatures checks that every user in the must sign list has signed the transaction payload.
+// When the verification fails, it responds with an error and returns false.
+func (d *dataRequestHandler) verifyTxSignatures(response http.ResponseWriter, mustSignUserIDs []string, signatures map[string][]byte, payload interface{}) bool {
+	if len(mustSignUserIDs) == 0 {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", payload)})
+		return false
+	}
+
 	var notSigned []string
-	for _, user := range txEnv.Payload.MustSignUserIds {
+	for _, user := range mustSignUserIDs {
 		if user == "" {
 			utils.SendHTTPResponse(response, http.StatusBadRequest,
 				&types.HttpResponseErr{ErrMsg: "an empty UserID in MustSignUserIDs list present in the transaction envelope"})
-			return
+			return false
 		}
 
-		if _, ok := txEnv.Signatures[user]; !ok {
+		if _, ok := signatures[user]; !ok {
 			notSigned = append(notSigned, user)
 		}
 	}
@@ -178,17 +261,17 @@ func (d *dataRequestHandler) dataTransaction(response http.ResponseWriter, reque
 		sort.Strings(notSigned)
 		utils.SendHTTPResponse(response, http.StatusBadRequest,
 			&types.HttpResponseErr{ErrMsg: "users [" + strings.Join(notSigned, ",") + "] in the must sign list have not signed the transaction"})
-		return
+		return false
 	}
 
-	for _, userID := range txEnv.Payload.MustSignUserIds {
-		if err, code := VerifyRequestSignature(d.sigVerifier, userID, txEnv.Signatures[userID], txEnv.Payload); err != nil {
+	for _, userID := range mustSignUserIDs {
+		if err, code := VerifyRequestSignature(d.sigVerifier, userID, signatures[userID], payload); err != nil {
 			utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
-			return
+			return false
 		}
 	}
 
-	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+	return true
 }
 
 func (d *dataRequestHandler) dataJSONQuery(response http.ResponseWriter, request *http.Request) {
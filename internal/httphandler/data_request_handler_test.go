@@ -37,6 +37,13 @@ func TestDataRequestHandler_DataQuery(t *testing.T) {
 		Key:    "foo",
 	})
 
+	sigFooLinearizable := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataQuery{
+		UserId:       submittingUserName,
+		DbName:       dbName,
+		Key:          "foo",
+		Linearizable: true,
+	})
+
 	testCases := []struct {
 		name               string
 		requestFactory     func() (*http.Request, error)
@@ -80,6 +87,94 @@ func TestDataRequestHandler_DataQuery(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "valid linearizable get data request",
+			expectedResponse: &types.GetDataResponseEnvelope{
+				Response: &types.GetDataResponse{
+					Header: &types.ResponseHeader{
+						NodeId:      "testNodeID",
+						BlockHeight: 5,
+					},
+					Value: []byte("bar"),
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetLinearizableData(dbName, "foo"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFooLinearizable))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("LinearizableRead", mock.Anything).Return(nil).Once()
+				db.On("GetData", dbName, submittingUserName, "foo").Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "linearizable read timeout",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetLinearizableData(dbName, "foo"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFooLinearizable))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("LinearizableRead", mock.Anything).Return(&interrors.TimeoutErr{ErrMsg: "timeout has occurred while waiting for the local commit to reach the read index"})
+				return db
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        "error while processing 'GET /data/test_database/foo?consistency=linearizable' because timeout has occurred while waiting for the local commit to reach the read index",
+		},
+		{
+			name: "linearizable signature does not match a non-linearizable request",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetLinearizableData(dbName, "foo"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name: "unsupported consistency",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetData(dbName, "foo")+"?consistency=eventual", nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "unsupported consistency \"eventual\", only \"linearizable\" is supported",
+		},
 		{
 			name: "submitting user is not eligible to update the key",
 			requestFactory: func() (*http.Request, error) {
@@ -255,6 +350,15 @@ func TestDataRequestHandler_DataRangeQuery(t *testing.T) {
 		Limit:    10,
 	})
 
+	sigFooLinearizable := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataRangeQuery{
+		UserId:       submittingUserName,
+		DbName:       dbName,
+		StartKey:     "key1",
+		EndKey:       "key10",
+		Limit:        10,
+		Linearizable: true,
+	})
+
 	sigFooNoLimits := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataRangeQuery{
 		UserId:   submittingUserName,
 		DbName:   dbName,
@@ -329,6 +433,42 @@ func TestDataRequestHandler_DataRangeQuery(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "valid linearizable get data range",
+			expectedResponse: &types.GetDataRangeResponseEnvelope{
+				Response: &types.GetDataRangeResponse{
+					Header: &types.ResponseHeader{
+						NodeId:      "testNodeID",
+						BlockHeight: 5,
+					},
+					KVs: []*types.KVWithMetadata{
+						{
+							Key:   "key2",
+							Value: []byte("value2"),
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetLinearizableDataRange(dbName, "key1", "key10", 10), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFooLinearizable))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataRangeResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("LinearizableRead", mock.Anything).Return(nil).Once()
+				db.On("GetDataRange", dbName, submittingUserName, "key1", "key10", uint64(10)).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 		{
 			name: "valid get data range with a limit and empty start key",
 			expectedResponse: &types.GetDataRangeResponseEnvelope{
@@ -1337,6 +1477,261 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 	}
 }
 
+func TestDataRequestHandler_ProcedureTransaction(t *testing.T) {
+	alice := "alice"
+	bob := "bob"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice", "bob"})
+	aliceCert, aliceSigner := testutils.LoadTestCrypto(t, cryptoDir, "alice")
+	_, bobSigner := testutils.LoadTestCrypto(t, cryptoDir, "bob")
+
+	procedureTx := &types.ProcedureTx{
+		MustSignUserIds: []string{alice},
+		TxId:            "1",
+		DbName:          "testDB",
+		ProcedureName:   "transfer",
+		Args:            []string{"alice", "bob", "10"},
+	}
+	aliceSig := testutils.SignatureFromTx(t, aliceSigner, procedureTx)
+	bobSig := testutils.SignatureFromTx(t, bobSigner, procedureTx)
+
+	unknownProcedureTx := &types.ProcedureTx{
+		MustSignUserIds: []string{alice},
+		TxId:            "2",
+		DbName:          "testDB",
+		ProcedureName:   "unknown",
+	}
+	unknownProcedureSig := testutils.SignatureFromTx(t, aliceSigner, unknownProcedureTx)
+	unknownProcedureRespEnv := &types.TxReceiptResponseEnvelope{
+		Response: &types.TxReceiptResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "node1",
+			},
+			Receipt: &types.TxReceipt{
+				Header: &types.BlockHeader{
+					BaseHeader: &types.BlockHeaderBase{
+						Number: 1,
+					},
+					ValidationInfo: []*types.ValidationInfo{
+						{
+							Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+							ReasonIfInvalid: "the stored procedure [unknown] is not registered on the database [testDB]",
+						},
+					},
+				},
+				TxIndex: 0,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		txEnvFactory            func() *types.ProcedureTxEnvelope
+		body                    []byte // overrides the marshaled transaction envelope if set
+		txRespEnv               *types.TxReceiptResponseEnvelope
+		createMockAndInstrument func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB
+		timeoutStr              string
+		expectedCode            int
+		expectedErr             string
+	}{
+		{
+			name: "submit valid procedure transaction",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return &types.ProcedureTxEnvelope{
+					Payload: procedureTx,
+					Signatures: map[string][]byte{
+						alice: aliceSig,
+					},
+				}
+			},
+			txRespEnv: correctTxRespEnv,
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						tx := args[0].(*types.ProcedureTxEnvelope)
+						require.Equal(t, txEnv, tx)
+						require.Equal(t, timeout, args[1].(time.Duration))
+					}).
+					Return(txRespEnv, nil)
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "unknown procedure",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return &types.ProcedureTxEnvelope{
+					Payload: unknownProcedureTx,
+					Signatures: map[string][]byte{
+						alice: unknownProcedureSig,
+					},
+				}
+			},
+			txRespEnv: unknownProcedureRespEnv,
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						tx := args[0].(*types.ProcedureTxEnvelope)
+						require.Equal(t, txEnv, tx)
+					}).
+					Return(txRespEnv, nil)
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bad signature",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return &types.ProcedureTxEnvelope{
+					Payload: procedureTx,
+					Signatures: map[string][]byte{
+						alice: bobSig,
+					},
+				}
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name: "missing signature",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				tx := &types.ProcedureTx{}
+				*tx = *procedureTx
+				tx.MustSignUserIds = []string{alice, bob}
+				return &types.ProcedureTxEnvelope{
+					Payload: tx,
+					Signatures: map[string][]byte{
+						alice: aliceSig,
+					},
+				}
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "users [bob] in the must sign list have not signed the transaction",
+		},
+		{
+			name: "missing procedure name",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				tx := &types.ProcedureTx{}
+				*tx = *procedureTx
+				tx.ProcedureName = ""
+				return &types.ProcedureTxEnvelope{
+					Payload: tx,
+					Signatures: map[string][]byte{
+						alice: aliceSig,
+					},
+				}
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing database name or procedure name in transaction envelope payload",
+		},
+		{
+			name: "missing payload",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return &types.ProcedureTxEnvelope{
+					Signatures: map[string][]byte{
+						alice: aliceSig,
+					},
+				}
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing transaction envelope payload (*types.ProcedureTx)",
+		},
+		{
+			name: "malformed body",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return nil
+			},
+			body: []byte(`{"payload":{"db_name":"testDB","procedure_name":"transfer"`),
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "unexpected EOF",
+		},
+		{
+			name: "unknown field in body",
+			txEnvFactory: func() *types.ProcedureTxEnvelope {
+				return nil
+			},
+			body: []byte(`{"payload":{"db_name":"testDB","procedure_name":"transfer","code":"delete all"}}`),
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "json: unknown field \"code\"",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			txEnv := tt.txEnvFactory()
+			txBytes := tt.body
+			if txBytes == nil {
+				txBytes, err = json.Marshal(txEnv)
+				require.NoError(t, err)
+			}
+
+			reqUrl := &url.URL{
+				Scheme: "http",
+				Host:   "server1.example.com:6091",
+				Path:   constants.PostDataProcedureTx,
+			}
+			req, err := http.NewRequest(http.MethodPost, reqUrl.String(), bytes.NewReader(txBytes))
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			var timeout time.Duration
+			if len(tt.timeoutStr) != 0 {
+				req.Header.Set(constants.TimeoutHeader, tt.timeoutStr)
+				timeout, err = time.ParseDuration(tt.timeoutStr)
+				require.NoError(t, err)
+			}
+
+			rr := httptest.NewRecorder()
+			db := tt.createMockAndInstrument(t, txEnv, tt.txRespEnv, timeout)
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				resp := &types.TxReceiptResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(resp)
+				require.NoError(t, err)
+				require.Equal(t, tt.txRespEnv, resp)
+			} else {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+			db.(*mocks.DB).AssertExpectations(t)
+		})
+	}
+}
+
 func TestDataRequestHandler_DataJSONQueryWithContext(t *testing.T) {
 	dbName := "test_database"
 
@@ -142,5 +142,10 @@ func (d *dbRequestHandler) dbTransaction(response http.ResponseWriter, request *
 		return
 	}
 
+	if err, code := VerifyApprovalSignatures(d.sigVerifier, txEnv.Signatures, txEnv.Payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
 	d.txHandler.handleTransaction(response, request, txEnv, timeout)
 }
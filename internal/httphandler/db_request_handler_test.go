@@ -622,6 +622,28 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			expectedCode: http.StatusUnauthorized,
 			expectedErr:  "signature verification failed",
 		},
+		{
+			name: "bad approval signature",
+			txEnvFactory: func() *types.DBAdministrationTxEnvelope {
+				return &types.DBAdministrationTxEnvelope{
+					Payload:    dbTx,
+					Signature:  aliceSig,
+					Signatures: map[string][]byte{"bob": []byte("bad-sig")},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return nil
+			},
+			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("GetCertificate", "bob").Return(aliceCert, nil)
+
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed for the approving user [bob]",
+		},
 		{
 			name: "fail to submit transaction",
 			txEnvFactory: func() *types.DBAdministrationTxEnvelope {
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// debugRequestHandler handles the admin-only requests that are meant for testing
+type debugRequestHandler struct {
+	db          bcdb.DB
+	sigVerifier *cryptoservice.SignatureVerifier
+	router      *mux.Router
+	logger      *logger.SugarLogger
+}
+
+// NewDebugRequestHandler return debug requests handler
+func NewDebugRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler {
+	handler := &debugRequestHandler{
+		db:          db,
+		sigVerifier: cryptoservice.NewVerifier(db, logger),
+		router:      mux.NewRouter(),
+		logger:      logger,
+	}
+
+	handler.router.HandleFunc(constants.PostNetworkFaults, handler.setNetworkFaults).Methods(http.MethodPost)
+
+	return handler
+}
+
+func (d *debugRequestHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	d.router.ServeHTTP(response, request)
+}
+
+func (d *debugRequestHandler) setNetworkFaults(response http.ResponseWriter, request *http.Request) {
+	decoder := json.NewDecoder(request.Body)
+	decoder.DisallowUnknownFields()
+
+	requestEnv := &types.SetNetworkFaultsRequestEnvelope{}
+	if err := decoder.Decode(requestEnv); err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	if requestEnv.Payload == nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if requestEnv.Payload.UserId == "" {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if len(requestEnv.Signature) == 0 {
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Signature in request envelope payload (%T)", requestEnv.Payload)})
+		return
+	}
+
+	if err, code := VerifyRequestSignature(d.sigVerifier, requestEnv.Payload.UserId, requestEnv.Signature, requestEnv.Payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	faultsResponseEnvelope, err := d.db.SetNetworkFaults(requestEnv.Payload.UserId, requestEnv.Payload.Faults, requestEnv.Payload.Seed)
+	if err != nil {
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			utils.SendHTTPResponse(response, http.StatusForbidden, &types.HttpResponseErr{ErrMsg: err.Error()})
+		case *ierrors.BadRequestError:
+			utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		default:
+			utils.SendHTTPResponse(
+				response,
+				http.StatusInternalServerError,
+				&types.HttpResponseErr{ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error()},
+			)
+		}
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, faultsResponseEnvelope)
+}
@@ -0,0 +1,179 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugRequestHandler_SetNetworkFaults(t *testing.T) {
+	submittingUserName := "admin"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin"})
+	adminCert, adminSigner := testutils.LoadTestCrypto(t, cryptoDir, "admin")
+
+	faults := []*types.NetworkFault{
+		{FromNodeId: "node1", ToNodeId: "node2", Partition: true},
+		{ToNodeId: "node3", DropRate: 0.5, DelayMs: 100},
+	}
+	faultsRequest := &types.SetNetworkFaultsRequest{
+		UserId: submittingUserName,
+		Faults: faults,
+		Seed:   7,
+	}
+	sigAdmin := testutils.SignatureFromQuery(t, adminSigner, faultsRequest)
+
+	faultsResponse := &types.SetNetworkFaultsResponseEnvelope{
+		Response: &types.SetNetworkFaultsResponse{
+			Header: &types.ResponseHeader{NodeId: "node1"},
+			Faults: faults,
+		},
+		Signature: []byte("signature"),
+	}
+
+	testCases := []struct {
+		name                    string
+		requestEnv              *types.SetNetworkFaultsRequestEnvelope
+		createMockAndInstrument func(t *testing.T) bcdb.DB
+		expectedCode            int
+		expectedErr             string
+	}{
+		{
+			name:       "valid request",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("SetNetworkFaults", submittingUserName, mock.Anything, int64(7)).Return(faultsResponse, nil)
+				return db
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:       "missing payload",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing request envelope payload (*types.SetNetworkFaultsRequest)",
+		},
+		{
+			name:       "missing user",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: &types.SetNetworkFaultsRequest{Faults: faults}, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing UserID in request envelope payload (*types.SetNetworkFaultsRequest)",
+		},
+		{
+			name:       "missing signature",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing Signature in request envelope payload (*types.SetNetworkFaultsRequest)",
+		},
+		{
+			name:       "bad signature",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest, Signature: []byte("bad-sig")},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name:       "not an admin",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("SetNetworkFaults", submittingUserName, mock.Anything, int64(7)).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "the user [admin] has no permission to set network faults"})
+				return db
+			},
+			expectedCode: http.StatusForbidden,
+			expectedErr:  "the user [admin] has no permission to set network faults",
+		},
+		{
+			name:       "fault injection not enabled",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("SetNetworkFaults", submittingUserName, mock.Anything, int64(7)).
+					Return(nil, &interrors.BadRequestError{ErrMsg: "network fault injection is not enabled on this node"})
+				return db
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "network fault injection is not enabled on this node",
+		},
+		{
+			name:       "internal error",
+			requestEnv: &types.SetNetworkFaultsRequestEnvelope{Payload: faultsRequest, Signature: sigAdmin},
+			createMockAndInstrument: func(t *testing.T) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("SetNetworkFaults", submittingUserName, mock.Anything, int64(7)).Return(nil, errors.New("oops"))
+				return db
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  "error while processing 'POST http://server1.example.com:6091/debug/network/faults' because oops",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBytes, err := json.Marshal(tt.requestEnv)
+			require.NoError(t, err)
+
+			reqUrl := &url.URL{
+				Scheme: "http",
+				Host:   "server1.example.com:6091",
+				Path:   constants.PostNetworkFaults,
+			}
+			req, err := http.NewRequest(http.MethodPost, reqUrl.String(), bytes.NewReader(reqBytes))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler := NewDebugRequestHandler(tt.createMockAndInstrument(t), logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				resp := &types.SetNetworkFaultsResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(resp)
+				require.NoError(t, err)
+				require.Equal(t, faultsResponse, resp)
+			} else {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+		})
+	}
+}
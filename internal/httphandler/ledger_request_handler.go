@@ -41,21 +41,37 @@ func NewLedgerRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handle
 	handler.router.HandleFunc(constants.GetLastBlockHeader, handler.lastBlockQuery).Methods(http.MethodGet)
 	// HTTP GET "/ledger/path?start={startId}&end={endId}" gets shortest path between blocks
 	handler.router.HandleFunc(constants.GetPath, handler.pathQuery).Methods(http.MethodGet).Queries("start", "{startId:[0-9]+}", "end", "{endId:[0-9]+}")
+	// HTTP GET "/ledger/proof/consistency?start={startId}&end={endId}" gets proof that the ledger at block endId extends the ledger at block startId
+	handler.router.HandleFunc(constants.GetLedgerConsistencyProof, handler.consistencyProof).Methods(http.MethodGet).Queries("start", "{startId:[0-9]+}", "end", "{endId:[0-9]+}")
 	// HTTP GET "/ledger/proof/tx/{blockId}?idx={idx}" gets proof for tx with index idx inside block blockId
 	handler.router.HandleFunc(constants.GetTxProof, handler.txProof).Methods(http.MethodGet).Queries("idx", "{idx:[0-9]+}")
+	// HTTP GET "/ledger/proof/txs/{blockId}?idx={idx1}&idx={idx2}" gets proof for txs with indexes idx1, idx2 inside block blockId
+	handler.router.HandleFunc(constants.GetTxProofs, handler.txProofs).Methods(http.MethodGet).Queries("idx", "{idx:[0-9]+}")
+	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}?deleted={true|false}&absent={true|false}" is rejected when both are true
+	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}", "deleted", "{deleted:true|false}", "absent", "{absent:true|false}")
+	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}?absent={true|false}" gets proof that (dbname, key) was never written in block blockId,
+	// when absent is true
+	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}", "absent", "{absent:true|false}")
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}?deleted={true|false}" gets proof for value associated with (dbname, key) in block blockId,
 	// deleted indicates if value existed in the past and was deleted
 	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}", "deleted", "{deleted:true|false}")
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}" gets proof for value associated with (dbname, key) in block blockId
 	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}")
+	// HTTP GET "/ledger/proof/data/{dbname}?block={blockId}&key={key1}&key={key2}" gets proof for the state of the keys of dbname in block blockId,
+	// whether the keys are written, deleted or were never written
+	handler.router.HandleFunc(constants.GetDataProofs, handler.dataProofs).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}", "key", "{key}")
 	// HTTP GET "/ledger/tx/receipt/{txId}" gets transaction receipt
 	handler.router.HandleFunc(constants.GetTxReceipt, handler.txReceipt).Methods(http.MethodGet)
 	// HTTP GET "/ledger/path?start={startId}&end={endId}" with invalid query params
 	handler.router.HandleFunc(constants.GetPath, handler.invalidPathQuery).Methods(http.MethodGet)
+	// HTTP GET "/ledger/proof/consistency?start={startId}&end={endId}" with invalid query params
+	handler.router.HandleFunc(constants.GetLedgerConsistencyProof, handler.invalidPathQuery).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/tx/{blockId}?idx={idx}" with invalid query params
 	handler.router.HandleFunc(constants.GetTxProofPrefix, handler.invalidTxProof).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/tx/{blockId}?idx={idx}" with invalid query params
 	handler.router.HandleFunc(constants.GetTxProof, handler.invalidTxProof).Methods(http.MethodGet)
+	// HTTP GET "/ledger/proof/txs/{blockId}?idx={idx1}&idx={idx2}" with invalid query params
+	handler.router.HandleFunc(constants.GetTxProofs, handler.invalidTxProof).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}" with invalid query params
 	handler.router.HandleFunc(constants.GetDataProofPrefix, handler.invalidDataProof).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}" with invalid query params
@@ -179,6 +195,40 @@ func (p *ledgerRequestHandler) pathQuery(response http.ResponseWriter, request *
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+func (p *ledgerRequestHandler) consistencyProof(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetLedgerConsistencyProof, p.sigVerifier)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetLedgerConsistencyProofQuery)
+
+	data, err := p.db.GetLedgerConsistencyProof(query.UserId, query.StartBlockNumber, query.EndBlockNumber)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.NotFoundErr:
+			status = http.StatusNotFound
+		case *errors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
 func (p *ledgerRequestHandler) txProof(response http.ResponseWriter, request *http.Request) {
 	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxProof, p.sigVerifier)
 	if respondedErr {
@@ -211,13 +261,51 @@ func (p *ledgerRequestHandler) txProof(response http.ResponseWriter, request *ht
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+func (p *ledgerRequestHandler) txProofs(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxProofs, p.sigVerifier)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetTxProofsQuery)
+
+	data, err := p.db.GetTxProofs(query.UserId, query.BlockNumber, query.TxIndexes)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.NotFoundErr:
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
 func (p *ledgerRequestHandler) dataProof(response http.ResponseWriter, request *http.Request) {
 	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataProof, p.sigVerifier)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataProofQuery)
-	data, err := p.db.GetDataProof(query.UserId, query.BlockNumber, query.DbName, query.Key, query.IsDeleted)
+	var data *types.GetDataProofResponseEnvelope
+	var err error
+	if query.IsAbsent {
+		data, err = p.db.GetDataAbsenceProof(query.UserId, query.BlockNumber, query.DbName, query.Key)
+	} else {
+		data, err = p.db.GetDataProof(query.UserId, query.BlockNumber, query.DbName, query.Key, query.IsDeleted)
+	}
 	if err != nil {
 		var status int
 
@@ -242,6 +330,39 @@ func (p *ledgerRequestHandler) dataProof(response http.ResponseWriter, request *
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+func (p *ledgerRequestHandler) dataProofs(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataProofs, p.sigVerifier)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDataProofsQuery)
+	data, err := p.db.GetDataProofs(query.UserId, query.BlockNumber, query.DbName, query.Keys)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.BadRequestError:
+			status = http.StatusBadRequest
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.NotFoundErr:
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
 func (p *ledgerRequestHandler) txReceipt(response http.ResponseWriter, request *http.Request) {
 	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxReceipt, p.sigVerifier)
 	if respondedErr {
@@ -514,6 +514,167 @@ func TestPathQuery(t *testing.T) {
 	}
 }
 
+func TestConsistencyProofQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.GetLedgerConsistencyProofResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetLedgerConsistencyProofResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get consistency proof request",
+			expectedResponse: &types.GetLedgerConsistencyProofResponseEnvelope{
+				Response: &types.GetLedgerConsistencyProofResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					BlockHeaders: []*types.BlockHeader{
+						{
+							BaseHeader: &types.BlockHeaderBase{
+								Number: 9,
+							},
+						},
+						{
+							BaseHeader: &types.BlockHeaderBase{
+								Number: 5,
+							},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForLedgerConsistencyProof(3, 17), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetLedgerConsistencyProofQuery{
+					UserId:           submittingUserName,
+					StartBlockNumber: 3,
+					EndBlockNumber:   17,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetLedgerConsistencyProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetLedgerConsistencyProof", submittingUserName, uint64(3), uint64(17)).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:             "end block not found",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForLedgerConsistencyProof(1, 10), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetLedgerConsistencyProofQuery{
+					UserId:           submittingUserName,
+					StartBlockNumber: 1,
+					EndBlockNumber:   10,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetLedgerConsistencyProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetLedgerConsistencyProof", submittingUserName, uint64(1), uint64(10)).Return(response, &interrors.NotFoundErr{Message: "can't find path in blocks skip list between 10 1: block not found: 10"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /ledger/proof/consistency?start=1&end=10' because can't find path in blocks skip list between 10 1: block not found: 10",
+		},
+		{
+			name:             "wrong url, endId not exist",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.LedgerEndpoint+fmt.Sprintf("proof/consistency?start=%s", "1"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetLedgerConsistencyProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "query error - bad or missing start/end block number",
+		},
+		{
+			name:             "endId < startId",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForLedgerConsistencyProof(10, 1), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetLedgerConsistencyProofQuery{
+					UserId:           submittingUserName,
+					StartBlockNumber: 10,
+					EndBlockNumber:   1,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetLedgerConsistencyProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "query error: startId=10 > endId=1",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetLedgerConsistencyProofResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestTxProofQuery(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
@@ -719,6 +880,151 @@ func TestTxProofQuery(t *testing.T) {
 	}
 }
 
+func TestTxProofsQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.GetTxProofsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetTxProofsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get proofs request",
+			expectedResponse: &types.GetTxProofsResponseEnvelope{
+				Response: &types.GetTxProofsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Hashes: [][]byte{[]byte("hash1"), []byte("hash2")},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLTxProofs(2, []uint64{1, 12}), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetTxProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					TxIndexes:   []uint64{1, 12},
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetTxProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxProofs", submittingUserName, uint64(2), []uint64{1, 12}).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:             "no tx exist",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLTxProofs(2, []uint64{1, 2}), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetTxProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					TxIndexes:   []uint64{1, 2},
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetTxProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxProofs", submittingUserName, uint64(2), []uint64{1, 2}).Return(response, &interrors.NotFoundErr{Message: "block not found: 2"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /ledger/proof/txs/2?idx=1&idx=2' because block not found: 2",
+		},
+		{
+			name:             "wrong url, one of idx params is not a number",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLTxProofs(2, []uint64{1})+"&idx=a", nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetTxProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "query error - bad or missing literal: idx strconv.ParseUint: parsing \"a\": invalid syntax",
+		},
+		{
+			name:             "wrong url, idx param doesn't exist",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, path.Join(constants.LedgerEndpoint, "proof", "txs", "2"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetTxProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "tx proof query error - bad or missing query parameter",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetTxProofsResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestDataProofQuery(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
@@ -822,6 +1128,114 @@ func TestDataProofQuery(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "valid get absence proof request",
+			expectedResponse: &types.GetDataProofResponseEnvelope{
+				Response: &types.GetDataProofResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					AbsenceProof: &types.MPTrieAbsenceProof{
+						Path: []*types.MPTrieProofElement{
+							{
+								Hashes: [][]byte{[]byte("hash1"), []byte("hash2")},
+							},
+						},
+						Witnesses: []*types.MPTrieWitness{
+							{
+								Path: []*types.MPTrieProofElement{
+									{
+										Hashes: [][]byte{[]byte("hash3"), []byte("hash4")},
+									},
+								},
+								ValueHash: []byte("hash5"),
+							},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataAbsenceProof(2, "bdb", "key1"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Key:         "key1",
+					IsAbsent:    true,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDataAbsenceProof", submittingUserName, uint64(2), "bdb", "key1").Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:             "absence proof of a written key",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataAbsenceProof(2, "bdb", "key1"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Key:         "key1",
+					IsAbsent:    true,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDataAbsenceProof", submittingUserName, uint64(2), "bdb", "key1").
+					Return(nil, &interrors.NotFoundErr{Message: "no absence proof for block 2, db bdb, key key1 found, the key was written"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /ledger/proof/data/bdb/key1?block=2&absent=true' because no absence proof for block 2, db bdb, key key1 found, the key was written",
+		},
+		{
+			name:             "deleted and absent",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProof(2, "bdb", "key1", true)+"&absent=true", nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Key:         "key1",
+					IsDeleted:   true,
+					IsAbsent:    true,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the deleted and absent parameters cannot be both set to 'true'",
+		},
 		{
 			name:             "user doesn't exist",
 			expectedResponse: nil,
@@ -1018,6 +1432,200 @@ func TestDataProofQuery(t *testing.T) {
 	}
 }
 
+func TestDataProofsQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestCrypto(t, cryptoDir, "alice")
+
+	keys := []string{"key1", "key 2&"}
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.GetDataProofsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetDataProofsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get proofs request",
+			expectedResponse: &types.GetDataProofsResponseEnvelope{
+				Response: &types.GetDataProofsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Proof: &types.MPTrieMultiProof{
+						Nodes: []*types.MPTrieProofElement{
+							{
+								Hashes: [][]byte{[]byte("hash1"), []byte("hash2")},
+							},
+							{
+								Hashes: [][]byte{[]byte("hash3")},
+							},
+						},
+						ValueHashes: [][]byte{[]byte("hash4")},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProofs(2, "bdb", keys), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Keys:        keys,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDataProofs", submittingUserName, uint64(2), "bdb", keys).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:             "block not found",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProofs(515, "bdb", keys), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 515,
+					DbName:      "bdb",
+					Keys:        keys,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDataProofs", submittingUserName, uint64(515), "bdb", keys).Return(nil, &interrors.NotFoundErr{Message: "block not found: 515"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET " + constants.URLDataProofs(515, "bdb", keys) + "' because block not found: 515",
+		},
+		{
+			name:             "too many keys",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProofs(2, "bdb", keys), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Keys:        keys,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDataProofs", submittingUserName, uint64(2), "bdb", keys).Return(nil, &interrors.BadRequestError{
+					ErrMsg: "the query has [2] keys but the proof of the state of at most [1] keys can be requested at once",
+				})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'GET " + constants.URLDataProofs(2, "bdb", keys) + "' because the query has [2] keys but the proof of the state of at most [1] keys can be requested at once",
+		},
+		{
+			name:             "invalid signature",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProofs(2, "bdb", keys), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataProofsQuery{
+					UserId:      submittingUserName,
+					BlockNumber: 2,
+					DbName:      "bdb",
+					Keys:        keys[:1],
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name:             "key param missing",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLDataProofs(2, "bdb", nil), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDataProofsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "data proof query error - bad or missing query parameter",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetDataProofsResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestTxReceiptQuery(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
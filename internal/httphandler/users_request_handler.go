@@ -124,5 +124,10 @@ func (u *usersRequestHandler) userTransaction(response http.ResponseWriter, requ
 		return
 	}
 
+	if err, code := VerifyApprovalSignatures(u.sigVerifier, txEnv.Signatures, txEnv.Payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
 	u.txHandler.handleTransaction(response, request, txEnv, timeout)
 }
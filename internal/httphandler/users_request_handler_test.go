@@ -223,8 +223,11 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 	userGet := "userGet"
 	userWrite := "userWrite"
 
-	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice"})
+	approverID := "approverID"
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"alice", "bob"})
 	aliceCert, aliceSigner := testutils.LoadTestCrypto(t, cryptoDir, "alice")
+	bobCert, bobSigner := testutils.LoadTestCrypto(t, cryptoDir, "bob")
 
 	userTx := &types.UserAdministrationTx{
 		TxId:        "1",
@@ -247,6 +250,29 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 		},
 	}
 	aliceSig := testutils.SignatureFromTx(t, aliceSigner, userTx)
+	bobSig := testutils.SignatureFromTx(t, bobSigner, userTx)
+
+	unapprovedTxRespEnv := &types.TxReceiptResponseEnvelope{
+		Response: &types.TxReceiptResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "node1",
+			},
+			Receipt: &types.TxReceipt{
+				Header: &types.BlockHeader{
+					BaseHeader: &types.BlockHeaderBase{
+						Number: 1,
+					},
+					ValidationInfo: []*types.ValidationInfo{
+						{
+							Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+							ReasonIfInvalid: "the governance policy requires [2] admins to approve user administrative operations but only [1] admins have signed the transaction",
+						},
+					},
+				},
+				TxIndex: 0,
+			},
+		},
+	}
 
 	testCases := []struct {
 		name                    string
@@ -282,6 +308,78 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			timeoutStr:   "1s",
 			expectedCode: http.StatusOK,
 		},
+		{
+			name: "submit userAdmin transaction approved by another admin",
+			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
+				return &types.UserAdministrationTxEnvelope{
+					Payload:    userTx,
+					Signature:  aliceSig,
+					Signatures: map[string][]byte{approverID: bobSig},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return correctTxRespEnv
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("GetCertificate", approverID).Return(bobCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					tx, ok := args[0].(*types.UserAdministrationTxEnvelope)
+					require.True(t, ok)
+					require.Equal(t, txEnv, tx)
+					require.Equal(t, timeout, args[1].(time.Duration))
+				}).Return(txRespEnv, nil)
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "submit userAdmin transaction without the approvals required by the governance policy",
+			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
+				return &types.UserAdministrationTxEnvelope{
+					Payload:   userTx,
+					Signature: aliceSig,
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return unapprovedTxRespEnv
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					tx, ok := args[0].(*types.UserAdministrationTxEnvelope)
+					require.True(t, ok)
+					require.Equal(t, txEnv, tx)
+				}).Return(txRespEnv, nil)
+				return db
+			},
+			timeoutStr:   "1s",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bad approval signature",
+			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
+				return &types.UserAdministrationTxEnvelope{
+					Payload:    userTx,
+					Signature:  aliceSig,
+					Signatures: map[string][]byte{approverID: aliceSig},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return nil
+			},
+			createMockAndInstrument: func(t *testing.T, txEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("GetCertificate", approverID).Return(bobCert, nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed for the approving user [approverID]",
+		},
 		{
 			name: "transaction timeout",
 			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
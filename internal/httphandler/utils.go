@@ -9,6 +9,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -31,10 +32,17 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 
 	switch queryType {
 	case constants.GetData:
+		linearizable, err := isLinearizable(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
 		payload = &types.GetDataQuery{
-			UserId: querierUserID,
-			DbName: params["dbname"],
-			Key:    params["key"],
+			UserId:       querierUserID,
+			DbName:       params["dbname"],
+			Key:          params["key"],
+			Linearizable: linearizable,
 		}
 	case constants.GetDataRange:
 		limit, err := strconv.ParseUint(params["limit"], 10, 64)
@@ -43,12 +51,19 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			return nil, true
 		}
 
+		linearizable, err := isLinearizable(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
 		payload = &types.GetDataRangeQuery{
-			UserId:   querierUserID,
-			DbName:   params["dbname"],
-			StartKey: params["startkey"][1 : len(params["startkey"])-1],
-			EndKey:   params["endkey"][1 : len(params["endkey"])-1],
-			Limit:    limit,
+			UserId:       querierUserID,
+			DbName:       params["dbname"],
+			StartKey:     params["startkey"][1 : len(params["startkey"])-1],
+			EndKey:       params["endkey"][1 : len(params["endkey"])-1],
+			Limit:        limit,
+			Linearizable: linearizable,
 		}
 	case constants.GetUser:
 		payload = &types.GetUserQuery{
@@ -130,6 +145,18 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			StartBlockNumber: startBlockNum,
 			EndBlockNumber:   endBlockNum,
 		}
+	case constants.GetLedgerConsistencyProof:
+		startBlockNum, endBlockNum, err := utils.GetStartAndEndBlockNum(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+
+		payload = &types.GetLedgerConsistencyProofQuery{
+			UserId:           querierUserID,
+			StartBlockNumber: startBlockNum,
+			EndBlockNumber:   endBlockNum,
+		}
 	case constants.GetTxProof:
 		blockNum, txIndex, err := utils.GetBlockNumAndTxIndex(params)
 		if err != nil {
@@ -142,6 +169,30 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			BlockNumber: blockNum,
 			TxIndex:     txIndex,
 		}
+	case constants.GetTxProofs:
+		blockNum, err := utils.GetBlockNum(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+
+		txIndexes := make([]uint64, 0)
+		for _, idx := range r.URL.Query()["idx"] {
+			txIndex, err := strconv.ParseUint(idx, 10, 64)
+			if err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{
+					ErrMsg: "query error - bad or missing literal: idx " + err.Error(),
+				})
+				return nil, true
+			}
+			txIndexes = append(txIndexes, txIndex)
+		}
+
+		payload = &types.GetTxProofsQuery{
+			UserId:      querierUserID,
+			BlockNumber: blockNum,
+			TxIndexes:   txIndexes,
+		}
 	case constants.GetDataProof:
 		blockNum, err := utils.GetBlockNum(params)
 		if err != nil {
@@ -158,12 +209,41 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			}
 		}
 
+		absent := false
+		if _, ok := params["absent"]; ok {
+			absent, err = strconv.ParseBool(params["absent"])
+			if err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+				return nil, true
+			}
+		}
+		if deleted && absent {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{
+				ErrMsg: "the deleted and absent parameters cannot be both set to 'true'",
+			})
+			return nil, true
+		}
+
 		payload = &types.GetDataProofQuery{
 			UserId:      querierUserID,
 			BlockNumber: blockNum,
 			DbName:      params["dbname"],
 			Key:         params["key"],
 			IsDeleted:   deleted,
+			IsAbsent:    absent,
+		}
+	case constants.GetDataProofs:
+		blockNum, err := utils.GetBlockNum(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+
+		payload = &types.GetDataProofsQuery{
+			UserId:      querierUserID,
+			BlockNumber: blockNum,
+			DbName:      params["dbname"],
+			Keys:        r.URL.Query()["key"],
 		}
 	case constants.GetTxReceipt:
 		payload = &types.GetTxReceiptQuery{
@@ -300,6 +380,31 @@ func VerifyRequestSignature(
 	return nil, http.StatusOK
 }
 
+// VerifyApprovalSignatures verifies the signatures of the admins approving an administrative transaction
+// in addition to its submitter. Whether the signers are admins is checked when the transaction is validated.
+func VerifyApprovalSignatures(
+	sigVerifier *cryptoservice.SignatureVerifier,
+	signatures map[string][]byte,
+	requestPayload interface{},
+) (error, int) {
+	var userIDs []string
+	for userID := range signatures {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		if err, code := VerifyRequestSignature(sigVerifier, userID, signatures[userID], requestPayload); err != nil {
+			if code == http.StatusUnauthorized {
+				return &types.HttpResponseErr{ErrMsg: "signature verification failed for the approving user [" + userID + "]"}, code
+			}
+			return err, code
+		}
+	}
+
+	return nil, http.StatusOK
+}
+
 func validateAndParseHeader(h *http.Header) (string, []byte, error) {
 	userID := h.Get(constants.UserHeader)
 	if userID == "" {
@@ -318,6 +423,18 @@ func validateAndParseHeader(h *http.Header) (string, []byte, error) {
 	return userID, signatureBytes, nil
 }
 
+// isLinearizable returns true when the `consistency` query parameter requests a linearizable read.
+func isLinearizable(params map[string]string) (bool, error) {
+	consistency, ok := params["consistency"]
+	if !ok {
+		return false, nil
+	}
+	if consistency != constants.LinearizableConsistency {
+		return false, errors.New("unsupported consistency " + strconv.Quote(consistency) + ", only " + strconv.Quote(constants.LinearizableConsistency) + " is supported")
+	}
+	return true, nil
+}
+
 func validateAndParseTxPostHeader(h *http.Header) (time.Duration, error) {
 	timeoutStr := h.Get(constants.TimeoutHeader)
 	if len(timeoutStr) == 0 {
@@ -173,6 +173,21 @@ func (q *Querier) GetNode(nodeID string) (*types.NodeConfig, *types.Metadata, er
 	return node, meta, nil
 }
 
+// GetNodeCertificate returns the current certificate associated with a given nodeID
+func (q *Querier) GetNodeCertificate(nodeID string) (*x509.Certificate, error) {
+	node, _, err := q.GetNode(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(node.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
 // GetNodeVersion returns the current version of a given nodeID
 func (q *Querier) GetNodeVersion(nodeID string) (*types.Version, error) {
 	_, metadata, err := q.GetNode(nodeID)
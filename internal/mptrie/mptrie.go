@@ -36,6 +36,21 @@ type Store interface {
 	// underlying database. Operation can cause to current MPTrie become invalid, so always reload trie
 	// after the call
 	RollbackChanges() error
+	// IsPruned returns true if the trie of the given block was removed by the pruning
+	IsPruned(blockNum uint64) bool
+	// Prune removes the nodes and values which are not reachable from the trie roots of the blocks
+	// retained by the policy. rootOf returns the trie root of a block.
+	Prune(policy *RetentionPolicy, rootOf func(blockNum uint64) ([]byte, error)) error
+}
+
+// RetentionPolicy defines the blocks whose state trie is retained by the pruning, i.e., the blocks
+// for which proofs can be served
+type RetentionPolicy struct {
+	// LastBlocks is the number of most recent blocks whose state trie is retained. It must be positive.
+	LastBlocks uint64
+	// CheckpointInterval, if positive, retains the state trie of every block whose number is a multiple
+	// of it as well.
+	CheckpointInterval uint64
 }
 
 // Merkle-Patricia Trie implementation. No node/value data stored inside trie, but in associated TrieStore
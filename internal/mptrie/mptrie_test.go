@@ -989,6 +989,14 @@ func (s *trieStoreMock) Height() (uint64, error) {
 	return s.lastBlock, nil
 }
 
+func (s *trieStoreMock) IsPruned(blockNum uint64) bool {
+	return false
+}
+
+func (s *trieStoreMock) Prune(policy *RetentionPolicy, rootOf func(blockNum uint64) ([]byte, error)) error {
+	return errors.New("not supported")
+}
+
 func (s *trieStoreMock) storeStatistic() (inMemoryNodes, persistNodes, inMemoryValues, persistValues int) {
 	return len(s.inMemoryNodes), len(s.persistNodes), len(s.inMemoryValues), len(s.persistValues)
 }
@@ -1,8 +1,12 @@
 package mptrie
 
 import (
+	"bytes"
+
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 )
 
 // GetProof calculates proof (path) from node contains value to root node in trie
@@ -34,3 +38,195 @@ func (t *MPTrie) GetProof(key []byte, isDeleted bool) (*state.Proof, error) {
 
 	return state.NewProof(resPath), nil
 }
+
+// GetAbsenceProof calculates proof that the key was never written in trie: the path from
+// root node to node at which the path of the key ends, and the witnesses of the keys below
+// that node. If the key is in trie, even with delete flag, no proof will be calculated.
+// All the keys in trie must have the same length, as the keys of the state trie do.
+func (t *MPTrie) GetAbsenceProof(key []byte) (*state.AbsenceProof, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	hexKey := convertByteToHex(key)
+	path := make([]*types.MPTrieProofElement, 0)
+	var node TrieNode = t.root
+	for {
+		path = append(path, &types.MPTrieProofElement{
+			Hashes: node.bytes(),
+		})
+
+		var childPtr []byte
+		switch n := node.(type) {
+		case *BranchNode:
+			if len(hexKey) == 0 {
+				return nil, errors.New("the key is shorter than the keys in the trie")
+			}
+			childPtr = n.GetChildren()[hexKey[0]]
+			if len(childPtr) == 0 {
+				witnesses := make([]*types.MPTrieWitness, 0)
+				for nibble, childPtr := range n.GetChildren() {
+					if len(childPtr) == 0 {
+						continue
+					}
+					witness, err := t.getWitness(childPtr)
+					if err != nil {
+						return nil, err
+					}
+					witness.Nibble = uint32(nibble)
+					witnesses = append(witnesses, witness)
+				}
+				return state.NewAbsenceProof(path, witnesses), nil
+			}
+			hexKey = hexKey[1:]
+		case *ExtensionNode:
+			if !bytes.HasPrefix(hexKey, n.GetKey()) {
+				witness, err := t.getWitness(n.GetChild())
+				if err != nil {
+					return nil, err
+				}
+				return state.NewAbsenceProof(path, []*types.MPTrieWitness{witness}), nil
+			}
+			hexKey = hexKey[len(n.GetKey()):]
+			childPtr = n.GetChild()
+		case *ValueNode:
+			if bytes.Equal(hexKey, n.GetKey()) {
+				return nil, nil
+			}
+			valueHash, err := t.getValueHash(n.GetValuePtr())
+			if err != nil {
+				return nil, err
+			}
+			return state.NewAbsenceProof(path, []*types.MPTrieWitness{{ValueHash: valueHash}}), nil
+		default:
+			return nil, errors.New("unknown trie node type")
+		}
+
+		var err error
+		if node, err = t.store.GetNode(childPtr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetMultiProof calculates proof of the state of several keys in trie: the nodes of the proofs calculated by
+// GetProof for the keys in trie, with or without delete flag, and by GetAbsenceProof for the keys that were never
+// written, each node once.
+func (t *MPTrie) GetMultiProof(keys [][]byte) (*state.MultiProof, error) {
+	nodes := make([]*types.MPTrieProofElement, 0)
+	valueHashes := make([][]byte, 0)
+	seenNodes := make(map[string]struct{})
+	seenValueHashes := make(map[string]struct{})
+
+	addNodes := func(path []*types.MPTrieProofElement) error {
+		for _, node := range path {
+			nodeHash, err := state.CalcHash(node.GetHashes())
+			if err != nil {
+				return err
+			}
+			if _, ok := seenNodes[string(nodeHash)]; ok {
+				continue
+			}
+			seenNodes[string(nodeHash)] = struct{}{}
+			nodes = append(nodes, node)
+		}
+		return nil
+	}
+	addValueHash := func(valueHash []byte) {
+		if len(valueHash) == 0 {
+			return
+		}
+		if _, ok := seenValueHashes[string(valueHash)]; ok {
+			return
+		}
+		seenValueHashes[string(valueHash)] = struct{}{}
+		valueHashes = append(valueHashes, valueHash)
+	}
+
+	for _, key := range keys {
+		absenceProof, err := t.GetAbsenceProof(key)
+		if err != nil {
+			return nil, err
+		}
+		if absenceProof != nil {
+			if err := addNodes(absenceProof.GetPath()); err != nil {
+				return nil, err
+			}
+			for _, witness := range absenceProof.GetWitnesses() {
+				if err := addNodes(witness.GetPath()); err != nil {
+					return nil, err
+				}
+				addValueHash(witness.GetValueHash())
+			}
+			continue
+		}
+
+		proof, err := t.GetProof(key, false)
+		if err != nil {
+			return nil, err
+		}
+		if proof == nil {
+			if proof, err = t.GetProof(key, true); err != nil {
+				return nil, err
+			}
+		}
+		if proof == nil {
+			return nil, errors.New("impossible state - the key is neither in trie nor absent from it")
+		}
+		if err := addNodes(proof.GetPath()); err != nil {
+			return nil, err
+		}
+	}
+
+	return state.NewMultiProof(nodes, valueHashes), nil
+}
+
+// getWitness returns the path from the node down to the first value node below it,
+// with the hash of its value
+func (t *MPTrie) getWitness(nodePtr []byte) (*types.MPTrieWitness, error) {
+	witness := &types.MPTrieWitness{}
+	for {
+		node, err := t.store.GetNode(nodePtr)
+		if err != nil {
+			return nil, err
+		}
+		witness.Path = append(witness.Path, &types.MPTrieProofElement{
+			Hashes: node.bytes(),
+		})
+
+		switch n := node.(type) {
+		case *BranchNode:
+			nodePtr = nil
+			for _, childPtr := range n.GetChildren() {
+				if len(childPtr) > 0 {
+					nodePtr = childPtr
+					break
+				}
+			}
+			if nodePtr == nil {
+				return nil, errors.New("impossible state - branch node without children below root")
+			}
+		case *ExtensionNode:
+			nodePtr = n.GetChild()
+		case *ValueNode:
+			witness.ValueHash, err = t.getValueHash(n.GetValuePtr())
+			if err != nil {
+				return nil, err
+			}
+			return witness, nil
+		default:
+			return nil, errors.New("unknown trie node type")
+		}
+	}
+}
+
+// getValueHash returns the hash of the value, as part of the value pointer
+func (t *MPTrie) getValueHash(valuePtr []byte) ([]byte, error) {
+	value, err := t.store.GetValue(valuePtr)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	return crypto.ComputeSHA256Hash(value)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 
 	"github.com/stretchr/testify/require"
 )
@@ -174,3 +176,216 @@ func convertKeyToHex(t *testing.T, key []byte) []byte {
 
 	return res
 }
+
+func TestMPTrieGetAbsenceProof(t *testing.T) {
+	// the keys of the state trie all have the same length
+	keysToInsert := [][]byte{
+		convertHexToKey(t, []byte("12345678")),
+		convertHexToKey(t, []byte("12345679")),
+		convertHexToKey(t, []byte("1234abcd")),
+		convertHexToKey(t, []byte("12ff0000")),
+		convertHexToKey(t, []byte("a0000000")),
+	}
+
+	absentKeys := []struct {
+		key               []byte
+		expectedPathLen   int
+		expectedWitnesses int
+	}{
+		// empty child of the root
+		{key: convertHexToKey(t, []byte("b0000000")), expectedPathLen: 1, expectedWitnesses: 2},
+		// value node with another key
+		{key: convertHexToKey(t, []byte("a1000000")), expectedPathLen: 2, expectedWitnesses: 1},
+		// divergent extension node
+		{key: convertHexToKey(t, []byte("13000000")), expectedPathLen: 2, expectedWitnesses: 1},
+		// empty child of the branch node above the last nibble
+		{key: convertHexToKey(t, []byte("12345670")), expectedPathLen: 7, expectedWitnesses: 2},
+		// value node with another key below a branch node
+		{key: convertHexToKey(t, []byte("1234a000")), expectedPathLen: 6, expectedWitnesses: 1},
+	}
+
+	store := newMockStore()
+	trie, err := NewTrie(nil, store)
+	require.NoError(t, err)
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+	proof, err := trie.GetAbsenceProof(keysToInsert[0])
+	require.NoError(t, err)
+	isValid, err := proof.Verify(keysToInsert[0], rootHash)
+	require.NoError(t, err)
+	require.True(t, isValid, "empty trie")
+
+	for i, key := range keysToInsert {
+		require.NoError(t, trie.Update(key, []byte(fmt.Sprintf("value%d", i))))
+	}
+	_, err = trie.Delete(keysToInsert[3])
+	require.NoError(t, err)
+	rootHash, err = trie.Hash()
+	require.NoError(t, err)
+
+	// the keys written, even deleted, are not absent
+	for _, key := range keysToInsert {
+		proof, err := trie.GetAbsenceProof(key)
+		require.NoError(t, err)
+		require.Nil(t, proof)
+	}
+
+	for _, absentKey := range absentKeys {
+		name := fmt.Sprintf("Key is: %s", convertKeyToHex(t, absentKey.key))
+		proof, err := trie.GetAbsenceProof(absentKey.key)
+		require.NoError(t, err)
+		require.NotNil(t, proof)
+		require.Len(t, proof.GetPath(), absentKey.expectedPathLen, name)
+		require.Len(t, proof.GetWitnesses(), absentKey.expectedWitnesses, name)
+
+		isValid, err := state.NewAbsenceProofFromProto(proof.ToProto()).Verify(absentKey.key, rootHash)
+		require.NoError(t, err)
+		require.True(t, isValid, name)
+
+		// the proof of a key is neither valid for another key nor for another root
+		for _, key := range keysToInsert {
+			isValid, _ := proof.Verify(key, rootHash)
+			require.False(t, isValid, name)
+		}
+		isValid, err = proof.Verify(absentKey.key, []byte("root"))
+		require.NoError(t, err)
+		require.False(t, isValid, name)
+	}
+
+	t.Run("children moved to other positions", func(t *testing.T) {
+		// as the hash of a branch node only depends on the sequence of its children, the children of the root can be
+		// moved to other positions without changing the root hash, which must not prove the absence of the key
+		// whose child was moved
+		proof, err := trie.GetAbsenceProof(convertHexToKey(t, []byte("b0000000")))
+		require.NoError(t, err)
+		children := proof.GetPath()[0].GetHashes()
+		require.NotEmpty(t, children[1])
+
+		movedChildren := make([][]byte, len(children))
+		movedChildren[2] = children[1]
+		movedChildren[0xa] = children[0xa]
+		movedPath := []*types.MPTrieProofElement{{Hashes: movedChildren}}
+		movedHash, err := state.CalcHash(movedChildren)
+		require.NoError(t, err)
+		require.Equal(t, rootHash, movedHash)
+
+		var witnesses []*types.MPTrieWitness
+		for _, w := range proof.GetWitnesses() {
+			w = proto.Clone(w).(*types.MPTrieWitness)
+			if w.Nibble == 1 {
+				w.Nibble = 2
+			}
+			witnesses = append(witnesses, w)
+		}
+
+		isValid, err := state.NewAbsenceProof(movedPath, witnesses).Verify(keysToInsert[0], rootHash)
+		require.NoError(t, err)
+		require.False(t, isValid)
+	})
+
+	t.Run("witness missing", func(t *testing.T) {
+		key := convertHexToKey(t, []byte("b0000000"))
+		proof, err := trie.GetAbsenceProof(key)
+		require.NoError(t, err)
+
+		isValid, err := state.NewAbsenceProof(proof.GetPath(), proof.GetWitnesses()[1:]).Verify(key, rootHash)
+		require.EqualError(t, err, "no witness for the child at nibble 1")
+		require.False(t, isValid)
+	})
+}
+
+func TestMPTrieGetMultiProof(t *testing.T) {
+	keysToInsert := [][]byte{
+		convertHexToKey(t, []byte("12345678")),
+		convertHexToKey(t, []byte("12345679")),
+		convertHexToKey(t, []byte("1234abcd")),
+		convertHexToKey(t, []byte("12ff0000")),
+		convertHexToKey(t, []byte("a0000000")),
+	}
+	absentKeys := [][]byte{
+		convertHexToKey(t, []byte("b0000000")),
+		convertHexToKey(t, []byte("a1000000")),
+		convertHexToKey(t, []byte("13000000")),
+		convertHexToKey(t, []byte("12345670")),
+		convertHexToKey(t, []byte("1234a000")),
+	}
+
+	store := newMockStore()
+	trie, err := NewTrie(nil, store)
+	require.NoError(t, err)
+
+	values := make([][]byte, len(keysToInsert))
+	for i, key := range keysToInsert {
+		values[i] = []byte(fmt.Sprintf("value%d", i))
+		require.NoError(t, trie.Update(key, values[i]))
+	}
+	_, err = trie.Delete(keysToInsert[3])
+	require.NoError(t, err)
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	proof, err := trie.GetMultiProof(append(append([][]byte{}, keysToInsert...), absentKeys...))
+	require.NoError(t, err)
+
+	// the nodes shared by the paths of the keys are held once
+	separateNodes := 0
+	for i, key := range keysToInsert {
+		p, err := trie.GetProof(key, i == 3)
+		require.NoError(t, err)
+		separateNodes += len(p.GetPath())
+	}
+	require.Less(t, len(proof.GetNodes()), separateNodes)
+
+	proof = state.NewMultiProofFromProto(proof.ToProto())
+	for i, key := range keysToInsert {
+		name := fmt.Sprintf("Key is: %s", convertKeyToHex(t, key))
+		valueHash, err := state.CalculateKeyValueHash(key, values[i])
+		require.NoError(t, err)
+
+		isValid, err := proof.Verify(key, valueHash, rootHash, i == 3)
+		require.NoError(t, err)
+		require.True(t, isValid, name)
+
+		isValid, err = proof.Verify(key, valueHash, rootHash, i != 3)
+		require.NoError(t, err)
+		require.False(t, isValid, name)
+
+		otherValueHash, err := state.CalculateKeyValueHash(key, []byte("other value"))
+		require.NoError(t, err)
+		isValid, err = proof.Verify(key, otherValueHash, rootHash, i == 3)
+		require.NoError(t, err)
+		require.False(t, isValid, name)
+
+		isValid, err = proof.VerifyAbsence(key, rootHash)
+		require.NoError(t, err)
+		require.False(t, isValid, name)
+	}
+
+	for _, key := range absentKeys {
+		name := fmt.Sprintf("Key is: %s", convertKeyToHex(t, key))
+		isValid, err := proof.VerifyAbsence(key, rootHash)
+		require.NoError(t, err)
+		require.True(t, isValid, name)
+
+		isValid, err = proof.VerifyAbsence(key, []byte("root"))
+		require.NoError(t, err)
+		require.False(t, isValid, name)
+	}
+
+	t.Run("keys not covered", func(t *testing.T) {
+		proof, err := trie.GetMultiProof(keysToInsert[:1])
+		require.NoError(t, err)
+
+		valueHash, err := state.CalculateKeyValueHash(keysToInsert[4], values[4])
+		require.NoError(t, err)
+		isValid, err := proof.Verify(keysToInsert[4], valueHash, rootHash, false)
+		require.NoError(t, err)
+		require.False(t, isValid)
+
+		// the path of the absent key is covered, but not the witnesses of the other children of the root
+		isValid, err = proof.VerifyAbsence(absentKeys[0], rootHash)
+		require.NoError(t, err)
+		require.False(t, isValid)
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package store
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// checkpointBatchSize is the number of pairs written in a single batch by the CheckpointWriter
+const checkpointBatchSize = 1000
+
+// Checkpoint holds a consistent view of the raw key-value pairs of the store, i.e., the persisted trie nodes and
+// values of the blocks retained by the pruning, the last block stored, and the state of the pruning. It is used to
+// transfer the whole trie to another node.
+type Checkpoint struct {
+	snap *leveldb.Snapshot
+}
+
+// Checkpoint returns a checkpoint of the persisted content of the store. The caller must make sure that no block is
+// being committed while the checkpoint is taken. The checkpoint must be released after use.
+func (s *Store) Checkpoint() (*Checkpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap, err := s.trieDataDB.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while taking a snapshot of the trie data database")
+	}
+
+	return &Checkpoint{snap: snap}, nil
+}
+
+// ForEach calls f with each key-value pair of the checkpoint. The slices passed to f must not be retained.
+func (c *Checkpoint) ForEach(f func(key, value []byte) error) error {
+	itr := c.snap.NewIterator(nil, &opt.ReadOptions{})
+	defer itr.Release()
+
+	for itr.Next() {
+		if err := f(itr.Key(), itr.Value()); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(itr.Error(), "error while iterating over the snapshot of the trie data database")
+}
+
+// Release releases the checkpoint
+func (c *Checkpoint) Release() {
+	c.snap.Release()
+}
+
+// CheckpointWriter creates a new store in a given directory from the key-value pairs of a checkpoint
+type CheckpointWriter struct {
+	s     *Store
+	batch *leveldb.Batch
+}
+
+// NewCheckpointWriter creates a new store in the given directory, which must not exist, and returns a writer that
+// fills it with the key-value pairs of a checkpoint.
+func NewCheckpointWriter(dir string, logger *logger.SugarLogger) (*CheckpointWriter, error) {
+	exist, err := fileops.Exists(dir)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Errorf("the directory [%s] already exists", dir)
+	}
+
+	s, err := openNewStore(&Config{StoreDir: dir, Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckpointWriter{
+		s:     s,
+		batch: &leveldb.Batch{},
+	}, nil
+}
+
+// Put writes a raw key-value pair to the new store
+func (w *CheckpointWriter) Put(key, value []byte) error {
+	w.batch.Put(key, value)
+	if w.batch.Len() < checkpointBatchSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *CheckpointWriter) flush() error {
+	if w.batch.Len() == 0 {
+		return nil
+	}
+
+	if err := w.s.trieDataDB.Write(w.batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrap(err, "error while writing to the trie data database")
+	}
+	w.batch.Reset()
+	return nil
+}
+
+// Close writes the remaining pairs and closes the new store
+func (w *CheckpointWriter) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	return w.s.Close()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 )
 
 var (
@@ -32,10 +33,14 @@ var (
 	trieValueNs = []byte{1}
 	// last block stored
 	lastBlockNs = []byte{2}
+	// state of the last pruning
+	pruneStateNs = []byte{3}
 )
 
 // Store maintains MPTrie nodes and values in backend store
 type Store struct {
+	storeDir        string
+	inMemory        bool // trieDataDB is an in-memory leveldb instance
 	trieDataDB      *leveldb.DB
 	inMemoryNodes   map[string][]byte
 	inMemoryValues  map[string][]byte
@@ -43,11 +48,28 @@ type Store struct {
 	valuesToPersist map[string][]byte
 	logger          *logger.SugarLogger
 	mu              sync.RWMutex
+
+	// pruned is the state of the last pruning, if any
+	pruned *pruneState
+	// writtenWhilePruning holds the keys written while a pruning is in progress,
+	// which must not be removed by it
+	writtenWhilePruning map[string]struct{}
+	pruneMu             sync.Mutex
+	pruneStopCh         chan struct{}
+	pruneDoneCh         chan struct{}
 }
 
 // Config holds the configuration of a trie store
 type Config struct {
 	StoreDir string
+	// InMemory keeps the trie data in an in-memory leveldb instance
+	// instead of StoreDir, which is not used. The store is empty on
+	// every open.
+	InMemory bool
+	// ReadOnly opens an existing store for reads only, e.g., to verify the
+	// ledger offline. The store is neither cleaned up nor created, and any
+	// write fails.
+	ReadOnly bool
 	Logger   *logger.SugarLogger
 }
 
@@ -58,6 +80,13 @@ type NodeBytesWithType struct {
 
 // Open opens the store to store MPTrie nodes and values
 func Open(c *Config) (*Store, error) {
+	if c.InMemory {
+		return openInMemoryStore(c)
+	}
+	if c.ReadOnly {
+		return openReadOnlyStore(c)
+	}
+
 	exist, err := fileops.Exists(c.StoreDir)
 	if err != nil {
 		return nil, err
@@ -114,6 +143,7 @@ func openNewStore(c *Config) (*Store, error) {
 	}
 
 	return &Store{
+		storeDir:        c.StoreDir,
 		trieDataDB:      trieDataDB,
 		inMemoryNodes:   make(map[string][]byte),
 		inMemoryValues:  make(map[string][]byte),
@@ -124,15 +154,58 @@ func openNewStore(c *Config) (*Store, error) {
 	}, nil
 }
 
+func openInMemoryStore(c *Config) (*Store, error) {
+	trieDataDB, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating an in-memory trie data database")
+	}
+
+	return &Store{
+		inMemory:        true,
+		trieDataDB:      trieDataDB,
+		inMemoryNodes:   make(map[string][]byte),
+		inMemoryValues:  make(map[string][]byte),
+		nodesToPersist:  make(map[string][]byte),
+		valuesToPersist: make(map[string][]byte),
+		logger:          c.Logger,
+		mu:              sync.RWMutex{},
+	}, nil
+}
+
+// openReadOnlyStore opens an existing store without modifying it
+func openReadOnlyStore(c *Config) (*Store, error) {
+	exist, err := fileops.Exists(c.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Errorf("the trie store [%s] does not exist", c.StoreDir)
+	}
+	partialStoreExist, err := isExistingStoreCreatedPartially(c.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+	if partialStoreExist {
+		return nil, errors.Errorf("the trie store [%s] was created partially", c.StoreDir)
+	}
+
+	return openLevelDBStore(c, &opt.Options{ErrorIfMissing: true, ReadOnly: true})
+}
+
 func openExistingStore(c *Config) (*Store, error) {
+	return openLevelDBStore(c, &opt.Options{ErrorIfMissing: true})
+}
+
+func openLevelDBStore(c *Config, dbOptions *opt.Options) (*Store, error) {
 	trieDataDBPath := filepath.Join(c.StoreDir, trieDataDBName)
 
-	trieDataDB, err := leveldb.OpenFile(trieDataDBPath, &opt.Options{ErrorIfMissing: true})
+	trieDataDB, err := leveldb.OpenFile(trieDataDBPath, dbOptions)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the trie data")
 	}
 
 	s := &Store{
+		storeDir:        c.StoreDir,
 		trieDataDB:      trieDataDB,
 		inMemoryNodes:   make(map[string][]byte),
 		inMemoryValues:  make(map[string][]byte),
@@ -141,11 +214,19 @@ func openExistingStore(c *Config) (*Store, error) {
 		logger:          c.Logger,
 		mu:              sync.RWMutex{},
 	}
+	if err := s.loadPruneState(); err != nil {
+		trieDataDB.Close()
+		return nil, err
+	}
 	return s, nil
 }
 
 // Close closes the store
 func (s *Store) Close() error {
+	s.stopPruning()
+
+	s.pruneMu.Lock()
+	defer s.pruneMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if err := s.trieDataDB.Close(); err != nil {
@@ -39,6 +39,22 @@ func TestOpen(t *testing.T) {
 		assertStore(t, storeDir, s)
 	})
 
+	t.Run("open an in-memory store", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Open(&Config{InMemory: true, Logger: logger})
+		require.NoError(t, err)
+		defer s.Close()
+
+		pointers := fillStore(t, s, true, 0, uint64(99))
+		checkStoreContent(t, s, pointers, true, true, 0)
+		lastBlock, err := s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(99), lastBlock)
+
+		require.EqualError(t, s.Replace("/tmp"), "an in-memory trie store cannot be replaced")
+	})
+
 	t.Run("open while partial store exist with an empty dir", func(t *testing.T) {
 		t.Parallel()
 
@@ -148,6 +164,45 @@ func TestOpen(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, uint64(999), lastBlock)
 	})
+
+	t.Run("open read-only", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir(".", "open_test")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		storeDir := filepath.Join(testDir, "read-only-store")
+		c := &Config{
+			StoreDir: storeDir,
+			ReadOnly: true,
+			Logger:   logger,
+		}
+		_, err = Open(c)
+		require.EqualError(t, err, "the trie store ["+storeDir+"] does not exist")
+
+		// a partially created store is neither removed nor created
+		require.NoError(t, fileops.CreateDir(storeDir))
+		_, err = Open(c)
+		require.EqualError(t, err, "the trie store ["+storeDir+"] was created partially")
+		require.DirExists(t, storeDir)
+		require.NoDirExists(t, filepath.Join(storeDir, trieDataDBName))
+
+		require.NoError(t, os.RemoveAll(storeDir))
+		s, err := Open(&Config{StoreDir: storeDir, Logger: logger})
+		require.NoError(t, err)
+		pointers := fillStore(t, s, true, 0, uint64(99))
+		require.NoError(t, s.Close())
+
+		s, err = Open(c)
+		require.NoError(t, err)
+		defer s.Close()
+		checkStoreContent(t, s, pointers, true, true, 0)
+		lastBlock, err := s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(99), lastBlock)
+		require.Error(t, s.CommitChanges(100))
+	})
 }
 
 func assertStore(t *testing.T, storeDir string, s *Store) {
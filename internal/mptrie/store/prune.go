@@ -0,0 +1,324 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package store
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// pruneBatchSize is the number of unreachable nodes and values removed in a single batch
+const pruneBatchSize = 1000
+
+// retainedBlocks returns the blocks whose state trie is retained by the policy at the given height, in
+// increasing order
+func retainedBlocks(p *mptrie.RetentionPolicy, height uint64) []uint64 {
+	firstRecent := uint64(1)
+	if height > p.LastBlocks {
+		firstRecent = height - p.LastBlocks + 1
+	}
+
+	var blocks []uint64
+	if p.CheckpointInterval > 0 {
+		for blockNum := p.CheckpointInterval; blockNum < firstRecent; blockNum += p.CheckpointInterval {
+			blocks = append(blocks, blockNum)
+		}
+	}
+	for blockNum := firstRecent; blockNum <= height; blockNum++ {
+		blocks = append(blocks, blockNum)
+	}
+	return blocks
+}
+
+// pruneState is the state of the pruned store: the state trie of the blocks up to prunedUpTo is removed,
+// except for the blocks whose number is a multiple of checkpointInterval, if positive
+type pruneState struct {
+	prunedUpTo         uint64
+	checkpointInterval uint64
+}
+
+func (p *pruneState) isPruned(blockNum uint64) bool {
+	if blockNum > p.prunedUpTo {
+		return false
+	}
+	return p.checkpointInterval == 0 || blockNum%p.checkpointInterval != 0
+}
+
+// IsPruned returns true if the state trie of the given block was removed by the pruning
+func (s *Store) IsPruned(blockNum uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.pruned != nil && s.pruned.isPruned(blockNum)
+}
+
+// Prune removes the trie nodes and values which are not reachable from the state trie roots of the blocks
+// retained by the policy at the current height of the store. rootOf returns the state trie root of a block.
+// Blocks can be committed while the store is pruned: the nodes and values written during the pruning are
+// never removed by it. As all the nodes reachable from a retained root are kept, the state trie of a block
+// is either complete or pruned. The checkpoint interval of the policy cannot change once the store is pruned.
+// As a pruning walks the retained state tries and scans the whole store, it is skipped when no block left the
+// retained ones since the last pruning, in which case nothing became unreachable.
+func (s *Store) Prune(policy *mptrie.RetentionPolicy, rootOf func(blockNum uint64) ([]byte, error)) error {
+	if policy.LastBlocks == 0 {
+		return errors.New("the retention policy must retain at least the last block")
+	}
+
+	s.pruneMu.Lock()
+	defer s.pruneMu.Unlock()
+
+	s.mu.Lock()
+	pruned := s.pruned
+	s.writtenWhilePruning = make(map[string]struct{})
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.writtenWhilePruning = nil
+		s.mu.Unlock()
+	}()
+
+	if pruned != nil && pruned.checkpointInterval != policy.CheckpointInterval {
+		return errors.Errorf("the store is pruned with the checkpoint interval [%d], which cannot be changed to [%d]",
+			pruned.checkpointInterval, policy.CheckpointInterval)
+	}
+
+	height, err := s.Height()
+	if err == leveldb.ErrNotFound || (err == nil && height <= policy.LastBlocks) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if pruned != nil && height-policy.LastBlocks <= pruned.prunedUpTo {
+		// no block left the retained ones since the last pruning, and hence, every node and value
+		// committed since then is still reachable from a retained root
+		return nil
+	}
+
+	reachable := make(map[string]struct{})
+	for _, blockNum := range retainedBlocks(policy, height) {
+		if pruned != nil && pruned.isPruned(blockNum) {
+			continue
+		}
+		root, err := rootOf(blockNum)
+		if err != nil {
+			return errors.WithMessagef(err, "error while fetching the state trie root of block [%d]", blockNum)
+		}
+		if root == nil {
+			continue
+		}
+		if err := s.markReachable(root, reachable); err != nil {
+			return errors.WithMessagef(err, "error while walking the state trie of block [%d]", blockNum)
+		}
+	}
+
+	// the pruned blocks are recorded before any removal so that the proofs of a block being pruned
+	// fail with a clear error
+	state := &pruneState{
+		prunedUpTo:         height - policy.LastBlocks,
+		checkpointInterval: policy.CheckpointInterval,
+	}
+	if pruned != nil && pruned.prunedUpTo > state.prunedUpTo {
+		state.prunedUpTo = pruned.prunedUpTo
+	}
+	if err := s.storePruneState(state); err != nil {
+		return err
+	}
+
+	removedNodes, err := s.removeUnreachable(trieNodesNs, reachable)
+	if err != nil {
+		return err
+	}
+	removedValues, err := s.removeUnreachable(trieValueNs, reachable)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("pruned the state trie up to block [%d] at height [%d]: removed %d nodes and %d values",
+		state.prunedUpTo, height, removedNodes, removedValues)
+	return nil
+}
+
+// markReachable adds the keys of all the nodes and values reachable from the given node to reachable. As the
+// nodes are content-addressed, the nodes below a node that is already marked are marked too.
+func (s *Store) markReachable(nodePtr []byte, reachable map[string]struct{}) error {
+	nodeKey := string(trieNodesNs) + base64.StdEncoding.EncodeToString(nodePtr)
+	if _, ok := reachable[nodeKey]; ok {
+		return nil
+	}
+
+	node, err := s.GetNode(nodePtr)
+	if err != nil {
+		return errors.Wrapf(err, "error while fetching trie node %x", nodePtr)
+	}
+	reachable[nodeKey] = struct{}{}
+
+	markValue := func(valuePtr []byte) {
+		if len(valuePtr) > 0 {
+			reachable[string(trieValueNs)+base64.StdEncoding.EncodeToString(valuePtr)] = struct{}{}
+		}
+	}
+
+	switch n := node.(type) {
+	case *mptrie.BranchNode:
+		markValue(n.GetValuePtr())
+		for _, childPtr := range n.GetChildren() {
+			if childPtr == nil {
+				continue
+			}
+			if err := s.markReachable(childPtr, reachable); err != nil {
+				return err
+			}
+		}
+	case *mptrie.ExtensionNode:
+		return s.markReachable(n.GetChild(), reachable)
+	case *mptrie.ValueNode:
+		markValue(n.GetValuePtr())
+	}
+	return nil
+}
+
+// removeUnreachable removes the keys of the given namespace which are neither reachable nor written since the
+// pruning started
+func (s *Store) removeUnreachable(ns []byte, reachable map[string]struct{}) (int, error) {
+	snap, err := s.trieDataDB.GetSnapshot()
+	if err != nil {
+		return 0, errors.Wrap(err, "error while taking a snapshot of the trie data database")
+	}
+	defer snap.Release()
+
+	itr := snap.NewIterator(util.BytesPrefix(ns), nil)
+	defer itr.Release()
+
+	removed := 0
+	var candidates [][]byte
+	flush := func() error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		batch := &leveldb.Batch{}
+		for _, key := range candidates {
+			if _, ok := s.writtenWhilePruning[string(key)]; ok {
+				continue
+			}
+			batch.Delete(key)
+		}
+		removed += batch.Len()
+		candidates = candidates[:0]
+		return errors.Wrap(s.trieDataDB.Write(batch, &opt.WriteOptions{}), "error while removing unreachable trie data")
+	}
+
+	for itr.Next() {
+		if _, ok := reachable[string(itr.Key())]; ok {
+			continue
+		}
+		candidates = append(candidates, append([]byte{}, itr.Key()...))
+		if len(candidates) == pruneBatchSize {
+			if err := flush(); err != nil {
+				return removed, err
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return removed, errors.Wrap(err, "error while iterating over the trie data database")
+	}
+	if len(candidates) > 0 {
+		if err := flush(); err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+func (s *Store) storePruneState(state *pruneState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stateBytes := make([]byte, 16)
+	binary.LittleEndian.PutUint64(stateBytes, state.prunedUpTo)
+	binary.LittleEndian.PutUint64(stateBytes[8:], state.checkpointInterval)
+	if err := s.trieDataDB.Put(pruneStateNs, stateBytes, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrap(err, "error while storing the prune state")
+	}
+
+	s.pruned = state
+	return nil
+}
+
+// loadPruneState loads the state of the last pruning, if any, before the store is shared
+func (s *Store) loadPruneState() error {
+	stateBytes, err := s.trieDataDB.Get(pruneStateNs, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error while loading the prune state")
+	}
+	if len(stateBytes) != 16 {
+		return errors.Errorf("the prune state has an unexpected length [%d]", len(stateBytes))
+	}
+
+	s.pruned = &pruneState{
+		prunedUpTo:         binary.LittleEndian.Uint64(stateBytes),
+		checkpointInterval: binary.LittleEndian.Uint64(stateBytes[8:]),
+	}
+	return nil
+}
+
+// StartPruning prunes the store with the given policy every interval, in the background, until the store is
+// closed. rootOf returns the state trie root of a block.
+func (s *Store) StartPruning(policy *mptrie.RetentionPolicy, interval time.Duration, rootOf func(blockNum uint64) ([]byte, error)) error {
+	if policy.LastBlocks == 0 {
+		return errors.New("the retention policy must retain at least the last block")
+	}
+	if interval <= 0 {
+		return errors.New("the pruning interval must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pruneStopCh != nil {
+		return errors.New("the pruning is already started")
+	}
+	s.pruneStopCh = make(chan struct{})
+	s.pruneDoneCh = make(chan struct{})
+
+	go func(stopCh, doneCh chan struct{}) {
+		defer close(doneCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if err := s.Prune(policy, rootOf); err != nil {
+					s.logger.Errorf("error while pruning the state trie: %s", err)
+				}
+			}
+		}
+	}(s.pruneStopCh, s.pruneDoneCh)
+
+	return nil
+}
+
+// stopPruning stops the background pruning, if started, and waits for the pruning in progress, if any
+func (s *Store) stopPruning() {
+	s.mu.Lock()
+	stopCh, doneCh := s.pruneStopCh, s.pruneDoneCh
+	s.pruneStopCh, s.pruneDoneCh = nil, nil
+	s.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		<-doneCh
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+type trieHistory struct {
+	mu     sync.Mutex
+	roots  map[uint64][]byte
+	values map[uint64]map[string]string
+}
+
+func (h *trieHistory) rootOf(blockNum uint64) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	root, ok := h.roots[blockNum]
+	if !ok {
+		return nil, errors.Errorf("block [%d] not found", blockNum)
+	}
+	return root, nil
+}
+
+// commitBlocks commits blocks which update 3 out of 10 keys and delete one of them
+func commitBlocks(t *testing.T, s *Store, h *trieHistory, fromBlock, toBlock uint64) {
+	h.mu.Lock()
+	lastRoot := h.roots[fromBlock-1]
+	lastValues := h.values[fromBlock-1]
+	h.mu.Unlock()
+
+	trie, err := mptrie.NewTrie(lastRoot, s)
+	require.NoError(t, err)
+
+	for blockNum := fromBlock; blockNum <= toBlock; blockNum++ {
+		values := make(map[string]string)
+		for k, v := range lastValues {
+			values[k] = v
+		}
+		for i := uint64(0); i < 3; i++ {
+			key := fmt.Sprintf("key%d", (blockNum*3+i)%10)
+			value := fmt.Sprintf("value%d-%d", blockNum, i)
+			require.NoError(t, trie.Update([]byte(key), []byte(value)))
+			values[key] = value
+		}
+		deletedKey := fmt.Sprintf("key%d", (blockNum*7)%10)
+		if _, ok := values[deletedKey]; ok {
+			_, err := trie.Delete([]byte(deletedKey))
+			require.NoError(t, err)
+			delete(values, deletedKey)
+		}
+		require.NoError(t, trie.Commit(blockNum))
+
+		root, err := trie.Hash()
+		require.NoError(t, err)
+		h.mu.Lock()
+		h.roots[blockNum] = root
+		h.values[blockNum] = values
+		h.mu.Unlock()
+		lastValues = values
+	}
+}
+
+func requireTrieRetained(t *testing.T, s *Store, h *trieHistory, blockNum uint64) {
+	require.False(t, s.IsPruned(blockNum), "block %d", blockNum)
+
+	trie, err := mptrie.NewTrie(h.roots[blockNum], s)
+	require.NoError(t, err, "block %d", blockNum)
+	require.NoError(t, trie.Verify(nil), "block %d", blockNum)
+	for key, value := range h.values[blockNum] {
+		v, err := trie.Get([]byte(key))
+		require.NoError(t, err, "block %d", blockNum)
+		require.Equal(t, value, string(v), "block %d", blockNum)
+	}
+}
+
+func requireTriePruned(t *testing.T, s *Store, h *trieHistory, blockNum uint64) {
+	require.True(t, s.IsPruned(blockNum), "block %d", blockNum)
+
+	_, err := s.GetNode(h.roots[blockNum])
+	require.Error(t, err, "block %d", blockNum)
+}
+
+func countTrieNodes(t *testing.T, s *Store) int {
+	itr := s.trieDataDB.NewIterator(util.BytesPrefix(trieNodesNs), nil)
+	defer itr.Release()
+
+	count := 0
+	for itr.Next() {
+		count++
+	}
+	require.NoError(t, itr.Error())
+	return count
+}
+
+func TestPrune(t *testing.T) {
+	lc := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(lc)
+	require.NoError(t, err)
+
+	setup := func(t *testing.T, totalBlocks uint64) (string, *Store, *trieHistory) {
+		testDir, err := ioutil.TempDir("", "prune_test")
+		require.NoError(t, err)
+
+		s, err := Open(&Config{StoreDir: filepath.Join(testDir, "trie"), Logger: logger})
+		require.NoError(t, err)
+
+		h := &trieHistory{
+			roots:  map[uint64][]byte{0: nil},
+			values: map[uint64]map[string]string{0: {}},
+		}
+		commitBlocks(t, s, h, 1, totalBlocks)
+		return testDir, s, h
+	}
+
+	t.Run("last blocks and checkpoints retained", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, s, h := setup(t, 20)
+		defer os.RemoveAll(testDir)
+		nodesBefore := countTrieNodes(t, s)
+
+		policy := &mptrie.RetentionPolicy{LastBlocks: 3, CheckpointInterval: 5}
+		require.NoError(t, s.Prune(policy, h.rootOf))
+		require.Less(t, countTrieNodes(t, s), nodesBefore)
+
+		for blockNum := uint64(1); blockNum <= 20; blockNum++ {
+			if blockNum > 17 || blockNum%5 == 0 {
+				requireTrieRetained(t, s, h, blockNum)
+			} else {
+				requireTriePruned(t, s, h, blockNum)
+			}
+		}
+
+		// the prune state survives a reopen
+		require.NoError(t, s.Close())
+		s, err = Open(&Config{StoreDir: filepath.Join(testDir, "trie"), Logger: logger})
+		require.NoError(t, err)
+		defer s.Close()
+		require.True(t, s.IsPruned(17))
+		require.False(t, s.IsPruned(15))
+
+		// blocks committed after a pruning build on the retained trie
+		commitBlocks(t, s, h, 21, 30)
+		require.NoError(t, s.Prune(&mptrie.RetentionPolicy{LastBlocks: 5, CheckpointInterval: 5}, h.rootOf))
+		for blockNum := uint64(1); blockNum <= 30; blockNum++ {
+			if blockNum > 25 || blockNum%5 == 0 {
+				requireTrieRetained(t, s, h, blockNum)
+			} else {
+				requireTriePruned(t, s, h, blockNum)
+			}
+		}
+
+		// a larger number of last blocks does not restore the pruned blocks
+		require.NoError(t, s.Prune(&mptrie.RetentionPolicy{LastBlocks: 20, CheckpointInterval: 5}, h.rootOf))
+		require.True(t, s.IsPruned(24))
+		requireTrieRetained(t, s, h, 30)
+
+		require.EqualError(t, s.Prune(&mptrie.RetentionPolicy{LastBlocks: 5, CheckpointInterval: 10}, h.rootOf),
+			"the store is pruned with the checkpoint interval [5], which cannot be changed to [10]")
+		require.EqualError(t, s.Prune(&mptrie.RetentionPolicy{}, h.rootOf),
+			"the retention policy must retain at least the last block")
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, s, h := setup(t, 3)
+		defer os.RemoveAll(testDir)
+		defer s.Close()
+
+		require.NoError(t, s.Prune(&mptrie.RetentionPolicy{LastBlocks: 3}, h.rootOf))
+		for blockNum := uint64(1); blockNum <= 3; blockNum++ {
+			requireTrieRetained(t, s, h, blockNum)
+		}
+	})
+
+	t.Run("no block left the retained ones since the last pruning", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, s, h := setup(t, 20)
+		defer os.RemoveAll(testDir)
+		defer s.Close()
+
+		policy := &mptrie.RetentionPolicy{LastBlocks: 5}
+		require.NoError(t, s.Prune(policy, h.rootOf))
+
+		// an unreachable node is removed only once a block leaves the retained ones, as the store is
+		// not scanned otherwise
+		unreachable := append(append([]byte{}, trieNodesNs...), []byte("unreachable")...)
+		require.NoError(t, s.trieDataDB.Put(unreachable, []byte("node"), nil))
+		require.NoError(t, s.Prune(policy, h.rootOf))
+		_, err := s.trieDataDB.Get(unreachable, nil)
+		require.NoError(t, err)
+
+		commitBlocks(t, s, h, 21, 21)
+		require.NoError(t, s.Prune(policy, h.rootOf))
+		_, err = s.trieDataDB.Get(unreachable, nil)
+		require.Error(t, err)
+		requireTriePruned(t, s, h, 16)
+		for blockNum := uint64(17); blockNum <= 21; blockNum++ {
+			requireTrieRetained(t, s, h, blockNum)
+		}
+	})
+
+	t.Run("blocks committed while pruning", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, s, h := setup(t, 50)
+		defer os.RemoveAll(testDir)
+		defer s.Close()
+
+		policy := &mptrie.RetentionPolicy{LastBlocks: 1}
+		require.NoError(t, s.StartPruning(policy, time.Millisecond, h.rootOf))
+		require.EqualError(t, s.StartPruning(policy, time.Millisecond, h.rootOf), "the pruning is already started")
+		for blockNum := uint64(51); blockNum <= 150; blockNum++ {
+			commitBlocks(t, s, h, blockNum, blockNum)
+			time.Sleep(time.Millisecond)
+		}
+		s.stopPruning()
+		require.True(t, s.IsPruned(50))
+
+		requireTrieRetained(t, s, h, 150)
+		require.NoError(t, s.Prune(policy, h.rootOf))
+		requireTrieRetained(t, s, h, 150)
+		requireTriePruned(t, s, h, 149)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package store
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Replace replaces the content of the store with the store in the given directory, e.g., a store rebuilt from the
+// blocks. The directory is moved into the place of the store, and the store is reopened.
+func (s *Store) Replace(dir string) error {
+	if s.inMemory {
+		return errors.New("an in-memory trie store cannot be replaced")
+	}
+
+	// waits for the pruning in progress, if any, which must not walk the replaced store
+	s.pruneMu.Lock()
+	defer s.pruneMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.trieDataDB.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the trie data database")
+	}
+
+	if err := os.RemoveAll(s.storeDir); err != nil {
+		return errors.Wrapf(err, "error while removing the directory [%s]", s.storeDir)
+	}
+	if err := os.Rename(dir, s.storeDir); err != nil {
+		return errors.Wrapf(err, "error while moving the directory [%s] to [%s]", dir, s.storeDir)
+	}
+
+	replaced, err := openExistingStore(&Config{StoreDir: s.storeDir, Logger: s.logger})
+	if err != nil {
+		return err
+	}
+
+	s.trieDataDB = replaced.trieDataDB
+	s.pruned = replaced.pruned
+	s.inMemoryNodes = make(map[string][]byte)
+	s.inMemoryValues = make(map[string][]byte)
+	s.nodesToPersist = make(map[string][]byte)
+	s.valuesToPersist = make(map[string][]byte)
+
+	return nil
+}
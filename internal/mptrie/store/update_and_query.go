@@ -32,6 +32,9 @@ func (s *Store) GetNode(nodePtr []byte) (mptrie.TrieNode, error) {
 			}
 		}
 	}
+	if len(storedNodeBytes) == 0 {
+		return nil, errors.New("empty node")
+	}
 	nodeTypePrefix := storedNodeBytes[0]
 	switch nodeTypePrefix {
 	case Branch:
@@ -174,6 +177,14 @@ func (s *Store) CommitChanges(blockNum uint64) error {
 	if err := s.trieDataDB.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
 		return err
 	}
+	if s.writtenWhilePruning != nil {
+		for k := range s.valuesToPersist {
+			s.writtenWhilePruning[string(trieValueNs)+k] = struct{}{}
+		}
+		for k := range s.nodesToPersist {
+			s.writtenWhilePruning[string(trieNodesNs)+k] = struct{}{}
+		}
+	}
 	s.nodesToPersist = make(map[string][]byte)
 	s.valuesToPersist = make(map[string][]byte)
 	s.inMemoryNodes = make(map[string][]byte)
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// Verify walks the whole trie from the root and checks that each node hashes to the pointer it is
+// referenced by, and that each value that is not deleted hashes, together with its key, to its value
+// pointer. As the hash of the root covers all the nodes, a successful walk proves that the trie store
+// holds the complete and untampered trie of the root, i.e., of Hash(). f, if not nil, is called with
+// the key and the value of each entry that is not deleted.
+func (t *MPTrie) Verify(f func(key, value []byte) error) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.verifyNode(t.root, nil, f)
+}
+
+func (t *MPTrie) verifyNode(node TrieNode, hexPath []byte, f func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case *BranchNode:
+		if len(n.GetChildren()) != 16 {
+			return errors.Errorf("branch node at path %x has %d children", hexPath, len(n.GetChildren()))
+		}
+		if err := t.verifyValue(n, hexPath, f); err != nil {
+			return err
+		}
+		for i, childPtr := range n.GetChildren() {
+			if childPtr == nil {
+				continue
+			}
+			if err := t.verifyChild(childPtr, append(hexPath[:len(hexPath):len(hexPath)], byte(i)), f); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *ExtensionNode:
+		if len(n.GetKey()) == 0 || n.GetChild() == nil {
+			return errors.Errorf("extension node at path %x has no key or no child", hexPath)
+		}
+		return t.verifyChild(n.GetChild(), append(hexPath[:len(hexPath):len(hexPath)], n.GetKey()...), f)
+
+	case *ValueNode:
+		return t.verifyValue(n, append(hexPath[:len(hexPath):len(hexPath)], n.GetKey()...), f)
+
+	default:
+		return errors.Errorf("unexpected trie node type %T at path %x", node, hexPath)
+	}
+}
+
+func (t *MPTrie) verifyChild(childPtr, hexPath []byte, f func(key, value []byte) error) error {
+	child, err := t.store.GetNode(childPtr)
+	if err != nil {
+		return errors.WithMessagef(err, "error while fetching trie node at path %x", hexPath)
+	}
+	if child == nil {
+		return errors.Errorf("trie node at path %x is missing", hexPath)
+	}
+
+	childHash, err := child.hash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(childHash, childPtr) {
+		return errors.Errorf("trie node at path %x does not match its hash", hexPath)
+	}
+
+	return t.verifyNode(child, hexPath, f)
+}
+
+func (t *MPTrie) verifyValue(node TrieNodeWithValue, hexPath []byte, f func(key, value []byte) error) error {
+	valuePtr := node.getValuePtr()
+	if len(valuePtr) == 0 || node.isDeleted() {
+		return nil
+	}
+
+	key, err := convertHexToByte(hexPath)
+	if err != nil {
+		return err
+	}
+
+	value, err := t.store.GetValue(valuePtr)
+	if err != nil {
+		return errors.WithMessagef(err, "error while fetching the value of key %x", key)
+	}
+	expectedPtr, err := state.CalculateKeyValueHash(key, value)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expectedPtr, valuePtr) {
+		return errors.Errorf("value of key %x does not match its hash", key)
+	}
+
+	if f == nil {
+		return nil
+	}
+	return f(key, value)
+}
+
+func convertHexToByte(hexKey []byte) ([]byte, error) {
+	if len(hexKey)%2 != 0 {
+		return nil, errors.Errorf("path %x of a value has an odd length", hexKey)
+	}
+
+	res := make([]byte, len(hexKey)/2)
+	for i := range res {
+		res[i] = hexKey[2*i]<<4 | hexKey[2*i+1]
+	}
+	return res, nil
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	keys := [][]byte{
+		convertHexToKey(t, []byte("11ada1df")),
+		convertHexToKey(t, []byte("11ada1de")),
+		convertHexToKey(t, []byte("11ada1")),
+		convertHexToKey(t, []byte("11ada3")),
+		convertHexToKey(t, []byte("22")),
+	}
+	values := [][]byte{
+		[]byte("A"),
+		[]byte("B"),
+		[]byte("C"),
+		[]byte("D"),
+		[]byte("E"),
+	}
+
+	setup := func(t *testing.T) (*MPTrie, *trieStoreMock) {
+		store := newMockStore()
+		trie, err := NewTrie(nil, store)
+		require.NoError(t, err)
+		for i, key := range keys {
+			require.NoError(t, trie.Update(key, values[i]))
+		}
+		_, err = trie.Delete(keys[3])
+		require.NoError(t, err)
+		require.NoError(t, trie.Commit(1))
+
+		return trie, store.(*trieStoreMock)
+	}
+
+	t.Run("all entries are verified", func(t *testing.T) {
+		trie, _ := setup(t)
+
+		entries := make(map[string][]byte)
+		err := trie.Verify(func(key, value []byte) error {
+			entries[string(key)] = value
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			string(keys[0]): values[0],
+			string(keys[1]): values[1],
+			string(keys[2]): values[2],
+			string(keys[4]): values[4],
+		}, entries)
+
+		require.NoError(t, trie.Verify(nil))
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		trie, store := setup(t)
+
+		for k := range store.persistValues {
+			store.persistValues[k] = []byte("tampered")
+		}
+		err := trie.Verify(nil)
+		require.Error(t, err)
+		require.Regexp(t, "^value of key [0-9a-f]+ does not match its hash$", err.Error())
+	})
+
+	t.Run("tampered node", func(t *testing.T) {
+		trie, store := setup(t)
+
+		rootHash, err := trie.Hash()
+		require.NoError(t, err)
+		root := trie.root.(*BranchNode)
+		for _, childPtr := range root.Children {
+			if childPtr == nil {
+				continue
+			}
+			child, err := store.GetNode(childPtr)
+			require.NoError(t, err)
+			tampered := &ValueNode{Key: []byte{1}, ValuePtr: []byte("ptr")}
+			if vn, ok := child.(*ValueNode); ok {
+				tampered.Key = vn.Key
+			}
+			require.NoError(t, store.PutNode(childPtr, tampered))
+			key := base64.StdEncoding.EncodeToString(childPtr)
+			store.persistNodes[key] = store.inMemoryNodes[key]
+		}
+
+		trie, err = NewTrie(rootHash, store)
+		require.NoError(t, err)
+		err = trie.Verify(nil)
+		require.Error(t, err)
+		require.Regexp(t, "^trie node at path [0-9a-f]+ does not match its hash$", err.Error())
+	})
+}
@@ -24,6 +24,17 @@ func calculateBlockTxHashes(block *types.Block) ([][]byte, error) {
 			hashes = append(hashes, h)
 		}
 		return hashes, nil
+	case *types.Block_ProcedureTxEnvelopes:
+		// the operations produced by the procedures are part of the block
+		// header and hence, they are covered by the block header hash
+		for i, tx := range block.GetProcedureTxEnvelopes().GetEnvelopes() {
+			h, err := calculateTxHash(tx, block.GetHeader().GetValidationInfo()[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "can't calculate msg hash %v", tx.GetPayload())
+			}
+			hashes = append(hashes, h)
+		}
+		return hashes, nil
 	case *types.Block_UserAdministrationTxEnvelope:
 		userTx := block.GetUserAdministrationTxEnvelope()
 		h, err := calculateTxHash(userTx, block.GetHeader().GetValidationInfo()[0])
@@ -38,6 +49,13 @@ func calculateBlockTxHashes(block *types.Block) ([][]byte, error) {
 			return nil, errors.Wrapf(err, "can't calculate msg hash %v", dbTx.GetPayload())
 		}
 		return [][]byte{h}, nil
+	case *types.Block_ExpiryTxEnvelope:
+		expiryTx := block.GetExpiryTxEnvelope()
+		h, err := calculateTxHash(expiryTx, block.GetHeader().GetValidationInfo()[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't calculate msg hash %v", expiryTx.GetPayload())
+		}
+		return [][]byte{h}, nil
 	case *types.Block_ConfigTxEnvelope:
 		configTx := block.GetConfigTxEnvelope()
 		h, err := calculateTxHash(configTx, block.GetHeader().GetValidationInfo()[0])
@@ -4,6 +4,7 @@ package mtree
 
 import (
 	"fmt"
+	"sort"
 
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/pkg/errors"
@@ -65,3 +66,51 @@ func computeProofFromPath(path []*Node) [][]byte {
 
 	return proof
 }
+
+// MultiProof calculates the intermediate hashes between the leaves with given indexes and root (caller node). Each
+// intermediate hash is part of the proof once, and the hashes computed from the leaves are not part of it. The hashes
+// are ordered level by level, from the leaves up to the root, and from left to right in each level.
+func (n *Node) MultiProof(leafIndexes []int) ([][]byte, error) {
+	if len(leafIndexes) == 0 {
+		return nil, errors.New("no leaf indexes to prove")
+	}
+
+	// the nodes proven at the current level, by their index in the level, in increasing order
+	type provenNode struct {
+		index int
+		path  []*Node
+	}
+	sortedIndexes := append([]int{}, leafIndexes...)
+	sort.Ints(sortedIndexes)
+
+	level := make([]*provenNode, 0, len(sortedIndexes))
+	for _, leafIndex := range sortedIndexes {
+		if len(level) > 0 && level[len(level)-1].index == leafIndex {
+			continue
+		}
+		path, err := n.findPath(leafIndex)
+		if err != nil {
+			return nil, err
+		}
+		level = append(level, &provenNode{index: leafIndex, path: path})
+	}
+
+	// all the leaves are at the same depth, as a node without sibling has a parent
+	proof := make([][]byte, 0)
+	for depth := 0; depth < len(level[0].path)-1; depth++ {
+		nextLevel := make([]*provenNode, 0, len(level))
+		for i := 0; i < len(level); i++ {
+			node := level[i]
+			if node.index%2 == 0 && i+1 < len(level) && level[i+1].index == node.index+1 {
+				// the sibling is proven as well
+				i++
+			} else if siblingHash := node.path[depth].Sibling().Hash(); siblingHash != nil {
+				proof = append(proof, siblingHash)
+			}
+			nextLevel = append(nextLevel, &provenNode{index: node.index / 2, path: node.path})
+		}
+		level = nextLevel
+	}
+
+	return proof, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
 )
@@ -113,3 +114,102 @@ func TestNodeProof(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeMultiProof(t *testing.T) {
+	tests := []struct {
+		name        string
+		block       *types.Block
+		indexes     []int
+		proofLen    int
+		expectedErr string
+	}{
+		{
+			name:     "Data block full tree, siblings",
+			block:    generateDataBlock(t, 32),
+			indexes:  []int{0, 1},
+			proofLen: 4,
+		},
+		{
+			name:     "Data block full tree, distant leaves",
+			block:    generateDataBlock(t, 32),
+			indexes:  []int{0, 31},
+			proofLen: 8,
+		},
+		{
+			name:     "Data block full tree, all leaves",
+			block:    generateDataBlock(t, 32),
+			indexes:  []int{5, 3, 0, 1, 2, 4, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+			proofLen: 0,
+		},
+		{
+			name:     "Data block half tree plus two, last leaves",
+			block:    generateDataBlock(t, 34),
+			indexes:  []int{32, 33},
+			proofLen: 1,
+		},
+		{
+			name:     "Data block half tree plus one, leaf without sibling",
+			block:    generateDataBlock(t, 33),
+			indexes:  []int{32, 7, 7},
+			proofLen: 5,
+		},
+		{
+			name:     "Config block",
+			block:    generateConfigBlock(t),
+			indexes:  []int{0},
+			proofLen: 0,
+		},
+		{
+			name:        "Data block index out of bounds",
+			block:       generateDataBlock(t, 34),
+			indexes:     []int{3, 34},
+			expectedErr: "node with index 34 is not part of merkle tree (0, 33)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := BuildTreeForBlockTx(tt.block)
+			require.NoError(t, err)
+			proof, err := root.MultiProof(tt.indexes)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				require.IsType(t, &interrors.NotFoundErr{}, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, proof, tt.proofLen)
+
+			hashes, err := calculateBlockTxHashes(tt.block)
+			require.NoError(t, err)
+			txHashes := make(map[uint64][]byte)
+			for _, idx := range tt.indexes {
+				txHashes[uint64(idx)] = hashes[idx]
+			}
+			txCount := uint64(len(hashes))
+
+			multiProof := state.NewTxMultiProof(proof)
+			isValid, err := multiProof.Verify(txHashes, txCount, root.Hash())
+			require.NoError(t, err)
+			require.True(t, isValid)
+
+			// the proof is valid neither for another transaction nor for another tree size
+			for idx := range txHashes {
+				tamperedHashes := make(map[uint64][]byte)
+				for i, h := range txHashes {
+					tamperedHashes[i] = h
+				}
+				tamperedHashes[idx] = []byte("tx hash")
+				isValid, err = multiProof.Verify(tamperedHashes, txCount, root.Hash())
+				require.NoError(t, err)
+				require.False(t, isValid)
+				break
+			}
+			if txCount > 1 {
+				isValid, err = multiProof.Verify(txHashes, txCount*2, root.Hash())
+				require.NoError(t, err)
+				require.False(t, isValid)
+			}
+		})
+	}
+}
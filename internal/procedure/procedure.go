@@ -0,0 +1,286 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package procedure
+
+import (
+	"regexp"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// MaxExecutionSteps is the maximum number of computation steps a
+	// stored procedure can take. As every node executes the procedure
+	// independently during the block validation, the limit must be
+	// identical across the cluster and hence, it is not configurable
+	MaxExecutionSteps = 1000000
+	// AllowedCharsInProcedureName holds the regexp for allowed characters
+	// in a stored procedure name
+	AllowedCharsInProcedureName = `^[a-zA-Z_][0-9a-zA-Z_]*$`
+	// EntryPoint is the name of the function which every stored procedure
+	// must define. It is called with the arguments of the transaction.
+	EntryPoint = "main"
+)
+
+var procedureNameRegexp = regexp.MustCompile(AllowedCharsInProcedureName)
+
+// predeclared holds the names of all values made available to a stored
+// procedure in addition to the Starlark universe
+var predeclared = starlark.StringDict{
+	"get":    starlark.None,
+	"put":    starlark.None,
+	"delete": starlark.None,
+	"json":   json.Module,
+}
+
+// ExecutionError denotes a failure of the stored procedure itself, such
+// as a runtime error, a call to fail(), or exceeding the execution steps
+// limit. Such a failure invalidates the transaction which invoked the
+// procedure.
+type ExecutionError struct {
+	procedureName string
+	err           error
+}
+
+func (e *ExecutionError) Error() string {
+	return "execution of the stored procedure [" + e.procedureName + "] failed: " + e.err.Error()
+}
+
+// ValidName returns true if the given procedure name is valid
+func ValidName(procedureName string) bool {
+	return procedureNameRegexp.MatchString(procedureName)
+}
+
+// Compile checks whether the given source is a well-formed stored procedure.
+// A stored procedure is a Starlark program defining a main function which is
+// called with the string arguments passed by the transaction. In addition to
+// the Starlark built-ins, the program can use the following predeclared values:
+//   - get(key): returns the value of the key as a string or None when the key does not exist
+//   - put(key, value): writes the string value to the key, retaining the access control of an existing key
+//   - delete(key): deletes the key if it exists
+//   - json: the Starlark JSON module to encode and decode values
+func Compile(procedureName, source string) error {
+	f, _, err := starlark.SourceProgram(procedureName, source, predeclared.Has)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range f.Stmts {
+		if def, ok := stmt.(*syntax.DefStmt); ok && def.Name.Name == EntryPoint {
+			return nil
+		}
+	}
+
+	return errors.Errorf("the stored procedure does not define the function [%s]", EntryPoint)
+}
+
+// Execute executes the given stored procedure against the committed state of
+// the database and returns the reads, writes, and deletes it has performed.
+// Each read records the committed version of the key so that the operation
+// can be validated like the one of a data transaction. When the procedure
+// itself fails, an *ExecutionError is returned.
+func Execute(db worldstate.DB, dbName, procedureName string, source []byte, args []string) (*types.DBOperation, error) {
+	e := &execution{
+		db:      db,
+		dbName:  dbName,
+		read:    make(map[string]bool),
+		pending: make(map[string]*pendingUpdate),
+		exist:   make(map[string]bool),
+		acl:     make(map[string]*types.AccessControl),
+	}
+
+	tupleArgs := make(starlark.Tuple, len(args))
+	for i, a := range args {
+		tupleArgs[i] = starlark.String(a)
+	}
+
+	env := starlark.StringDict{
+		"get":    starlark.NewBuiltin("get", e.get),
+		"put":    starlark.NewBuiltin("put", e.put),
+		"delete": starlark.NewBuiltin("delete", e.delete),
+		"json":   json.Module,
+	}
+
+	thread := &starlark.Thread{
+		Name:  procedureName,
+		Print: func(_ *starlark.Thread, _ string) {},
+		Load: func(_ *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, errors.Errorf("loading module [%s] is not allowed in a stored procedure", module)
+		},
+	}
+	thread.SetMaxExecutionSteps(MaxExecutionSteps)
+
+	globals, err := starlark.ExecFile(thread, procedureName, source, env)
+	if err == nil {
+		main, ok := globals[EntryPoint].(starlark.Callable)
+		if !ok {
+			err = errors.Errorf("the stored procedure does not define the function [%s]", EntryPoint)
+		} else {
+			_, err = starlark.Call(thread, main, tupleArgs, nil)
+		}
+	}
+	if e.dbErr != nil {
+		return nil, errors.WithMessagef(e.dbErr, "error while executing the stored procedure [%s] on database [%s]", procedureName, dbName)
+	}
+	if err != nil {
+		return nil, &ExecutionError{
+			procedureName: procedureName,
+			err:           err,
+		}
+	}
+
+	return e.operation(), nil
+}
+
+type pendingUpdate struct {
+	value   []byte
+	deleted bool
+}
+
+type execution struct {
+	db     worldstate.DB
+	dbName string
+	// dbErr holds the first error returned by the database. It is reported
+	// separately as it is not a failure of the procedure.
+	dbErr error
+
+	reads   []*types.DataRead
+	read    map[string]bool
+	order   []string
+	pending map[string]*pendingUpdate
+	exist   map[string]bool
+	acl     map[string]*types.AccessControl
+}
+
+func (e *execution) get(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &key); err != nil {
+		return nil, err
+	}
+
+	if p, ok := e.pending[key]; ok {
+		if p.deleted {
+			return starlark.None, nil
+		}
+		return starlark.String(p.value), nil
+	}
+
+	value, exist, err := e.readCommitted(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return starlark.None, nil
+	}
+
+	return starlark.String(value), nil
+}
+
+func (e *execution) put(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key, value string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &key, &value); err != nil {
+		return nil, err
+	}
+
+	if err := e.loadCommitted(key); err != nil {
+		return nil, err
+	}
+	e.update(key, &pendingUpdate{value: []byte(value)})
+
+	return starlark.None, nil
+}
+
+func (e *execution) delete(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &key); err != nil {
+		return nil, err
+	}
+
+	if err := e.loadCommitted(key); err != nil {
+		return nil, err
+	}
+	e.update(key, &pendingUpdate{deleted: true})
+
+	return starlark.None, nil
+}
+
+func (e *execution) update(key string, p *pendingUpdate) {
+	if _, ok := e.pending[key]; !ok {
+		e.order = append(e.order, key)
+	}
+	e.pending[key] = p
+}
+
+// readCommitted reads the committed value of the key and records the read
+// along with the committed version, unless the key has been read already
+func (e *execution) readCommitted(key string) ([]byte, bool, error) {
+	value, metadata, err := e.db.Get(e.dbName, key)
+	if err != nil {
+		e.dbErr = err
+		return nil, false, err
+	}
+
+	if !e.read[key] {
+		e.read[key] = true
+		e.reads = append(e.reads, &types.DataRead{
+			Key:     key,
+			Version: metadata.GetVersion(),
+		})
+	}
+
+	exist := value != nil || metadata != nil
+	e.exist[key] = exist
+	e.acl[key] = metadata.GetAccessControl()
+
+	return value, exist, nil
+}
+
+// loadCommitted fetches the existence and the access control of the
+// committed key without recording a read
+func (e *execution) loadCommitted(key string) error {
+	if _, ok := e.exist[key]; ok {
+		return nil
+	}
+
+	value, metadata, err := e.db.Get(e.dbName, key)
+	if err != nil {
+		e.dbErr = err
+		return err
+	}
+
+	e.exist[key] = value != nil || metadata != nil
+	e.acl[key] = metadata.GetAccessControl()
+	return nil
+}
+
+func (e *execution) operation() *types.DBOperation {
+	op := &types.DBOperation{
+		DbName:    e.dbName,
+		DataReads: e.reads,
+	}
+
+	for _, key := range e.order {
+		p := e.pending[key]
+		switch {
+		case !p.deleted:
+			op.DataWrites = append(op.DataWrites, &types.DataWrite{
+				Key:   key,
+				Value: p.value,
+				Acl:   e.acl[key],
+			})
+		case e.exist[key]:
+			op.DataDeletes = append(op.DataDeletes, &types.DataDelete{
+				Key: key,
+			})
+		}
+		// a delete of a key which does not exist in the committed state is a no-op
+	}
+
+	return op
+}
@@ -0,0 +1,265 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package procedure
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	db      *leveldb.LevelDB
+	cleanup func()
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "procedure")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir: filepath.Join(dir, "leveldb"),
+			Logger:    logger,
+		},
+	)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			t.Errorf("error while removing directory %s, %v", dir, rmErr)
+		}
+		t.Fatalf("error while creating leveldb, %v", err)
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("error while closing the db instance, %v", err)
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("error while removing directory %s, %v", dir, err)
+		}
+	}
+
+	return &testEnv{
+		db:      db,
+		cleanup: cleanup,
+	}
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		source        string
+		expectedError string
+	}{
+		{
+			name:   "valid procedure",
+			source: "def main(a, b):\n  v = get(a)\n  put(a, json.encode({'old': v}))\n  delete(b)",
+		},
+		{
+			name:          "syntax error",
+			source:        "def f(:\n  pass",
+			expectedError: "transfer:1:8: got ':', want ')'",
+		},
+		{
+			name:          "undefined name",
+			source:        "def main():\n  put('a', now())",
+			expectedError: "transfer:2:12: undefined: now",
+		},
+		{
+			name:          "missing entry point",
+			source:        "def run():\n  pass",
+			expectedError: "the stored procedure does not define the function [main]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Compile("transfer", tt.source)
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestValidName(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, ValidName("transfer_v2"))
+	require.False(t, ValidName("2transfer"))
+	require.False(t, ValidName("trans~fer"))
+	require.False(t, ValidName(""))
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	acl := &types.AccessControl{
+		ReadWriteUsers: map[string]bool{
+			"alice": true,
+		},
+	}
+	setup := func(db worldstate.DB) {
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "alice",
+						Value: []byte("100"),
+						Metadata: &types.Metadata{
+							Version:       &types.Version{BlockNum: 2, TxNum: 1},
+							AccessControl: acl,
+						},
+					},
+					{
+						Key:   "bob",
+						Value: []byte("20"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{BlockNum: 2, TxNum: 2},
+						},
+					},
+				},
+			},
+		}, 2))
+	}
+
+	tests := []struct {
+		name          string
+		source        string
+		args          []string
+		expectedOp    *types.DBOperation
+		expectedError string
+	}{
+		{
+			name: "transfer between keys",
+			source: `
+def main(from_key, to_key, amount):
+    amount = int(amount)
+    from_balance = int(get(from_key))
+    if from_balance < amount:
+        fail("insufficient balance")
+    put(from_key, str(from_balance - amount))
+    put(to_key, str(int(get(to_key) or "0") + amount))
+`,
+			args: []string{"alice", "bob", "30"},
+			expectedOp: &types.DBOperation{
+				DbName: worldstate.DefaultDBName,
+				DataReads: []*types.DataRead{
+					{Key: "alice", Version: &types.Version{BlockNum: 2, TxNum: 1}},
+					{Key: "bob", Version: &types.Version{BlockNum: 2, TxNum: 2}},
+				},
+				DataWrites: []*types.DataWrite{
+					{Key: "alice", Value: []byte("70"), Acl: acl},
+					{Key: "bob", Value: []byte("50")},
+				},
+			},
+		},
+		{
+			name:   "reads see own writes and missing keys are recorded with nil version",
+			source: "def main():\n  put('carol', '1')\n  put('dave', get('carol') + str(get('eve')))",
+			expectedOp: &types.DBOperation{
+				DbName: worldstate.DefaultDBName,
+				DataReads: []*types.DataRead{
+					{Key: "eve"},
+				},
+				DataWrites: []*types.DataWrite{
+					{Key: "carol", Value: []byte("1")},
+					{Key: "dave", Value: []byte("1None")},
+				},
+			},
+		},
+		{
+			name:   "delete of existing and non-existing keys",
+			source: "def main():\n  delete('bob')\n  put('carol', '1')\n  delete('carol')\n  delete('eve')",
+			expectedOp: &types.DBOperation{
+				DbName: worldstate.DefaultDBName,
+				DataDeletes: []*types.DataDelete{
+					{Key: "bob"},
+				},
+			},
+		},
+		{
+			name:          "procedure fails",
+			source:        "def main():\n  fail('insufficient balance')",
+			expectedError: "execution of the stored procedure [transfer] failed: fail: insufficient balance",
+		},
+		{
+			name:          "wrong number of arguments",
+			source:        "def main(a):\n  pass",
+			args:          []string{"a", "b"},
+			expectedError: "execution of the stored procedure [transfer] failed: function main accepts 1 positional argument (2 given)",
+		},
+		{
+			name:          "wrong argument type",
+			source:        "def main():\n  put('a', 1)",
+			expectedError: "execution of the stored procedure [transfer] failed: put: for parameter 2: got int, want string",
+		},
+		{
+			name:          "execution steps exceeded",
+			source:        "def main():\n  for i in range(10000000):\n    pass",
+			expectedError: "execution of the stored procedure [transfer] failed: Starlark computation cancelled: too many steps",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newTestEnv(t)
+			defer env.cleanup()
+			setup(env.db)
+
+			op, err := Execute(env.db, worldstate.DefaultDBName, "transfer", []byte(tt.source), tt.args)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				require.IsType(t, &ExecutionError{}, err)
+				require.Nil(t, op)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOp.DbName, op.DbName)
+			require.Len(t, op.DataReads, len(tt.expectedOp.DataReads))
+			for i := range op.DataReads {
+				require.Equal(t, tt.expectedOp.DataReads[i].Key, op.DataReads[i].Key)
+				require.Equal(t, tt.expectedOp.DataReads[i].Version.String(), op.DataReads[i].Version.String())
+			}
+			require.Len(t, op.DataWrites, len(tt.expectedOp.DataWrites))
+			for i := range op.DataWrites {
+				require.Equal(t, tt.expectedOp.DataWrites[i].Key, op.DataWrites[i].Key)
+				require.Equal(t, tt.expectedOp.DataWrites[i].Value, op.DataWrites[i].Value)
+				require.Equal(t, tt.expectedOp.DataWrites[i].Acl.String(), op.DataWrites[i].Acl.String())
+			}
+			require.Len(t, op.DataDeletes, len(tt.expectedOp.DataDeletes))
+			for i := range op.DataDeletes {
+				require.Equal(t, tt.expectedOp.DataDeletes[i].Key, op.DataDeletes[i].Key)
+			}
+		})
+	}
+}
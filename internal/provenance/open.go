@@ -6,24 +6,19 @@ import (
 	"path/filepath"
 	"sync"
 
-	"github.com/hyperledger-labs/orion-server/internal/fileops"
-	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/cayleygraph/cayley"
 	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/graph/kv"
-	db "github.com/cayleygraph/cayley/graph/kv/leveldb"
-	"github.com/hidal-go/hidalgo/kv/flat/leveldb"
+	"github.com/hidal-go/hidalgo/kv/flat"
+	hleveldb "github.com/hidal-go/hidalgo/kv/flat/leveldb"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 )
 
-func init() {
-	kv.Register(leveldb.Name, kv.Registration{
-		NewFunc:      db.Open,
-		InitFunc:     db.Create,
-		IsPersistent: true,
-	})
-}
-
 var (
 	// underCreationFlag is used to mark that the provenancestore
 	// is being created. If a failure happens during the
@@ -37,6 +32,8 @@ var (
 // graph database
 type Store struct {
 	rootDir     string
+	inMemory    bool
+	db          *leveldb.DB
 	cayleyGraph *cayley.Handle
 	mutex       sync.RWMutex
 	logger      *logger.SugarLogger
@@ -46,11 +43,19 @@ type Store struct {
 // provenance store
 type Config struct {
 	StoreDir string
+	// InMemory keeps the graph in an in-memory leveldb instance
+	// instead of StoreDir, which is not used. The store is empty
+	// on every open.
+	InMemory bool
 	Logger   *logger.SugarLogger
 }
 
 // Open opens a provenance store to maintain historical values of each state
 func Open(conf *Config) (*Store, error) {
+	if conf.InMemory {
+		return openInMemoryProvenanceStore(conf)
+	}
+
 	exist, err := fileops.Exists(conf.StoreDir)
 	if err != nil {
 		return nil, err
@@ -97,11 +102,7 @@ func openNewProvenanceStore(c *Config) (*Store, error) {
 		return nil, err
 	}
 
-	if err := graph.InitQuadStore(leveldb.Name, c.StoreDir, nil); err != nil {
-		return nil, err
-	}
-
-	cayleyGraph, err := cayley.NewGraph(leveldb.Name, c.StoreDir, nil)
+	db, cayleyGraph, err := openGraph(c.StoreDir, true)
 	if err != nil {
 		return nil, err
 	}
@@ -112,24 +113,91 @@ func openNewProvenanceStore(c *Config) (*Store, error) {
 
 	return &Store{
 		rootDir:     c.StoreDir,
+		db:          db,
+		cayleyGraph: cayleyGraph,
+		logger:      c.Logger,
+	}, nil
+}
+
+func openInMemoryProvenanceStore(c *Config) (*Store, error) {
+	db, err := leveldb.Open(storage.NewMemStorage(), &opt.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while opening an in-memory leveldb instance")
+	}
+
+	cayleyGraph, err := newGraph(db, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		inMemory:    true,
+		db:          db,
 		cayleyGraph: cayleyGraph,
 		logger:      c.Logger,
 	}, nil
 }
 
 func openExistingLevelDBInstance(c *Config) (*Store, error) {
-	cayleyGraph, err := cayley.NewGraph(leveldb.Name, c.StoreDir, nil)
+	db, cayleyGraph, err := openGraph(c.StoreDir, false)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Store{
 		rootDir:     c.StoreDir,
+		db:          db,
 		cayleyGraph: cayleyGraph,
 		logger:      c.Logger,
 	}, nil
 }
 
+// openGraph opens the graph database on top of a leveldb instance in the given directory and
+// initializes the quad store if requested. The leveldb instance is returned too so that the
+// raw key-value pairs of the graph can be read and written, e.g., for a checkpoint.
+func openGraph(dir string, initialize bool) (*leveldb.DB, *cayley.Handle, error) {
+	db, err := leveldb.OpenFile(dir, &opt.Options{})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while opening leveldb instance [%s]", dir)
+	}
+
+	cayleyGraph, err := newGraph(db, initialize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, cayleyGraph, nil
+}
+
+// newGraph opens the graph database on top of the given leveldb instance, which is
+// closed on failure
+func newGraph(db *leveldb.DB, initialize bool) (*cayley.Handle, error) {
+	hdb := hleveldb.New(db)
+	hdb.SetWriteOptions(&opt.WriteOptions{Sync: true})
+	kvdb := flat.Upgrade(hdb)
+
+	if initialize {
+		if err := kv.Init(kvdb, nil); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	qs, err := kv.New(kvdb, nil)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	qw, err := graph.NewQuadWriter("single", qs, nil)
+	if err != nil {
+		qs.Close()
+		return nil, err
+	}
+
+	return &cayley.Handle{QuadStore: qs, QuadWriter: qw}, nil
+}
+
 // Close closes the database instance by closing all leveldb databases
 func (s *Store) Close() error {
 	s.mutex.Lock()
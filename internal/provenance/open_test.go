@@ -63,6 +63,21 @@ func TestOpenStore(t *testing.T) {
 		assertStore(t, storeDir, s)
 	})
 
+	t.Run("open an in-memory store", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Open(&Config{InMemory: true, Logger: logger})
+		require.NoError(t, err)
+		defer func() {
+			if err := s.Close(); err != nil {
+				t.Errorf("error wile closing the store: %s", err.Error())
+			}
+		}()
+
+		assertStore(t, "", s)
+		require.EqualError(t, s.Replace("/tmp"), "an in-memory provenance store cannot be replaced")
+	})
+
 	t.Run("open while partial store exist with an empty dir", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,41 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package provenance
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Replace replaces the provenance store with the one in the given directory, e.g., a
+// store rebuilt from the blocks. The directory is moved into the place of the store,
+// and the store is reopened.
+func (s *Store) Replace(dir string) error {
+	if s.inMemory {
+		return errors.New("an in-memory provenance store cannot be replaced")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.cayleyGraph.Close(); err != nil {
+		return errors.Wrap(err, "error closing provenance store")
+	}
+
+	if err := os.RemoveAll(s.rootDir); err != nil {
+		return errors.Wrapf(err, "error while removing the directory [%s]", s.rootDir)
+	}
+	if err := os.Rename(dir, s.rootDir); err != nil {
+		return errors.Wrapf(err, "error while moving the directory [%s] to [%s]", dir, s.rootDir)
+	}
+
+	db, cayleyGraph, err := openGraph(s.rootDir, false)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	s.cayleyGraph = cayleyGraph
+
+	return nil
+}
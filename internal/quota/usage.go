@@ -0,0 +1,419 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DBUsageNamespace is the namespace of keys in the QuotasDBName
+	// holding the usage of user databases
+	DBUsageNamespace = "db~"
+	// UserUsageNamespace is the namespace of keys in the QuotasDBName
+	// holding the usage of users
+	UserUsageNamespace = "user~"
+	// OwnerNamespace is the namespace of keys in the QuotasDBName
+	// holding the owner of each data key
+	OwnerNamespace = "owner~"
+)
+
+// DBUsageKey returns the key under which the usage of the given database is stored
+func DBUsageKey(dbName string) string {
+	return DBUsageNamespace + dbName
+}
+
+// UserUsageKey returns the key under which the usage of the given user is stored
+func UserUsageKey(userID string) string {
+	return UserUsageNamespace + userID
+}
+
+// OwnerKey returns the key under which the owner of the given data key is stored
+func OwnerKey(dbName, key string) string {
+	return OwnerNamespace + dbName + "~" + key
+}
+
+// OwnerKeyRange returns the start key (inclusive) and the end key (exclusive)
+// of the owner entries of all keys in the given database
+func OwnerKeyRange(dbName string) (string, string) {
+	// '\x7f' is the successor of the separator '~'
+	return OwnerNamespace + dbName + "~", OwnerNamespace + dbName + "\x7f"
+}
+
+// Usage holds a change in the number of keys and the total size
+// of values. A change can be negative.
+type Usage struct {
+	Keys            int64
+	TotalValueBytes int64
+}
+
+func (u *Usage) add(keys, valueBytes int64) {
+	u.Keys += keys
+	u.TotalValueBytes += valueBytes
+}
+
+// Delta holds the changes in usage of databases and users, along with the
+// changes in ownership of keys, caused by a set of data operations
+type Delta struct {
+	DBs   map[string]*Usage
+	Users map[string]*Usage
+	// Owners maps the owner key of a data key to its new owner.
+	// An empty owner denotes the removal of the owner entry.
+	Owners map[string]string
+}
+
+// NewDelta creates an empty Delta
+func NewDelta() *Delta {
+	return &Delta{
+		DBs:    make(map[string]*Usage),
+		Users:  make(map[string]*Usage),
+		Owners: make(map[string]string),
+	}
+}
+
+// Merge adds the given delta to the delta
+func (d *Delta) Merge(other *Delta) {
+	for dbName, u := range other.DBs {
+		if u == nil {
+			d.DBs[dbName] = nil
+			continue
+		}
+		d.db(dbName).add(u.Keys, u.TotalValueBytes)
+	}
+	for userID, u := range other.Users {
+		d.user(userID).add(u.Keys, u.TotalValueBytes)
+	}
+	for key, owner := range other.Owners {
+		d.Owners[key] = owner
+	}
+}
+
+func (d *Delta) db(dbName string) *Usage {
+	u, ok := d.DBs[dbName]
+	if !ok {
+		u = &Usage{}
+		d.DBs[dbName] = u
+	}
+	return u
+}
+
+func (d *Delta) user(userID string) *Usage {
+	u, ok := d.Users[userID]
+	if !ok {
+		u = &Usage{}
+		d.Users[userID] = u
+	}
+	return u
+}
+
+// ComputeDelta computes the change in usage caused by the given operations of a transaction
+// submitted by the given user against the committed state. Only the usage of the databases and
+// users having a quota on the number of keys or the total size of values is tracked. Hence, the
+// submitting user becomes the owner of each written key only if the user has such a quota, while
+// the previous owner, if any, is always released from the key. As a key can be modified only once
+// within a block, the deltas of the transactions in a block can be computed independently and
+// merged.
+func ComputeDelta(db worldstate.DB, userID string, ops []*types.DBOperation) (*Delta, error) {
+	d := NewDelta()
+
+	userQuota, err := GetUserQuota(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	userTracked := IsTracked(userQuota)
+
+	for _, op := range ops {
+		dbQuota, err := GetDBQuota(db, op.DbName)
+		if err != nil {
+			return nil, err
+		}
+		dbTracked := IsTracked(dbQuota)
+
+		for _, w := range op.DataWrites {
+			oldSize, oldOwner, exist, err := committedEntry(db, op.DbName, w.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			newSize := int64(len(w.Value))
+			if dbTracked {
+				if exist {
+					d.db(op.DbName).add(0, newSize-oldSize)
+				} else {
+					d.db(op.DbName).add(1, newSize)
+				}
+			}
+			if oldOwner != "" {
+				d.user(oldOwner).add(-1, -oldSize)
+				d.Owners[OwnerKey(op.DbName, w.Key)] = ""
+			}
+			if userTracked {
+				d.user(userID).add(1, newSize)
+				d.Owners[OwnerKey(op.DbName, w.Key)] = userID
+			}
+		}
+
+		for _, del := range op.DataDeletes {
+			oldSize, oldOwner, exist, err := committedEntry(db, op.DbName, del.Key)
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				continue
+			}
+
+			if dbTracked {
+				d.db(op.DbName).add(-1, -oldSize)
+			}
+			if oldOwner != "" {
+				d.user(oldOwner).add(-1, -oldSize)
+				d.Owners[OwnerKey(op.DbName, del.Key)] = ""
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// ComputeDBUsage computes the usage of the given database from its committed keys. It is used to
+// start tracking the usage of a database once a quota is defined on it.
+func ComputeDBUsage(db worldstate.DB, dbName string) (*Usage, error) {
+	itr, err := db.GetIterator(dbName, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	u := &Usage{}
+	for itr.Next() {
+		value := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), value); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the value of the key [%s] in the database [%s]", string(itr.Key()), dbName)
+		}
+		u.add(1, int64(len(value.Value)))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func committedEntry(db worldstate.DB, dbName, key string) (int64, string, bool, error) {
+	value, metadata, err := db.Get(dbName, key)
+	if err != nil {
+		return 0, "", false, err
+	}
+	if value == nil && metadata == nil {
+		return 0, "", false, nil
+	}
+
+	owner, _, err := db.Get(worldstate.QuotasDBName, OwnerKey(dbName, key))
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	return int64(len(value)), string(owner), true, nil
+}
+
+// ComputeDeltaForDeletedDB computes the change in usage caused by the deletion
+// of the given database. The owners of all keys in the database are released
+// from them.
+func ComputeDeltaForDeletedDB(db worldstate.DB, dbName string) (*Delta, error) {
+	d := NewDelta()
+
+	startKey, endKey := OwnerKeyRange(dbName)
+	itr, err := db.GetIterator(worldstate.QuotasDBName, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	for itr.Next() {
+		ownerKey := string(itr.Key())
+		owner := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), owner); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the owner entry [%s]", ownerKey)
+		}
+
+		key := ownerKey[len(startKey):]
+		value, _, err := db.Get(dbName, key)
+		if err != nil {
+			return nil, err
+		}
+
+		d.user(string(owner.Value)).add(-1, -int64(len(value)))
+		d.Owners[ownerKey] = ""
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+
+	// the usage entry of the database is removed along with the database
+	d.DBs[dbName] = nil
+	return d, nil
+}
+
+// ConstructDBEntries constructs the entries of the QuotasDBName which apply the
+// given delta on the committed usage
+func ConstructDBEntries(db worldstate.DB, d *Delta, version *types.Version) (*worldstate.DBUpdates, error) {
+	updates := &worldstate.DBUpdates{}
+
+	addUsage := func(usageKey string, u *Usage) error {
+		if u == nil {
+			exist, err := db.Has(worldstate.QuotasDBName, usageKey)
+			if err != nil {
+				return err
+			}
+			if exist {
+				updates.Deletes = append(updates.Deletes, usageKey)
+			}
+			return nil
+		}
+
+		if u.Keys == 0 && u.TotalValueBytes == 0 {
+			return nil
+		}
+
+		usage, err := GetUsage(db, usageKey)
+		if err != nil {
+			return err
+		}
+		usage.Keys = applyChange(usage.Keys, u.Keys)
+		usage.TotalValueBytes = applyChange(usage.TotalValueBytes, u.TotalValueBytes)
+
+		value, err := proto.Marshal(usage)
+		if err != nil {
+			return errors.Wrapf(err, "error while marshaling the usage [%s]", usageKey)
+		}
+		updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+			Key:   usageKey,
+			Value: value,
+			Metadata: &types.Metadata{
+				Version: version,
+			},
+		})
+		return nil
+	}
+
+	// the entries are constructed in a deterministic order
+	for _, dbName := range sortedKeys(d.DBs) {
+		if err := addUsage(DBUsageKey(dbName), d.DBs[dbName]); err != nil {
+			return nil, err
+		}
+	}
+	for _, userID := range sortedKeys(d.Users) {
+		if err := addUsage(UserUsageKey(userID), d.Users[userID]); err != nil {
+			return nil, err
+		}
+	}
+
+	var ownerKeys []string
+	for key := range d.Owners {
+		ownerKeys = append(ownerKeys, key)
+	}
+	sort.Strings(ownerKeys)
+
+	for _, key := range ownerKeys {
+		owner := d.Owners[key]
+		if owner != "" {
+			updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+				Key:   key,
+				Value: []byte(owner),
+				Metadata: &types.Metadata{
+					Version: version,
+				},
+			})
+			continue
+		}
+
+		exist, err := db.Has(worldstate.QuotasDBName, key)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			updates.Deletes = append(updates.Deletes, key)
+		}
+	}
+
+	return updates, nil
+}
+
+// GetUsage returns the committed usage stored under the given usage key
+func GetUsage(db worldstate.DB, usageKey string) (*types.QuotaUsage, error) {
+	value, _, err := db.Get(worldstate.QuotasDBName, usageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &types.QuotaUsage{}
+	if err := proto.Unmarshal(value, usage); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the usage [%s]", usageKey)
+	}
+	return usage, nil
+}
+
+// GetDBQuota returns the quota of the given database. When the
+// database has no quota, an empty quota is returned.
+func GetDBQuota(db worldstate.DB, dbName string) (*types.Quota, error) {
+	value, _, err := db.Get(worldstate.DatabasesDBName, worldstate.QuotaKey(dbName))
+	if err != nil {
+		return nil, err
+	}
+
+	q := &types.Quota{}
+	if err := proto.Unmarshal(value, q); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the quota of the database [%s]", dbName)
+	}
+	return q, nil
+}
+
+// GetUserQuota returns the quota of the given user. When the user
+// does not exist or has no quota, an empty quota is returned.
+func GetUserQuota(db worldstate.DB, userID string) (*types.Quota, error) {
+	user, _, err := identity.NewQuerier(db).GetUser(userID)
+	switch err.(type) {
+	case nil:
+		return user.GetPrivilege().GetQuota(), nil
+	case *identity.NotFoundErr:
+		return &types.Quota{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// IsTracked returns true if the usage must be tracked to enforce the quota, i.e.,
+// the quota limits the number of keys or the total size of values
+func IsTracked(q *types.Quota) bool {
+	return q.GetMaxKeys() > 0 || q.GetMaxTotalValueBytes() > 0
+}
+
+// IsUnlimited returns true if the quota does not limit any resource
+func IsUnlimited(q *types.Quota) bool {
+	return q.GetMaxKeys() == 0 && q.GetMaxTotalValueBytes() == 0 && q.GetMaxValueSize() == 0
+}
+
+func applyChange(value uint64, change int64) uint64 {
+	if change < 0 && uint64(-change) > value {
+		// keys written before the usage tracking was introduced are
+		// not accounted and hence, the usage cannot become negative
+		return 0
+	}
+	return uint64(int64(value) + change)
+}
+
+func sortedKeys(m map[string]*Usage) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,260 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	db      *leveldb.LevelDB
+	cleanup func()
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "quota")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir: filepath.Join(dir, "leveldb"),
+			Logger:    logger,
+		},
+	)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			t.Errorf("error while removing directory %s, %v", dir, rmErr)
+		}
+		t.Fatalf("error while creating leveldb, %v", err)
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("error while closing the db instance, %v", err)
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("error while removing directory %s, %v", dir, err)
+		}
+	}
+
+	return &testEnv{
+		db:      db,
+		cleanup: cleanup,
+	}
+}
+
+func TestComputeDelta(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	bob, err := proto.Marshal(&types.User{
+		Id: "bob",
+		Privilege: &types.Privilege{
+			Quota: &types.Quota{MaxKeys: 10},
+		},
+	})
+	require.NoError(t, err)
+	carol, err := proto.Marshal(&types.User{
+		Id: "carol",
+		Privilege: &types.Privilege{
+			Quota: &types.Quota{MaxValueSize: 10},
+		},
+	})
+	require.NoError(t, err)
+	dbQuota, err := proto.Marshal(&types.Quota{MaxTotalValueBytes: 100})
+	require.NoError(t, err)
+
+	// key1 is owned by alice while key2 has been written
+	// by a user without a quota and hence, has no owner
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: string(identity.UserNamespace) + "bob", Value: bob},
+				{Key: string(identity.UserNamespace) + "carol", Value: carol},
+			},
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: worldstate.QuotaKey(worldstate.DefaultDBName), Value: dbQuota},
+			},
+		},
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("abc"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+				{Key: "key2", Value: []byte("defg"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+			},
+		},
+		worldstate.QuotasDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: OwnerKey(worldstate.DefaultDBName, "key1"), Value: []byte("alice")},
+			},
+		},
+	}, 1))
+
+	delta, err := ComputeDelta(env.db, "bob", []*types.DBOperation{
+		{
+			DbName: worldstate.DefaultDBName,
+			DataWrites: []*types.DataWrite{
+				{Key: "key1", Value: []byte("a")},
+				{Key: "key3", Value: []byte("hello")},
+			},
+			DataDeletes: []*types.DataDelete{
+				{Key: "key2"},
+				{Key: "key4"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Delta{
+		DBs: map[string]*Usage{
+			worldstate.DefaultDBName: {Keys: 0, TotalValueBytes: -2 + 5 - 4},
+		},
+		Users: map[string]*Usage{
+			"alice": {Keys: -1, TotalValueBytes: -3},
+			"bob":   {Keys: 2, TotalValueBytes: 6},
+		},
+		Owners: map[string]string{
+			OwnerKey(worldstate.DefaultDBName, "key1"): "bob",
+			OwnerKey(worldstate.DefaultDBName, "key3"): "bob",
+		},
+	}, delta)
+
+	// neither the usage of carol, whose quota does not limit the number of keys or the total
+	// size of values, nor the ownership of the written keys is tracked
+	delta, err = ComputeDelta(env.db, "carol", []*types.DBOperation{
+		{
+			DbName: worldstate.DefaultDBName,
+			DataWrites: []*types.DataWrite{
+				{Key: "key1", Value: []byte("a")},
+				{Key: "key3", Value: []byte("hello")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Delta{
+		DBs: map[string]*Usage{
+			worldstate.DefaultDBName: {Keys: 1, TotalValueBytes: -2 + 5},
+		},
+		Users: map[string]*Usage{
+			"alice": {Keys: -1, TotalValueBytes: -3},
+		},
+		Owners: map[string]string{
+			OwnerKey(worldstate.DefaultDBName, "key1"): "",
+		},
+	}, delta)
+
+	// the usage of a database without a quota is not tracked
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{{Key: "db1"}},
+		},
+	}, 2))
+	delta, err = ComputeDelta(env.db, "bob", []*types.DBOperation{
+		{
+			DbName:     "db1",
+			DataWrites: []*types.DataWrite{{Key: "key1", Value: []byte("abc")}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Delta{
+		DBs: map[string]*Usage{},
+		Users: map[string]*Usage{
+			"bob": {Keys: 1, TotalValueBytes: 3},
+		},
+		Owners: map[string]string{
+			OwnerKey("db1", "key1"): "bob",
+		},
+	}, delta)
+}
+
+func TestComputeDBUsage(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("abc"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+				{Key: "key2", Value: []byte("defg"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+			},
+		},
+	}, 1))
+
+	u, err := ComputeDBUsage(env.db, worldstate.DefaultDBName)
+	require.NoError(t, err)
+	require.Equal(t, &Usage{Keys: 2, TotalValueBytes: 7}, u)
+}
+
+func TestConstructDBEntries(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	usage, err := proto.Marshal(&types.QuotaUsage{Keys: 1, TotalValueBytes: 3})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.QuotasDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: DBUsageKey("db1"), Value: usage},
+				{Key: UserUsageKey("alice"), Value: usage},
+				{Key: OwnerKey("db1", "key1"), Value: []byte("alice")},
+			},
+		},
+	}, 1))
+
+	d := NewDelta()
+	d.Merge(&Delta{
+		DBs:    map[string]*Usage{"db1": nil, "db2": {Keys: 1, TotalValueBytes: 2}},
+		Users:  map[string]*Usage{"alice": {Keys: -2, TotalValueBytes: -1}, "bob": {}},
+		Owners: map[string]string{OwnerKey("db1", "key1"): "", OwnerKey("db1", "key2"): "", OwnerKey("db2", "key1"): "bob"},
+	})
+
+	version := &types.Version{BlockNum: 2, TxNum: 1}
+	updates, err := ConstructDBEntries(env.db, d, version)
+	require.NoError(t, err)
+
+	// the usage of alice cannot become negative
+	aliceUsage, err := proto.Marshal(&types.QuotaUsage{Keys: 0, TotalValueBytes: 2})
+	require.NoError(t, err)
+	db2Usage, err := proto.Marshal(&types.QuotaUsage{Keys: 1, TotalValueBytes: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, &worldstate.DBUpdates{
+		Writes: []*worldstate.KVWithMetadata{
+			{Key: DBUsageKey("db2"), Value: db2Usage, Metadata: &types.Metadata{Version: version}},
+			{Key: UserUsageKey("alice"), Value: aliceUsage, Metadata: &types.Metadata{Version: version}},
+			{Key: OwnerKey("db2", "key1"), Value: []byte("bob"), Metadata: &types.Metadata{Version: version}},
+		},
+		Deletes: []string{
+			DBUsageKey("db1"),
+			OwnerKey("db1", "key1"),
+		},
+	}, updates)
+}
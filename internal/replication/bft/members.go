@@ -0,0 +1,203 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bft
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft/bftpb"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// members are the consensus members of a cluster config, with the verifiers of their node certificates.
+// A cluster of n members tolerates f = (n-1)/3 Byzantine members, and a quorum is n-f members, so that any two quorums
+// intersect in at least one correct member.
+type members struct {
+	raftIDs   []uint64 // sorted, the primary of view v is raftIDs[v % n]
+	nodeIDs   map[uint64]string
+	verifiers map[uint64]*crypto.Verifier
+}
+
+func newMembers(clusterConfig *types.ClusterConfig) (*members, error) {
+	certs := make(map[string][]byte)
+	for _, n := range clusterConfig.GetNodes() {
+		certs[n.Id] = n.Certificate
+	}
+
+	m := &members{
+		nodeIDs:   make(map[uint64]string),
+		verifiers: make(map[uint64]*crypto.Verifier),
+	}
+	for _, peer := range clusterConfig.GetConsensusConfig().GetMembers() {
+		verifier, err := crypto.NewVerifier(certs[peer.NodeId])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create a verifier for member [%s]", peer.NodeId)
+		}
+		m.raftIDs = append(m.raftIDs, peer.RaftId)
+		m.nodeIDs[peer.RaftId] = peer.NodeId
+		m.verifiers[peer.RaftId] = verifier
+	}
+	if len(m.raftIDs) == 0 {
+		return nil, errors.New("no consensus members in cluster config")
+	}
+	sort.Slice(m.raftIDs, func(i, j int) bool { return m.raftIDs[i] < m.raftIDs[j] })
+
+	return m, nil
+}
+
+func (m *members) faulty() int {
+	return (len(m.raftIDs) - 1) / 3
+}
+
+func (m *members) quorum() int {
+	return len(m.raftIDs) - m.faulty()
+}
+
+func (m *members) primary(view uint64) uint64 {
+	return m.raftIDs[view%uint64(len(m.raftIDs))]
+}
+
+func (m *members) isMember(raftID uint64) bool {
+	_, ok := m.nodeIDs[raftID]
+	return ok
+}
+
+func (m *members) raftID(nodeID string) (uint64, bool) {
+	for raftID, id := range m.nodeIDs {
+		if id == nodeID {
+			return raftID, true
+		}
+	}
+	return 0, false
+}
+
+// open verifies that the signed message was signed by the member it claims to come from, and returns the message.
+func (m *members) open(signed *bftpb.SignedMessage) (*bftpb.Message, error) {
+	verifier, ok := m.verifiers[signed.GetFrom()]
+	if !ok {
+		return nil, errors.Errorf("message from unknown member: %d", signed.GetFrom())
+	}
+	if err := verifier.Verify(signed.Message, signed.Signature); err != nil {
+		return nil, errors.Wrapf(err, "invalid signature on message from member: %d", signed.From)
+	}
+
+	msg := &bftpb.Message{}
+	if err := proto.Unmarshal(signed.Message, msg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal message from member: %d", signed.From)
+	}
+	return msg, nil
+}
+
+// BlockDigest returns the digest the BFT members vote on: the hash of the deterministic marshaling of the base header
+// and the payload of the block. Both are fixed when the block is proposed, and are not changed by the commit, so the
+// digest of a committed block is the digest of the proposed block.
+func BlockDigest(block *types.Block) ([]byte, error) {
+	proposed := &types.Block{
+		Header:  &types.BlockHeader{BaseHeader: block.GetHeader().GetBaseHeader()},
+		Payload: block.GetPayload(),
+	}
+
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(proposed); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal block")
+	}
+	digest := sha256.Sum256(buf.Bytes())
+	return digest[:], nil
+}
+
+// CommitSigningBytes returns the bytes a BFT member signs when it commits the block with the given number and digest
+// in a view. These signatures are kept in the `ConsensusMetadata.BftSignatures` of the committed block.
+func CommitSigningBytes(view, blockNumber uint64, digest []byte) []byte {
+	const prefix = "orion-bft-commit"
+
+	b := make([]byte, len(prefix)+16, len(prefix)+16+len(digest))
+	copy(b, prefix)
+	binary.BigEndian.PutUint64(b[len(prefix):], view)
+	binary.BigEndian.PutUint64(b[len(prefix)+8:], blockNumber)
+	return append(b, digest...)
+}
+
+// VerifyCommitSignatures verifies that the BFT signatures in the consensus metadata of the block were made on its
+// commit by a quorum of the members of the cluster config.
+func VerifyCommitSignatures(block *types.Block, clusterConfig *types.ClusterConfig) error {
+	m, err := newMembers(clusterConfig)
+	if err != nil {
+		return err
+	}
+	return m.verifyCommitSignatures(block)
+}
+
+func (m *members) verifyCommitSignatures(block *types.Block) error {
+	cert, err := commitCertificate(block)
+	if err != nil {
+		return err
+	}
+	return m.verifyCommitCertificate(cert)
+}
+
+// commitCertificate returns the commit certificate of a committed block, made of the BFT signatures in its consensus
+// metadata.
+func commitCertificate(block *types.Block) (*bftpb.CommitCertificate, error) {
+	digest, err := BlockDigest(block)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := &bftpb.CommitCertificate{
+		View:     block.GetConsensusMetadata().GetBftView(),
+		Sequence: block.GetHeader().GetBaseHeader().GetNumber(),
+		Digest:   digest,
+	}
+	for _, s := range block.GetConsensusMetadata().GetBftSignatures() {
+		cert.Signatures = append(cert.Signatures, &bftpb.CommitSignature{NodeId: s.NodeId, Signature: s.Signature})
+	}
+	return cert, nil
+}
+
+// verifyCommitCertificate verifies that the signatures of the certificate were made on the commit of its block by a
+// quorum of the members.
+func (m *members) verifyCommitCertificate(cert *bftpb.CommitCertificate) error {
+	blockNumber := cert.GetSequence()
+	signed := CommitSigningBytes(cert.GetView(), blockNumber, cert.GetDigest())
+	signers := make(map[uint64]bool)
+	for _, s := range cert.GetSignatures() {
+		raftID, ok := m.raftID(s.NodeId)
+		if !ok {
+			return errors.Errorf("block [%d] is signed by [%s], which is not a consensus member", blockNumber, s.NodeId)
+		}
+		if signers[raftID] {
+			return errors.Errorf("block [%d] is signed more than once by [%s]", blockNumber, s.NodeId)
+		}
+		if err := m.verifiers[raftID].Verify(signed, s.Signature); err != nil {
+			return errors.Wrapf(err, "block [%d] has an invalid signature by [%s]", blockNumber, s.NodeId)
+		}
+		signers[raftID] = true
+	}
+
+	if len(signers) < m.quorum() {
+		return errors.Errorf("block [%d] is signed by [%d] members, a quorum is [%d]", blockNumber, len(signers), m.quorum())
+	}
+	return nil
+}
+
+// verifyLastCommitted verifies that a member that claims to have committed a block proves it with the commit
+// certificate of the block. The genesis block is not ordered by the members, and needs no certificate.
+func (m *members) verifyLastCommitted(lastCommitted uint64, cert *bftpb.CommitCertificate) error {
+	if lastCommitted <= 1 {
+		return nil
+	}
+	if cert == nil {
+		return errors.Errorf("last committed block [%d] has no commit certificate", lastCommitted)
+	}
+	if cert.Sequence != lastCommitted {
+		return errors.Errorf("commit certificate of block [%d] does not match the last committed block [%d]", cert.Sequence, lastCommitted)
+	}
+	return m.verifyCommitCertificate(cert)
+}
@@ -0,0 +1,301 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft/bftpb"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testMembersConfig(t *testing.T, n int) (*types.ClusterConfig, map[string]crypto.Signer) {
+	var names []string
+	for i := 1; i <= n; i++ {
+		names = append(names, fmt.Sprintf("node%d", i))
+	}
+	cryptoDir := testutils.GenerateTestCrypto(t, names)
+
+	clusterConfig := &types.ClusterConfig{ConsensusConfig: &types.ConsensusConfig{Algorithm: "bft"}}
+	signers := make(map[string]crypto.Signer)
+	for i, name := range names {
+		cert, signer := testutils.LoadTestCrypto(t, cryptoDir, name)
+		signers[name] = signer
+		clusterConfig.Nodes = append(clusterConfig.Nodes, &types.NodeConfig{Id: name, Certificate: cert.Raw})
+		// Raft IDs in reverse order, to check that the primary is picked by Raft ID
+		clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members,
+			&types.PeerConfig{NodeId: name, RaftId: uint64(n - i)})
+	}
+	return clusterConfig, signers
+}
+
+func TestMembers(t *testing.T) {
+	for _, tt := range []struct {
+		n      int
+		faulty int
+		quorum int
+	}{
+		{n: 1, faulty: 0, quorum: 1},
+		{n: 2, faulty: 0, quorum: 2},
+		{n: 3, faulty: 0, quorum: 3},
+		{n: 4, faulty: 1, quorum: 3},
+		{n: 5, faulty: 1, quorum: 4},
+		{n: 7, faulty: 2, quorum: 5},
+	} {
+		t.Run(fmt.Sprintf("%d members", tt.n), func(t *testing.T) {
+			clusterConfig, _ := testMembersConfig(t, tt.n)
+			m, err := newMembers(clusterConfig)
+			require.NoError(t, err)
+			require.Equal(t, tt.faulty, m.faulty())
+			require.Equal(t, tt.quorum, m.quorum())
+
+			for view := uint64(0); view < uint64(2*tt.n); view++ {
+				require.Equal(t, view%uint64(tt.n)+1, m.primary(view))
+			}
+			raftID, ok := m.raftID("node1")
+			require.True(t, ok)
+			require.Equal(t, uint64(tt.n), raftID)
+			require.True(t, m.isMember(1))
+			require.False(t, m.isMember(uint64(tt.n+1)))
+		})
+	}
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		clusterConfig, _ := testMembersConfig(t, 1)
+		clusterConfig.Nodes[0].Certificate = []byte("bogus-cert")
+		_, err := newMembers(clusterConfig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create a verifier for member [node1]")
+	})
+}
+
+func TestMembers_Open(t *testing.T) {
+	clusterConfig, signers := testMembersConfig(t, 4)
+	m, err := newMembers(clusterConfig)
+	require.NoError(t, err)
+
+	msgBytes, err := proto.Marshal(&bftpb.Message{Type: &bftpb.Message_Heartbeat{Heartbeat: &bftpb.Heartbeat{View: 3, LastCommitted: 7}}})
+	require.NoError(t, err)
+	signature, err := signers["node1"].Sign(msgBytes)
+	require.NoError(t, err)
+
+	msg, err := m.open(&bftpb.SignedMessage{From: 4, Message: msgBytes, Signature: signature})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), msg.GetHeartbeat().GetView())
+	require.Equal(t, uint64(7), msg.GetHeartbeat().GetLastCommitted())
+
+	_, err = m.open(&bftpb.SignedMessage{From: 3, Message: msgBytes, Signature: signature})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid signature on message from member: 3")
+
+	_, err = m.open(&bftpb.SignedMessage{From: 5, Message: msgBytes, Signature: signature})
+	require.EqualError(t, err, "message from unknown member: 5")
+}
+
+func TestBlockDigest(t *testing.T) {
+	block := &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 5, PreviousBaseHeaderHash: []byte("prev")}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{
+			Envelopes: []*types.DataTxEnvelope{{
+				Payload:    &types.DataTx{TxId: "tx1"},
+				Signatures: map[string][]byte{"alice": []byte("sig-a"), "bob": []byte("sig-b"), "charlie": []byte("sig-c")},
+			}},
+		}},
+	}
+	digest, err := BlockDigest(block)
+	require.NoError(t, err)
+	require.Len(t, digest, 32)
+
+	// the fields set by the commit are not part of the digest
+	committed := proto.Clone(block).(*types.Block)
+	committed.Header.TxMerkelTreeRootHash = []byte("root")
+	committed.Header.SkipchainHashes = [][]byte{[]byte("skip")}
+	committed.Header.ValidationInfo = []*types.ValidationInfo{{Flag: types.Flag_VALID}}
+	committed.ConsensusMetadata = &types.ConsensusMetadata{BftView: 2}
+	d, err := BlockDigest(committed)
+	require.NoError(t, err)
+	require.Equal(t, digest, d)
+
+	for i := 0; i < 10; i++ {
+		d, err := BlockDigest(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+		require.Equal(t, digest, d)
+	}
+
+	changed := proto.Clone(block).(*types.Block)
+	changed.GetDataTxEnvelopes().Envelopes[0].Payload.TxId = "tx2"
+	d, err = BlockDigest(changed)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, d)
+
+	changed = proto.Clone(block).(*types.Block)
+	changed.Header.BaseHeader.Number = 6
+	d, err = BlockDigest(changed)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, d)
+}
+
+func TestVerifyCommitSignatures(t *testing.T) {
+	clusterConfig, signers := testMembersConfig(t, 4)
+	block := &types.Block{
+		Header:  &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 5}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{}},
+	}
+	digest, err := BlockDigest(block)
+	require.NoError(t, err)
+
+	sign := func(nodeID string, view uint64) *types.BFTSignature {
+		signature, err := signers[nodeID].Sign(CommitSigningBytes(view, 5, digest))
+		require.NoError(t, err)
+		return &types.BFTSignature{NodeId: nodeID, Signature: signature}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		signatures  []*types.BFTSignature
+		expectedErr string
+	}{
+		{
+			name:       "valid: quorum",
+			signatures: []*types.BFTSignature{sign("node1", 1), sign("node2", 1), sign("node4", 1)},
+		},
+		{
+			name:       "valid: all",
+			signatures: []*types.BFTSignature{sign("node1", 1), sign("node2", 1), sign("node3", 1), sign("node4", 1)},
+		},
+		{
+			name:        "invalid: no quorum",
+			signatures:  []*types.BFTSignature{sign("node1", 1), sign("node2", 1)},
+			expectedErr: "block [5] is signed by [2] members, a quorum is [3]",
+		},
+		{
+			name:        "invalid: signed twice",
+			signatures:  []*types.BFTSignature{sign("node1", 1), sign("node2", 1), sign("node2", 1)},
+			expectedErr: "block [5] is signed more than once by [node2]",
+		},
+		{
+			name:        "invalid: not a member",
+			signatures:  []*types.BFTSignature{sign("node1", 1), sign("node2", 1), {NodeId: "node5", Signature: []byte("sig")}},
+			expectedErr: "block [5] is signed by [node5], which is not a consensus member",
+		},
+		{
+			name:        "invalid: signed in another view",
+			signatures:  []*types.BFTSignature{sign("node1", 1), sign("node2", 1), sign("node3", 0)},
+			expectedErr: "block [5] has an invalid signature by [node3]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b := proto.Clone(block).(*types.Block)
+			b.ConsensusMetadata = &types.ConsensusMetadata{BftView: 1, BftSignatures: tt.signatures}
+			err := VerifyCommitSignatures(b, clusterConfig)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSelectPrepared(t *testing.T) {
+	prepared := func(view, sequence uint64) *bftpb.SignedMessage {
+		msgBytes, err := proto.Marshal(&bftpb.Message{Type: &bftpb.Message_PrePrepare{PrePrepare: &bftpb.PrePrepare{
+			View: view, Sequence: sequence, Block: []byte(fmt.Sprintf("block-%d-%d", view, sequence)),
+		}}})
+		require.NoError(t, err)
+		return &bftpb.SignedMessage{Message: msgBytes}
+	}
+
+	lastCommitted, pp := selectPrepared([]*bftpb.ViewChange{{LastCommitted: 4}, {LastCommitted: 5}, {LastCommitted: 3}})
+	require.Equal(t, uint64(5), lastCommitted)
+	require.Nil(t, pp)
+
+	lastCommitted, pp = selectPrepared([]*bftpb.ViewChange{
+		{LastCommitted: 5, Prepared: prepared(1, 6)},
+		{LastCommitted: 5, Prepared: prepared(3, 6)},
+		{LastCommitted: 4, Prepared: prepared(4, 5)},
+		{LastCommitted: 5},
+	})
+	require.Equal(t, uint64(5), lastCommitted)
+	require.Equal(t, uint64(3), pp.View)
+	require.Equal(t, uint64(6), pp.Sequence)
+	require.Equal(t, []byte("block-3-6"), pp.Block)
+
+	// a block prepared after a block that was since committed is not selected
+	lastCommitted, pp = selectPrepared([]*bftpb.ViewChange{
+		{LastCommitted: 5, Prepared: prepared(1, 6)},
+		{LastCommitted: 6},
+	})
+	require.Equal(t, uint64(6), lastCommitted)
+	require.Nil(t, pp)
+}
+
+func TestVerifyViewChange_LastCommitted(t *testing.T) {
+	clusterConfig, signers := testMembersConfig(t, 4)
+	m, err := newMembers(clusterConfig)
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header:  &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 5}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{}},
+	}
+	digest, err := BlockDigest(block)
+	require.NoError(t, err)
+	block.ConsensusMetadata = &types.ConsensusMetadata{BftView: 1}
+	for _, nodeID := range []string{"node1", "node2", "node3"} {
+		signature, err := signers[nodeID].Sign(CommitSigningBytes(1, 5, digest))
+		require.NoError(t, err)
+		block.ConsensusMetadata.BftSignatures = append(block.ConsensusMetadata.BftSignatures,
+			&types.BFTSignature{NodeId: nodeID, Signature: signature})
+	}
+	cert, err := commitCertificate(block)
+	require.NoError(t, err)
+	noQuorum := proto.Clone(cert).(*bftpb.CommitCertificate)
+	noQuorum.Signatures = noQuorum.Signatures[:2]
+
+	for _, tt := range []struct {
+		name        string
+		viewChange  *bftpb.ViewChange
+		expectedErr string
+	}{
+		{
+			name:       "valid: genesis block",
+			viewChange: &bftpb.ViewChange{NewView: 2, LastCommitted: 1},
+		},
+		{
+			name:       "valid: certified block",
+			viewChange: &bftpb.ViewChange{NewView: 2, LastCommitted: 5, LastCommittedCertificate: cert},
+		},
+		{
+			name:        "invalid: no certificate",
+			viewChange:  &bftpb.ViewChange{NewView: 2, LastCommitted: 5},
+			expectedErr: "last committed block [5] has no commit certificate",
+		},
+		{
+			name:        "invalid: certificate of an earlier block",
+			viewChange:  &bftpb.ViewChange{NewView: 2, LastCommitted: 100, LastCommittedCertificate: cert},
+			expectedErr: "commit certificate of block [5] does not match the last committed block [100]",
+		},
+		{
+			name:        "invalid: no quorum",
+			viewChange:  &bftpb.ViewChange{NewView: 2, LastCommitted: 5, LastCommittedCertificate: noQuorum},
+			expectedErr: "block [5] is signed by [2] members, a quorum is [3]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.verifyViewChange(tt.viewChange)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
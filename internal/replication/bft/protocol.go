@@ -0,0 +1,558 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bft
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft/bftpb"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// handleMessage verifies the signature of a message, and dispatches it. Messages that are not valid in the current
+// state are dropped; the protocol does not rely on any single message being delivered.
+func (r *Replicator) handleMessage(signed *bftpb.SignedMessage) {
+	_, _, m := r.getView()
+	msg, err := m.open(signed)
+	if err != nil {
+		r.lg.Warnf("Dropping BFT message: %s", err)
+		return
+	}
+
+	switch t := msg.Type.(type) {
+	case *bftpb.Message_PrePrepare:
+		r.handlePrePrepare(signed, t.PrePrepare)
+	case *bftpb.Message_Prepare:
+		r.handleVote(signed, t.Prepare, false)
+	case *bftpb.Message_Commit:
+		r.handleVote(signed, t.Commit, true)
+	case *bftpb.Message_ViewChange:
+		r.handleViewChange(signed, t.ViewChange)
+	case *bftpb.Message_NewView:
+		r.handleNewView(signed, t.NewView)
+	case *bftpb.Message_Heartbeat:
+		r.handleHeartbeat(signed, t.Heartbeat)
+	default:
+		r.lg.Warnf("Dropping BFT message of unknown type from [%d]", signed.From)
+	}
+}
+
+func (r *Replicator) handlePrePrepare(signed *bftpb.SignedMessage, pp *bftpb.PrePrepare) {
+	view, active, m := r.getView()
+	if pp.View > view && signed.From == m.primary(pp.View) {
+		r.requestNewView(signed.From)
+		return
+	}
+	if !active || pp.View != view || signed.From != m.primary(view) {
+		r.lg.Debugf("Dropping pre-prepare from [%d] for view [%d], current view [%d], active: %v", signed.From, pp.View, view, active)
+		return
+	}
+	r.lastPrimaryContact = time.Now()
+
+	if pp.Sequence > r.getLastCommittedBlockNumber()+1 {
+		// a pre-prepare does not prove that the blocks before it were committed; the member catches up on the
+		// heartbeats of the primary, which do, and the pre-prepare is handled again once the blocks are committed
+		r.aheadPrePrepare = signed
+		return
+	}
+	if pp.Sequence != r.getLastCommittedBlockNumber()+1 {
+		return
+	}
+
+	if s := r.slot; s != nil && s.view == pp.View && s.sequence == pp.Sequence {
+		if !bytes.Equal(s.prePrepare.Message, signed.Message) {
+			r.lg.Warnf("Primary [%d] proposed two different blocks for sequence [%d] in view [%d]", signed.From, pp.Sequence, pp.View)
+			return
+		}
+		// the primary sends the pre-prepare again when the block does not commit, in case votes were lost
+		for _, vote := range s.ownVotes {
+			r.broadcast(vote)
+		}
+		return
+	}
+
+	block := &types.Block{}
+	if err := proto.Unmarshal(pp.Block, block); err != nil {
+		r.lg.Warnf("Dropping pre-prepare from [%d], failed to unmarshal block: %s", signed.From, err)
+		return
+	}
+	if err := r.validateProposal(block); err != nil {
+		r.lg.Warnf("Dropping pre-prepare from [%d]: %s", signed.From, err)
+		return
+	}
+	digest, err := BlockDigest(block)
+	if err != nil {
+		r.lg.Warnf("Dropping pre-prepare from [%d]: %s", signed.From, err)
+		return
+	}
+
+	r.slot = &slot{
+		view:       pp.View,
+		sequence:   pp.Sequence,
+		block:      block,
+		digest:     digest,
+		prePrepare: signed,
+		acceptedAt: time.Now(),
+	}
+	r.lg.Debugf("Accepted pre-prepare for block [%d] in view [%d]", pp.Sequence, pp.View)
+
+	// the pre-prepare of the primary stands for its prepare
+	if signed.From != r.raftID {
+		r.vote(&bftpb.Message{Type: &bftpb.Message_Prepare{Prepare: &bftpb.Vote{
+			View:     pp.View,
+			Sequence: pp.Sequence,
+			Digest:   digest,
+		}}})
+	}
+	r.checkPrepared()
+}
+
+// vote broadcasts a prepare or commit of this member, and counts it
+func (r *Replicator) vote(msg *bftpb.Message) {
+	signed := r.sign(msg)
+	r.slot.ownVotes = append(r.slot.ownVotes, signed)
+	r.broadcast(signed)
+	r.handleMessage(signed)
+}
+
+func (r *Replicator) handleVote(signed *bftpb.SignedMessage, vote *bftpb.Vote, isCommit bool) {
+	view, active, m := r.getView()
+	if !active || vote.View != view || vote.Sequence <= r.getLastCommittedBlockNumber() {
+		return
+	}
+
+	votes := r.prepares
+	if isCommit {
+		signedBytes := CommitSigningBytes(vote.View, vote.Sequence, vote.Digest)
+		if err := m.verifiers[signed.From].Verify(signedBytes, vote.CommitSignature); err != nil {
+			r.lg.Warnf("Dropping commit from [%d], invalid commit signature: %s", signed.From, err)
+			return
+		}
+		votes = r.commits
+	} else if signed.From == m.primary(view) {
+		return
+	}
+
+	key := voteKey{view: vote.View, sequence: vote.Sequence, digest: string(vote.Digest)}
+	if votes[key] == nil {
+		votes[key] = make(map[uint64]*signedVote)
+	}
+	votes[key][signed.From] = &signedVote{signed: signed, vote: vote}
+
+	r.checkPrepared()
+	r.checkCommitted()
+}
+
+// checkPrepared marks the slot prepared once a quorum agrees on its block: the pre-prepare of the primary and the
+// prepares of the other members. The member then commits the block.
+func (r *Replicator) checkPrepared() {
+	s := r.slot
+	_, _, m := r.getView()
+	if s == nil || s.prepared || len(r.prepares[s.key()]) < m.quorum()-1 {
+		return
+	}
+
+	s.prepared = true
+	for _, p := range r.prepares[s.key()] {
+		s.prepares = append(s.prepares, p.signed)
+	}
+	sort.Slice(s.prepares, func(i, j int) bool { return s.prepares[i].From < s.prepares[j].From })
+	r.lg.Debugf("Prepared block [%d] in view [%d]", s.sequence, s.view)
+
+	signature, err := r.signer.Sign(CommitSigningBytes(s.view, s.sequence, s.digest))
+	if err != nil {
+		r.lg.Panicf("Error signing a BFT commit: %s", err)
+	}
+	r.vote(&bftpb.Message{Type: &bftpb.Message_Commit{Commit: &bftpb.Vote{
+		View:            s.view,
+		Sequence:        s.sequence,
+		Digest:          s.digest,
+		CommitSignature: signature,
+	}}})
+}
+
+// checkCommitted commits the block of the slot once it is prepared, and a quorum committed it.
+func (r *Replicator) checkCommitted() {
+	s := r.slot
+	_, _, m := r.getView()
+	if s == nil || !s.prepared || len(r.commits[s.key()]) < m.quorum() {
+		return
+	}
+
+	metadata := &types.ConsensusMetadata{BftView: s.view}
+	for raftID, c := range r.commits[s.key()] {
+		metadata.BftSignatures = append(metadata.BftSignatures, &types.BFTSignature{
+			NodeId:    m.nodeIDs[raftID],
+			Signature: c.vote.CommitSignature,
+		})
+	}
+	sort.Slice(metadata.BftSignatures, func(i, j int) bool {
+		return metadata.BftSignatures[i].NodeId < metadata.BftSignatures[j].NodeId
+	})
+	s.block.ConsensusMetadata = metadata
+
+	r.slot = nil
+	if err := r.commitBlock(s.block); err != nil {
+		r.lg.Errorf("Failed to commit block [%d]: %s", s.sequence, err)
+	}
+}
+
+func (r *Replicator) handleHeartbeat(signed *bftpb.SignedMessage, hb *bftpb.Heartbeat) {
+	view, active, m := r.getView()
+	if hb.View > view && signed.From == m.primary(hb.View) {
+		r.requestNewView(signed.From)
+		return
+	}
+	if !active || hb.View != view || signed.From != m.primary(view) {
+		return
+	}
+
+	if hb.LastCommitted > r.getLastCommittedBlockNumber() {
+		// a member catches up only to a block that a quorum committed, so that a faulty primary cannot send it after
+		// blocks that do not exist
+		if err := m.verifyLastCommitted(hb.LastCommitted, hb.LastCommittedCertificate); err != nil {
+			r.lg.Warnf("Dropping heartbeat from [%d]: %s", signed.From, err)
+			return
+		}
+		r.lastPrimaryContact = time.Now()
+		if s := r.slot; s != nil && s.sequence <= hb.LastCommitted {
+			r.slot = nil
+		}
+		r.catchUp(hb.LastCommitted, signed.From)
+		return
+	}
+	r.lastPrimaryContact = time.Now()
+}
+
+// requestNewView asks the primary of a later view, e.g. after this member restarted, for the new view that installed
+// it. The request is a view change for the current view, which the primary answers as one from a member that is behind.
+func (r *Replicator) requestNewView(to uint64) {
+	view, _, _ := r.getView()
+	r.lg.Debugf("Requesting the new view from [%d], current view [%d]", to, view)
+	r.send(to, r.sign(&bftpb.Message{Type: &bftpb.Message_ViewChange{ViewChange: &bftpb.ViewChange{
+		NewView:                  view,
+		LastCommitted:            r.getLastCommittedBlockNumber(),
+		LastCommittedCertificate: r.lastCommitCertificate(),
+	}}}))
+}
+
+// tick sends heartbeats from the primary, and starts a view change when the primary does not make progress.
+func (r *Replicator) tick(now time.Time) {
+	view, active, m := r.getView()
+
+	switch {
+	case active && m.primary(view) == r.raftID:
+		if r.slot != nil && now.Sub(r.slot.acceptedAt) > r.viewChangeTimeout/2 {
+			r.broadcast(r.slot.prePrepare)
+			return
+		}
+		r.broadcast(r.sign(&bftpb.Message{Type: &bftpb.Message_Heartbeat{Heartbeat: &bftpb.Heartbeat{
+			View:                     view,
+			LastCommitted:            r.getLastCommittedBlockNumber(),
+			LastCommittedCertificate: r.lastCommitCertificate(),
+		}}}))
+
+	case active:
+		if now.Sub(r.lastPrimaryContact) > r.viewChangeTimeout {
+			r.lg.Warnf("No message from primary [%d] of view [%d] since %s", m.primary(view), view, r.lastPrimaryContact)
+			r.startViewChange(view + 1)
+		} else if r.slot != nil && now.Sub(r.slot.acceptedAt) > r.viewChangeTimeout {
+			r.lg.Warnf("Block [%d] was not committed in view [%d] since %s", r.slot.sequence, view, r.slot.acceptedAt)
+			r.startViewChange(view + 1)
+		}
+
+	default:
+		if now.Sub(r.viewChangeStart) > 2*r.viewChangeTimeout {
+			r.lg.Warnf("View [%d] was not installed since %s", view, r.viewChangeStart)
+			r.startViewChange(view + 1)
+		}
+	}
+}
+
+// startViewChange moves this member to a new view, which it does not accept messages of until it is installed, and
+// broadcasts a view change with the block it prepared.
+func (r *Replicator) startViewChange(newView uint64) {
+	r.lg.Infof("Starting a view change to view [%d]", newView)
+	r.setView(newView, false)
+	r.viewChangeStart = time.Now()
+
+	vc := &bftpb.ViewChange{
+		NewView:                  newView,
+		LastCommitted:            r.getLastCommittedBlockNumber(),
+		LastCommittedCertificate: r.lastCommitCertificate(),
+	}
+	if s := r.slot; s != nil && s.prepared {
+		vc.Prepared = s.prePrepare
+		vc.Prepares = s.prepares
+	}
+
+	signed := r.sign(&bftpb.Message{Type: &bftpb.Message_ViewChange{ViewChange: vc}})
+	r.broadcast(signed)
+	r.handleMessage(signed)
+}
+
+func (r *Replicator) handleViewChange(signed *bftpb.SignedMessage, vc *bftpb.ViewChange) {
+	view, active, m := r.getView()
+	if vc.NewView < view || (vc.NewView == view && active) {
+		// the sender is behind, it may learn the current view from the new view that installed it
+		if r.newView != nil && signed.From != r.raftID {
+			r.send(signed.From, r.newView)
+		}
+		return
+	}
+	if err := m.verifyViewChange(vc); err != nil {
+		r.lg.Warnf("Dropping view change from [%d]: %s", signed.From, err)
+		return
+	}
+
+	if r.viewChanges[vc.NewView] == nil {
+		r.viewChanges[vc.NewView] = make(map[uint64]*signedViewChange)
+	}
+	r.viewChanges[vc.NewView][signed.From] = &signedViewChange{signed: signed, viewChange: vc}
+
+	// a member joins a view change once f+1 members ask for a later view, as at least one of them is correct
+	if vc.NewView > view {
+		senders := make(map[uint64]bool)
+		minView := vc.NewView
+		for v, vcs := range r.viewChanges {
+			if v <= view {
+				continue
+			}
+			for from := range vcs {
+				senders[from] = true
+			}
+			if v < minView {
+				minView = v
+			}
+		}
+		if len(senders) > m.faulty() {
+			r.startViewChange(minView)
+			return
+		}
+	}
+
+	r.maybeSendNewView()
+}
+
+// maybeSendNewView installs the pending view, if this member is its primary and a quorum asked for it.
+func (r *Replicator) maybeSendNewView() {
+	view, active, m := r.getView()
+	vcs := r.viewChanges[view]
+	if active || m.primary(view) != r.raftID || len(vcs) < m.quorum() {
+		return
+	}
+
+	nv := &bftpb.NewView{View: view}
+	var viewChanges []*bftpb.ViewChange
+	for _, vc := range vcs {
+		nv.ViewChanges = append(nv.ViewChanges, vc.signed)
+		viewChanges = append(viewChanges, vc.viewChange)
+	}
+	sort.Slice(nv.ViewChanges, func(i, j int) bool { return nv.ViewChanges[i].From < nv.ViewChanges[j].From })
+
+	if _, prepared := selectPrepared(viewChanges); prepared != nil {
+		nv.PrePrepare = r.sign(&bftpb.Message{Type: &bftpb.Message_PrePrepare{PrePrepare: &bftpb.PrePrepare{
+			View:     view,
+			Sequence: prepared.Sequence,
+			Block:    prepared.Block,
+		}}})
+	}
+
+	r.lg.Infof("Installing view [%d] with [%d] view changes", view, len(nv.ViewChanges))
+	signed := r.sign(&bftpb.Message{Type: &bftpb.Message_NewView{NewView: nv}})
+	r.broadcast(signed)
+	r.handleMessage(signed)
+}
+
+func (r *Replicator) handleNewView(signed *bftpb.SignedMessage, nv *bftpb.NewView) {
+	view, active, m := r.getView()
+	if nv.View < view || (nv.View == view && active) {
+		return
+	}
+	lastCommitted, prePrepare, err := m.verifyNewView(signed, nv)
+	if err != nil {
+		r.lg.Warnf("Dropping new view from [%d]: %s", signed.From, err)
+		return
+	}
+
+	r.lg.Infof("Installed view [%d], primary [%d]", nv.View, signed.From)
+	r.setView(nv.View, true)
+	r.newView = signed
+	r.lastPrimaryContact = time.Now()
+	for v := range r.viewChanges {
+		if v <= nv.View {
+			delete(r.viewChanges, v)
+		}
+	}
+	for key := range r.prepares {
+		if key.view < nv.View {
+			delete(r.prepares, key)
+		}
+	}
+	for key := range r.commits {
+		if key.view < nv.View {
+			delete(r.commits, key)
+		}
+	}
+	// a block that was not prepared by a quorum in an earlier view is abandoned, the new primary proposes again a
+	// block that might have been committed
+	r.slot = nil
+
+	r.catchUp(lastCommitted, signed.From)
+	if nv.PrePrepare != nil {
+		r.handlePrePrepare(nv.PrePrepare, prePrepare)
+	}
+}
+
+// verifyNewView verifies that a new view was sent by the primary of the view, with a quorum of valid view changes for
+// it, and that it proposes again the block the view changes select, if any. It returns the highest last committed
+// block number of the view changes, and the pre-prepare.
+func (m *members) verifyNewView(signed *bftpb.SignedMessage, nv *bftpb.NewView) (uint64, *bftpb.PrePrepare, error) {
+	if signed.From != m.primary(nv.View) {
+		return 0, nil, errors.Errorf("sender is not the primary of view [%d]", nv.View)
+	}
+
+	var viewChanges []*bftpb.ViewChange
+	senders := make(map[uint64]bool)
+	for _, signedVC := range nv.ViewChanges {
+		msg, err := m.open(signedVC)
+		if err != nil {
+			return 0, nil, err
+		}
+		vc := msg.GetViewChange()
+		if vc == nil || vc.NewView != nv.View {
+			return 0, nil, errors.Errorf("view change from [%d] is not for view [%d]", signedVC.From, nv.View)
+		}
+		if err := m.verifyViewChange(vc); err != nil {
+			return 0, nil, err
+		}
+		if !senders[signedVC.From] {
+			senders[signedVC.From] = true
+			viewChanges = append(viewChanges, vc)
+		}
+	}
+	if len(senders) < m.quorum() {
+		return 0, nil, errors.Errorf("[%d] view changes, a quorum is [%d]", len(senders), m.quorum())
+	}
+
+	lastCommitted, prepared := selectPrepared(viewChanges)
+	if prepared == nil {
+		if nv.PrePrepare != nil {
+			return 0, nil, errors.New("unexpected pre-prepare, no block was prepared")
+		}
+		return lastCommitted, nil, nil
+	}
+
+	if nv.PrePrepare == nil || nv.PrePrepare.From != signed.From {
+		return 0, nil, errors.Errorf("missing the pre-prepare of the prepared block [%d]", prepared.Sequence)
+	}
+	msg, err := m.open(nv.PrePrepare)
+	if err != nil {
+		return 0, nil, err
+	}
+	pp := msg.GetPrePrepare()
+	if pp == nil || pp.View != nv.View || pp.Sequence != prepared.Sequence || !bytes.Equal(pp.Block, prepared.Block) {
+		return 0, nil, errors.Errorf("the pre-prepare does not propose again the prepared block [%d]", prepared.Sequence)
+	}
+	return lastCommitted, pp, nil
+}
+
+// verifyViewChange verifies the commit certificate of the last committed block of a view change, and the certificate
+// of its prepared block, if any.
+func (m *members) verifyViewChange(vc *bftpb.ViewChange) error {
+	if err := m.verifyLastCommitted(vc.LastCommitted, vc.LastCommittedCertificate); err != nil {
+		return err
+	}
+	return m.verifyPrepared(vc)
+}
+
+// verifyPrepared verifies the certificate of the prepared block a view change carries, if any: a pre-prepare from the
+// primary of its view for the block after the last committed block, and the matching prepares of a quorum of the other
+// members.
+func (m *members) verifyPrepared(vc *bftpb.ViewChange) error {
+	if vc.Prepared == nil {
+		if len(vc.Prepares) > 0 {
+			return errors.New("prepares without a pre-prepare")
+		}
+		return nil
+	}
+
+	msg, err := m.open(vc.Prepared)
+	if err != nil {
+		return err
+	}
+	pp := msg.GetPrePrepare()
+	switch {
+	case pp == nil:
+		return errors.New("prepared message is not a pre-prepare")
+	case vc.Prepared.From != m.primary(pp.View):
+		return errors.Errorf("prepared block was not proposed by the primary of view [%d]", pp.View)
+	case pp.View >= vc.NewView:
+		return errors.Errorf("prepared block in view [%d], which is not before view [%d]", pp.View, vc.NewView)
+	case pp.Sequence != vc.LastCommitted+1:
+		return errors.Errorf("prepared block [%d] does not follow the last committed block [%d]", pp.Sequence, vc.LastCommitted)
+	}
+
+	block := &types.Block{}
+	if err := proto.Unmarshal(pp.Block, block); err != nil {
+		return errors.Wrap(err, "failed to unmarshal prepared block")
+	}
+	digest, err := BlockDigest(block)
+	if err != nil {
+		return err
+	}
+
+	senders := make(map[uint64]bool)
+	for _, p := range vc.Prepares {
+		msg, err := m.open(p)
+		if err != nil {
+			return err
+		}
+		vote := msg.GetPrepare()
+		if vote == nil || vote.View != pp.View || vote.Sequence != pp.Sequence || !bytes.Equal(vote.Digest, digest) {
+			return errors.Errorf("prepare from [%d] does not match the prepared block [%d]", p.From, pp.Sequence)
+		}
+		if p.From != m.primary(pp.View) {
+			senders[p.From] = true
+		}
+	}
+	if len(senders) < m.quorum()-1 {
+		return errors.Errorf("prepared block [%d] has [%d] prepares, needs [%d]", pp.Sequence, len(senders), m.quorum()-1)
+	}
+	return nil
+}
+
+// selectPrepared returns the highest last committed block number of the view changes, and the pre-prepare of the
+// block that follows it, prepared in the latest view, if any. Because a committed block was prepared by a quorum,
+// which intersects every quorum of view changes, the block is proposed again in the new view if it may have been
+// committed by any correct member. The view changes must be verified, so that every last committed block number is
+// proven by a commit certificate, and a faulty member cannot move the new view past blocks that were not committed.
+func selectPrepared(viewChanges []*bftpb.ViewChange) (uint64, *bftpb.PrePrepare) {
+	var lastCommitted uint64
+	for _, vc := range viewChanges {
+		if vc.LastCommitted > lastCommitted {
+			lastCommitted = vc.LastCommitted
+		}
+	}
+
+	var selected *bftpb.PrePrepare
+	for _, vc := range viewChanges {
+		if vc.Prepared == nil {
+			continue
+		}
+		msg := &bftpb.Message{}
+		if err := proto.Unmarshal(vc.Prepared.Message, msg); err != nil {
+			continue
+		}
+		pp := msg.GetPrePrepare()
+		if pp.GetSequence() == lastCommitted+1 && (selected == nil || pp.View > selected.View) {
+			selected = pp
+		}
+	}
+	return lastCommitted, selected
+}
@@ -0,0 +1,695 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bft
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft/bftpb"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+// DefaultViewChangeTimeout is used when the `BFTConfig.ViewChangeTimeout` of the cluster config is empty.
+const DefaultViewChangeTimeout = 2 * time.Second
+
+// maxCatchUpBlocks bounds the number of blocks a member pulls in one catch-up; a member that is further behind
+// catches up again on the next heartbeat of the primary.
+const maxCatchUpBlocks = 1000
+
+// maxProposalTimeSkew bounds how far ahead of the clock of a member the time of a proposed block can be.
+const maxProposalTimeSkew = 30 * time.Second
+
+// Replicator orders blocks with a PBFT-style protocol, which tolerates f Byzantine members out of n = 3f+1.
+//
+// The members take turns being the primary, one view at a time. The primary of a view proposes a block in a
+// pre-prepare, the members broadcast a prepare vote on its digest, and once a member sees a quorum of prepares it
+// broadcasts a signed commit vote. A block is committed when a quorum of commit votes is collected, and the commit
+// signatures are kept in the consensus metadata of the block, so that anyone can verify that a quorum ordered it.
+// One block is ordered at a time, its sequence is the block number.
+//
+// When a member suspects the primary, because it does not hear from it, or a block it accepted does not commit in time,
+// it asks to move to the next view with a view change that carries the block it prepared, if any. The primary of the
+// next view installs it with a new view that carries a quorum of view changes, and proposes again the prepared block
+// that may have been committed by some members.
+//
+// All the messages are signed by their sender. The protocol state, other than the ledger, is not persisted; a member
+// that restarts learns the current view from the new view the other members send it, and pulls the blocks it missed
+// from them, verifying their commit signatures. A member catches up only to a block whose commit certificate, the
+// commit signatures of a quorum, it was sent, and it pulls the blocks outside of the protocol go-routine. The BFT
+// consensus does not support observers, membership changes,
+// on-boarding with a join block, leadership transfer, and linearizable reads.
+type Replicator struct {
+	raftID            uint64
+	nodeID            string
+	signer            crypto.Signer
+	viewChangeTimeout time.Duration
+	proposeCh         chan *types.Block
+	msgCh             chan *bftpb.SignedMessage
+	pulledCh          chan []*types.Block    // blocks pulled by a catch-up, to be committed by the event-loop
+	oneQueueBarrier   *queue.OneQueueBarrier // Synchronizes the block-replication deliver with the block-processor commit
+	transport         comm.Transport
+	pendingTxs        replication.PendingTxsReleaser
+	configTxValidator replication.ConfigTxValidator
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+
+	// shared state between the event-loop go-routine and the transport go-routines
+	mutex              sync.Mutex
+	clusterConfig      *types.ClusterConfig
+	members            *members
+	view               uint64
+	viewActive         bool // false while changing to `view`
+	lastCommittedBlock *types.Block
+
+	// state of the event-loop go-routine
+	slot               *slot                                   // the block accepted for the next sequence, if any
+	prepares           map[voteKey]map[uint64]*signedVote      // prepare votes, by sender
+	commits            map[voteKey]map[uint64]*signedVote      // commit votes, by sender
+	viewChanges        map[uint64]map[uint64]*signedViewChange // view changes, by view and by sender
+	newView            *bftpb.SignedMessage                    // the new view that installed the current view, nil in view 0
+	lastPrimaryContact time.Time
+	viewChangeStart    time.Time
+	catchingUp         bool                     // a catch-up is pulling blocks
+	aheadPrePrepare    *bftpb.SignedMessage     // a pre-prepare past the next sequence, handled again after a catch-up
+	lastCertificate    *bftpb.CommitCertificate // the commit certificate of the last committed block, once computed
+
+	lg *logger.SugarLogger
+}
+
+// slot is the state of the block accepted for the sequence that follows the last committed block
+type slot struct {
+	view       uint64
+	sequence   uint64
+	block      *types.Block
+	digest     []byte
+	prePrepare *bftpb.SignedMessage
+	prepares   []*bftpb.SignedMessage // a quorum of prepares, once prepared
+	prepared   bool
+	ownVotes   []*bftpb.SignedMessage // the prepare and commit of this member, sent again with a repeated pre-prepare
+	acceptedAt time.Time
+}
+
+func (s *slot) key() voteKey {
+	return voteKey{view: s.view, sequence: s.sequence, digest: string(s.digest)}
+}
+
+type voteKey struct {
+	view     uint64
+	sequence uint64
+	digest   string
+}
+
+type signedVote struct {
+	signed *bftpb.SignedMessage
+	vote   *bftpb.Vote
+}
+
+type signedViewChange struct {
+	signed     *bftpb.SignedMessage
+	viewChange *bftpb.ViewChange
+}
+
+var _ replication.Consenter = &Replicator{}
+
+// NewReplicator creates a new BFT Replicator.
+func NewReplicator(conf *replication.Config) (*Replicator, error) {
+	if conf.JoinBlock != nil {
+		return nil, errors.New("the BFT consensus does not support on-boarding with a join block")
+	}
+	if conf.Signer == nil {
+		return nil, errors.New("the BFT consensus requires a signer")
+	}
+
+	nodeID := conf.LocalConf.Server.Identity.ID
+	raftID, err := comm.MemberRaftID(nodeID, conf.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	members, err := newMembers(conf.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !members.isMember(raftID) {
+		return nil, errors.Errorf("node [%s] is not a consensus member", nodeID)
+	}
+
+	viewChangeTimeout := DefaultViewChangeTimeout
+	if timeout := conf.ClusterConfig.GetConsensusConfig().GetBftConfig().GetViewChangeTimeout(); timeout != "" {
+		if viewChangeTimeout, err = time.ParseDuration(timeout); err != nil {
+			return nil, errors.Wrap(err, "failed to parse BFT view change timeout")
+		}
+	}
+
+	height, err := conf.LedgerReader.Height()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read block height")
+	}
+	if height == 0 {
+		return nil, errors.New("the BFT consensus requires a ledger with a genesis block")
+	}
+	lastBlock, err := conf.LedgerReader.Get(height)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read last block")
+	}
+
+	return &Replicator{
+		raftID:             raftID,
+		nodeID:             nodeID,
+		signer:             conf.Signer,
+		viewChangeTimeout:  viewChangeTimeout,
+		proposeCh:          make(chan *types.Block, 1),
+		msgCh:              make(chan *bftpb.SignedMessage, 1024),
+		pulledCh:           make(chan []*types.Block, 1),
+		oneQueueBarrier:    conf.BlockOneQueueBarrier,
+		transport:          conf.Transport,
+		pendingTxs:         conf.PendingTxs,
+		configTxValidator:  conf.ConfigValidator,
+		stopCh:             make(chan struct{}),
+		doneCh:             make(chan struct{}),
+		clusterConfig:      conf.ClusterConfig,
+		members:            members,
+		viewActive:         true,
+		lastCommittedBlock: lastBlock,
+		prepares:           make(map[voteKey]map[uint64]*signedVote),
+		commits:            make(map[voteKey]map[uint64]*signedVote),
+		viewChanges:        make(map[uint64]map[uint64]*signedViewChange),
+		lg:                 conf.Logger.With("nodeID", nodeID, "raftID", raftID),
+	}, nil
+}
+
+func (r *Replicator) RaftID() uint64 {
+	return r.raftID
+}
+
+// Submit a block for ordering.
+//
+// This call may block if the input queue is full.
+// Returns an error if the current node is not the primary.
+// Returns an error if the component is already closed.
+func (r *Replicator) Submit(block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	if err := r.IsLeader(); err != nil {
+		r.lg.Debugf("Submit of block [%d] refused, not the primary: %s", blockNum, err)
+		return err
+	}
+
+	select {
+	case <-r.stopCh:
+		return &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	default:
+	}
+
+	select {
+	case <-r.stopCh:
+		return &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	case r.proposeCh <- block:
+		r.lg.Debugf("Submitted block [%d]", blockNum)
+		return nil
+	}
+}
+
+// Start the internal go-routine that runs the protocol.
+func (r *Replicator) Start() {
+	readyCh := make(chan struct{})
+	go r.run(readyCh)
+	<-readyCh
+}
+
+// Close signals the internal go-routine to stop and waits for it to exit.
+// If the component is already closed, and error is returned.
+func (r *Replicator) Close() (err error) {
+	err = &ierrors.ClosedError{ErrMsg: "block replicator already closed"}
+	r.stopOnce.Do(func() {
+		r.lg.Info("closing block replicator")
+		close(r.stopCh)
+		if errQB := r.oneQueueBarrier.Close(); errQB != nil {
+			r.lg.Debugf("OneQueueBarrier error: %s", errQB)
+		}
+		<-r.doneCh
+
+		err = nil
+	})
+
+	return err
+}
+
+// IsLeader returns nil if this node is the primary of the current view. During a view change the primary is not
+// known, and an empty NotLeaderError is returned.
+func (r *Replicator) IsLeader() *ierrors.NotLeaderError {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.viewActive {
+		return &ierrors.NotLeaderError{}
+	}
+	primary := r.members.primary(r.view)
+	if primary == r.raftID {
+		return nil
+	}
+	return &ierrors.NotLeaderError{LeaderID: primary, LeaderHostPort: r.nodeHostPortFromRaftID(primary)}
+}
+
+func (r *Replicator) GetClusterStatus() (leaderID uint64, activePeers map[string]*types.PeerConfig) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	activePeers = r.transport.ActivePeers(500*time.Millisecond, true)
+	if r.viewActive {
+		leaderID = r.members.primary(r.view)
+	}
+
+	return
+}
+
+// TransferLeadership is not supported, the primary changes only with a view change.
+func (r *Replicator) TransferLeadership(_ string, _ time.Duration) (*types.LeadershipTransfer, error) {
+	return nil, &ierrors.BadRequestError{ErrMsg: "leadership transfer is not supported by the BFT consensus"}
+}
+
+func (r *Replicator) LeadershipTransfer() *types.LeadershipTransfer {
+	return nil
+}
+
+// LinearizableRead is not supported, a single member cannot tell whether it is up to date with the cluster.
+func (r *Replicator) LinearizableRead(_ context.Context) (uint64, error) {
+	return 0, &ierrors.ServerRestrictionError{ErrMsg: "linearizable reads are not supported by the BFT consensus"}
+}
+
+// Process incoming BFT messages, which are carried in the first entry of a Raft message.
+func (r *Replicator) Process(ctx context.Context, m raftpb.Message) error {
+	if len(m.Entries) != 1 {
+		return errors.Errorf("expected a BFT message in a single entry, got %d entries", len(m.Entries))
+	}
+	signed := &bftpb.SignedMessage{}
+	if err := proto.Unmarshal(m.Entries[0].Data, signed); err != nil {
+		return errors.Wrap(err, "failed to unmarshal BFT message")
+	}
+	if signed.From != m.From {
+		return errors.Errorf("BFT message from [%d] was sent by [%d]", signed.From, m.From)
+	}
+
+	select {
+	case r.msgCh <- signed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.stopCh:
+		return &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	}
+}
+
+func (r *Replicator) IsIDRemoved(id uint64) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return !r.members.isMember(id)
+}
+
+func (r *Replicator) ReportUnreachable(id uint64) {
+	r.lg.Debugf("ReportUnreachable: %d", id)
+}
+
+func (r *Replicator) ReportSnapshot(id uint64, status raft.SnapshotStatus) {
+	r.lg.Debugf("ReportSnapshot: %d, %v", id, status)
+}
+
+func (r *Replicator) run(readyCh chan<- struct{}) {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.viewChangeTimeout / 4)
+	defer ticker.Stop()
+	r.lastPrimaryContact = time.Now()
+
+	r.lg.Infof("Starting the BFT replicator, view change timeout: %s", r.viewChangeTimeout)
+	close(readyCh)
+
+	for {
+		// the primary proposes one block at a time; other members drain the queue and release the blocks
+		var proposeCh chan *types.Block
+		if r.slot == nil || r.IsLeader() != nil {
+			proposeCh = r.proposeCh
+		}
+
+		select {
+		case signed := <-r.msgCh:
+			r.handleMessage(signed)
+		case block := <-proposeCh:
+			r.propose(block)
+		case blocks := <-r.pulledCh:
+			r.commitPulledBlocks(blocks)
+		case now := <-ticker.C:
+			r.tick(now)
+		case <-r.stopCh:
+			r.lg.Info("Exiting the BFT replicator")
+			return
+		}
+	}
+}
+
+func (r *Replicator) getView() (view uint64, active bool, m *members) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.view, r.viewActive, r.members
+}
+
+func (r *Replicator) setView(view uint64, active bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.view = view
+	r.viewActive = active
+}
+
+func (r *Replicator) getLastCommittedBlock() *types.Block {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.lastCommittedBlock
+}
+
+func (r *Replicator) getLastCommittedBlockNumber() uint64 {
+	return r.getLastCommittedBlock().GetHeader().GetBaseHeader().GetNumber()
+}
+
+func (r *Replicator) sign(msg *bftpb.Message) *bftpb.SignedMessage {
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		r.lg.Panicf("Error marshaling a BFT message: %s", err)
+	}
+	signature, err := r.signer.Sign(msgBytes)
+	if err != nil {
+		r.lg.Panicf("Error signing a BFT message: %s", err)
+	}
+	return &bftpb.SignedMessage{From: r.raftID, Message: msgBytes, Signature: signature}
+}
+
+// broadcast sends the message to all the other members
+func (r *Replicator) broadcast(signed *bftpb.SignedMessage) {
+	_, _, m := r.getView()
+	for _, raftID := range m.raftIDs {
+		if raftID != r.raftID {
+			r.send(raftID, signed)
+		}
+	}
+}
+
+func (r *Replicator) send(to uint64, signed *bftpb.SignedMessage) {
+	msg := raftpb.Message{
+		Type:    raftpb.MsgProp,
+		To:      to,
+		From:    r.raftID,
+		Entries: []raftpb.Entry{{Data: utils.MarshalOrPanic(signed)}},
+	}
+	if err := r.transport.SendConsensus([]raftpb.Message{msg}); err != nil {
+		r.lg.Warnf("Failed to send BFT message to [%d]: %s", to, err)
+	}
+}
+
+// propose a block as the primary, or release it if this node is no longer the primary
+func (r *Replicator) propose(block *types.Block) {
+	if errLeader := r.IsLeader(); errLeader != nil {
+		r.releasePendingTXs(block, "Declined to propose block", errLeader)
+		return
+	}
+
+	if utils.IsConfigBlock(block) {
+		// validate a config TX preorder, as the Raft block replicator does, so that invalid config TXs fail fast
+		valInfo, errVal := r.configTxValidator.Validate(block.GetConfigTxEnvelope())
+		if errVal != nil {
+			r.lg.Errorf("Failed to validate configTxEnv, internal error: %s", errVal)
+			r.releasePendingTXs(block, "Declined to propose block, internal error in Validate", errVal)
+			return
+		}
+		if valInfo.Flag != types.Flag_VALID {
+			r.releasePendingTXs(block, "Declined to propose block, invalid config tx",
+				&ierrors.BadRequestError{ErrMsg: fmt.Sprintf("Invalid config tx, reason: %s", valInfo.ReasonIfInvalid)})
+			return
+		}
+	}
+
+	view, _, _ := r.getView()
+	r.insertBlockBaseHeader(block)
+	signed := r.sign(&bftpb.Message{Type: &bftpb.Message_PrePrepare{PrePrepare: &bftpb.PrePrepare{
+		View:     view,
+		Sequence: block.GetHeader().GetBaseHeader().GetNumber(),
+		Block:    utils.MarshalOrPanic(block),
+	}}})
+
+	r.lg.Debugf("Proposing block [%d] in view [%d]", block.GetHeader().GetBaseHeader().GetNumber(), view)
+	r.broadcast(signed)
+	r.handleMessage(signed)
+}
+
+func (r *Replicator) releasePendingTXs(block *types.Block, reasonMsg string, reasonErr error) {
+	r.lg.Infof("%s: %+v; because: %s", reasonMsg, block.GetHeader(), reasonErr)
+	txIDs, err := utils.BlockPayloadToTxIDs(block.GetPayload())
+	if err != nil {
+		r.lg.Errorf("Failed to extract TxIDs from block, dropping block: %v; error: %s", block.GetHeader(), err)
+		return
+	}
+
+	r.pendingTxs.ReleaseWithError(txIDs, reasonErr)
+}
+
+// insertBlockBaseHeader numbers the block after the last committed block, links it to it, and sets its time,
+// which does not decrease along the chain.
+func (r *Replicator) insertBlockBaseHeader(block *types.Block) {
+	last := r.getLastCommittedBlock()
+	baseHash, err := blockstore.ComputeBlockBaseHash(last)
+	if err != nil {
+		r.lg.Panicf("Failed to compute last block base hash: %s", err)
+	}
+	hash, err := blockstore.ComputeBlockHash(last)
+	if err != nil {
+		r.lg.Panicf("Failed to compute last block hash: %s", err)
+	}
+
+	block.Header = &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{
+		Number:                 last.GetHeader().GetBaseHeader().GetNumber() + 1,
+		PreviousBaseHeaderHash: baseHash,
+		LastCommittedBlockHash: hash,
+		LastCommittedBlockNum:  last.GetHeader().GetBaseHeader().GetNumber(),
+		Timestamp:              time.Now().Unix(),
+	}}
+	if lastTimestamp := last.GetHeader().GetBaseHeader().GetTimestamp(); block.Header.BaseHeader.Timestamp < lastTimestamp {
+		block.Header.BaseHeader.Timestamp = lastTimestamp
+	}
+}
+
+// validateProposal checks that a proposed block is numbered after the last committed block, is linked to it, and
+// that its time is neither before the time of the last committed block nor too far ahead of the local clock.
+func (r *Replicator) validateProposal(block *types.Block) error {
+	expected := &types.Block{}
+	r.insertBlockBaseHeader(expected)
+	timestamp := block.GetHeader().GetBaseHeader().GetTimestamp()
+	lastTimestamp := r.getLastCommittedBlock().GetHeader().GetBaseHeader().GetTimestamp()
+	if timestamp < lastTimestamp || timestamp > time.Now().Add(maxProposalTimeSkew).Unix() {
+		return errors.Errorf("proposed block [%d] has time [%d], which is before the last committed block time [%d] or ahead of the local time by more than %s",
+			block.GetHeader().GetBaseHeader().GetNumber(), timestamp, lastTimestamp, maxProposalTimeSkew)
+	}
+	expected.Header.BaseHeader.Timestamp = timestamp
+	if !proto.Equal(block.GetHeader(), expected.GetHeader()) {
+		return errors.Errorf("proposed block header %+v does not follow the last committed block, expected %+v",
+			block.GetHeader(), expected.GetHeader())
+	}
+	if block.GetPayload() == nil {
+		return errors.Errorf("proposed block [%d] has no payload", block.GetHeader().GetBaseHeader().GetNumber())
+	}
+	return nil
+}
+
+// commitBlock commits the block to the ledger and DB, and applies the new cluster config if it is a valid config block.
+func (r *Replicator) commitBlock(block *types.Block) error {
+	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
+	r.lg.Infof("Enqueue for commit block [%d], view [%d], signatures: %d",
+		blockNumber, block.GetConsensusMetadata().GetBftView(), len(block.GetConsensusMetadata().GetBftSignatures()))
+
+	reConfig, err := r.oneQueueBarrier.EnqueueWait(block)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	r.lastCommittedBlock = block
+	r.mutex.Unlock()
+
+	for key := range r.prepares {
+		if key.sequence <= blockNumber {
+			delete(r.prepares, key)
+		}
+	}
+	for key := range r.commits {
+		if key.sequence <= blockNumber {
+			delete(r.commits, key)
+		}
+	}
+
+	if reConfig == nil {
+		return nil
+	}
+
+	if err := r.updateClusterConfig(reConfig.(*types.ClusterConfig)); err != nil {
+		r.lg.Panicf("Failed to update to ClusterConfig during commitBlock: error: %s", err)
+	}
+	return nil
+}
+
+func (r *Replicator) updateClusterConfig(clusterConfig *types.ClusterConfig) error {
+	r.lg.Infof("New cluster config committed, going to apply to BFT replicator: %+v", clusterConfig)
+
+	members, err := newMembers(clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// membership changes are not supported, so only the endpoints of the members may change
+	var changedPeers []*types.PeerConfig
+	for _, updated := range clusterConfig.GetConsensusConfig().GetMembers() {
+		for _, current := range r.clusterConfig.GetConsensusConfig().GetMembers() {
+			if current.NodeId == updated.NodeId && !proto.Equal(current, updated) {
+				changedPeers = append(changedPeers, updated)
+			}
+		}
+	}
+
+	if err = r.transport.UpdatePeers(nil, nil, changedPeers, clusterConfig); err != nil {
+		return errors.Wrap(err, "failed to update peers on transport")
+	}
+
+	r.clusterConfig = clusterConfig
+	r.members = members
+
+	return nil
+}
+
+// catchUp starts to pull the blocks that follow the last committed block up to the target, at most maxCatchUpBlocks
+// of them, from the other members, unless a catch-up is in progress. The pull starts from the member with the hinted
+// Raft ID. The target must be proven committed by a commit certificate.
+func (r *Replicator) catchUp(target, hint uint64) {
+	lastBlock := r.getLastCommittedBlock()
+	lastBlockNumber := lastBlock.GetHeader().GetBaseHeader().GetNumber()
+	if r.catchingUp || lastBlockNumber >= target {
+		return
+	}
+	if target > lastBlockNumber+maxCatchUpBlocks {
+		target = lastBlockNumber + maxCatchUpBlocks
+	}
+	r.lg.Infof("Catching up from block [%d] to block [%d]", lastBlockNumber, target)
+
+	r.catchingUp = true
+	_, _, m := r.getView()
+	go r.pullBlocks(lastBlock, target, hint, m)
+}
+
+// pullBlocks pulls the blocks that follow the last block up to the target, verifies their commit signatures, and
+// hands them to the event-loop, which commits them. It does not run in the event-loop, so that the protocol is not
+// blocked while the blocks are pulled.
+func (r *Replicator) pullBlocks(lastBlock *types.Block, target, hint uint64, m *members) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.viewChangeTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// there is no hash of the target block to anchor the pulled chain to, the commit signatures of each block are
+	// verified instead
+	var pulled []*types.Block
+	err := r.transport.PullBlocksConcurrently(ctx, lastBlock, target, nil, hint, func(blocks []*types.Block) error {
+		for _, block := range blocks {
+			if err := m.verifyCommitSignatures(block); err != nil {
+				return err
+			}
+			pulled = append(pulled, block)
+		}
+		return nil
+	})
+	if err != nil {
+		r.lg.Warnf("Failed to catch up to block [%d], last block number [%d]: %s", target, lastBlock.GetHeader().GetBaseHeader().GetNumber(), err)
+	}
+
+	select {
+	case r.pulledCh <- pulled:
+	case <-r.stopCh:
+	}
+}
+
+// commitPulledBlocks commits the blocks pulled by a catch-up that follow the last committed block; the blocks that
+// were committed by the protocol in the meantime are skipped. The pre-prepare that was ahead of the member, if any, is
+// then handled again.
+func (r *Replicator) commitPulledBlocks(blocks []*types.Block) {
+	r.catchingUp = false
+	for _, block := range blocks {
+		blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
+		if lastBlockNumber := r.getLastCommittedBlockNumber(); blockNumber != lastBlockNumber+1 {
+			continue
+		}
+		if s := r.slot; s != nil && s.sequence <= blockNumber {
+			r.slot = nil
+		}
+		if err := r.commitBlock(block); err != nil {
+			r.lg.Errorf("Failed to commit block [%d]: %s", blockNumber, err)
+			return
+		}
+	}
+
+	if signed := r.aheadPrePrepare; signed != nil {
+		r.aheadPrePrepare = nil
+		r.handleMessage(signed)
+	}
+}
+
+// lastCommitCertificate returns the commit certificate of the last committed block, or nil for the genesis block.
+func (r *Replicator) lastCommitCertificate() *bftpb.CommitCertificate {
+	last := r.getLastCommittedBlock()
+	blockNumber := last.GetHeader().GetBaseHeader().GetNumber()
+	if blockNumber <= 1 {
+		return nil
+	}
+	if r.lastCertificate.GetSequence() != blockNumber {
+		cert, err := commitCertificate(last)
+		if err != nil {
+			r.lg.Panicf("Error computing the commit certificate of block [%d]: %s", blockNumber, err)
+		}
+		r.lastCertificate = cert
+	}
+	return r.lastCertificate
+}
+
+// called inside a r.mutex.Lock()
+func (r *Replicator) nodeHostPortFromRaftID(raftID uint64) string {
+	nodeID := r.members.nodeIDs[raftID]
+	for _, n := range r.clusterConfig.Nodes {
+		if n.Id == nodeID {
+			return fmt.Sprintf("%s:%d", n.Address, n.Port)
+		}
+	}
+
+	r.lg.Warnf("not found: no node with NodeID: %s, RaftID: %d", nodeID, raftID)
+	return ""
+}
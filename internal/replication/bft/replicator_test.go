@@ -0,0 +1,504 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bft_test
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft"
+	"github.com/hyperledger-labs/orion-server/internal/replication/bft/bftpb"
+	"github.com/hyperledger-labs/orion-server/internal/replication/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+var nodePortBase = uint32(26000)
+var peerPortBase = uint32(27000)
+
+// A single node environment around a BFT Replicator
+type nodeEnv struct {
+	conf        *replication.Config
+	replicator  *bft.Replicator
+	ledger      *memLedger
+	transport   *pullTrackingTransport
+	stopServeCh chan struct{}
+}
+
+// pullTrackingTransport records the highest block a replicator pulls
+type pullTrackingTransport struct {
+	comm.Transport
+
+	mutex     sync.Mutex
+	maxPulled uint64
+}
+
+func (p *pullTrackingTransport) PullBlocksConcurrently(ctx context.Context, lastBlock *types.Block, endBlock uint64, endBaseHash []byte, leaderID uint64, deliver func(blocks []*types.Block) error) error {
+	p.mutex.Lock()
+	if endBlock > p.maxPulled {
+		p.maxPulled = endBlock
+	}
+	p.mutex.Unlock()
+
+	return p.Transport.PullBlocksConcurrently(ctx, lastBlock, endBlock, endBaseHash, leaderID, deliver)
+}
+
+func (p *pullTrackingTransport) MaxPulled() uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.maxPulled
+}
+
+// A cluster environment around a set of BFT Replicator objects
+type clusterEnv struct {
+	clusterConfig *types.ClusterConfig
+	signers       map[string]crypto.Signer
+	nodes         []*nodeEnv
+}
+
+func createClusterEnv(t *testing.T, nNodes int) *clusterEnv {
+	var names []string
+	for i := 1; i <= nNodes; i++ {
+		names = append(names, fmt.Sprintf("node%d", i))
+	}
+	cryptoDir := testutils.GenerateTestCrypto(t, names)
+
+	clusterConfig := &types.ClusterConfig{
+		Admins: []*types.Admin{{Id: "admin", Certificate: []byte("something")}},
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm: replication.ConsensusAlgorithmBFT,
+			BftConfig: &types.BFTConfig{ViewChangeTimeout: "500ms"},
+		},
+	}
+	signers := make(map[string]crypto.Signer)
+	for i, name := range names {
+		cert, signer := testutils.LoadTestCrypto(t, cryptoDir, name)
+		signers[name] = signer
+		clusterConfig.Nodes = append(clusterConfig.Nodes, &types.NodeConfig{
+			Id:          name,
+			Address:     "127.0.0.1",
+			Port:        nodePortBase + uint32(i+1),
+			Certificate: cert.Raw,
+		})
+		clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members, &types.PeerConfig{
+			NodeId:   name,
+			RaftId:   uint64(i + 1),
+			PeerHost: "127.0.0.1",
+			PeerPort: peerPortBase + uint32(i+1),
+		})
+	}
+
+	lg := testLogger(t, "info")
+	env := &clusterEnv{clusterConfig: clusterConfig, signers: signers}
+	for i, name := range names {
+		node, err := newNodeEnv(uint32(i+1), t.TempDir(), lg, clusterConfig, signers[name])
+		require.NoError(t, err)
+		env.nodes = append(env.nodes, node)
+	}
+	return env
+}
+
+func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, signer crypto.Signer) (*nodeEnv, error) {
+	nodeID := fmt.Sprintf("node%d", n)
+	localConf := &config.LocalConfiguration{
+		Server: config.ServerConf{
+			Identity: config.IdentityConf{
+				ID: nodeID,
+			},
+		},
+		Replication: config.ReplicationConf{
+			WALDir:  path.Join(testDir, nodeID, "wal"),
+			SnapDir: path.Join(testDir, nodeID, "snap"),
+			Network: config.NetworkConf{
+				Address: "127.0.0.1",
+				Port:    peerPortBase + n,
+			},
+		},
+	}
+
+	ledger := &memLedger{}
+	genesisBlock := &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 1}},
+		Payload: &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: &types.ConfigTxEnvelope{
+				Payload:   &types.ConfigTx{NewConfig: clusterConfig},
+				Signature: []byte("sig"),
+			},
+		},
+	}
+	if err := ledger.Append(genesisBlock); err != nil {
+		return nil, err
+	}
+
+	configValidator := &mocks.ConfigTxValidator{}
+	configValidator.ValidateReturns(&types.ValidationInfo{Flag: types.Flag_VALID}, nil)
+	conf := &replication.Config{
+		LocalConf:       localConf,
+		ClusterConfig:   clusterConfig,
+		LedgerReader:    ledger,
+		PendingTxs:      &mocks.PendingTxsReleaser{},
+		ConfigValidator: configValidator,
+		Signer:          signer,
+		Logger:          lg,
+	}
+
+	node := &nodeEnv{conf: conf, ledger: ledger}
+	if err := node.create(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (n *nodeEnv) create() error {
+	transport, err := comm.NewHTTPTransport(&comm.Config{
+		LedgerReader: n.ledger,
+		LocalConf:    n.conf.LocalConf,
+		Logger:       n.conf.Logger,
+	})
+	if err != nil {
+		return err
+	}
+	n.transport = &pullTrackingTransport{Transport: transport}
+	n.conf.Transport = n.transport
+	n.conf.BlockOneQueueBarrier = queue.NewOneQueueBarrier(n.conf.Logger)
+	n.stopServeCh = make(chan struct{})
+
+	if n.replicator, err = bft.NewReplicator(n.conf); err != nil {
+		return err
+	}
+	if err = n.conf.Transport.SetConsensusListener(n.replicator); err != nil {
+		return err
+	}
+	return n.conf.Transport.SetClusterConfig(n.conf.ClusterConfig)
+}
+
+func (n *nodeEnv) Start() error {
+	if err := n.conf.Transport.Start(); err != nil {
+		return err
+	}
+	n.replicator.Start()
+	go n.ServeCommit()
+	return nil
+}
+
+func (n *nodeEnv) Close() error {
+	select {
+	case <-n.stopServeCh:
+		return errors.New("node already closed")
+	default:
+		close(n.stopServeCh)
+	}
+	err := n.replicator.Close()
+	n.conf.Transport.Close()
+	return err
+}
+
+// Restart a closed node, with its ledger.
+func (n *nodeEnv) Restart() error {
+	if err := n.create(); err != nil {
+		return err
+	}
+	return n.Start()
+}
+
+func (n *nodeEnv) ServeCommit() {
+	for {
+		select {
+		case <-n.stopServeCh:
+			return
+		default:
+			b, err := n.conf.BlockOneQueueBarrier.Dequeue()
+			if err != nil {
+				return
+			}
+			if err = n.ledger.Append(b.(*types.Block)); err != nil {
+				n.conf.Logger.Panicf("Stopping to serve commit loop, error: %s", err)
+			}
+			if err = n.conf.BlockOneQueueBarrier.Reply(nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *clusterEnv) Start(t *testing.T) {
+	for _, node := range c.nodes {
+		require.NoError(t, node.Start())
+	}
+}
+
+func (c *clusterEnv) Close() {
+	for _, node := range c.nodes {
+		_ = node.Close()
+	}
+}
+
+func (c *clusterEnv) AssertEqualHeight(height uint64, indices ...int) bool {
+	for _, i := range indices {
+		h, _ := c.nodes[i].ledger.Height()
+		if h != height {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *clusterEnv) AssertCommitSignatures(t *testing.T, index int) {
+	height, err := c.nodes[index].ledger.Height()
+	require.NoError(t, err)
+	for n := uint64(2); n <= height; n++ {
+		block, err := c.nodes[index].ledger.Get(n)
+		require.NoError(t, err)
+		require.NoError(t, bft.VerifyCommitSignatures(block, c.clusterConfig))
+	}
+}
+
+type memLedger struct {
+	mutex  sync.Mutex
+	ledger []*types.Block
+}
+
+func (l *memLedger) Height() (uint64, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return uint64(len(l.ledger)), nil
+}
+
+func (l *memLedger) Append(block *types.Block) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if expected := uint64(len(l.ledger)) + 1; block.GetHeader().GetBaseHeader().GetNumber() != expected {
+		return errors.Errorf("block number [%d] out of sequence, expected [%d]", block.GetHeader().GetBaseHeader().GetNumber(), expected)
+	}
+	l.ledger = append(l.ledger, block)
+	return nil
+}
+
+func (l *memLedger) Get(blockNum uint64) (*types.Block, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if blockNum-1 >= uint64(len(l.ledger)) {
+		return nil, errors.Errorf("block number out of bounds: %d, len: %d", blockNum, len(l.ledger))
+	}
+	return l.ledger[blockNum-1], nil
+}
+
+func testLogger(t *testing.T, level string) *logger.SugarLogger {
+	lg, err := logger.New(&logger.Config{
+		Level:         level,
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+	return lg
+}
+
+func testDataBlock(txID string) *types.Block {
+	return &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 1}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{
+			Envelopes: []*types.DataTxEnvelope{{
+				Payload: &types.DataTx{
+					MustSignUserIds: []string{"alice"},
+					TxId:            txID,
+					DbOperations: []*types.DBOperation{{
+						DbName:     "my-db",
+						DataWrites: []*types.DataWrite{{Key: "key", Value: []byte(txID)}},
+					}},
+				},
+				Signatures: map[string][]byte{"alice": []byte("alice-sig")},
+			}},
+		}},
+	}
+}
+
+// submit blocks to the node until they are all accepted, as a primary may refuse blocks during a view change.
+func testSubmitDataBlocks(t *testing.T, node *nodeEnv, first, numBlocks int) {
+	for i := first; i < first+numBlocks; i++ {
+		block := testDataBlock(fmt.Sprintf("tx%d", i))
+		require.Eventually(t, func() bool {
+			return node.replicator.Submit(proto.Clone(block).(*types.Block)) == nil
+		}, 30*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestNewReplicator(t *testing.T) {
+	env := createClusterEnv(t, 1)
+	conf := *env.nodes[0].conf
+
+	t.Run("no signer", func(t *testing.T) {
+		c := conf
+		c.Signer = nil
+		_, err := bft.NewReplicator(&c)
+		require.EqualError(t, err, "the BFT consensus requires a signer")
+	})
+
+	t.Run("join block", func(t *testing.T) {
+		c := conf
+		c.JoinBlock = &types.Block{}
+		_, err := bft.NewReplicator(&c)
+		require.EqualError(t, err, "the BFT consensus does not support on-boarding with a join block")
+	})
+
+	t.Run("empty ledger", func(t *testing.T) {
+		c := conf
+		c.LedgerReader = &memLedger{}
+		_, err := bft.NewReplicator(&c)
+		require.EqualError(t, err, "the BFT consensus requires a ledger with a genesis block")
+	})
+
+	t.Run("bad view change timeout", func(t *testing.T) {
+		c := conf
+		c.ClusterConfig = proto.Clone(conf.ClusterConfig).(*types.ClusterConfig)
+		c.ClusterConfig.ConsensusConfig.BftConfig.ViewChangeTimeout = "1 sec"
+		_, err := bft.NewReplicator(&c)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse BFT view change timeout")
+	})
+}
+
+func TestReplicator_1Node(t *testing.T) {
+	env := createClusterEnv(t, 1)
+	env.Start(t)
+	defer env.Close()
+
+	node := env.nodes[0]
+	require.Nil(t, node.replicator.IsLeader())
+	leaderID, _ := node.replicator.GetClusterStatus()
+	require.Equal(t, uint64(1), leaderID)
+
+	testSubmitDataBlocks(t, node, 0, 10)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(11, 0) }, 30*time.Second, 10*time.Millisecond)
+	env.AssertCommitSignatures(t, 0)
+
+	_, err := node.replicator.TransferLeadership("", time.Second)
+	require.EqualError(t, err, "leadership transfer is not supported by the BFT consensus")
+	require.IsType(t, &ierrors.BadRequestError{}, err)
+	require.Nil(t, node.replicator.LeadershipTransfer())
+
+	_, err = node.replicator.LinearizableRead(context.Background())
+	require.EqualError(t, err, "linearizable reads are not supported by the BFT consensus")
+	require.IsType(t, &ierrors.ServerRestrictionError{}, err)
+
+	require.NoError(t, node.replicator.Close())
+	require.EqualError(t, node.replicator.Submit(testDataBlock("tx")), "block replicator closed")
+	require.EqualError(t, node.replicator.Close(), "block replicator already closed")
+}
+
+func TestReplicator_4Nodes(t *testing.T) {
+	env := createClusterEnv(t, 4)
+	env.Start(t)
+	defer env.Close()
+
+	// view 0: the primary is node1
+	for i := 1; i < 4; i++ {
+		err := env.nodes[i].replicator.Submit(testDataBlock("tx"))
+		require.EqualError(t, err, "not a leader, leader is RaftID: 1, with HostPort: 127.0.0.1:26001")
+	}
+	testSubmitDataBlocks(t, env.nodes[0], 0, 10)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(11, 0, 1, 2, 3) }, 30*time.Second, 10*time.Millisecond)
+	for i := 0; i < 4; i++ {
+		env.AssertCommitSignatures(t, i)
+	}
+
+	// the primary fails, the members change to view 1, where the primary is node2
+	require.NoError(t, env.nodes[0].Close())
+	require.Eventually(t, func() bool { return env.nodes[1].replicator.IsLeader() == nil }, 30*time.Second, 10*time.Millisecond)
+	for i := 2; i < 4; i++ {
+		require.Eventually(t, func() bool {
+			leaderID, _ := env.nodes[i].replicator.GetClusterStatus()
+			return leaderID == 2
+		}, 30*time.Second, 10*time.Millisecond)
+	}
+	testSubmitDataBlocks(t, env.nodes[1], 10, 10)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(21, 1, 2, 3) }, 30*time.Second, 10*time.Millisecond)
+
+	// the restarted node catches up, and learns the current view
+	require.NoError(t, env.nodes[0].Restart())
+	testSubmitDataBlocks(t, env.nodes[1], 20, 5)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(26, 0, 1, 2, 3) }, 30*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		leaderID, _ := env.nodes[0].replicator.GetClusterStatus()
+		return leaderID == 2
+	}, 30*time.Second, 10*time.Millisecond)
+	for i := 0; i < 4; i++ {
+		env.AssertCommitSignatures(t, i)
+	}
+}
+
+// Scenario: a faulty primary claims in its heartbeats that blocks were committed, without a valid commit certificate.
+// The members do not try to pull the blocks, and keep ordering blocks.
+func TestReplicator_ByzantineHeartbeat(t *testing.T) {
+	env := createClusterEnv(t, 4)
+	env.Start(t)
+	defer env.Close()
+
+	testSubmitDataBlocks(t, env.nodes[0], 0, 5)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(6, 0, 1, 2, 3) }, 30*time.Second, 10*time.Millisecond)
+
+	block, err := env.nodes[0].ledger.Get(6)
+	require.NoError(t, err)
+	digest, err := bft.BlockDigest(block)
+	require.NoError(t, err)
+	valid := &bftpb.CommitCertificate{View: 0, Sequence: 6, Digest: digest}
+	for _, s := range block.GetConsensusMetadata().GetBftSignatures() {
+		valid.Signatures = append(valid.Signatures, &bftpb.CommitSignature{NodeId: s.NodeId, Signature: s.Signature})
+	}
+	reused := proto.Clone(valid).(*bftpb.CommitCertificate)
+	reused.Sequence = 1000
+	signature, err := env.signers["node1"].Sign(bft.CommitSigningBytes(0, 1000, []byte("digest")))
+	require.NoError(t, err)
+	primaryOnly := &bftpb.CommitCertificate{View: 0, Sequence: 1000, Digest: []byte("digest"),
+		Signatures: []*bftpb.CommitSignature{{NodeId: "node1", Signature: signature}}}
+
+	for _, cert := range []*bftpb.CommitCertificate{nil, valid, reused, primaryOnly} {
+		msgBytes, err := proto.Marshal(&bftpb.Message{Type: &bftpb.Message_Heartbeat{Heartbeat: &bftpb.Heartbeat{
+			View:                     0,
+			LastCommitted:            1000,
+			LastCommittedCertificate: cert,
+		}}})
+		require.NoError(t, err)
+		signature, err := env.signers["node1"].Sign(msgBytes)
+		require.NoError(t, err)
+		signed := &bftpb.SignedMessage{From: 1, Message: msgBytes, Signature: signature}
+
+		for i := 1; i < 4; i++ {
+			err := env.nodes[i].replicator.Process(context.Background(), raftpb.Message{
+				To:      uint64(i + 1),
+				From:    1,
+				Entries: []raftpb.Entry{{Data: utils.MarshalOrPanic(signed)}},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	testSubmitDataBlocks(t, env.nodes[0], 5, 5)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(11, 0, 1, 2, 3) }, 30*time.Second, 10*time.Millisecond)
+	for i := 1; i < 4; i++ {
+		require.Less(t, env.nodes[i].transport.MaxPulled(), uint64(1000))
+		leaderID, _ := env.nodes[i].replicator.GetClusterStatus()
+		require.Equal(t, uint64(1), leaderID)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -62,15 +63,17 @@ type BlockReplicator struct {
 	raftStorage       *RaftStorage
 	raftConfig        *raft.Config
 	oneQueueBarrier   *queue.OneQueueBarrier // Synchronizes the block-replication deliver with the block-processor commit
-	transport         *comm.HTTPTransport
+	transport         comm.Transport
 	ledgerReader      BlockLedgerReader
 	pendingTxs        PendingTxsReleaser
 	configTxValidator ConfigTxValidator
+	stateTransferer   StateTransferer
 
 	stopCh        chan struct{}
 	stopOnce      sync.Once
 	doneProposeCh chan struct{}
 	doneEventCh   chan struct{}
+	readIndexSeq  uint64 // numbers the context of read index requests, accessed atomically
 
 	// shared state between the propose-loop go-routine and event-loop go-routine; as well as transport go-routines.
 	mutex                           sync.Mutex
@@ -85,10 +88,14 @@ type BlockReplicator struct {
 	cancelProposeContext            func() // cancels the propose-context if leadership is lost
 	lastProposedBlockNumber         uint64
 	lastProposedBlockHeaderBaseHash []byte
+	lastProposedBlockTimestamp      int64 // the time of a proposed block does not decrease along the chain
 	lastCommittedBlock              *types.Block
 	numInFlightBlocks               uint32 // number of in-flight blocks
 	inFlightConfigBlockNumber       uint64 // the block number of the in-flight config, if any; 0 if none
 	condTooManyInFlightBlocks       *sync.Cond
+	transferringLeadership          bool                         // the leader is handing over the leadership, and does not accept new blocks
+	leadershipTransfer              *types.LeadershipTransfer    // the last leadership transfer requested on this node, if any
+	readIndexRequests               map[string]*readIndexRequest // linearizable reads waiting for a read index, or for the commit to reach it
 
 	appliedIndex uint64
 
@@ -107,10 +114,12 @@ type Config struct {
 	ClusterConfig        *types.ClusterConfig
 	JoinBlock            *types.Block
 	LedgerReader         BlockLedgerReader
-	Transport            *comm.HTTPTransport
+	Transport            comm.Transport
 	BlockOneQueueBarrier *queue.OneQueueBarrier
 	PendingTxs           PendingTxsReleaser
 	ConfigValidator      ConfigTxValidator
+	StateTransferer      StateTransferer // optional, catch-up pulls and commits all the blocks if nil
+	Signer               crypto.Signer   // signs the consensus messages of consenters that need it, i.e. BFT
 	Logger               *logger.SugarLogger
 }
 
@@ -123,14 +132,20 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 
 	lg := conf.Logger.With("nodeID", conf.LocalConf.Server.Identity.ID, "raftID", raftID)
 
-	haveWAL := wal.Exist(conf.LocalConf.Replication.WALDir)
-	storage, err := CreateStorage(lg, conf.LocalConf.Replication.WALDir, conf.LocalConf.Replication.SnapDir)
-	if err != nil {
-		return nil, errors.Errorf("failed to restore persisted raft data: %s", err)
+	var haveWAL bool
+	var storage *RaftStorage
+	if conf.LocalConf.Server.Database.InMemory() {
+		storage = CreateInMemoryStorage(lg)
+	} else {
+		haveWAL = wal.Exist(conf.LocalConf.Replication.WALDir)
+		storage, err = CreateStorage(lg, conf.LocalConf.Replication.WALDir, conf.LocalConf.Replication.SnapDir)
+		if err != nil {
+			return nil, errors.Errorf("failed to restore persisted raft data: %s", err)
+		}
 	}
 	storage.SnapshotCatchUpEntries = DefaultSnapshotCatchUpEntries
 
-	var snapBlkNum uint64
+	var snapBlkNum, snapIndex uint64
 	var confState raftpb.ConfState
 	if s := storage.Snapshot(); !raft.IsEmptySnap(s) {
 		snapBlock := &types.Block{}
@@ -139,6 +154,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		}
 
 		snapBlkNum = snapBlock.GetHeader().GetBaseHeader().GetNumber()
+		snapIndex = s.Metadata.Index
 		confState = s.Metadata.ConfState
 		lg.Debugf("Starting from last snapshot: block number [%d], Raft ConfState: %+v", snapBlkNum, confState)
 	}
@@ -155,6 +171,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		ledgerReader:         conf.LedgerReader,
 		pendingTxs:           conf.PendingTxs,
 		configTxValidator:    conf.ConfigValidator,
+		stateTransferer:      conf.StateTransferer,
 		stopCh:               make(chan struct{}),
 		doneProposeCh:        make(chan struct{}),
 		doneEventCh:          make(chan struct{}),
@@ -163,6 +180,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		sizeLimit:            conf.ClusterConfig.ConsensusConfig.RaftConfig.SnapshotIntervalSize,
 		lastSnapBlockNum:     snapBlkNum,
 		confState:            confState,
+		readIndexRequests:    make(map[string]*readIndexRequest),
 		lg:                   lg,
 	}
 	br.condTooManyInFlightBlocks = sync.NewCond(&br.mutex)
@@ -178,6 +196,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 			br.lg.Panicf("Failed to read last block: %s", err)
 		}
 		br.lastProposedBlockNumber = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+		br.lastProposedBlockTimestamp = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetTimestamp()
 		if baseHash, err := blockstore.ComputeBlockBaseHash(br.lastCommittedBlock); err == nil {
 			br.lastProposedBlockHeaderBaseHash = baseHash
 		} else {
@@ -191,6 +210,12 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		br.lg.Debugf("last block [%d], consensus metadata: %+v", height, metadata)
 	}
 
+	// The ledger already contains the snapshot block, but it carries no consensus metadata. This happens when a new
+	// cluster with observers is bootstrapped from a snapshot of the genesis block.
+	if height > 0 && height >= snapBlkNum && br.appliedIndex < snapIndex {
+		br.appliedIndex = snapIndex
+	}
+
 	//DO NOT use Applied option in config, we guard against replay of written blocks with `appliedIndex` instead.
 	br.raftConfig = &raft.Config{
 		ID:              raftID,
@@ -234,13 +259,54 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 				br.runCampaign = true
 				lg.Info("This node was selected to run a leader election campaign on the new cluster")
 			}
-			br.raftNode = raft.StartNode(br.raftConfig, startPeers)
+			if len(br.clusterConfig.ConsensusConfig.RaftObservers()) == 0 {
+				br.raftNode = raft.StartNode(br.raftConfig, startPeers)
+			} else {
+				// raft.StartNode bootstraps all the peers as voters, observers must join as learners.
+				if err = br.bootstrapWithObservers(genesisBytes); err != nil {
+					return nil, err
+				}
+				br.raftNode = raft.RestartNode(br.raftConfig)
+			}
 		}
 	}
 
 	return br, nil
 }
 
+// bootstrapWithObservers starts the Raft storage of a new cluster that has observers with a snapshot of the genesis
+// block. The snapshot carries a raftpb.ConfState in which the members are voters and the observers are learners.
+// All the peers bootstrap from the same snapshot, hence their Raft logs are identical.
+func (br *BlockReplicator) bootstrapWithObservers(genesisBytes []byte) error {
+	br.confState = raftpb.ConfState{}
+	for _, peer := range br.clusterConfig.ConsensusConfig.Members {
+		br.confState.Voters = append(br.confState.Voters, peer.RaftId)
+	}
+	for _, peer := range br.clusterConfig.ConsensusConfig.RaftObservers() {
+		br.confState.Learners = append(br.confState.Learners, peer.RaftId)
+	}
+	sort.Slice(br.confState.Voters, func(i, j int) bool { return br.confState.Voters[i] < br.confState.Voters[j] })
+	sort.Slice(br.confState.Learners, func(i, j int) bool { return br.confState.Learners[i] < br.confState.Learners[j] })
+	br.lg.Infof("Bootstrapping Raft from the genesis block, voters: %v, learners: %v", br.confState.Voters, br.confState.Learners)
+
+	snapshot := raftpb.Snapshot{
+		Data: genesisBytes,
+		Metadata: raftpb.SnapshotMetadata{
+			ConfState: br.confState,
+			Index:     1,
+			Term:      1,
+		},
+	}
+	if err := br.raftStorage.Store(nil, raftpb.HardState{}, snapshot); err != nil {
+		return errors.Wrap(err, "failed to store genesis block as snapshot in raft storage")
+	}
+
+	br.lastSnapBlockNum = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+	br.appliedIndex = snapshot.Metadata.Index
+
+	return nil
+}
+
 func (br *BlockReplicator) RaftID() uint64 {
 	return br.raftID
 }
@@ -323,8 +389,12 @@ func (br *BlockReplicator) startOnBoarding() {
 	for _, peer := range br.clusterConfig.GetConsensusConfig().GetMembers() {
 		br.confState.Voters = append(br.confState.Voters, peer.RaftId)
 	}
+	for _, peer := range br.clusterConfig.GetConsensusConfig().RaftObservers() {
+		br.confState.Learners = append(br.confState.Learners, peer.RaftId)
+	}
 	sort.Slice(br.confState.Voters, func(i, j int) bool { return br.confState.Voters[i] < br.confState.Voters[j] })
-	br.lg.Infof("Starting Raft on an existing cluster, current peers: %v", br.confState.Voters)
+	sort.Slice(br.confState.Learners, func(i, j int) bool { return br.confState.Learners[i] < br.confState.Learners[j] })
+	br.lg.Infof("Starting Raft on an existing cluster, current peers: %v, observers: %v", br.confState.Voters, br.confState.Learners)
 
 	snapshot := raftpb.Snapshot{
 		Data: snapData,
@@ -415,6 +485,8 @@ Event_Loop:
 
 			br.processLeaderJustElected()
 
+			br.processReadStates(rd.ReadStates)
+
 			// update last known leader
 			if rd.SoftState != nil {
 				leader := atomic.LoadUint64(&rd.SoftState.Lead) // etcdraft requires atomic access to this var
@@ -551,6 +623,7 @@ func (br *BlockReplicator) processLeaderChanges(leader uint64) {
 func (br *BlockReplicator) resetLastProposed() {
 	var err error
 	br.lastProposedBlockNumber = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+	br.lastProposedBlockTimestamp = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetTimestamp()
 	br.lastProposedBlockHeaderBaseHash, err = blockstore.ComputeBlockBaseHash(br.lastCommittedBlock)
 	if err != nil {
 		br.lg.Panicf("Error computing base header hash of last commited block: %+v; error: %s",
@@ -587,7 +660,10 @@ func (br *BlockReplicator) catchUp(snap raftpb.Snapshot) error {
 	br.confState = snap.Metadata.ConfState
 	br.appliedIndex = snap.Metadata.Index
 
-	err := br.catchUpToBlock(initBlockNumber, snapBlock.Header.BaseHeader.Number, true)
+	err := br.transferStateIfFarBehind(initBlockNumber, snapBlock, true)
+	if err == nil {
+		err = br.catchUpToBlock(br.getLastCommittedBlockNumber(), snapBlock, true)
+	}
 	if err != nil {
 		switch err.(type) {
 		case *ierrors.ClosedError:
@@ -619,7 +695,11 @@ func (br *BlockReplicator) onBoard(joinBlock *types.Block) error {
 	br.lg.Infof("Starting on-boarding state transfer; From block: %d, To block: %d", initBlockNumber, joinBlockNumber)
 	// we do not update the cluster-config with incoming config blocks because the join-block is the most updated
 	// config, and it is already applied to `replication` and `comm`.
-	err := br.catchUpToBlock(initBlockNumber, joinBlockNumber, false)
+	err := br.transferStateIfFarBehind(initBlockNumber, joinBlock, false)
+	if err != nil {
+		return err
+	}
+	err = br.catchUpToBlock(br.getLastCommittedBlockNumber(), joinBlock, false)
 	if err != nil {
 		return err
 	}
@@ -629,6 +709,11 @@ func (br *BlockReplicator) onBoard(joinBlock *types.Block) error {
 
 	if lastBlockNumber > 1 {
 		metadata := br.lastCommittedBlock.GetConsensusMetadata()
+		if lastBlockNumber > joinBlockNumber {
+			// A state transfer went past the join-block, Raft starts from the join-block and the blocks after it are
+			// skipped when they are delivered.
+			metadata = joinBlockMeta
+		}
 		br.appliedIndex = metadata.GetRaftIndex()
 		br.lg.Debugf("last block [%d], consensus metadata: %+v", lastBlockNumber, metadata)
 	}
@@ -636,44 +721,40 @@ func (br *BlockReplicator) onBoard(joinBlock *types.Block) error {
 	return nil
 }
 
-// Pull the missing blocks, starting with one past the last block we have, and ending with the target block number
-// (inclusive); that is, get (initBlockNumber, targetBlockNumber].
+// Pull the missing blocks, starting with one past the last block we have, and ending with the target block (inclusive);
+// that is, get (initBlockNumber, targetBlockNumber]. The blocks are pulled concurrently from the cluster members and
+// observers, and are committed in order once they are verified against the base header hash of the target block, which
+// comes from Raft.
 //
 // When catching-up to a snapshot, we update `replication` and `comm` with each config block we bring.
 // When pulling blocks during on-boarding, we do not, because the latest cluster-config comes from the join-block.
-func (br *BlockReplicator) catchUpToBlock(initBlockNumber, targetBlockNumber uint64, updateConfig bool) error {
-	for nextBlockNumber := initBlockNumber + 1; nextBlockNumber <= targetBlockNumber; {
-		var blocks []*types.Block
-		var err error
-		blocksReadyCh := make(chan struct{})
-		ctx, cancel := context.WithCancel(context.Background())
-
-		//Try to pull some blocks in a go-routine so that we may cancel it if the server shuts down.
-		//Note that `PullBlocks` will not necessarily return all the blocks we requested, hence the enclosing loop.
-		go func() {
-			defer close(blocksReadyCh)
-			blocks, err = br.transport.PullBlocks(ctx, nextBlockNumber, targetBlockNumber, br.GetLeaderID())
-		}()
+func (br *BlockReplicator) catchUpToBlock(initBlockNumber uint64, targetBlock *types.Block, updateConfig bool) error {
+	targetBlockNumber := targetBlock.GetHeader().GetBaseHeader().GetNumber()
+	if initBlockNumber >= targetBlockNumber {
+		return nil
+	}
+	targetBaseHash, err := blockstore.ComputeBlockBaseHash(targetBlock)
+	if err != nil {
+		return err
+	}
 
-		select {
-		case <-br.stopCh:
-			cancel()
-			<-blocksReadyCh
-			return &ierrors.ClosedError{ErrMsg: "server stopped during catch-up"}
-		case <-blocksReadyCh:
-			cancel()
-			if err != nil {
-				lastBlockNumber := br.getLastCommittedBlockNumber()
-				switch err.(type) {
-				case *ierrors.ClosedError:
-					br.lg.Warnf("closing, stopping to pull blocks from cluster; last block number [%d]", lastBlockNumber)
-					return err
-				default:
-					return errors.Wrapf(err, "failed to pull blocks from cluster; last block number [%d]", lastBlockNumber)
-				}
-			}
+	br.mutex.Lock()
+	lastBlock := br.lastCommittedBlock
+	br.mutex.Unlock()
+	if lastBlock.GetHeader().GetBaseHeader().GetNumber() != initBlockNumber {
+		return errors.Errorf("catch-up must start from the last committed block [%d], not [%d]",
+			lastBlock.GetHeader().GetBaseHeader().GetNumber(), initBlockNumber)
+	}
 
-			br.lg.Infof("Going to commit [%d] blocks", len(blocks)) //Not necessarily the entire range requested!
+	doneCh := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Pull and commit in a go-routine so that we may cancel it if the server shuts down.
+	go func() {
+		defer close(doneCh)
+		err = br.transport.PullBlocksConcurrently(ctx, lastBlock, targetBlockNumber, targetBaseHash, br.GetLeaderID(), func(blocks []*types.Block) error {
+			br.lg.Infof("Going to commit [%d] blocks", len(blocks))
 
 			for _, blockToCommit := range blocks {
 				br.lg.Infof("enqueue for commit block [%d], ConsensusMetadata: [%+v]",
@@ -681,20 +762,32 @@ func (br *BlockReplicator) catchUpToBlock(initBlockNumber, targetBlockNumber uin
 					blockToCommit.GetConsensusMetadata())
 
 				if err := br.commitBlock(blockToCommit, updateConfig); err != nil {
-					lastBlockNumber := br.getLastCommittedBlockNumber()
-					switch err.(type) {
-					case *ierrors.ClosedError:
-						br.lg.Warnf("closing, stopping to pull blocks from cluster; last block number [%d]", lastBlockNumber)
-						return err
-					default:
-						return err
-					}
+					return err
 				}
-
-				nextBlockNumber++
 			}
+			return nil
+		})
+	}()
+
+	select {
+	case <-br.stopCh:
+		cancel()
+		<-doneCh
+		return &ierrors.ClosedError{ErrMsg: "server stopped during catch-up"}
+	case <-doneCh:
+	}
+
+	if err != nil {
+		lastBlockNumber := br.getLastCommittedBlockNumber()
+		switch err.(type) {
+		case *ierrors.ClosedError:
+			br.lg.Warnf("closing, stopping to pull blocks from cluster; last block number [%d]", lastBlockNumber)
+			return err
+		default:
+			return errors.WithMessagef(err, "failed to pull blocks from cluster; last block number [%d]", lastBlockNumber)
 		}
 	}
+
 	return nil
 }
 
@@ -729,16 +822,27 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 				break
 			}
 
+			blockBytes, err := decodeEntryData(committedEntries[i].Data, br.localConf.Replication.MessageSizeLimit())
+			if err != nil {
+				br.lg.Panicf("Error decoding entry [#%d], entry: %+v, error: %s", i, committedEntries[i], err)
+			}
 			var block = &types.Block{}
-			if err := proto.Unmarshal(committedEntries[i].Data, block); err != nil {
+			if err := proto.Unmarshal(blockBytes, block); err != nil {
 				br.lg.Panicf("Error unmarshaling entry [#%d], entry: %+v, error: %s", i, committedEntries[i], err)
 			}
+			// A state transfer may bring the ledger past the snapshot it was triggered by, the blocks it brought
+			// must not be committed again.
+			if blockNumber := block.GetHeader().GetBaseHeader().GetNumber(); blockNumber <= br.getLastCommittedBlockNumber() {
+				br.lg.Debugf("Received block [%d] <= last committed block [%d], skip", blockNumber, br.getLastCommittedBlockNumber())
+				break
+			}
+
 			block.ConsensusMetadata = &types.ConsensusMetadata{
 				RaftTerm:  committedEntries[i].Term,
 				RaftIndex: committedEntries[i].Index,
 			}
 
-			err := br.commitBlock(block, true)
+			err = br.commitBlock(block, true)
 			if err != nil {
 				br.lg.Errorf("commit block error: %s, stopping block replicator", err.Error())
 				return false
@@ -781,12 +885,18 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 				skipCommit = true
 			}
 
+			var block = &types.Block{}
 			if !skipCommit && ccV2.Context != nil {
-				var block = &types.Block{}
 				if err := proto.Unmarshal(ccV2.Context, block); err != nil {
 					br.lg.Panicf("Error unmarshaling entry [#%d], entry: %+v, error: %s", i, committedEntries[i], err)
 				}
+				if blockNumber := block.GetHeader().GetBaseHeader().GetNumber(); blockNumber <= br.getLastCommittedBlockNumber() {
+					br.lg.Debugf("Received config block [%d] <= last committed block [%d], skip commit", blockNumber, br.getLastCommittedBlockNumber())
+					skipCommit = true
+				}
+			}
 
+			if !skipCommit && ccV2.Context != nil {
 				block.ConsensusMetadata = &types.ConsensusMetadata{
 					RaftTerm:  committedEntries[i].Term,
 					RaftIndex: committedEntries[i].Index,
@@ -800,14 +910,14 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 			}
 
 			br.confState = *br.raftNode.ApplyConfChange(ccV2)
-			br.lg.Infof("Applied config changes: %+v, current nodes in cluster: %+v", ccV2.Changes, br.confState.Voters)
+			br.lg.Infof("Applied config changes: %+v, current nodes in cluster: %+v, observers: %+v", ccV2.Changes, br.confState.Voters, br.confState.Learners)
 			br.lg.Infof("Raft ConfState: %+v", br.confState)
 
 			// TODO detect removal of leader?
 
-			// detect removal of self
+			// detect removal of self, observers are learners
 			removalOfSelf := true
-			for _, id := range br.confState.Voters {
+			for _, id := range append(append([]uint64{}, br.confState.Voters...), br.confState.Learners...) {
 				if id == br.raftID {
 					removalOfSelf = false
 					break
@@ -831,10 +941,14 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 		var snapData []byte
 		switch committedEntries[position].Type {
 		case raftpb.EntryNormal:
-			if err := proto.Unmarshal(committedEntries[position].Data, snapBlock); err != nil {
+			blockBytes, err := decodeEntryData(committedEntries[position].Data, br.localConf.Replication.MessageSizeLimit())
+			if err != nil {
+				br.lg.Panicf("Error decoding Normal entry [#%d], entry: %+v, error: %s", position, committedEntries[position], err)
+			}
+			if err := proto.Unmarshal(blockBytes, snapBlock); err != nil {
 				br.lg.Panicf("Error unmarshaling Normal entry [#%d], entry: %+v, error: %s", position, committedEntries[position], err)
 			}
-			snapData = committedEntries[position].Data
+			snapData = blockBytes
 		case raftpb.EntryConfChangeV2:
 			var ccV2 raftpb.ConfChangeV2
 			if err := ccV2.Unmarshal(committedEntries[position].Data); err != nil {
@@ -942,9 +1056,18 @@ func (br *BlockReplicator) proposeRegular(blockToPropose *types.Block) bool {
 		return false
 	}
 
+	br.mutex.Lock()
+	raftConfig := br.clusterConfig.GetConsensusConfig().GetRaftConfig()
+	br.mutex.Unlock()
+
+	entryData, err := encodeEntryData(raftConfig.GetEntryFormatVersion(), raftConfig.GetCompression(), blockBytes)
+	if err != nil {
+		br.lg.Panicf("Error compressing a block: %s", err)
+	}
+
 	// Propose to raft: the call to raft.Node.Propose() may block when a leader loses its leadership and has no quorum.
 	// It is cancelled when the node loses leadership, by the event-loop go-routine.
-	err := br.raftNode.Propose(ctx, blockBytes)
+	err = br.raftNode.Propose(ctx, entryData)
 	if err != nil {
 		br.releasePendingTXs(blockToPropose, "Failed to propose block", err)
 		return false
@@ -953,7 +1076,8 @@ func (br *BlockReplicator) proposeRegular(blockToPropose *types.Block) bool {
 	return true
 }
 
-// proposeMembershipConfigChange propose membership config changes, that is, adding or removing a peer.
+// proposeMembershipConfigChange propose membership config changes, that is, adding or removing a peer. A peer that is
+// added as an observer joins Raft as a learner.
 // This is proposed in a 'raftpb.ConfChangeV2' message using the 'ProposeConfChange' API. This call consents on the
 // Raft membership change as well as on the config block, which is given as the 'ConfChangeV2.Context'.
 // The return value signals whether the proposal completed correctly.
@@ -968,9 +1092,14 @@ func (br *BlockReplicator) proposeMembershipConfigChange(blockToPropose *types.B
 		Transition: raftpb.ConfChangeTransitionAuto,
 		Context:    blockBytes,
 	}
+	newConsensusConfig := blockToPropose.GetConfigTxEnvelope().GetPayload().GetNewConfig().GetConsensusConfig()
 	for _, peer := range addedPeers {
+		changeType := raftpb.ConfChangeAddNode
+		if isObserver(newConsensusConfig, peer.NodeId) {
+			changeType = raftpb.ConfChangeAddLearnerNode
+		}
 		ccV2.Changes = append(ccV2.Changes, raftpb.ConfChangeSingle{
-			Type:   raftpb.ConfChangeAddNode,
+			Type:   changeType,
 			NodeID: peer.RaftId,
 		})
 	}
@@ -1010,7 +1139,8 @@ func (br *BlockReplicator) releasePendingTXs(blockToPropose *types.Block, reason
 func (br *BlockReplicator) prepareProposal(blockToPropose *types.Block) (ctx context.Context, blockBytes []byte, doPropose bool) {
 	br.mutex.Lock()
 
-	if errLeader := br.isLeaderReady(); errLeader != nil {
+	// Blocks submitted before a leadership transfer had started are still proposed, the transfer waits for them to commit.
+	if errLeader := br.isLeaderReady(); errLeader != nil && !(br.transferringLeadership && br.isLeader() == nil) {
 		br.mutex.Unlock() //do not call the pendingTxs component with a mutex locked
 
 		br.releasePendingTXs(blockToPropose, "Declined to propose block", errLeader)
@@ -1044,6 +1174,7 @@ func (br *BlockReplicator) updateLastProposal(lastBlockProposed *types.Block) {
 
 	if br.isLeader() == nil {
 		br.lastProposedBlockNumber = lastBlockProposed.GetHeader().GetBaseHeader().GetNumber()
+		br.lastProposedBlockTimestamp = lastBlockProposed.GetHeader().GetBaseHeader().GetTimestamp()
 		if baseHash, err := blockstore.ComputeBlockBaseHash(lastBlockProposed); err == nil {
 			br.lastProposedBlockHeaderBaseHash = baseHash
 		} else {
@@ -1136,13 +1267,18 @@ func (br *BlockReplicator) isLeader() *ierrors.NotLeaderError {
 // If this node is the leader, and it is ready, it returns nil.
 // If this node is the leader, but it is not ready, it returns an empty `NotLeaderError` error.
 // If this node is not the leader, it returns the last know leader in a `NotLeaderError` error.
-// A leader is not ready when it was just elected and still has in-flight blocks.
+// A leader is not ready when it was just elected and still has in-flight blocks, or when it is transferring the
+// leadership to another member.
 func (br *BlockReplicator) isLeaderReady() *ierrors.NotLeaderError {
 	if br.lastKnownLeader == br.raftID {
 		if br.justElectedInFlightBlocks {
 			// this node was just elected leader, but may be processing in-flight messages
 			return &ierrors.NotLeaderError{}
 		}
+		if br.transferringLeadership {
+			// this node is handing over the leadership, the new leader is not known yet
+			return &ierrors.NotLeaderError{}
+		}
 		return nil
 	}
 
@@ -1163,8 +1299,8 @@ func (br *BlockReplicator) GetClusterStatus() (leaderID uint64, activePeers map[
 
 	activePeers = br.transport.ActivePeers(500*time.Millisecond, true)
 
-	if br.lastKnownLeader == br.raftID && br.justElectedInFlightBlocks {
-		leaderID = 0 // it is this node, but it is not ready yet
+	if br.lastKnownLeader == br.raftID && (br.justElectedInFlightBlocks || br.transferringLeadership) {
+		leaderID = 0 // it is this node, but it is not ready yet, or it is handing over the leadership
 	} else {
 		leaderID = br.lastKnownLeader
 	}
@@ -1331,6 +1467,12 @@ func (br *BlockReplicator) isIDRemoved(id uint64) bool {
 			return false
 		}
 	}
+	for _, observer := range br.clusterConfig.GetConsensusConfig().RaftObservers() {
+		if observer.RaftId == id {
+			br.lg.Debugf("isIDRemoved: %d, false", id)
+			return false
+		}
+	}
 
 	br.lg.Debugf("isIDRemoved: %d, true", id)
 	return true
@@ -1375,6 +1517,10 @@ func (br *BlockReplicator) insertBlockBaseHeader(proposedBlock *types.Block) {
 	baseHeader := &types.BlockHeaderBase{
 		Number:                 blockNum,
 		PreviousBaseHeaderHash: br.lastProposedBlockHeaderBaseHash,
+		Timestamp:              time.Now().Unix(),
+	}
+	if baseHeader.Timestamp < br.lastProposedBlockTimestamp {
+		baseHeader.Timestamp = br.lastProposedBlockTimestamp
 	}
 
 	if blockNum > 1 {
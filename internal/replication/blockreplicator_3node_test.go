@@ -4,6 +4,7 @@
 package replication_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -379,6 +382,26 @@ func TestBlockReplicator_3Node_SubmitRecover(t *testing.T) {
 // - Restart the node, wait for leader, wait for node to get missing blocks.
 //   Recovering node is expected to get a snapshot from the leader and trigger catch-up from it.
 func TestBlockReplicator_3Node_Catchup(t *testing.T) {
+	testBlockReplicator3NodeCatchup(t, comm.TransportHTTP, "")
+}
+
+// Scenario: same as TestBlockReplicator_3Node_Catchup, over the gRPC transport.
+func TestBlockReplicator_3Node_Catchup_GRPCTransport(t *testing.T) {
+	testBlockReplicator3NodeCatchup(t, comm.TransportGRPC, "")
+}
+
+// Scenario: same as TestBlockReplicator_3Node_Catchup, with the blocks compressed in the Raft entries and in the
+// catch-up responses.
+func TestBlockReplicator_3Node_Catchup_Compression(t *testing.T) {
+	t.Run("http zstd", func(t *testing.T) {
+		testBlockReplicator3NodeCatchup(t, comm.TransportHTTP, compression.Zstd)
+	})
+	t.Run("grpc gzip", func(t *testing.T) {
+		testBlockReplicator3NodeCatchup(t, comm.TransportGRPC, compression.Gzip)
+	})
+}
+
+func testBlockReplicator3NodeCatchup(t *testing.T, transport, compressionAlgorithm string) {
 	block := &types.Block{
 		Header: &types.BlockHeader{
 			BaseHeader: &types.BlockHeaderBase{
@@ -388,10 +411,34 @@ func TestBlockReplicator_3Node_Catchup(t *testing.T) {
 		},
 		Payload: &types.Block_DataTxEnvelopes{},
 	}
+	entrySize := len(utils.MarshalOrPanic(block))
+	if compression.Enabled(compressionAlgorithm) {
+		// a payload that compresses well
+		block.Payload = &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					{
+						Payload: &types.DataTx{
+							DbOperations: []*types.DBOperation{
+								{
+									DbName:     "bdb",
+									DataWrites: []*types.DataWrite{{Key: "key", Value: []byte(strings.Repeat(`{"a":1}`, 100))}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		compressed, err := compression.Compress(compressionAlgorithm, utils.MarshalOrPanic(block))
+		require.NoError(t, err)
+		entrySize = len(compressed) + 2
+	}
 	raftConfig := proto.Clone(raftConfigNoSnapshots).(*types.RaftConfig)
-	raftConfig.SnapshotIntervalSize = uint64(4*len(utils.MarshalOrPanic(block)) + 1) // snapshot every ~5 blocks
+	raftConfig.SnapshotIntervalSize = uint64(4*entrySize + 1) // snapshot every ~5 blocks
+	raftConfig.Compression = compressionAlgorithm
 
-	env := createClusterEnv(t, 3, raftConfig, "info")
+	env := createClusterEnvWithTransport(t, 3, 0, transport, raftConfig, "info")
 	defer os.RemoveAll(env.testDir)
 	require.Equal(t, 3, len(env.nodes))
 
@@ -895,3 +942,187 @@ func TestBlockReplicator_3Node_InFlightBlocks(t *testing.T) {
 
 	require.True(t, isCountOver(4))
 }
+
+// Scenario:
+// - Start 3 members and 1 observer together, wait for leader,
+// - Submit blocks, wait for all ledgers to get them, including the observer,
+// - Stop the leader, wait for a new leader among the members,
+// - Restart the observer, submit blocks, wait for the observer to get them.
+func TestBlockReplicator_3Node_Observer(t *testing.T) {
+	env := createClusterEnvWithObservers(t, 4, 1, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 4, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	// wait for an agreed leader, the observer follows it as well
+	assert.Eventually(t, func() bool { return env.ExistsAgreedLeader() }, 30*time.Second, 100*time.Millisecond)
+	leaderIndex := env.FindLeaderIndex()
+	require.NotEqual(t, 3, leaderIndex)
+
+	numBlocks := uint64(10)
+	testSubmitDataBlocks(t, env, numBlocks, 32)
+	require.NoError(t, env.AssertEqualLedger())
+
+	// the observer is not electable
+	t.Logf("Stopping leader, index: %d", leaderIndex)
+	err := env.nodes[leaderIndex].Close()
+	require.NoError(t, err)
+	var running []int
+	for i := range env.nodes {
+		if i != leaderIndex {
+			running = append(running, i)
+		}
+	}
+	stoppedIndex := leaderIndex
+	isLeaderCond := func() bool {
+		idx := env.AgreedLeaderIndex(running...)
+		return idx >= 0 && idx != stoppedIndex
+	}
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+	leaderIndex = env.AgreedLeaderIndex(running...)
+	require.NotEqual(t, 3, leaderIndex)
+	require.EqualError(t, env.nodes[3].blockReplicator.IsLeader(), fmt.Sprintf("not a leader, leader is RaftID: %d, with HostPort: 127.0.0.1:2200%d", leaderIndex+1, leaderIndex+1))
+
+	// restart the observer, it recovers from the bootstrap snapshot
+	err = env.nodes[3].Close()
+	require.NoError(t, err)
+	err = env.nodes[3].Restart()
+	require.NoError(t, err)
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	block, _ := testDataBlock(32)
+	for i := uint64(0); i < numBlocks; i++ {
+		err := env.nodes[leaderIndex].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(2*numBlocks+1, running...) }, 30*time.Second, 100*time.Millisecond)
+
+	for _, idx := range running {
+		err := env.nodes[idx].Close()
+		require.NoError(t, err)
+	}
+}
+
+func TestBlockReplicator_3Node_TransferLeadership(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool { return env.ExistsAgreedLeader() }, 30*time.Second, 100*time.Millisecond)
+	leaderIndex := env.FindLeaderIndex()
+	targetIndex := (leaderIndex + 1) % 3
+	followerIndex := (leaderIndex + 2) % 3
+	targetNodeID := fmt.Sprintf("node%d", targetIndex+1)
+
+	numBlocks := uint64(10)
+	testSubmitDataBlocks(t, env, numBlocks, 32)
+
+	// requests that cannot start a transfer
+	transfer, err := env.nodes[followerIndex].blockReplicator.TransferLeadership(targetNodeID, 0)
+	require.EqualError(t, err, fmt.Sprintf("not a leader, leader is RaftID: %d, with HostPort: 127.0.0.1:2200%d", leaderIndex+1, leaderIndex+1))
+	require.Nil(t, transfer)
+	transfer, err = env.nodes[leaderIndex].blockReplicator.TransferLeadership(fmt.Sprintf("node%d", leaderIndex+1), 0)
+	require.EqualError(t, err, fmt.Sprintf("the target node [node%d] is already the leader", leaderIndex+1))
+	require.Nil(t, transfer)
+	transfer, err = env.nodes[leaderIndex].blockReplicator.TransferLeadership("node4", 0)
+	require.EqualError(t, err, "the target node [node4] is not a consensus member")
+	require.Nil(t, transfer)
+	require.Nil(t, env.nodes[leaderIndex].blockReplicator.LeadershipTransfer())
+
+	// blocks submitted before the transfer are committed before the leadership moves
+	block, _ := testDataBlock(32)
+	for i := uint64(0); i < numBlocks; i++ {
+		err := env.nodes[leaderIndex].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+	}
+
+	transfer, err = env.nodes[leaderIndex].blockReplicator.TransferLeadership(targetNodeID, 30*time.Second)
+	require.NoError(t, err)
+	expectedTransfer := &types.LeadershipTransfer{
+		FromNodeId: fmt.Sprintf("node%d", leaderIndex+1),
+		ToNodeId:   targetNodeID,
+		Status:     types.LeadershipTransfer_SUCCEEDED,
+	}
+	require.True(t, proto.Equal(expectedTransfer, transfer), "transfer: %v", transfer)
+	require.True(t, proto.Equal(expectedTransfer, env.nodes[leaderIndex].blockReplicator.LeadershipTransfer()))
+
+	isTargetLeaderCond := func() bool {
+		return env.AgreedLeaderIndex() == targetIndex
+	}
+	assert.Eventually(t, isTargetLeaderCond, 30*time.Second, 100*time.Millisecond)
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(2*numBlocks + 1) }, 30*time.Second, 100*time.Millisecond)
+	require.EqualError(t, env.nodes[leaderIndex].blockReplicator.IsLeader(),
+		fmt.Sprintf("not a leader, leader is RaftID: %d, with HostPort: 127.0.0.1:2200%d", targetIndex+1, targetIndex+1))
+
+	// the new leader accepts blocks
+	for i := uint64(0); i < numBlocks; i++ {
+		err := env.nodes[targetIndex].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(3*numBlocks + 1) }, 30*time.Second, 100*time.Millisecond)
+	require.NoError(t, env.AssertEqualLedger())
+
+	for _, node := range env.nodes {
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
+
+func TestBlockReplicator_3Node_LinearizableRead(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool { return env.ExistsAgreedLeader() }, 30*time.Second, 100*time.Millisecond)
+	leaderIndex := env.FindLeaderIndex()
+
+	numBlocks := uint64(10)
+	testSubmitDataBlocks(t, env, numBlocks, 32)
+
+	for _, node := range env.nodes {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		blockNum, err := node.blockReplicator.LinearizableRead(ctx)
+		cancel()
+		require.NoError(t, err)
+		require.Equal(t, numBlocks+1, blockNum)
+		height, err := node.ledger.Height()
+		require.NoError(t, err)
+		require.True(t, height >= blockNum)
+	}
+
+	// a read on a follower started after a block is submitted to the leader reflects that block once committed
+	block, _ := testDataBlock(32)
+	err := env.nodes[leaderIndex].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+	require.NoError(t, err)
+	followerIndex := (leaderIndex + 1) % 3
+	require.Eventually(t, func() bool {
+		blockNum, err := env.nodes[followerIndex].blockReplicator.LinearizableRead(context.Background())
+		return err == nil && blockNum == numBlocks+2
+	}, 30*time.Second, 100*time.Millisecond)
+
+	// without a quorum the read index cannot be confirmed
+	otherFollowerIndex := (leaderIndex + 2) % 3
+	require.NoError(t, env.nodes[followerIndex].Close())
+	require.NoError(t, env.nodes[otherFollowerIndex].Close())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	blockNum, err := env.nodes[leaderIndex].blockReplicator.LinearizableRead(ctx)
+	require.Error(t, err)
+	require.Equal(t, uint64(0), blockNum)
+
+	require.NoError(t, env.nodes[leaderIndex].Close())
+}
@@ -369,6 +369,238 @@ func TestBlockReplicator_ReConfig_AddPeer(t *testing.T) {
 	}
 }
 
+// Scenario: add a peer to the cluster, which on-boards with a state transfer
+// - start 3 nodes, wait for leader, submit a few blocks and verify reception by all
+// - submit a config tx to adds a 4th peer, wait for all 3 to get it, and submit a few more blocks
+// - start the 4th peer with a join block derived from said config-tx, and a state transfer threshold
+// - ensure the new node installs a state snapshot, which is past the join-block, instead of committing the blocks
+// - submit a few blocks and check that all nodes got them, including the 4th node
+func TestBlockReplicator_ReConfig_AddPeer_StateTransfer(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	// wait for some node to become a leader
+	isLeaderCond := func() bool {
+		return env.AgreedLeaderIndex() >= 0
+	}
+	require.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+	leaderIdx := env.AgreedLeaderIndex()
+
+	numBlocks := uint64(10)
+	approxDataSize := 32
+	testSubmitDataBlocks(t, env, numBlocks, approxDataSize)
+
+	// a config tx that updates the membership by adding a 4th peer
+	next, updatedClusterConfig, proposeBlock := env.NextNodeConfig()
+	err := env.nodes[leaderIdx].blockReplicator.Submit(proposeBlock)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(numBlocks+2, 0, 1, 2) }, 30*time.Second, 100*time.Millisecond)
+	joinBlock, err := env.nodes[0].ledger.Get(numBlocks + 2)
+	require.NoError(t, err)
+
+	// the cluster moves past the join-block before the new node starts
+	isLeaderCond2 := func() bool {
+		return env.AgreedLeaderIndex(0, 1, 2) >= 0
+	}
+	require.Eventually(t, isLeaderCond2, 30*time.Second, 100*time.Millisecond)
+	testSubmitDataBlocks(t, env, 5, approxDataSize, 0, 1, 2)
+
+	// start the new node
+	env.AddNodeWithStateTransfer(t, next, updatedClusterConfig, joinBlock, numBlocks)
+	env.UpdateConfig()
+	err = env.nodes[next-1].Start()
+	require.NoError(t, err)
+	t.Logf("Started node: %d", next)
+
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(numBlocks + 7) }, 30*time.Second, 100*time.Millisecond)
+	require.Equal(t, 1, env.nodes[next-1].stateTransferer.Installed())
+
+	// wait for some node to become a leader, including the 4th node
+	require.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	// submit a few blocks and check that all nodes got them, including the 4th node
+	testSubmitDataBlocks(t, env, numBlocks, approxDataSize)
+	require.NoError(t, env.AssertEqualLedger())
+
+	t.Log("Closing")
+	for _, node := range env.nodes {
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
+
+// Scenario: a node that is far behind does not install a state snapshot that is not attested by a quorum
+// - start 3 nodes, wait for leader, submit a few blocks
+// - the nodes stop attesting blocks
+// - add a 4th node that on-boards with a state transfer, wait for it to catch up by pulling all the blocks
+func TestBlockReplicator_ReConfig_AddPeer_StateTransferNotAttested(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	isLeaderCond := func() bool {
+		return env.AgreedLeaderIndex() >= 0
+	}
+	require.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+	leaderIdx := env.AgreedLeaderIndex()
+
+	numBlocks := uint64(10)
+	approxDataSize := 32
+	testSubmitDataBlocks(t, env, numBlocks, approxDataSize)
+
+	next, updatedClusterConfig, proposeBlock := env.NextNodeConfig()
+	err := env.nodes[leaderIdx].blockReplicator.Submit(proposeBlock)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(numBlocks+2, 0, 1, 2) }, 30*time.Second, 100*time.Millisecond)
+	joinBlock, err := env.nodes[0].ledger.Get(numBlocks + 2)
+	require.NoError(t, err)
+
+	for _, node := range env.nodes {
+		node.attestations.signer = nil
+	}
+
+	env.AddNodeWithStateTransfer(t, next, updatedClusterConfig, joinBlock, numBlocks)
+	env.UpdateConfig()
+	err = env.nodes[next-1].Start()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(numBlocks + 2) }, 60*time.Second, 100*time.Millisecond)
+	require.Equal(t, 0, env.nodes[next-1].stateTransferer.Installed())
+	require.NoError(t, env.AssertEqualLedger())
+
+	for _, node := range env.nodes {
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
+
+// Scenario: add an observer to the cluster
+// - start 3 nodes, wait for leader, submit a few blocks and verify reception by all
+// - submit a config tx to adds a 4th peer as an observer, wait for all 3 to get it
+// - start the 4th peer with a join block derived from said config-tx, it joins Raft as a learner
+// - submit a few blocks and check that all nodes got them, including the observer
+// - submit a config tx that removes the observer, and check that the members keep replicating
+func TestBlockReplicator_ReConfig_AddRemoveObserver(t *testing.T) {
+	var countMutex sync.Mutex
+	var addedCount int
+
+	learnerAddedHook := func(entry zapcore.Entry) error {
+		if strings.Contains(entry.Message, "Applied config changes: [{Type:ConfChangeAddLearnerNode NodeID:4") {
+			countMutex.Lock()
+			defer countMutex.Unlock()
+
+			addedCount++
+		}
+		return nil
+	}
+
+	isCountOver := func(num int) bool {
+		countMutex.Lock()
+		defer countMutex.Unlock()
+
+		return addedCount >= num
+	}
+
+	env := createClusterEnv(t, 3, nil, "info", zap.Hooks(learnerAddedHook))
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	isLeaderCond := func() bool {
+		return env.AgreedLeaderIndex() >= 0
+	}
+	require.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+	leaderIdx := env.AgreedLeaderIndex()
+
+	numBlocks := uint64(10)
+	approxDataSize := 32
+	testSubmitDataBlocks(t, env, numBlocks, approxDataSize)
+
+	// a config tx that adds a 4th peer as an observer
+	next, updatedClusterConfig, proposeBlock := env.NextNodeConfig()
+	members := updatedClusterConfig.ConsensusConfig.Members
+	updatedClusterConfig.ConsensusConfig.Observers = members[len(members)-1:]
+	updatedClusterConfig.ConsensusConfig.Members = members[:len(members)-1]
+	err := env.nodes[leaderIdx].blockReplicator.Submit(proposeBlock)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(numBlocks+2, 0, 1, 2) }, 30*time.Second, 100*time.Millisecond)
+	joinBlock, err := env.nodes[0].ledger.Get(numBlocks + 2)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return isCountOver(3) }, 30*time.Second, 100*time.Millisecond)
+
+	// start the observer
+	env.AddNode(t, next, updatedClusterConfig, joinBlock)
+	env.UpdateConfig()
+	err = env.nodes[next-1].Start()
+	require.NoError(t, err)
+	t.Logf("Started observer: %d", next)
+
+	require.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+	require.NotEqual(t, int(next-1), env.AgreedLeaderIndex())
+
+	// submit a few blocks and check that all nodes got them, including the observer
+	testSubmitDataBlocks(t, env, numBlocks, approxDataSize)
+	require.NoError(t, env.AssertEqualLedger())
+
+	// a config tx that removes the observer
+	removeConfig := env.LastConfig()
+	removeConfig.Nodes = removeConfig.Nodes[:len(removeConfig.Nodes)-1]
+	removeConfig.ConsensusConfig.Observers = nil
+	removeBlock := &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 1}},
+		Payload: &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: &types.ConfigTxEnvelope{
+				Payload: &types.ConfigTx{
+					UserId:    "admin",
+					TxId:      fmt.Sprintf("config-remove-%d", next),
+					NewConfig: removeConfig,
+				},
+			},
+		},
+	}
+	leaderIdx = env.AgreedLeaderIndex()
+	err = env.nodes[leaderIdx].blockReplicator.Submit(removeBlock)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(2*numBlocks+3, 0, 1, 2) }, 30*time.Second, 100*time.Millisecond)
+
+	// the members keep replicating without the observer
+	height, err := env.nodes[next-1].ledger.Height()
+	require.NoError(t, err)
+	block, _ := testDataBlock(approxDataSize)
+	for i := uint64(0); i < numBlocks; i++ {
+		err := env.nodes[leaderIdx].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return env.AssertEqualHeight(3*numBlocks+3, 0, 1, 2) }, 30*time.Second, 100*time.Millisecond)
+	heightAfter, err := env.nodes[next-1].ledger.Height()
+	require.NoError(t, err)
+	require.True(t, heightAfter <= 2*numBlocks+3, "observer height before: %d, after: %d", height, heightAfter)
+
+	t.Log("Closing")
+	for _, node := range env.nodes {
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
+
 // Scenario: add a peer to the cluster, with frequent snapshots
 // - start 3 nodes, configured to take frequent snapshots, wait for leader,
 // - submit a few blocks and verify reception by all, these blocks will create snapshots
@@ -0,0 +1,48 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// ConsensusAlgorithmRaft orders blocks with etcd Raft, which tolerates crash faults
+	ConsensusAlgorithmRaft = "raft"
+	// ConsensusAlgorithmBFT orders blocks with the PBFT-style protocol of the bft package, which tolerates Byzantine faults
+	ConsensusAlgorithmBFT = "bft"
+)
+
+// Consenter orders the blocks created by the block creator, and delivers them for commit, in the same order, on all
+// the nodes of the cluster. It is selected by the `ConsensusConfig.Algorithm` of the cluster config, and receives the
+// messages of its peers from the comm.Transport.
+type Consenter interface {
+	comm.ConsensusListener
+
+	// Submit a block for ordering; an error is returned if this node is not the leader.
+	Submit(block *types.Block) error
+	// Start the internal go-routines.
+	Start()
+	// Close stops the internal go-routines and waits for them to exit.
+	Close() error
+
+	// IsLeader returns nil if this node is the leader, and is ready to order blocks.
+	IsLeader() *ierrors.NotLeaderError
+	// GetClusterStatus returns the Raft ID of the leader, or 0 if it is not known, and the active peers.
+	GetClusterStatus() (leaderID uint64, activePeers map[string]*types.PeerConfig)
+	// TransferLeadership hands over the leadership to the member with the given node ID.
+	TransferLeadership(targetNodeID string, timeout time.Duration) (*types.LeadershipTransfer, error)
+	// LeadershipTransfer returns the last leadership transfer requested on this node, or nil if there was none.
+	LeadershipTransfer() *types.LeadershipTransfer
+	// LinearizableRead waits until this node commits every block committed by the cluster before it was called, and
+	// returns the number of the last committed block.
+	LinearizableRead(ctx context.Context) (uint64, error)
+}
+
+var _ Consenter = &BlockReplicator{}
@@ -1,7 +1,10 @@
 package replication_test
 
 import (
+	"encoding/binary"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -18,7 +21,10 @@ import (
 	"github.com/hyperledger-labs/orion-server/internal/replication"
 	"github.com/hyperledger-labs/orion-server/internal/replication/mocks"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/attestation"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -28,6 +34,9 @@ import (
 var nodePortBase = uint32(22000)
 var peerPortBase = uint32(23000)
 
+// the number of nodes, including the ones added later, that get a certificate in a clusterEnv
+const maxCryptoNodes = 9
+
 var raftConfigNoSnapshots = &types.RaftConfig{
 	TickInterval:         "20ms",
 	ElectionTicks:        100,
@@ -67,6 +76,8 @@ type nodeEnv struct {
 	ledger          *memLedger
 	pendingTxs      *mocks.PendingTxsReleaser
 	configValidator *mocks.ConfigTxValidator
+	stateTransferer *memStateTransferer
+	attestations    *memAttestations
 	stopServeCh     chan struct{}
 }
 
@@ -117,11 +128,13 @@ func (n *nodeEnv) Restart() error {
 	}
 
 	//recreate
-	n.conf.Transport, _ = comm.NewHTTPTransport(
+	n.conf.Transport, _ = comm.NewTransport(
 		&comm.Config{
-			LedgerReader: n.conf.LedgerReader,
-			LocalConf:    n.conf.LocalConf,
-			Logger:       n.conf.Logger,
+			LedgerReader:          n.conf.LedgerReader,
+			LocalConf:             n.conf.LocalConf,
+			Logger:                n.conf.Logger,
+			StateSnapshotProvider: n.stateTransferer,
+			AttestationProvider:   n.attestations,
 		},
 	)
 	n.conf.BlockOneQueueBarrier = queue.NewOneQueueBarrier(n.conf.Logger)
@@ -195,11 +208,22 @@ type clusterEnv struct {
 	nodes                 []*nodeEnv
 	clusterConfigSequence []*types.ClusterConfig
 	testDir               string
+	cryptoDir             string
 	lg                    *logger.SugarLogger
 }
 
 // create a clusterEnv
 func createClusterEnv(t *testing.T, nNodes int, raftConf *types.RaftConfig, logLevel string, logOpts ...zap.Option) *clusterEnv {
+	return createClusterEnvWithObservers(t, nNodes, 0, raftConf, logLevel, logOpts...)
+}
+
+// create a clusterEnv in which the last nObservers nodes are observers
+func createClusterEnvWithObservers(t *testing.T, nNodes, nObservers int, raftConf *types.RaftConfig, logLevel string, logOpts ...zap.Option) *clusterEnv {
+	return createClusterEnvWithTransport(t, nNodes, nObservers, comm.TransportHTTP, raftConf, logLevel, logOpts...)
+}
+
+// create a clusterEnv in which the nodes communicate over the given transport
+func createClusterEnvWithTransport(t *testing.T, nNodes, nObservers int, transport string, raftConf *types.RaftConfig, logLevel string, logOpts ...zap.Option) *clusterEnv {
 	lg := testLogger(t, logLevel, logOpts...)
 
 	testDir, err := ioutil.TempDir("", "replication-test")
@@ -217,13 +241,21 @@ func createClusterEnv(t *testing.T, nNodes int, raftConf *types.RaftConfig, logL
 	}
 	clusterConfig.ConsensusConfig.RaftConfig.MaxRaftId = uint64(nNodes)
 
+	var names []string
+	for n := 1; n <= maxCryptoNodes; n++ {
+		names = append(names, fmt.Sprintf("node%d", n))
+	}
+	cryptoDir := testutils.GenerateTestCrypto(t, names)
+
 	for n := uint32(1); n <= uint32(nNodes); n++ {
 		nodeID := fmt.Sprintf("node%d", n)
+		cert, _, err := loadNodeCrypto(cryptoDir, nodeID)
+		require.NoError(t, err)
 		nodeConfig := &types.NodeConfig{
 			Id:          nodeID,
 			Address:     "127.0.0.1",
 			Port:        nodePortBase + n,
-			Certificate: []byte("bogus-cert"),
+			Certificate: cert,
 		}
 		peerConfig := &types.PeerConfig{
 			NodeId:   nodeID,
@@ -232,17 +264,22 @@ func createClusterEnv(t *testing.T, nNodes int, raftConf *types.RaftConfig, logL
 			PeerPort: peerPortBase + n,
 		}
 		clusterConfig.Nodes = append(clusterConfig.Nodes, nodeConfig)
-		clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members, peerConfig)
+		if n > uint32(nNodes-nObservers) {
+			clusterConfig.ConsensusConfig.Observers = append(clusterConfig.ConsensusConfig.Observers, peerConfig)
+		} else {
+			clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members, peerConfig)
+		}
 	}
 
 	cEnv := &clusterEnv{
-		testDir: testDir,
-		lg:      lg,
+		testDir:   testDir,
+		cryptoDir: cryptoDir,
+		lg:        lg,
 	}
 
 	cEnv.clusterConfigSequence = append(cEnv.clusterConfigSequence, clusterConfig)
 	for n := uint32(1); n <= uint32(nNodes); n++ {
-		nEnv, err := newNodeEnv(n, testDir, lg, proto.Clone(clusterConfig).(*types.ClusterConfig))
+		nEnv, err := newNodeEnvWithTransport(n, testDir, cryptoDir, lg, proto.Clone(clusterConfig).(*types.ClusterConfig), transport)
 		if err != nil {
 			os.RemoveAll(testDir)
 			return nil
@@ -254,8 +291,14 @@ func createClusterEnv(t *testing.T, nNodes int, raftConf *types.RaftConfig, logL
 	return cEnv
 }
 
-// create a BlockReplicator environment with a genesis block
+// create a BlockReplicator environment with a genesis block, of a node that does not attest blocks
 func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig) (*nodeEnv, error) {
+	return newNodeEnvWithTransport(n, testDir, "", lg, clusterConfig, comm.TransportHTTP)
+}
+
+// create a BlockReplicator environment with a genesis block, which communicates over the given transport, and attests
+// blocks with its key in cryptoDir, if any
+func newNodeEnvWithTransport(n uint32, testDir, cryptoDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, transport string) (*nodeEnv, error) {
 	nodeID := fmt.Sprintf("node%d", n)
 	localTestDir := path.Join(testDir, nodeID)
 
@@ -266,8 +309,9 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 			},
 		},
 		Replication: config.ReplicationConf{
-			WALDir:  path.Join(testDir, nodeID, "wal"),
-			SnapDir: path.Join(testDir, nodeID, "snap"),
+			WALDir:    path.Join(testDir, nodeID, "wal"),
+			SnapDir:   path.Join(testDir, nodeID, "snap"),
+			Transport: transport,
 			Network: config.NetworkConf{
 				Address: "127.0.0.1",
 				Port:    peerPortBase + n,
@@ -303,10 +347,18 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 		return nil, err
 	}
 
-	peerTransport, _ := comm.NewHTTPTransport(&comm.Config{
-		LedgerReader: ledger,
-		LocalConf:    localConf,
-		Logger:       lg,
+	stateTransferer := &memStateTransferer{ledger: ledger}
+	attestations, err := newMemAttestations(cryptoDir, nodeID, ledger)
+	if err != nil {
+		return nil, err
+	}
+
+	peerTransport, _ := comm.NewTransport(&comm.Config{
+		LedgerReader:          ledger,
+		LocalConf:             localConf,
+		Logger:                lg,
+		StateSnapshotProvider: stateTransferer,
+		AttestationProvider:   attestations,
 	})
 
 	conf := &replication.Config{
@@ -317,6 +369,7 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 		BlockOneQueueBarrier: qBarrier,
 		PendingTxs:           pendingTxs,
 		ConfigValidator:      configValidator,
+		StateTransferer:      stateTransferer,
 		Logger:               lg,
 	}
 
@@ -342,6 +395,8 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 		ledger:          ledger,
 		pendingTxs:      pendingTxs,
 		configValidator: configValidator,
+		stateTransferer: stateTransferer,
+		attestations:    attestations,
 		stopServeCh:     make(chan struct{}),
 	}
 
@@ -349,7 +404,7 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 }
 
 // create a BlockReplicator environment with a join block
-func newNodeEnvJoin(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, joinBlock *types.Block) (*nodeEnv, error) {
+func newNodeEnvJoin(n uint32, testDir, cryptoDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, joinBlock *types.Block, stateTransferMinBlocks uint64) (*nodeEnv, error) {
 	nodeID := fmt.Sprintf("node%d", n)
 	localTestDir := path.Join(testDir, nodeID)
 
@@ -369,6 +424,8 @@ func newNodeEnvJoin(n uint32, testDir string, lg *logger.SugarLogger, clusterCon
 			TLS: config.TLSConf{
 				Enabled: false,
 			},
+			StateTransferMinBlocks:     stateTransferMinBlocks,
+			StateTransferAnchorTimeout: 3 * time.Second,
 		},
 	}
 
@@ -378,10 +435,18 @@ func newNodeEnvJoin(n uint32, testDir string, lg *logger.SugarLogger, clusterCon
 	configValidator.ValidateReturns(&types.ValidationInfo{Flag: types.Flag_VALID}, nil)
 	ledger := &memLedger{}
 
+	stateTransferer := &memStateTransferer{ledger: ledger}
+	attestations, err := newMemAttestations(cryptoDir, nodeID, ledger)
+	if err != nil {
+		return nil, err
+	}
+
 	peerTransport, _ := comm.NewHTTPTransport(&comm.Config{
-		LedgerReader: ledger,
-		LocalConf:    localConf,
-		Logger:       lg,
+		LedgerReader:          ledger,
+		LocalConf:             localConf,
+		Logger:                lg,
+		StateSnapshotProvider: stateTransferer,
+		AttestationProvider:   attestations,
 	})
 
 	conf := &replication.Config{
@@ -393,6 +458,7 @@ func newNodeEnvJoin(n uint32, testDir string, lg *logger.SugarLogger, clusterCon
 		BlockOneQueueBarrier: qBarrier,
 		PendingTxs:           pendingTxs,
 		ConfigValidator:      configValidator,
+		StateTransferer:      stateTransferer,
 		Logger:               lg,
 	}
 
@@ -418,6 +484,8 @@ func newNodeEnvJoin(n uint32, testDir string, lg *logger.SugarLogger, clusterCon
 		ledger:          ledger,
 		pendingTxs:      pendingTxs,
 		configValidator: configValidator,
+		stateTransferer: stateTransferer,
+		attestations:    attestations,
 		stopServeCh:     make(chan struct{}),
 	}
 
@@ -428,11 +496,15 @@ func (c *clusterEnv) NextNodeConfig() (nextRaftID uint32, config *types.ClusterC
 	nextRaftID = uint32(len(c.nodes)) + 1
 	num := len(c.clusterConfigSequence)
 	updatedClusterConfig := proto.Clone(c.clusterConfigSequence[num-1]).(*types.ClusterConfig)
+	cert, _, err := loadNodeCrypto(c.cryptoDir, fmt.Sprintf("node%d", nextRaftID))
+	if err != nil {
+		cert = []byte("bogus-cert")
+	}
 	nodeConfig := &types.NodeConfig{
 		Id:          fmt.Sprintf("node%d", nextRaftID),
 		Address:     "127.0.0.1",
 		Port:        nodePortBase + nextRaftID,
-		Certificate: []byte("bogus-cert"),
+		Certificate: cert,
 	}
 	peerConfig := &types.PeerConfig{
 		NodeId:   fmt.Sprintf("node%d", nextRaftID),
@@ -461,7 +533,13 @@ func (c *clusterEnv) NextNodeConfig() (nextRaftID uint32, config *types.ClusterC
 }
 
 func (c *clusterEnv) AddNode(t *testing.T, n uint32, clusterConfig *types.ClusterConfig, joinBlock *types.Block) {
-	node, err := newNodeEnvJoin(n, c.testDir, c.lg, clusterConfig, joinBlock)
+	c.AddNodeWithStateTransfer(t, n, clusterConfig, joinBlock, 0)
+}
+
+// AddNodeWithStateTransfer adds a node that on-boards with a state transfer when it lags behind the join-block by at
+// least stateTransferMinBlocks blocks.
+func (c *clusterEnv) AddNodeWithStateTransfer(t *testing.T, n uint32, clusterConfig *types.ClusterConfig, joinBlock *types.Block, stateTransferMinBlocks uint64) {
+	node, err := newNodeEnvJoin(n, c.testDir, c.cryptoDir, c.lg, clusterConfig, joinBlock, stateTransferMinBlocks)
 	require.NoError(t, err)
 	c.nodes = append(c.nodes, node)
 	c.clusterConfigSequence = append(c.clusterConfigSequence, clusterConfig)
@@ -672,6 +750,153 @@ func (l *memLedger) Get(blockNum uint64) (*types.Block, error) {
 	return l.ledger[blockNum-1], nil
 }
 
+// memStateTransferer mocks the state transfer of a node whose state is its memLedger, so a state snapshot holds just
+// the height of the ledger, and the installation appends the staged blocks to the ledger.
+type memStateTransferer struct {
+	ledger *memLedger
+
+	mutex        sync.Mutex
+	height       uint64
+	ledgerHeight uint64
+	staged       []*types.Block
+	installed    int
+}
+
+func (m *memStateTransferer) WriteStateSnapshot(w io.Writer) error {
+	height, err := m.ledger.Height()
+	if err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, height)
+}
+
+func (m *memStateTransferer) Stage(r io.Reader) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.staged = nil
+	if err := binary.Read(r, binary.BigEndian, &m.height); err != nil {
+		return 0, err
+	}
+	ledgerHeight, err := m.ledger.Height()
+	if err != nil {
+		return 0, err
+	}
+	m.ledgerHeight = ledgerHeight
+	return m.height, nil
+}
+
+func (m *memStateTransferer) StageBlock(block *types.Block) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.staged = append(m.staged, block)
+	return nil
+}
+
+func (m *memStateTransferer) Install() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if uint64(len(m.staged)) != m.height-m.ledgerHeight {
+		return errors.Errorf("staged [%d] blocks, expected [%d]", len(m.staged), m.height-m.ledgerHeight)
+	}
+	for _, block := range m.staged {
+		if err := m.ledger.Append(block); err != nil {
+			return err
+		}
+	}
+	m.staged = nil
+	m.installed++
+	return nil
+}
+
+func (m *memStateTransferer) Discard() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.staged = nil
+	return nil
+}
+
+func (m *memStateTransferer) Installed() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.installed
+}
+
+// memAttestations mocks the attestor of a node, which signs the headers of the blocks in its memLedger. A node without
+// a key attests no block.
+type memAttestations struct {
+	nodeID string
+	signer crypto.Signer
+	ledger *memLedger
+}
+
+func newMemAttestations(cryptoDir, nodeID string, ledger *memLedger) (*memAttestations, error) {
+	m := &memAttestations{nodeID: nodeID, ledger: ledger}
+	if cryptoDir == "" {
+		return m, nil
+	}
+
+	_, signer, err := loadNodeCrypto(cryptoDir, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	m.signer = signer
+	return m, nil
+}
+
+func (m *memAttestations) GetAttestations(start, end uint64) ([]*types.BlockAttestation, error) {
+	if m.signer == nil {
+		return nil, nil
+	}
+
+	height, err := m.ledger.Height()
+	if err != nil {
+		return nil, err
+	}
+	var attestations []*types.BlockAttestation
+	for n := start; n <= end && n <= height; n++ {
+		block, err := m.ledger.Get(n)
+		if err != nil {
+			return nil, err
+		}
+		headerHash, err := attestation.HeaderHash(block.GetHeader())
+		if err != nil {
+			return nil, err
+		}
+		a, err := attestation.Sign(m.signer, m.nodeID, n, headerHash)
+		if err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, a)
+	}
+	return attestations, nil
+}
+
+// loadNodeCrypto returns the DER certificate and the signer of a node whose crypto material is in cryptoDir.
+func loadNodeCrypto(cryptoDir, nodeID string) ([]byte, crypto.Signer, error) {
+	certPEM, err := ioutil.ReadFile(path.Join(cryptoDir, nodeID+".pem"))
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, nil, errors.Errorf("no certificate found for node [%s]", nodeID)
+	}
+
+	signer, err := crypto.NewSigner(&crypto.SignerOptions{
+		Identity:    nodeID,
+		KeyFilePath: path.Join(cryptoDir, nodeID+".key"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return block.Bytes, signer, nil
+}
+
 func testLogger(t *testing.T, level string, opts ...zap.Option) *logger.SugarLogger {
 	c := &logger.Config{
 		Level:         level,
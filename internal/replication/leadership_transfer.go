@@ -0,0 +1,188 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"go.etcd.io/etcd/raft"
+)
+
+// TransferLeadership hands over the leadership of the cluster from this node to the consensus member with the given
+// node ID. The leader first stops accepting new blocks and waits for the in-flight blocks to commit, and then asks
+// Raft to transfer the leadership to the target. It returns once the target is known to be the leader, or once the
+// transfer fails or times out. If the timeout is 0, twice the Raft election timeout is used.
+//
+// If a transfer cannot be started, for example because this node is not the leader or the target is not a member,
+// an error is returned. Otherwise, the outcome of the transfer is returned, and kept for reporting the cluster status.
+func (br *BlockReplicator) TransferLeadership(targetNodeID string, timeout time.Duration) (*types.LeadershipTransfer, error) {
+	targetRaftID, raftConfig, err := br.startLeadershipTransfer(targetNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickInterval, err := time.ParseDuration(raftConfig.TickInterval)
+	if err != nil {
+		br.lg.Panicf("Error parsing raft tick interval duration: %s", err.Error())
+	}
+	if timeout == 0 {
+		timeout = 2 * time.Duration(raftConfig.ElectionTicks) * tickInterval
+	}
+	deadline := time.Now().Add(timeout)
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	br.lg.Infof("Leadership transfer to node [%s], Raft ID [%d], started; waiting for in-flight blocks to commit", targetNodeID, targetRaftID)
+	for {
+		drained, errLeader := br.inFlightBlocksDrained()
+		if errLeader != nil {
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED,
+				fmt.Sprintf("lost leadership while waiting for in-flight blocks to commit, leader Raft ID is [%d]", errLeader.GetLeaderID())), nil
+		}
+		if drained {
+			break
+		}
+
+		select {
+		case <-br.stopCh:
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED, "block replicator closed"), nil
+		case <-ticker.C:
+		}
+
+		if time.Now().After(deadline) {
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED,
+				fmt.Sprintf("timed out after [%s] waiting for in-flight blocks to commit", timeout)), nil
+		}
+	}
+
+	br.lg.Infof("In-flight blocks committed, transferring leadership to Raft ID [%d]", targetRaftID)
+	br.raftNode.TransferLeadership(context.Background(), br.raftID, targetRaftID)
+
+	for {
+		select {
+		case <-br.stopCh:
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED, "block replicator closed"), nil
+		case <-ticker.C:
+		}
+
+		switch leader := br.GetLeaderID(); leader {
+		case targetRaftID:
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_SUCCEEDED, ""), nil
+		case br.raftID:
+			// Raft aborts a transfer that does not complete within an election timeout, try again until the deadline.
+			if status := br.raftNode.Status(); status.Lead == br.raftID && status.LeadTransferee == raft.None {
+				br.raftNode.TransferLeadership(context.Background(), br.raftID, targetRaftID)
+			}
+		case raft.None:
+			// an election is in progress
+		default:
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED,
+				fmt.Sprintf("leadership moved to Raft ID [%d] instead of the target", leader)), nil
+		}
+
+		if time.Now().After(deadline) {
+			return br.finishLeadershipTransfer(types.LeadershipTransfer_FAILED,
+				fmt.Sprintf("timed out after [%s] waiting for the target to become the leader", timeout)), nil
+		}
+	}
+}
+
+// LeadershipTransfer returns the last leadership transfer requested on this node, or nil if there was none.
+func (br *BlockReplicator) LeadershipTransfer() *types.LeadershipTransfer {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	if br.leadershipTransfer == nil {
+		return nil
+	}
+	return proto.Clone(br.leadershipTransfer).(*types.LeadershipTransfer)
+}
+
+// startLeadershipTransfer checks that the leadership can be transferred to the target, and stops the leader from
+// accepting new blocks. It returns the Raft ID of the target, and the Raft config the transfer is timed by.
+func (br *BlockReplicator) startLeadershipTransfer(targetNodeID string) (uint64, *types.RaftConfig, error) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	if br.transferringLeadership {
+		return 0, nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("a leadership transfer to node [%s] is already in progress", br.leadershipTransfer.GetToNodeId()),
+		}
+	}
+
+	if errLeader := br.isLeaderReady(); errLeader != nil {
+		return 0, nil, errLeader
+	}
+
+	var targetRaftID uint64
+	for _, peer := range br.clusterConfig.ConsensusConfig.Members {
+		if peer.NodeId == targetNodeID {
+			targetRaftID = peer.RaftId
+			break
+		}
+	}
+	if targetRaftID == 0 {
+		if isObserver(br.clusterConfig.ConsensusConfig, targetNodeID) {
+			return 0, nil, &ierrors.BadRequestError{
+				ErrMsg: fmt.Sprintf("the target node [%s] is an observer, leadership can only be transferred to a consensus member", targetNodeID),
+			}
+		}
+		return 0, nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the target node [%s] is not a consensus member", targetNodeID),
+		}
+	}
+	if targetRaftID == br.raftID {
+		return 0, nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the target node [%s] is already the leader", targetNodeID),
+		}
+	}
+
+	br.transferringLeadership = true
+	br.leadershipTransfer = &types.LeadershipTransfer{
+		FromNodeId: br.localConf.Server.Identity.ID,
+		ToNodeId:   targetNodeID,
+		Status:     types.LeadershipTransfer_IN_PROGRESS,
+	}
+
+	return targetRaftID, br.clusterConfig.ConsensusConfig.RaftConfig, nil
+}
+
+// inFlightBlocksDrained returns true when all the blocks submitted to this node had been proposed and committed. If
+// this node is no longer the leader, an error is returned.
+func (br *BlockReplicator) inFlightBlocksDrained() (bool, *ierrors.NotLeaderError) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	if errLeader := br.isLeader(); errLeader != nil {
+		return false, errLeader
+	}
+
+	lastIndex, _ := br.raftStorage.MemoryStorage.LastIndex() //never returns error
+	return len(br.proposeCh) == 0 && br.numInFlightBlocks == 0 && br.inFlightConfigBlockNumber == 0 && lastIndex <= br.appliedIndex, nil
+}
+
+// finishLeadershipTransfer records the outcome of the leadership transfer, and lets this node accept new blocks
+// again if it is still the leader.
+func (br *BlockReplicator) finishLeadershipTransfer(status types.LeadershipTransfer_Status, reason string) *types.LeadershipTransfer {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	if status == types.LeadershipTransfer_SUCCEEDED {
+		br.lg.Infof("Leadership transferred to node [%s]", br.leadershipTransfer.ToNodeId)
+	} else {
+		br.lg.Warnf("Leadership transfer to node [%s] failed: %s", br.leadershipTransfer.ToNodeId, reason)
+	}
+
+	br.transferringLeadership = false
+	br.leadershipTransfer.Status = status
+	br.leadershipTransfer.Reason = reason
+
+	return proto.Clone(br.leadershipTransfer).(*types.LeadershipTransfer)
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/pkg/errors"
+)
+
+// A compressed block in the data of a Raft entry is prefixed by a zero marker byte and a byte that identifies the
+// compression algorithm. A marshaled block never starts with a zero byte, because protobuf field numbers start at 1,
+// so entries that carry an uncompressed block are read as before. That makes the entry data self describing: peers
+// decompress entries regardless of the `RaftConfig.Compression` setting, which may therefore be changed at any time.
+//
+// Servers that predate the compression of entries cannot read a compressed entry, though. Hence, blocks are compressed
+// only once the `RaftConfig.EntryFormatVersion` is raised to EntryFormatCompressed, which the admins do after all the
+// servers of the cluster are upgraded:
+//  1. upgrade the servers, one at a time, while the version is EntryFormatPlain;
+//  2. submit a config transaction that sets the version to EntryFormatCompressed, and the compression.
+const compressedEntryMarker byte = 0
+
+// The versions of the format of the data in Raft entries
+const (
+	// EntryFormatPlain entries carry marshaled blocks
+	EntryFormatPlain uint32 = 0
+	// EntryFormatCompressed entries may carry compressed blocks
+	EntryFormatCompressed uint32 = 1
+	// MaxEntryFormatVersion is the latest version of the format that this server reads and writes
+	MaxEntryFormatVersion = EntryFormatCompressed
+)
+
+var entryCompressionIDs = map[string]byte{
+	compression.Gzip: 1,
+	compression.Zstd: 2,
+}
+
+// encodeEntryData compresses the marshaled block with the algorithm into the data of a Raft entry. The block is kept
+// uncompressed if the format version of the entries predates the compression, if the algorithm does not compress, or
+// if it does not make the block any smaller.
+func encodeEntryData(formatVersion uint32, algorithm string, blockBytes []byte) ([]byte, error) {
+	if formatVersion < EntryFormatCompressed {
+		return blockBytes, nil
+	}
+	id, ok := entryCompressionIDs[algorithm]
+	if !ok {
+		return blockBytes, nil
+	}
+
+	compressed, err := compression.Compress(algorithm, blockBytes)
+	if err != nil {
+		return nil, err
+	}
+	compression.Observe(compression.PathRaftEntry, algorithm, len(blockBytes), len(compressed))
+
+	if len(compressed)+2 >= len(blockBytes) {
+		return blockBytes, nil
+	}
+
+	data := make([]byte, 0, len(compressed)+2)
+	data = append(data, compressedEntryMarker, id)
+	return append(data, compressed...), nil
+}
+
+// decodeEntryData returns the marshaled block carried by the data of a Raft entry. A compressed block that exceeds
+// maxSize bytes once decompressed is rejected.
+func decodeEntryData(data []byte, maxSize uint64) ([]byte, error) {
+	if len(data) == 0 || data[0] != compressedEntryMarker {
+		return data, nil
+	}
+	if len(data) < 2 {
+		return nil, errors.New("compressed entry data is truncated")
+	}
+
+	for algorithm, id := range entryCompressionIDs {
+		if id == data[1] {
+			blockBytes, err := compression.DecompressLimit(algorithm, data[2:], maxSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decompress entry data with %s", algorithm)
+			}
+			return blockBytes, nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown entry compression: %d", data[1])
+}
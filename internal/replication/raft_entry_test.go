@@ -0,0 +1,104 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeEntryData(t *testing.T) {
+	value := []byte(strings.Repeat(`{"owner":"alice","amount":100}`, 50))
+	block := &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 7}},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					{
+						Payload: &types.DataTx{
+							DbOperations: []*types.DBOperation{
+								{
+									DbName:     "bdb",
+									DataWrites: []*types.DataWrite{{Key: "key1", Value: value}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	blockBytes, err := proto.Marshal(block)
+	require.NoError(t, err)
+
+	t.Run("uncompressed", func(t *testing.T) {
+		for _, algorithm := range []string{"", compression.None} {
+			data, err := encodeEntryData(EntryFormatCompressed, algorithm, blockBytes)
+			require.NoError(t, err)
+			require.Equal(t, blockBytes, data)
+
+			decoded, err := decodeEntryData(data, config.DefaultMaxMessageSize)
+			require.NoError(t, err)
+			require.Equal(t, blockBytes, decoded)
+		}
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+			data, err := encodeEntryData(EntryFormatCompressed, algorithm, blockBytes)
+			require.NoError(t, err)
+			require.Equal(t, compressedEntryMarker, data[0])
+			require.Equal(t, entryCompressionIDs[algorithm], data[1])
+			require.Less(t, len(data), len(blockBytes)/5)
+
+			decoded, err := decodeEntryData(data, config.DefaultMaxMessageSize)
+			require.NoError(t, err)
+			require.Equal(t, blockBytes, decoded)
+		}
+	})
+
+	t.Run("plain format", func(t *testing.T) {
+		for _, algorithm := range []string{compression.Gzip, compression.Zstd} {
+			data, err := encodeEntryData(EntryFormatPlain, algorithm, blockBytes)
+			require.NoError(t, err)
+			require.Equal(t, blockBytes, data)
+		}
+	})
+
+	t.Run("incompressible", func(t *testing.T) {
+		smallBytes, err := proto.Marshal(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 8}}})
+		require.NoError(t, err)
+
+		data, err := encodeEntryData(EntryFormatCompressed, compression.Zstd, smallBytes)
+		require.NoError(t, err)
+		require.Equal(t, smallBytes, data)
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		_, err := decodeEntryData([]byte{compressedEntryMarker}, config.DefaultMaxMessageSize)
+		require.EqualError(t, err, "compressed entry data is truncated")
+
+		_, err = decodeEntryData([]byte{compressedEntryMarker, 9, 1, 2, 3}, config.DefaultMaxMessageSize)
+		require.EqualError(t, err, "unknown entry compression: 9")
+
+		_, err = decodeEntryData([]byte{compressedEntryMarker, entryCompressionIDs[compression.Gzip], 1, 2, 3}, config.DefaultMaxMessageSize)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decompress entry data with gzip")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		data, err := encodeEntryData(EntryFormatCompressed, compression.Gzip, blockBytes)
+		require.NoError(t, err)
+
+		_, err = decodeEntryData(data, uint64(len(blockBytes)-1))
+		require.EqualError(t, err, fmt.Sprintf("failed to decompress entry data with gzip: the decompressed data exceeds the limit of %d bytes", len(blockBytes)-1))
+	})
+}
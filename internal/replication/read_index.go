@@ -0,0 +1,93 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft"
+)
+
+type readIndexRequest struct {
+	index      uint64      // the read index, once it is known
+	indexKnown bool        // the read index was returned by Raft
+	doneCh     chan uint64 // receives the number of the last committed block once the commit reaches the read index
+}
+
+// LinearizableRead performs a Raft ReadIndex round, which confirms with a quorum that the leader is still the leader
+// and returns its commit index, and then waits until this node had committed the log up to that index. It returns the
+// number of the last block committed when the read index was reached. Reads from the local state that start after
+// it returns reflect every block committed by the cluster before it was called.
+//
+// If the context has no deadline, the wait is bounded by the Raft election timeout, as Raft drops a ReadIndex request
+// when there is no leader, or when the leader has not committed an entry in its term yet.
+func (br *BlockReplicator) LinearizableRead(ctx context.Context) (uint64, error) {
+	br.mutex.Lock()
+	if br.isOnBoarding() {
+		br.mutex.Unlock()
+		return 0, &ierrors.ServerRestrictionError{ErrMsg: "node is on-boarding, linearizable reads are not available"}
+	}
+	tickInterval, err := time.ParseDuration(br.clusterConfig.ConsensusConfig.RaftConfig.TickInterval)
+	if err != nil {
+		br.lg.Panicf("Error parsing raft tick interval duration: %s", err.Error())
+	}
+	electionTimeout := time.Duration(br.clusterConfig.ConsensusConfig.RaftConfig.ElectionTicks) * tickInterval
+
+	requestCtx := make([]byte, 8)
+	binary.BigEndian.PutUint64(requestCtx, atomic.AddUint64(&br.readIndexSeq, 1))
+	request := &readIndexRequest{doneCh: make(chan uint64, 1)}
+	br.readIndexRequests[string(requestCtx)] = request
+	br.mutex.Unlock()
+
+	defer func() {
+		br.mutex.Lock()
+		delete(br.readIndexRequests, string(requestCtx))
+		br.mutex.Unlock()
+	}()
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, electionTimeout)
+		defer cancel()
+	}
+
+	if err := br.raftNode.ReadIndex(ctx, requestCtx); err != nil {
+		return 0, errors.Wrap(err, "failed to request a read index")
+	}
+
+	select {
+	case blockNum := <-request.doneCh:
+		return blockNum, nil
+	case <-ctx.Done():
+		return 0, &ierrors.TimeoutErr{ErrMsg: "timeout has occurred while waiting for the local commit to reach the read index: " + ctx.Err().Error()}
+	case <-br.stopCh:
+		return 0, &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	}
+}
+
+// processReadStates records the read indices returned by Raft, and releases the linearizable reads whose read index
+// was reached by the commit. It is called by the event-loop go-routine after the committed entries are delivered.
+func (br *BlockReplicator) processReadStates(readStates []raft.ReadState) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	for _, rs := range readStates {
+		if request, ok := br.readIndexRequests[string(rs.RequestCtx)]; ok {
+			request.index = rs.Index
+			request.indexKnown = true
+		}
+	}
+
+	for requestCtx, request := range br.readIndexRequests {
+		if request.indexKnown && request.index <= br.appliedIndex {
+			request.doneCh <- br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+			delete(br.readIndexRequests, requestCtx)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/attestation"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultStateAnchorTimeout bounds the time spent collecting the attestations of a quorum on the last block of a
+	// state snapshot, when no timeout is configured. The members attest a block shortly after they commit it, so a
+	// fresh snapshot may not be attested yet.
+	defaultStateAnchorTimeout = 30 * time.Second
+	// stateAnchorRetryInterval is the interval between two rounds of pulling attestations from the members
+	stateAnchorRetryInterval = time.Second
+)
+
+// StateTransferer stages a state snapshot pulled from a remote member, together with the blocks the node misses up to
+// the snapshot, and installs it in place of the local stores.
+type StateTransferer interface {
+	// Stage reads a state snapshot stream and stages it, returning the number of the block the snapshot was taken at.
+	Stage(r io.Reader) (uint64, error)
+	// StageBlock adds the next block to the staged blocks, starting from the block that follows the last committed block.
+	StageBlock(block *types.Block) error
+	// Install verifies the staged state against the state root of the last staged block and installs it, together with
+	// the staged blocks.
+	Install() error
+	// Discard drops the staged state.
+	Discard() error
+}
+
+// transferStateIfFarBehind replaces the catch-up of a node that lags behind the target block by at least
+// `StateTransferMinBlocks` blocks with a state transfer: a state snapshot is pulled from one of the members, together
+// with the blocks that follow the last committed block up to the snapshot, and is installed in place of the local
+// stores, so that the blocks need not be executed. The snapshot may be ahead of the target block, in which case the blocks past the target that are
+// delivered later by Raft are skipped.
+//
+// A failed state transfer is not an error, the caller falls back to pulling and committing all the blocks.
+func (br *BlockReplicator) transferStateIfFarBehind(initBlockNumber uint64, targetBlock *types.Block, updateConfig bool) error {
+	minBlocks := br.localConf.Replication.StateTransferMinBlocks
+	targetBlockNumber := targetBlock.GetHeader().GetBaseHeader().GetNumber()
+	if br.stateTransferer == nil || minBlocks == 0 || targetBlockNumber < initBlockNumber+minBlocks {
+		return nil
+	}
+
+	br.lg.Infof("Starting state snapshot transfer; From block: %d, To block: %d", initBlockNumber, targetBlockNumber)
+
+	var lastBlock *types.Block
+	var lastConfig *types.ClusterConfig
+	var err error
+	doneCh := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Transfer in a go-routine so that we may cancel it if the server shuts down.
+	go func() {
+		defer close(doneCh)
+		lastBlock, lastConfig, err = br.transferState(ctx, targetBlock)
+	}()
+
+	select {
+	case <-br.stopCh:
+		cancel()
+		<-doneCh
+		if err == nil {
+			// the state was installed just before the cancellation
+			br.setLastCommittedBlock(lastBlock)
+		} else if err := br.stateTransferer.Discard(); err != nil {
+			br.lg.Errorf("Failed to discard the staged state: %s", err)
+		}
+		return &ierrors.ClosedError{ErrMsg: "server stopped during state transfer"}
+	case <-doneCh:
+	}
+
+	if err != nil {
+		br.lg.Warnf("State snapshot transfer failed, falling back to pulling all the blocks: %s", err)
+		if err := br.stateTransferer.Discard(); err != nil {
+			br.lg.Panicf("Failed to discard the staged state: %s", err)
+		}
+		return nil
+	}
+
+	br.setLastCommittedBlock(lastBlock)
+	if updateConfig && lastConfig != nil {
+		if err := br.updateClusterConfig(lastConfig); err != nil {
+			br.lg.Panicf("Failed to update to ClusterConfig after state transfer: error: %s", err)
+		}
+	}
+
+	br.lg.Infof("Finished state snapshot transfer up to and including block [%d]", lastBlock.GetHeader().GetBaseHeader().GetNumber())
+	return nil
+}
+
+// transferState pulls a state snapshot that is at or past the target block, and the blocks that follow the last
+// committed block up to the snapshot, and installs them. The pulled chain is linked to the last committed block, and
+// the target block anchors it: the blocks up to the target block are verified against its base header hash. The
+// header of the last block, which carries the state root the snapshot is verified against, must in addition be
+// attested by a quorum of the consensus members, since it is not covered by any hash the node already trusts. It
+// returns the last block installed and the last valid cluster config in the pulled chain.
+func (br *BlockReplicator) transferState(ctx context.Context, targetBlock *types.Block) (*types.Block, *types.ClusterConfig, error) {
+	targetBlockNumber := targetBlock.GetHeader().GetBaseHeader().GetNumber()
+	targetBaseHash, err := blockstore.ComputeBlockBaseHash(targetBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var height uint64
+	err = br.transport.PullStateSnapshot(ctx, br.GetLeaderID(), func(r io.Reader) error {
+		var err error
+		if height, err = br.stateTransferer.Stage(r); err != nil {
+			return err
+		}
+		if height < targetBlockNumber {
+			return errors.Errorf("the state snapshot is at block [%d], behind the target block [%d]", height, targetBlockNumber)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to pull a state snapshot from cluster")
+	}
+
+	br.mutex.Lock()
+	lastBlock := br.lastCommittedBlock
+	br.mutex.Unlock()
+
+	var lastConfig *types.ClusterConfig
+	stageBlocks := func(blocks []*types.Block) error {
+		for _, block := range blocks {
+			if err := br.stateTransferer.StageBlock(block); err != nil {
+				return err
+			}
+
+			if utils.IsConfigBlock(block) {
+				if validInfo := block.GetHeader().GetValidationInfo(); (len(validInfo) != 0) && (validInfo[0].Flag == types.Flag_VALID) {
+					lastConfig = block.GetConfigTxEnvelope().GetPayload().GetNewConfig()
+				}
+			}
+
+			lastBlock = block
+		}
+		return nil
+	}
+
+	// the blocks up to the target block are verified against its hash before they are staged, while the blocks that
+	// follow it are covered by the attestation of the last block, which is verified before the snapshot is installed
+	err = br.transport.PullBlocksConcurrently(ctx, lastBlock, targetBlockNumber, targetBaseHash, br.GetLeaderID(), stageBlocks)
+	if err == nil {
+		err = br.transport.PullBlocksConcurrently(ctx, lastBlock, height, nil, br.GetLeaderID(), stageBlocks)
+	}
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to pull blocks from cluster")
+	}
+
+	if lastBlock.GetHeader().GetBaseHeader().GetNumber() != height {
+		return nil, nil, errors.Errorf("the blocks pulled from cluster end at block [%d] while the state snapshot is at block [%d]",
+			lastBlock.GetHeader().GetBaseHeader().GetNumber(), height)
+	}
+	if err := br.verifyStateAnchor(ctx, lastBlock.GetHeader()); err != nil {
+		return nil, nil, err
+	}
+
+	if err := br.stateTransferer.Install(); err != nil {
+		return nil, nil, err
+	}
+
+	return lastBlock, lastConfig, nil
+}
+
+// verifyStateAnchor pulls the attestations of the consensus members, other than the local node, on the header, until
+// a quorum of the members of the current cluster config attested it. The state snapshot is installed only then, as
+// the state root and the chain up to the header are otherwise vouched for by a single peer. If the members changed
+// since the local cluster config, the quorum may not be reached, and the caller falls back to pulling all the blocks.
+func (br *BlockReplicator) verifyStateAnchor(ctx context.Context, header *types.BlockHeader) error {
+	br.mutex.Lock()
+	clusterConfig := br.clusterConfig
+	br.mutex.Unlock()
+
+	blockNumber := header.GetBaseHeader().GetNumber()
+	headerHash, err := attestation.HeaderHash(header)
+	if err != nil {
+		return err
+	}
+	verifiers, err := attestation.Verifiers(clusterConfig)
+	if err != nil {
+		return err
+	}
+	signed := attestation.SigningBytes(blockNumber, headerHash)
+	quorum := attestation.Quorum(clusterConfig)
+
+	timeout := br.localConf.Replication.StateTransferAnchorTimeout
+	if timeout <= 0 {
+		timeout = defaultStateAnchorTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	attested := make(map[string]bool)
+	for {
+		for _, member := range clusterConfig.GetConsensusConfig().GetMembers() {
+			if attested[member.NodeId] || member.NodeId == br.localConf.Server.Identity.ID {
+				continue
+			}
+			attestations, err := br.transport.PullAttestations(ctx, member.RaftId, blockNumber, blockNumber)
+			if err != nil {
+				br.lg.Debugf("Failed to pull the attestation of member [%s] on block [%d]: %s", member.NodeId, blockNumber, err)
+				continue
+			}
+			for _, a := range attestations {
+				if a.GetNodeId() != member.NodeId || a.GetBlockNumber() != blockNumber {
+					continue
+				}
+				if err := verifiers[member.NodeId].Verify(signed, a.GetSignature()); err != nil {
+					br.lg.Warnf("Member [%s] attested a different header of block [%d]: %s", member.NodeId, blockNumber, err)
+					continue
+				}
+				attested[member.NodeId] = true
+			}
+		}
+
+		if len(attested) >= quorum {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("block [%d] pulled from cluster is attested by [%d] consensus members, a quorum is [%d]",
+				blockNumber, len(attested), quorum)
+		case <-time.After(stateAnchorRetryInterval):
+		}
+	}
+}
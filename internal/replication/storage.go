@@ -100,6 +100,20 @@ func CreateStorage(lg *logger.SugarLogger, walDir string, snapDir string) (*Raft
 	}, nil
 }
 
+// CreateInMemoryStorage creates a storage that keeps etcd/raft data in memory only, for a node whose ledger is kept
+// in memory too. Nothing survives a restart, hence the node always starts with an empty Raft log.
+func CreateInMemoryStorage(lg *logger.SugarLogger) *RaftStorage {
+	return &RaftStorage{
+		lg:            lg,
+		MemoryStorage: raft.NewMemoryStorage(),
+	}
+}
+
+// inMemory returns true if the storage does not persist etcd/raft data
+func (rs *RaftStorage) inMemory() bool {
+	return rs.wal == nil
+}
+
 // ListSnapshots returns a list of RaftIndex of snapshots stored on disk.
 // If a file is corrupted, rename the file.
 func ListSnapshots(logger *logger.SugarLogger, snapDir string) []uint64 {
@@ -225,8 +239,10 @@ func (rs *RaftStorage) Snapshot() raftpb.Snapshot {
 
 // Store persists etcd/raft data
 func (rs *RaftStorage) Store(entries []raftpb.Entry, hardstate raftpb.HardState, snapshot raftpb.Snapshot) error {
-	if err := rs.wal.Save(hardstate, entries); err != nil {
-		return err
+	if !rs.inMemory() {
+		if err := rs.wal.Save(hardstate, entries); err != nil {
+			return err
+		}
 	}
 
 	if !raft.IsEmptySnap(snapshot) {
@@ -252,6 +268,10 @@ func (rs *RaftStorage) Store(entries []raftpb.Entry, hardstate raftpb.HardState,
 }
 
 func (rs *RaftStorage) saveSnap(snap raftpb.Snapshot) error {
+	if rs.inMemory() {
+		return nil
+	}
+
 	rs.lg.Infof("Persisting snapshot (term: %d, index: %d) to WAL and disk", snap.Metadata.Term, snap.Metadata.Index)
 
 	// must save the snapshot index to the WAL before saving the
@@ -320,6 +340,9 @@ func (rs *RaftStorage) gc() {
 	}
 
 	rs.snapshotIndex = rs.snapshotIndex[len(rs.snapshotIndex)-MaxSnapshotFiles:]
+	if rs.inMemory() {
+		return
+	}
 
 	rs.purgeWAL()
 	rs.purgeSnap()
@@ -424,6 +447,10 @@ func (rs *RaftStorage) ApplySnapshot(snap raftpb.Snapshot) {
 
 // Close closes storage
 func (rs *RaftStorage) Close() error {
+	if rs.inMemory() {
+		return nil
+	}
+
 	if err := rs.wal.Close(); err != nil {
 		return err
 	}
@@ -398,3 +398,45 @@ func TestApplyOutOfDateSnapshot(t *testing.T) {
 		assertFileCount(t, 12, 1)
 	})
 }
+
+func TestInMemoryStorage(t *testing.T) {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	lg, err := logger.New(c)
+	require.NoError(t, err)
+
+	backup := MaxSnapshotFiles
+	MaxSnapshotFiles = 2
+	defer func() { MaxSnapshotFiles = backup }()
+
+	store := CreateInMemoryStorage(lg)
+	store.SnapshotCatchUpEntries = 2
+
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, store.Store(
+			[]raftpb.Entry{{Index: uint64(i), Term: 1, Data: make([]byte, 100)}},
+			raftpb.HardState{Term: 1, Commit: uint64(i)},
+			raftpb.Snapshot{},
+		))
+	}
+	lastIndex, err := store.MemoryStorage.LastIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), lastIndex)
+
+	for _, i := range []uint64{4, 6, 8} {
+		require.NoError(t, store.TakeSnapshot(i, raftpb.ConfState{Voters: []uint64{1}}, make([]byte, 10)))
+	}
+	require.Equal(t, []uint64{6, 8}, store.snapshotIndex)
+	require.Equal(t, uint64(8), store.Snapshot().Metadata.Index)
+
+	// the entries prior to the catch-up entries are purged from memory
+	firstIndex, err := store.MemoryStorage.FirstIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), firstIndex)
+
+	require.NoError(t, store.Close())
+}
@@ -34,20 +34,32 @@ func raftEntryString(e raftpb.Entry) string {
 //
 // This method checks that the changes between one ConsensusConfig to the next are safe, because some mutations might
 // cause a permanent loss of quorum in the cluster, something that is very difficult to recover from.
-// - Members can be added or removed (membership change) one member at a time
-// - Members' endpoints cannot be changed together with a membership change
-// - Members' endpoints can be updated one at a time
-// - An existing member cannot change its Raft ID (it must be removed from the cluster and added again as a new member)
-// - The Raft ID of a new member must be unique - therefore it must be larger than MaxRaftId
+// - Members or observers can be added or removed (membership change) one peer at a time
+// - Peers' endpoints cannot be changed together with a membership change
+// - Peers' endpoints can be updated one at a time
+// - An existing peer cannot change its Raft ID (it must be removed from the cluster and added again as a new peer)
+// - An existing peer cannot change its role between member and observer (it must be removed and added again)
+// - The Raft ID of a new peer must be unique - therefore it must be larger than MaxRaftId
+// - An observer with Raft ID 0, which predates the replication by observers, is added once it is assigned a Raft ID
+// - The consensus algorithm cannot be changed, and the BFT algorithm does not support membership changes yet
 //
 // We assume that both the current and updated ClusterConfig are internally consistent, specifically, that the Nodes
-// and the ConsensusConfig.Members arrays match by NodeId in each.
+// and the ConsensusConfig.Members and the Raft observers (see ConsensusConfig.RaftObservers) arrays match by NodeId in
+// each.
 func VerifyConsensusReConfig(currentConfig, updatedConfig *types.ConsensusConfig, lg *logger.SugarLogger) error {
+	if currentConfig.Algorithm != updatedConfig.Algorithm {
+		return errors.Errorf("cannot change the consensus algorithm: current=%s, updated=%s", currentConfig.Algorithm, updatedConfig.Algorithm)
+	}
+
 	addedPeers, removedPeers, changedPeers, err := detectPeerConfigChanges(currentConfig, updatedConfig)
 	if err != nil {
 		return err
 	}
 
+	if updatedConfig.Algorithm == ConsensusAlgorithmBFT && len(addedPeers)+len(removedPeers) > 0 {
+		return errors.Errorf("the BFT consensus does not support membership changes: %d added, %d removed", len(addedPeers), len(removedPeers))
+	}
+
 	if len(addedPeers)+len(removedPeers) > 1 {
 		return errors.Errorf("cannot make more than one membership change at a time: %d added, %d removed", len(addedPeers), len(removedPeers))
 	}
@@ -56,24 +68,44 @@ func VerifyConsensusReConfig(currentConfig, updatedConfig *types.ConsensusConfig
 		return errors.Errorf("cannot update peer endpoints while making membership changes: %d added, %d removed, %d updated", len(addedPeers), len(removedPeers), len(changedPeers))
 	}
 
-	if !proto.Equal(currentConfig.RaftConfig, updatedConfig.RaftConfig) {
+	// the compression and the entry format are applied as soon as the config commits, all other RaftConfig changes
+	// require a restart
+	currentRaftConfig := proto.Clone(currentConfig.RaftConfig).(*types.RaftConfig)
+	updatedRaftConfig := proto.Clone(updatedConfig.RaftConfig).(*types.RaftConfig)
+	if currentRaftConfig != nil && updatedRaftConfig != nil {
+		currentRaftConfig.Compression, updatedRaftConfig.Compression = "", ""
+		currentRaftConfig.EntryFormatVersion, updatedRaftConfig.EntryFormatVersion = 0, 0
+	}
+	if !proto.Equal(currentRaftConfig, updatedRaftConfig) {
 		lg.Warning("ConsensusConfig RaftConfig changed, the new RaftConfig will be applied after server restart!")
 	}
+	if !proto.Equal(currentConfig.BftConfig, updatedConfig.BftConfig) {
+		lg.Warning("ConsensusConfig BFTConfig changed, the new BFTConfig will be applied after server restart!")
+	}
 
 	return nil
 }
 
+// detectPeerConfigChanges detects the changes in the Raft peers, that is, both the members and the observers.
 func detectPeerConfigChanges(currentConfig, updatedConfig *types.ConsensusConfig) (addedPeers, removedPeers, changedPeers []*types.PeerConfig, err error) {
 	currPeers := make(map[string]*types.PeerConfig)
 	for _, m := range currentConfig.Members {
 		currPeers[m.NodeId] = m
 	}
+	for _, o := range currentConfig.RaftObservers() {
+		currPeers[o.NodeId] = o
+	}
 
 	updtPeers := make(map[string]*types.PeerConfig)
-	for _, updtMember := range updatedConfig.Members {
+	for _, updtMember := range append(append([]*types.PeerConfig{}, updatedConfig.Members...), updatedConfig.RaftObservers()...) {
 		updtPeers[updtMember.NodeId] = updtMember
 		if currMember, ok := currPeers[updtMember.NodeId]; ok {
 			// existing peer
+			if isObserver(currentConfig, currMember.NodeId) != isObserver(updatedConfig, updtMember.NodeId) {
+				return nil, nil, nil,
+					errors.Errorf("cannot change the role of an existing peer between member and observer: NodeId=%s",
+						currMember.NodeId)
+			}
 			if !proto.Equal(updtMember, currMember) {
 				if updtMember.RaftId != currMember.RaftId {
 					return nil, nil, nil,
@@ -103,6 +135,16 @@ func detectPeerConfigChanges(currentConfig, updatedConfig *types.ConsensusConfig
 	return
 }
 
+// isObserver checks whether a node is an observer, that is, a non-voting Raft learner.
+func isObserver(config *types.ConsensusConfig, nodeID string) bool {
+	for _, o := range config.RaftObservers() {
+		if o.NodeId == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
 // ClassifyClusterReConfig detects the kind of changes that happened in the ClusterConfig.
 // We assume that both the current and updated config are internally consistent (valid), but not necessarily with
 // respect to each other.
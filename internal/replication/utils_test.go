@@ -160,6 +160,132 @@ func TestVerifyConsensusReConfig(t *testing.T) {
 		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
 		require.EqualError(t, err, "the RaftId of a new peer must be unique,  > MaxRaftId [5]; but: NodeId=node4, RaftID=4")
 	})
+
+	observer := &types.PeerConfig{
+		NodeId:   "node4",
+		RaftId:   6,
+		PeerHost: "127.0.0.1",
+		PeerPort: 7094,
+	}
+
+	t.Run("valid: add an observer", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Observers = append(updateConfig.Observers, observer)
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.NoError(t, err)
+
+		added, removed, changed, err := detectPeerConfigChanges(clusterConfig.ConsensusConfig, updateConfig)
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		require.True(t, proto.Equal(observer, added[0]))
+		require.Len(t, removed, 0)
+		require.Len(t, changed, 0)
+	})
+
+	t.Run("valid: remove an observer", func(t *testing.T) {
+		currentConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		currentConfig.Observers = append(currentConfig.Observers, observer)
+		currentConfig.RaftConfig.MaxRaftId = 6
+		updateConfig := proto.Clone(currentConfig).(*types.ConsensusConfig)
+		updateConfig.Observers = nil
+		err := VerifyConsensusReConfig(currentConfig, updateConfig, lg)
+		require.NoError(t, err)
+
+		added, removed, changed, err := detectPeerConfigChanges(currentConfig, updateConfig)
+		require.NoError(t, err)
+		require.Len(t, added, 0)
+		require.Len(t, removed, 1)
+		require.True(t, proto.Equal(observer, removed[0]))
+		require.Len(t, changed, 0)
+	})
+
+	t.Run("valid: an observer with raft ID 0 is assigned a raft ID", func(t *testing.T) {
+		// observers with raft ID 0 predate the replication by observers, they are not raft peers
+		currentConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		currentConfig.Observers = append(currentConfig.Observers, &types.PeerConfig{
+			NodeId:   "node4",
+			RaftId:   0,
+			PeerHost: "127.0.0.1",
+			PeerPort: 7094,
+		})
+		updateConfig := proto.Clone(currentConfig).(*types.ConsensusConfig)
+		updateConfig.RaftConfig.SnapshotIntervalSize++
+		err := VerifyConsensusReConfig(currentConfig, updateConfig, lg)
+		require.NoError(t, err)
+		added, removed, changed, err := detectPeerConfigChanges(currentConfig, updateConfig)
+		require.NoError(t, err)
+		require.Len(t, added, 0)
+		require.Len(t, removed, 0)
+		require.Len(t, changed, 0)
+
+		updateConfig.Observers[0].RaftId = 6
+		err = VerifyConsensusReConfig(currentConfig, updateConfig, lg)
+		require.NoError(t, err)
+		added, removed, changed, err = detectPeerConfigChanges(currentConfig, updateConfig)
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		require.True(t, proto.Equal(observer, added[0]))
+		require.Len(t, removed, 0)
+		require.Len(t, changed, 0)
+		require.True(t, isObserver(updateConfig, "node4"))
+		require.False(t, isObserver(currentConfig, "node4"))
+	})
+
+	t.Run("invalid: add a member and an observer", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Observers = append(updateConfig.Observers, observer)
+		updateConfig.Members = append(updateConfig.Members, &types.PeerConfig{
+			NodeId:   "node5",
+			RaftId:   7,
+			PeerHost: "127.0.0.1",
+			PeerPort: 7095,
+		})
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.EqualError(t, err, "cannot make more than one membership change at a time: 2 added, 0 removed")
+	})
+
+	t.Run("invalid: add an observer with non-unique RaftID", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Observers = append(updateConfig.Observers, &types.PeerConfig{
+			NodeId:   "node4",
+			RaftId:   5,
+			PeerHost: "127.0.0.1",
+			PeerPort: 7094,
+		})
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.EqualError(t, err, "the RaftId of a new peer must be unique,  > MaxRaftId [5]; but: NodeId=node4, RaftID=5")
+	})
+
+	t.Run("invalid: member becomes an observer", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Observers = append(updateConfig.Observers, updateConfig.Members[2])
+		updateConfig.Members = updateConfig.Members[0:2]
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.EqualError(t, err, "cannot change the role of an existing peer between member and observer: NodeId=node3")
+	})
+
+	t.Run("invalid: change the algorithm", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Algorithm = ConsensusAlgorithmBFT
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.EqualError(t, err, "cannot change the consensus algorithm: current=raft, updated=bft")
+	})
+
+	t.Run("BFT", func(t *testing.T) {
+		currentConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		currentConfig.Algorithm = ConsensusAlgorithmBFT
+
+		updateConfig := proto.Clone(currentConfig).(*types.ConsensusConfig)
+		updateConfig.Members[0].PeerPort++
+		updateConfig.BftConfig = &types.BFTConfig{ViewChangeTimeout: "5s"}
+		err := VerifyConsensusReConfig(currentConfig, updateConfig, lg)
+		require.NoError(t, err)
+
+		updateConfig = proto.Clone(currentConfig).(*types.ConsensusConfig)
+		updateConfig.Members = updateConfig.Members[0:2]
+		err = VerifyConsensusReConfig(currentConfig, updateConfig, lg)
+		require.EqualError(t, err, "the BFT consensus does not support membership changes: 0 added, 1 removed")
+	})
 }
 
 func testClusterConfig() *types.ClusterConfig {
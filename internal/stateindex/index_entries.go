@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -343,3 +344,8 @@ func toStrings(indexEntries []*IndexEntry) ([]string, error) {
 func IndexDB(dbName string) string {
 	return indexDBPrefix + dbName
 }
+
+// IsIndexDB returns true if the given db holds the index of a user database
+func IsIndexDB(dbName string) bool {
+	return strings.HasPrefix(dbName, indexDBPrefix)
+}
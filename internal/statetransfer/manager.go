@@ -0,0 +1,633 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statetransfer transfers a consistent copy of the world state, the state trie, and the
+// provenance store of a remote peer to a node that is far behind the cluster, so that the node
+// does not need to re-execute the missing blocks. The transferred state trie is checked to be the
+// complete trie of the state trie root hash in the header of the block the snapshot was taken at,
+// and every key-value pair of the transferred world state that is covered by the state trie must
+// be proven by it. The state trie does not cover the metadata of the values, the derived databases,
+// i.e., the indexes, the quota usage, and the expiry index, and the provenance store, and it keeps
+// the keys of the deleted databases, so that a key omitted by the peer cannot be told apart from a
+// deleted one: these are taken as sent by the peer. Only the blocks that the node misses up to the
+// snapshot are pulled, and they are appended to the block store without being executed. The
+// transferred stores are installed atomically with respect to a crash, see Manager.Install and
+// Manager.Recover.
+package statetransfer
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	trieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	stagedWorldStateDir = "worldstate"
+	stagedStateTrieDir  = "statetrie"
+	stagedProvenanceDir = "provenance"
+	// stagedBlocksFile holds the blocks pulled up to the snapshot, each prefixed by its length
+	// as an uvarint, which are appended to the block store on installation
+	stagedBlocksFile = "blocks"
+
+	// installFlag marks that the staged stores were verified and are being installed. If a failure
+	// happens during the installation, Recover uses this file to complete the installation.
+	installFlag = "install"
+)
+
+// Committer is the component that commits blocks to the stores, i.e., the block processor
+type Committer interface {
+	// RunExclusively runs f while no block is being committed
+	RunExclusively(f func() error) error
+	// ReloadStateTrie reloads the state trie after the stores were replaced
+	ReloadStateTrie() error
+}
+
+// Config holds the configuration of the state transfer manager
+type Config struct {
+	StagingDir      string
+	DB              worldstate.TransferableDB
+	BlockStore      *blockstore.Store
+	ProvenanceStore *provenance.Store
+	StateTrieStore  *trieStore.Store
+	Logger          *logger.SugarLogger
+}
+
+// Manager takes state snapshots to be sent to remote peers, and stages, verifies, and installs
+// state snapshots received from remote peers.
+type Manager struct {
+	stagingDir      string
+	db              worldstate.TransferableDB
+	blockStore      *blockstore.Store
+	provenanceStore *provenance.Store
+	stateTrieStore  *trieStore.Store
+	committer       Committer
+	logger          *logger.SugarLogger
+
+	mutex  sync.Mutex
+	staged *stagedState
+}
+
+type stagedState struct {
+	height uint64
+	// blocks holds the staged blocks, the next of which is nextBlockNum
+	blocks        *os.File
+	blocksWriter  *recordWriter
+	nextBlockNum  uint64
+	lastBlockHash []byte
+	// lastHeader is the header of the block the snapshot was taken at, once staged
+	lastHeader *types.BlockHeader
+}
+
+func (s *stagedState) close() error {
+	return s.blocks.Close()
+}
+
+// New creates a state transfer manager
+func New(conf *Config) *Manager {
+	return &Manager{
+		stagingDir:      conf.StagingDir,
+		db:              conf.DB,
+		blockStore:      conf.BlockStore,
+		provenanceStore: conf.ProvenanceStore,
+		stateTrieStore:  conf.StateTrieStore,
+		logger:          conf.Logger,
+	}
+}
+
+// SetCommitter sets the component that commits blocks to the stores. This must be called before
+// taking or installing a snapshot.
+func (m *Manager) SetCommitter(c Committer) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.committer = c
+}
+
+// Recover completes an installation that was interrupted by a failure, or removes the staged
+// stores of a state transfer that was not completed. It must be called after the stores are
+// opened, and before any block is committed.
+func (m *Manager) Recover() error {
+	exist, err := fileops.Exists(filepath.Join(m.stagingDir, installFlag))
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		m.logger.Infof("completing the installation of a transferred state snapshot")
+		if err := m.installStaged(); err != nil {
+			return err
+		}
+	}
+
+	return fileops.RemoveAll(m.stagingDir)
+}
+
+// TakeSnapshot takes a snapshot of the world state, the state trie, and the provenance store as
+// of the last committed block. The snapshot must be released after use.
+func (m *Manager) TakeSnapshot() (*Snapshot, error) {
+	m.mutex.Lock()
+	committer := m.committer
+	m.mutex.Unlock()
+
+	if committer == nil {
+		return nil, errors.New("state snapshots are not available yet")
+	}
+
+	s := &Snapshot{}
+	err := committer.RunExclusively(func() error {
+		var err error
+		if s.height, err = m.blockStore.Height(); err != nil {
+			return err
+		}
+		if s.worldState, err = m.db.Checkpoint(); err != nil {
+			return err
+		}
+		if s.stateTrie, err = m.stateTrieStore.Checkpoint(); err != nil {
+			return err
+		}
+		s.provenance, err = m.provenanceStore.Checkpoint()
+		return err
+	})
+	if err != nil {
+		s.Release()
+		return nil, errors.WithMessage(err, "error while taking a state snapshot")
+	}
+
+	return s, nil
+}
+
+// WriteStateSnapshot takes a snapshot of the state and writes it to w as a stream. If the snapshot
+// cannot be taken, an error is returned before anything is written.
+func (m *Manager) WriteStateSnapshot(w io.Writer) error {
+	s, err := m.TakeSnapshot()
+	if err != nil {
+		return err
+	}
+	defer s.Release()
+
+	m.logger.Infof("sending a state snapshot taken at block [%d]", s.Height())
+	_, err = s.WriteTo(w)
+	return err
+}
+
+// Stage reads a state snapshot stream and writes it to new stores in the staging directory. It
+// returns the number of the block the snapshot was taken at. Any previously staged state is
+// discarded. The blocks that follow the last block of the block store, up to that number, must
+// be staged by StageBlock before the state is installed.
+func (m *Manager) Stage(r io.Reader) (uint64, error) {
+	if err := m.Discard(); err != nil {
+		return 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	staged, err := m.stage(r)
+	if err != nil {
+		if rmErr := fileops.RemoveAll(m.stagingDir); rmErr != nil {
+			m.logger.Errorf("error while removing the staging directory: %s", rmErr)
+		}
+		return 0, err
+	}
+
+	m.staged = staged
+	m.logger.Infof("staged a state snapshot taken at block [%d]", staged.height)
+
+	return staged.height, nil
+}
+
+func (m *Manager) stage(r io.Reader) (*stagedState, error) {
+	localHeight, err := m.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := m.stageStores(r)
+	if err != nil {
+		return nil, err
+	}
+	if height <= localHeight {
+		return nil, errors.Errorf("the state snapshot is at block [%d], which is not ahead of the block store at block [%d]", height, localHeight)
+	}
+
+	var lastBlockHash []byte
+	if localHeight > 0 {
+		if lastBlockHash, err = m.blockStore.GetHash(localHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	blocks, err := os.Create(filepath.Join(m.stagingDir, stagedBlocksFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating the file of the staged blocks")
+	}
+
+	return &stagedState{
+		height:        height,
+		blocks:        blocks,
+		blocksWriter:  newRecordWriter(blocks),
+		nextBlockNum:  localHeight + 1,
+		lastBlockHash: lastBlockHash,
+	}, nil
+}
+
+func (m *Manager) stageStores(r io.Reader) (uint64, error) {
+	if err := fileops.CreateDir(m.stagingDir); err != nil {
+		return 0, err
+	}
+
+	worldStateWriter, err := m.db.NewCheckpointWriter(filepath.Join(m.stagingDir, stagedWorldStateDir))
+	if err != nil {
+		return 0, err
+	}
+	stateTrieWriter, err := trieStore.NewCheckpointWriter(filepath.Join(m.stagingDir, stagedStateTrieDir), m.logger)
+	if err != nil {
+		worldStateWriter.Close()
+		return 0, err
+	}
+	provenanceWriter, err := provenance.NewCheckpointWriter(filepath.Join(m.stagingDir, stagedProvenanceDir))
+	if err != nil {
+		worldStateWriter.Close()
+		stateTrieWriter.Close()
+		return 0, err
+	}
+
+	height, err := readSnapshot(r, worldStateWriter, stateTrieWriter, provenanceWriter)
+	for _, w := range []io.Closer{worldStateWriter, stateTrieWriter, provenanceWriter} {
+		if closeErr := w.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return height, err
+}
+
+func readSnapshot(
+	r io.Reader,
+	worldStateWriter worldstate.CheckpointWriter,
+	stateTrieWriter *trieStore.CheckpointWriter,
+	provenanceWriter *provenance.CheckpointWriter,
+) (uint64, error) {
+	rr := newRecordReader(r)
+
+	height, err := rr.readHeader()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		kind, dbName, key, value, err := rr.readRecord()
+		if err != nil {
+			return 0, err
+		}
+
+		switch kind {
+		case recordEnd:
+			return height, nil
+		case recordDatabase:
+			err = worldStateWriter.CreateDB(dbName)
+		case recordWorldState:
+			err = worldStateWriter.Put(dbName, key, value)
+		case recordStateTrie:
+			err = stateTrieWriter.Put(key, value)
+		case recordProvenance:
+			err = provenanceWriter.Put(key, value)
+		default:
+			err = errors.Errorf("unknown record kind [%d] in the state snapshot", kind)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// StageBlock adds the next block to the staged blocks. The blocks must be staged in order, starting
+// from the block that follows the last block of the block store, up to the block the staged snapshot
+// was taken at. Each block must be linked to the previous one by the hash in its skip list.
+func (m *Manager) StageBlock(block *types.Block) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.staged == nil {
+		return errors.New("no state snapshot is staged")
+	}
+
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	if blockNum > m.staged.height {
+		return errors.Errorf("block [%d] is beyond the staged state snapshot, which was taken at block [%d]", blockNum, m.staged.height)
+	}
+	if blockNum != m.staged.nextBlockNum {
+		return errors.Errorf("expected block [%d] but received block [%d]", m.staged.nextBlockNum, blockNum)
+	}
+
+	if blockNum > 1 {
+		skipchainHashes := block.GetHeader().GetSkipchainHashes()
+		if len(skipchainHashes) == 0 || !bytes.Equal(skipchainHashes[0], m.staged.lastBlockHash) {
+			return errors.Errorf("block [%d] is not linked to the hash of the previous block", blockNum)
+		}
+	}
+
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return errors.Wrapf(err, "error while marshaling block [%d]", blockNum)
+	}
+	if err := m.staged.blocksWriter.writeField(blockBytes); err != nil {
+		return errors.Wrap(err, "error while writing to the file of the staged blocks")
+	}
+
+	hash, err := blockstore.ComputeBlockHash(block)
+	if err != nil {
+		return err
+	}
+	m.staged.lastBlockHash = hash
+	m.staged.nextBlockNum++
+	if blockNum == m.staged.height {
+		m.staged.lastHeader = block.GetHeader()
+	}
+
+	return nil
+}
+
+// Install verifies the staged stores against the state trie root hash of the block the snapshot was
+// taken at, and replaces the world state, the state trie, and the provenance store with the staged
+// ones. The staged blocks are then appended to the block store. The header of the block the snapshot
+// was taken at must have been verified by the caller, as the staged blocks are linked to it. The
+// installation is completed by Recover if a failure happens in between.
+func (m *Manager) Install() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.staged == nil {
+		return errors.New("no state snapshot is staged")
+	}
+	if m.committer == nil {
+		return errors.New("the committer is not set")
+	}
+
+	if err := m.verify(); err != nil {
+		return errors.WithMessage(err, "error while verifying the state snapshot")
+	}
+
+	if err := m.staged.blocksWriter.w.Flush(); err != nil {
+		return errors.Wrap(err, "error while writing to the file of the staged blocks")
+	}
+	if err := m.staged.blocks.Sync(); err != nil {
+		return errors.Wrap(err, "error while syncing the file of the staged blocks")
+	}
+	if err := m.staged.close(); err != nil {
+		return err
+	}
+	m.staged = nil
+
+	if err := fileops.CreateFile(filepath.Join(m.stagingDir, installFlag)); err != nil {
+		return err
+	}
+
+	err := m.committer.RunExclusively(func() error {
+		if err := m.installStaged(); err != nil {
+			return err
+		}
+		return m.committer.ReloadStateTrie()
+	})
+	if err != nil {
+		return errors.WithMessage(err, "error while installing the state snapshot")
+	}
+
+	return fileops.RemoveAll(m.stagingDir)
+}
+
+// Discard removes the staged state, if any. A state whose installation has started cannot be
+// discarded, as the stores may already be partially replaced; it is completed by Recover.
+func (m *Manager) Discard() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	installing, err := fileops.Exists(filepath.Join(m.stagingDir, installFlag))
+	if err != nil {
+		return err
+	}
+	if installing {
+		return errors.New("the installation of the staged state has started, it is completed on restart")
+	}
+
+	if m.staged != nil {
+		if err := m.staged.close(); err != nil {
+			m.logger.Errorf("error while closing the file of the staged blocks: %s", err)
+		}
+		m.staged = nil
+	}
+
+	return fileops.RemoveAll(m.stagingDir)
+}
+
+// installStaged replaces the stores with the staged ones and appends the staged blocks to the block
+// store. A store that was already replaced, and the blocks that were already appended, before a
+// failure are skipped.
+func (m *Manager) installStaged() error {
+	stores := []struct {
+		dir     string
+		replace func(dir string) error
+	}{
+		{dir: stagedWorldStateDir, replace: m.db.Replace},
+		{dir: stagedStateTrieDir, replace: m.stateTrieStore.Replace},
+		{dir: stagedProvenanceDir, replace: m.provenanceStore.Replace},
+	}
+
+	for _, s := range stores {
+		dir := filepath.Join(m.stagingDir, s.dir)
+		exist, err := fileops.Exists(dir)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			// already replaced before a failure
+			continue
+		}
+
+		if err := s.replace(dir); err != nil {
+			return errors.WithMessagef(err, "error while replacing the store with [%s]", dir)
+		}
+	}
+
+	return m.commitStagedBlocks()
+}
+
+func (m *Manager) commitStagedBlocks() error {
+	height, err := m.blockStore.Height()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filepath.Join(m.stagingDir, stagedBlocksFile))
+	if err != nil {
+		return errors.Wrap(err, "error while opening the file of the staged blocks")
+	}
+	defer f.Close()
+
+	rr := newRecordReader(f)
+	for {
+		if _, err := rr.r.Peek(1); err == io.EOF {
+			return nil
+		}
+
+		blockBytes, err := rr.readField()
+		if err != nil {
+			return err
+		}
+		block := &types.Block{}
+		if err := proto.Unmarshal(blockBytes, block); err != nil {
+			return errors.Wrap(err, "error while unmarshaling a staged block")
+		}
+
+		if block.GetHeader().GetBaseHeader().GetNumber() <= height {
+			// already committed before a failure
+			continue
+		}
+		if err := m.blockStore.Commit(block); err != nil {
+			return err
+		}
+	}
+}
+
+// verify checks that all the blocks up to the snapshot are staged, and that the staged state trie is
+// the complete trie of the state trie root hash of the block the snapshot was taken at. It then checks
+// that every key-value pair of the staged world state which is covered by the state trie, i.e., every
+// pair which is not an index, a quota usage, an expiry entry, or metadata, is proven by the trie.
+func (m *Manager) verify() error {
+	height := m.staged.height
+	if m.staged.lastHeader == nil {
+		return errors.Errorf("the staged blocks end at block [%d] while the snapshot was taken at block [%d]", m.staged.nextBlockNum-1, height)
+	}
+
+	stagedTrieStore, err := trieStore.Open(
+		&trieStore.Config{
+			StoreDir: filepath.Join(m.stagingDir, stagedStateTrieDir),
+			Logger:   m.logger,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer stagedTrieStore.Close()
+
+	trieHeight, err := stagedTrieStore.Height()
+	if err != nil {
+		return errors.Wrap(err, "error while reading the height of the state trie")
+	}
+	if trieHeight != height {
+		return errors.Errorf("the state trie is at block [%d] while the snapshot was taken at block [%d]", trieHeight, height)
+	}
+
+	trie, err := mptrie.NewTrie(m.staged.lastHeader.GetStateMerkelTreeRootHash(), stagedTrieStore)
+	if err != nil {
+		return errors.WithMessagef(err, "the state trie does not hold the root of block [%d]", height)
+	}
+	rootHash, err := trie.Hash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rootHash, m.staged.lastHeader.GetStateMerkelTreeRootHash()) {
+		return errors.Errorf("the root of the state trie does not match the state root of block [%d]", height)
+	}
+	if err := trie.Verify(nil); err != nil {
+		return err
+	}
+
+	stagedDB, err := m.db.OpenCheckpoint(filepath.Join(m.stagingDir, stagedWorldStateDir))
+	if err != nil {
+		return err
+	}
+	defer stagedDB.Close()
+
+	dbHeight, err := stagedDB.Height()
+	if err != nil {
+		return err
+	}
+	if dbHeight != height {
+		return errors.Errorf("the world state is at block [%d] while the snapshot was taken at block [%d]", dbHeight, height)
+	}
+
+	checkpoint, err := stagedDB.Checkpoint()
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Release()
+
+	for _, dbName := range checkpoint.DBNames() {
+		if err := verifyDBExistence(stagedDB, dbName); err != nil {
+			return err
+		}
+	}
+
+	return checkpoint.ForEach(func(dbName string, key, value []byte) error {
+		if !coveredByStateTrie(dbName) {
+			return nil
+		}
+
+		compositeKey, err := state.ConstructCompositeKey(dbName, string(key))
+		if err != nil {
+			return err
+		}
+		trieValue, err := trie.Get(compositeKey)
+		if err != nil {
+			return err
+		}
+
+		v := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(value, v); err != nil {
+			return errors.Wrapf(err, "error while unmarshaling the value of key [%s] in database [%s]", key, dbName)
+		}
+		if trieValue == nil || !bytes.Equal(trieValue, v.GetValue()) {
+			return errors.Errorf("the value of key [%s] in database [%s] is not proven by the state trie", key, dbName)
+		}
+
+		if dbName == worldstate.DatabasesDBName && worldstate.IsDatabaseKey(string(key)) && !stagedDB.Exist(string(key)) {
+			return errors.Errorf("database [%s] is missing from the state snapshot", key)
+		}
+		return nil
+	})
+}
+
+// verifyDBExistence checks that a user database of the staged world state is recorded in the
+// DatabasesDBName, whose entries are proven by the state trie
+func verifyDBExistence(stagedDB worldstate.DB, dbName string) error {
+	if !coveredByStateTrie(dbName) || worldstate.IsSystemDB(dbName) || worldstate.IsDefaultWorldStateDB(dbName) {
+		return nil
+	}
+
+	value, metadata, err := stagedDB.Get(worldstate.DatabasesDBName, dbName)
+	if err != nil {
+		return err
+	}
+	if value == nil && metadata == nil {
+		return errors.Errorf("database [%s] of the state snapshot does not exist in the state trie", dbName)
+	}
+	return nil
+}
+
+// coveredByStateTrie returns true if the key-value pairs of the database are added to the state
+// trie, i.e., the database is not an index, the quota usage, the expiry index, or the metadata of
+// the world state, which are derived from the other databases
+func coveredByStateTrie(dbName string) bool {
+	switch dbName {
+	case worldstate.MetadataDBName, worldstate.QuotasDBName, worldstate.ExpiryDBName:
+		return false
+	default:
+		return !stateindex.IsIndexDB(dbName)
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package statetransfer
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	trieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCommitter struct {
+	err     error
+	reloads int
+}
+
+func (c *fakeCommitter) RunExclusively(f func() error) error {
+	if c.err != nil {
+		return c.err
+	}
+	return f()
+}
+
+func (c *fakeCommitter) ReloadStateTrie() error {
+	c.reloads++
+	return nil
+}
+
+type testNode struct {
+	dir             string
+	db              *leveldb.LevelDB
+	blockStore      *blockstore.Store
+	provenanceStore *provenance.Store
+	stateTrieStore  *trieStore.Store
+	committer       *fakeCommitter
+	manager         *Manager
+}
+
+func newTestNode(t *testing.T, dir string, lg *logger.SugarLogger) *testNode {
+	n := &testNode{
+		dir:       dir,
+		committer: &fakeCommitter{},
+	}
+
+	var err error
+	n.db, err = leveldb.Open(&leveldb.Config{DBRootDir: filepath.Join(dir, "worldstate"), Logger: lg})
+	require.NoError(t, err)
+	n.blockStore, err = blockstore.Open(&blockstore.Config{StoreDir: filepath.Join(dir, "blockstore"), Logger: lg})
+	require.NoError(t, err)
+	n.provenanceStore, err = provenance.Open(&provenance.Config{StoreDir: filepath.Join(dir, "provenance"), Logger: lg})
+	require.NoError(t, err)
+	n.stateTrieStore, err = trieStore.Open(&trieStore.Config{StoreDir: filepath.Join(dir, "statetrie"), Logger: lg})
+	require.NoError(t, err)
+
+	n.manager = New(&Config{
+		StagingDir:      filepath.Join(dir, "statetransfer"),
+		DB:              n.db,
+		BlockStore:      n.blockStore,
+		ProvenanceStore: n.provenanceStore,
+		StateTrieStore:  n.stateTrieStore,
+		Logger:          lg,
+	})
+	require.NoError(t, n.manager.Recover())
+	n.manager.SetCommitter(n.committer)
+
+	return n
+}
+
+func (n *testNode) close(t *testing.T) {
+	require.NoError(t, n.db.Close())
+	require.NoError(t, n.blockStore.Close())
+	require.NoError(t, n.provenanceStore.Close())
+	require.NoError(t, n.stateTrieStore.Close())
+}
+
+// commitBlocks commits blocks that write key-<block> and delete the key written two blocks before. The blocks are
+// committed to the block store, and are replayed to fill the other stores.
+func (n *testNode) commitBlocks(t *testing.T, numBlocks uint64) {
+	trieDir, err := ioutil.TempDir("", "statetransfer-trie")
+	require.NoError(t, err)
+	defer os.RemoveAll(trieDir)
+	scratchTrieStore, err := trieStore.Open(&trieStore.Config{StoreDir: trieDir, Logger: n.manager.logger})
+	require.NoError(t, err)
+	defer scratchTrieStore.Close()
+	trie, err := mptrie.NewTrie(nil, scratchTrieStore)
+	require.NoError(t, err)
+
+	var prevBlockHash []byte
+	for blockNum := uint64(1); blockNum <= numBlocks; blockNum++ {
+		tx := &types.DataTx{
+			MustSignUserIds: []string{"alice"},
+			TxId:            fmt.Sprintf("tx-%d", blockNum),
+			DbOperations: []*types.DBOperation{
+				{
+					DbName: worldstate.DefaultDBName,
+					DataWrites: []*types.DataWrite{
+						{
+							Key:   fmt.Sprintf("key-%d", blockNum),
+							Value: []byte(fmt.Sprintf("value-%d", blockNum)),
+							Acl:   &types.AccessControl{ReadUsers: map[string]bool{"alice": true}},
+						},
+					},
+				},
+			},
+		}
+		if blockNum > 2 {
+			tx.DbOperations[0].DataDeletes = []*types.DataDelete{{Key: fmt.Sprintf("key-%d", blockNum-2)}}
+		}
+
+		dbsUpdates := make(map[string]*worldstate.DBUpdates)
+		blockprocessor.AddDBEntriesForDataTx(tx, &types.Version{BlockNum: blockNum}, 0, dbsUpdates)
+		require.NoError(t, blockprocessor.ApplyBlockOnStateTrie(trie, dbsUpdates))
+		rootHash, err := trie.Hash()
+		require.NoError(t, err)
+
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader:              &types.BlockHeaderBase{Number: blockNum},
+				StateMerkelTreeRootHash: rootHash,
+				ValidationInfo:          []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{{Payload: tx}},
+				},
+			},
+		}
+		if blockNum > 1 {
+			block.Header.SkipchainHashes = [][]byte{prevBlockHash}
+		}
+		require.NoError(t, n.blockStore.Commit(block))
+		prevBlockHash, err = blockstore.ComputeBlockHash(block)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, blockprocessor.Replay(&blockprocessor.ReplayConfig{
+		BlockStore:      n.blockStore,
+		DB:              n.db,
+		ProvenanceStore: n.provenanceStore,
+		StateTrieStore:  n.stateTrieStore,
+		Logger:          n.manager.logger,
+	}))
+}
+
+// stageBlocks stages the blocks of the source from the block that follows the last block of the block store up to
+// lastBlockNum
+func (n *testNode) stageBlocks(t *testing.T, source *testNode, lastBlockNum uint64) {
+	height, err := n.blockStore.Height()
+	require.NoError(t, err)
+
+	for blockNum := height + 1; blockNum <= lastBlockNum; blockNum++ {
+		block, err := source.blockStore.Get(blockNum)
+		require.NoError(t, err)
+		require.NoError(t, n.manager.StageBlock(block))
+	}
+}
+
+// rewriteSnapshot rewrites a snapshot stream, passing each record through f
+func rewriteSnapshot(t *testing.T, snapshot *bytes.Buffer, f func(kind byte, dbName string, key, value []byte) []byte) *bytes.Buffer {
+	rr := newRecordReader(snapshot)
+	height, err := rr.readHeader()
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	rw := newRecordWriter(buf)
+	require.NoError(t, rw.writeHeader(height))
+	for {
+		kind, dbName, key, value, err := rr.readRecord()
+		require.NoError(t, err)
+		if kind == recordEnd {
+			break
+		}
+		require.NoError(t, rw.writeRecord(kind, dbName, key, f(kind, dbName, key, value)))
+	}
+	require.NoError(t, rw.w.WriteByte(recordEnd))
+	require.NoError(t, rw.w.Flush())
+
+	return buf
+}
+
+func (n *testNode) requireTransferredState(t *testing.T, numBlocks uint64) {
+	height, err := n.blockStore.Height()
+	require.NoError(t, err)
+	require.Equal(t, numBlocks, height)
+	height, err = n.db.Height()
+	require.NoError(t, err)
+	require.Equal(t, numBlocks, height)
+	height, err = n.stateTrieStore.Height()
+	require.NoError(t, err)
+	require.Equal(t, numBlocks, height)
+
+	value, _, err := n.db.Get(worldstate.DefaultDBName, fmt.Sprintf("key-%d", numBlocks))
+	require.NoError(t, err)
+	require.Equal(t, []byte(fmt.Sprintf("value-%d", numBlocks)), value)
+	value, _, err = n.db.Get(worldstate.DefaultDBName, fmt.Sprintf("key-%d", numBlocks-2))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	values, err := n.provenanceStore.GetValues(worldstate.DefaultDBName, fmt.Sprintf("key-%d", numBlocks-2))
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	require.Equal(t, []byte(fmt.Sprintf("value-%d", numBlocks-2)), values[0].Value)
+
+	header, err := n.blockStore.GetHeader(numBlocks)
+	require.NoError(t, err)
+	trie, err := mptrie.NewTrie(header.GetStateMerkelTreeRootHash(), n.stateTrieStore)
+	require.NoError(t, err)
+	require.NoError(t, trie.Verify(nil))
+}
+
+func TestStateTransfer(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          "statetransfer-test",
+	})
+	require.NoError(t, err)
+
+	numBlocks := uint64(20)
+
+	setup := func(t *testing.T) (source, target *testNode, cleanup func()) {
+		testDir, err := ioutil.TempDir("", "statetransfer")
+		require.NoError(t, err)
+
+		source = newTestNode(t, filepath.Join(testDir, "source"), lg)
+		source.commitBlocks(t, numBlocks)
+		target = newTestNode(t, filepath.Join(testDir, "target"), lg)
+
+		return source, target, func() {
+			source.close(t)
+			target.close(t)
+			os.RemoveAll(testDir)
+		}
+	}
+
+	t.Run("transfer and install", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+
+		height, err := target.manager.Stage(buf)
+		require.NoError(t, err)
+		require.Equal(t, numBlocks, height)
+
+		target.stageBlocks(t, source, numBlocks)
+		require.NoError(t, target.manager.Install())
+
+		require.Equal(t, 1, target.committer.reloads)
+		target.requireTransferredState(t, numBlocks)
+		exist, err := fileops.Exists(target.manager.stagingDir)
+		require.NoError(t, err)
+		require.False(t, exist)
+	})
+
+	t.Run("installation completed by recover", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		_, err := target.manager.Stage(buf)
+		require.NoError(t, err)
+		target.stageBlocks(t, source, numBlocks)
+
+		target.committer.err = errors.New("crash")
+		require.EqualError(t, target.manager.Install(), "error while installing the state snapshot: crash")
+		require.EqualError(t, target.manager.Discard(), "the installation of the staged state has started, it is completed on restart")
+
+		target.close(t)
+		*target = *newTestNode(t, target.dir, lg)
+		target.requireTransferredState(t, numBlocks)
+	})
+
+	t.Run("catch up from the last committed block", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		target.commitBlocks(t, numBlocks/2)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		_, err := target.manager.Stage(buf)
+		require.NoError(t, err)
+
+		block, err := source.blockStore.Get(numBlocks/2 - 1)
+		require.NoError(t, err)
+		require.EqualError(t, target.manager.StageBlock(block), "expected block [11] but received block [9]")
+
+		target.stageBlocks(t, source, numBlocks)
+		require.NoError(t, target.manager.Install())
+		target.requireTransferredState(t, numBlocks)
+	})
+
+	t.Run("snapshot not ahead of the block store", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		target.commitBlocks(t, numBlocks)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		_, err := target.manager.Stage(buf)
+		require.EqualError(t, err, "the state snapshot is at block [20], which is not ahead of the block store at block [20]")
+	})
+
+	requireRejected := func(t *testing.T, snapshot *bytes.Buffer, source, target *testNode, lastBlock *types.Block, expectedErr string) {
+		_, err := target.manager.Stage(snapshot)
+		require.NoError(t, err)
+		target.stageBlocks(t, source, numBlocks-1)
+		require.NoError(t, target.manager.StageBlock(lastBlock))
+
+		err = target.manager.Install()
+		require.Error(t, err)
+		require.True(t, strings.HasPrefix(err.Error(), "error while verifying the state snapshot: "))
+		require.Contains(t, err.Error(), expectedErr)
+		require.NoError(t, target.manager.Discard())
+		require.Equal(t, 0, target.committer.reloads)
+
+		height, err := target.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), height)
+		height, err = target.db.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), height)
+	}
+
+	snapshotAndLastBlock := func(t *testing.T, source *testNode) (*bytes.Buffer, *types.Block) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		block, err := source.blockStore.Get(numBlocks)
+		require.NoError(t, err)
+		return buf, block
+	}
+
+	t.Run("key not in the state trie", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		require.NoError(t, source.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "key-0", Value: []byte("value-0")}},
+			},
+		}, numBlocks))
+
+		snapshot, lastBlock := snapshotAndLastBlock(t, source)
+		requireRejected(t, snapshot, source, target, lastBlock, "the value of key [key-0] in database [bdb] is not proven by the state trie")
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		_, metadata, err := source.db.Get(worldstate.DefaultDBName, fmt.Sprintf("key-%d", numBlocks))
+		require.NoError(t, err)
+		require.NoError(t, source.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: fmt.Sprintf("key-%d", numBlocks), Value: []byte("tampered"), Metadata: metadata}},
+			},
+		}, numBlocks))
+
+		snapshot, lastBlock := snapshotAndLastBlock(t, source)
+		requireRejected(t, snapshot, source, target, lastBlock, "the value of key [key-20] in database [bdb] is not proven by the state trie")
+	})
+
+	t.Run("tampered state trie", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		snapshot, lastBlock := snapshotAndLastBlock(t, source)
+		snapshot = rewriteSnapshot(t, snapshot, func(kind byte, _ string, key, value []byte) []byte {
+			// the values of the state trie store are in namespace 1
+			if kind != recordStateTrie || key[0] != 1 {
+				return value
+			}
+			return append(value, 'x')
+		})
+		requireRejected(t, snapshot, source, target, lastBlock, "does not match its hash")
+	})
+
+	t.Run("state root not in the state trie", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		snapshot, lastBlock := snapshotAndLastBlock(t, source)
+		lastBlock.Header.StateMerkelTreeRootHash = []byte("wrong root")
+		requireRejected(t, snapshot, source, target, lastBlock, "the state trie does not hold the root of block [20]")
+	})
+
+	t.Run("missing blocks", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		_, err := target.manager.Stage(buf)
+		require.NoError(t, err)
+		target.stageBlocks(t, source, numBlocks-1)
+
+		err = target.manager.Install()
+		require.EqualError(t, err, "error while verifying the state snapshot: the staged blocks end at block [19] while the snapshot was taken at block [20]")
+	})
+	t.Run("block not linked to the previous block", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+		_, err := target.manager.Stage(buf)
+		require.NoError(t, err)
+		target.stageBlocks(t, source, 2)
+
+		block, err := source.blockStore.Get(3)
+		require.NoError(t, err)
+		block.Header.SkipchainHashes = [][]byte{[]byte("wrong hash")}
+		require.EqualError(t, target.manager.StageBlock(block), "block [3] is not linked to the hash of the previous block")
+
+		block, err = source.blockStore.Get(numBlocks)
+		require.NoError(t, err)
+		block.Header.BaseHeader.Number = numBlocks + 1
+		require.EqualError(t, target.manager.StageBlock(block), "block [21] is beyond the staged state snapshot, which was taken at block [20]")
+	})
+
+	t.Run("truncated snapshot", func(t *testing.T) {
+		source, target, cleanup := setup(t)
+		defer cleanup()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, source.manager.WriteStateSnapshot(buf))
+
+		_, err := target.manager.Stage(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+		require.EqualError(t, err, "error while reading the state snapshot: unexpected EOF")
+		exist, err := fileops.Exists(target.manager.stagingDir)
+		require.NoError(t, err)
+		require.False(t, exist)
+
+		_, err = target.manager.Stage(bytes.NewReader([]byte("not a snapshot")))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,243 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package statetransfer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	trieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/pkg/errors"
+)
+
+// The snapshot stream starts with a header holding a magic string, the stream version, and the number
+// of the block the snapshot was taken at. The header is followed by records, each holding the kind of
+// the record, the database name, the key, and the value. Every field except the kind is prefixed by its
+// length as an uvarint. The last record is an end record, which has no fields, so that a truncated
+// stream is detected. The records of the state trie and of the provenance store have no database name.
+const (
+	streamVersion = uint64(3)
+
+	recordEnd        = byte(0)
+	recordDatabase   = byte(1)
+	recordWorldState = byte(2)
+	recordStateTrie  = byte(3)
+	recordProvenance = byte(4)
+
+	// maxFieldLength protects the receiver from allocating a huge buffer due to a corrupted stream
+	maxFieldLength = 256 * 1024 * 1024
+)
+
+var streamMagic = []byte("orion-state-snapshot")
+
+// Snapshot is a consistent copy of the world state, the state trie, and the provenance store as of a block
+type Snapshot struct {
+	height     uint64
+	worldState worldstate.Checkpoint
+	stateTrie  *trieStore.Checkpoint
+	provenance *provenance.Checkpoint
+}
+
+// Height returns the number of the block the snapshot was taken at
+func (s *Snapshot) Height() uint64 {
+	return s.height
+}
+
+// WriteTo writes the snapshot as a stream to w
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	rw := newRecordWriter(w)
+
+	if err := rw.writeHeader(s.height); err != nil {
+		return rw.n, err
+	}
+
+	// databases are created first, as a database may have no key-value pairs
+	for _, dbName := range s.worldState.DBNames() {
+		if err := rw.writeRecord(recordDatabase, dbName, nil, nil); err != nil {
+			return rw.n, err
+		}
+	}
+
+	err := s.worldState.ForEach(func(dbName string, key, value []byte) error {
+		return rw.writeRecord(recordWorldState, dbName, key, value)
+	})
+	if err != nil {
+		return rw.n, err
+	}
+
+	err = s.stateTrie.ForEach(func(key, value []byte) error {
+		return rw.writeRecord(recordStateTrie, "", key, value)
+	})
+	if err != nil {
+		return rw.n, err
+	}
+
+	err = s.provenance.ForEach(func(key, value []byte) error {
+		return rw.writeRecord(recordProvenance, "", key, value)
+	})
+	if err != nil {
+		return rw.n, err
+	}
+
+	if err := rw.w.WriteByte(recordEnd); err != nil {
+		return rw.n, err
+	}
+	rw.n++
+
+	return rw.n, rw.w.Flush()
+}
+
+// Release releases the snapshot
+func (s *Snapshot) Release() {
+	if s.worldState != nil {
+		s.worldState.Release()
+	}
+	if s.stateTrie != nil {
+		s.stateTrie.Release()
+	}
+	if s.provenance != nil {
+		s.provenance.Release()
+	}
+}
+
+type recordWriter struct {
+	w   *bufio.Writer
+	n   int64
+	buf []byte
+}
+
+func newRecordWriter(w io.Writer) *recordWriter {
+	return &recordWriter{
+		w:   bufio.NewWriter(w),
+		buf: make([]byte, binary.MaxVarintLen64),
+	}
+}
+
+func (rw *recordWriter) writeHeader(height uint64) error {
+	if err := rw.writeField(streamMagic); err != nil {
+		return err
+	}
+	if err := rw.writeUvarint(streamVersion); err != nil {
+		return err
+	}
+	return rw.writeUvarint(height)
+}
+
+func (rw *recordWriter) writeRecord(kind byte, dbName string, key, value []byte) error {
+	if err := rw.w.WriteByte(kind); err != nil {
+		return err
+	}
+	rw.n++
+
+	if err := rw.writeField([]byte(dbName)); err != nil {
+		return err
+	}
+	if err := rw.writeField(key); err != nil {
+		return err
+	}
+	return rw.writeField(value)
+}
+
+func (rw *recordWriter) writeField(b []byte) error {
+	if err := rw.writeUvarint(uint64(len(b))); err != nil {
+		return err
+	}
+
+	n, err := rw.w.Write(b)
+	rw.n += int64(n)
+	return err
+}
+
+func (rw *recordWriter) writeUvarint(v uint64) error {
+	n := binary.PutUvarint(rw.buf, v)
+	n, err := rw.w.Write(rw.buf[:n])
+	rw.n += int64(n)
+	return err
+}
+
+type recordReader struct {
+	r *bufio.Reader
+}
+
+func newRecordReader(r io.Reader) *recordReader {
+	return &recordReader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (rr *recordReader) readHeader() (uint64, error) {
+	magic, err := rr.readField()
+	if err != nil {
+		return 0, err
+	}
+	if string(magic) != string(streamMagic) {
+		return 0, errors.New("the stream is not a state snapshot")
+	}
+
+	version, err := rr.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if version != streamVersion {
+		return 0, errors.Errorf("unsupported state snapshot version [%d]", version)
+	}
+
+	return rr.readUvarint()
+}
+
+func (rr *recordReader) readRecord() (kind byte, dbName string, key, value []byte, err error) {
+	kind, err = rr.r.ReadByte()
+	if err != nil {
+		return 0, "", nil, nil, rr.unexpectedEOF(err)
+	}
+	if kind == recordEnd {
+		return kind, "", nil, nil, nil
+	}
+
+	name, err := rr.readField()
+	if err != nil {
+		return 0, "", nil, nil, err
+	}
+	if key, err = rr.readField(); err != nil {
+		return 0, "", nil, nil, err
+	}
+	if value, err = rr.readField(); err != nil {
+		return 0, "", nil, nil, err
+	}
+
+	return kind, string(name), key, value, nil
+}
+
+func (rr *recordReader) readField() ([]byte, error) {
+	l, err := rr.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if l > maxFieldLength {
+		return nil, errors.Errorf("field length [%d] exceeds the maximum [%d]", l, maxFieldLength)
+	}
+
+	b := make([]byte, l)
+	if _, err := io.ReadFull(rr.r, b); err != nil {
+		return nil, rr.unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+func (rr *recordReader) readUvarint() (uint64, error) {
+	v, err := binary.ReadUvarint(rr.r)
+	if err != nil {
+		return 0, rr.unexpectedEOF(err)
+	}
+	return v, nil
+}
+
+func (rr *recordReader) unexpectedEOF(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return errors.Wrap(err, "error while reading the state snapshot")
+}
@@ -22,6 +22,7 @@ type TxReorderer struct {
 	stop               chan struct{}
 	stopped            chan struct{}
 	pendingDataTxs     *types.DataTxEnvelopes
+	pendingProcTxs     *types.ProcedureTxEnvelopes
 	logger             *logger.SugarLogger
 	// TODO:
 	// tx merkle tree
@@ -63,6 +64,7 @@ func (r *TxReorderer) Start() {
 	defer ticker.Stop()
 
 	r.pendingDataTxs = &types.DataTxEnvelopes{}
+	r.pendingProcTxs = &types.ProcedureTxEnvelopes{}
 
 	for {
 		select {
@@ -72,7 +74,7 @@ func (r *TxReorderer) Start() {
 
 		case <-ticker.C:
 			r.logger.Debug("block timeout has occurred")
-			r.enqueueAndResetPendingDataTxBatch()
+			r.enqueueAndResetPendingBatches()
 
 		default:
 			tx := r.txQueue.DequeueWithWaitLimit(r.batchTimeout)
@@ -82,6 +84,9 @@ func (r *TxReorderer) Start() {
 
 			switch env := tx.(type) {
 			case *types.DataTxEnvelope:
+				// a block holds transactions of a single type and hence,
+				// the pending procedure transactions are enqueued first
+				r.enqueueAndResetPendingProcedureTxBatch()
 				r.pendingDataTxs.Envelopes = append(r.pendingDataTxs.Envelopes, env)
 
 				if uint32(len(r.pendingDataTxs.Envelopes)) == r.maxTxCountPerBatch {
@@ -89,8 +94,17 @@ func (r *TxReorderer) Start() {
 					ticker.Reset(r.batchTimeout)
 				}
 
-			case *types.UserAdministrationTxEnvelope:
+			case *types.ProcedureTxEnvelope:
 				r.enqueueAndResetPendingDataTxBatch()
+				r.pendingProcTxs.Envelopes = append(r.pendingProcTxs.Envelopes, env)
+
+				if uint32(len(r.pendingProcTxs.Envelopes)) == r.maxTxCountPerBatch {
+					r.enqueueAndResetPendingProcedureTxBatch()
+					ticker.Reset(r.batchTimeout)
+				}
+
+			case *types.UserAdministrationTxEnvelope:
+				r.enqueueAndResetPendingBatches()
 
 				r.logger.Debug("enqueueing user administrative transaction")
 				r.txBatchQueue.Enqueue(
@@ -101,7 +115,7 @@ func (r *TxReorderer) Start() {
 				ticker.Reset(r.batchTimeout)
 
 			case *types.DBAdministrationTxEnvelope:
-				r.enqueueAndResetPendingDataTxBatch()
+				r.enqueueAndResetPendingBatches()
 
 				r.logger.Debug("enqueueing db administrative transaction")
 				r.txBatchQueue.Enqueue(
@@ -111,8 +125,19 @@ func (r *TxReorderer) Start() {
 				)
 				ticker.Reset(r.batchTimeout)
 
+			case *types.ExpiryTxEnvelope:
+				r.enqueueAndResetPendingBatches()
+
+				r.logger.Debug("enqueueing expiry transaction")
+				r.txBatchQueue.Enqueue(
+					&types.Block_ExpiryTxEnvelope{
+						ExpiryTxEnvelope: env,
+					},
+				)
+				ticker.Reset(r.batchTimeout)
+
 			case *types.ConfigTxEnvelope:
-				r.enqueueAndResetPendingDataTxBatch()
+				r.enqueueAndResetPendingBatches()
 
 				r.logger.Debug("enqueueing cluster config transaction")
 				r.txBatchQueue.Enqueue(
@@ -138,6 +163,11 @@ func (r *TxReorderer) Stop() {
 	<-r.stopped
 }
 
+func (r *TxReorderer) enqueueAndResetPendingBatches() {
+	r.enqueueAndResetPendingDataTxBatch()
+	r.enqueueAndResetPendingProcedureTxBatch()
+}
+
 func (r *TxReorderer) enqueueAndResetPendingDataTxBatch() {
 	if len(r.pendingDataTxs.Envelopes) == 0 {
 		return
@@ -152,3 +182,18 @@ func (r *TxReorderer) enqueueAndResetPendingDataTxBatch() {
 
 	r.pendingDataTxs = &types.DataTxEnvelopes{}
 }
+
+func (r *TxReorderer) enqueueAndResetPendingProcedureTxBatch() {
+	if len(r.pendingProcTxs.Envelopes) == 0 {
+		return
+	}
+
+	r.logger.Debugf("enqueueing [%d] procedure transactions", len(r.pendingProcTxs.Envelopes))
+	r.txBatchQueue.Enqueue(
+		&types.Block_ProcedureTxEnvelopes{
+			ProcedureTxEnvelopes: r.pendingProcTxs,
+		},
+	)
+
+	r.pendingProcTxs = &types.ProcedureTxEnvelopes{}
+}
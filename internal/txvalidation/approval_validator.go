@@ -0,0 +1,90 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+type adminApprovalValidator struct {
+	db              worldstate.DB
+	identityQuerier *identity.Querier
+	sigValidator    *txSigValidator
+	logger          *logger.SugarLogger
+}
+
+// validate ensures that an administrative transaction submitted by the given admin is approved by as many
+// admins as the committed governance policy requires. The submitter, whose signature and privilege are
+// validated by the caller, counts as one approval and every other entry in the signatures must be a valid
+// signature of an admin over the transaction payload.
+func (v *adminApprovalValidator) validate(
+	submitterID string,
+	signatures map[string][]byte,
+	txPayload interface{},
+	minAdmins func(*types.GovernancePolicy) uint32,
+	operations string,
+) (*types.ValidationInfo, error) {
+	approvers := map[string]bool{submitterID: true}
+
+	var signers []string
+	for userID := range signatures {
+		signers = append(signers, userID)
+	}
+	sort.Strings(signers)
+
+	for _, userID := range signers {
+		if approvers[userID] {
+			continue
+		}
+
+		valInfo, err := v.sigValidator.validate(userID, signatures[userID], txPayload)
+		if err != nil {
+			return nil, err
+		}
+		if valInfo.Flag != types.Flag_VALID {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature of the approving user [" + userID + "] is not valid: " + valInfo.ReasonIfInvalid,
+			}, nil
+		}
+
+		hasPerm, err := v.identityQuerier.HasAdministrationPrivilege(userID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while checking the administrative privilege of the approving user [%s]", userID)
+		}
+		if !hasPerm {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the approving user [" + userID + "] has no privilege to perform " + operations + " administrative operations",
+			}, nil
+		}
+
+		approvers[userID] = true
+	}
+
+	clusterConfig, _, err := v.db.GetConfig()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while fetching the governance policy")
+	}
+
+	required := minAdmins(clusterConfig.GetGovernancePolicy())
+	if uint32(len(approvers)) < required {
+		return &types.ValidationInfo{
+			Flag: types.Flag_INVALID_MISSING_SIGNATURE,
+			ReasonIfInvalid: fmt.Sprintf("the governance policy requires [%d] admins to approve %s administrative operations but only [%d] admins have signed the transaction",
+				required, operations, len(approvers)),
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
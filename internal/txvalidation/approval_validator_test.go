@@ -0,0 +1,159 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAdminApprovals(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin1", "admin2", "admin3", "user1"})
+	admin1Cert, admin1Signer := testutils.LoadTestCrypto(t, cryptoDir, "admin1")
+	admin2Cert, admin2Signer := testutils.LoadTestCrypto(t, cryptoDir, "admin2")
+	admin3Cert, admin3Signer := testutils.LoadTestCrypto(t, cryptoDir, "admin3")
+	user1Cert, user1Signer := testutils.LoadTestCrypto(t, cryptoDir, "user1")
+
+	version := &types.Version{BlockNum: 1, TxNum: 1}
+	adminPrivilege := &types.Privilege{Admin: true}
+
+	setup := func(db worldstate.DB, policy *types.GovernancePolicy) {
+		config := &types.ClusterConfig{
+			GovernancePolicy: policy,
+		}
+		configSerialized, err := proto.Marshal(config)
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					constructUserForTest(t, "admin1", admin1Cert.Raw, adminPrivilege, version, nil),
+					constructUserForTest(t, "admin2", admin2Cert.Raw, adminPrivilege, version, nil),
+					constructUserForTest(t, "admin3", admin3Cert.Raw, adminPrivilege, version, nil),
+					constructUserForTest(t, "user1", user1Cert.Raw, nil, version, nil),
+				},
+			},
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.ConfigKey,
+						Value: configSerialized,
+					},
+				},
+			},
+		}, 1))
+	}
+
+	tx := &types.DBAdministrationTx{
+		UserId:    "admin1",
+		TxId:      "tx1",
+		CreateDbs: []string{"db1"},
+	}
+
+	tests := []struct {
+		name           string
+		policy         *types.GovernancePolicy
+		signatures     map[string][]byte
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:       "valid: no governance policy",
+			policy:     nil,
+			signatures: nil,
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:       "valid: the policy of other transactions does not apply",
+			policy:     &types.GovernancePolicy{ConfigTxMinAdmins: 3, UserAdminTxMinAdmins: 2},
+			signatures: nil,
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:   "valid: enough admins have approved",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 3},
+			signatures: map[string][]byte{
+				"admin2": testutils.SignatureFromTx(t, admin2Signer, tx),
+				"admin3": testutils.SignatureFromTx(t, admin3Signer, tx),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:   "invalid: the submitter is counted once",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 3},
+			signatures: map[string][]byte{
+				"admin1": testutils.SignatureFromTx(t, admin1Signer, tx),
+				"admin2": testutils.SignatureFromTx(t, admin2Signer, tx),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+				ReasonIfInvalid: "the governance policy requires [3] admins to approve database administrative operations but only [2] admins have signed the transaction",
+			},
+		},
+		{
+			name:   "invalid: the approving user is not an admin",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 2},
+			signatures: map[string][]byte{
+				"user1": testutils.SignatureFromTx(t, user1Signer, tx),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the approving user [user1] has no privilege to perform database administrative operations",
+			},
+		},
+		{
+			name:   "invalid: the signature of the approving admin is incorrect",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 2},
+			signatures: map[string][]byte{
+				"admin2": testutils.SignatureFromTx(t, admin3Signer, tx),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature of the approving user [admin2] is not valid: signature verification failed: x509: ECDSA verification failure",
+			},
+		},
+		{
+			name:   "invalid: the approving user does not exist",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 2},
+			signatures: map[string][]byte{
+				"admin4": testutils.SignatureFromTx(t, admin3Signer, tx),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature of the approving user [admin4] is not valid: signature verification failed: the user [admin4] does not exist",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			setup(env.db, tt.policy)
+
+			txEnv := testutils.SignedDBAdministrationTxEnvelope(t, admin1Signer, tx)
+			txEnv.Signatures = tt.signatures
+
+			result, err := env.validator.dbAdminTxValidator.validate(txEnv)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
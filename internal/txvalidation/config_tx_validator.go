@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/compression"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
@@ -21,10 +22,11 @@ import (
 )
 
 type ConfigTxValidator struct {
-	db              worldstate.DB
-	identityQuerier *identity.Querier
-	sigValidator    *txSigValidator
-	logger          *logger.SugarLogger
+	db                worldstate.DB
+	identityQuerier   *identity.Querier
+	sigValidator      *txSigValidator
+	approvalValidator *adminApprovalValidator
+	logger            *logger.SugarLogger
 }
 
 func (v *ConfigTxValidator) Validate(txEnv *types.ConfigTxEnvelope) (*types.ValidationInfo, error) {
@@ -45,6 +47,11 @@ func (v *ConfigTxValidator) Validate(txEnv *types.ConfigTxEnvelope) (*types.Vali
 		}, nil
 	}
 
+	valInfo, err = v.approvalValidator.validate(tx.UserId, txEnv.Signatures, txEnv.Payload, (*types.GovernancePolicy).GetConfigTxMinAdmins, "cluster")
+	if err != nil || valInfo.Flag != types.Flag_VALID {
+		return valInfo, err
+	}
+
 	if tx.NewConfig == nil {
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
@@ -98,11 +105,15 @@ func validateConfig(config *types.ClusterConfig) *types.ValidationInfo {
 		return vi
 	}
 
+	if vi = validateGovernancePolicy(config.GovernancePolicy, len(config.Admins)); vi.Flag != types.Flag_VALID {
+		return vi
+	}
+
 	if vi = validateConsensusConfig(config.ConsensusConfig); vi.Flag != types.Flag_VALID {
 		return vi
 	}
 
-	if vi = validateMembersNodesMatch(config.ConsensusConfig.Members, config.Nodes); vi.Flag != types.Flag_VALID {
+	if vi = validateMembersNodesMatch(config.ConsensusConfig.Members, config.ConsensusConfig.RaftObservers(), config.Nodes); vi.Flag != types.Flag_VALID {
 		return vi
 	}
 
@@ -256,6 +267,34 @@ func validateAdminConfig(admins []*types.Admin, caCertCollection *certificateaut
 	}
 }
 
+// validateGovernancePolicy ensures that the admins in the config can approve
+// every kind of administrative transaction, as otherwise, the cluster could
+// never be reconfigured again
+func validateGovernancePolicy(policy *types.GovernancePolicy, adminsCount int) *types.ValidationInfo {
+	minAdmins := []struct {
+		operations string
+		count      uint32
+	}{
+		{operations: "cluster", count: policy.GetConfigTxMinAdmins()},
+		{operations: "user", count: policy.GetUserAdminTxMinAdmins()},
+		{operations: "database", count: policy.GetDbAdminTxMinAdmins()},
+	}
+
+	for _, m := range minAdmins {
+		if m.count > uint32(adminsCount) {
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the governance policy requires [%d] admins to approve %s administrative operations but there are only [%d] admins in the admin config",
+					m.count, m.operations, adminsCount),
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
 // validate the internal consistency of the ConsensusConfig
 func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.ValidationInfo {
 	switch {
@@ -265,12 +304,18 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 			ReasonIfInvalid: "Consensus config is empty.",
 		}
 
-	case consensusConf.Algorithm != "raft":
+	case consensusConf.Algorithm != replication.ConsensusAlgorithmRaft && consensusConf.Algorithm != replication.ConsensusAlgorithmBFT:
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 			ReasonIfInvalid: fmt.Sprintf("Consensus config Algorithm '%s' is not supported.", consensusConf.Algorithm),
 		}
 
+	case consensusConf.Algorithm == replication.ConsensusAlgorithmBFT && len(consensusConf.Observers) > 0:
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "Consensus config has observers, which are not supported by the BFT algorithm.",
+		}
+
 	case len(consensusConf.Members) == 0:
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
@@ -352,12 +397,6 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "Consensus config has an observer with an empty ID. A valid nodeID must be an non-empty string.",
 			}
-
-		case o.RaftId != 0:
-			return &types.ValidationInfo{
-				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: fmt.Sprintf("Consensus config has an observer [%s] with Raft ID >0.", o.NodeId),
-			}
 		}
 
 		if err := validateHostPort(o.PeerHost, o.PeerPort); err != nil {
@@ -376,6 +415,18 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 		}
 		nodeIDsSet[o.NodeId] = true
 
+		// observers join Raft as learners, their raft IDs must be unique across members as well; an observer with Raft
+		// ID 0 predates the replication by observers, and does not join Raft until it is assigned a Raft ID
+		if o.RaftId != 0 {
+			if raftIDSet[o.RaftId] {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: fmt.Sprintf("Consensus config has two peers with the same Raft ID [%d], Raft IDs must be unique.", o.RaftId),
+				}
+			}
+			raftIDSet[o.RaftId] = true
+		}
+
 		// peer host:port must be unique, across members as well
 		hostPort := fmt.Sprintf("%s:%d", o.PeerHost, o.PeerPort)
 		if hostPortSet[hostPort] {
@@ -411,6 +462,18 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 			ReasonIfInvalid: "Consensus config RaftConfig.ElectionTicks is 0.",
 		}
+
+	case !compression.IsSupported(consensusConf.RaftConfig.Compression):
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "Consensus config RaftConfig.Compression is not supported: " + consensusConf.RaftConfig.Compression,
+		}
+
+	case consensusConf.RaftConfig.EntryFormatVersion > replication.MaxEntryFormatVersion:
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("Consensus config RaftConfig.EntryFormatVersion [%d] is not supported, the latest version is [%d].", consensusConf.RaftConfig.EntryFormatVersion, replication.MaxEntryFormatVersion),
+		}
 	}
 
 	if d, err := time.ParseDuration(consensusConf.RaftConfig.TickInterval); err != nil {
@@ -425,17 +488,40 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 		}
 	}
 
+	if timeout := consensusConf.GetBftConfig().GetViewChangeTimeout(); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config BFTConfig.ViewChangeTimeout is invalid: " + err.Error(),
+			}
+		} else if d <= 0 {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config BFTConfig.ViewChangeTimeout is invalid: " + timeout,
+			}
+		}
+	}
+
 	return &types.ValidationInfo{
 		Flag: types.Flag_VALID,
 	}
 }
 
-func validateMembersNodesMatch(members []*types.PeerConfig, nodes []*types.NodeConfig) *types.ValidationInfo {
-	if len(nodes) != len(members) {
+// validateMembersNodesMatch ensures that every node in the cluster is either a consensus member or an observer that
+// replicates as a Raft learner, and vice versa.
+func validateMembersNodesMatch(members, observers []*types.PeerConfig, nodes []*types.NodeConfig) *types.ValidationInfo {
+	if len(nodes) != len(members)+len(observers) {
+		if len(observers) == 0 {
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf(
+					"ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members, and Nodes set must include all Members"),
+			}
+		}
 		return &types.ValidationInfo{
 			Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
 			ReasonIfInvalid: fmt.Sprintf(
-				"ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members, and Nodes set must include all Members"),
+				"ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members and Observers combined, and Nodes set must include all Members and Observers"),
 		}
 	}
 
@@ -444,8 +530,19 @@ func validateMembersNodesMatch(members []*types.PeerConfig, nodes []*types.NodeC
 		nodesMap[n.Id] = n
 	}
 
-	for _, m := range members {
+	peers := make([]*types.PeerConfig, 0, len(members)+len(observers))
+	peers = append(peers, members...)
+	peers = append(peers, observers...)
+
+	for i, m := range peers {
 		if n, ok := nodesMap[m.NodeId]; !ok {
+			if i >= len(members) {
+				return &types.ValidationInfo{
+					Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: fmt.Sprintf(
+						"ClusterConfig.Nodes set does not include ClusterConfig.ConsensusConfig.Observers peer [%s], Nodes set must include all Observers.", m.NodeId),
+				}
+			}
 			return &types.ValidationInfo{
 				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: fmt.Sprintf(
@@ -524,6 +621,10 @@ func (v *ConfigTxValidator) validateConfigTransitionRules(currentConfig, updated
 		// TODO add rules for admin re-config safety: https://github.com/hyperledger-labs/orion-server/issues/262
 	}
 
+	if !proto.Equal(currentConfig.GetGovernancePolicy(), updatedConfig.GetGovernancePolicy()) {
+		v.logger.Infof("ClusterConfig GovernancePolicy changed: current: %v; updated: %v", currentConfig.GovernancePolicy, updatedConfig.GovernancePolicy)
+	}
+
 	if consensus {
 		err := replication.VerifyConsensusReConfig(currentConfig.GetConsensusConfig(), updatedConfig.GetConsensusConfig(), v.logger)
 		if err != nil {
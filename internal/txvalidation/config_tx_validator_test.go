@@ -598,6 +598,173 @@ func TestValidateConfigTx(t *testing.T) {
 	}
 }
 
+func TestValidateConfigTxWithPreUpgradeObservers(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"adminUser", "node"})
+	adminCert, adminSigner := testutils.LoadTestCrypto(t, cryptoDir, "adminUser")
+	nodeCert, _ := testutils.LoadTestCrypto(t, cryptoDir, "node")
+	caCert, _ := testutils.LoadTestCA(t, cryptoDir, testutils.RootCAFileName)
+
+	// before observers replicated as Raft learners, their Raft ID had to be 0 and they were not part of the nodes
+	newConfig := func(observerRaftID uint64, observerInNodes bool) *types.ClusterConfig {
+		config := &types.ClusterConfig{
+			Nodes: []*types.NodeConfig{
+				{
+					Id:          "node1",
+					Address:     "127.0.0.1",
+					Port:        6090,
+					Certificate: nodeCert.Raw,
+				},
+			},
+			Admins: []*types.Admin{
+				{
+					Id:          "adminUser",
+					Certificate: adminCert.Raw,
+				},
+			},
+			CertAuthConfig: &types.CAConfig{
+				Roots: [][]byte{caCert.Raw},
+			},
+			ConsensusConfig: &types.ConsensusConfig{
+				Algorithm: "raft",
+				Members: []*types.PeerConfig{
+					{
+						NodeId:   "node1",
+						RaftId:   1,
+						PeerHost: "127.0.0.1",
+						PeerPort: 7090,
+					},
+				},
+				Observers: []*types.PeerConfig{
+					{
+						NodeId:   "node2",
+						RaftId:   observerRaftID,
+						PeerHost: "127.0.0.1",
+						PeerPort: 7091,
+					},
+				},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:   "100ms",
+					ElectionTicks:  100,
+					HeartbeatTicks: 10,
+					MaxRaftId:      1,
+				},
+			},
+		}
+		if observerInNodes {
+			config.Nodes = append(config.Nodes, &types.NodeConfig{
+				Id:          "node2",
+				Address:     "127.0.0.1",
+				Port:        6091,
+				Certificate: nodeCert.Raw,
+			})
+		}
+		return config
+	}
+
+	setup := func(db worldstate.DB) {
+		adminUser := &types.User{
+			Id:          "adminUser",
+			Certificate: adminCert.Raw,
+			Privilege: &types.Privilege{
+				Admin: true,
+			},
+		}
+		adminUserSerialized, err := proto.Marshal(adminUser)
+		require.NoError(t, err)
+
+		configSerialized, err := proto.Marshal(newConfig(0, false))
+		require.NoError(t, err)
+
+		dbUpdates := map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   string(identity.UserNamespace) + "adminUser",
+						Value: adminUserSerialized,
+					},
+				},
+			},
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.ConfigKey,
+						Value: configSerialized,
+						Metadata: &types.Metadata{
+							Version: &types.Version{BlockNum: 1, TxNum: 1},
+						},
+					},
+				},
+			},
+		}
+
+		require.NoError(t, db.Commit(dbUpdates, 1))
+	}
+
+	tests := []struct {
+		name           string
+		newConfig      *types.ClusterConfig
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid: the observer keeps Raft ID 0",
+			newConfig: func() *types.ClusterConfig {
+				config := newConfig(0, false)
+				config.ConsensusConfig.RaftConfig.SnapshotIntervalSize = 1000000
+				return config
+			}(),
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:      "valid: the observer is assigned a Raft ID and joins as a learner",
+			newConfig: newConfig(2, true),
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:      "invalid: the observer is assigned a Raft ID but is not a node",
+			newConfig: newConfig(2, false),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members and Observers combined, and Nodes set must include all Members and Observers",
+			},
+		},
+		{
+			name:      "invalid: the observer with Raft ID 0 is a node",
+			newConfig: newConfig(0, true),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members, and Nodes set must include all Members",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			setup(env.db)
+
+			txEnv := testutils.SignedConfigTxEnvelope(t, adminSigner, &types.ConfigTx{
+				UserId:               "adminUser",
+				ReadOldConfigVersion: &types.Version{BlockNum: 1, TxNum: 1},
+				NewConfig:            tt.newConfig,
+			})
+			result, err := env.validator.configTxValidator.Validate(txEnv)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
 func TestValidateCAConfig(t *testing.T) {
 	t.Parallel()
 
@@ -991,6 +1158,64 @@ func TestValidateAdminConfig(t *testing.T) {
 }
 
 //TODO
+func TestValidateGovernancePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		policy         *types.GovernancePolicy
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:   "valid: no policy",
+			policy: nil,
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:   "valid: all admins must approve",
+			policy: &types.GovernancePolicy{ConfigTxMinAdmins: 3, UserAdminTxMinAdmins: 3, DbAdminTxMinAdmins: 3},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:   "invalid: config transactions need more admins than exist",
+			policy: &types.GovernancePolicy{ConfigTxMinAdmins: 4},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the governance policy requires [4] admins to approve cluster administrative operations but there are only [3] admins in the admin config",
+			},
+		},
+		{
+			name:   "invalid: user administration transactions need more admins than exist",
+			policy: &types.GovernancePolicy{UserAdminTxMinAdmins: 5},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the governance policy requires [5] admins to approve user administrative operations but there are only [3] admins in the admin config",
+			},
+		},
+		{
+			name:   "invalid: database administration transactions need more admins than exist",
+			policy: &types.GovernancePolicy{DbAdminTxMinAdmins: 4},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the governance policy requires [4] admins to approve database administrative operations but there are only [3] admins in the admin config",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expectedResult, validateGovernancePolicy(tt.policy, 3))
+		})
+	}
+}
+
 func TestValidateConsensusConfig(t *testing.T) {
 	t.Parallel()
 
@@ -1225,7 +1450,7 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node2",
-						RaftId:   0,
+						RaftId:   2,
 						PeerHost: "",
 						PeerPort: 7091,
 					},
@@ -1244,7 +1469,7 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node2",
-						RaftId:   0,
+						RaftId:   2,
 						PeerHost: "server.com/data",
 						PeerPort: 6091,
 					},
@@ -1263,13 +1488,13 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node2",
-						RaftId:   0,
+						RaftId:   2,
 						PeerHost: "10.10.10.10",
 						PeerPort: 6091,
 					},
 					{
 						NodeId:   "node2",
-						RaftId:   0,
+						RaftId:   3,
 						PeerHost: "server.com",
 						PeerPort: 6092,
 					},
@@ -1288,13 +1513,13 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node2",
-						RaftId:   0,
+						RaftId:   2,
 						PeerHost: "10.10.10.10",
 						PeerPort: 6091,
 					},
 					{
 						NodeId:   "node3",
-						RaftId:   0,
+						RaftId:   3,
 						PeerHost: "10.10.10.10",
 						PeerPort: 6091,
 					},
@@ -1306,14 +1531,14 @@ func TestValidateConsensusConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "invalid: member with raft ID >0",
+			name: "invalid: observer with the raft ID of a member",
 			consensusConfig: &types.ConsensusConfig{
 				Algorithm: "raft",
 				Members:   []*types.PeerConfig{peer1},
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node2",
-						RaftId:   2,
+						RaftId:   peer1.RaftId,
 						PeerHost: "server.com",
 						PeerPort: 6091,
 					},
@@ -1321,7 +1546,7 @@ func TestValidateConsensusConfig(t *testing.T) {
 			},
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "Consensus config has an observer [node2] with Raft ID >0.",
+				ReasonIfInvalid: "Consensus config has two peers with the same Raft ID [1], Raft IDs must be unique.",
 			},
 		},
 		//=== Raft
@@ -1382,6 +1607,79 @@ func TestValidateConsensusConfig(t *testing.T) {
 				ReasonIfInvalid: "Consensus config RaftConfig.HeartbeatTicks is 0.",
 			},
 		},
+		{
+			name: "invalid: raft config compression",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "raft",
+				Members:   []*types.PeerConfig{peer1},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:   "10s",
+					ElectionTicks:  10,
+					HeartbeatTicks: 1,
+					Compression:    "lz4",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config RaftConfig.Compression is not supported: lz4",
+			},
+		},
+		{
+			name: "invalid: raft config entry format version",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "raft",
+				Members:   []*types.PeerConfig{peer1},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:       "10s",
+					ElectionTicks:      10,
+					HeartbeatTicks:     1,
+					Compression:        "zstd",
+					EntryFormatVersion: 2,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config RaftConfig.EntryFormatVersion [2] is not supported, the latest version is [1].",
+			},
+		},
+		{
+			name: "invalid: bft with observers",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "bft",
+				Members:   []*types.PeerConfig{peer1},
+				Observers: []*types.PeerConfig{
+					{
+						NodeId:   "node2",
+						RaftId:   2,
+						PeerHost: "10.10.10.10",
+						PeerPort: 6091,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config has observers, which are not supported by the BFT algorithm.",
+			},
+		},
+		{
+			name: "invalid: bft config view change timeout",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "bft",
+				Members:   []*types.PeerConfig{peer1},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:   "10s",
+					ElectionTicks:  10,
+					HeartbeatTicks: 1,
+				},
+				BftConfig: &types.BFTConfig{
+					ViewChangeTimeout: "-2s",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config BFTConfig.ViewChangeTimeout is invalid: -2s",
+			},
+		},
 
 		//=== valid
 		{
@@ -1405,13 +1703,13 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Observers: []*types.PeerConfig{
 					{
 						NodeId:   "node3",
-						RaftId:   0,
+						RaftId:   3,
 						PeerHost: "10.10.10.10",
 						PeerPort: 6092,
 					},
 					{
 						NodeId:   "node4",
-						RaftId:   0,
+						RaftId:   4,
 						PeerHost: "server.com",
 						PeerPort: 6093,
 					},
@@ -1426,6 +1724,53 @@ func TestValidateConsensusConfig(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		},
+		{
+			name: "valid: observers with raft ID 0, which predate the replication by observers",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "raft",
+				Members:   []*types.PeerConfig{peer1},
+				Observers: []*types.PeerConfig{
+					{
+						NodeId:   "node2",
+						RaftId:   0,
+						PeerHost: "server.com",
+						PeerPort: 6091,
+					},
+					{
+						NodeId:   "node3",
+						RaftId:   0,
+						PeerHost: "server.com",
+						PeerPort: 6092,
+					},
+				},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:   "100ms",
+					ElectionTicks:  100,
+					HeartbeatTicks: 10,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: bft",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "bft",
+				Members:   []*types.PeerConfig{peer1},
+				RaftConfig: &types.RaftConfig{
+					TickInterval:   "100ms",
+					ElectionTicks:  100,
+					HeartbeatTicks: 10,
+				},
+				BftConfig: &types.BFTConfig{
+					ViewChangeTimeout: "3s",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1473,6 +1818,7 @@ func TestValidateMembersNodesMatch(t *testing.T) {
 		name           string
 		nodes          []*types.NodeConfig
 		members        []*types.PeerConfig
+		observers      []*types.PeerConfig
 		expectedResult *types.ValidationInfo
 	}{
 		{
@@ -1501,6 +1847,42 @@ func TestValidateMembersNodesMatch(t *testing.T) {
 				ReasonIfInvalid: "ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members, and Nodes set must include all Members",
 			},
 		},
+		{
+			name:      "exact match with observers",
+			nodes:     []*types.NodeConfig{node1, node2},
+			members:   []*types.PeerConfig{peer1},
+			observers: []*types.PeerConfig{peer2},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:      "invalid: more observers",
+			nodes:     []*types.NodeConfig{node1},
+			members:   []*types.PeerConfig{peer1},
+			observers: []*types.PeerConfig{peer2},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "ClusterConfig.Nodes must be the same length as ClusterConfig.ConsensusConfig.Members and Observers combined, and Nodes set must include all Members and Observers",
+			},
+		},
+		{
+			name:    "invalid: observer is missing from nodes",
+			nodes:   []*types.NodeConfig{node1, node2},
+			members: []*types.PeerConfig{peer1},
+			observers: []*types.PeerConfig{
+				{
+					NodeId:   "node3",
+					RaftId:   3,
+					PeerHost: "10.10.10.10",
+					PeerPort: 6092,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "ClusterConfig.Nodes set does not include ClusterConfig.ConsensusConfig.Observers peer [node3], Nodes set must include all Observers.",
+			},
+		},
 		{
 			name:  "invalid: node-peer endpoint clash",
 			nodes: []*types.NodeConfig{node1},
@@ -1524,7 +1906,7 @@ func TestValidateMembersNodesMatch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := validateMembersNodesMatch(tt.members, tt.nodes)
+			result := validateMembersNodesMatch(tt.members, tt.observers, tt.nodes)
 			require.Equal(t, tt.expectedResult, result)
 		})
 	}
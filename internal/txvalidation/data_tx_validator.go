@@ -4,21 +4,25 @@
 package txvalidation
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 type dataTxValidator struct {
 	db              worldstate.DB
 	identityQuerier *identity.Querier
 	sigValidator    *txSigValidator
+	schemas         *schemaCache
 	logger          *logger.SugarLogger
 }
 
@@ -76,7 +80,7 @@ func (v *dataTxValidator) validate(txEnv *types.DataTxEnvelope, userIDsWithValid
 		}
 	}
 
-	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+	return v.validateQuotas(txEnv.Payload.MustSignUserIds[0], txEnv.Payload.DbOperations, pendingOps)
 }
 
 func (v *dataTxValidator) validateSignatures(txEnv *types.DataTxEnvelope) ([]string, *types.ValidationInfo, error) {
@@ -147,6 +151,14 @@ func (v *dataTxValidator) validateOps(
 		return r, nil
 	}
 
+	r, err = v.validateSchemaOnDataWrites(dbName, txOps.DataWrites)
+	if err != nil {
+		return nil, err
+	}
+	if r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
 	r, err = v.validateFieldsInDataDeletes(txOps.DbName, txOps.DataDeletes, pendingOps)
 	if err != nil {
 		return nil, err
@@ -240,6 +252,157 @@ func (v *dataTxValidator) validateFieldsInDataWrites(DataWrites []*types.DataWri
 	}, nil
 }
 
+func (v *dataTxValidator) validateSchemaOnDataWrites(dbName string, dataWrites []*types.DataWrite) (*types.ValidationInfo, error) {
+	jsonSchema, metadata, err := v.db.GetSchemaDefinition(dbName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching the schema definition of the database [%s]", dbName)
+	}
+	if len(jsonSchema) == 0 || len(dataWrites) == 0 {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	// the schema has already been validated by the database administration
+	// transaction which defined it and hence, it is expected to compile
+	schema, err := v.schemas.get(dbName, jsonSchema, metadata.GetVersion())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while compiling the schema defined on the database [%s]", dbName)
+	}
+
+	for _, w := range dataWrites {
+		res, err := schema.Validate(gojsonschema.NewBytesLoader(w.Value))
+		if err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: "the value of the key [" + w.Key + "] is not a valid JSON document as required by the schema defined on the database [" + dbName + "]",
+			}, nil
+		}
+
+		if !res.Valid() {
+			var violations []string
+			for _, e := range res.Errors() {
+				violations = append(violations, e.String())
+			}
+
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: "the value of the key [" + w.Key + "] does not conform to the schema defined on the database [" + dbName + "]: " + strings.Join(violations, "; "),
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateQuotas ensures that the operations do not exceed the quota of the databases they modify
+// and the quota of the submitting user, who becomes the owner of the written keys. It must be the
+// last validation step as the usage change caused by a valid transaction is added to the pending
+// operations.
+func (v *dataTxValidator) validateQuotas(userID string, ops []*types.DBOperation, pendingOps *pendingOperations) (*types.ValidationInfo, error) {
+	userQuota, err := quota.GetUserQuota(v.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := !quota.IsUnlimited(userQuota)
+	dbQuotas := make(map[string]*types.Quota)
+	for _, op := range ops {
+		dbQuota, err := quota.GetDBQuota(v.db, op.DbName)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while fetching the quota of the database [%s]", op.DbName)
+		}
+		dbQuotas[op.DbName] = dbQuota
+		limited = limited || !quota.IsUnlimited(dbQuota)
+
+		for _, w := range op.DataWrites {
+			size := uint64(len(w.Value))
+			if max := dbQuota.GetMaxValueSize(); max > 0 && size > max {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+					ReasonIfInvalid: fmt.Sprintf("the size [%d] of the value of the key [%s] exceeds the maximum value size [%d] of the database [%s]", size, w.Key, max, op.DbName),
+				}, nil
+			}
+			if max := userQuota.GetMaxValueSize(); max > 0 && size > max {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+					ReasonIfInvalid: fmt.Sprintf("the size [%d] of the value of the key [%s] exceeds the maximum value size [%d] of the user [%s]", size, w.Key, max, userID),
+				}, nil
+			}
+		}
+	}
+
+	if !limited {
+		// when no quota applies to the transaction, there is no need to track
+		// the usage change. Skipping it only omits the release of keys by other
+		// owners, which makes the validation of later transactions in the block
+		// more conservative.
+		return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+	}
+
+	delta, err := quota.ComputeDelta(v.db, userID, ops)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while computing the quota usage")
+	}
+
+	for _, op := range ops {
+		reason, err := v.exceedsQuota(dbQuotas[op.DbName], quota.DBUsageKey(op.DbName), pendingOps.usage.DBs[op.DbName], delta.DBs[op.DbName])
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+				ReasonIfInvalid: "the transaction exceeds the quota of the database [" + op.DbName + "]: " + reason,
+			}, nil
+		}
+	}
+
+	reason, err := v.exceedsQuota(userQuota, quota.UserUsageKey(userID), pendingOps.usage.Users[userID], delta.Users[userID])
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+			ReasonIfInvalid: "the transaction exceeds the quota of the user [" + userID + "]: " + reason,
+		}, nil
+	}
+
+	pendingOps.usage.Merge(delta)
+	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
+
+// exceedsQuota returns the reason when the usage change increases the usage beyond the quota.
+// A change which reduces the usage is always allowed, even if the usage remains above the quota.
+func (v *dataTxValidator) exceedsQuota(q *types.Quota, usageKey string, pending, change *quota.Usage) (string, error) {
+	if change == nil || (q.GetMaxKeys() == 0 && q.GetMaxTotalValueBytes() == 0) {
+		return "", nil
+	}
+	if pending == nil {
+		pending = &quota.Usage{}
+	}
+
+	committed, err := quota.GetUsage(v.db, usageKey)
+	if err != nil {
+		return "", errors.WithMessagef(err, "error while fetching the quota usage [%s]", usageKey)
+	}
+
+	keys := int64(committed.Keys) + pending.Keys + change.Keys
+	if max := q.GetMaxKeys(); max > 0 && change.Keys > 0 && keys > int64(max) {
+		return fmt.Sprintf("the number of keys would be [%d] while the maximum is [%d]", keys, max), nil
+	}
+
+	totalValueBytes := int64(committed.TotalValueBytes) + pending.TotalValueBytes + change.TotalValueBytes
+	if max := q.GetMaxTotalValueBytes(); max > 0 && change.TotalValueBytes > 0 && totalValueBytes > int64(max) {
+		return fmt.Sprintf("the total size of values would be [%d] bytes while the maximum is [%d] bytes", totalValueBytes, max), nil
+	}
+
+	return "", nil
+}
+
 func (v *dataTxValidator) validateFieldsInDataDeletes(
 	dbName string,
 	dataDeletes []*types.DataDelete,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
@@ -1244,6 +1245,311 @@ func TestValidateFieldsInDataDeletes(t *testing.T) {
 	}
 }
 
+func TestValidateSchemaOnDataWrites(t *testing.T) {
+	t.Parallel()
+
+	addSchema := func(db worldstate.DB) {
+		schema := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.SchemaKey(worldstate.DefaultDBName),
+						Value: []byte(`{"type": "object", "properties": {"name": {"type": "string"}, "age": {"type": "integer"}}, "required": ["name"]}`),
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(schema, 1))
+	}
+
+	tests := []struct {
+		name           string
+		setup          func(db worldstate.DB)
+		dataWrites     []*types.DataWrite
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:  "valid: no schema defined on the database",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:   "key1",
+					Value: []byte("not a json"),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:  "valid: all values conform to the schema",
+			setup: addSchema,
+			dataWrites: []*types.DataWrite{
+				{
+					Key:   "key1",
+					Value: []byte(`{"name": "alice", "age": 30}`),
+				},
+				{
+					Key:   "key2",
+					Value: []byte(`{"name": "bob"}`),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:  "invalid: value is not a JSON document",
+			setup: addSchema,
+			dataWrites: []*types.DataWrite{
+				{
+					Key:   "key1",
+					Value: []byte("not a json"),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: "the value of the key [key1] is not a valid JSON document as required by the schema defined on the database [bdb]",
+			},
+		},
+		{
+			name:  "invalid: value does not conform to the schema",
+			setup: addSchema,
+			dataWrites: []*types.DataWrite{
+				{
+					Key:   "key1",
+					Value: []byte(`{"name": "alice"}`),
+				},
+				{
+					Key:   "key2",
+					Value: []byte(`{"age": "thirty"}`),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: "the value of the key [key2] does not conform to the schema defined on the database [bdb]: (root): name is required; age: Invalid type. Expected: integer, given: string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			tt.setup(env.db)
+
+			result, err := env.validator.dataTxValidator.validateSchemaOnDataWrites(worldstate.DefaultDBName, tt.dataWrites)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateSchemaOnDataWritesWithSchemaUpdate(t *testing.T) {
+	t.Parallel()
+
+	env := newValidatorTestEnv(t)
+	defer env.cleanup()
+
+	setSchema := func(jsonSchema string, blockNum uint64) {
+		schema := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.SchemaKey(worldstate.DefaultDBName),
+						Value: []byte(jsonSchema),
+						Metadata: &types.Metadata{
+							Version: &types.Version{BlockNum: blockNum},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, env.db.Commit(schema, blockNum))
+	}
+	dataWrites := []*types.DataWrite{
+		{
+			Key:   "key1",
+			Value: []byte(`{"name": "alice"}`),
+		},
+	}
+
+	setSchema(`{"type": "object", "required": ["name"]}`, 1)
+	for i := 0; i < 2; i++ {
+		result, err := env.validator.dataTxValidator.validateSchemaOnDataWrites(worldstate.DefaultDBName, dataWrites)
+		require.NoError(t, err)
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, result)
+	}
+	cached := env.validator.dataTxValidator.schemas.schemas[worldstate.DefaultDBName]
+	require.Equal(t, uint64(1), cached.version.GetBlockNum())
+
+	// the cached schema is compiled again once the definition is updated
+	setSchema(`{"type": "object", "required": ["name", "age"]}`, 2)
+	result, err := env.validator.dataTxValidator.validateSchemaOnDataWrites(worldstate.DefaultDBName, dataWrites)
+	require.NoError(t, err)
+	require.Equal(t, &types.ValidationInfo{
+		Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+		ReasonIfInvalid: "the value of the key [key1] does not conform to the schema defined on the database [bdb]: (root): age is required",
+	}, result)
+	cached = env.validator.dataTxValidator.schemas.schemas[worldstate.DefaultDBName]
+	require.Equal(t, uint64(2), cached.version.GetBlockNum())
+}
+
+func TestValidateQuotas(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		alice, err := proto.Marshal(&types.User{
+			Id: "alice",
+			Privilege: &types.Privilege{
+				Quota: &types.Quota{MaxKeys: 2},
+			},
+		})
+		require.NoError(t, err)
+		dbQuota, err := proto.Marshal(&types.Quota{MaxTotalValueBytes: 10, MaxValueSize: 5})
+		require.NoError(t, err)
+		aliceUsage, err := proto.Marshal(&types.QuotaUsage{Keys: 1, TotalValueBytes: 3})
+		require.NoError(t, err)
+		dbUsage, err := proto.Marshal(&types.QuotaUsage{Keys: 1, TotalValueBytes: 3})
+		require.NoError(t, err)
+
+		updates := map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: string(identity.UserNamespace) + "alice", Value: alice},
+				},
+			},
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: worldstate.QuotaKey(worldstate.DefaultDBName), Value: dbQuota},
+				},
+			},
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("abc"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+				},
+			},
+			worldstate.QuotasDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: quota.UserUsageKey("alice"), Value: aliceUsage},
+					{Key: quota.DBUsageKey(worldstate.DefaultDBName), Value: dbUsage},
+					{Key: quota.OwnerKey(worldstate.DefaultDBName, "key1"), Value: []byte("alice")},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(updates, 1))
+	}
+
+	tests := []struct {
+		name           string
+		userID         string
+		op             *types.DBOperation
+		pendingUsage   *quota.Usage
+		expectedResult *types.ValidationInfo
+		expectedUsage  *quota.Delta
+	}{
+		{
+			name:   "valid: within the quota of the database and the user",
+			userID: "alice",
+			op: &types.DBOperation{
+				DbName:     worldstate.DefaultDBName,
+				DataWrites: []*types.DataWrite{{Key: "key2", Value: []byte("ab")}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+			expectedUsage: &quota.Delta{
+				DBs:    map[string]*quota.Usage{worldstate.DefaultDBName: {Keys: 1, TotalValueBytes: 2}},
+				Users:  map[string]*quota.Usage{"alice": {Keys: 1, TotalValueBytes: 2}},
+				Owners: map[string]string{quota.OwnerKey(worldstate.DefaultDBName, "key2"): "alice"},
+			},
+		},
+		{
+			name:   "valid: the usage is reduced even though it remains above the quota",
+			userID: "bob",
+			op: &types.DBOperation{
+				DbName:      worldstate.DefaultDBName,
+				DataDeletes: []*types.DataDelete{{Key: "key1"}},
+			},
+			pendingUsage: &quota.Usage{Keys: 1, TotalValueBytes: 20},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+			expectedUsage: &quota.Delta{
+				DBs:    map[string]*quota.Usage{worldstate.DefaultDBName: {Keys: 0, TotalValueBytes: 17}},
+				Users:  map[string]*quota.Usage{"alice": {Keys: -1, TotalValueBytes: -3}},
+				Owners: map[string]string{quota.OwnerKey(worldstate.DefaultDBName, "key1"): ""},
+			},
+		},
+		{
+			name:   "invalid: value exceeds the maximum value size of the database",
+			userID: "alice",
+			op: &types.DBOperation{
+				DbName:     worldstate.DefaultDBName,
+				DataWrites: []*types.DataWrite{{Key: "key2", Value: []byte("abcdef")}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+				ReasonIfInvalid: "the size [6] of the value of the key [key2] exceeds the maximum value size [5] of the database [bdb]",
+			},
+		},
+		{
+			name:   "invalid: number of keys exceeds the quota of the user",
+			userID: "alice",
+			op: &types.DBOperation{
+				DbName: worldstate.DefaultDBName,
+				DataWrites: []*types.DataWrite{
+					{Key: "key2", Value: []byte("a")},
+					{Key: "key3", Value: []byte("b")},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+				ReasonIfInvalid: "the transaction exceeds the quota of the user [alice]: the number of keys would be [3] while the maximum is [2]",
+			},
+		},
+		{
+			name:   "invalid: total size of values exceeds the quota of the database due to pending transactions",
+			userID: "bob",
+			op: &types.DBOperation{
+				DbName:     worldstate.DefaultDBName,
+				DataWrites: []*types.DataWrite{{Key: "key2", Value: []byte("ab")}},
+			},
+			pendingUsage: &quota.Usage{Keys: 1, TotalValueBytes: 6},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+				ReasonIfInvalid: "the transaction exceeds the quota of the database [bdb]: the total size of values would be [11] bytes while the maximum is [10] bytes",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			pendingOps := newPendingOperations()
+			if tt.pendingUsage != nil {
+				pendingOps.usage.DBs[worldstate.DefaultDBName] = tt.pendingUsage
+			}
+
+			result, err := env.validator.dataTxValidator.validateQuotas(tt.userID, []*types.DBOperation{tt.op}, pendingOps)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+			if tt.expectedUsage != nil {
+				require.Equal(t, tt.expectedUsage, pendingOps.usage)
+			}
+		})
+	}
+}
+
 func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 	t.Parallel()
 
@@ -4,7 +4,10 @@
 package txvalidation
 
 import (
+	"sort"
+
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/procedure"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -12,10 +15,11 @@ import (
 )
 
 type dbAdminTxValidator struct {
-	db              worldstate.DB
-	identityQuerier *identity.Querier
-	sigValidator    *txSigValidator
-	logger          *logger.SugarLogger
+	db                worldstate.DB
+	identityQuerier   *identity.Querier
+	sigValidator      *txSigValidator
+	approvalValidator *adminApprovalValidator
+	logger            *logger.SugarLogger
 }
 
 func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope) (*types.ValidationInfo, error) {
@@ -36,6 +40,11 @@ func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope) (
 		}, nil
 	}
 
+	valInfo, err = v.approvalValidator.validate(tx.UserId, txEnv.Signatures, txEnv.Payload, (*types.GovernancePolicy).GetDbAdminTxMinAdmins, "database")
+	if err != nil || valInfo.Flag != types.Flag_VALID {
+		return valInfo, err
+	}
+
 	if r := v.validateCreateDBEntries(tx.CreateDbs); r.Flag != types.Flag_VALID {
 		return r, nil
 	}
@@ -44,7 +53,19 @@ func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope) (
 		return r, nil
 	}
 
-	return v.validateIndexEntries(tx.DbsIndex, tx.CreateDbs, tx.DeleteDbs), nil
+	if r := v.validateIndexEntries(tx.DbsIndex, tx.CreateDbs, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	if r := v.validateSchemaEntries(tx.DbsSchema, tx.CreateDbs, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	if r := v.validateProcedureEntries(tx.DbsProcedures, tx.CreateDbs, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	return v.validateQuotaEntries(tx.DbsQuota, tx.CreateDbs, tx.DeleteDbs), nil
 }
 
 func (v *dbAdminTxValidator) validateCreateDBEntries(toCreateDBs []string) *types.ValidationInfo {
@@ -58,6 +79,12 @@ func (v *dbAdminTxValidator) validateCreateDBEntries(toCreateDBs []string) *type
 				ReasonIfInvalid: "the name of the database to be created cannot be empty",
 			}
 
+		case worldstate.IsReservedDBName(dbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database name [" + dbName + "] starts with a prefix reserved for the schema, stored procedures, and quota of databases",
+			}
+
 		case !v.db.ValidDBName(dbName):
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
@@ -205,3 +232,179 @@ func (v *dbAdminTxValidator) validateIndexEntries(dbsIndex map[string]*types.DBI
 		Flag: types.Flag_VALID,
 	}
 }
+
+func (v *dbAdminTxValidator) validateSchemaEntries(dbsSchema map[string]*types.DBSchema, toCreateDBs, toDeleteDBs []string) *types.ValidationInfo {
+	toCreateDBsLookup := make(map[string]bool)
+	toDeleteDBsLookup := make(map[string]bool)
+
+	for _, dbName := range toCreateDBs {
+		toCreateDBsLookup[dbName] = true
+	}
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	// the reason for invalidation becomes part of the block and hence, the
+	// entries are validated in a deterministic order
+	var dbNames []string
+	for dbName := range dbsSchema {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		switch {
+		case worldstate.IsSystemDB(dbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for the system database [" + dbName + "] is not allowed",
+			}
+
+		case !v.db.Exist(dbName) && !toCreateDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [" + dbName + "] cannot be processed as the database neither exists nor is in the create DB list",
+			}
+
+		case toDeleteDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [" + dbName + "] cannot be processed as the database is present in the delete list",
+			}
+		}
+
+		jsonSchema := dbsSchema[dbName].GetJsonSchema()
+		if jsonSchema == "" {
+			continue
+		}
+
+		if _, err := compileJSONSchema([]byte(jsonSchema)); err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [" + dbName + "] is not a valid JSON schema: " + err.Error(),
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+func (v *dbAdminTxValidator) validateProcedureEntries(dbsProcedures map[string]*types.DBProcedures, toCreateDBs, toDeleteDBs []string) *types.ValidationInfo {
+	toCreateDBsLookup := make(map[string]bool)
+	toDeleteDBsLookup := make(map[string]bool)
+
+	for _, dbName := range toCreateDBs {
+		toCreateDBsLookup[dbName] = true
+	}
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	// the reason for invalidation becomes part of the block and hence, the
+	// entries are validated in a deterministic order
+	var dbNames []string
+	for dbName := range dbsProcedures {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		switch {
+		case worldstate.IsSystemDB(dbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for the system database [" + dbName + "] are not allowed",
+			}
+
+		case !v.db.Exist(dbName) && !toCreateDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for database [" + dbName + "] cannot be processed as the database neither exists nor is in the create DB list",
+			}
+
+		case toDeleteDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for database [" + dbName + "] cannot be processed as the database is present in the delete list",
+			}
+		}
+
+		procedures := dbsProcedures[dbName].GetProcedures()
+		var procedureNames []string
+		for name := range procedures {
+			procedureNames = append(procedureNames, name)
+		}
+		sort.Strings(procedureNames)
+
+		for _, name := range procedureNames {
+			if !procedure.ValidName(name) {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the stored procedure name [" + name + "] provided for database [" + dbName + "] is not valid",
+				}
+			}
+
+			source := procedures[name]
+			if source == "" {
+				continue
+			}
+
+			if err := procedure.Compile(name, source); err != nil {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the stored procedure [" + name + "] provided for database [" + dbName + "] is not valid: " + err.Error(),
+				}
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+func (v *dbAdminTxValidator) validateQuotaEntries(dbsQuota map[string]*types.Quota, toCreateDBs, toDeleteDBs []string) *types.ValidationInfo {
+	toCreateDBsLookup := make(map[string]bool)
+	toDeleteDBsLookup := make(map[string]bool)
+
+	for _, dbName := range toCreateDBs {
+		toCreateDBsLookup[dbName] = true
+	}
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	var dbNames []string
+	for dbName := range dbsQuota {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		switch {
+		case worldstate.IsSystemDB(dbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for the system database [" + dbName + "] is not allowed",
+			}
+
+		case !v.db.Exist(dbName) && !toCreateDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for database [" + dbName + "] cannot be processed as the database neither exists nor is in the create DB list",
+			}
+
+		case toDeleteDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for database [" + dbName + "] cannot be processed as the database is present in the delete list",
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
@@ -254,6 +254,30 @@ func TestValidateCreateDBEntries(t *testing.T) {
 				ReasonIfInvalid: "the name of the database to be created cannot be empty",
 			},
 		},
+		{
+			name:        "invalid: database name with the reserved schema prefix",
+			toCreateDBs: []string{"_schema_db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database name [_schema_db1] starts with a prefix reserved for the schema, stored procedures, and quota of databases",
+			},
+		},
+		{
+			name:        "invalid: database name with the reserved procedure prefix",
+			toCreateDBs: []string{"_procedure_db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database name [_procedure_db1] starts with a prefix reserved for the schema, stored procedures, and quota of databases",
+			},
+		},
+		{
+			name:        "invalid: database name with the reserved quota prefix",
+			toCreateDBs: []string{"_quota_db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database name [_quota_db1] starts with a prefix reserved for the schema, stored procedures, and quota of databases",
+			},
+		},
 		{
 			name:        "invalid: system database cannot be created",
 			toCreateDBs: []string{worldstate.ConfigDBName},
@@ -550,3 +574,400 @@ func TestValidateIndexDBEntries(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSchemaDBEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		setup          func(db worldstate.DB)
+		toCreateDBs    []string
+		toDeleteDBs    []string
+		dbsSchema      map[string]*types.DBSchema
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "invalid: db does not exist already and also does not appear in the createDB list",
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object"}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] cannot be processed as the database neither exists nor is in the create DB list",
+			},
+		},
+		{
+			name: "invalid: schema on a system database",
+			dbsSchema: map[string]*types.DBSchema{
+				worldstate.UsersDBName: {
+					JsonSchema: `{"type": "object"}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for the system database [_users] is not allowed",
+			},
+		},
+		{
+			name: "invalid: db exist but appears in the deleteDB list too",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			toDeleteDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object"}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] cannot be processed as the database is present in the delete list",
+			},
+		},
+		{
+			name:        "invalid: schema is not a valid JSON",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object"`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] is not a valid JSON schema: unexpected EOF",
+			},
+		},
+		{
+			name:        "invalid: schema uses an unknown type",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "record"}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] is not a valid JSON schema: has a primitive type that is NOT VALID -- given: /record/ Expected valid values are:[array boolean integer number null object string]",
+			},
+		},
+		{
+			name:        "invalid: schema references a remote document",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object", "properties": {"name": {"$ref": "http://example.com/name.json"}}}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] is not a valid JSON schema: the reference [http://example.com/name.json] is not local, only references starting with # are allowed",
+			},
+		},
+		{
+			name:        "invalid: schema references a local file",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object", "items": [{"$ref": "#/definitions/name"}, {"$ref": "file:///etc/passwd"}], "definitions": {"name": {"type": "string"}}}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db1] is not a valid JSON schema: the reference [file:///etc/passwd] is not local, only references starting with # are allowed",
+			},
+		},
+		{
+			name:        "invalid: the first database in order is reported",
+			toCreateDBs: []string{"db1", "db2", "db3"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db3": {
+					JsonSchema: `{"type": "record"}`,
+				},
+				"db2": {
+					JsonSchema: `{"type": "object"`,
+				},
+				"db1": {
+					JsonSchema: `{"type": "object"}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "schema definition provided for database [db2] is not a valid JSON schema: unexpected EOF",
+			},
+		},
+		{
+			name:        "valid: schema references its own definitions",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object", "properties": {"name": {"$ref": "#/definitions/name"}}, "definitions": {"name": {"type": "string"}}}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:        "valid: db does not exist already but appears in the createDB list",
+			toCreateDBs: []string{"db1"},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: schema update and removal on existing databases",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}, {Key: "db2"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			dbsSchema: map[string]*types.DBSchema{
+				"db1": {
+					JsonSchema: `{"type": "object"}`,
+				},
+				"db2": {},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			if tt.setup != nil {
+				tt.setup(env.db)
+			}
+
+			result := env.validator.dbAdminTxValidator.validateSchemaEntries(tt.dbsSchema, tt.toCreateDBs, tt.toDeleteDBs)
+			require.True(t, proto.Equal(tt.expectedResult, result), "result: %v", result)
+		})
+	}
+}
+
+func TestValidateProcedureDBEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		setup          func(db worldstate.DB)
+		toCreateDBs    []string
+		toDeleteDBs    []string
+		dbsProcedures  map[string]*types.DBProcedures
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "invalid: db does not exist already and also does not appear in the createDB list",
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{"noop": "def main():\n  pass"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for database [db1] cannot be processed as the database neither exists nor is in the create DB list",
+			},
+		},
+		{
+			name: "invalid: procedures on a system database",
+			dbsProcedures: map[string]*types.DBProcedures{
+				worldstate.UsersDBName: {
+					Procedures: map[string]string{"noop": "def main():\n  pass"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for the system database [_users] are not allowed",
+			},
+		},
+		{
+			name: "invalid: db exist but appears in the deleteDB list too",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			toDeleteDBs: []string{"db1"},
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{"noop": "def main():\n  pass"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "stored procedures provided for database [db1] cannot be processed as the database is present in the delete list",
+			},
+		},
+		{
+			name:        "invalid: procedure name is not valid",
+			toCreateDBs: []string{"db1"},
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{"no~op": "def main():\n  pass"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored procedure name [no~op] provided for database [db1] is not valid",
+			},
+		},
+		{
+			name:        "invalid: procedure does not compile",
+			toCreateDBs: []string{"db1"},
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{
+						"noop": "def main():\n  pass",
+						"now":  "def main():\n  put('time', now())",
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored procedure [now] provided for database [db1] is not valid: now:2:15: undefined: now",
+			},
+		},
+		{
+			name:        "invalid: procedure does not define the entry point",
+			toCreateDBs: []string{"db1"},
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{"noop": "def run():\n  pass"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored procedure [noop] provided for database [db1] is not valid: the stored procedure does not define the function [main]",
+			},
+		},
+		{
+			name: "valid: procedure registration and removal",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			toCreateDBs: []string{"db2"},
+			dbsProcedures: map[string]*types.DBProcedures{
+				"db1": {
+					Procedures: map[string]string{
+						"noop":     "def main():\n  pass",
+						"obsolete": "",
+					},
+				},
+				"db2": {
+					Procedures: map[string]string{"copy": "def main(from_key, to_key):\n  put(to_key, get(from_key))"},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			if tt.setup != nil {
+				tt.setup(env.db)
+			}
+
+			result := env.validator.dbAdminTxValidator.validateProcedureEntries(tt.dbsProcedures, tt.toCreateDBs, tt.toDeleteDBs)
+			require.True(t, proto.Equal(tt.expectedResult, result), "result: %v", result)
+		})
+	}
+}
+
+func TestValidateQuotaDBEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		setup          func(db worldstate.DB)
+		toCreateDBs    []string
+		toDeleteDBs    []string
+		dbsQuota       map[string]*types.Quota
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "invalid: db does not exist already and also does not appear in the createDB list",
+			dbsQuota: map[string]*types.Quota{
+				"db1": {MaxKeys: 10},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for database [db1] cannot be processed as the database neither exists nor is in the create DB list",
+			},
+		},
+		{
+			name: "invalid: quota on a system database",
+			dbsQuota: map[string]*types.Quota{
+				worldstate.QuotasDBName: {MaxKeys: 10},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for the system database [_quotas] is not allowed",
+			},
+		},
+		{
+			name: "invalid: db exist but appears in the deleteDB list too",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			toDeleteDBs: []string{"db1"},
+			dbsQuota: map[string]*types.Quota{
+				"db1": {MaxKeys: 10},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "quota provided for database [db1] cannot be processed as the database is present in the delete list",
+			},
+		},
+		{
+			name: "valid: quota definition and removal",
+			setup: func(db worldstate.DB) {
+				createDB := map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}}}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			toCreateDBs: []string{"db2"},
+			dbsQuota: map[string]*types.Quota{
+				"db1": {},
+				"db2": {MaxKeys: 10, MaxTotalValueBytes: 1024, MaxValueSize: 128},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			if tt.setup != nil {
+				tt.setup(env.db)
+			}
+
+			result := env.validator.dbAdminTxValidator.validateQuotaEntries(tt.dbsQuota, tt.toCreateDBs, tt.toDeleteDBs)
+			require.True(t, proto.Equal(tt.expectedResult, result), "result: %v", result)
+		})
+	}
+}
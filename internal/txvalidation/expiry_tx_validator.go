@@ -0,0 +1,117 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/expiry"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+type expiryTxValidator struct {
+	db              worldstate.DB
+	identityQuerier *identity.Querier
+	// sigValidator verifies the signatures of the nodes
+	sigValidator *txSigValidator
+	logger       *logger.SugarLogger
+}
+
+// nodeCertificates provides the certificates of the nodes of the cluster to a signature verifier
+type nodeCertificates struct {
+	querier *identity.Querier
+}
+
+func (n *nodeCertificates) GetCertificate(nodeID string) (*x509.Certificate, error) {
+	return n.querier.GetNodeCertificate(nodeID)
+}
+
+// validate ensures that the expiry transaction is signed by a node of the cluster, that it was
+// not proposed after the block holding it, and that every key deleted by the transaction exists
+// with the version observed by the proposer and has expired in that block. As the transaction
+// deletes all keys or none, a key which has been updated after the proposal invalidates the
+// transaction and the remaining keys are proposed again.
+func (v *expiryTxValidator) validate(txEnv *types.ExpiryTxEnvelope, blockHeader *types.BlockHeaderBase) (*types.ValidationInfo, error) {
+	tx := txEnv.GetPayload()
+	blockNum := blockHeader.GetNumber()
+	if len(tx.GetExpiredKeys()) == 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the expiry transaction does not contain any key",
+		}, nil
+	}
+
+	if _, _, err := v.identityQuerier.GetNode(tx.GetNodeId()); err != nil {
+		if _, ok := err.(*identity.NotFoundErr); ok {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "the expiry transaction is proposed by [" + tx.GetNodeId() + "], which is not a node of the cluster",
+			}, nil
+		}
+		return nil, err
+	}
+	if len(txEnv.GetSignature()) == 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+			ReasonIfInvalid: "the expiry transaction is not signed by the node [" + tx.GetNodeId() + "]",
+		}, nil
+	}
+	if valRes, err := v.sigValidator.validate(tx.GetNodeId(), txEnv.GetSignature(), tx); err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, err
+	}
+
+	if tx.GetTimestamp() > blockHeader.GetTimestamp() {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the time [%d] of the expiry transaction is ahead of the time [%d] of the block [%d]", tx.GetTimestamp(), blockHeader.GetTimestamp(), blockNum),
+		}, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, k := range tx.ExpiredKeys {
+		if worldstate.IsSystemDB(k.DbName) || !v.db.Exist(k.DbName) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DATABASE_DOES_NOT_EXIST,
+				ReasonIfInvalid: "the database [" + k.DbName + "] does not exist in the cluster",
+			}, nil
+		}
+
+		if seen[k.DbName+"~"+k.Key] {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + k.Key + "] of the database [" + k.DbName + "] is duplicated in the expiry transaction",
+			}, nil
+		}
+		seen[k.DbName+"~"+k.Key] = true
+
+		_, metadata, err := v.db.Get(k.DbName, k.Key)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while fetching the key [%s] of the database [%s]", k.Key, k.DbName)
+		}
+
+		if metadata == nil || !proto.Equal(metadata.GetVersion(), k.Version) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
+				ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [" + k.Key + "] in database [" + k.DbName + "] changed",
+			}, nil
+		}
+
+		if !expiry.IsExpired(metadata.GetExpiry(), blockNum, tx.Timestamp) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the key [%s] of the database [%s] has not expired in the block [%d] at the time [%d]", k.Key, k.DbName, blockNum, tx.Timestamp),
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
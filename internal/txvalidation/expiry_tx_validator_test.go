@@ -0,0 +1,284 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExpiryTx(t *testing.T) {
+	t.Parallel()
+
+	version := &types.Version{BlockNum: 2, TxNum: 1}
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"node1", "node2", "user1"})
+	node1Cert, node1Signer := testutils.LoadTestCrypto(t, cryptoDir, "node1")
+	_, node2Signer := testutils.LoadTestCrypto(t, cryptoDir, "node2")
+	_, user1Signer := testutils.LoadTestCrypto(t, cryptoDir, "user1")
+	node1, err := proto.Marshal(&types.NodeConfig{Id: "node1", Address: "127.0.0.1", Port: 6001, Certificate: node1Cert.Raw})
+	require.NoError(t, err)
+
+	setup := func(db worldstate.DB) {
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1"},
+				},
+			},
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: string(identity.NodeNamespace) + "node1", Value: node1},
+				},
+			},
+		}, 1))
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("v"), Metadata: &types.Metadata{Version: version, Expiry: &types.Expiry{BlockNum: 5}}},
+					{Key: "key2", Value: []byte("v"), Metadata: &types.Metadata{Version: version, Expiry: &types.Expiry{UnixTime: 1000}}},
+					{Key: "key3", Value: []byte("v"), Metadata: &types.Metadata{Version: version}},
+				},
+			},
+		}, 2))
+	}
+
+	tests := []struct {
+		name string
+		tx   *types.ExpiryTx
+		// signer signs the transaction, node1 when not set
+		signer         crypto.Signer
+		unsigned       bool
+		blockNum       uint64
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:     "invalid: no keys",
+			tx:       &types.ExpiryTx{TxId: "tx1", NodeId: "node1", Timestamp: 1000},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the expiry transaction does not contain any key",
+			},
+		},
+		{
+			name: "invalid: proposed by a node which is not in the cluster",
+			tx: &types.ExpiryTx{
+				NodeId:    "node2",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: version},
+				},
+			},
+			signer:   node2Signer,
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "the expiry transaction is proposed by [node2], which is not a node of the cluster",
+			},
+		},
+		{
+			name: "invalid: forged signature of a node",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: version},
+				},
+			},
+			signer:   user1Signer,
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature verification failed: x509: ECDSA verification failure",
+			},
+		},
+		{
+			name: "invalid: not signed",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: version},
+				},
+			},
+			unsigned: true,
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+				ReasonIfInvalid: "the expiry transaction is not signed by the node [node1]",
+			},
+		},
+		{
+			name: "invalid: time ahead of the block time",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1001,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key2", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the time [1001] of the expiry transaction is ahead of the time [1000] of the block [5]",
+			},
+		},
+		{
+			name: "invalid: system database",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: worldstate.UsersDBName, Key: "user1", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DATABASE_DOES_NOT_EXIST,
+				ReasonIfInvalid: "the database [" + worldstate.UsersDBName + "] does not exist in the cluster",
+			},
+		},
+		{
+			name: "invalid: database does not exist",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db2", Key: "key1", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DATABASE_DOES_NOT_EXIST,
+				ReasonIfInvalid: "the database [db2] does not exist in the cluster",
+			},
+		},
+		{
+			name: "invalid: duplicate key",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: version},
+					{DbName: "db1", Key: "key1", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] of the database [db1] is duplicated in the expiry transaction",
+			},
+		},
+		{
+			name: "invalid: key has been updated",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: &types.Version{BlockNum: 1, TxNum: 1}},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
+				ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [key1] in database [db1] changed",
+			},
+		},
+		{
+			name: "invalid: key has been deleted",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key4", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
+				ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [key4] in database [db1] changed",
+			},
+		},
+		{
+			name: "invalid: key has not expired at the block",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key2", Version: version},
+					{DbName: "db1", Key: "key1", Version: version},
+				},
+			},
+			blockNum: 4,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] of the database [db1] has not expired in the block [4] at the time [1000]",
+			},
+		},
+		{
+			name: "invalid: key without ttl",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key3", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key3] of the database [db1] has not expired in the block [5] at the time [1000]",
+			},
+		},
+		{
+			name: "valid",
+			tx: &types.ExpiryTx{
+				NodeId:    "node1",
+				Timestamp: 1000,
+				ExpiredKeys: []*types.ExpiredKey{
+					{DbName: "db1", Key: "key1", Version: version},
+					{DbName: "db1", Key: "key2", Version: version},
+				},
+			},
+			blockNum: 5,
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			setup(env.db)
+
+			txEnv := &types.ExpiryTxEnvelope{Payload: tt.tx}
+			if !tt.unsigned {
+				signer := tt.signer
+				if signer == nil {
+					signer = node1Signer
+				}
+				txEnv.Signature = testutils.SignatureFromTx(t, signer, tt.tx)
+			}
+
+			// the block is proposed at the time of the transaction
+			result, err := env.validator.expiryTxValidator.validate(txEnv, &types.BlockHeaderBase{Number: tt.blockNum, Timestamp: 1000})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// compileJSONSchema compiles the JSON schema defined on a database. gojsonschema loads the documents
+// referenced by a $ref, whether over http or from the local file system, and hence, the schema may
+// only reference its own definitions, i.e., each $ref must start with #. Otherwise, the outcome of the
+// validation would depend on a remote document which may differ between the nodes.
+func compileJSONSchema(jsonSchema []byte) (*gojsonschema.Schema, error) {
+	var doc interface{}
+	d := json.NewDecoder(bytes.NewReader(jsonSchema))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if err := checkLocalReferences(doc); err != nil {
+		return nil, err
+	}
+
+	return gojsonschema.NewSchema(gojsonschema.NewBytesLoader(jsonSchema))
+}
+
+// checkLocalReferences walks the decoded schema, in a deterministic order so that the reported
+// reference does not differ between the nodes, and returns an error on the first $ref which does
+// not point into the schema itself
+func checkLocalReferences(doc interface{}) error {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if ref, ok := v[k].(string); ok && k == "$ref" && !strings.HasPrefix(ref, "#") {
+				return errors.Errorf("the reference [%s] is not local, only references starting with # are allowed", ref)
+			}
+			if err := checkLocalReferences(v[k]); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, e := range v {
+			if err := checkLocalReferences(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaCache holds the compiled JSON schema of each database so that a schema is not compiled again
+// for every transaction which writes to the database. A cached schema is used as long as the version
+// of the schema definition, which changes on every update of the definition, is the same.
+type schemaCache struct {
+	mu      sync.Mutex
+	schemas map[string]*cachedSchema
+}
+
+type cachedSchema struct {
+	version *types.Version
+	schema  *gojsonschema.Schema
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{
+		schemas: make(map[string]*cachedSchema),
+	}
+}
+
+// get returns the compiled schema of the given database, compiling the given definition if the
+// cached one has a different version
+func (c *schemaCache) get(dbName string, jsonSchema []byte, version *types.Version) (*gojsonschema.Schema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.schemas[dbName]; ok && version != nil &&
+		cached.version.GetBlockNum() == version.GetBlockNum() && cached.version.GetTxNum() == version.GetTxNum() {
+		return cached.schema, nil
+	}
+
+	schema, err := compileJSONSchema(jsonSchema)
+	if err != nil {
+		return nil, err
+	}
+	c.schemas[dbName] = &cachedSchema{
+		version: version,
+		schema:  schema,
+	}
+
+	return schema, nil
+}
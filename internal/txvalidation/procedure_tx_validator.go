@@ -0,0 +1,120 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/procedure"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+type procedureTxValidator struct {
+	db              worldstate.DB
+	identityQuerier *identity.Querier
+	sigValidator    *txSigValidator
+	dataTxValidator *dataTxValidator
+	logger          *logger.SugarLogger
+}
+
+// validate executes the stored procedure invoked by the transaction and validates the
+// resulting operation exactly as the operation of a data transaction. The operation
+// is returned only when the transaction is valid.
+func (v *procedureTxValidator) validate(txEnv *types.ProcedureTxEnvelope, pendingOps *pendingOperations) (*types.ValidationInfo, *types.DBOperation, error) {
+	userIDsWithValidSign, valRes, err := v.validateSignatures(txEnv)
+	if err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, nil, err
+	}
+
+	tx := txEnv.Payload
+	valRes, err = v.dataTxValidator.validateDBName(tx.DbName)
+	if err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, nil, err
+	}
+
+	var usersWithDBAccess []string
+	sort.Strings(userIDsWithValidSign)
+
+	for _, userID := range userIDsWithValidSign {
+		hasPerm, err := v.identityQuerier.HasReadWriteAccess(userID, tx.DbName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasPerm {
+			usersWithDBAccess = append(usersWithDBAccess, userID)
+		}
+	}
+
+	if len(usersWithDBAccess) == 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_NO_PERMISSION,
+			ReasonIfInvalid: "none of the user in [" + strings.Join(userIDsWithValidSign, ", ") + "] has read-write permission on the database [" + tx.DbName + "]",
+		}, nil, nil
+	}
+
+	source, _, err := v.db.GetProcedure(tx.DbName, tx.ProcedureName)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "error while fetching the stored procedure [%s] of the database [%s]", tx.ProcedureName, tx.DbName)
+	}
+	if source == nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the stored procedure [" + tx.ProcedureName + "] is not registered on the database [" + tx.DbName + "]",
+		}, nil, nil
+	}
+
+	ops, err := procedure.Execute(v.db, tx.DbName, tx.ProcedureName, source, tx.Args)
+	if err != nil {
+		if execErr, ok := err.(*procedure.ExecutionError); ok {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_PROCEDURE_EXECUTION,
+				ReasonIfInvalid: execErr.Error(),
+			}, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	valRes, err = v.dataTxValidator.validateOps(usersWithDBAccess, ops, pendingOps)
+	if err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, nil, err
+	}
+
+	valRes, err = v.dataTxValidator.validateQuotas(tx.MustSignUserIds[0], []*types.DBOperation{ops}, pendingOps)
+	if err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, nil, err
+	}
+
+	return valRes, ops, nil
+}
+
+func (v *procedureTxValidator) validateSignatures(txEnv *types.ProcedureTxEnvelope) ([]string, *types.ValidationInfo, error) {
+	var userIDsWithValidSign []string
+	for userID, signature := range txEnv.Signatures {
+		valRes, err := v.sigValidator.validate(userID, signature, txEnv.Payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		if valRes.Flag != types.Flag_VALID {
+			for _, mustSignUserID := range txEnv.Payload.MustSignUserIds {
+				if userID == mustSignUserID {
+					return nil,
+						&types.ValidationInfo{
+							Flag:            types.Flag_INVALID_UNAUTHORISED,
+							ReasonIfInvalid: "signature of the must sign user [" + userID + "] is not valid (maybe the certificate got changed)",
+						}, nil
+				}
+			}
+			continue
+		}
+
+		userIDsWithValidSign = append(userIDsWithValidSign, userID)
+	}
+
+	return userIDsWithValidSign, &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
@@ -14,10 +14,11 @@ import (
 )
 
 type userAdminTxValidator struct {
-	db              worldstate.DB
-	identityQuerier *identity.Querier
-	sigValidator    *txSigValidator
-	logger          *logger.SugarLogger
+	db                worldstate.DB
+	identityQuerier   *identity.Querier
+	sigValidator      *txSigValidator
+	approvalValidator *adminApprovalValidator
+	logger            *logger.SugarLogger
 }
 
 func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelope) (*types.ValidationInfo, error) {
@@ -38,6 +39,11 @@ func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelop
 		}, nil
 	}
 
+	valInfo, err = v.approvalValidator.validate(tx.UserId, txEnv.Signatures, txEnv.Payload, (*types.GovernancePolicy).GetUserAdminTxMinAdmins, "user")
+	if err != nil || valInfo.Flag != types.Flag_VALID {
+		return valInfo, err
+	}
+
 	r, err := v.validateFieldsInUserWrites(tx.UserWrites)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error while validating fields in user writes")
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -22,6 +23,8 @@ type Validator struct {
 	dbAdminTxValidator   *dbAdminTxValidator
 	userAdminTxValidator *userAdminTxValidator
 	dataTxValidator      *dataTxValidator
+	procedureTxValidator *procedureTxValidator
+	expiryTxValidator    *expiryTxValidator
 	signValidator        *txSigValidator
 	logger               *logger.SugarLogger
 }
@@ -34,37 +37,70 @@ type Config struct {
 // NewValidator creates a new Validator
 func NewValidator(conf *Config) *Validator {
 	idQuerier := identity.NewQuerier(conf.DB)
+	nodeSigValidator := &txSigValidator{
+		sigVerifier: cryptoservice.NewVerifier(&nodeCertificates{querier: idQuerier}, conf.Logger),
+		logger:      conf.Logger,
+	}
+
 	txSigValidator := &txSigValidator{
 		sigVerifier: cryptoservice.NewVerifier(idQuerier, conf.Logger),
 		logger:      conf.Logger,
 	}
 
+	approvalValidator := &adminApprovalValidator{
+		db:              conf.DB,
+		identityQuerier: idQuerier,
+		sigValidator:    txSigValidator,
+		logger:          conf.Logger,
+	}
+
+	dataTxValidator := &dataTxValidator{
+		db:              conf.DB,
+		identityQuerier: idQuerier,
+		sigValidator:    txSigValidator,
+		schemas:         newSchemaCache(),
+		logger:          conf.Logger,
+	}
+
 	return &Validator{
 		configTxValidator: &ConfigTxValidator{
-			db:              conf.DB,
-			identityQuerier: idQuerier,
-			sigValidator:    txSigValidator,
-			logger:          conf.Logger,
+			db:                conf.DB,
+			identityQuerier:   idQuerier,
+			sigValidator:      txSigValidator,
+			approvalValidator: approvalValidator,
+			logger:            conf.Logger,
 		},
 
 		dbAdminTxValidator: &dbAdminTxValidator{
-			db:              conf.DB,
-			identityQuerier: idQuerier,
-			sigValidator:    txSigValidator,
-			logger:          conf.Logger,
+			db:                conf.DB,
+			identityQuerier:   idQuerier,
+			sigValidator:      txSigValidator,
+			approvalValidator: approvalValidator,
+			logger:            conf.Logger,
 		},
 
 		userAdminTxValidator: &userAdminTxValidator{
+			db:                conf.DB,
+			identityQuerier:   idQuerier,
+			sigValidator:      txSigValidator,
+			approvalValidator: approvalValidator,
+			logger:            conf.Logger,
+		},
+
+		dataTxValidator: dataTxValidator,
+
+		procedureTxValidator: &procedureTxValidator{
 			db:              conf.DB,
 			identityQuerier: idQuerier,
 			sigValidator:    txSigValidator,
+			dataTxValidator: dataTxValidator,
 			logger:          conf.Logger,
 		},
 
-		dataTxValidator: &dataTxValidator{
+		expiryTxValidator: &expiryTxValidator{
 			db:              conf.DB,
 			identityQuerier: idQuerier,
-			sigValidator:    txSigValidator,
+			sigValidator:    nodeSigValidator,
 			logger:          conf.Logger,
 		},
 
@@ -75,7 +111,9 @@ func NewValidator(conf *Config) *Validator {
 }
 
 // ValidateBlock validates each transaction present in the block to ensure
-// the request isolation level
+// the request isolation level. For a block of procedure transactions, the
+// operations produced by executing the stored procedures are recorded in the
+// block header so that the commit does not depend on re-execution.
 func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo, error) {
 	if block.Header.BaseHeader.Number == 1 {
 		// for the genesis block, which is created by the node itself, we cannot
@@ -121,6 +159,38 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 
 		return valInfoArray, nil
 
+	case *types.Block_ProcedureTxEnvelopes:
+		procedureTxEnvs := block.GetProcedureTxEnvelopes().Envelopes
+		valInfoArray := make([]*types.ValidationInfo, len(procedureTxEnvs))
+		results := make([]*types.DBOperation, len(procedureTxEnvs))
+
+		pendingOps := newPendingOperations()
+		for txNum, txEnv := range procedureTxEnvs {
+			valRes, ops, err := v.procedureTxValidator.validate(txEnv, pendingOps)
+			if err != nil {
+				return nil, errors.WithMessage(err, "error while validating procedure transaction")
+			}
+
+			valInfoArray[txNum] = valRes
+			results[txNum] = &types.DBOperation{}
+			if valRes.Flag != types.Flag_VALID {
+				v.logger.Debugf("procedure transaction [%v] is invalid due to [%s]", txEnv.Payload, valRes.ReasonIfInvalid)
+				continue
+			}
+
+			results[txNum] = ops
+			for _, w := range ops.DataWrites {
+				pendingOps.addWrite(ops.DbName, w.Key)
+			}
+
+			for _, d := range ops.DataDeletes {
+				pendingOps.addDelete(ops.DbName, d.Key)
+			}
+		}
+
+		block.Header.ProcedureResults = results
+		return valInfoArray, nil
+
 	case *types.Block_UserAdministrationTxEnvelope:
 		userTxEnv := block.GetUserAdministrationTxEnvelope()
 		valRes, err := v.userAdminTxValidator.validate(userTxEnv)
@@ -151,6 +221,21 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 			valRes,
 		}, nil
 
+	case *types.Block_ExpiryTxEnvelope:
+		expiryTxEnv := block.GetExpiryTxEnvelope()
+		valRes, err := v.expiryTxValidator.validate(expiryTxEnv, block.GetHeader().GetBaseHeader())
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while validating expiry transaction")
+		}
+
+		if valRes.Flag != types.Flag_VALID {
+			v.logger.Debugf("expiry transaction [%v] is invalid due to [%s]", expiryTxEnv.Payload, valRes.ReasonIfInvalid)
+		}
+
+		return []*types.ValidationInfo{
+			valRes,
+		}, nil
+
 	case *types.Block_ConfigTxEnvelope:
 		configTxEnv := block.GetConfigTxEnvelope()
 		valRes, err := v.configTxValidator.Validate(configTxEnv)
@@ -215,12 +300,14 @@ func (v *Validator) parallelSigValidation(dataTxEnvs []*types.DataTxEnvelope) ([
 type pendingOperations struct {
 	pendingWrites  map[string]bool
 	pendingDeletes map[string]bool
+	usage          *quota.Delta
 }
 
 func newPendingOperations() *pendingOperations {
 	return &pendingOperations{
 		pendingWrites:  make(map[string]bool),
 		pendingDeletes: make(map[string]bool),
+		usage:          quota.NewDelta(),
 	}
 }
 
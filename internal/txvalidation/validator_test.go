@@ -79,8 +79,9 @@ func newValidatorTestEnv(t *testing.T) *validatorTestEnv {
 func TestValidateGenesisBlock(t *testing.T) {
 	t.Parallel()
 
-	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin1", "node1"})
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"admin1", "admin2", "node1"})
 	adminCert, _ := testutils.LoadTestCrypto(t, cryptoDir, "admin1")
+	admin2Cert, _ := testutils.LoadTestCrypto(t, cryptoDir, "admin2")
 	nodeCert, _ := testutils.LoadTestCrypto(t, cryptoDir, "node1")
 	caCert, _ := testutils.LoadTestCA(t, cryptoDir, testutils.RootCAFileName)
 
@@ -271,6 +272,45 @@ func TestValidateGenesisBlock(t *testing.T) {
 				},
 				expectedError: "genesis block cannot be invalid: reason for invalidation [the admin [admin1] has an invalid certificate",
 			},
+			{
+				name: "governance policy requires more admins than the config has",
+				genesisBlock: &types.Block{
+					Header: &types.BlockHeader{
+						BaseHeader: &types.BlockHeaderBase{
+							Number: 1,
+						},
+					},
+					Payload: &types.Block_ConfigTxEnvelope{
+						ConfigTxEnvelope: &types.ConfigTxEnvelope{
+							Payload: &types.ConfigTx{
+								NewConfig: &types.ClusterConfig{
+									Nodes: []*types.NodeConfig{
+										{
+											Id:          "node1",
+											Address:     "127.0.0.1",
+											Port:        6090,
+											Certificate: nodeCert.Raw,
+										},
+									},
+									Admins: []*types.Admin{
+										{
+											Id:          "admin1",
+											Certificate: adminCert.Raw,
+										},
+									},
+									CertAuthConfig: &types.CAConfig{
+										Roots: [][]byte{caCert.Raw},
+									},
+									GovernancePolicy: &types.GovernancePolicy{
+										ConfigTxMinAdmins: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+				expectedError: "genesis block cannot be invalid: reason for invalidation [the governance policy requires [2] admins to approve cluster administrative operations but there are only [1] admins in the admin config]",
+			},
 		}
 
 		for _, tt := range tests {
@@ -351,6 +391,75 @@ func TestValidateGenesisBlock(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expectedResults, results)
 	})
+
+	t.Run("genesis block with a governance policy", func(t *testing.T) {
+		t.Parallel()
+
+		genesisBlock := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: 1,
+				},
+			},
+			Payload: &types.Block_ConfigTxEnvelope{
+				ConfigTxEnvelope: &types.ConfigTxEnvelope{
+					Payload: &types.ConfigTx{
+						NewConfig: &types.ClusterConfig{
+							Nodes: []*types.NodeConfig{
+								{
+									Id:          "node1",
+									Address:     "127.0.0.1",
+									Port:        6090,
+									Certificate: nodeCert.Raw,
+								},
+							},
+							Admins: []*types.Admin{
+								{
+									Id:          "admin1",
+									Certificate: adminCert.Raw,
+								},
+								{
+									Id:          "admin2",
+									Certificate: admin2Cert.Raw,
+								},
+							},
+							CertAuthConfig: &types.CAConfig{
+								Roots: [][]byte{caCert.Raw},
+							},
+							GovernancePolicy: &types.GovernancePolicy{
+								ConfigTxMinAdmins:    2,
+								UserAdminTxMinAdmins: 2,
+								DbAdminTxMinAdmins:   1,
+							},
+							ConsensusConfig: &types.ConsensusConfig{
+								Algorithm: "raft",
+								Members: []*types.PeerConfig{
+									{
+										NodeId:   "node1",
+										RaftId:   1,
+										PeerHost: "10.10.10.10",
+										PeerPort: 7090,
+									},
+								},
+								RaftConfig: &types.RaftConfig{
+									TickInterval:   "100ms",
+									ElectionTicks:  100,
+									HeartbeatTicks: 10,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		env := newValidatorTestEnv(t)
+		defer env.cleanup()
+
+		results, err := env.validator.ValidateBlock(genesisBlock)
+		require.NoError(t, err)
+		require.Equal(t, []*types.ValidationInfo{{Flag: types.Flag_VALID}}, results)
+	})
 }
 
 func TestValidateDataBlock(t *testing.T) {
@@ -654,6 +763,168 @@ func TestValidateDataBlock(t *testing.T) {
 	}
 }
 
+func TestValidateProcedureBlock(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestCrypto(t, []string{"operatingUser"})
+	userCert, userSigner := testutils.LoadTestCrypto(t, cryptoDir, "operatingUser")
+
+	transfer := `
+def main(from_key, to_key, amount):
+    amount = int(amount)
+    balance = int(get(from_key))
+    if balance < amount:
+        fail("insufficient balance in " + from_key)
+    put(from_key, str(balance - amount))
+    put(to_key, str(int(get(to_key) or "0") + amount))
+`
+
+	setup := func(db worldstate.DB) {
+		user := &types.User{
+			Id:          "operatingUser",
+			Certificate: userCert.Raw,
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					worldstate.DefaultDBName: types.Privilege_ReadWrite,
+				},
+			},
+		}
+		userSerialized, err := proto.Marshal(user)
+		require.NoError(t, err)
+
+		updates := map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   string(identity.UserNamespace) + "operatingUser",
+						Value: userSerialized,
+					},
+				},
+			},
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.ProcedureKey(worldstate.DefaultDBName, "transfer"),
+						Value: []byte(transfer),
+					},
+				},
+			},
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "alice",
+						Value: []byte("100"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    1,
+							},
+						},
+					},
+					{
+						Key:   "bob",
+						Value: []byte("10"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    1,
+							},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(updates, 1))
+	}
+
+	procedureTx := func(txID, procedureName string, args ...string) *types.ProcedureTxEnvelope {
+		return testutils.SignedProcedureTxEnvelope(t, []crypto.Signer{userSigner}, &types.ProcedureTx{
+			MustSignUserIds: []string{"operatingUser"},
+			TxId:            txID,
+			DbName:          worldstate.DefaultDBName,
+			ProcedureName:   procedureName,
+			Args:            args,
+		})
+	}
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 2,
+			},
+		},
+		Payload: &types.Block_ProcedureTxEnvelopes{
+			ProcedureTxEnvelopes: &types.ProcedureTxEnvelopes{
+				Envelopes: []*types.ProcedureTxEnvelope{
+					procedureTx("tx1", "transfer", "alice", "carol", "30"),
+					procedureTx("tx2", "transfer", "alice", "bob", "10"),
+					procedureTx("tx3", "transfer", "bob", "dave", "50"),
+					procedureTx("tx4", "mint", "bob"),
+					procedureTx("tx5", "transfer", "bob", "dave", "5"),
+				},
+			},
+		},
+	}
+
+	expectedResults := []*types.ValidationInfo{
+		{
+			Flag: types.Flag_VALID,
+		},
+		{
+			Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
+			ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [alice] in database [" + worldstate.DefaultDBName + "]",
+		},
+		{
+			Flag:            types.Flag_INVALID_PROCEDURE_EXECUTION,
+			ReasonIfInvalid: "execution of the stored procedure [transfer] failed: fail: insufficient balance in bob",
+		},
+		{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the stored procedure [mint] is not registered on the database [" + worldstate.DefaultDBName + "]",
+		},
+		{
+			Flag: types.Flag_VALID,
+		},
+	}
+
+	env := newValidatorTestEnv(t)
+	defer env.cleanup()
+	setup(env.db)
+
+	results, err := env.validator.ValidateBlock(block)
+	require.NoError(t, err)
+	require.Equal(t, expectedResults, results)
+
+	procedureResults := block.Header.ProcedureResults
+	require.Len(t, procedureResults, 5)
+	for _, i := range []int{1, 2, 3} {
+		require.True(t, proto.Equal(&types.DBOperation{}, procedureResults[i]))
+	}
+
+	require.True(t, proto.Equal(&types.DBOperation{
+		DbName: worldstate.DefaultDBName,
+		DataReads: []*types.DataRead{
+			{Key: "alice", Version: &types.Version{BlockNum: 1, TxNum: 1}},
+			{Key: "carol"},
+		},
+		DataWrites: []*types.DataWrite{
+			{Key: "alice", Value: []byte("70")},
+			{Key: "carol", Value: []byte("30")},
+		},
+	}, procedureResults[0]))
+	require.True(t, proto.Equal(&types.DBOperation{
+		DbName: worldstate.DefaultDBName,
+		DataReads: []*types.DataRead{
+			{Key: "bob", Version: &types.Version{BlockNum: 1, TxNum: 1}},
+			{Key: "dave"},
+		},
+		DataWrites: []*types.DataWrite{
+			{Key: "bob", Value: []byte("5")},
+			{Key: "dave", Value: []byte("5")},
+		},
+	}, procedureResults[4]))
+}
+
 func TestValidateUserBlock(t *testing.T) {
 	t.Parallel()
 
@@ -32,6 +32,23 @@ func BlockPayloadToTxIDs(blockPayload interface{}) ([]string, error) {
 			return nil, errors.Errorf("empty payload in: %+v", blockPayload)
 		}
 
+	case *types.Block_ProcedureTxEnvelopes:
+		for i, pEnv := range env.ProcedureTxEnvelopes.GetEnvelopes() {
+			p := pEnv.GetPayload()
+			if p == nil {
+				return nil, errors.Errorf("empty payload in index [%d]: %+v", i, env)
+			}
+			id := p.GetTxId()
+			if id == "" {
+				return nil, errors.Errorf("missing TxId in index [%d]: %+v", i, env)
+			}
+			txIDs = append(txIDs, id)
+		}
+
+		if len(txIDs) == 0 {
+			return nil, errors.Errorf("empty payload in: %+v", blockPayload)
+		}
+
 	case *types.Block_UserAdministrationTxEnvelope:
 		p := env.UserAdministrationTxEnvelope.GetPayload()
 		if p == nil {
@@ -65,6 +82,17 @@ func BlockPayloadToTxIDs(blockPayload interface{}) ([]string, error) {
 		}
 		txIDs = append(txIDs, id)
 
+	case *types.Block_ExpiryTxEnvelope:
+		p := env.ExpiryTxEnvelope.GetPayload()
+		if p == nil {
+			return nil, errors.Errorf("empty payload in: %+v", blockPayload)
+		}
+		id := p.GetTxId()
+		if id == "" {
+			return nil, errors.Errorf("missing TxId in: %+v", blockPayload)
+		}
+		txIDs = append(txIDs, id)
+
 	default:
 		return nil, errors.Errorf("unexpected envelope type: %v", env)
 	}
@@ -0,0 +1,1112 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conformance holds the tests that every implementation of the world
+// state database must pass. An implementation runs them from its own tests by
+// calling RunTests.
+package conformance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// OpenFunc opens the instance in the given directory, creating it if the
+// directory does not exist
+type OpenFunc func(t *testing.T, dir string) worldstate.TransferableDB
+
+// RunTests runs all the conformance tests against the instances opened by open
+func RunTests(t *testing.T, open OpenFunc) {
+	runTests(t, open, true)
+}
+
+// RunVolatileTests runs the conformance tests against the instances opened by
+// open, except for those that reopen an instance, for the implementations that
+// keep the state in memory only
+func RunVolatileTests(t *testing.T, open OpenFunc) {
+	runTests(t, open, false)
+}
+
+func runTests(t *testing.T, open OpenFunc, durable bool) {
+	tests := []struct {
+		name    string
+		test    func(t *testing.T, open OpenFunc)
+		durable bool
+	}{
+		{name: "open", test: testOpen},
+		{name: "open and reopen", test: testOpenAndReopen, durable: true},
+		{name: "valid db name", test: testValidDBName},
+		{name: "commit with db management", test: testCommitWithDBManagement},
+		{name: "list dbs and exist", test: testListDBsAndExist},
+		{name: "commit and query", test: testCommitAndQuery},
+		{name: "iterator", test: testIterator},
+		{name: "definitions of databases", test: testDefinitions},
+		{name: "get config", test: testGetConfig},
+		{name: "height", test: testHeight},
+		{name: "snapshots", test: testSnapshots},
+		{name: "checkpoint and replace", test: testCheckpointAndReplace},
+	}
+
+	for _, tt := range tests {
+		if tt.durable && !durable {
+			continue
+		}
+
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, open)
+		})
+	}
+}
+
+// newTestDir returns a path, which does not exist yet, in a new temporary directory
+// that is removed at the end of the test
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "worldstate-conformance")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove %s, %v", dir, err)
+		}
+	})
+
+	return filepath.Join(dir, "worldstate")
+}
+
+// newTestDB opens a new instance, which is closed at the end of the test
+func newTestDB(t *testing.T, open OpenFunc) worldstate.TransferableDB {
+	db := open(t, newTestDir(t))
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close the database instance, %v", err)
+		}
+	})
+
+	return db
+}
+
+func createDBs(t *testing.T, db worldstate.DB, blockNumber uint64, dbNames ...string) {
+	updates := &worldstate.DBUpdates{}
+	for _, dbName := range dbNames {
+		updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{Key: dbName})
+	}
+
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.DatabasesDBName: updates}, blockNumber))
+}
+
+func metadata(blockNum, txNum uint64) *types.Metadata {
+	return &types.Metadata{
+		Version: &types.Version{
+			BlockNum: blockNum,
+			TxNum:    txNum,
+		},
+	}
+}
+
+func testOpen(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+
+	for _, dbName := range append(worldstate.SystemDBs(), worldstate.DefaultDBName) {
+		require.True(t, db.Exist(dbName))
+	}
+	require.Empty(t, db.ListDBs())
+	height, err := db.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), height)
+}
+
+func testOpenAndReopen(t *testing.T, open OpenFunc) {
+	dir := newTestDir(t)
+
+	db := open(t, dir)
+	createDBs(t, db, 1, "db1")
+	require.NoError(t, db.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("value1"), Metadata: metadata(2, 1)},
+				},
+			},
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("db1-value1"), Metadata: metadata(2, 2)},
+				},
+			},
+		},
+		2,
+	))
+
+	// close and reopen the instance
+	require.NoError(t, db.Close())
+	db = open(t, dir)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	for _, dbName := range append(worldstate.SystemDBs(), worldstate.DefaultDBName, "db1") {
+		require.True(t, db.Exist(dbName))
+	}
+	require.Equal(t, []string{"db1"}, db.ListDBs())
+
+	height, err := db.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), height)
+
+	val, meta, err := db.Get(worldstate.DefaultDBName, "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+	require.True(t, proto.Equal(metadata(2, 1), meta))
+
+	val, meta, err = db.Get("db1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("db1-value1"), val)
+	require.True(t, proto.Equal(metadata(2, 2), meta))
+}
+
+func testValidDBName(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+
+	require.True(t, db.ValidDBName("db1DZ0-_."))
+	require.False(t, db.ValidDBName("/db1DZ0/-_."))
+	require.False(t, db.ValidDBName("$p"))
+	require.False(t, db.ValidDBName(""))
+	require.False(t, db.ValidDBName("_schema_db1"))
+	require.False(t, db.ValidDBName("_procedure_db1"))
+	require.False(t, db.ValidDBName("_quota_db1"))
+}
+
+func testCommitWithDBManagement(t *testing.T, open OpenFunc) {
+	tests := []struct {
+		name                   string
+		preCreateDBs           []string
+		updates                *worldstate.DBUpdates
+		expectedDBsAfterCommit []string
+		expectedIndex          map[string][]byte
+	}{
+		{
+			name: "only create DBs",
+			updates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1"},
+					{Key: "db2"},
+				},
+			},
+			expectedDBsAfterCommit: []string{"db1", "db2"},
+		},
+		{
+			name:         "only delete DBs",
+			preCreateDBs: []string{"db1", "db2"},
+			updates: &worldstate.DBUpdates{
+				Deletes: []string{"db1", "db2"},
+			},
+			expectedDBsAfterCommit: nil,
+		},
+		{
+			name:         "create and delete DBs",
+			preCreateDBs: []string{"db3", "db4"},
+			updates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1", Value: []byte("index-db1")},
+					{Key: "db2", Value: []byte("index-db2")},
+				},
+				Deletes: []string{"db3", "db4"},
+			},
+			expectedDBsAfterCommit: []string{"db1", "db2"},
+			expectedIndex: map[string][]byte{
+				"db1": []byte("index-db1"),
+				"db2": []byte("index-db2"),
+			},
+		},
+		{
+			name:         "create already existing DBs and delete non-existing DBs",
+			preCreateDBs: []string{"db1", "db3"},
+			updates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1", Value: []byte("index-db1")},
+					{Key: "db2", Value: []byte("index-db2")},
+				},
+				Deletes: []string{"db3", "db4"},
+			},
+			expectedDBsAfterCommit: []string{"db1", "db2"},
+			expectedIndex: map[string][]byte{
+				"db1": []byte("index-db1"),
+				"db2": []byte("index-db2"),
+			},
+		},
+		{
+			name: "definitions of databases do not create databases",
+			updates: &worldstate.DBUpdates{
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1"},
+					{Key: worldstate.SchemaKey("db1"), Value: []byte("schema")},
+					{Key: worldstate.ProcedureKey("db1", "proc"), Value: []byte("procedure")},
+					{Key: worldstate.QuotaKey("db1"), Value: []byte("quota")},
+				},
+			},
+			expectedDBsAfterCommit: []string{"db1"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDB(t, open)
+			if len(tt.preCreateDBs) > 0 {
+				createDBs(t, db, 1, tt.preCreateDBs...)
+				// a deleted database must not hold its data when it is created again
+				for _, dbName := range tt.preCreateDBs {
+					require.NoError(t, db.Commit(
+						map[string]*worldstate.DBUpdates{
+							dbName: {
+								Writes: []*worldstate.KVWithMetadata{
+									{Key: "key1", Value: []byte("value1")},
+								},
+							},
+						},
+						1,
+					))
+				}
+			}
+
+			require.NoError(
+				t,
+				db.Commit(
+					map[string]*worldstate.DBUpdates{
+						worldstate.DatabasesDBName: tt.updates,
+					},
+					2,
+				),
+			)
+
+			require.ElementsMatch(t, tt.expectedDBsAfterCommit, db.ListDBs())
+
+			for dbName, expectedIndex := range tt.expectedIndex {
+				index, _, err := db.GetIndexDefinition(dbName)
+				require.NoError(t, err)
+				require.Equal(t, expectedIndex, index)
+			}
+
+			for _, dbName := range tt.updates.Deletes {
+				require.False(t, db.Exist(dbName))
+				_, _, err := db.Get(dbName, "key1")
+				require.EqualError(t, err, "database "+dbName+" does not exist")
+
+				createDBs(t, db, 3, dbName)
+				val, _, err := db.Get(dbName, "key1")
+				require.NoError(t, err)
+				require.Nil(t, val)
+			}
+		})
+	}
+
+	t.Run("commit to a non-existing database", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+		err := db.Commit(
+			map[string]*worldstate.DBUpdates{
+				"db1": {
+					Writes: []*worldstate.KVWithMetadata{
+						{Key: "key1", Value: []byte("value1")},
+					},
+				},
+			},
+			1,
+		)
+		require.EqualError(t, err, "database db1 does not exist")
+	})
+}
+
+func testListDBsAndExist(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+
+	dbs := []string{"db1", "db2", "db3"}
+	createDBs(t, db, 1, dbs...)
+	require.ElementsMatch(t, dbs, db.ListDBs())
+
+	for _, dbName := range append(worldstate.SystemDBs(), worldstate.DefaultDBName, "db1", "db2", "db3") {
+		require.True(t, db.Exist(dbName))
+	}
+
+	for _, dbName := range []string{"no-db1", "no-db2", "no-db3"} {
+		require.False(t, db.Exist(dbName))
+	}
+}
+
+func setupWithData(t *testing.T, db worldstate.DB) (map[string]*types.ValueWithMetadata, map[string]*types.ValueWithMetadata) {
+	db1KVs := map[string]*types.ValueWithMetadata{
+		"db1-key1": {
+			Value: []byte("db1-value1"),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 2,
+					TxNum:    1,
+				},
+				AccessControl: &types.AccessControl{
+					ReadUsers: map[string]bool{
+						"user1": true,
+					},
+					ReadWriteUsers: map[string]bool{
+						"user2": true,
+					},
+				},
+			},
+		},
+		"db1-key2": {
+			Value:    []byte("db1-value2"),
+			Metadata: metadata(2, 2),
+		},
+	}
+	db2KVs := map[string]*types.ValueWithMetadata{
+		"db2-key1": {
+			Value: []byte("db2-value1"),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 2,
+					TxNum:    3,
+				},
+				AccessControl: &types.AccessControl{
+					ReadUsers: map[string]bool{
+						"user1": true,
+					},
+				},
+			},
+		},
+		"db2-key2": {
+			Value:    []byte("db2-value2"),
+			Metadata: metadata(2, 4),
+		},
+	}
+
+	dbsUpdates := make(map[string]*worldstate.DBUpdates)
+	for dbName, kvs := range map[string]map[string]*types.ValueWithMetadata{"db1": db1KVs, "db2": db2KVs} {
+		updates := &worldstate.DBUpdates{}
+		for key, valAndMetadata := range kvs {
+			updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+				Key:      key,
+				Value:    valAndMetadata.Value,
+				Metadata: valAndMetadata.Metadata,
+			})
+		}
+		dbsUpdates[dbName] = updates
+	}
+
+	createDBs(t, db, 1, "db1", "db2")
+	require.NoError(t, db.Commit(dbsUpdates, 2))
+
+	return db1KVs, db2KVs
+}
+
+func requireKVs(t *testing.T, db worldstate.DB, dbName string, expected map[string]*types.ValueWithMetadata) {
+	for key, expectedValAndMetadata := range expected {
+		val, meta, err := db.Get(dbName, key)
+		require.NoError(t, err)
+		require.Equal(t, expectedValAndMetadata.GetValue(), val)
+		require.True(t, proto.Equal(expectedValAndMetadata.GetMetadata(), meta))
+
+		ver, err := db.GetVersion(dbName, key)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expectedValAndMetadata.GetMetadata().GetVersion(), ver))
+
+		acl, err := db.GetACL(dbName, key)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expectedValAndMetadata.GetMetadata().GetAccessControl(), acl))
+
+		exist, err := db.Has(dbName, key)
+		require.NoError(t, err)
+		require.Equal(t, expectedValAndMetadata != nil, exist)
+	}
+}
+
+func testCommitAndQuery(t *testing.T, open OpenFunc) {
+	t.Run("Get(), GetVersion(), GetACL(), and Has() on empty databases", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+		createDBs(t, db, 1, "db1", "db2")
+
+		for _, dbName := range []string{"db1", "db2"} {
+			requireKVs(t, db, dbName, map[string]*types.ValueWithMetadata{
+				dbName + "-key1": nil,
+				dbName + "-key2": nil,
+			})
+		}
+	})
+
+	t.Run("Get(), GetVersion(), GetACL(), and Has() on non-empty databases", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+		db1KVs, db2KVs := setupWithData(t, db)
+
+		requireKVs(t, db, "db1", db1KVs)
+		requireKVs(t, db, "db2", db2KVs)
+
+		// the same key in another database
+		val, meta, err := db.Get("db2", "db1-key1")
+		require.NoError(t, err)
+		require.Nil(t, val)
+		require.Nil(t, meta)
+	})
+
+	t.Run("Get() on a non-existing database", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+		val, meta, err := db.Get("db3", "key1")
+		require.EqualError(t, err, "database db3 does not exist")
+		require.Nil(t, val)
+		require.Nil(t, meta)
+	})
+
+	t.Run("Commit() and Get() on non-empty databases", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+		db1KVs, db2KVs := setupWithData(t, db)
+
+		db1valAndMetadata1New := &types.ValueWithMetadata{
+			Value: []byte("db1-value1-new"),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 3,
+					TxNum:    1,
+				},
+				AccessControl: &types.AccessControl{
+					ReadUsers: map[string]bool{
+						"user3": true,
+					},
+					ReadWriteUsers: map[string]bool{
+						"user4": true,
+					},
+				},
+			},
+		}
+		db2valAndMetadata1New := &types.ValueWithMetadata{
+			Value:    []byte("db2-value1-new"),
+			Metadata: metadata(3, 2),
+		}
+		dbsUpdates := map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      "db1-key1",
+						Value:    db1valAndMetadata1New.Value,
+						Metadata: db1valAndMetadata1New.Metadata,
+					},
+				},
+				Deletes: []string{"db1-key2"},
+			},
+			"db2": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      "db2-key1",
+						Value:    db2valAndMetadata1New.Value,
+						Metadata: db2valAndMetadata1New.Metadata,
+					},
+				},
+				Deletes: []string{"db2-key2"},
+			},
+		}
+		require.NoError(t, db.Commit(dbsUpdates, 3))
+
+		db1KVs["db1-key1"] = db1valAndMetadata1New
+		db1KVs["db1-key2"] = nil
+		requireKVs(t, db, "db1", db1KVs)
+
+		db2KVs["db2-key1"] = db2valAndMetadata1New
+		db2KVs["db2-key2"] = nil
+		requireKVs(t, db, "db2", db2KVs)
+	})
+}
+
+func iterate(t *testing.T, itr worldstate.Iterator) map[string]*types.ValueWithMetadata {
+	kvs := make(map[string]*types.ValueWithMetadata)
+	for itr.Next() {
+		v := &types.ValueWithMetadata{}
+		require.NoError(t, proto.Unmarshal(itr.Value(), v))
+		kvs[string(itr.Key())] = v
+	}
+	require.NoError(t, itr.Error())
+
+	return kvs
+}
+
+func requireEqualKVs(t *testing.T, expected, actual map[string]*types.ValueWithMetadata) {
+	require.Equal(t, len(expected), len(actual))
+	for expectedKey, expectedValueAndMeta := range expected {
+		valueAndMeta, ok := actual[expectedKey]
+		require.True(t, ok)
+		require.True(t, proto.Equal(expectedValueAndMeta, valueAndMeta))
+	}
+}
+
+func testIterator(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+	db1KVs, db2KVs := setupWithData(t, db)
+
+	tests := []struct {
+		name                 string
+		dbName               string
+		startKey             string
+		endKey               string
+		expectedValueAndMeta map[string]*types.ValueWithMetadata
+	}{
+		{
+			name:     "end key is exclusive and both keys exist",
+			dbName:   "db1",
+			startKey: "db1-key1",
+			endKey:   "db1-key2",
+			expectedValueAndMeta: map[string]*types.ValueWithMetadata{
+				"db1-key1": db1KVs["db1-key1"],
+			},
+		},
+		{
+			name:                 "end key does not exist",
+			dbName:               "db1",
+			startKey:             "db1-key1",
+			endKey:               "db1-key3",
+			expectedValueAndMeta: db1KVs,
+		},
+		{
+			name:                 "start key does not exist",
+			dbName:               "db1",
+			startKey:             "db1-key0",
+			endKey:               "db1-key3",
+			expectedValueAndMeta: db1KVs,
+		},
+		{
+			name:                 "start key is beyond the range",
+			dbName:               "db1",
+			startKey:             "db1-key3",
+			endKey:               "",
+			expectedValueAndMeta: nil,
+		},
+		{
+			name:                 "end key is before the beginning",
+			dbName:               "db1",
+			startKey:             "",
+			endKey:               "db1-key0",
+			expectedValueAndMeta: nil,
+		},
+		{
+			name:                 "[begin:]",
+			dbName:               "db2",
+			startKey:             "db2-key1",
+			endKey:               "",
+			expectedValueAndMeta: db2KVs,
+		},
+		{
+			name:     "[:end]",
+			dbName:   "db2",
+			startKey: "",
+			endKey:   "db2-key2",
+			expectedValueAndMeta: map[string]*types.ValueWithMetadata{
+				"db2-key1": db2KVs["db2-key1"],
+			},
+		},
+		{
+			name:                 "[:]",
+			dbName:               "db2",
+			startKey:             "",
+			endKey:               "",
+			expectedValueAndMeta: db2KVs,
+		},
+		{
+			name:                 "empty database",
+			dbName:               worldstate.DefaultDBName,
+			startKey:             "",
+			endKey:               "",
+			expectedValueAndMeta: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itr, err := db.GetIterator(tt.dbName, tt.startKey, tt.endKey)
+			require.NoError(t, err)
+			defer itr.Release()
+
+			requireEqualKVs(t, tt.expectedValueAndMeta, iterate(t, itr))
+			require.Nil(t, itr.Key())
+			require.Nil(t, itr.Value())
+		})
+	}
+
+	t.Run("non-existing database", func(t *testing.T) {
+		itr, err := db.GetIterator("db3", "", "")
+		require.Nil(t, itr)
+		require.EqualError(t, err, "database db3 does not exist")
+	})
+
+	t.Run("keys in order", func(t *testing.T) {
+		itr, err := db.GetIterator("db1", "", "")
+		require.NoError(t, err)
+		defer itr.Release()
+
+		require.Nil(t, itr.Key())
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("db1-key1"), itr.Key())
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("db1-key2"), itr.Key())
+		require.False(t, itr.Next())
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+	})
+
+	t.Run("released iterator", func(t *testing.T) {
+		itr, err := db.GetIterator("db2", "", "")
+		require.NoError(t, err)
+		itr.Release()
+		require.False(t, itr.Next())
+		require.Error(t, itr.Error())
+		itr.Release()
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		itr, err := db.GetIterator("db2", "", "")
+		require.NoError(t, err)
+		defer itr.Release()
+
+		// let's skip "db2-key1" by seeking to "db2-key10".
+		// As the next greater key to "db2-key10" is "db2-key2",
+		// seek would return true
+		require.True(t, itr.Seek([]byte("db2-key10")))
+		require.Equal(t, []byte("db2-key2"), itr.Key())
+		require.False(t, itr.Next())
+
+		// seeking backwards is allowed
+		require.True(t, itr.Seek([]byte("db2-key1")))
+		require.Equal(t, []byte("db2-key1"), itr.Key())
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("db2-key2"), itr.Key())
+
+		// let's skip to the end. As there is no key equal to or
+		// greater than "db2-key3", seek would return a false
+		require.False(t, itr.Seek([]byte("db2-key3")))
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+	})
+
+	t.Run("seek within the range", func(t *testing.T) {
+		itr, err := db.GetIterator("db1", "db1-key1", "db1-key2")
+		require.NoError(t, err)
+		defer itr.Release()
+
+		// seeking before the start key positions the iterator at the start key
+		require.True(t, itr.Seek([]byte("db1-key0")))
+		require.Equal(t, []byte("db1-key1"), itr.Key())
+
+		// the end key is exclusive
+		require.False(t, itr.Seek([]byte("db1-key2")))
+	})
+}
+
+func testDefinitions(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+
+	require.NoError(t, db.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db1", Value: []byte("index"), Metadata: metadata(1, 1)},
+					{Key: worldstate.SchemaKey("db1"), Value: []byte("schema"), Metadata: metadata(1, 2)},
+					{Key: worldstate.ProcedureKey("db1", "proc"), Value: []byte("procedure"), Metadata: metadata(1, 3)},
+				},
+			},
+		},
+		1,
+	))
+
+	index, meta, err := db.GetIndexDefinition("db1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("index"), index)
+	require.True(t, proto.Equal(metadata(1, 1), meta))
+
+	schema, meta, err := db.GetSchemaDefinition("db1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("schema"), schema)
+	require.True(t, proto.Equal(metadata(1, 2), meta))
+
+	procedure, meta, err := db.GetProcedure("db1", "proc")
+	require.NoError(t, err)
+	require.Equal(t, []byte("procedure"), procedure)
+	require.True(t, proto.Equal(metadata(1, 3), meta))
+
+	procedure, meta, err = db.GetProcedure("db1", "no-proc")
+	require.NoError(t, err)
+	require.Nil(t, procedure)
+	require.Nil(t, meta)
+
+	schema, meta, err = db.GetSchemaDefinition(worldstate.DefaultDBName)
+	require.NoError(t, err)
+	require.Nil(t, schema)
+	require.Nil(t, meta)
+}
+
+func testGetConfig(t *testing.T, open OpenFunc) {
+	t.Run("commit and query cluster config", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+
+		clusterConfig := &types.ClusterConfig{
+			Nodes: []*types.NodeConfig{
+				{
+					Id:          "node1",
+					Address:     "127.0.0.1",
+					Port:        1234,
+					Certificate: []byte("cert"),
+				},
+			},
+			Admins: []*types.Admin{
+				{
+					Id:          "admin",
+					Certificate: []byte("cert"),
+				},
+			},
+			CertAuthConfig: &types.CAConfig{
+				Roots: [][]byte{[]byte("cert")},
+			},
+		}
+		config, err := proto.Marshal(clusterConfig)
+		require.NoError(t, err)
+
+		dbUpdates := map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      worldstate.ConfigKey,
+						Value:    config,
+						Metadata: metadata(1, 5),
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(dbUpdates, 1))
+
+		actualConfig, actualMetadata, err := db.GetConfig()
+		require.NoError(t, err)
+		require.True(t, proto.Equal(clusterConfig, actualConfig))
+		require.True(t, proto.Equal(metadata(1, 5), actualMetadata))
+	})
+
+	t.Run("querying config returns error", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t, open)
+
+		dbUpdates := map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      worldstate.ConfigKey,
+						Value:    []byte("config"),
+						Metadata: metadata(1, 5),
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(dbUpdates, 1))
+
+		actualConfig, actualMetadata, err := db.GetConfig()
+		require.Contains(t, err.Error(), "error while unmarshaling committed cluster configuration")
+		require.Nil(t, actualConfig)
+		require.Nil(t, actualMetadata)
+	})
+}
+
+func testHeight(t *testing.T, open OpenFunc) {
+	tests := []struct {
+		name           string
+		blockNumber    uint64
+		dbsUpdates     map[string]*worldstate.DBUpdates
+		expectedHeight uint64
+	}{
+		{
+			name:           "block 1 with empty updates",
+			blockNumber:    1,
+			dbsUpdates:     nil,
+			expectedHeight: 1,
+		},
+		{
+			name:        "block 10 with non-empty updates",
+			blockNumber: 10,
+			dbsUpdates: map[string]*worldstate.DBUpdates{
+				worldstate.DefaultDBName: {
+					Deletes: []string{"key1"},
+				},
+			},
+			expectedHeight: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDB(t, open)
+
+			height, err := db.Height()
+			require.NoError(t, err)
+			require.Equal(t, uint64(0), height)
+
+			require.NoError(t, db.Commit(tt.dbsUpdates, tt.blockNumber))
+
+			height, err = db.Height()
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedHeight, height)
+
+			require.NoError(t, db.Commit(nil, tt.blockNumber+1))
+
+			height, err = db.Height()
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedHeight+1, height)
+		})
+	}
+}
+
+type snapshotTestAPIs interface {
+	Get(dbName, key string) ([]byte, *types.Metadata, error)
+	GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error)
+	GetIterator(dbName, startKey, endKey string) (worldstate.Iterator, error)
+}
+
+func verifyEmptiness(t *testing.T, db snapshotTestAPIs) {
+	v, m, err := db.Get("db1", "key1")
+	require.NoError(t, err)
+	require.Nil(t, v)
+	require.Nil(t, m)
+
+	v, m, err = db.GetIndexDefinition("db2")
+	require.NoError(t, err)
+	require.Nil(t, v)
+	require.Nil(t, m)
+
+	itr, err := db.GetIterator("db1", "key1", "key4")
+	require.NoError(t, err)
+	defer itr.Release()
+	require.False(t, itr.Next())
+	require.NoError(t, itr.Error())
+}
+
+func verifyNonEmptiness(t *testing.T, db snapshotTestAPIs) {
+	v, m, err := db.Get("db1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), v)
+	require.True(t, proto.Equal(metadata(2, 1), m))
+
+	v, m, err = db.GetIndexDefinition("db2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("index def"), v)
+	require.True(t, proto.Equal(metadata(2, 1), m))
+
+	itr, err := db.GetIterator("db1", "key1", "key4")
+	require.NoError(t, err)
+	defer itr.Release()
+
+	keys := []string{}
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	require.NoError(t, itr.Error())
+	require.Equal(t, []string{"key1", "key2", "key3"}, keys)
+}
+
+func testSnapshots(t *testing.T, open OpenFunc) {
+	db := newTestDB(t, open)
+	createDBs(t, db, 1, "db1")
+
+	dbNames := []string{"db1", worldstate.DatabasesDBName, worldstate.MetadataDBName}
+	s0, err := db.GetDBsSnapshot(dbNames)
+	require.NoError(t, err)
+	defer s0.Release()
+	requireSnapshotHeight(t, s0, 1)
+
+	// as db is empty, both snapshot and real db should
+	// return no kv pairs
+	verifyEmptiness(t, db)
+	verifyEmptiness(t, s0)
+
+	// write some kv pairs to the real db. As a result,
+	// the real db should return some kv pairs while the
+	// snapshot should not
+	require.NoError(t, db.Commit(
+		map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("value1"), Metadata: metadata(2, 1)},
+					{Key: "key2", Value: []byte("value2"), Metadata: metadata(2, 1)},
+					{Key: "key3", Value: []byte("value3"), Metadata: metadata(2, 1)},
+				},
+			},
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "db2", Value: []byte("index def"), Metadata: metadata(2, 1)},
+				},
+			},
+		},
+		2,
+	))
+
+	verifyNonEmptiness(t, db)
+	verifyEmptiness(t, s0)
+
+	// create a new snapshot on db which is not empty.
+	// as a result, the new snapshot s1 should return
+	// some kv pairs while the old snapshot s0 should
+	// not return any kv pairs
+	s1, err := db.GetDBsSnapshot(dbNames)
+	require.NoError(t, err)
+	defer s1.Release()
+
+	verifyNonEmptiness(t, s1)
+	verifyEmptiness(t, s0)
+	requireSnapshotHeight(t, s1, 2)
+	requireSnapshotHeight(t, s0, 1)
+
+	// an iterator of the snapshot remains valid after
+	// the snapshot is released
+	s, err := db.GetDBsSnapshot(dbNames)
+	require.NoError(t, err)
+	itr, err := s.GetIterator("db1", "", "")
+	require.NoError(t, err)
+	defer itr.Release()
+	s.Release()
+
+	// remove all kv pairs from the real db. As a result,
+	// the real db should not return any kv pairs while
+	// the snapshot s1 should return the deleted kv pairs
+	require.NoError(t, db.Commit(
+		map[string]*worldstate.DBUpdates{
+			"db1": {
+				Deletes: []string{"key1", "key2", "key3"},
+			},
+			worldstate.DatabasesDBName: {
+				Deletes: []string{"db2"},
+			},
+		},
+		3,
+	))
+
+	verifyEmptiness(t, db)
+	verifyNonEmptiness(t, s1)
+	verifyEmptiness(t, s0)
+	require.Len(t, iterate(t, itr), 3)
+
+	// acquire a new snapshot s2. The snapshot s2 should
+	// not return any kv pairs while s1 should
+	s2, err := db.GetDBsSnapshot(dbNames)
+	require.NoError(t, err)
+	defer s2.Release()
+
+	verifyEmptiness(t, s2)
+	verifyNonEmptiness(t, s1)
+	verifyEmptiness(t, s0)
+	requireSnapshotHeight(t, s2, 3)
+	requireSnapshotHeight(t, s1, 2)
+	requireSnapshotHeight(t, s0, 1)
+
+	// only the given databases are snapshotted
+	_, _, err = s2.Get(worldstate.DefaultDBName, "key1")
+	require.Error(t, err)
+	_, err = s2.GetIterator(worldstate.DefaultDBName, "", "")
+	require.Error(t, err)
+
+	// the height needs the metadata database to be snapshotted
+	s3, err := db.GetDBsSnapshot([]string{"db1"})
+	require.NoError(t, err)
+	defer s3.Release()
+	_, err = s3.Height()
+	require.EqualError(t, err, "_metadata is needed to fetch the height and is not snapshotted")
+
+	_, err = db.GetDBsSnapshot([]string{"db3"})
+	require.EqualError(t, err, "database db3 does not exist")
+}
+
+func requireSnapshotHeight(t *testing.T, s worldstate.DBsSnapshot, expected uint64) {
+	height, err := s.Height()
+	require.NoError(t, err)
+	require.Equal(t, expected, height)
+}
+
+func testCheckpointAndReplace(t *testing.T, open OpenFunc) {
+	source := newTestDB(t, open)
+	db1KVs, db2KVs := setupWithData(t, source)
+
+	checkpoint, err := source.Checkpoint()
+	require.NoError(t, err)
+	defer checkpoint.Release()
+
+	// the checkpoint is not affected by the later commits
+	require.NoError(t, source.Commit(
+		map[string]*worldstate.DBUpdates{
+			"db1": {
+				Deletes: []string{"db1-key1"},
+			},
+		},
+		3,
+	))
+
+	expectedDBNames := append(worldstate.SystemDBs(), worldstate.DefaultDBName, "db1", "db2")
+	require.ElementsMatch(t, expectedDBNames, checkpoint.DBNames())
+	require.IsIncreasing(t, checkpoint.DBNames())
+
+	// transfer the checkpoint to an instance in another directory
+	target := newTestDB(t, open)
+	stagedDir := filepath.Join(filepath.Dir(newTestDir(t)), "staged")
+	w, err := target.NewCheckpointWriter(stagedDir)
+	require.NoError(t, err)
+
+	_, err = target.NewCheckpointWriter(stagedDir)
+	require.EqualError(t, err, "the directory ["+stagedDir+"] already exists")
+
+	for _, dbName := range checkpoint.DBNames() {
+		require.NoError(t, w.CreateDB(dbName))
+	}
+	var lastDBName string
+	pairs := 0
+	require.NoError(t, checkpoint.ForEach(func(dbName string, key, value []byte) error {
+		require.True(t, lastDBName <= dbName)
+		lastDBName = dbName
+		pairs++
+		return w.Put(dbName, key, value)
+	}))
+	// four user pairs, the block height, and the index definitions of db1 and db2
+	require.Equal(t, 7, pairs)
+	require.Error(t, w.CreateDB("$p"))
+	require.NoError(t, w.Close())
+
+	staged, err := target.OpenCheckpoint(stagedDir)
+	require.NoError(t, err)
+	requireKVs(t, staged, "db1", db1KVs)
+	requireKVs(t, staged, "db2", db2KVs)
+	height, err := staged.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), height)
+	require.NoError(t, staged.Close())
+
+	// replace the target with the staged instance
+	require.NoError(t, target.Replace(stagedDir))
+	requireKVs(t, target, "db1", db1KVs)
+	requireKVs(t, target, "db2", db2KVs)
+	require.ElementsMatch(t, []string{"db1", "db2"}, target.ListDBs())
+	height, err = target.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), height)
+
+	// the replaced instance is usable
+	createDBs(t, target, 3, "db3")
+	require.True(t, target.Exist("db3"))
+}
@@ -3,6 +3,8 @@
 package worldstate
 
 import (
+	"strings"
+
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
@@ -17,6 +19,12 @@ const (
 	// MetadataDBName holds the name of the database that holds
 	// the metadata about the worldstate database
 	MetadataDBName = "_metadata"
+	// QuotasDBName holds the name of the database that holds
+	// the quota usage of databases and users
+	QuotasDBName = "_quotas"
+	// ExpiryDBName holds the name of the database that holds
+	// the index of keys by their expiry
+	ExpiryDBName = "_expiry"
 	// DefaultDBName is the default database created during
 	// node bootstrap
 	DefaultDBName = "bdb"
@@ -26,6 +34,18 @@ const (
 	// AllowedCharsInDBName holds the regexp for allowed characters
 	// in a database name
 	AllowedCharsInDBName = `^[0-9a-zA-Z_-.]+$`
+	// schemaKeyPrefix is the prefix added to each user database name to
+	// construct the key under which the JSON schema of that database is
+	// stored in the DatabasesDBName
+	schemaKeyPrefix = "_schema_"
+	// procedureKeyPrefix is the prefix added to each user database name to
+	// construct the keys under which the stored procedures of that database
+	// are stored in the DatabasesDBName
+	procedureKeyPrefix = "_procedure_"
+	// quotaKeyPrefix is the prefix added to each user database name to
+	// construct the key under which the quota of that database is stored
+	// in the DatabasesDBName
+	quotaKeyPrefix = "_quota_"
 )
 
 // DB provides method to create and access states stored in
@@ -51,6 +71,11 @@ type DB interface {
 	GetConfig() (*types.ClusterConfig, *types.Metadata, error)
 	// GetIndexDefinition returns the index definition of a given database
 	GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error)
+	// GetSchemaDefinition returns the JSON schema defined on a given database
+	GetSchemaDefinition(dbName string) ([]byte, *types.Metadata, error)
+	// GetProcedure returns the source code of a stored procedure registered
+	// on a given database
+	GetProcedure(dbName, procedureName string) ([]byte, *types.Metadata, error)
 	// GetIterator returns an iterator to fetch values associated with a range of keys
 	// startKey is inclusive while the endKey is exclusive. An empty startKey (i.e., "") denotes that
 	// the caller wants from the first key in the database (lexicographic order). An empty
@@ -72,6 +97,52 @@ type DB interface {
 	Close() error
 }
 
+// TransferableDB is a DB whose whole state can be checkpointed and
+// transferred to another node, where it replaces the local state
+type TransferableDB interface {
+	DB
+	// Checkpoint returns a checkpoint of all the databases. The caller must
+	// make sure that no block is being committed while the checkpoint is taken.
+	// The checkpoint must be released after use.
+	Checkpoint() (Checkpoint, error)
+	// NewCheckpointWriter creates a new instance of the same kind in the given
+	// directory, which must not exist, and returns a writer that fills it with
+	// the key-value pairs of a checkpoint
+	NewCheckpointWriter(dir string) (CheckpointWriter, error)
+	// OpenCheckpoint opens the instance created by a CheckpointWriter in the
+	// given directory. It must be closed after use.
+	OpenCheckpoint(dir string) (TransferableDB, error)
+	// Replace replaces all the databases with the instance created by a
+	// CheckpointWriter in the given directory
+	Replace(dir string) error
+}
+
+// Checkpoint holds a consistent view of the raw key-value pairs of all the
+// databases, including the system databases and the index databases
+type Checkpoint interface {
+	// DBNames returns the names of all the databases of the checkpoint
+	// in sorted order
+	DBNames() []string
+	// ForEach calls f with each key-value pair of the checkpoint, database by
+	// database in the order of their names. The value is the raw, marshaled
+	// types.ValueWithMetadata. The slices passed to f must not be retained.
+	ForEach(f func(dbName string, key, value []byte) error) error
+	// Release releases the checkpoint
+	Release()
+}
+
+// CheckpointWriter fills a new instance with the key-value pairs of a
+// checkpoint
+type CheckpointWriter interface {
+	// CreateDB creates the given database if it does not exist
+	CreateDB(dbName string) error
+	// Put writes a raw key-value pair to the given database, creating the
+	// database if it does not exist
+	Put(dbName string, key, value []byte) error
+	// Close writes the remaining pairs and closes the new instance
+	Close() error
+}
+
 // DBsSnapshot provides methods to read from a database snapshot
 type DBsSnapshot interface {
 	// Get returns the value of the key present in the
@@ -84,6 +155,10 @@ type DBsSnapshot interface {
 	// the caller wants from the first key in the database (lexicographic order). An empty
 	// endKey (i.e., "") denotes that the caller wants till the last key in the database (lexicographic order).
 	GetIterator(dbName string, startKey, endKey string) (Iterator, error)
+	// Height returns the block height of the snapshot. The metadata database
+	// must be snapshotted. The other snapshotted databases reflect at least
+	// all the blocks up to the returned height.
+	Height() (uint64, error)
 	// Release releases the snapshot. This will not release any returned
 	// iterators, the iterators would still be valid until released or the
 	// underlying DB is closed.
@@ -138,7 +213,9 @@ func IsSystemDB(dbName string) bool {
 	return dbName == UsersDBName ||
 		dbName == DatabasesDBName ||
 		dbName == ConfigDBName ||
-		dbName == MetadataDBName
+		dbName == MetadataDBName ||
+		dbName == QuotasDBName ||
+		dbName == ExpiryDBName
 }
 
 // IsDefaultWorldStateDB returns true if the given db is the default
@@ -147,6 +224,64 @@ func IsDefaultWorldStateDB(dbName string) bool {
 	return dbName == DefaultDBName
 }
 
+// SchemaKey returns the key under which the JSON schema of the
+// given database is stored in the DatabasesDBName
+func SchemaKey(dbName string) string {
+	return schemaKeyPrefix + dbName
+}
+
+// IsSchemaKey returns true if the given key of the DatabasesDBName
+// holds a JSON schema rather than the index definition of a database
+func IsSchemaKey(key string) bool {
+	return strings.HasPrefix(key, schemaKeyPrefix)
+}
+
+// ProcedureKey returns the key under which the source code of the given
+// stored procedure of the given database is stored in the DatabasesDBName
+func ProcedureKey(dbName, procedureName string) string {
+	return procedureKeyPrefix + dbName + "~" + procedureName
+}
+
+// ProcedureKeyRange returns the start key (inclusive) and the end key
+// (exclusive) of all stored procedures of the given database
+func ProcedureKeyRange(dbName string) (string, string) {
+	// '\x7f' is the successor of the separator '~'
+	return procedureKeyPrefix + dbName + "~", procedureKeyPrefix + dbName + "\x7f"
+}
+
+// IsProcedureKey returns true if the given key of the DatabasesDBName
+// holds a stored procedure rather than the index definition of a database
+func IsProcedureKey(key string) bool {
+	return strings.HasPrefix(key, procedureKeyPrefix)
+}
+
+// QuotaKey returns the key under which the quota of the given
+// database is stored in the DatabasesDBName
+func QuotaKey(dbName string) string {
+	return quotaKeyPrefix + dbName
+}
+
+// IsQuotaKey returns true if the given key of the DatabasesDBName
+// holds a quota rather than the index definition of a database
+func IsQuotaKey(key string) bool {
+	return strings.HasPrefix(key, quotaKeyPrefix)
+}
+
+// IsDatabaseKey returns true if the given key of the DatabasesDBName
+// denotes a database rather than a definition attached to a database,
+// such as its schema, stored procedures, or quota
+func IsDatabaseKey(key string) bool {
+	return !IsSchemaKey(key) && !IsProcedureKey(key) && !IsQuotaKey(key)
+}
+
+// IsReservedDBName returns true if the given name starts with one of the
+// prefixes of the keys under which the definitions attached to a database,
+// such as its schema, stored procedures, or quota, are stored in the
+// DatabasesDBName. Such a name cannot denote a database.
+func IsReservedDBName(dbName string) bool {
+	return !IsDatabaseKey(dbName)
+}
+
 // SystemDBs returns the name of all system databases
 func SystemDBs() []string {
 	return []string{
@@ -154,5 +289,7 @@ func SystemDBs() []string {
 		DatabasesDBName,
 		ConfigDBName,
 		MetadataDBName,
+		QuotasDBName,
+		ExpiryDBName,
 	}
 }
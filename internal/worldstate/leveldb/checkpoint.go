@@ -0,0 +1,222 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package leveldb
+
+import (
+	"os"
+	"sort"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// checkpointBatchSize is the number of pairs written to a database in a single batch by the CheckpointWriter
+const checkpointBatchSize = 1000
+
+// Checkpoint holds a consistent view of the raw key-value pairs of all the databases, including the system
+// databases and the index databases. It is used to transfer the whole state to another node.
+type Checkpoint struct {
+	dbSnap map[string]*leveldb.Snapshot
+}
+
+// Checkpoint returns a checkpoint of all the databases. The caller must make sure that no block is being committed
+// while the checkpoint is taken. The checkpoint must be released after use.
+func (l *LevelDB) Checkpoint() (worldstate.Checkpoint, error) {
+	l.dbsList.RLock()
+	defer l.dbsList.RUnlock()
+
+	c := &Checkpoint{
+		dbSnap: make(map[string]*leveldb.Snapshot),
+	}
+
+	for dbName, db := range l.dbs {
+		db.mu.RLock()
+		s, err := db.file.GetSnapshot()
+		db.mu.RUnlock()
+		if err != nil {
+			c.Release()
+			return nil, errors.Wrapf(err, "error while taking a snapshot of database [%s]", dbName)
+		}
+
+		c.dbSnap[dbName] = s
+	}
+
+	return c, nil
+}
+
+// DBNames returns the names of all the databases of the checkpoint in sorted order
+func (c *Checkpoint) DBNames() []string {
+	var dbNames []string
+	for dbName := range c.dbSnap {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	return dbNames
+}
+
+// ForEach calls f with each key-value pair of the checkpoint, database by database in the order of their names.
+// The value is the raw, marshaled types.ValueWithMetadata. The slices passed to f must not be retained.
+func (c *Checkpoint) ForEach(f func(dbName string, key, value []byte) error) error {
+	for _, dbName := range c.DBNames() {
+		itr := c.dbSnap[dbName].NewIterator(nil, &opt.ReadOptions{})
+		for itr.Next() {
+			if err := f(dbName, itr.Key(), itr.Value()); err != nil {
+				itr.Release()
+				return err
+			}
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			return errors.Wrapf(err, "error while iterating over the snapshot of database [%s]", dbName)
+		}
+	}
+
+	return nil
+}
+
+// Release releases the checkpoint
+func (c *Checkpoint) Release() {
+	for _, s := range c.dbSnap {
+		s.Release()
+	}
+	c.dbSnap = nil
+}
+
+// CheckpointWriter creates a new instance in a given directory from the key-value pairs of a checkpoint
+type CheckpointWriter struct {
+	l       *LevelDB
+	batches map[string]*leveldb.Batch
+}
+
+// NewCheckpointWriter creates a new instance in the given directory, which must not exist, and returns a writer that
+// fills it with the key-value pairs of a checkpoint.
+func NewCheckpointWriter(dir string, logger *logger.SugarLogger) (*CheckpointWriter, error) {
+	exist, err := fileops.Exists(dir)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Errorf("the directory [%s] already exists", dir)
+	}
+
+	l, err := openNewLevelDBInstance(&Config{DBRootDir: dir, Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckpointWriter{
+		l:       l,
+		batches: make(map[string]*leveldb.Batch),
+	}, nil
+}
+
+// CreateDB creates the given database if it does not exist
+func (w *CheckpointWriter) CreateDB(dbName string) error {
+	if _, ok := w.batches[dbName]; ok {
+		return nil
+	}
+
+	if !w.l.ValidDBName(dbName) {
+		return errors.Errorf("invalid database name [%s]", dbName)
+	}
+	if err := w.l.create(dbName); err != nil {
+		return err
+	}
+	w.batches[dbName] = &leveldb.Batch{}
+
+	return nil
+}
+
+// Put writes a raw key-value pair to the given database, creating the database if it does not exist
+func (w *CheckpointWriter) Put(dbName string, key, value []byte) error {
+	if err := w.CreateDB(dbName); err != nil {
+		return err
+	}
+
+	batch := w.batches[dbName]
+	batch.Put(key, value)
+	if batch.Len() < checkpointBatchSize {
+		return nil
+	}
+	return w.flush(dbName)
+}
+
+func (w *CheckpointWriter) flush(dbName string) error {
+	batch := w.batches[dbName]
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	if err := w.l.dbs[dbName].file.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while writing to database [%s]", dbName)
+	}
+	batch.Reset()
+	return nil
+}
+
+// Close writes the remaining pairs and closes the new instance
+func (w *CheckpointWriter) Close() error {
+	for dbName := range w.batches {
+		if err := w.flush(dbName); err != nil {
+			return err
+		}
+	}
+
+	return w.l.Close()
+}
+
+// NewCheckpointWriter creates a new instance in the given directory, which must not exist, and returns a writer that
+// fills it with the key-value pairs of a checkpoint.
+func (l *LevelDB) NewCheckpointWriter(dir string) (worldstate.CheckpointWriter, error) {
+	w, err := NewCheckpointWriter(dir, l.logger)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenCheckpoint opens the instance in the given directory, which was created by a CheckpointWriter
+func (l *LevelDB) OpenCheckpoint(dir string) (worldstate.TransferableDB, error) {
+	staged, err := Open(&Config{DBRootDir: dir, Logger: l.logger})
+	if err != nil {
+		return nil, err
+	}
+	return staged, nil
+}
+
+// Replace replaces all the databases with the instance in the given directory, which was created by a
+// CheckpointWriter. The directory is moved into the place of the instance, and the databases are reopened.
+func (l *LevelDB) Replace(dir string) error {
+	l.dbsList.Lock()
+	defer l.dbsList.Unlock()
+
+	for name, db := range l.dbs {
+		db.mu.Lock()
+		err := db.file.Close()
+		db.mu.Unlock()
+		if err != nil {
+			return errors.Wrapf(err, "error while closing database [%s]", name)
+		}
+	}
+	l.dbs = make(map[string]*db)
+
+	if err := os.RemoveAll(l.dbRootDir); err != nil {
+		return errors.Wrapf(err, "error while removing the directory [%s]", l.dbRootDir)
+	}
+	if err := os.Rename(dir, l.dbRootDir); err != nil {
+		return errors.Wrapf(err, "error while moving the directory [%s] to [%s]", dir, l.dbRootDir)
+	}
+
+	replaced, err := openExistingLevelDBInstance(&Config{DBRootDir: l.dbRootDir, Logger: l.logger})
+	if err != nil {
+		return err
+	}
+	l.dbs = replaced.dbs
+
+	return nil
+}
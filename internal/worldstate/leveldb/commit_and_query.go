@@ -164,6 +164,16 @@ func (l *LevelDB) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, er
 	return l.Get(worldstate.DatabasesDBName, dbName)
 }
 
+// GetSchemaDefinition returns the JSON schema defined on a given database
+func (l *LevelDB) GetSchemaDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return l.Get(worldstate.DatabasesDBName, worldstate.SchemaKey(dbName))
+}
+
+// GetProcedure returns the source code of a stored procedure registered on a given database
+func (l *LevelDB) GetProcedure(dbName, procedureName string) ([]byte, *types.Metadata, error) {
+	return l.Get(worldstate.DatabasesDBName, worldstate.ProcedureKey(dbName, procedureName))
+}
+
 // GetIterator returns an iterator to fetch values associated with a range of keys
 // startKey is inclusive while the endKey is exclusive. An empty startKey (i.e., "") denotes that
 // the caller wants from the first key in the database (lexicographic order). An empty
@@ -276,14 +286,22 @@ func (l *LevelDB) commitToDB(dbName string, db *db, updates *worldstate.DBUpdate
 	// and delete list to be unique which is to be ensured
 	// by the validator.
 
+	// schema, procedure, and quota entries are stored alongside the index
+	// definition of a database and do not have a database of their own
 	for _, kv := range updates.Writes {
 		dbName := kv.Key
+		if !worldstate.IsDatabaseKey(dbName) {
+			continue
+		}
 		if err := l.create(dbName); err != nil {
 			return err
 		}
 	}
 
 	for _, dbName := range updates.Deletes {
+		if !worldstate.IsDatabaseKey(dbName) {
+			continue
+		}
 		if err := l.delete(dbName); err != nil {
 			return err
 		}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/conformance"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	conformance.RunTests(t, func(t *testing.T, dir string) worldstate.TransferableDB {
+		l, err := Open(&Config{DBRootDir: dir, Logger: lg})
+		require.NoError(t, err)
+		return l
+	})
+}
+
+// TestOpenPartiallyCreated covers the recovery from a crash during the creation of the
+// instance, which is specific to the way the leveldb instance is laid out on disk
+func TestOpenPartiallyCreated(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		creationFlag bool
+	}{
+		{
+			name: "empty dir",
+		},
+		{
+			name:         "with the creation flag",
+			creationFlag: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// create folders and files to mimic an existing creation but a crash before
+			// the successful completion
+			dbRootDir := filepath.Join(t.TempDir(), "existing-leveldb")
+			require.NoError(t, fileops.CreateDir(dbRootDir))
+			if tt.creationFlag {
+				require.NoError(t, fileops.CreateFile(filepath.Join(dbRootDir, underCreationFlag)))
+			}
+
+			l, err := Open(&Config{DBRootDir: dbRootDir, Logger: lg})
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, l.Close())
+			}()
+
+			require.NoFileExists(t, filepath.Join(dbRootDir, underCreationFlag))
+			require.Len(t, l.dbs, len(preCreateDBs))
+			for _, dbName := range preCreateDBs {
+				require.NotNil(t, l.dbs[dbName])
+			}
+		})
+	}
+}
@@ -157,6 +157,14 @@ func openExistingLevelDBInstance(c *Config) (*LevelDB, error) {
 		}
 	}
 
+	// system databases introduced after the instance has been
+	// created are not present in the existing instance
+	for _, dbName := range worldstate.SystemDBs() {
+		if err := l.create(dbName); err != nil {
+			return nil, err
+		}
+	}
+
 	return l, nil
 }
 
@@ -179,7 +187,8 @@ func (l *LevelDB) Close() error {
 	return nil
 }
 
-// ValidDBName returns true if the given dbName is valid
+// ValidDBName returns true if the given dbName is valid, i.e., it is made of
+// the allowed characters and does not start with a reserved prefix
 func (l *LevelDB) ValidDBName(dbName string) bool {
-	return l.dbNameRegex.MatchString(dbName)
+	return l.dbNameRegex.MatchString(dbName) && !worldstate.IsReservedDBName(dbName)
 }
@@ -1,6 +1,8 @@
 package leveldb
 
 import (
+	"bytes"
+	"encoding/binary"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
@@ -25,7 +27,19 @@ func (l *LevelDB) GetDBsSnapshot(dbNames []string) (worldstate.DBsSnapshot, erro
 		dbSnap: make(map[string]*leveldb.Snapshot),
 	}
 
+	// the height is stored after the updates of a block are committed to all
+	// the other databases, hence the metadata database is snapshotted first so
+	// that the other snapshots reflect at least all the blocks up to its height
+	sorted := make([]string, 0, len(dbNames))
 	for _, dbName := range dbNames {
+		if dbName == worldstate.MetadataDBName {
+			sorted = append([]string{dbName}, sorted...)
+		} else {
+			sorted = append(sorted, dbName)
+		}
+	}
+
+	for _, dbName := range sorted {
 		db, ok := l.dbs[dbName]
 		if !ok {
 			return nil, &DBNotFoundErr{
@@ -101,6 +115,32 @@ func (s *Snapshots) GetIterator(dbName string, startKey, endKey string) (worldst
 	return lSnap.NewIterator(r, &opt.ReadOptions{}), nil
 }
 
+// Height returns the block height of the snapshot
+func (s *Snapshots) Height() (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	lSnap, ok := s.dbSnap[worldstate.MetadataDBName]
+	if !ok {
+		return 0, errors.New(worldstate.MetadataDBName + " is needed to fetch the height and is not snapshotted")
+	}
+
+	blockNumberEnc, err := lSnap.Get(lastCommittedBlockNumberKey, &opt.ReadOptions{})
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error while retrieving the height from the snapshot")
+	}
+
+	blockNumberDec, err := binary.ReadUvarint(bytes.NewBuffer(blockNumberEnc))
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the stored height")
+	}
+
+	return blockNumberDec, nil
+}
+
 func (s *Snapshots) Release() {
 	s.Lock()
 	defer s.Unlock()
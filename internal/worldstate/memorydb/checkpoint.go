@@ -0,0 +1,179 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"sort"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint holds a consistent view of the raw key-value pairs of all the databases, including the system
+// databases and the index databases. It is used to transfer the whole state to another node.
+type Checkpoint struct {
+	dbs     map[string]*table
+	dbNames []string
+}
+
+// Checkpoint returns a checkpoint of all the databases. The checkpoint must be released after use.
+func (m *MemoryDB) Checkpoint() (worldstate.Checkpoint, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	c := &Checkpoint{
+		dbs: make(map[string]*table),
+	}
+	for dbName, db := range m.dbs {
+		c.dbs[dbName] = db.acquire()
+		c.dbNames = append(c.dbNames, dbName)
+	}
+	sort.Strings(c.dbNames)
+
+	return c, nil
+}
+
+// DBNames returns the names of all the databases of the checkpoint in sorted order
+func (c *Checkpoint) DBNames() []string {
+	return append([]string{}, c.dbNames...)
+}
+
+// ForEach calls f with each key-value pair of the checkpoint, database by database in the order of their names.
+// The value is the raw, marshaled types.ValueWithMetadata. The slices passed to f must not be retained.
+func (c *Checkpoint) ForEach(f func(dbName string, key, value []byte) error) error {
+	for _, dbName := range c.dbNames {
+		db := c.dbs[dbName]
+		for _, key := range db.sortedKeys("", "") {
+			if err := f(dbName, []byte(key), db.kvs[key]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Release releases the checkpoint
+func (c *Checkpoint) Release() {
+	for _, db := range c.dbs {
+		db.release()
+	}
+	c.dbs = nil
+	c.dbNames = nil
+}
+
+// CheckpointWriter fills a staged instance with the key-value pairs of a checkpoint
+type CheckpointWriter struct {
+	m *MemoryDB
+}
+
+// NewCheckpointWriter creates a new staged instance, which is identified by the given directory, and returns a
+// writer that fills it with the key-value pairs of a checkpoint. No directory is created.
+func (m *MemoryDB) NewCheckpointWriter(dir string) (worldstate.CheckpointWriter, error) {
+	m.stagedLock.Lock()
+	defer m.stagedLock.Unlock()
+
+	if _, ok := m.staged[dir]; ok {
+		return nil, errors.Errorf("the directory [%s] already exists", dir)
+	}
+
+	staged := &MemoryDB{
+		dbs:         make(map[string]*table),
+		logger:      m.logger,
+		dbNameRegex: m.dbNameRegex,
+		staged:      make(map[string]*MemoryDB),
+	}
+	m.staged[dir] = staged
+
+	return &CheckpointWriter{m: staged}, nil
+}
+
+// CreateDB creates the given database if it does not exist
+func (w *CheckpointWriter) CreateDB(dbName string) error {
+	if _, ok := w.m.dbs[dbName]; ok {
+		return nil
+	}
+
+	if !w.m.ValidDBName(dbName) {
+		return errors.Errorf("invalid database name [%s]", dbName)
+	}
+	w.m.dbs[dbName] = newTable()
+
+	return nil
+}
+
+// Put writes a raw key-value pair to the given database, creating the database if it does not exist
+func (w *CheckpointWriter) Put(dbName string, key, value []byte) error {
+	if err := w.CreateDB(dbName); err != nil {
+		return err
+	}
+
+	w.m.dbs[dbName].put(string(key), append([]byte{}, value...))
+	return nil
+}
+
+// Close completes the staged instance
+func (w *CheckpointWriter) Close() error {
+	return nil
+}
+
+// stagedInstance returns the staged instance identified by the given directory
+func (m *MemoryDB) stagedInstance(dir string) (*MemoryDB, error) {
+	m.stagedLock.Lock()
+	defer m.stagedLock.Unlock()
+
+	staged, ok := m.staged[dir]
+	if !ok {
+		return nil, errors.Errorf("the directory [%s] does not exist", dir)
+	}
+	return staged, nil
+}
+
+// OpenCheckpoint opens the staged instance identified by the given directory, which was created by a
+// CheckpointWriter. The returned instance shares the databases with the staged instance, and commits to it do not
+// affect the staged instance.
+func (m *MemoryDB) OpenCheckpoint(dir string) (worldstate.TransferableDB, error) {
+	staged, err := m.stagedInstance(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	staged.dbsList.RLock()
+	defer staged.dbsList.RUnlock()
+
+	view := &MemoryDB{
+		dbs:         make(map[string]*table),
+		logger:      m.logger,
+		dbNameRegex: m.dbNameRegex,
+		staged:      make(map[string]*MemoryDB),
+	}
+	for dbName, db := range staged.dbs {
+		view.dbs[dbName] = db.acquire()
+		view.acquired = append(view.acquired, db)
+	}
+
+	return view, nil
+}
+
+// Replace replaces all the databases with those of the staged instance identified by the given directory, which was
+// created by a CheckpointWriter. The staged instance is removed.
+func (m *MemoryDB) Replace(dir string) error {
+	staged, err := m.stagedInstance(dir)
+	if err != nil {
+		return err
+	}
+
+	m.stagedLock.Lock()
+	delete(m.staged, dir)
+	m.stagedLock.Unlock()
+
+	staged.dbsList.RLock()
+	dbs := staged.dbs
+	staged.dbsList.RUnlock()
+
+	m.dbsList.Lock()
+	defer m.dbsList.Unlock()
+
+	m.dbs = dbs
+	return nil
+}
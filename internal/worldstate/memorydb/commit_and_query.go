@@ -0,0 +1,294 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const lastCommittedBlockNumberKey = "lastCommittedBlockNumber"
+
+// Exist returns true if the given database exist. Otherwise, it returns false.
+func (m *MemoryDB) Exist(dbName string) bool {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	_, ok := m.dbs[dbName]
+	return ok
+}
+
+// ListDBs list all user databases
+func (m *MemoryDB) ListDBs() []string {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	dbsToExclude := make(map[string]struct{})
+	for _, name := range preCreateDBs {
+		dbsToExclude[name] = struct{}{}
+	}
+
+	var dbNames []string
+	for name := range m.dbs {
+		if _, ok := dbsToExclude[name]; ok {
+			continue
+		}
+		dbNames = append(dbNames, name)
+	}
+
+	return dbNames
+}
+
+// Height returns the block height of the state database. In other words, it
+// returns the last committed block number
+func (m *MemoryDB) Height() (uint64, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	metadataDB, ok := m.dbs[worldstate.MetadataDBName]
+	if !ok {
+		return 0, errors.Errorf("unable to retrieve the state database height due to missing metadataDB")
+	}
+
+	blockNumberEnc, ok := metadataDB.kvs[lastCommittedBlockNumberKey]
+	if !ok {
+		return 0, nil
+	}
+
+	blockNumberDec, err := binary.ReadUvarint(bytes.NewBuffer(blockNumberEnc))
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the stored height")
+	}
+
+	return blockNumberDec, nil
+}
+
+// Get returns the value of the key present in the database.
+func (m *MemoryDB) Get(dbName string, key string) ([]byte, *types.Metadata, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	db, ok := m.dbs[dbName]
+	if !ok {
+		return nil, nil, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	return get(db, key)
+}
+
+func get(db *table, key string) ([]byte, *types.Metadata, error) {
+	dbval, ok := db.kvs[key]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	persisted := &types.ValueWithMetadata{}
+	if err := proto.Unmarshal(dbval, persisted); err != nil {
+		return nil, nil, err
+	}
+
+	return persisted.Value, persisted.Metadata, nil
+}
+
+// GetVersion returns the version of the key present in the database
+func (m *MemoryDB) GetVersion(dbName string, key string) (*types.Version, error) {
+	_, metadata, err := m.Get(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.GetVersion(), nil
+}
+
+// GetACL returns the access control rule for the given key present in the database
+func (m *MemoryDB) GetACL(dbName, key string) (*types.AccessControl, error) {
+	_, metadata, err := m.Get(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.GetAccessControl(), nil
+}
+
+// Has returns true if the key exist in the database
+func (m *MemoryDB) Has(dbName, key string) (bool, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	db, ok := m.dbs[dbName]
+	if !ok {
+		return false, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	_, ok = db.kvs[key]
+	return ok, nil
+}
+
+// GetConfig returns the cluster configuration
+func (m *MemoryDB) GetConfig() (*types.ClusterConfig, *types.Metadata, error) {
+	configSerialized, metadata, err := m.Get(worldstate.ConfigDBName, worldstate.ConfigKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &types.ClusterConfig{}
+	if err := proto.Unmarshal(configSerialized, config); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshaling committed cluster configuration")
+	}
+
+	return config, metadata, nil
+}
+
+// GetIndexDefinition returns the index definition of a given database
+func (m *MemoryDB) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return m.Get(worldstate.DatabasesDBName, dbName)
+}
+
+// GetSchemaDefinition returns the JSON schema defined on a given database
+func (m *MemoryDB) GetSchemaDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return m.Get(worldstate.DatabasesDBName, worldstate.SchemaKey(dbName))
+}
+
+// GetProcedure returns the source code of a stored procedure registered on a given database
+func (m *MemoryDB) GetProcedure(dbName, procedureName string) ([]byte, *types.Metadata, error) {
+	return m.Get(worldstate.DatabasesDBName, worldstate.ProcedureKey(dbName, procedureName))
+}
+
+// GetIterator returns an iterator to fetch values associated with a range of keys
+// startKey is inclusive while the endKey is exclusive. An empty startKey (i.e., "") denotes that
+// the caller wants from the first key in the database (lexicographic order). An empty
+// endKey (i.e., "") denotes that the caller wants till the last key in the database (lexicographic order).
+// The iterator observes the database as it was when the iterator was created.
+func (m *MemoryDB) GetIterator(dbName string, startKey, endKey string) (worldstate.Iterator, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	db, ok := m.dbs[dbName]
+	if !ok {
+		m.logger.Errorf("database %s does not exist", dbName)
+		return nil, errors.Errorf("database %s does not exist", dbName)
+	}
+
+	return newIterator(db, startKey, endKey), nil
+}
+
+// Commit commits the updates to the database. The updates are validated and
+// marshaled before any database is modified, hence either all the updates are
+// applied, or none of them.
+func (m *MemoryDB) Commit(dbsUpdates map[string]*worldstate.DBUpdates, blockNumber uint64) error {
+	start := time.Now()
+
+	m.dbsList.Lock()
+	defer m.dbsList.Unlock()
+
+	if _, ok := m.dbs[worldstate.MetadataDBName]; !ok {
+		m.logger.Errorf("metadata database does not exist")
+		return errors.Errorf("metadata database does not exist")
+	}
+
+	// schema, procedure, and quota entries are stored alongside the index
+	// definition of a database and do not create a database of their own
+	var created, deleted []string
+	beingCreated := make(map[string]struct{})
+	if updates, ok := dbsUpdates[worldstate.DatabasesDBName]; ok {
+		for _, kv := range updates.Writes {
+			if worldstate.IsDatabaseKey(kv.Key) {
+				created = append(created, kv.Key)
+				beingCreated[kv.Key] = struct{}{}
+			}
+		}
+		for _, dbName := range updates.Deletes {
+			if worldstate.IsDatabaseKey(dbName) {
+				deleted = append(deleted, dbName)
+			}
+		}
+	}
+
+	marshaled := make(map[string]map[string][]byte)
+	for dbName, updates := range dbsUpdates {
+		_, exist := m.dbs[dbName]
+		if _, ok := beingCreated[dbName]; !exist && !ok {
+			m.logger.Errorf("database %s does not exist", dbName)
+			return errors.Errorf("database %s does not exist", dbName)
+		}
+
+		writes := make(map[string][]byte)
+		for _, kv := range updates.Writes {
+			dbval, err := proto.Marshal(
+				&types.ValueWithMetadata{
+					Value:    kv.Value,
+					Metadata: kv.Metadata,
+				},
+			)
+			if err != nil {
+				return errors.WithMessagef(err, "failed to marshal the constructed dbValue [%v]", kv.Value)
+			}
+			writes[kv.Key] = dbval
+		}
+		marshaled[dbName] = writes
+	}
+
+	// creating an existing database and deleting a non-existing
+	// database are no-ops. We assume the union of dbNames in create
+	// and delete list to be unique which is to be ensured by the
+	// validator.
+	for _, dbName := range created {
+		if _, ok := m.dbs[dbName]; !ok {
+			m.dbs[dbName] = newTable()
+		}
+	}
+
+	for dbName, updates := range dbsUpdates {
+		db := m.writable(dbName)
+		for key, dbval := range marshaled[dbName] {
+			db.put(key, dbval)
+		}
+		for _, key := range updates.Deletes {
+			db.delete(key)
+		}
+	}
+
+	for _, dbName := range deleted {
+		delete(m.dbs, dbName)
+	}
+
+	b := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(b, blockNumber)
+	m.writable(worldstate.MetadataDBName).put(lastCommittedBlockNumberKey, b)
+
+	m.logger.Debugf("changes of block [%d] committed to %d databases, took %d ms", blockNumber, len(dbsUpdates), time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// writable returns the table of the given database for modification, replacing it
+// with a copy if it is shared with a reader. It must be called with the write lock
+// held.
+func (m *MemoryDB) writable(dbName string) *table {
+	db := m.dbs[dbName]
+	if db.shared() {
+		db = db.clone()
+		m.dbs[dbName] = db
+	}
+	return db
+}
+
+// DBNotFoundErr denotes that the given dbName is not present in the database
+type DBNotFoundErr struct {
+	dbName string
+}
+
+func (e *DBNotFoundErr) Error() string {
+	return fmt.Sprintf("database %s does not exist", e.dbName)
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package memorydb
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *MemoryDB {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	m, err := Open(&Config{Logger: lg})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := m.Close(); err != nil {
+			t.Errorf("failed to close the database instance, %v", err)
+		}
+	})
+
+	return m
+}
+
+func commitKey(t *testing.T, m *MemoryDB, key, value string, blockNumber uint64) {
+	require.NoError(t, m.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: key, Value: []byte(value)}},
+			},
+		},
+		blockNumber,
+	))
+}
+
+func TestCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	m := newTestDB(t)
+	commitKey(t, m, "key1", "value1", 1)
+
+	// a table that is not shared is modified in place
+	tbl := m.dbs[worldstate.DefaultDBName]
+	commitKey(t, m, "key2", "value2", 2)
+	require.Same(t, tbl, m.dbs[worldstate.DefaultDBName])
+
+	// a table that is shared with an iterator is replaced by a copy
+	itr, err := m.GetIterator(worldstate.DefaultDBName, "", "")
+	require.NoError(t, err)
+	commitKey(t, m, "key1", "value1-updated", 3)
+	commitKey(t, m, "key3", "value3", 4)
+	require.NotSame(t, tbl, m.dbs[worldstate.DefaultDBName])
+
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	require.NoError(t, itr.Error())
+	require.Equal(t, []string{"key1", "key2"}, keys)
+	itr.Release()
+
+	// once released, the copy is modified in place again
+	tbl = m.dbs[worldstate.DefaultDBName]
+	commitKey(t, m, "key4", "value4", 5)
+	require.Same(t, tbl, m.dbs[worldstate.DefaultDBName])
+
+	val, _, err := m.Get(worldstate.DefaultDBName, "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1-updated"), val)
+}
+
+func TestCommitIsAtomic(t *testing.T) {
+	t.Parallel()
+
+	m := newTestDB(t)
+	err := m.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+			},
+			"db2": {
+				Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+			},
+		},
+		1,
+	)
+	require.EqualError(t, err, "database db2 does not exist")
+
+	val, _, err := m.Get(worldstate.DefaultDBName, "key1")
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	height, err := m.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), height)
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	m := newTestDB(t)
+	commitKey(t, m, "key1", "value1", 1)
+	require.NoError(t, m.Close())
+
+	require.False(t, m.Exist(worldstate.DefaultDBName))
+	_, _, err := m.Get(worldstate.DefaultDBName, "key1")
+	require.EqualError(t, err, "database bdb does not exist")
+	_, err = m.Height()
+	require.EqualError(t, err, "unable to retrieve the state database height due to missing metadataDB")
+}
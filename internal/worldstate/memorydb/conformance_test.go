@@ -0,0 +1,29 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package memorydb
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/conformance"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	conformance.RunVolatileTests(t, func(t *testing.T, dir string) worldstate.TransferableDB {
+		m, err := Open(&Config{Logger: lg})
+		require.NoError(t, err)
+		return m
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+var errIteratorReleased = errors.New("memorydb: iterator released")
+
+// iterator iterates over the keys of a table in the range given at creation. The
+// table is acquired until the iterator is released, hence the iterator observes the
+// state of the database at creation.
+type iterator struct {
+	tbl      *table
+	keys     []string
+	pos      int
+	released bool
+	err      error
+}
+
+func newIterator(tbl *table, startKey, endKey string) *iterator {
+	tbl.acquire()
+
+	return &iterator{
+		tbl:  tbl,
+		keys: tbl.sortedKeys(startKey, endKey),
+		pos:  -1,
+	}
+}
+
+func (i *iterator) valid() bool {
+	return !i.released && i.pos >= 0 && i.pos < len(i.keys)
+}
+
+// Key returns the key of the current key/value pair, or nil if done
+func (i *iterator) Key() []byte {
+	if !i.valid() {
+		return nil
+	}
+	return []byte(i.keys[i.pos])
+}
+
+// Value returns the value of the current key/value pair, or nil if done
+func (i *iterator) Value() []byte {
+	if !i.valid() {
+		return nil
+	}
+	return i.tbl.kvs[i.keys[i.pos]]
+}
+
+// Next moves the iterator to the next key/value pair
+func (i *iterator) Next() bool {
+	if i.released {
+		i.err = errIteratorReleased
+		return false
+	}
+
+	if i.pos < len(i.keys) {
+		i.pos++
+	}
+	return i.valid()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key
+func (i *iterator) Seek(key []byte) bool {
+	if i.released {
+		i.err = errIteratorReleased
+		return false
+	}
+
+	i.pos = sort.SearchStrings(i.keys, string(key))
+	return i.valid()
+}
+
+// Error returns any accumulated error
+func (i *iterator) Error() error {
+	return i.err
+}
+
+// Release releases the iterator. It can be called multiple times.
+func (i *iterator) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	i.tbl.release()
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+)
+
+var (
+	// allowedCharsInDBName holds the regexp for allowed characters
+	// in a database name
+	allowedCharsInDBName = `^[0-9a-zA-Z_\-\.]+$`
+
+	preCreateDBs = append(
+		worldstate.SystemDBs(),
+		worldstate.DefaultDBName,
+	)
+)
+
+// MemoryDB holds all the databases in memory. Nothing is written to disk, hence
+// the state is lost when the instance is closed. It is meant for tests and for
+// ephemeral nodes, which rebuild their state from the other nodes on start.
+type MemoryDB struct {
+	dbs         map[string]*table
+	logger      *logger.SugarLogger
+	dbsList     sync.RWMutex
+	dbNameRegex *regexp.Regexp
+
+	// staged holds the instances created by the checkpoint writers, keyed by
+	// the directory that a disk-based instance would have been created in
+	staged     map[string]*MemoryDB
+	stagedLock sync.Mutex
+	// acquired holds the tables shared with a staged instance, which are
+	// released on close
+	acquired []*table
+}
+
+type Config struct {
+	Logger *logger.SugarLogger
+}
+
+// Open creates a new, empty, in-memory instance to maintain world state
+func Open(conf *Config) (*MemoryDB, error) {
+	m := &MemoryDB{
+		dbs:         make(map[string]*table),
+		logger:      conf.Logger,
+		dbNameRegex: regexp.MustCompile(allowedCharsInDBName),
+		staged:      make(map[string]*MemoryDB),
+	}
+
+	for _, dbName := range preCreateDBs {
+		m.dbs[dbName] = newTable()
+	}
+
+	return m, nil
+}
+
+// Close drops all the databases
+func (m *MemoryDB) Close() error {
+	m.dbsList.Lock()
+	m.dbs = make(map[string]*table)
+	for _, t := range m.acquired {
+		t.release()
+	}
+	m.acquired = nil
+	m.dbsList.Unlock()
+
+	m.stagedLock.Lock()
+	m.staged = make(map[string]*MemoryDB)
+	m.stagedLock.Unlock()
+
+	return nil
+}
+
+// ValidDBName returns true if the given dbName is valid, i.e., it is made of
+// the allowed characters and does not start with a reserved prefix
+func (m *MemoryDB) ValidDBName(dbName string) bool {
+	return m.dbNameRegex.MatchString(dbName) && !worldstate.IsReservedDBName(dbName)
+}
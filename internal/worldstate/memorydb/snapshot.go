@@ -0,0 +1,106 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Snapshots holds the tables of the snapshotted databases, which are not
+// modified until the snapshot is released
+type Snapshots struct {
+	dbs map[string]*table
+	sync.RWMutex
+}
+
+// GetDBsSnapshot returns a latest snapshot of the given databases
+func (m *MemoryDB) GetDBsSnapshot(dbNames []string) (worldstate.DBsSnapshot, error) {
+	m.dbsList.RLock()
+	defer m.dbsList.RUnlock()
+
+	snapshotted := make(map[string]*table)
+	for _, dbName := range dbNames {
+		db, ok := m.dbs[dbName]
+		if !ok {
+			return nil, &DBNotFoundErr{
+				dbName: dbName,
+			}
+		}
+		snapshotted[dbName] = db
+	}
+
+	for _, db := range snapshotted {
+		db.acquire()
+	}
+
+	return &Snapshots{
+		dbs: snapshotted,
+	}, nil
+}
+
+func (s *Snapshots) Get(dbName, key string) ([]byte, *types.Metadata, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	db, ok := s.dbs[dbName]
+	if !ok {
+		return nil, nil, errors.New(dbName + " is needed to fetch the index definiton and is not snapshotted")
+	}
+
+	return get(db, key)
+}
+
+func (s *Snapshots) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return s.Get(worldstate.DatabasesDBName, dbName)
+}
+
+func (s *Snapshots) GetIterator(dbName string, startKey, endKey string) (worldstate.Iterator, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	db, ok := s.dbs[dbName]
+	if !ok {
+		return nil, errors.New(dbName + " database is not snapshotted")
+	}
+
+	return newIterator(db, startKey, endKey), nil
+}
+
+// Height returns the block height of the snapshot
+func (s *Snapshots) Height() (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	metadataDB, ok := s.dbs[worldstate.MetadataDBName]
+	if !ok {
+		return 0, errors.New(worldstate.MetadataDBName + " is needed to fetch the height and is not snapshotted")
+	}
+
+	blockNumberEnc, ok := metadataDB.kvs[lastCommittedBlockNumberKey]
+	if !ok {
+		return 0, nil
+	}
+
+	blockNumberDec, err := binary.ReadUvarint(bytes.NewBuffer(blockNumberEnc))
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the stored height")
+	}
+
+	return blockNumberDec, nil
+}
+
+func (s *Snapshots) Release() {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, db := range s.dbs {
+		db.release()
+	}
+	s.dbs = nil
+}
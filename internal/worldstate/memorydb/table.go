@@ -0,0 +1,100 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package memorydb
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// table holds the key-value pairs of a database. A table that is referenced by
+// an iterator, a snapshot, or a checkpoint is never modified: a commit to its
+// database replaces it with a modified copy. As a result, the readers observe the
+// state of the database at the time they were created, without copying the pairs
+// of the databases that are not updated meanwhile.
+type table struct {
+	kvs  map[string][]byte
+	refs int32
+
+	// keys holds the keys in sorted order, and is computed
+	// on the first iteration after a modification
+	keysLock sync.Mutex
+	keys     []string
+	sorted   bool
+}
+
+func newTable() *table {
+	return &table{
+		kvs: make(map[string][]byte),
+	}
+}
+
+// acquire prevents the modification of the table until it is released
+func (t *table) acquire() *table {
+	atomic.AddInt32(&t.refs, 1)
+	return t
+}
+
+func (t *table) release() {
+	atomic.AddInt32(&t.refs, -1)
+}
+
+func (t *table) shared() bool {
+	return atomic.LoadInt32(&t.refs) > 0
+}
+
+// clone returns a copy of the table, which is not shared. The values are not
+// copied, as they are never modified in place.
+func (t *table) clone() *table {
+	c := &table{
+		kvs: make(map[string][]byte, len(t.kvs)),
+	}
+	for k, v := range t.kvs {
+		c.kvs[k] = v
+	}
+	return c
+}
+
+func (t *table) put(key string, value []byte) {
+	t.kvs[key] = value
+	t.sorted = false
+}
+
+func (t *table) delete(key string) {
+	if _, ok := t.kvs[key]; !ok {
+		return
+	}
+	delete(t.kvs, key)
+	t.sorted = false
+}
+
+// sortedKeys returns the keys of the table in the range [startKey, endKey). An
+// empty startKey or endKey denotes the first or the last key, respectively.
+func (t *table) sortedKeys(startKey, endKey string) []string {
+	t.keysLock.Lock()
+	defer t.keysLock.Unlock()
+
+	if !t.sorted {
+		t.keys = make([]string, 0, len(t.kvs))
+		for k := range t.kvs {
+			t.keys = append(t.keys, k)
+		}
+		sort.Strings(t.keys)
+		t.sorted = true
+	}
+
+	start := 0
+	if startKey != "" {
+		start = sort.SearchStrings(t.keys, startKey)
+	}
+	end := len(t.keys)
+	if endKey != "" {
+		end = sort.SearchStrings(t.keys, endKey)
+	}
+	if end < start {
+		end = start
+	}
+
+	return t.keys[start:end]
+}
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pebbledb
+
+import (
+	"os"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+// checkpointBatchSize is the number of pairs written in a single batch by the CheckpointWriter
+const checkpointBatchSize = 1000
+
+// Checkpoint holds a consistent view of the raw key-value pairs of all the databases, including the system
+// databases and the index databases. It is used to transfer the whole state to another node.
+type Checkpoint struct {
+	snap    *pebble.Snapshot
+	dbNames []string
+}
+
+// Checkpoint returns a checkpoint of all the databases. The caller must make sure that no block is being committed
+// while the checkpoint is taken. The checkpoint must be released after use.
+func (p *PebbleDB) Checkpoint() (worldstate.Checkpoint, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	c := &Checkpoint{
+		snap: p.file.NewSnapshot(),
+	}
+	for dbName := range p.dbs {
+		c.dbNames = append(c.dbNames, dbName)
+	}
+	sort.Strings(c.dbNames)
+
+	return c, nil
+}
+
+// DBNames returns the names of all the databases of the checkpoint in sorted order
+func (c *Checkpoint) DBNames() []string {
+	return append([]string{}, c.dbNames...)
+}
+
+// ForEach calls f with each key-value pair of the checkpoint, database by database in the order of their names.
+// The value is the raw, marshaled types.ValueWithMetadata. The slices passed to f must not be retained.
+func (c *Checkpoint) ForEach(f func(dbName string, key, value []byte) error) error {
+	for _, dbName := range c.dbNames {
+		itr := newIterator(c.snap, dbName, "", "")
+		for itr.Next() {
+			if err := f(dbName, itr.Key(), itr.Value()); err != nil {
+				itr.Release()
+				return err
+			}
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			return errors.Wrapf(err, "error while iterating over the snapshot of database [%s]", dbName)
+		}
+	}
+
+	return nil
+}
+
+// Release releases the checkpoint
+func (c *Checkpoint) Release() {
+	if c.snap == nil {
+		return
+	}
+	c.snap.Close()
+	c.snap = nil
+	c.dbNames = nil
+}
+
+// CheckpointWriter creates a new instance in a given directory from the key-value pairs of a checkpoint
+type CheckpointWriter struct {
+	p     *PebbleDB
+	batch *pebble.Batch
+}
+
+// NewCheckpointWriter creates a new instance in the given directory, which must not exist, and returns a writer that
+// fills it with the key-value pairs of a checkpoint.
+func NewCheckpointWriter(dir string, logger *logger.SugarLogger) (*CheckpointWriter, error) {
+	exist, err := fileops.Exists(dir)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Errorf("the directory [%s] already exists", dir)
+	}
+
+	p, err := Open(&Config{DBRootDir: dir, Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckpointWriter{
+		p:     p,
+		batch: p.file.NewBatch(),
+	}, nil
+}
+
+// CreateDB creates the given database if it does not exist
+func (w *CheckpointWriter) CreateDB(dbName string) error {
+	if _, ok := w.p.dbs[dbName]; ok {
+		return nil
+	}
+
+	if !w.p.ValidDBName(dbName) {
+		return errors.Errorf("invalid database name [%s]", dbName)
+	}
+	if err := w.batch.Set(registryKey(dbName), nil, nil); err != nil {
+		return errors.Wrapf(err, "error while creating database [%s]", dbName)
+	}
+	w.p.dbs[dbName] = struct{}{}
+
+	return w.flushIfFull()
+}
+
+// Put writes a raw key-value pair to the given database, creating the database if it does not exist
+func (w *CheckpointWriter) Put(dbName string, key, value []byte) error {
+	if err := w.CreateDB(dbName); err != nil {
+		return err
+	}
+
+	if err := w.batch.Set(dbKey(dbName, key), value, nil); err != nil {
+		return errors.Wrapf(err, "error while writing to database [%s]", dbName)
+	}
+	return w.flushIfFull()
+}
+
+func (w *CheckpointWriter) flushIfFull() error {
+	if w.batch.Count() < checkpointBatchSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *CheckpointWriter) flush() error {
+	if w.batch.Empty() {
+		return nil
+	}
+
+	if err := w.batch.Commit(pebble.Sync); err != nil {
+		return errors.Wrap(err, "error while writing a checkpoint batch")
+	}
+	w.batch.Reset()
+	return nil
+}
+
+// Close writes the remaining pairs and closes the new instance
+func (w *CheckpointWriter) Close() error {
+	err := w.flush()
+	w.batch.Close()
+
+	if closeErr := w.p.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// NewCheckpointWriter creates a new instance in the given directory, which must not exist, and returns a writer that
+// fills it with the key-value pairs of a checkpoint.
+func (p *PebbleDB) NewCheckpointWriter(dir string) (worldstate.CheckpointWriter, error) {
+	w, err := NewCheckpointWriter(dir, p.logger)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenCheckpoint opens the instance in the given directory, which was created by a CheckpointWriter
+func (p *PebbleDB) OpenCheckpoint(dir string) (worldstate.TransferableDB, error) {
+	staged, err := Open(&Config{DBRootDir: dir, Logger: p.logger})
+	if err != nil {
+		return nil, err
+	}
+	return staged, nil
+}
+
+// Replace replaces all the databases with the instance in the given directory, which was created by a
+// CheckpointWriter. The directory is moved into the place of the instance, and the instance is reopened.
+func (p *PebbleDB) Replace(dir string) error {
+	p.dbsList.Lock()
+	defer p.dbsList.Unlock()
+
+	if p.file != nil {
+		if err := p.file.Close(); err != nil {
+			return errors.Wrap(err, "error while closing the pebble instance")
+		}
+		p.file = nil
+	}
+	p.dbs = make(map[string]struct{})
+
+	if err := os.RemoveAll(p.dbRootDir); err != nil {
+		return errors.Wrapf(err, "error while removing the directory [%s]", p.dbRootDir)
+	}
+	if err := os.Rename(dir, p.dbRootDir); err != nil {
+		return errors.Wrapf(err, "error while moving the directory [%s] to [%s]", dir, p.dbRootDir)
+	}
+
+	replaced, err := Open(&Config{DBRootDir: p.dbRootDir, Logger: p.logger})
+	if err != nil {
+		return err
+	}
+	p.file = replaced.file
+	p.dbs = replaced.dbs
+
+	return nil
+}
@@ -0,0 +1,368 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pebbledb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	lastCommittedBlockNumberKey = []byte("lastCommittedBlockNumber")
+	// registryPrefix is the prefix of the keys that hold the names of
+	// the databases. As a database name cannot be empty nor contain
+	// '\x00', the prefix does not clash with the keys of a database.
+	registryPrefix = []byte("\x00dbs\x00")
+)
+
+// dbPrefix returns the prefix of all the keys of the given database
+func dbPrefix(dbName string) []byte {
+	return append([]byte(dbName), 0)
+}
+
+// dbKey returns the key under which the given key of the given database is stored
+func dbKey(dbName string, key []byte) []byte {
+	return append(dbPrefix(dbName), key...)
+}
+
+// registryKey returns the key that records the existence of the given database
+func registryKey(dbName string) []byte {
+	return append(append([]byte{}, registryPrefix...), dbName...)
+}
+
+// prefixEnd returns the smallest key that is greater than all the keys with the
+// given prefix, which must end with '\x00'
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1]++
+	return end
+}
+
+// Exist returns true if the given database exist. Otherwise, it returns false.
+func (p *PebbleDB) Exist(dbName string) bool {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	_, ok := p.dbs[dbName]
+	return ok
+}
+
+// ListDBs list all user databases
+func (p *PebbleDB) ListDBs() []string {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	dbsToExclude := make(map[string]struct{})
+	for _, name := range preCreateDBs {
+		dbsToExclude[name] = struct{}{}
+	}
+
+	var dbNames []string
+	for name := range p.dbs {
+		if _, ok := dbsToExclude[name]; ok {
+			continue
+		}
+		dbNames = append(dbNames, name)
+	}
+
+	return dbNames
+}
+
+// Height returns the block height of the state database. In other words, it
+// returns the last committed block number
+func (p *PebbleDB) Height() (uint64, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	if _, ok := p.dbs[worldstate.MetadataDBName]; !ok {
+		return 0, errors.Errorf("unable to retrieve the state database height due to missing metadataDB")
+	}
+
+	blockNumberEnc, closer, err := p.file.Get(dbKey(worldstate.MetadataDBName, lastCommittedBlockNumberKey))
+	if err == pebble.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error while retrieving the state database height")
+	}
+	defer closer.Close()
+
+	blockNumberDec, err := binary.ReadUvarint(bytes.NewBuffer(blockNumberEnc))
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the stored height")
+	}
+
+	return blockNumberDec, nil
+}
+
+// Get returns the value of the key present in the database.
+func (p *PebbleDB) Get(dbName string, key string) ([]byte, *types.Metadata, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	if _, ok := p.dbs[dbName]; !ok {
+		return nil, nil, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	return get(p.file, dbName, key)
+}
+
+// reader is implemented by both a pebble instance and its snapshots
+type reader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) *pebble.Iterator
+}
+
+func get(r reader, dbName, key string) ([]byte, *types.Metadata, error) {
+	dbval, closer, err := r.Get(dbKey(dbName, []byte(key)))
+	if err == pebble.ErrNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to retrieve pebble key [%s] from database %s", key, dbName)
+	}
+	defer closer.Close()
+
+	persisted := &types.ValueWithMetadata{}
+	if err := proto.Unmarshal(dbval, persisted); err != nil {
+		return nil, nil, err
+	}
+
+	return persisted.Value, persisted.Metadata, nil
+}
+
+// GetVersion returns the version of the key present in the database
+func (p *PebbleDB) GetVersion(dbName string, key string) (*types.Version, error) {
+	_, metadata, err := p.Get(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.GetVersion(), nil
+}
+
+// GetACL returns the access control rule for the given key present in the database
+func (p *PebbleDB) GetACL(dbName, key string) (*types.AccessControl, error) {
+	_, metadata, err := p.Get(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.GetAccessControl(), nil
+}
+
+// Has returns true if the key exist in the database
+func (p *PebbleDB) Has(dbName, key string) (bool, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	if _, ok := p.dbs[dbName]; !ok {
+		return false, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	_, closer, err := p.file.Get(dbKey(dbName, []byte(key)))
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to retrieve pebble key [%s] from database %s", key, dbName)
+	}
+	closer.Close()
+
+	return true, nil
+}
+
+// GetConfig returns the cluster configuration
+func (p *PebbleDB) GetConfig() (*types.ClusterConfig, *types.Metadata, error) {
+	configSerialized, metadata, err := p.Get(worldstate.ConfigDBName, worldstate.ConfigKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &types.ClusterConfig{}
+	if err := proto.Unmarshal(configSerialized, config); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshaling committed cluster configuration")
+	}
+
+	return config, metadata, nil
+}
+
+// GetIndexDefinition returns the index definition of a given database
+func (p *PebbleDB) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return p.Get(worldstate.DatabasesDBName, dbName)
+}
+
+// GetSchemaDefinition returns the JSON schema defined on a given database
+func (p *PebbleDB) GetSchemaDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return p.Get(worldstate.DatabasesDBName, worldstate.SchemaKey(dbName))
+}
+
+// GetProcedure returns the source code of a stored procedure registered on a given database
+func (p *PebbleDB) GetProcedure(dbName, procedureName string) ([]byte, *types.Metadata, error) {
+	return p.Get(worldstate.DatabasesDBName, worldstate.ProcedureKey(dbName, procedureName))
+}
+
+// GetIterator returns an iterator to fetch values associated with a range of keys
+// startKey is inclusive while the endKey is exclusive. An empty startKey (i.e., "") denotes that
+// the caller wants from the first key in the database (lexicographic order). An empty
+// endKey (i.e., "") denotes that the caller wants till the last key in the database (lexicographic order).
+func (p *PebbleDB) GetIterator(dbName string, startKey, endKey string) (worldstate.Iterator, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	if _, ok := p.dbs[dbName]; !ok {
+		p.logger.Errorf("database %s does not exist", dbName)
+		return nil, errors.Errorf("database %s does not exist", dbName)
+	}
+
+	return newIterator(p.file, dbName, startKey, endKey), nil
+}
+
+// Commit commits the updates to the database. The updates to all the databases,
+// including the creation and deletion of databases, and the block number are
+// written in a single batch.
+func (p *PebbleDB) Commit(dbsUpdates map[string]*worldstate.DBUpdates, blockNumber uint64) error {
+	start := time.Now()
+
+	p.dbsList.RLock()
+	batch, created, deleted, err := p.prepareBatch(dbsUpdates, blockNumber)
+	p.dbsList.RUnlock()
+	if err != nil {
+		return err
+	}
+	defer batch.Close()
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return errors.Wrapf(err, "error while committing the updates of block [%d]", blockNumber)
+	}
+
+	p.dbsList.Lock()
+	for _, dbName := range created {
+		p.dbs[dbName] = struct{}{}
+	}
+	for _, dbName := range deleted {
+		delete(p.dbs, dbName)
+	}
+	p.dbsList.Unlock()
+
+	p.logger.Debugf("changes of block [%d] committed to %d databases, took %d ms", blockNumber, len(dbsUpdates), time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// prepareBatch constructs the batch of the updates, and returns it along with the
+// names of the databases being created and deleted
+func (p *PebbleDB) prepareBatch(dbsUpdates map[string]*worldstate.DBUpdates, blockNumber uint64) (*pebble.Batch, []string, []string, error) {
+	if _, ok := p.dbs[worldstate.MetadataDBName]; !ok {
+		p.logger.Errorf("metadata database does not exist")
+		return nil, nil, nil, errors.Errorf("metadata database does not exist")
+	}
+
+	// schema, procedure, and quota entries are stored alongside the index
+	// definition of a database and do not create a database of their own
+	var created, deleted []string
+	beingCreated := make(map[string]struct{})
+	if updates, ok := dbsUpdates[worldstate.DatabasesDBName]; ok {
+		for _, kv := range updates.Writes {
+			if worldstate.IsDatabaseKey(kv.Key) {
+				created = append(created, kv.Key)
+				beingCreated[kv.Key] = struct{}{}
+			}
+		}
+		for _, dbName := range updates.Deletes {
+			if worldstate.IsDatabaseKey(dbName) {
+				deleted = append(deleted, dbName)
+			}
+		}
+	}
+
+	batch := p.file.NewBatch()
+	fail := func(err error) (*pebble.Batch, []string, []string, error) {
+		batch.Close()
+		return nil, nil, nil, err
+	}
+
+	for dbName, updates := range dbsUpdates {
+		_, exist := p.dbs[dbName]
+		if _, ok := beingCreated[dbName]; !exist && !ok {
+			p.logger.Errorf("database %s does not exist", dbName)
+			return fail(errors.Errorf("database %s does not exist", dbName))
+		}
+
+		for _, kv := range updates.Writes {
+			dbval, err := proto.Marshal(
+				&types.ValueWithMetadata{
+					Value:    kv.Value,
+					Metadata: kv.Metadata,
+				},
+			)
+			if err != nil {
+				return fail(errors.WithMessagef(err, "failed to marshal the constructed dbValue [%v]", kv.Value))
+			}
+
+			if err := batch.Set(dbKey(dbName, []byte(kv.Key)), dbval, nil); err != nil {
+				return fail(errors.Wrapf(err, "error while adding key [%s] of database [%s] to the update batch", kv.Key, dbName))
+			}
+		}
+
+		for _, key := range updates.Deletes {
+			if err := batch.Delete(dbKey(dbName, []byte(key)), nil); err != nil {
+				return fail(errors.Wrapf(err, "error while adding the deletion of key [%s] of database [%s] to the update batch", key, dbName))
+			}
+		}
+	}
+
+	// if node fails during the commit, none of the updates
+	// are persisted, and the block is committed again during
+	// the recovery. Creating an existing database and deleting
+	// a non-existing database are no-ops.
+
+	// we also assume the union of dbNames in create
+	// and delete list to be unique which is to be ensured
+	// by the validator.
+	for _, dbName := range created {
+		if err := batch.Set(registryKey(dbName), nil, nil); err != nil {
+			return fail(errors.Wrapf(err, "error while adding the creation of database [%s] to the update batch", dbName))
+		}
+	}
+
+	for _, dbName := range deleted {
+		prefix := dbPrefix(dbName)
+		if err := batch.DeleteRange(prefix, prefixEnd(prefix), nil); err != nil {
+			return fail(errors.Wrapf(err, "error while adding the deletion of database [%s] to the update batch", dbName))
+		}
+		if err := batch.Delete(registryKey(dbName), nil); err != nil {
+			return fail(errors.Wrapf(err, "error while adding the deletion of database [%s] to the update batch", dbName))
+		}
+	}
+
+	b := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(b, blockNumber)
+	if err := batch.Set(dbKey(worldstate.MetadataDBName, lastCommittedBlockNumberKey), b, nil); err != nil {
+		return fail(errors.Wrapf(err, "error while adding the last committed block number [%d] to the update batch", blockNumber))
+	}
+
+	return batch, created, deleted, nil
+}
+
+// DBNotFoundErr denotes that the given dbName is not present in the database
+type DBNotFoundErr struct {
+	dbName string
+}
+
+func (e *DBNotFoundErr) Error() string {
+	return fmt.Sprintf("database %s does not exist", e.dbName)
+}
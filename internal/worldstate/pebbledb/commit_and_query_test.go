@@ -0,0 +1,176 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pebbledb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *PebbleDB {
+	dir, err := ioutil.TempDir("", "pebbledb")
+	require.NoError(t, err)
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	p, err := Open(&Config{DBRootDir: filepath.Join(dir, "pebbledb"), Logger: lg})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := p.Close(); err != nil {
+			t.Errorf("failed to close the database instance, %v", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove %s, %v", dir, err)
+		}
+	})
+
+	return p
+}
+
+func TestDatabasesAreIsolated(t *testing.T) {
+	t.Parallel()
+
+	p := newTestDB(t)
+
+	// "db" is a prefix of "db1", and the keys hold the separator
+	// of the database prefix
+	require.NoError(t, p.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "db"}, {Key: "db1"}},
+			},
+		},
+		1,
+	))
+	require.NoError(t, p.Commit(
+		map[string]*worldstate.DBUpdates{
+			"db": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "1\x00key1", Value: []byte("value1")},
+					{Key: "", Value: []byte("empty key")},
+				},
+			},
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "key1", Value: []byte("db1-value1")},
+				},
+			},
+		},
+		2,
+	))
+
+	val, _, err := p.Get("db", "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("empty key"), val)
+
+	val, _, err = p.Get("db1", "\x00key1")
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	itr, err := p.GetIterator("db", "", "")
+	require.NoError(t, err)
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	require.NoError(t, itr.Error())
+	itr.Release()
+	require.Equal(t, []string{"", "1\x00key1"}, keys)
+
+	// deleting "db" does not affect "db1"
+	require.NoError(t, p.Commit(
+		map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Deletes: []string{"db"},
+			},
+		},
+		3,
+	))
+	require.False(t, p.Exist("db"))
+
+	val, _, err = p.Get("db1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("db1-value1"), val)
+}
+
+func TestCommitIsAtomic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("a failed commit writes nothing", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestDB(t)
+		err := p.Commit(
+			map[string]*worldstate.DBUpdates{
+				worldstate.DefaultDBName: {
+					Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+				},
+				worldstate.DatabasesDBName: {
+					Writes: []*worldstate.KVWithMetadata{{Key: "db1"}},
+				},
+				"db2": {
+					Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+				},
+			},
+			1,
+		)
+		require.EqualError(t, err, "database db2 does not exist")
+
+		val, _, err := p.Get(worldstate.DefaultDBName, "key1")
+		require.NoError(t, err)
+		require.Nil(t, val)
+		require.False(t, p.Exist("db1"))
+
+		height, err := p.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), height)
+	})
+
+	t.Run("write to a database created in the same commit", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestDB(t)
+		require.NoError(t, p.Commit(
+			map[string]*worldstate.DBUpdates{
+				worldstate.DatabasesDBName: {
+					Writes: []*worldstate.KVWithMetadata{{Key: "db1"}},
+				},
+				"db1": {
+					Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+				},
+			},
+			1,
+		))
+
+		val, _, err := p.Get("db1", "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), val)
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	p := newTestDB(t)
+	require.NoError(t, p.Close())
+
+	require.False(t, p.Exist(worldstate.DefaultDBName))
+	_, _, err := p.Get(worldstate.DefaultDBName, "key1")
+	require.EqualError(t, err, "database bdb does not exist")
+	_, err = p.Height()
+	require.EqualError(t, err, "unable to retrieve the state database height due to missing metadataDB")
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+)
+
+var errIteratorReleased = errors.New("pebble: iterator released")
+
+// iterator adapts a pebble iterator over the keys of a database to the
+// worldstate.Iterator, which is positioned before the first pair until
+// Next or Seek is called, and strips the database prefix from the keys
+type iterator struct {
+	itr      *pebble.Iterator
+	prefix   []byte
+	started  bool
+	released bool
+	err      error
+}
+
+func newIterator(r reader, dbName string, startKey, endKey string) *iterator {
+	prefix := dbPrefix(dbName)
+
+	opts := &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixEnd(prefix),
+	}
+	if startKey != "" {
+		opts.LowerBound = dbKey(dbName, []byte(startKey))
+	}
+	if endKey != "" {
+		opts.UpperBound = dbKey(dbName, []byte(endKey))
+	}
+
+	return &iterator{
+		itr:    r.NewIter(opts),
+		prefix: prefix,
+	}
+}
+
+// Key returns the key of the current key/value pair, or nil if done
+func (i *iterator) Key() []byte {
+	if i.released || !i.started || !i.itr.Valid() {
+		return nil
+	}
+	return i.itr.Key()[len(i.prefix):]
+}
+
+// Value returns the value of the current key/value pair, or nil if done
+func (i *iterator) Value() []byte {
+	if i.released || !i.started || !i.itr.Valid() {
+		return nil
+	}
+	return i.itr.Value()
+}
+
+// Next moves the iterator to the next key/value pair
+func (i *iterator) Next() bool {
+	if i.released {
+		i.err = errIteratorReleased
+		return false
+	}
+
+	if !i.started {
+		i.started = true
+		return i.itr.First()
+	}
+	return i.itr.Next()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key
+func (i *iterator) Seek(key []byte) bool {
+	if i.released {
+		i.err = errIteratorReleased
+		return false
+	}
+
+	i.started = true
+	return i.itr.SeekGE(append(append([]byte{}, i.prefix...), key...))
+}
+
+// Error returns any accumulated error
+func (i *iterator) Error() error {
+	if i.err != nil {
+		return i.err
+	}
+	if i.released {
+		return nil
+	}
+	return i.itr.Error()
+}
+
+// Release releases the iterator. It can be called multiple times.
+func (i *iterator) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+
+	if err := i.itr.Close(); err != nil && i.err == nil {
+		i.err = err
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pebbledb
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+var (
+	// allowedCharsInDBName holds the regexp for allowed characters
+	// in a database name
+	allowedCharsInDBName = `^[0-9a-zA-Z_\-\.]+$`
+
+	preCreateDBs = append(
+		worldstate.SystemDBs(),
+		worldstate.DefaultDBName,
+	)
+)
+
+// PebbleDB holds all the databases in a single pebble instance. The key-value
+// pairs of a database are stored under the keys prefixed by the name of the
+// database, and the names of all the databases are stored under a registry
+// prefix. As a result, the updates of a block to all the databases, including
+// the creation and deletion of databases, are committed in a single atomic batch.
+type PebbleDB struct {
+	dbRootDir   string
+	file        *pebble.DB
+	dbs         map[string]struct{}
+	logger      *logger.SugarLogger
+	dbsList     sync.RWMutex
+	dbNameRegex *regexp.Regexp
+}
+
+type Config struct {
+	DBRootDir string
+	Logger    *logger.SugarLogger
+}
+
+// Open opens a pebble instance to maintain world state. The instance is created
+// if it does not exist.
+func Open(conf *Config) (*PebbleDB, error) {
+	if err := fileops.CreateDir(conf.DBRootDir); err != nil {
+		return nil, errors.WithMessagef(err, "failed to create director %s", conf.DBRootDir)
+	}
+
+	file, err := pebble.Open(conf.DBRootDir, &pebble.Options{
+		Logger: &pebbleLogger{lg: conf.Logger},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open pebble instance at %s", conf.DBRootDir)
+	}
+
+	p := &PebbleDB{
+		dbRootDir:   conf.DBRootDir,
+		file:        file,
+		dbs:         make(map[string]struct{}),
+		logger:      conf.Logger,
+		dbNameRegex: regexp.MustCompile(allowedCharsInDBName),
+	}
+
+	if err := p.loadDBs(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// loadDBs reads the names of the existing databases from the registry. As the
+// creation of a new instance is a single batch, an empty registry denotes a new
+// instance, in which all the pre-created databases are created. In an existing
+// instance, only the system databases introduced after the instance has been
+// created are created.
+func (p *PebbleDB) loadDBs() error {
+	itr := p.file.NewIter(&pebble.IterOptions{
+		LowerBound: registryPrefix,
+		UpperBound: prefixEnd(registryPrefix),
+	})
+	for valid := itr.First(); valid; valid = itr.Next() {
+		p.dbs[string(itr.Key()[len(registryPrefix):])] = struct{}{}
+	}
+	if err := itr.Close(); err != nil {
+		return errors.Wrap(err, "error while reading the existing databases")
+	}
+
+	toCreate := worldstate.SystemDBs()
+	if len(p.dbs) == 0 {
+		toCreate = preCreateDBs
+	}
+
+	batch := p.file.NewBatch()
+	defer batch.Close()
+
+	for _, dbName := range toCreate {
+		if _, ok := p.dbs[dbName]; ok {
+			continue
+		}
+		if err := batch.Set(registryKey(dbName), nil, nil); err != nil {
+			return errors.Wrapf(err, "error while creating database %s", dbName)
+		}
+	}
+
+	if batch.Empty() {
+		return nil
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return errors.Wrap(err, "error while creating the system databases")
+	}
+
+	for _, dbName := range toCreate {
+		p.dbs[dbName] = struct{}{}
+	}
+
+	return nil
+}
+
+// Close closes the pebble instance
+func (p *PebbleDB) Close() error {
+	p.dbsList.Lock()
+	defer p.dbsList.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+
+	if err := p.file.Close(); err != nil {
+		return errors.Wrap(err, "error while closing the pebble instance")
+	}
+	p.file = nil
+	p.dbs = make(map[string]struct{})
+
+	return nil
+}
+
+// ValidDBName returns true if the given dbName is valid, i.e., it is made of
+// the allowed characters and does not start with a reserved prefix
+func (p *PebbleDB) ValidDBName(dbName string) bool {
+	return p.dbNameRegex.MatchString(dbName) && !worldstate.IsReservedDBName(dbName)
+}
+
+// pebbleLogger routes the informational messages of pebble, such as those
+// about flushes and compactions, to the debug level
+type pebbleLogger struct {
+	lg *logger.SugarLogger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.lg.Debugf(format, args...)
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.lg.Fatalf(format, args...)
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pebbledb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Snapshots holds a single pebble snapshot, which is consistent across all the
+// snapshotted databases
+type Snapshots struct {
+	snap    *pebble.Snapshot
+	dbNames map[string]struct{}
+	sync.RWMutex
+}
+
+// GetDBsSnapshot returns a latest snapshot of the given databases
+func (p *PebbleDB) GetDBsSnapshot(dbNames []string) (worldstate.DBsSnapshot, error) {
+	p.dbsList.RLock()
+	defer p.dbsList.RUnlock()
+
+	snapshotted := make(map[string]struct{})
+	for _, dbName := range dbNames {
+		if _, ok := p.dbs[dbName]; !ok {
+			return nil, &DBNotFoundErr{
+				dbName: dbName,
+			}
+		}
+		snapshotted[dbName] = struct{}{}
+	}
+
+	return &Snapshots{
+		snap:    p.file.NewSnapshot(),
+		dbNames: snapshotted,
+	}, nil
+}
+
+func (s *Snapshots) Get(dbName, key string) ([]byte, *types.Metadata, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if _, ok := s.dbNames[dbName]; !ok {
+		return nil, nil, errors.New(dbName + " is needed to fetch the index definiton and is not snapshotted")
+	}
+
+	return get(s.snap, dbName, key)
+}
+
+func (s *Snapshots) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error) {
+	return s.Get(worldstate.DatabasesDBName, dbName)
+}
+
+func (s *Snapshots) GetIterator(dbName string, startKey, endKey string) (worldstate.Iterator, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if _, ok := s.dbNames[dbName]; !ok {
+		return nil, errors.New(dbName + " database is not snapshotted")
+	}
+
+	return newIterator(s.snap, dbName, startKey, endKey), nil
+}
+
+// Height returns the block height of the snapshot
+func (s *Snapshots) Height() (uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if _, ok := s.dbNames[worldstate.MetadataDBName]; !ok {
+		return 0, errors.New(worldstate.MetadataDBName + " is needed to fetch the height and is not snapshotted")
+	}
+
+	blockNumberEnc, closer, err := s.snap.Get(dbKey(worldstate.MetadataDBName, lastCommittedBlockNumberKey))
+	if err == pebble.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error while retrieving the height from the snapshot")
+	}
+	defer closer.Close()
+
+	blockNumberDec, err := binary.ReadUvarint(bytes.NewBuffer(blockNumberEnc))
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the stored height")
+	}
+
+	return blockNumberDec, nil
+}
+
+func (s *Snapshots) Release() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.snap == nil {
+		return
+	}
+
+	s.snap.Close()
+	s.snap = nil
+	s.dbNames = nil
+}
@@ -0,0 +1,132 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attestation defines the attestations the servers make on the headers of the blocks they commit, and the
+// quorum certificates made of them. A quorum certificate lets a client verify that a majority of the consensus
+// members agreed on a block header it received from a single server.
+package attestation
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const signingPrefix = "orion-block-attestation"
+
+// HeaderHash returns the hash of a block header that the attestations sign, i.e., the SHA256 hash of its deterministic
+// marshaling, so that all the servers and clients compute the same hash from the same header.
+func HeaderHash(header *types.BlockHeader) ([]byte, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(header); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal block header")
+	}
+	return crypto.ComputeSHA256Hash(buf.Bytes())
+}
+
+// SigningBytes returns the bytes a server signs to attest the header with the given hash of the block with the given
+// number.
+func SigningBytes(blockNumber uint64, headerHash []byte) []byte {
+	b := make([]byte, len(signingPrefix)+8, len(signingPrefix)+8+len(headerHash))
+	copy(b, signingPrefix)
+	binary.BigEndian.PutUint64(b[len(signingPrefix):], blockNumber)
+	return append(b, headerHash...)
+}
+
+// Sign returns the attestation of the node on the header with the given hash of the block with the given number.
+func Sign(signer crypto.Signer, nodeID string, blockNumber uint64, headerHash []byte) (*types.BlockAttestation, error) {
+	signature, err := signer.Sign(SigningBytes(blockNumber, headerHash))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign the header of block [%d]", blockNumber)
+	}
+	return &types.BlockAttestation{
+		BlockNumber: blockNumber,
+		NodeId:      nodeID,
+		Signature:   signature,
+	}, nil
+}
+
+// Quorum returns the number of attestations of distinct consensus members that a quorum certificate needs, i.e., a
+// majority of the consensus members of the cluster config.
+func Quorum(clusterConfig *types.ClusterConfig) int {
+	return len(clusterConfig.GetConsensusConfig().GetMembers())/2 + 1
+}
+
+// Verifiers returns the verifiers of the consensus members of the cluster config, by node ID.
+func Verifiers(clusterConfig *types.ClusterConfig) (map[string]*crypto.Verifier, error) {
+	certs := make(map[string][]byte)
+	for _, node := range clusterConfig.GetNodes() {
+		certs[node.Id] = node.Certificate
+	}
+
+	verifiers := make(map[string]*crypto.Verifier)
+	for _, member := range clusterConfig.GetConsensusConfig().GetMembers() {
+		verifier, err := crypto.NewVerifier(certs[member.NodeId])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create a verifier for consensus member [%s]", member.NodeId)
+		}
+		verifiers[member.NodeId] = verifier
+	}
+	return verifiers, nil
+}
+
+// VerifyQuorumCertificate verifies that the quorum certificate attests the block header, with the valid attestations
+// of a quorum of the consensus members. The cluster config must be the one that was in effect when the block was
+// committed.
+func VerifyQuorumCertificate(qc *types.QuorumCertificate, header *types.BlockHeader, clusterConfig *types.ClusterConfig) error {
+	if qc == nil {
+		return errors.New("quorum certificate is nil")
+	}
+
+	blockNumber := header.GetBaseHeader().GetNumber()
+	if qc.BlockNumber != blockNumber {
+		return errors.Errorf("quorum certificate of block [%d] does not match block [%d]", qc.BlockNumber, blockNumber)
+	}
+	headerHash, err := HeaderHash(header)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(qc.HeaderHash, headerHash) {
+		return errors.Errorf("quorum certificate of block [%d] does not match the hash of the block header", blockNumber)
+	}
+
+	verifiers, err := Verifiers(clusterConfig)
+	if err != nil {
+		return err
+	}
+	signed := SigningBytes(blockNumber, headerHash)
+	attested := make(map[string]bool)
+	for _, a := range qc.Attestations {
+		if err := verifyAttestation(a, blockNumber, signed, verifiers, attested); err != nil {
+			return err
+		}
+		attested[a.NodeId] = true
+	}
+
+	if quorum := Quorum(clusterConfig); len(attested) < quorum {
+		return errors.Errorf("block [%d] is attested by [%d] consensus members, a quorum is [%d]", blockNumber, len(attested), quorum)
+	}
+	return nil
+}
+
+func verifyAttestation(a *types.BlockAttestation, blockNumber uint64, signed []byte, verifiers map[string]*crypto.Verifier, attested map[string]bool) error {
+	if a.GetBlockNumber() != blockNumber {
+		return errors.Errorf("attestation of [%s] is on block [%d], not on block [%d]", a.GetNodeId(), a.GetBlockNumber(), blockNumber)
+	}
+	verifier, ok := verifiers[a.NodeId]
+	if !ok {
+		return errors.Errorf("block [%d] is attested by [%s], which is not a consensus member", blockNumber, a.NodeId)
+	}
+	if attested[a.NodeId] {
+		return errors.Errorf("block [%d] is attested more than once by [%s]", blockNumber, a.NodeId)
+	}
+	if err := verifier.Verify(signed, a.Signature); err != nil {
+		return errors.Wrapf(err, "block [%d] has an invalid attestation by [%s]", blockNumber, a.NodeId)
+	}
+	return nil
+}
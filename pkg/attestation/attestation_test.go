@@ -0,0 +1,180 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attestation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/attestation"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testClusterConfig(t *testing.T, n int) (*types.ClusterConfig, map[string]crypto.Signer) {
+	var names []string
+	for i := 1; i <= n; i++ {
+		names = append(names, fmt.Sprintf("node%d", i))
+	}
+	cryptoDir := testutils.GenerateTestCrypto(t, append(names, "observer"))
+
+	clusterConfig := &types.ClusterConfig{ConsensusConfig: &types.ConsensusConfig{}}
+	signers := make(map[string]crypto.Signer)
+	for i, name := range append(names, "observer") {
+		cert, signer := testutils.LoadTestCrypto(t, cryptoDir, name)
+		signers[name] = signer
+		clusterConfig.Nodes = append(clusterConfig.Nodes, &types.NodeConfig{Id: name, Certificate: cert.Raw})
+		peer := &types.PeerConfig{NodeId: name, RaftId: uint64(i + 1)}
+		if name == "observer" {
+			clusterConfig.ConsensusConfig.Observers = append(clusterConfig.ConsensusConfig.Observers, peer)
+		} else {
+			clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members, peer)
+		}
+	}
+	return clusterConfig, signers
+}
+
+func TestHeaderHash(t *testing.T) {
+	header := &types.BlockHeader{
+		BaseHeader: &types.BlockHeaderBase{Number: 5, PreviousBaseHeaderHash: []byte("prev")},
+		ValidationInfo: []*types.ValidationInfo{
+			{Flag: types.Flag_VALID},
+			{Flag: types.Flag_INVALID_NO_PERMISSION, ReasonIfInvalid: "no permission"},
+		},
+	}
+	hash, err := attestation.HeaderHash(header)
+	require.NoError(t, err)
+	require.Len(t, hash, 32)
+
+	for i := 0; i < 10; i++ {
+		h, err := attestation.HeaderHash(proto.Clone(header).(*types.BlockHeader))
+		require.NoError(t, err)
+		require.Equal(t, hash, h)
+	}
+
+	changed := proto.Clone(header).(*types.BlockHeader)
+	changed.ValidationInfo[1].Flag = types.Flag_VALID
+	h, err := attestation.HeaderHash(changed)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, h)
+}
+
+func TestSigningBytes(t *testing.T) {
+	require.Equal(t, []byte("orion-block-attestation\x00\x00\x00\x00\x00\x00\x01\x02hash"), attestation.SigningBytes(258, []byte("hash")))
+	require.NotEqual(t, attestation.SigningBytes(1, []byte("hash")), attestation.SigningBytes(2, []byte("hash")))
+}
+
+func TestQuorum(t *testing.T) {
+	for n, quorum := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 3, 7: 4} {
+		clusterConfig := &types.ClusterConfig{ConsensusConfig: &types.ConsensusConfig{}}
+		for i := 0; i < n; i++ {
+			clusterConfig.ConsensusConfig.Members = append(clusterConfig.ConsensusConfig.Members, &types.PeerConfig{})
+		}
+		require.Equal(t, quorum, attestation.Quorum(clusterConfig), "members: %d", n)
+	}
+}
+
+func TestVerifiers(t *testing.T) {
+	clusterConfig, _ := testClusterConfig(t, 3)
+	verifiers, err := attestation.Verifiers(clusterConfig)
+	require.NoError(t, err)
+	require.Len(t, verifiers, 3)
+	for _, name := range []string{"node1", "node2", "node3"} {
+		require.NotNil(t, verifiers[name])
+	}
+
+	clusterConfig.Nodes[1].Certificate = []byte("bogus-cert")
+	_, err = attestation.Verifiers(clusterConfig)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to create a verifier for consensus member [node2]")
+}
+
+func TestVerifyQuorumCertificate(t *testing.T) {
+	clusterConfig, signers := testClusterConfig(t, 4)
+	header := &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 5}}
+	headerHash, err := attestation.HeaderHash(header)
+	require.NoError(t, err)
+
+	sign := func(nodeID string, blockNumber uint64, headerHash []byte) *types.BlockAttestation {
+		a, err := attestation.Sign(signers[nodeID], nodeID, blockNumber, headerHash)
+		require.NoError(t, err)
+		return a
+	}
+	attest := func(nodeIDs ...string) []*types.BlockAttestation {
+		var attestations []*types.BlockAttestation
+		for _, nodeID := range nodeIDs {
+			attestations = append(attestations, sign(nodeID, 5, headerHash))
+		}
+		return attestations
+	}
+
+	for _, tt := range []struct {
+		name        string
+		qc          *types.QuorumCertificate
+		expectedErr string
+	}{
+		{
+			name: "valid: quorum",
+			qc:   &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash, Attestations: attest("node1", "node3", "node4")},
+		},
+		{
+			name: "valid: all",
+			qc:   &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash, Attestations: attest("node1", "node2", "node3", "node4")},
+		},
+		{
+			name:        "invalid: nil",
+			expectedErr: "quorum certificate is nil",
+		},
+		{
+			name:        "invalid: another block",
+			qc:          &types.QuorumCertificate{BlockNumber: 6, HeaderHash: headerHash, Attestations: attest("node1", "node2", "node3")},
+			expectedErr: "quorum certificate of block [6] does not match block [5]",
+		},
+		{
+			name:        "invalid: another header",
+			qc:          &types.QuorumCertificate{BlockNumber: 5, HeaderHash: []byte("hash"), Attestations: attest("node1", "node2", "node3")},
+			expectedErr: "quorum certificate of block [5] does not match the hash of the block header",
+		},
+		{
+			name:        "invalid: no quorum",
+			qc:          &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash, Attestations: attest("node1", "node2")},
+			expectedErr: "block [5] is attested by [2] consensus members, a quorum is [3]",
+		},
+		{
+			name:        "invalid: attested twice",
+			qc:          &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash, Attestations: attest("node1", "node2", "node2")},
+			expectedErr: "block [5] is attested more than once by [node2]",
+		},
+		{
+			name:        "invalid: observer",
+			qc:          &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash, Attestations: attest("node1", "node2", "observer")},
+			expectedErr: "block [5] is attested by [observer], which is not a consensus member",
+		},
+		{
+			name: "invalid: attestation on another block",
+			qc: &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash,
+				Attestations: append(attest("node1", "node2"), sign("node3", 4, headerHash))},
+			expectedErr: "attestation of [node3] is on block [4], not on block [5]",
+		},
+		{
+			name: "invalid: attestation on another header",
+			qc: &types.QuorumCertificate{BlockNumber: 5, HeaderHash: headerHash,
+				Attestations: append(attest("node1", "node2"), sign("node3", 5, []byte("hash")))},
+			expectedErr: "block [5] has an invalid attestation by [node3]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := attestation.VerifyQuorumCertificate(tt.qc, header, clusterConfig)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package constants
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"regexp"
 
@@ -33,15 +34,20 @@ const (
 	SignatureHeader = "Signature"
 	TimeoutHeader   = "TxTimeout"
 
+	// LinearizableConsistency is the value of the `consistency` query parameter that makes the node serve a data
+	// query only after its commit had reached the leader's commit, as determined by a Raft ReadIndex round.
+	LinearizableConsistency = "linearizable"
+
 	UserEndpoint = "/user/"
 	GetUser      = "/user/{userid}"
 	PostUserTx   = "/user/tx"
 
-	DataEndpoint  = "/data/"
-	GetData       = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
-	GetDataRange  = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}"
-	PostDataTx    = "/data/tx"
-	PostDataQuery = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/jsonquery"
+	DataEndpoint        = "/data/"
+	GetData             = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
+	GetDataRange        = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}"
+	PostDataTx          = "/data/tx"
+	PostDataProcedureTx = "/data/procedure/tx"
+	PostDataQuery       = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/jsonquery"
 
 	DBEndpoint  = "/db/"
 	GetDBStatus = "/db/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}"
@@ -55,16 +61,20 @@ const (
 	GetNodeConfig      = "/config/node/{nodeId}"
 	GetLastConfigBlock = "/config/block/last"
 	GetClusterStatus   = "/config/cluster"
-
-	LedgerEndpoint     = "/ledger/"
-	GetBlockHeader     = "/ledger/block/{blockId:[0-9]+}"
-	GetLastBlockHeader = "/ledger/block/last"
-	GetPath            = "/ledger/path"
-	GetTxProofPrefix   = "/ledger/proof/tx"
-	GetTxProof         = "/ledger/proof/tx/{blockId:[0-9]+}"
-	GetDataProofPrefix = "/ledger/proof/data"
-	GetDataProof       = "/ledger/proof/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
-	GetTxReceipt       = "/ledger/tx/receipt/{txId}"
+	PostClusterLeader  = "/config/cluster/leader"
+
+	LedgerEndpoint            = "/ledger/"
+	GetBlockHeader            = "/ledger/block/{blockId:[0-9]+}"
+	GetLastBlockHeader        = "/ledger/block/last"
+	GetPath                   = "/ledger/path"
+	GetLedgerConsistencyProof = "/ledger/proof/consistency"
+	GetTxProofPrefix          = "/ledger/proof/tx"
+	GetTxProof                = "/ledger/proof/tx/{blockId:[0-9]+}"
+	GetTxProofs               = "/ledger/proof/txs/{blockId:[0-9]+}"
+	GetDataProofPrefix        = "/ledger/proof/data"
+	GetDataProof              = "/ledger/proof/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
+	GetDataProofs             = "/ledger/proof/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}"
+	GetTxReceipt              = "/ledger/tx/receipt/{txId}"
 
 	ProvenanceEndpoint      = "/provenance/"
 	GetHistoricalData       = "/provenance/data/history/{dbname}/{key}"
@@ -75,6 +85,14 @@ const (
 	GetDataDeletedBy        = "/provenance/data/deleted/{userId}"
 	GetTxIDsSubmittedBy     = "/provenance/data/tx/{userId}"
 	GetMostRecentUserOrNode = "/provenance/{type:user|node}/{id}"
+
+	// DebugEndpoint serves admin-only requests that are meant for testing, and are enabled by the local configuration
+	DebugEndpoint     = "/debug/"
+	PostNetworkFaults = "/debug/network/faults"
+
+	// MetricsEndpoint exposes the server metrics in the Prometheus text format, on the separate listener set by
+	// `server.metrics` in the local configuration
+	MetricsEndpoint = "/metrics"
 )
 
 // URLForGetData returns url for GET request to retrieve
@@ -90,6 +108,18 @@ func URLForGetDataRange(dbName, startKey, endKey string, limit uint64) string {
 		fmt.Sprintf("?startkey=\"%s\"&endkey=\"%s\"&limit=%d", startKey, endKey, limit)
 }
 
+// URLForGetLinearizableData returns url for GET request to retrieve
+// value of the key present in the dbName, with a linearizable read
+func URLForGetLinearizableData(dbName, key string) string {
+	return URLForGetData(dbName, key) + "?consistency=" + LinearizableConsistency
+}
+
+// URLForGetLinearizableDataRange returns url for GET request to retrieve
+// a range of values, with a linearizable read
+func URLForGetLinearizableDataRange(dbName, startKey, endKey string, limit uint64) string {
+	return URLForGetDataRange(dbName, startKey, endKey, limit) + "&consistency=" + LinearizableConsistency
+}
+
 // URLForJSONQuery returns url for GET request to retrieve
 // key-value pairs present in the dbName which are matching the
 // given JSON query criteria
@@ -136,10 +166,30 @@ func URLForLedgerPath(start, end uint64) string {
 	return LedgerEndpoint + fmt.Sprintf("path?start=%d&end=%d", start, end)
 }
 
+// URLForLedgerConsistencyProof returns url for GET request to retrieve the proof
+// that the ledger at the end block extends the ledger at the start block
+func URLForLedgerConsistencyProof(start, end uint64) string {
+	return LedgerEndpoint + fmt.Sprintf("proof/consistency?start=%d&end=%d", start, end)
+}
+
 func URLTxProof(blockNum uint64, txIdx uint64) string {
 	return LedgerEndpoint + fmt.Sprintf("proof/tx/%d?idx=%d", blockNum, txIdx)
 }
 
+// URLTxProofs returns url for GET request to retrieve the proof of
+// the inclusion of several txs in the block
+func URLTxProofs(blockNum uint64, txIndexes []uint64) string {
+	query := ""
+	for i, txIdx := range txIndexes {
+		if i == 0 {
+			query += fmt.Sprintf("?idx=%d", txIdx)
+		} else {
+			query += fmt.Sprintf("&idx=%d", txIdx)
+		}
+	}
+	return LedgerEndpoint + fmt.Sprintf("proof/txs/%d", blockNum) + query
+}
+
 func URLDataProof(blockNum uint64, dbname, key string, deleted bool) string {
 	if deleted {
 		return LedgerEndpoint + fmt.Sprintf("proof/data/%s/%s?block=%d&deleted=%t", dbname, key, blockNum, deleted)
@@ -147,6 +197,22 @@ func URLDataProof(blockNum uint64, dbname, key string, deleted bool) string {
 	return LedgerEndpoint + fmt.Sprintf("proof/data/%s/%s?block=%d", dbname, key, blockNum)
 }
 
+// URLDataAbsenceProof returns url for GET request to retrieve the proof
+// that the key was never written in the dbName, as of the given block
+func URLDataAbsenceProof(blockNum uint64, dbname, key string) string {
+	return LedgerEndpoint + fmt.Sprintf("proof/data/%s/%s?block=%d&absent=true", dbname, key, blockNum)
+}
+
+// URLDataProofs returns url for GET request to retrieve the proof of
+// the state of several keys in the dbName, as of the given block
+func URLDataProofs(blockNum uint64, dbname string, keys []string) string {
+	query := fmt.Sprintf("?block=%d", blockNum)
+	for _, key := range keys {
+		query += "&key=" + url.QueryEscape(key)
+	}
+	return LedgerEndpoint + fmt.Sprintf("proof/data/%s", dbname) + query
+}
+
 func URLForNodeConfigPath(nodeID string) string {
 	return path.Join(GetNodeConfigPath, nodeID)
 }
@@ -34,6 +34,20 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/data/db1?startkey=\"key1\"&endkey=\"key10\"&limit=10",
 		},
+		{
+			name: "GetLinearizableData",
+			execute: func() string {
+				return URLForGetLinearizableData("db1", "key1")
+			},
+			expectedURL: "/data/db1/key1?consistency=linearizable",
+		},
+		{
+			name: "GetLinearizableDataRange",
+			execute: func() string {
+				return URLForGetLinearizableDataRange("db1", "key1", "key10", 10)
+			},
+			expectedURL: "/data/db1?startkey=\"key1\"&endkey=\"key10\"&limit=10&consistency=linearizable",
+		},
 		{
 			name: "JSONQuery",
 			execute: func() string {
@@ -125,6 +139,34 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/ledger/proof/data/db1/key?block=1&deleted=true",
 		},
+		{
+			name: "URLForLedgerConsistencyProof",
+			execute: func() string {
+				return URLForLedgerConsistencyProof(2, 17)
+			},
+			expectedURL: "/ledger/proof/consistency?start=2&end=17",
+		},
+		{
+			name: "URLTxProofs",
+			execute: func() string {
+				return URLTxProofs(1, []uint64{2, 5})
+			},
+			expectedURL: "/ledger/proof/txs/1?idx=2&idx=5",
+		},
+		{
+			name: "URLDataAbsenceProof",
+			execute: func() string {
+				return URLDataAbsenceProof(1, "db1", "key")
+			},
+			expectedURL: "/ledger/proof/data/db1/key?block=1&absent=true",
+		},
+		{
+			name: "URLDataProofs",
+			execute: func() string {
+				return URLDataProofs(1, "db1", []string{"key1", "key 2&"})
+			},
+			expectedURL: "/ledger/proof/data/db1?block=1&key=key1&key=key+2%26",
+		},
 		{
 			name: "URLForGetHistoricalData",
 			execute: func() string {
@@ -15,6 +15,8 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	case *types.GetConfigQuery:
 	case *types.GetConfigBlockQuery:
 	case *types.GetClusterStatusQuery:
+	case *types.TransferLeadershipRequest:
+	case *types.SetNetworkFaultsRequest:
 	case *types.GetDataQuery:
 	case *types.GetDataRangeQuery:
 	case *types.GetDBStatusQuery:
@@ -23,8 +25,10 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	case *types.GetBlockQuery:
 	case *types.GetLastBlockQuery:
 	case *types.GetLedgerPathQuery:
+	case *types.GetLedgerConsistencyProofQuery:
 	case *types.GetNodeConfigQuery:
 	case *types.GetTxProofQuery:
+	case *types.GetTxProofsQuery:
 	case *types.GetTxReceiptQuery:
 	case *types.GetHistoricalDataQuery:
 	case *types.GetDataReadersQuery:
@@ -35,6 +39,7 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	case *types.GetTxIDsSubmittedByQuery:
 	case *types.GetMostRecentUserOrNodeQuery:
 	case *types.GetDataProofQuery:
+	case *types.GetDataProofsQuery:
 	case *types.DataJSONQuery:
 
 	default:
@@ -48,8 +53,10 @@ func SignTx(txSigner crypto.Signer, tx interface{}) ([]byte, error) {
 	switch v := tx.(type) {
 	case *types.ConfigTx:
 	case *types.DataTx:
+	case *types.ProcedureTx:
 	case *types.UserAdministrationTx:
 	case *types.DBAdministrationTx:
+	case *types.ExpiryTx:
 
 	default:
 		return nil, errors.Errorf("unknown transaction type: %T", v)
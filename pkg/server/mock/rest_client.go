@@ -426,6 +426,29 @@ func (c *Client) ExecuteJSONQuery(urlPath string, e *types.DataJSONQuery, signat
 	return res, err
 }
 
+func (c *Client) SetNetworkFaults(e *types.SetNetworkFaultsRequestEnvelope) (*types.SetNetworkFaultsResponseEnvelope, error) {
+	requestBytes, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.handlePostRequest(
+		constants.PostNetworkFaults,
+		e.Payload.UserId,
+		requestBytes,
+		e.Signature,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while issuing "+constants.PostNetworkFaults)
+	}
+
+	defer resp.Body.Close()
+
+	res := &types.SetNetworkFaultsResponseEnvelope{}
+	err = json.NewDecoder(resp.Body).Decode(res)
+	return res, err
+}
+
 func (c *Client) handlePostRequest(urlPath string, userID string, postData, signature []byte) (*http.Response, error) {
 	parsedURL, err := url.Parse(urlPath)
 	if err != nil {
@@ -18,16 +18,19 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // BCDBHTTPServer holds the database and http server objects
 type BCDBHTTPServer struct {
-	db      bcdb.DB
-	handler http.Handler
-	listen  net.Listener
-	server  *http.Server
-	conf    *config.Configurations
-	logger  *logger.SugarLogger
+	db            bcdb.DB
+	handler       http.Handler
+	listen        net.Listener
+	server        *http.Server
+	metricsListen net.Listener
+	metricsServer *http.Server
+	conf          *config.Configurations
+	logger        *logger.SugarLogger
 }
 
 // New creates a object of BCDBHTTPServer
@@ -56,6 +59,7 @@ func New(conf *config.Configurations) (*BCDBHTTPServer, error) {
 	mux.Handle(constants.ConfigEndpoint, httphandler.NewConfigRequestHandler(db, lg))
 	mux.Handle(constants.LedgerEndpoint, httphandler.NewLedgerRequestHandler(db, lg))
 	mux.Handle(constants.ProvenanceEndpoint, httphandler.NewProvenanceRequestHandler(db, lg))
+	mux.Handle(constants.DebugEndpoint, httphandler.NewDebugRequestHandler(db, lg))
 
 	netConf := conf.LocalConfig.Server.Network
 	addr := fmt.Sprintf("%s:%d", netConf.Address, netConf.Port)
@@ -113,14 +117,34 @@ func New(conf *config.Configurations) (*BCDBHTTPServer, error) {
 		server.TLSConfig = tlsServerConfig
 	}
 
-	return &BCDBHTTPServer{
+	s := &BCDBHTTPServer{
 		db:      db,
 		handler: mux,
 		listen:  netListener,
 		server:  server,
 		conf:    conf,
 		logger:  lg,
-	}, nil
+	}
+
+	// the metrics are not authenticated, hence they are served on a separate listener, which is bound to an
+	// interface that is reachable by the monitoring system only
+	if metricsConf := conf.LocalConfig.Server.Metrics; metricsConf != nil {
+		metricsAddr := fmt.Sprintf("%s:%d", metricsConf.Address, metricsConf.Port)
+		s.metricsListen, err = net.Listen("tcp", metricsAddr)
+		if err != nil {
+			lg.Errorf("Failed to create a tcp listener on: %s, error: %s", metricsAddr, err)
+			netListener.Close()
+			return nil, errors.Wrapf(err, "error while creating a tcp listener for the metrics on: %s", metricsAddr)
+		}
+
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle(constants.MetricsEndpoint, promhttp.Handler())
+		s.metricsServer = &http.Server{
+			Handler: metricsMux,
+		}
+	}
+
+	return s, nil
 }
 
 // Start starts the server
@@ -140,10 +164,24 @@ func (s *BCDBHTTPServer) Start() error {
 	}
 
 	go s.serveRequests(s.listen)
+	if s.metricsServer != nil {
+		go s.serveMetrics()
+	}
 
 	return nil
 }
 
+func (s *BCDBHTTPServer) serveMetrics() {
+	s.logger.Infof("Starting to serve metrics on: %s", s.metricsListen.Addr().String())
+
+	if err := s.metricsServer.Serve(s.metricsListen); err != http.ErrServerClosed {
+		s.logger.Errorf("metrics server stopped unexpectedly, %v", err)
+		return
+	}
+
+	s.logger.Infof("Finished serving metrics on: %s", s.metricsListen.Addr().String())
+}
+
 func (s *BCDBHTTPServer) serveRequests(l net.Listener) {
 	s.logger.Infof("Starting to serve requests on: %s", s.listen.Addr().String())
 
@@ -177,6 +215,13 @@ func (s *BCDBHTTPServer) Stop() error {
 		errR = err
 	}
 
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Close(); err != nil {
+			s.logger.Errorf("Failure while closing the metrics server: %s", err)
+			errR = err
+		}
+	}
+
 	if err := s.db.Close(); err != nil {
 		s.logger.Errorf("Failure while closing the database: %s", err)
 		errR = err
@@ -190,6 +235,15 @@ func (s *BCDBHTTPServer) Port() (port string, err error) {
 	return
 }
 
+// MetricsPort returns the port number of the metrics listener, if the metrics are served
+func (s *BCDBHTTPServer) MetricsPort() (port string, err error) {
+	if s.metricsListen == nil {
+		return "", errors.New("the metrics are not served")
+	}
+	_, port, err = net.SplitHostPort(s.metricsListen.Addr().String())
+	return
+}
+
 func (s *BCDBHTTPServer) IsLeader() *ierrors.NotLeaderError {
 	return s.db.IsLeader()
 }
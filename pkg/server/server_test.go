@@ -136,6 +136,10 @@ func (env *serverTestEnv) getConfigResponse(t *testing.T) *types.GetConfigRespon
 }
 
 func newServerTestEnv(t *testing.T, serverTLS bool, clientTLS bool) *serverTestEnv {
+	return newServerTestEnvWithStateDB(t, serverTLS, clientTLS, "leveldb")
+}
+
+func newServerTestEnvWithStateDB(t *testing.T, serverTLS bool, clientTLS bool, stateDB string) *serverTestEnv {
 	tempDir, err := ioutil.TempDir("/tmp", "serverTest")
 	require.NoError(t, err)
 	t.Cleanup(func() {
@@ -238,7 +242,7 @@ func newServerTestEnv(t *testing.T, serverTLS bool, clientTLS bool) *serverTestE
 					KeyPath:         path.Join(tempDir, "server.key"),
 				},
 				Database: config.DatabaseConf{
-					Name:            "leveldb",
+					Name:            stateDB,
 					LedgerDirectory: path.Join(tempDir, "ledger"),
 				},
 				Network: config.NetworkConf{
@@ -571,7 +575,24 @@ func TestServerWithUserAdminRequest(t *testing.T) {
 }
 
 func TestServerWithDBAdminRequest(t *testing.T) {
-	env := newServerTestEnv(t, false, false)
+	for _, stateDB := range []string{"leveldb", "pebble", "memory"} {
+		t.Run(stateDB, func(t *testing.T) {
+			testServerWithDBAdminRequest(t, stateDB)
+		})
+	}
+}
+
+func TestServerWithInMemoryLedger(t *testing.T) {
+	env := newServerTestEnvWithStateDB(t, false, false, "memory")
+	defer env.cleanup(t)
+
+	// neither the ledger nor the Raft log is written to disk
+	require.NoDirExists(t, path.Join(env.tempDir, "ledger"))
+	require.NoDirExists(t, path.Join(env.tempDir, "raft"))
+}
+
+func testServerWithDBAdminRequest(t *testing.T, stateDB string) {
+	env := newServerTestEnvWithStateDB(t, false, false, stateDB)
 	defer env.cleanup(t)
 
 	dbTx := &types.DBAdministrationTx{
@@ -714,6 +735,37 @@ func TestServerWithConfigRequest(t *testing.T) {
 	require.Equal(t, uint32(0x1e), configRes.Config.ConsensusConfig.RaftConfig.ElectionTicks)
 }
 
+func TestServerMetrics(t *testing.T) {
+	env := newServerTestEnv(t, false, false)
+	defer env.cleanup(t)
+
+	// the metrics are not served on the client facing listener
+	port, err := env.bcdbHTTPServer.Port()
+	require.NoError(t, err)
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%s%s", port, constants.MetricsEndpoint))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	_, err = env.bcdbHTTPServer.MetricsPort()
+	require.EqualError(t, err, "the metrics are not served")
+
+	env.serverConfig.LocalConfig.Server.Metrics = &config.NetworkConf{
+		Address: "127.0.0.1",
+		Port:    0,
+	}
+	env.restart(t)
+
+	metricsPort, err := env.bcdbHTTPServer.MetricsPort()
+	require.NoError(t, err)
+	resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%s%s", metricsPort, constants.MetricsEndpoint))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "go_goroutines")
+}
+
 func TestServerWithFailureScenarios(t *testing.T) {
 	testCases := []struct {
 		testName         string
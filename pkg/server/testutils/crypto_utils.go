@@ -278,6 +278,18 @@ func SignedDataTxEnvelope(t *testing.T, signers []crypto.Signer, tx *types.DataT
 	return env
 }
 
+func SignedProcedureTxEnvelope(t *testing.T, signers []crypto.Signer, tx *types.ProcedureTx) *types.ProcedureTxEnvelope {
+	env := &types.ProcedureTxEnvelope{
+		Payload:    tx,
+		Signatures: map[string][]byte{},
+	}
+
+	for _, signer := range signers {
+		env.Signatures[signer.Identity()] = SignatureFromTx(t, signer, tx)
+	}
+	return env
+}
+
 func SignedConfigTxEnvelope(t *testing.T, signer crypto.Signer, tx *types.ConfigTx) *types.ConfigTxEnvelope {
 	env := &types.ConfigTxEnvelope{
 		Payload:   tx,
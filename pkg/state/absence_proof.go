@@ -0,0 +1,347 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// branchChildrenCount is the number of children of a branch node, one per nibble
+	branchChildrenCount = 16
+	// hashSize is the size of the hash of a trie node and of a value pointer
+	hashSize = 32
+)
+
+// AbsenceProof contains the path in Merkle-Patricia Trie showing that a key was never written.
+// Each path element contains the bytes of a trie node, as in Proof. The path goes from the root
+// down to the node at which the path of the key ends, and the witnesses prove the keys below that
+// node. All the keys of the trie must have the same length, as the composite keys of the state
+// trie do.
+type AbsenceProof struct {
+	path      []*types.MPTrieProofElement
+	witnesses []*types.MPTrieWitness
+}
+
+func NewAbsenceProof(path []*types.MPTrieProofElement, witnesses []*types.MPTrieWitness) *AbsenceProof {
+	return &AbsenceProof{path: path, witnesses: witnesses}
+}
+
+// NewAbsenceProofFromProto creates the proof held by the response to a query for an absence proof
+func NewAbsenceProofFromProto(p *types.MPTrieAbsenceProof) *AbsenceProof {
+	return NewAbsenceProof(p.GetPath(), p.GetWitnesses())
+}
+
+func (p *AbsenceProof) GetPath() []*types.MPTrieProofElement {
+	return p.path
+}
+
+func (p *AbsenceProof) GetWitnesses() []*types.MPTrieWitness {
+	return p.witnesses
+}
+
+// ToProto returns the proof as held by the response to a query for an absence proof
+func (p *AbsenceProof) ToProto() *types.MPTrieAbsenceProof {
+	return &types.MPTrieAbsenceProof{
+		Path:      p.path,
+		Witnesses: p.witnesses,
+	}
+}
+
+// Verify validates that the path goes from the trie root down to the node at which the path of the
+// trie key ends without reaching it, i.e., an empty child of a branch node, an extension node whose
+// key diverges, or a value node holding another key. As the hash of a branch node only commits to the
+// sequence of its non-empty children, the position of each child of the last branch node is validated
+// by a witness path down to a key having the nibble of that position. In the same way, the key of the
+// last extension node or value node is validated by a witness. As a value pointer is the hash of the
+// full key and the value hash, a witness also binds the nodes above it to the prefix of the trie key.
+// A deleted key is not absent, its deletion is proven by Proof.
+func (p *AbsenceProof) Verify(trieKey, rootHash []byte) (bool, error) {
+	if len(p.path) == 0 {
+		return false, errors.New("proof can't be empty")
+	}
+	if len(trieKey) == 0 {
+		return false, errors.New("trie key can't be empty")
+	}
+
+	hexKey := convertByteToHex(trieKey)
+	hashToFind := rootHash
+	depth := 0
+	for i, element := range p.path {
+		if !hashMatches(element, hashToFind) {
+			return false, nil
+		}
+		isLast := i == len(p.path)-1
+
+		node, err := parseNode(element, len(hexKey)-depth)
+		if err != nil {
+			return false, errors.WithMessagef(err, "invalid proof element at depth %d", depth)
+		}
+
+		switch node.kind {
+		case branchNodeKind:
+			childPtr := node.children[hexKey[depth]]
+			if !isLast {
+				if len(childPtr) == 0 {
+					return false, nil
+				}
+				hashToFind = childPtr
+				depth++
+				continue
+			}
+			if len(childPtr) > 0 {
+				return false, nil
+			}
+			return p.verifyBranchWitnesses(hexKey[:depth], node.children, len(hexKey))
+
+		case extensionNodeKind:
+			keyLeft := hexKey[depth:]
+			if !isLast {
+				if !bytes.HasPrefix(keyLeft, node.key) {
+					return false, nil
+				}
+				hashToFind = node.child
+				depth += len(node.key)
+				continue
+			}
+			if bytes.HasPrefix(keyLeft, node.key) {
+				return false, nil
+			}
+			if len(p.witnesses) != 1 {
+				return false, errors.Errorf("a proof ending at an extension node must have a single witness, %d found", len(p.witnesses))
+			}
+			return verifyWitness(concatNibbles(hexKey[:depth], node.key), node.child, p.witnesses[0], len(hexKey))
+
+		default:
+			if !isLast {
+				return false, errors.Errorf("invalid proof element at depth %d: the path continues below a value node", depth)
+			}
+			if bytes.Equal(hexKey[depth:], node.key) {
+				return false, nil
+			}
+			if len(p.witnesses) != 1 || len(p.witnesses[0].GetPath()) > 0 {
+				return false, errors.New("a proof ending at a value node must have a single witness without path")
+			}
+			return verifyValuePtr(concatNibbles(hexKey[:depth], node.key), node.valuePtr, p.witnesses[0].GetValueHash())
+		}
+	}
+
+	return false, nil
+}
+
+// verifyBranchWitnesses validates the position of each child of the last branch node of the path
+func (p *AbsenceProof) verifyBranchWitnesses(prefix []byte, children [][]byte, hexKeyLen int) (bool, error) {
+	witnessByNibble := make(map[uint32]*types.MPTrieWitness)
+	for _, witness := range p.witnesses {
+		if _, ok := witnessByNibble[witness.GetNibble()]; ok {
+			return false, errors.Errorf("more than one witness for the child at nibble %d", witness.GetNibble())
+		}
+		witnessByNibble[witness.GetNibble()] = witness
+	}
+
+	childrenCount := 0
+	for nibble, childPtr := range children {
+		if len(childPtr) == 0 {
+			continue
+		}
+		childrenCount++
+
+		witness, ok := witnessByNibble[uint32(nibble)]
+		if !ok {
+			return false, errors.Errorf("no witness for the child at nibble %d", nibble)
+		}
+		if ok, err := verifyWitness(concatNibbles(prefix, []byte{byte(nibble)}), childPtr, witness, hexKeyLen); err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if childrenCount != len(p.witnesses) {
+		return false, errors.New("the proof holds witnesses for empty children")
+	}
+	// only the root of an empty trie is a branch node without children
+	if childrenCount == 0 && len(prefix) > 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// verifyWitness validates that the witness path goes from the node with the given hash, whose key
+// starts with the given prefix, down to a value node whose value pointer matches its full key
+func verifyWitness(prefix, nodeHash []byte, witness *types.MPTrieWitness, hexKeyLen int) (bool, error) {
+	path := witness.GetPath()
+	if len(path) == 0 {
+		return false, errors.New("witness path can't be empty")
+	}
+
+	hexKey := append([]byte{}, prefix...)
+	hashToFind := nodeHash
+	for i, element := range path {
+		if !hashMatches(element, hashToFind) {
+			return false, nil
+		}
+		isLast := i == len(path)-1
+
+		node, err := parseNode(element, hexKeyLen-len(hexKey))
+		if err != nil {
+			return false, errors.WithMessagef(err, "invalid witness element at depth %d", len(hexKey))
+		}
+
+		switch node.kind {
+		case branchNodeKind:
+			if isLast {
+				return false, nil
+			}
+			nextHash, err := CalcHash(path[i+1].GetHashes())
+			if err != nil {
+				return false, err
+			}
+			nibble := -1
+			for n, childPtr := range node.children {
+				if len(childPtr) > 0 && bytes.Equal(childPtr, nextHash) {
+					nibble = n
+					break
+				}
+			}
+			if nibble < 0 {
+				return false, nil
+			}
+			hexKey = append(hexKey, byte(nibble))
+			hashToFind = nextHash
+
+		case extensionNodeKind:
+			hexKey = append(hexKey, node.key...)
+			hashToFind = node.child
+
+		default:
+			if !isLast {
+				return false, nil
+			}
+			return verifyValuePtr(concatNibbles(hexKey, node.key), node.valuePtr, witness.GetValueHash())
+		}
+	}
+
+	return false, nil
+}
+
+// verifyValuePtr checks that the value pointer is the hash of the key and the value hash, as
+// calculated by CalculateKeyValueHash
+func verifyValuePtr(hexKey, valuePtr, valueHash []byte) (bool, error) {
+	if len(hexKey)%2 != 0 {
+		return false, nil
+	}
+	key := convertHexToByte(hexKey)
+	expectedValuePtr, err := crypto.ComputeSHA256Hash(append(key, valueHash...))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(expectedValuePtr, valuePtr), nil
+}
+
+type nodeKind int
+
+const (
+	branchNodeKind nodeKind = iota
+	extensionNodeKind
+	valueNodeKind
+)
+
+type parsedNode struct {
+	kind      nodeKind
+	children  [][]byte
+	key       []byte
+	child     []byte
+	valuePtr  []byte
+	isDeleted bool
+}
+
+// parseNode parses the bytes of a trie node whose key, from the node down to a value, has the given
+// number of nibbles left. As the nodes hash the concatenation of their bytes, the kind of a node is
+// only deduced from the number and the sizes of its bytes, which are fully checked:
+//   - a branch node has 16 children, each empty or a node hash, and no value as all the keys have
+//     the same length
+//   - an extension node has a key shorter than the key left and a child node hash
+//   - a value node has the whole key left, a value pointer and, if deleted, the delete marker
+func parseNode(element *types.MPTrieProofElement, keyLeftLen int) (*parsedNode, error) {
+	hashes := element.GetHashes()
+
+	if len(hashes) == branchChildrenCount {
+		if keyLeftLen == 0 {
+			return nil, errors.New("branch node at the end of the key")
+		}
+		for _, childPtr := range hashes {
+			if len(childPtr) != 0 && len(childPtr) != hashSize {
+				return nil, errors.New("branch node with a child of an invalid size")
+			}
+		}
+		return &parsedNode{kind: branchNodeKind, children: hashes}, nil
+	}
+
+	isDeleted := false
+	if len(hashes) > 0 && bytes.Equal(hashes[len(hashes)-1], KeyDeleteMarkerBytes) {
+		isDeleted = true
+		hashes = hashes[:len(hashes)-1]
+		if len(hashes) == 0 {
+			return nil, errors.New("delete marker without value")
+		}
+	}
+
+	var key []byte
+	switch {
+	case len(hashes) == 1 && keyLeftLen == 0:
+	case len(hashes) == 2 && keyLeftLen > 0:
+		key = hashes[0]
+		if len(key) == 0 || len(key) > keyLeftLen {
+			return nil, errors.New("node with a key of an invalid size")
+		}
+		for _, nibble := range key {
+			if nibble >= branchChildrenCount {
+				return nil, errors.New("node with a key holding an invalid nibble")
+			}
+		}
+	default:
+		return nil, errors.New("node with an invalid number of elements")
+	}
+
+	ptr := hashes[len(hashes)-1]
+	if len(ptr) != hashSize {
+		return nil, errors.New("node with a pointer of an invalid size")
+	}
+
+	if len(key) == keyLeftLen {
+		return &parsedNode{kind: valueNodeKind, key: key, valuePtr: ptr, isDeleted: isDeleted}, nil
+	}
+	if isDeleted {
+		return nil, errors.New("extension node with a delete marker")
+	}
+	return &parsedNode{kind: extensionNodeKind, key: key, child: ptr}, nil
+}
+
+func hashMatches(element *types.MPTrieProofElement, hash []byte) bool {
+	elementHash, err := CalcHash(element.GetHashes())
+	return err == nil && bytes.Equal(elementHash, hash)
+}
+
+func concatNibbles(a, b []byte) []byte {
+	res := make([]byte, 0, len(a)+len(b))
+	res = append(res, a...)
+	return append(res, b...)
+}
+
+func convertByteToHex(b []byte) []byte {
+	res := make([]byte, len(b)*2)
+	for i, v := range b {
+		res[2*i] = v >> 4
+		res[2*i+1] = v & 0x0f
+	}
+	return res
+}
+
+func convertHexToByte(hexKey []byte) []byte {
+	res := make([]byte, len(hexKey)/2)
+	for i := range res {
+		res[i] = hexKey[2*i]<<4 | hexKey[2*i+1]
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// LedgerConsistencyProof contains the headers of the blocks in the skip list path from a later block down to an
+// earlier block, both excluded, in descending order. As each block header holds the hashes of the headers of the
+// blocks it links to in the skip list, the path proves that the ledger at the later block extends the ledger at the
+// earlier block. The number of headers in the path is logarithmic in the distance between the blocks.
+type LedgerConsistencyProof struct {
+	path []*types.BlockHeader
+}
+
+func NewLedgerConsistencyProof(path []*types.BlockHeader) *LedgerConsistencyProof {
+	return &LedgerConsistencyProof{path: path}
+}
+
+func (p *LedgerConsistencyProof) GetPath() []*types.BlockHeader {
+	return p.path
+}
+
+// Verify validates that the end block header links, through the headers of the path, to the start block header,
+// which the client holds. No header of the path is trusted: the hash of each header must be held by the header
+// above it, and the block numbers must decrease.
+func (p *LedgerConsistencyProof) Verify(startHeader, endHeader *types.BlockHeader) (bool, error) {
+	if startHeader == nil || endHeader == nil {
+		return false, errors.New("start and end block headers can't be empty")
+	}
+	startNum := startHeader.GetBaseHeader().GetNumber()
+	endNum := endHeader.GetBaseHeader().GetNumber()
+	if endNum < startNum {
+		return false, errors.Errorf("end block number [%d] is smaller than start block number [%d]", endNum, startNum)
+	}
+
+	if startNum == endNum {
+		if len(p.path) > 0 {
+			return false, nil
+		}
+		startHash, err := computeHeaderHash(startHeader)
+		if err != nil {
+			return false, err
+		}
+		endHash, err := computeHeaderHash(endHeader)
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(startHash, endHash), nil
+	}
+
+	current := endHeader
+	for _, next := range append(append([]*types.BlockHeader{}, p.path...), startHeader) {
+		if next.GetBaseHeader().GetNumber() >= current.GetBaseHeader().GetNumber() {
+			return false, nil
+		}
+		nextHash, err := computeHeaderHash(next)
+		if err != nil {
+			return false, err
+		}
+
+		isLinked := false
+		for _, hash := range current.GetSkipchainHashes() {
+			if bytes.Equal(hash, nextHash) {
+				isLinked = true
+				break
+			}
+		}
+		if !isLinked {
+			return false, nil
+		}
+		current = next
+	}
+	return true, nil
+}
+
+// computeHeaderHash returns the hash of the block header, as the block hash is calculated by the block store
+func computeHeaderHash(header *types.BlockHeader) ([]byte, error) {
+	headerBytes, err := proto.Marshal(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling the block header")
+	}
+	return crypto.ComputeSHA256Hash(headerBytes)
+}
@@ -0,0 +1,234 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// MultiProof contains the nodes of Merkle-Patricia Trie proving the state of several keys, each node once.
+// For each key, the nodes cover the path from the root down to the value node holding the key, or, if
+// the key was never written, the path and the witnesses held by AbsenceProof. The value hashes are the
+// hashes of the values of the value nodes ending the witnesses.
+//
+// As the keys of the state trie are hashes of the database name and the key, the keys of a database are
+// spread over the whole trie: a multi proof proves the state of the keys it is verified for, but it can't
+// prove that no other key of a database was written in a range of keys.
+type MultiProof struct {
+	nodes       []*types.MPTrieProofElement
+	valueHashes [][]byte
+
+	nodesByHash map[string]*types.MPTrieProofElement
+}
+
+func NewMultiProof(nodes []*types.MPTrieProofElement, valueHashes [][]byte) *MultiProof {
+	return &MultiProof{nodes: nodes, valueHashes: valueHashes}
+}
+
+// NewMultiProofFromProto creates the proof held by the response to a query for the proofs of several keys
+func NewMultiProofFromProto(p *types.MPTrieMultiProof) *MultiProof {
+	return NewMultiProof(p.GetNodes(), p.GetValueHashes())
+}
+
+func (p *MultiProof) GetNodes() []*types.MPTrieProofElement {
+	return p.nodes
+}
+
+func (p *MultiProof) GetValueHashes() [][]byte {
+	return p.valueHashes
+}
+
+// ToProto returns the proof as held by the response to a query for the proofs of several keys
+func (p *MultiProof) ToProto() *types.MPTrieMultiProof {
+	return &types.MPTrieMultiProof{
+		Nodes:       p.nodes,
+		ValueHashes: p.valueHashes,
+	}
+}
+
+// Verify validates that the proof holds the path of the trie key from the trie root down to the value node
+// holding the key, and that the node holds the value hash, calculated by CalculateKeyValueHash, and the delete
+// flag, as Proof does
+func (p *MultiProof) Verify(trieKey, valueHash, rootHash []byte, isDeleted bool) (bool, error) {
+	hexKey := convertByteToHex(trieKey)
+	path, node, depth, err := p.path(hexKey, rootHash)
+	if err != nil || path == nil {
+		return false, err
+	}
+
+	if node.kind != valueNodeKind || !bytes.Equal(hexKey[depth:], node.key) {
+		return false, nil
+	}
+	return bytes.Equal(node.valuePtr, valueHash) && node.isDeleted == isDeleted, nil
+}
+
+// VerifyAbsence validates that the trie key was never written, as AbsenceProof does. The witnesses below the
+// node at which the path of the key ends are rebuilt from the nodes of the proof.
+func (p *MultiProof) VerifyAbsence(trieKey, rootHash []byte) (bool, error) {
+	hexKey := convertByteToHex(trieKey)
+	path, node, depth, err := p.path(hexKey, rootHash)
+	if err != nil || path == nil {
+		return false, err
+	}
+
+	var witnesses []*types.MPTrieWitness
+	switch node.kind {
+	case branchNodeKind:
+		for nibble, childPtr := range node.children {
+			if len(childPtr) == 0 {
+				continue
+			}
+			witness, err := p.witness(concatNibbles(hexKey[:depth], []byte{byte(nibble)}), childPtr, len(hexKey))
+			if err != nil || witness == nil {
+				return false, err
+			}
+			witness.Nibble = uint32(nibble)
+			witnesses = append(witnesses, witness)
+		}
+
+	case extensionNodeKind:
+		witness, err := p.witness(concatNibbles(hexKey[:depth], node.key), node.child, len(hexKey))
+		if err != nil || witness == nil {
+			return false, err
+		}
+		witnesses = append(witnesses, witness)
+
+	default:
+		if bytes.Equal(hexKey[depth:], node.key) {
+			return false, nil
+		}
+		valueHash, found, err := p.valueHash(concatNibbles(hexKey[:depth], node.key), node.valuePtr)
+		if err != nil || !found {
+			return false, err
+		}
+		witnesses = append(witnesses, &types.MPTrieWitness{ValueHash: valueHash})
+	}
+
+	return NewAbsenceProof(path, witnesses).Verify(trieKey, rootHash)
+}
+
+// path returns the nodes of the proof from the trie root down to the node at which the path of the key ends,
+// along with that node, parsed, and its depth. A nil path is returned if the proof lacks a node of the path.
+func (p *MultiProof) path(hexKey, rootHash []byte) ([]*types.MPTrieProofElement, *parsedNode, int, error) {
+	if len(hexKey) == 0 {
+		return nil, nil, 0, errors.New("trie key can't be empty")
+	}
+
+	var path []*types.MPTrieProofElement
+	hashToFind := rootHash
+	depth := 0
+	for {
+		element, err := p.node(hashToFind)
+		if err != nil || element == nil {
+			return nil, nil, 0, err
+		}
+		path = append(path, element)
+
+		node, err := parseNode(element, len(hexKey)-depth)
+		if err != nil {
+			return nil, nil, 0, errors.WithMessagef(err, "invalid proof node at depth %d", depth)
+		}
+
+		switch node.kind {
+		case branchNodeKind:
+			childPtr := node.children[hexKey[depth]]
+			if len(childPtr) == 0 {
+				return path, node, depth, nil
+			}
+			hashToFind = childPtr
+			depth++
+		case extensionNodeKind:
+			if !bytes.HasPrefix(hexKey[depth:], node.key) {
+				return path, node, depth, nil
+			}
+			hashToFind = node.child
+			depth += len(node.key)
+		default:
+			return path, node, depth, nil
+		}
+	}
+}
+
+// witness rebuilds, from the nodes of the proof, a witness path from the node with the given hash, whose key
+// starts with the given prefix, down to a value node. A nil witness is returned if the proof lacks the nodes
+// or the value hash of such a path.
+func (p *MultiProof) witness(prefix, nodeHash []byte, hexKeyLen int) (*types.MPTrieWitness, error) {
+	witness := &types.MPTrieWitness{}
+	hexKey := append([]byte{}, prefix...)
+	for {
+		element, err := p.node(nodeHash)
+		if err != nil || element == nil {
+			return nil, err
+		}
+		witness.Path = append(witness.Path, element)
+
+		node, err := parseNode(element, hexKeyLen-len(hexKey))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid witness node at depth %d", len(hexKey))
+		}
+
+		switch node.kind {
+		case branchNodeKind:
+			nodeHash = nil
+			for nibble, childPtr := range node.children {
+				if len(childPtr) == 0 {
+					continue
+				}
+				child, err := p.node(childPtr)
+				if err != nil {
+					return nil, err
+				}
+				if child != nil {
+					nodeHash = childPtr
+					hexKey = append(hexKey, byte(nibble))
+					break
+				}
+			}
+			if nodeHash == nil {
+				return nil, nil
+			}
+		case extensionNodeKind:
+			hexKey = append(hexKey, node.key...)
+			nodeHash = node.child
+		default:
+			valueHash, found, err := p.valueHash(concatNibbles(hexKey, node.key), node.valuePtr)
+			if err != nil || !found {
+				return nil, err
+			}
+			witness.ValueHash = valueHash
+			return witness, nil
+		}
+	}
+}
+
+// valueHash returns the value hash of the proof matching the value pointer of the key, if any. An empty value
+// has no hash.
+func (p *MultiProof) valueHash(hexKey, valuePtr []byte) ([]byte, bool, error) {
+	for _, valueHash := range append([][]byte{nil}, p.valueHashes...) {
+		ok, err := verifyValuePtr(hexKey, valuePtr, valueHash)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return valueHash, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// node returns the node of the proof with the given hash, or nil if the proof does not hold it
+func (p *MultiProof) node(hash []byte) (*types.MPTrieProofElement, error) {
+	if p.nodesByHash == nil {
+		nodesByHash := make(map[string]*types.MPTrieProofElement, len(p.nodes))
+		for _, element := range p.nodes {
+			elementHash, err := CalcHash(element.GetHashes())
+			if err != nil {
+				return nil, err
+			}
+			nodesByHash[string(elementHash)] = element
+		}
+		p.nodesByHash = nodesByHash
+	}
+	return p.nodesByHash[string(hash)], nil
+}
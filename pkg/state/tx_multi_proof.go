@@ -0,0 +1,84 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/pkg/errors"
+)
+
+// TxMultiProof contains the intermediate hashes of the Merkle tree of the transactions of a block which prove the
+// inclusion of several transactions, each hash once. The hashes are ordered level by level, from the leaves up to the
+// root, and from left to right in each level. The hashes computed from the proven transactions are not part of it.
+type TxMultiProof struct {
+	hashes [][]byte
+}
+
+func NewTxMultiProof(hashes [][]byte) *TxMultiProof {
+	return &TxMultiProof{hashes: hashes}
+}
+
+func (p *TxMultiProof) GetHashes() [][]byte {
+	return p.hashes
+}
+
+// Verify validates that the transactions, given by their hashes keyed by their index in the block, are part of the
+// Merkle tree with the given root. txCount is the number of transactions in the block, i.e., the number of
+// validation info entries of its header. In each level of the tree, the nodes are paired from left to right, and
+// the last node, if it has no sibling, is carried to the next level as it is.
+func (p *TxMultiProof) Verify(txHashes map[uint64][]byte, txCount uint64, rootHash []byte) (bool, error) {
+	if len(txHashes) == 0 {
+		return false, errors.New("no transaction to verify")
+	}
+
+	indexes := make([]uint64, 0, len(txHashes))
+	for index := range txHashes {
+		if index >= txCount {
+			return false, errors.Errorf("transaction index %d is out of the block with %d transactions", index, txCount)
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	levelHashes := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		levelHashes[i] = txHashes[index]
+	}
+
+	nextProofHash := 0
+	for width := txCount; width > 1; width = (width + 1) / 2 {
+		nextIndexes := make([]uint64, 0, len(indexes))
+		nextHashes := make([][]byte, 0, len(indexes))
+		for i := 0; i < len(indexes); i++ {
+			index, hash := indexes[i], levelHashes[i]
+			var siblingHash []byte
+			switch {
+			case index%2 == 0 && i+1 < len(indexes) && indexes[i+1] == index+1:
+				siblingHash = levelHashes[i+1]
+				i++
+			case index%2 == 0 && index+1 == width:
+				// the last node of the level has no sibling
+			default:
+				if nextProofHash == len(p.hashes) {
+					return false, nil
+				}
+				siblingHash = p.hashes[nextProofHash]
+				nextProofHash++
+			}
+
+			hash, err := crypto.ConcatenateHashes(hash, siblingHash)
+			if err != nil {
+				return false, err
+			}
+			nextIndexes = append(nextIndexes, index/2)
+			nextHashes = append(nextHashes, hash)
+		}
+		indexes, levelHashes = nextIndexes, nextHashes
+	}
+
+	if nextProofHash != len(p.hashes) {
+		return false, nil
+	}
+	return bytes.Equal(levelHashes[0], rootHash), nil
+}
@@ -31,17 +31,23 @@ const (
 	Flag_INVALID_INCORRECT_ENTRIES                  Flag = 5
 	Flag_INVALID_UNAUTHORISED                       Flag = 6
 	Flag_INVALID_MISSING_SIGNATURE                  Flag = 7
+	Flag_INVALID_SCHEMA_VIOLATION                   Flag = 8
+	Flag_INVALID_PROCEDURE_EXECUTION                Flag = 9
+	Flag_INVALID_QUOTA_EXCEEDED                     Flag = 10
 )
 
 var Flag_name = map[int32]string{
-	0: "VALID",
-	1: "INVALID_MVCC_CONFLICT_WITHIN_BLOCK",
-	2: "INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE",
-	3: "INVALID_DATABASE_DOES_NOT_EXIST",
-	4: "INVALID_NO_PERMISSION",
-	5: "INVALID_INCORRECT_ENTRIES",
-	6: "INVALID_UNAUTHORISED",
-	7: "INVALID_MISSING_SIGNATURE",
+	0:  "VALID",
+	1:  "INVALID_MVCC_CONFLICT_WITHIN_BLOCK",
+	2:  "INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE",
+	3:  "INVALID_DATABASE_DOES_NOT_EXIST",
+	4:  "INVALID_NO_PERMISSION",
+	5:  "INVALID_INCORRECT_ENTRIES",
+	6:  "INVALID_UNAUTHORISED",
+	7:  "INVALID_MISSING_SIGNATURE",
+	8:  "INVALID_SCHEMA_VIOLATION",
+	9:  "INVALID_PROCEDURE_EXECUTION",
+	10: "INVALID_QUOTA_EXCEEDED",
 }
 
 var Flag_value = map[string]int32{
@@ -53,6 +59,9 @@ var Flag_value = map[string]int32{
 	"INVALID_INCORRECT_ENTRIES":                  5,
 	"INVALID_UNAUTHORISED":                       6,
 	"INVALID_MISSING_SIGNATURE":                  7,
+	"INVALID_SCHEMA_VIOLATION":                   8,
+	"INVALID_PROCEDURE_EXECUTION":                9,
+	"INVALID_QUOTA_EXCEEDED":                     10,
 }
 
 func (x Flag) String() string {
@@ -113,7 +122,7 @@ func (x AccessControlWritePolicy) String() string {
 }
 
 func (AccessControlWritePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{22, 0}
+	return fileDescriptor_8098d268f52aac08, []int{32, 0}
 }
 
 // Block holds the chain information and transactions
@@ -124,6 +133,8 @@ type Block struct {
 	//	*Block_ConfigTxEnvelope
 	//	*Block_DbAdministrationTxEnvelope
 	//	*Block_UserAdministrationTxEnvelope
+	//	*Block_ProcedureTxEnvelopes
+	//	*Block_ExpiryTxEnvelope
 	Payload isBlock_Payload `protobuf_oneof:"Payload"`
 	// Consensus protocol metadata
 	ConsensusMetadata    *ConsensusMetadata `protobuf:"bytes,6,opt,name=consensus_metadata,json=consensusMetadata,proto3" json:"consensus_metadata,omitempty"`
@@ -184,6 +195,14 @@ type Block_UserAdministrationTxEnvelope struct {
 	UserAdministrationTxEnvelope *UserAdministrationTxEnvelope `protobuf:"bytes,5,opt,name=user_administration_tx_envelope,json=userAdministrationTxEnvelope,proto3,oneof"`
 }
 
+type Block_ProcedureTxEnvelopes struct {
+	ProcedureTxEnvelopes *ProcedureTxEnvelopes `protobuf:"bytes,7,opt,name=procedure_tx_envelopes,json=procedureTxEnvelopes,proto3,oneof"`
+}
+
+type Block_ExpiryTxEnvelope struct {
+	ExpiryTxEnvelope *ExpiryTxEnvelope `protobuf:"bytes,8,opt,name=expiry_tx_envelope,json=expiryTxEnvelope,proto3,oneof"`
+}
+
 func (*Block_DataTxEnvelopes) isBlock_Payload() {}
 
 func (*Block_ConfigTxEnvelope) isBlock_Payload() {}
@@ -192,6 +211,10 @@ func (*Block_DbAdministrationTxEnvelope) isBlock_Payload() {}
 
 func (*Block_UserAdministrationTxEnvelope) isBlock_Payload() {}
 
+func (*Block_ProcedureTxEnvelopes) isBlock_Payload() {}
+
+func (*Block_ExpiryTxEnvelope) isBlock_Payload() {}
+
 func (m *Block) GetPayload() isBlock_Payload {
 	if m != nil {
 		return m.Payload
@@ -227,6 +250,20 @@ func (m *Block) GetUserAdministrationTxEnvelope() *UserAdministrationTxEnvelope
 	return nil
 }
 
+func (m *Block) GetProcedureTxEnvelopes() *ProcedureTxEnvelopes {
+	if x, ok := m.GetPayload().(*Block_ProcedureTxEnvelopes); ok {
+		return x.ProcedureTxEnvelopes
+	}
+	return nil
+}
+
+func (m *Block) GetExpiryTxEnvelope() *ExpiryTxEnvelope {
+	if x, ok := m.GetPayload().(*Block_ExpiryTxEnvelope); ok {
+		return x.ExpiryTxEnvelope
+	}
+	return nil
+}
+
 func (m *Block) GetConsensusMetadata() *ConsensusMetadata {
 	if m != nil {
 		return m.ConsensusMetadata
@@ -241,6 +278,8 @@ func (*Block) XXX_OneofWrappers() []interface{} {
 		(*Block_ConfigTxEnvelope)(nil),
 		(*Block_DbAdministrationTxEnvelope)(nil),
 		(*Block_UserAdministrationTxEnvelope)(nil),
+		(*Block_ProcedureTxEnvelopes)(nil),
+		(*Block_ExpiryTxEnvelope)(nil),
 	}
 }
 
@@ -253,10 +292,14 @@ type BlockHeaderBase struct {
 	// Hash of BlockHeader of last block already committed to ledger
 	LastCommittedBlockHash []byte `protobuf:"bytes,3,opt,name=last_committed_block_hash,json=lastCommittedBlockHash,proto3" json:"last_committed_block_hash,omitempty"`
 	// Number of last block already committed to ledger
-	LastCommittedBlockNum uint64   `protobuf:"varint,4,opt,name=last_committed_block_num,json=lastCommittedBlockNum,proto3" json:"last_committed_block_num,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+	LastCommittedBlockNum uint64 `protobuf:"varint,4,opt,name=last_committed_block_num,json=lastCommittedBlockNum,proto3" json:"last_committed_block_num,omitempty"`
+	// Unix time, in seconds, at which the block was proposed. It does not
+	// decrease along the chain. Time-dependent transactions are validated
+	// against it.
+	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BlockHeaderBase) Reset()         { *m = BlockHeaderBase{} }
@@ -312,6 +355,13 @@ func (m *BlockHeaderBase) GetLastCommittedBlockNum() uint64 {
 	return 0
 }
 
+func (m *BlockHeaderBase) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
 // BlockHeader holds, in addition to base header, additional chain integrity information that is computed after transactions validation,
 // including the state and transaction Merkle trees roots, skip-chain hashes, and transaction validation information.
 type BlockHeader struct {
@@ -323,10 +373,14 @@ type BlockHeader struct {
 	// Root hash of system wide state merkle-particia tree
 	StateMerkelTreeRootHash []byte `protobuf:"bytes,4,opt,name=state_merkel_tree_root_hash,json=stateMerkelTreeRootHash,proto3" json:"state_merkel_tree_root_hash,omitempty"`
 	// Validation info for transactions in block.
-	ValidationInfo       []*ValidationInfo `protobuf:"bytes,5,rep,name=validation_info,json=validationInfo,proto3" json:"validation_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	ValidationInfo []*ValidationInfo `protobuf:"bytes,5,rep,name=validation_info,json=validationInfo,proto3" json:"validation_info,omitempty"`
+	// Operations produced by executing the stored procedure invoked by each
+	// transaction in a procedure block. The i-th entry corresponds to the
+	// i-th transaction and is empty if the transaction is invalid.
+	ProcedureResults     []*DBOperation `protobuf:"bytes,6,rep,name=procedure_results,json=procedureResults,proto3" json:"procedure_results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
@@ -389,6 +443,13 @@ func (m *BlockHeader) GetValidationInfo() []*ValidationInfo {
 	return nil
 }
 
+func (m *BlockHeader) GetProcedureResults() []*DBOperation {
+	if m != nil {
+		return m.ProcedureResults
+	}
+	return nil
+}
+
 type DataTxEnvelopes struct {
 	Envelopes            []*DataTxEnvelope `protobuf:"bytes,1,rep,name=envelopes,proto3" json:"envelopes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -475,19 +536,109 @@ func (m *DataTxEnvelope) GetSignatures() map[string][]byte {
 	return nil
 }
 
+type ProcedureTxEnvelopes struct {
+	Envelopes            []*ProcedureTxEnvelope `protobuf:"bytes,1,rep,name=envelopes,proto3" json:"envelopes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ProcedureTxEnvelopes) Reset()         { *m = ProcedureTxEnvelopes{} }
+func (m *ProcedureTxEnvelopes) String() string { return proto.CompactTextString(m) }
+func (*ProcedureTxEnvelopes) ProtoMessage()    {}
+func (*ProcedureTxEnvelopes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{5}
+}
+
+func (m *ProcedureTxEnvelopes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcedureTxEnvelopes.Unmarshal(m, b)
+}
+func (m *ProcedureTxEnvelopes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcedureTxEnvelopes.Marshal(b, m, deterministic)
+}
+func (m *ProcedureTxEnvelopes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcedureTxEnvelopes.Merge(m, src)
+}
+func (m *ProcedureTxEnvelopes) XXX_Size() int {
+	return xxx_messageInfo_ProcedureTxEnvelopes.Size(m)
+}
+func (m *ProcedureTxEnvelopes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcedureTxEnvelopes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcedureTxEnvelopes proto.InternalMessageInfo
+
+func (m *ProcedureTxEnvelopes) GetEnvelopes() []*ProcedureTxEnvelope {
+	if m != nil {
+		return m.Envelopes
+	}
+	return nil
+}
+
+type ProcedureTxEnvelope struct {
+	Payload              *ProcedureTx      `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signatures           map[string][]byte `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ProcedureTxEnvelope) Reset()         { *m = ProcedureTxEnvelope{} }
+func (m *ProcedureTxEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ProcedureTxEnvelope) ProtoMessage()    {}
+func (*ProcedureTxEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{6}
+}
+
+func (m *ProcedureTxEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcedureTxEnvelope.Unmarshal(m, b)
+}
+func (m *ProcedureTxEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcedureTxEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ProcedureTxEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcedureTxEnvelope.Merge(m, src)
+}
+func (m *ProcedureTxEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ProcedureTxEnvelope.Size(m)
+}
+func (m *ProcedureTxEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcedureTxEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcedureTxEnvelope proto.InternalMessageInfo
+
+func (m *ProcedureTxEnvelope) GetPayload() *ProcedureTx {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ProcedureTxEnvelope) GetSignatures() map[string][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 type ConfigTxEnvelope struct {
-	Payload              *ConfigTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Payload *ConfigTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The signature of the submitter, i.e., payload.user_id.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The signatures of the other admins approving the transaction, keyed by their user IDs.
+	// See GovernancePolicy.
+	Signatures           map[string][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ConfigTxEnvelope) Reset()         { *m = ConfigTxEnvelope{} }
 func (m *ConfigTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*ConfigTxEnvelope) ProtoMessage()    {}
 func (*ConfigTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{5}
+	return fileDescriptor_8098d268f52aac08, []int{7}
 }
 
 func (m *ConfigTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -522,19 +673,30 @@ func (m *ConfigTxEnvelope) GetSignature() []byte {
 	return nil
 }
 
+func (m *ConfigTxEnvelope) GetSignatures() map[string][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 type DBAdministrationTxEnvelope struct {
-	Payload              *DBAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Payload *DBAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The signature of the submitter, i.e., payload.user_id.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The signatures of the other admins approving the transaction, keyed by their user IDs.
+	// See GovernancePolicy.
+	Signatures           map[string][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DBAdministrationTxEnvelope) Reset()         { *m = DBAdministrationTxEnvelope{} }
 func (m *DBAdministrationTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DBAdministrationTxEnvelope) ProtoMessage()    {}
 func (*DBAdministrationTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{6}
+	return fileDescriptor_8098d268f52aac08, []int{8}
 }
 
 func (m *DBAdministrationTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -569,19 +731,30 @@ func (m *DBAdministrationTxEnvelope) GetSignature() []byte {
 	return nil
 }
 
+func (m *DBAdministrationTxEnvelope) GetSignatures() map[string][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 type UserAdministrationTxEnvelope struct {
-	Payload              *UserAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Payload *UserAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The signature of the submitter, i.e., payload.user_id.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The signatures of the other admins approving the transaction, keyed by their user IDs.
+	// See GovernancePolicy.
+	Signatures           map[string][]byte `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *UserAdministrationTxEnvelope) Reset()         { *m = UserAdministrationTxEnvelope{} }
 func (m *UserAdministrationTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*UserAdministrationTxEnvelope) ProtoMessage()    {}
 func (*UserAdministrationTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{7}
+	return fileDescriptor_8098d268f52aac08, []int{9}
 }
 
 func (m *UserAdministrationTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -616,6 +789,63 @@ func (m *UserAdministrationTxEnvelope) GetSignature() []byte {
 	return nil
 }
 
+func (m *UserAdministrationTxEnvelope) GetSignatures() map[string][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// ExpiryTxEnvelope holds a system transaction which is proposed by
+// the leader node and hence, it is signed by that node instead of a user
+type ExpiryTxEnvelope struct {
+	Payload *ExpiryTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// signature of the node in the payload on the payload
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExpiryTxEnvelope) Reset()         { *m = ExpiryTxEnvelope{} }
+func (m *ExpiryTxEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ExpiryTxEnvelope) ProtoMessage()    {}
+func (*ExpiryTxEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{10}
+}
+
+func (m *ExpiryTxEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpiryTxEnvelope.Unmarshal(m, b)
+}
+func (m *ExpiryTxEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpiryTxEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ExpiryTxEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpiryTxEnvelope.Merge(m, src)
+}
+func (m *ExpiryTxEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ExpiryTxEnvelope.Size(m)
+}
+func (m *ExpiryTxEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpiryTxEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpiryTxEnvelope proto.InternalMessageInfo
+
+func (m *ExpiryTxEnvelope) GetPayload() *ExpiryTx {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ExpiryTxEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type DataTx struct {
 	MustSignUserIds      []string       `protobuf:"bytes,1,rep,name=must_sign_user_ids,json=mustSignUserIds,proto3" json:"must_sign_user_ids,omitempty"`
 	TxId                 string         `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
@@ -629,7 +859,7 @@ func (m *DataTx) Reset()         { *m = DataTx{} }
 func (m *DataTx) String() string { return proto.CompactTextString(m) }
 func (*DataTx) ProtoMessage()    {}
 func (*DataTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{8}
+	return fileDescriptor_8098d268f52aac08, []int{11}
 }
 
 func (m *DataTx) XXX_Unmarshal(b []byte) error {
@@ -671,6 +901,204 @@ func (m *DataTx) GetDbOperations() []*DBOperation {
 	return nil
 }
 
+// ProcedureTx invokes a stored procedure registered on a database. The
+// procedure is executed by each node during block validation and the
+// resulting reads, writes, and deletes are committed as a data transaction.
+type ProcedureTx struct {
+	MustSignUserIds      []string `protobuf:"bytes,1,rep,name=must_sign_user_ids,json=mustSignUserIds,proto3" json:"must_sign_user_ids,omitempty"`
+	TxId                 string   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	DbName               string   `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	ProcedureName        string   `protobuf:"bytes,4,opt,name=procedure_name,json=procedureName,proto3" json:"procedure_name,omitempty"`
+	Args                 []string `protobuf:"bytes,5,rep,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProcedureTx) Reset()         { *m = ProcedureTx{} }
+func (m *ProcedureTx) String() string { return proto.CompactTextString(m) }
+func (*ProcedureTx) ProtoMessage()    {}
+func (*ProcedureTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{12}
+}
+
+func (m *ProcedureTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcedureTx.Unmarshal(m, b)
+}
+func (m *ProcedureTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcedureTx.Marshal(b, m, deterministic)
+}
+func (m *ProcedureTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcedureTx.Merge(m, src)
+}
+func (m *ProcedureTx) XXX_Size() int {
+	return xxx_messageInfo_ProcedureTx.Size(m)
+}
+func (m *ProcedureTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcedureTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcedureTx proto.InternalMessageInfo
+
+func (m *ProcedureTx) GetMustSignUserIds() []string {
+	if m != nil {
+		return m.MustSignUserIds
+	}
+	return nil
+}
+
+func (m *ProcedureTx) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ProcedureTx) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *ProcedureTx) GetProcedureName() string {
+	if m != nil {
+		return m.ProcedureName
+	}
+	return ""
+}
+
+func (m *ProcedureTx) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+// ExpiryTx deletes keys which have expired. Each node validates that
+// every key has indeed expired at the block holding the transaction.
+type ExpiryTx struct {
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// ID of the node which proposed the transaction
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Unix time, in seconds, on the proposing node when the transaction
+	// was proposed. Keys expiring at a wall clock time are checked against it.
+	Timestamp            int64         `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExpiredKeys          []*ExpiredKey `protobuf:"bytes,4,rep,name=expired_keys,json=expiredKeys,proto3" json:"expired_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExpiryTx) Reset()         { *m = ExpiryTx{} }
+func (m *ExpiryTx) String() string { return proto.CompactTextString(m) }
+func (*ExpiryTx) ProtoMessage()    {}
+func (*ExpiryTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{13}
+}
+
+func (m *ExpiryTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpiryTx.Unmarshal(m, b)
+}
+func (m *ExpiryTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpiryTx.Marshal(b, m, deterministic)
+}
+func (m *ExpiryTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpiryTx.Merge(m, src)
+}
+func (m *ExpiryTx) XXX_Size() int {
+	return xxx_messageInfo_ExpiryTx.Size(m)
+}
+func (m *ExpiryTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpiryTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpiryTx proto.InternalMessageInfo
+
+func (m *ExpiryTx) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ExpiryTx) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ExpiryTx) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ExpiryTx) GetExpiredKeys() []*ExpiredKey {
+	if m != nil {
+		return m.ExpiredKeys
+	}
+	return nil
+}
+
+type ExpiredKey struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// the committed version of the key when the expiry was detected
+	Version              *Version `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExpiredKey) Reset()         { *m = ExpiredKey{} }
+func (m *ExpiredKey) String() string { return proto.CompactTextString(m) }
+func (*ExpiredKey) ProtoMessage()    {}
+func (*ExpiredKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{14}
+}
+
+func (m *ExpiredKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpiredKey.Unmarshal(m, b)
+}
+func (m *ExpiredKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpiredKey.Marshal(b, m, deterministic)
+}
+func (m *ExpiredKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpiredKey.Merge(m, src)
+}
+func (m *ExpiredKey) XXX_Size() int {
+	return xxx_messageInfo_ExpiredKey.Size(m)
+}
+func (m *ExpiredKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpiredKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpiredKey proto.InternalMessageInfo
+
+func (m *ExpiredKey) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *ExpiredKey) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ExpiredKey) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
 type DBOperation struct {
 	DbName               string        `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
 	DataReads            []*DataRead   `protobuf:"bytes,4,rep,name=data_reads,json=dataReads,proto3" json:"data_reads,omitempty"`
@@ -685,7 +1113,7 @@ func (m *DBOperation) Reset()         { *m = DBOperation{} }
 func (m *DBOperation) String() string { return proto.CompactTextString(m) }
 func (*DBOperation) ProtoMessage()    {}
 func (*DBOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{9}
+	return fileDescriptor_8098d268f52aac08, []int{15}
 }
 
 func (m *DBOperation) XXX_Unmarshal(b []byte) error {
@@ -747,7 +1175,7 @@ func (m *DataRead) Reset()         { *m = DataRead{} }
 func (m *DataRead) String() string { return proto.CompactTextString(m) }
 func (*DataRead) ProtoMessage()    {}
 func (*DataRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{10}
+	return fileDescriptor_8098d268f52aac08, []int{16}
 }
 
 func (m *DataRead) XXX_Unmarshal(b []byte) error {
@@ -784,19 +1212,21 @@ func (m *DataRead) GetVersion() *Version {
 
 // DataWrite hold a write including a delete
 type DataWrite struct {
-	Key                  string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Acl                  *AccessControl `protobuf:"bytes,3,opt,name=acl,proto3" json:"acl,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Key   string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Acl   *AccessControl `protobuf:"bytes,3,opt,name=acl,proto3" json:"acl,omitempty"`
+	// optional time-to-live of the key
+	Ttl                  *TTL     `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DataWrite) Reset()         { *m = DataWrite{} }
 func (m *DataWrite) String() string { return proto.CompactTextString(m) }
 func (*DataWrite) ProtoMessage()    {}
 func (*DataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{11}
+	return fileDescriptor_8098d268f52aac08, []int{17}
 }
 
 func (m *DataWrite) XXX_Unmarshal(b []byte) error {
@@ -824,18 +1254,80 @@ func (m *DataWrite) GetKey() string {
 	return ""
 }
 
-func (m *DataWrite) GetValue() []byte {
+func (m *DataWrite) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *DataWrite) GetAcl() *AccessControl {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
+func (m *DataWrite) GetTtl() *TTL {
+	if m != nil {
+		return m.Ttl
+	}
+	return nil
+}
+
+// TTL denotes when a written key expires, relative to the block which
+// writes the key. When both fields are set, the key expires at whichever
+// happens first. An expired key is deleted by an expiry transaction
+// proposed by the leader.
+type TTL struct {
+	// number of blocks, counted from the block which writes the key,
+	// after which the key expires
+	Blocks uint64 `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	// number of seconds, counted from the timestamp in the header of the
+	// block which writes the key, after which the key expires
+	Seconds              uint64   `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TTL) Reset()         { *m = TTL{} }
+func (m *TTL) String() string { return proto.CompactTextString(m) }
+func (*TTL) ProtoMessage()    {}
+func (*TTL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{18}
+}
+
+func (m *TTL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TTL.Unmarshal(m, b)
+}
+func (m *TTL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TTL.Marshal(b, m, deterministic)
+}
+func (m *TTL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TTL.Merge(m, src)
+}
+func (m *TTL) XXX_Size() int {
+	return xxx_messageInfo_TTL.Size(m)
+}
+func (m *TTL) XXX_DiscardUnknown() {
+	xxx_messageInfo_TTL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TTL proto.InternalMessageInfo
+
+func (m *TTL) GetBlocks() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Blocks
 	}
-	return nil
+	return 0
 }
 
-func (m *DataWrite) GetAcl() *AccessControl {
+func (m *TTL) GetSeconds() uint64 {
 	if m != nil {
-		return m.Acl
+		return m.Seconds
 	}
-	return nil
+	return 0
 }
 
 type DataDelete struct {
@@ -849,7 +1341,7 @@ func (m *DataDelete) Reset()         { *m = DataDelete{} }
 func (m *DataDelete) String() string { return proto.CompactTextString(m) }
 func (*DataDelete) ProtoMessage()    {}
 func (*DataDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{12}
+	return fileDescriptor_8098d268f52aac08, []int{19}
 }
 
 func (m *DataDelete) XXX_Unmarshal(b []byte) error {
@@ -891,7 +1383,7 @@ func (m *ConfigTx) Reset()         { *m = ConfigTx{} }
 func (m *ConfigTx) String() string { return proto.CompactTextString(m) }
 func (*ConfigTx) ProtoMessage()    {}
 func (*ConfigTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{13}
+	return fileDescriptor_8098d268f52aac08, []int{20}
 }
 
 func (m *ConfigTx) XXX_Unmarshal(b []byte) error {
@@ -941,21 +1433,26 @@ func (m *ConfigTx) GetNewConfig() *ClusterConfig {
 }
 
 type DBAdministrationTx struct {
-	UserId               string              `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TxId                 string              `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	CreateDbs            []string            `protobuf:"bytes,3,rep,name=create_dbs,json=createDbs,proto3" json:"create_dbs,omitempty"`
-	DeleteDbs            []string            `protobuf:"bytes,4,rep,name=delete_dbs,json=deleteDbs,proto3" json:"delete_dbs,omitempty"`
-	DbsIndex             map[string]*DBIndex `protobuf:"bytes,5,rep,name=dbs_index,json=dbsIndex,proto3" json:"dbs_index,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	UserId        string                   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId          string                   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	CreateDbs     []string                 `protobuf:"bytes,3,rep,name=create_dbs,json=createDbs,proto3" json:"create_dbs,omitempty"`
+	DeleteDbs     []string                 `protobuf:"bytes,4,rep,name=delete_dbs,json=deleteDbs,proto3" json:"delete_dbs,omitempty"`
+	DbsIndex      map[string]*DBIndex      `protobuf:"bytes,5,rep,name=dbs_index,json=dbsIndex,proto3" json:"dbs_index,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DbsSchema     map[string]*DBSchema     `protobuf:"bytes,6,rep,name=dbs_schema,json=dbsSchema,proto3" json:"dbs_schema,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DbsProcedures map[string]*DBProcedures `protobuf:"bytes,7,rep,name=dbs_procedures,json=dbsProcedures,proto3" json:"dbs_procedures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// quota per database. A quota with no limit set removes the
+	// existing quota of the database.
+	DbsQuota             map[string]*Quota `protobuf:"bytes,8,rep,name=dbs_quota,json=dbsQuota,proto3" json:"dbs_quota,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DBAdministrationTx) Reset()         { *m = DBAdministrationTx{} }
 func (m *DBAdministrationTx) String() string { return proto.CompactTextString(m) }
 func (*DBAdministrationTx) ProtoMessage()    {}
 func (*DBAdministrationTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{14}
+	return fileDescriptor_8098d268f52aac08, []int{21}
 }
 
 func (m *DBAdministrationTx) XXX_Unmarshal(b []byte) error {
@@ -1011,6 +1508,27 @@ func (m *DBAdministrationTx) GetDbsIndex() map[string]*DBIndex {
 	return nil
 }
 
+func (m *DBAdministrationTx) GetDbsSchema() map[string]*DBSchema {
+	if m != nil {
+		return m.DbsSchema
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetDbsProcedures() map[string]*DBProcedures {
+	if m != nil {
+		return m.DbsProcedures
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetDbsQuota() map[string]*Quota {
+	if m != nil {
+		return m.DbsQuota
+	}
+	return nil
+}
+
 type DBIndex struct {
 	AttributeAndType     map[string]IndexAttributeType `protobuf:"bytes,1,rep,name=attribute_and_type,json=attributeAndType,proto3" json:"attribute_and_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=types.IndexAttributeType"`
 	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
@@ -1022,7 +1540,7 @@ func (m *DBIndex) Reset()         { *m = DBIndex{} }
 func (m *DBIndex) String() string { return proto.CompactTextString(m) }
 func (*DBIndex) ProtoMessage()    {}
 func (*DBIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{15}
+	return fileDescriptor_8098d268f52aac08, []int{22}
 }
 
 func (m *DBIndex) XXX_Unmarshal(b []byte) error {
@@ -1050,6 +1568,90 @@ func (m *DBIndex) GetAttributeAndType() map[string]IndexAttributeType {
 	return nil
 }
 
+// DBSchema holds a JSON Schema (draft 4, 6 or 7) to which every value
+// written to the database must conform. An empty json_schema removes
+// the existing schema of the database.
+type DBSchema struct {
+	JsonSchema           string   `protobuf:"bytes,1,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBSchema) Reset()         { *m = DBSchema{} }
+func (m *DBSchema) String() string { return proto.CompactTextString(m) }
+func (*DBSchema) ProtoMessage()    {}
+func (*DBSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{23}
+}
+
+func (m *DBSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBSchema.Unmarshal(m, b)
+}
+func (m *DBSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBSchema.Marshal(b, m, deterministic)
+}
+func (m *DBSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBSchema.Merge(m, src)
+}
+func (m *DBSchema) XXX_Size() int {
+	return xxx_messageInfo_DBSchema.Size(m)
+}
+func (m *DBSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBSchema proto.InternalMessageInfo
+
+func (m *DBSchema) GetJsonSchema() string {
+	if m != nil {
+		return m.JsonSchema
+	}
+	return ""
+}
+
+// DBProcedures holds the source code of the stored procedures, keyed by
+// procedure name, to be registered on a database. An empty source removes
+// the existing procedure with that name.
+type DBProcedures struct {
+	Procedures           map[string]string `protobuf:"bytes,1,rep,name=procedures,proto3" json:"procedures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DBProcedures) Reset()         { *m = DBProcedures{} }
+func (m *DBProcedures) String() string { return proto.CompactTextString(m) }
+func (*DBProcedures) ProtoMessage()    {}
+func (*DBProcedures) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{24}
+}
+
+func (m *DBProcedures) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBProcedures.Unmarshal(m, b)
+}
+func (m *DBProcedures) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBProcedures.Marshal(b, m, deterministic)
+}
+func (m *DBProcedures) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBProcedures.Merge(m, src)
+}
+func (m *DBProcedures) XXX_Size() int {
+	return xxx_messageInfo_DBProcedures.Size(m)
+}
+func (m *DBProcedures) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBProcedures.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBProcedures proto.InternalMessageInfo
+
+func (m *DBProcedures) GetProcedures() map[string]string {
+	if m != nil {
+		return m.Procedures
+	}
+	return nil
+}
+
 type UserAdministrationTx struct {
 	UserId               string        `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	TxId                 string        `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
@@ -1065,7 +1667,7 @@ func (m *UserAdministrationTx) Reset()         { *m = UserAdministrationTx{} }
 func (m *UserAdministrationTx) String() string { return proto.CompactTextString(m) }
 func (*UserAdministrationTx) ProtoMessage()    {}
 func (*UserAdministrationTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{16}
+	return fileDescriptor_8098d268f52aac08, []int{25}
 }
 
 func (m *UserAdministrationTx) XXX_Unmarshal(b []byte) error {
@@ -1133,7 +1735,7 @@ func (m *UserRead) Reset()         { *m = UserRead{} }
 func (m *UserRead) String() string { return proto.CompactTextString(m) }
 func (*UserRead) ProtoMessage()    {}
 func (*UserRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{17}
+	return fileDescriptor_8098d268f52aac08, []int{26}
 }
 
 func (m *UserRead) XXX_Unmarshal(b []byte) error {
@@ -1180,7 +1782,7 @@ func (m *UserWrite) Reset()         { *m = UserWrite{} }
 func (m *UserWrite) String() string { return proto.CompactTextString(m) }
 func (*UserWrite) ProtoMessage()    {}
 func (*UserWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{18}
+	return fileDescriptor_8098d268f52aac08, []int{27}
 }
 
 func (m *UserWrite) XXX_Unmarshal(b []byte) error {
@@ -1226,7 +1828,7 @@ func (m *UserDelete) Reset()         { *m = UserDelete{} }
 func (m *UserDelete) String() string { return proto.CompactTextString(m) }
 func (*UserDelete) ProtoMessage()    {}
 func (*UserDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{19}
+	return fileDescriptor_8098d268f52aac08, []int{28}
 }
 
 func (m *UserDelete) XXX_Unmarshal(b []byte) error {
@@ -1257,6 +1859,7 @@ func (m *UserDelete) GetUserId() string {
 type Metadata struct {
 	Version              *Version       `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	AccessControl        *AccessControl `protobuf:"bytes,2,opt,name=access_control,json=accessControl,proto3" json:"access_control,omitempty"`
+	Expiry               *Expiry        `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1266,7 +1869,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{20}
+	return fileDescriptor_8098d268f52aac08, []int{29}
 }
 
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
@@ -1301,6 +1904,63 @@ func (m *Metadata) GetAccessControl() *AccessControl {
 	return nil
 }
 
+func (m *Metadata) GetExpiry() *Expiry {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+// Expiry holds the point at which a key expires. A zero field is not applied.
+type Expiry struct {
+	// the key has expired in the block with this number and in all later blocks
+	BlockNum uint64 `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	// the key has expired at this unix time, in seconds
+	UnixTime             int64    `protobuf:"varint,2,opt,name=unix_time,json=unixTime,proto3" json:"unix_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Expiry) Reset()         { *m = Expiry{} }
+func (m *Expiry) String() string { return proto.CompactTextString(m) }
+func (*Expiry) ProtoMessage()    {}
+func (*Expiry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{30}
+}
+
+func (m *Expiry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Expiry.Unmarshal(m, b)
+}
+func (m *Expiry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Expiry.Marshal(b, m, deterministic)
+}
+func (m *Expiry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Expiry.Merge(m, src)
+}
+func (m *Expiry) XXX_Size() int {
+	return xxx_messageInfo_Expiry.Size(m)
+}
+func (m *Expiry) XXX_DiscardUnknown() {
+	xxx_messageInfo_Expiry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Expiry proto.InternalMessageInfo
+
+func (m *Expiry) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *Expiry) GetUnixTime() int64 {
+	if m != nil {
+		return m.UnixTime
+	}
+	return 0
+}
+
 type Version struct {
 	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
 	TxNum                uint64   `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
@@ -1313,7 +1973,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{21}
+	return fileDescriptor_8098d268f52aac08, []int{31}
 }
 
 func (m *Version) XXX_Unmarshal(b []byte) error {
@@ -1361,7 +2021,7 @@ func (m *AccessControl) Reset()         { *m = AccessControl{} }
 func (m *AccessControl) String() string { return proto.CompactTextString(m) }
 func (*AccessControl) ProtoMessage()    {}
 func (*AccessControl) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{22}
+	return fileDescriptor_8098d268f52aac08, []int{32}
 }
 
 func (m *AccessControl) XXX_Unmarshal(b []byte) error {
@@ -1403,6 +2063,54 @@ func (m *AccessControl) GetSignPolicyForWrite() AccessControlWritePolicy {
 	return AccessControl_ANY
 }
 
+// QuotaUsage holds the amount of data stored in a database or owned by a user
+type QuotaUsage struct {
+	Keys                 uint64   `protobuf:"varint,1,opt,name=keys,proto3" json:"keys,omitempty"`
+	TotalValueBytes      uint64   `protobuf:"varint,2,opt,name=total_value_bytes,json=totalValueBytes,proto3" json:"total_value_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuotaUsage) Reset()         { *m = QuotaUsage{} }
+func (m *QuotaUsage) String() string { return proto.CompactTextString(m) }
+func (*QuotaUsage) ProtoMessage()    {}
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{33}
+}
+
+func (m *QuotaUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuotaUsage.Unmarshal(m, b)
+}
+func (m *QuotaUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuotaUsage.Marshal(b, m, deterministic)
+}
+func (m *QuotaUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuotaUsage.Merge(m, src)
+}
+func (m *QuotaUsage) XXX_Size() int {
+	return xxx_messageInfo_QuotaUsage.Size(m)
+}
+func (m *QuotaUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuotaUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuotaUsage proto.InternalMessageInfo
+
+func (m *QuotaUsage) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *QuotaUsage) GetTotalValueBytes() uint64 {
+	if m != nil {
+		return m.TotalValueBytes
+	}
+	return 0
+}
+
 type KVWithMetadata struct {
 	Key                  string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte    `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -1416,7 +2124,7 @@ func (m *KVWithMetadata) Reset()         { *m = KVWithMetadata{} }
 func (m *KVWithMetadata) String() string { return proto.CompactTextString(m) }
 func (*KVWithMetadata) ProtoMessage()    {}
 func (*KVWithMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{23}
+	return fileDescriptor_8098d268f52aac08, []int{34}
 }
 
 func (m *KVWithMetadata) XXX_Unmarshal(b []byte) error {
@@ -1470,7 +2178,7 @@ func (m *ValueWithMetadata) Reset()         { *m = ValueWithMetadata{} }
 func (m *ValueWithMetadata) String() string { return proto.CompactTextString(m) }
 func (*ValueWithMetadata) ProtoMessage()    {}
 func (*ValueWithMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{24}
+	return fileDescriptor_8098d268f52aac08, []int{35}
 }
 
 func (m *ValueWithMetadata) XXX_Unmarshal(b []byte) error {
@@ -1519,7 +2227,7 @@ func (m *Digest) Reset()         { *m = Digest{} }
 func (m *Digest) String() string { return proto.CompactTextString(m) }
 func (*Digest) ProtoMessage()    {}
 func (*Digest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{25}
+	return fileDescriptor_8098d268f52aac08, []int{36}
 }
 
 func (m *Digest) XXX_Unmarshal(b []byte) error {
@@ -1566,7 +2274,7 @@ func (m *ValidationInfo) Reset()         { *m = ValidationInfo{} }
 func (m *ValidationInfo) String() string { return proto.CompactTextString(m) }
 func (*ValidationInfo) ProtoMessage()    {}
 func (*ValidationInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{26}
+	return fileDescriptor_8098d268f52aac08, []int{37}
 }
 
 func (m *ValidationInfo) XXX_Unmarshal(b []byte) error {
@@ -1613,7 +2321,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{27}
+	return fileDescriptor_8098d268f52aac08, []int{38}
 }
 
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
@@ -1660,7 +2368,7 @@ func (m *BlockProof) Reset()         { *m = BlockProof{} }
 func (m *BlockProof) String() string { return proto.CompactTextString(m) }
 func (*BlockProof) ProtoMessage()    {}
 func (*BlockProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{28}
+	return fileDescriptor_8098d268f52aac08, []int{39}
 }
 
 func (m *BlockProof) XXX_Unmarshal(b []byte) error {
@@ -1707,7 +2415,7 @@ func (m *TxReceipt) Reset()         { *m = TxReceipt{} }
 func (m *TxReceipt) String() string { return proto.CompactTextString(m) }
 func (*TxReceipt) ProtoMessage()    {}
 func (*TxReceipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{29}
+	return fileDescriptor_8098d268f52aac08, []int{40}
 }
 
 func (m *TxReceipt) XXX_Unmarshal(b []byte) error {
@@ -1748,17 +2456,21 @@ type ConsensusMetadata struct {
 	// The Raft term associated with the block
 	RaftTerm uint64 `protobuf:"varint,1,opt,name=raft_term,json=raftTerm,proto3" json:"raft_term,omitempty"`
 	// The Raft index associated with the block
-	RaftIndex            uint64   `protobuf:"varint,2,opt,name=raft_index,json=raftIndex,proto3" json:"raft_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RaftIndex uint64 `protobuf:"varint,2,opt,name=raft_index,json=raftIndex,proto3" json:"raft_index,omitempty"`
+	// The BFT view in which the block was committed
+	BftView uint64 `protobuf:"varint,3,opt,name=bft_view,json=bftView,proto3" json:"bft_view,omitempty"`
+	// The commit signatures of a quorum of BFT members on the block
+	BftSignatures        []*BFTSignature `protobuf:"bytes,4,rep,name=bft_signatures,json=bftSignatures,proto3" json:"bft_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *ConsensusMetadata) Reset()         { *m = ConsensusMetadata{} }
 func (m *ConsensusMetadata) String() string { return proto.CompactTextString(m) }
 func (*ConsensusMetadata) ProtoMessage()    {}
 func (*ConsensusMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{30}
+	return fileDescriptor_8098d268f52aac08, []int{41}
 }
 
 func (m *ConsensusMetadata) XXX_Unmarshal(b []byte) error {
@@ -1793,6 +2505,183 @@ func (m *ConsensusMetadata) GetRaftIndex() uint64 {
 	return 0
 }
 
+func (m *ConsensusMetadata) GetBftView() uint64 {
+	if m != nil {
+		return m.BftView
+	}
+	return 0
+}
+
+func (m *ConsensusMetadata) GetBftSignatures() []*BFTSignature {
+	if m != nil {
+		return m.BftSignatures
+	}
+	return nil
+}
+
+// BFTSignature is the signature of a BFT member on the commit of a block, see the bft package for the signed bytes.
+type BFTSignature struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BFTSignature) Reset()         { *m = BFTSignature{} }
+func (m *BFTSignature) String() string { return proto.CompactTextString(m) }
+func (*BFTSignature) ProtoMessage()    {}
+func (*BFTSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{42}
+}
+
+func (m *BFTSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BFTSignature.Unmarshal(m, b)
+}
+func (m *BFTSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BFTSignature.Marshal(b, m, deterministic)
+}
+func (m *BFTSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BFTSignature.Merge(m, src)
+}
+func (m *BFTSignature) XXX_Size() int {
+	return xxx_messageInfo_BFTSignature.Size(m)
+}
+func (m *BFTSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_BFTSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BFTSignature proto.InternalMessageInfo
+
+func (m *BFTSignature) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *BFTSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// BlockAttestation is the signature of a node on the header of a committed block, see the attestation package for
+// the signed bytes.
+type BlockAttestation struct {
+	BlockNumber          uint64   `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	NodeId               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockAttestation) Reset()         { *m = BlockAttestation{} }
+func (m *BlockAttestation) String() string { return proto.CompactTextString(m) }
+func (*BlockAttestation) ProtoMessage()    {}
+func (*BlockAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{43}
+}
+
+func (m *BlockAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAttestation.Unmarshal(m, b)
+}
+func (m *BlockAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAttestation.Marshal(b, m, deterministic)
+}
+func (m *BlockAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAttestation.Merge(m, src)
+}
+func (m *BlockAttestation) XXX_Size() int {
+	return xxx_messageInfo_BlockAttestation.Size(m)
+}
+func (m *BlockAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAttestation proto.InternalMessageInfo
+
+func (m *BlockAttestation) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockAttestation) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *BlockAttestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// QuorumCertificate holds the attestations of a majority of the consensus members on the header of a block, which
+// proves that they agreed on the block.
+type QuorumCertificate struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The hash of the block header the attestations sign
+	HeaderHash           []byte              `protobuf:"bytes,2,opt,name=header_hash,json=headerHash,proto3" json:"header_hash,omitempty"`
+	Attestations         []*BlockAttestation `protobuf:"bytes,3,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *QuorumCertificate) Reset()         { *m = QuorumCertificate{} }
+func (m *QuorumCertificate) String() string { return proto.CompactTextString(m) }
+func (*QuorumCertificate) ProtoMessage()    {}
+func (*QuorumCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{44}
+}
+
+func (m *QuorumCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuorumCertificate.Unmarshal(m, b)
+}
+func (m *QuorumCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuorumCertificate.Marshal(b, m, deterministic)
+}
+func (m *QuorumCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuorumCertificate.Merge(m, src)
+}
+func (m *QuorumCertificate) XXX_Size() int {
+	return xxx_messageInfo_QuorumCertificate.Size(m)
+}
+func (m *QuorumCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuorumCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuorumCertificate proto.InternalMessageInfo
+
+func (m *QuorumCertificate) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *QuorumCertificate) GetHeaderHash() []byte {
+	if m != nil {
+		return m.HeaderHash
+	}
+	return nil
+}
+
+func (m *QuorumCertificate) GetAttestations() []*BlockAttestation {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
 type AugmentedBlockHeader struct {
 	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	TxIds                []string     `protobuf:"bytes,2,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
@@ -1805,7 +2694,7 @@ func (m *AugmentedBlockHeader) Reset()         { *m = AugmentedBlockHeader{} }
 func (m *AugmentedBlockHeader) String() string { return proto.CompactTextString(m) }
 func (*AugmentedBlockHeader) ProtoMessage()    {}
 func (*AugmentedBlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{31}
+	return fileDescriptor_8098d268f52aac08, []int{45}
 }
 
 func (m *AugmentedBlockHeader) XXX_Unmarshal(b []byte) error {
@@ -1850,28 +2739,47 @@ func init() {
 	proto.RegisterType((*DataTxEnvelopes)(nil), "types.DataTxEnvelopes")
 	proto.RegisterType((*DataTxEnvelope)(nil), "types.DataTxEnvelope")
 	proto.RegisterMapType((map[string][]byte)(nil), "types.DataTxEnvelope.SignaturesEntry")
+	proto.RegisterType((*ProcedureTxEnvelopes)(nil), "types.ProcedureTxEnvelopes")
+	proto.RegisterType((*ProcedureTxEnvelope)(nil), "types.ProcedureTxEnvelope")
+	proto.RegisterMapType((map[string][]byte)(nil), "types.ProcedureTxEnvelope.SignaturesEntry")
 	proto.RegisterType((*ConfigTxEnvelope)(nil), "types.ConfigTxEnvelope")
+	proto.RegisterMapType((map[string][]byte)(nil), "types.ConfigTxEnvelope.SignaturesEntry")
 	proto.RegisterType((*DBAdministrationTxEnvelope)(nil), "types.DBAdministrationTxEnvelope")
+	proto.RegisterMapType((map[string][]byte)(nil), "types.DBAdministrationTxEnvelope.SignaturesEntry")
 	proto.RegisterType((*UserAdministrationTxEnvelope)(nil), "types.UserAdministrationTxEnvelope")
+	proto.RegisterMapType((map[string][]byte)(nil), "types.UserAdministrationTxEnvelope.SignaturesEntry")
+	proto.RegisterType((*ExpiryTxEnvelope)(nil), "types.ExpiryTxEnvelope")
 	proto.RegisterType((*DataTx)(nil), "types.DataTx")
+	proto.RegisterType((*ProcedureTx)(nil), "types.ProcedureTx")
+	proto.RegisterType((*ExpiryTx)(nil), "types.ExpiryTx")
+	proto.RegisterType((*ExpiredKey)(nil), "types.ExpiredKey")
 	proto.RegisterType((*DBOperation)(nil), "types.DBOperation")
 	proto.RegisterType((*DataRead)(nil), "types.DataRead")
 	proto.RegisterType((*DataWrite)(nil), "types.DataWrite")
+	proto.RegisterType((*TTL)(nil), "types.TTL")
 	proto.RegisterType((*DataDelete)(nil), "types.DataDelete")
 	proto.RegisterType((*ConfigTx)(nil), "types.ConfigTx")
 	proto.RegisterType((*DBAdministrationTx)(nil), "types.DBAdministrationTx")
 	proto.RegisterMapType((map[string]*DBIndex)(nil), "types.DBAdministrationTx.DbsIndexEntry")
+	proto.RegisterMapType((map[string]*DBProcedures)(nil), "types.DBAdministrationTx.DbsProceduresEntry")
+	proto.RegisterMapType((map[string]*Quota)(nil), "types.DBAdministrationTx.DbsQuotaEntry")
+	proto.RegisterMapType((map[string]*DBSchema)(nil), "types.DBAdministrationTx.DbsSchemaEntry")
 	proto.RegisterType((*DBIndex)(nil), "types.DBIndex")
 	proto.RegisterMapType((map[string]IndexAttributeType)(nil), "types.DBIndex.AttributeAndTypeEntry")
+	proto.RegisterType((*DBSchema)(nil), "types.DBSchema")
+	proto.RegisterType((*DBProcedures)(nil), "types.DBProcedures")
+	proto.RegisterMapType((map[string]string)(nil), "types.DBProcedures.ProceduresEntry")
 	proto.RegisterType((*UserAdministrationTx)(nil), "types.UserAdministrationTx")
 	proto.RegisterType((*UserRead)(nil), "types.UserRead")
 	proto.RegisterType((*UserWrite)(nil), "types.UserWrite")
 	proto.RegisterType((*UserDelete)(nil), "types.UserDelete")
 	proto.RegisterType((*Metadata)(nil), "types.Metadata")
+	proto.RegisterType((*Expiry)(nil), "types.Expiry")
 	proto.RegisterType((*Version)(nil), "types.Version")
 	proto.RegisterType((*AccessControl)(nil), "types.AccessControl")
 	proto.RegisterMapType((map[string]bool)(nil), "types.AccessControl.ReadUsersEntry")
 	proto.RegisterMapType((map[string]bool)(nil), "types.AccessControl.ReadWriteUsersEntry")
+	proto.RegisterType((*QuotaUsage)(nil), "types.QuotaUsage")
 	proto.RegisterType((*KVWithMetadata)(nil), "types.KVWithMetadata")
 	proto.RegisterType((*ValueWithMetadata)(nil), "types.ValueWithMetadata")
 	proto.RegisterType((*Digest)(nil), "types.Digest")
@@ -1880,131 +2788,182 @@ func init() {
 	proto.RegisterType((*BlockProof)(nil), "types.BlockProof")
 	proto.RegisterType((*TxReceipt)(nil), "types.TxReceipt")
 	proto.RegisterType((*ConsensusMetadata)(nil), "types.ConsensusMetadata")
+	proto.RegisterType((*BFTSignature)(nil), "types.BFTSignature")
+	proto.RegisterType((*BlockAttestation)(nil), "types.BlockAttestation")
+	proto.RegisterType((*QuorumCertificate)(nil), "types.QuorumCertificate")
 	proto.RegisterType((*AugmentedBlockHeader)(nil), "types.AugmentedBlockHeader")
 }
 
 func init() { proto.RegisterFile("block_and_transaction.proto", fileDescriptor_8098d268f52aac08) }
 
 var fileDescriptor_8098d268f52aac08 = []byte{
-	// 1910 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xdd, 0x72, 0xdb, 0xb8,
-	0x15, 0x8e, 0xfe, 0xad, 0x23, 0x47, 0xa2, 0x11, 0x3b, 0x51, 0x9c, 0xa4, 0xc9, 0x32, 0xfb, 0x93,
-	0xcd, 0xce, 0x2a, 0xd3, 0x64, 0xdb, 0x74, 0xdb, 0x4d, 0x67, 0xf4, 0x97, 0x98, 0x13, 0x5b, 0xca,
-	0x40, 0x8c, 0xd3, 0xed, 0x4e, 0xcb, 0x21, 0x45, 0x48, 0xe2, 0x44, 0x22, 0x55, 0x02, 0x72, 0xe4,
-	0xcb, 0x4e, 0x1f, 0xa1, 0x2f, 0xd0, 0xbb, 0xbe, 0x40, 0x6f, 0x3b, 0x7d, 0x8d, 0xde, 0xf4, 0x0d,
-	0xfa, 0x10, 0x3b, 0xf8, 0x21, 0x45, 0xca, 0x92, 0x13, 0xdf, 0x81, 0xf8, 0xce, 0xf9, 0xce, 0x01,
-	0x70, 0xf0, 0x01, 0x20, 0xdc, 0x71, 0xa6, 0xc1, 0xf0, 0xbd, 0x65, 0xfb, 0xae, 0xc5, 0x42, 0xdb,
-	0xa7, 0xf6, 0x90, 0x79, 0x81, 0xdf, 0x98, 0x87, 0x01, 0x0b, 0x50, 0x81, 0x9d, 0xcf, 0x09, 0x3d,
-	0xbc, 0x31, 0x0c, 0xfc, 0x91, 0x37, 0x5e, 0x84, 0xf6, 0x0a, 0xd3, 0xff, 0x9f, 0x83, 0x42, 0x8b,
-	0xfb, 0xa2, 0xc7, 0x50, 0x9c, 0x10, 0xdb, 0x25, 0x61, 0x3d, 0xf3, 0x20, 0xf3, 0xa8, 0xf2, 0x14,
-	0x35, 0x84, 0x5b, 0x43, 0xa0, 0x47, 0x02, 0xc1, 0xca, 0x02, 0x75, 0x60, 0xcf, 0xb5, 0x99, 0x6d,
-	0xb1, 0xa5, 0x45, 0xfc, 0x33, 0x32, 0x0d, 0xe6, 0x84, 0xd6, 0xb3, 0xc2, 0xed, 0xa6, 0x72, 0xeb,
-	0xd8, 0xcc, 0x36, 0x97, 0xdd, 0x08, 0x3d, 0xba, 0x86, 0x6b, 0x6e, 0xba, 0x0b, 0xbd, 0x02, 0x24,
-	0x53, 0x4a, 0xf2, 0xd4, 0x73, 0x82, 0xe6, 0x96, 0xa2, 0x69, 0x0b, 0x83, 0x95, 0xd7, 0xd1, 0x35,
-	0xac, 0x0d, 0xd7, 0xfa, 0xd0, 0x08, 0xee, 0xb9, 0x8e, 0x65, 0xbb, 0x33, 0xcf, 0xf7, 0x28, 0x93,
-	0xe3, 0x4b, 0x71, 0xe6, 0x05, 0xe7, 0x67, 0x51, 0x6a, 0xad, 0x66, 0xca, 0x34, 0xc5, 0x7e, 0xe8,
-	0x3a, 0xdb, 0x50, 0x34, 0x85, 0xfb, 0x0b, 0x4a, 0xc2, 0xcb, 0x22, 0x15, 0x44, 0xa4, 0x87, 0x2a,
-	0xd2, 0x5b, 0x4a, 0xc2, 0x4b, 0x62, 0xdd, 0x5d, 0x5c, 0x82, 0xab, 0xe9, 0xa1, 0xc4, 0xa7, 0x0b,
-	0x6a, 0xcd, 0x08, 0xb3, 0xf9, 0xfc, 0xd5, 0x8b, 0x22, 0x40, 0x7d, 0x35, 0x3d, 0xd2, 0xe0, 0x44,
-	0xe1, 0x78, 0x6f, 0xb8, 0xde, 0xd5, 0x2a, 0x43, 0xe9, 0x8d, 0x7d, 0x3e, 0x0d, 0x6c, 0x57, 0xff,
-	0x6f, 0x06, 0x6a, 0x89, 0x05, 0x6d, 0xd9, 0x94, 0xa0, 0x9b, 0x50, 0xf4, 0x17, 0x33, 0x47, 0x2d,
-	0x7c, 0x1e, 0xab, 0x2f, 0xf4, 0x3d, 0xdc, 0x9e, 0x87, 0xe4, 0xcc, 0x0b, 0x16, 0xd4, 0x72, 0x6c,
-	0x4a, 0x2c, 0xb9, 0xf8, 0xd6, 0xc4, 0xa6, 0x13, 0xb1, 0xd8, 0xbb, 0xf8, 0x66, 0x64, 0xc0, 0x89,
-	0x24, 0xe5, 0x91, 0x4d, 0x27, 0xdc, 0x75, 0x6a, 0x53, 0x66, 0x0d, 0x83, 0xd9, 0xcc, 0x63, 0x8c,
-	0xb8, 0x96, 0xac, 0x4f, 0xe1, 0x9a, 0x93, 0xae, 0xdc, 0xa0, 0x1d, 0xe1, 0x32, 0x27, 0xee, 0xfa,
-	0x1c, 0xea, 0x1b, 0x5d, 0xfd, 0xc5, 0x4c, 0x2c, 0x63, 0x1e, 0x1f, 0x5c, 0xf4, 0xec, 0x2d, 0x66,
-	0xfa, 0x3f, 0xb3, 0x50, 0x49, 0x0c, 0x0d, 0x3d, 0x87, 0x4a, 0x22, 0x6b, 0x55, 0xd4, 0x37, 0x2f,
-	0x16, 0x35, 0x4f, 0x1d, 0x83, 0x13, 0x0f, 0x00, 0x7d, 0x0d, 0x1a, 0x7d, 0xef, 0xcd, 0x87, 0x13,
-	0xdb, 0xf3, 0x45, 0xc6, 0xa2, 0xb6, 0x73, 0x8f, 0x76, 0x71, 0x2d, 0xee, 0x3f, 0x12, 0xdd, 0xe8,
-	0xd7, 0x50, 0x67, 0x4b, 0x6b, 0x46, 0xc2, 0xf7, 0x64, 0x6a, 0xb1, 0x90, 0x10, 0x2b, 0x0c, 0x02,
-	0x96, 0x1c, 0xe6, 0x3e, 0x5b, 0x9e, 0x08, 0xd8, 0x0c, 0x09, 0xc1, 0x41, 0xc0, 0xc4, 0x20, 0x7f,
-	0x80, 0x3b, 0x94, 0xd9, 0x8c, 0x6c, 0x71, 0xcd, 0x0b, 0xd7, 0x5b, 0xc2, 0x64, 0x83, 0xf7, 0xef,
-	0xa1, 0x76, 0x66, 0x4f, 0x3d, 0x57, 0x56, 0x9f, 0xe7, 0x8f, 0x82, 0x7a, 0xe1, 0x41, 0xee, 0x51,
-	0xe5, 0xe9, 0x81, 0x1a, 0xdd, 0x69, 0x8c, 0x1a, 0xfe, 0x28, 0xc0, 0xd5, 0xb3, 0xd4, 0xb7, 0xfe,
-	0x12, 0x6a, 0x6b, 0xbb, 0x13, 0x3d, 0x83, 0xf2, 0x6a, 0x23, 0x67, 0x52, 0x64, 0x69, 0x53, 0xbc,
-	0xb2, 0xd3, 0xff, 0x93, 0x81, 0x6a, 0x1a, 0x45, 0x5f, 0x41, 0x69, 0x2e, 0x4b, 0x4d, 0x4d, 0xf8,
-	0xf5, 0x14, 0x0b, 0x8e, 0x50, 0xd4, 0x05, 0xa0, 0xde, 0xd8, 0xb7, 0xd9, 0x22, 0x54, 0xd3, 0x5b,
-	0x79, 0xfa, 0xc5, 0xc6, 0x88, 0x8d, 0x41, 0x6c, 0xd7, 0xf5, 0x59, 0x78, 0x8e, 0x13, 0x8e, 0x87,
-	0x2f, 0xa0, 0xb6, 0x06, 0x23, 0x0d, 0x72, 0xef, 0xc9, 0xb9, 0x08, 0x5f, 0xc6, 0xbc, 0x89, 0xf6,
-	0xa1, 0x70, 0x66, 0x4f, 0x17, 0x44, 0x15, 0xad, 0xfc, 0xf8, 0x6d, 0xf6, 0x37, 0x19, 0xfd, 0x27,
-	0xd0, 0xd6, 0x05, 0x06, 0x7d, 0xbd, 0x3e, 0x84, 0xda, 0x9a, 0x14, 0xad, 0x06, 0x71, 0x17, 0xca,
-	0x71, 0x2e, 0x8a, 0x7c, 0xd5, 0xa1, 0x07, 0x70, 0xb8, 0x5d, 0x69, 0xd0, 0xb3, 0xf5, 0x30, 0xb7,
-	0xb7, 0xaa, 0xd3, 0xa7, 0x06, 0xa4, 0x70, 0xf7, 0x32, 0xc1, 0x41, 0xbf, 0x5a, 0x0f, 0x79, 0xe7,
-	0x12, 0x99, 0xfa, 0xd4, 0xa0, 0x7f, 0xcb, 0x40, 0x51, 0x2e, 0x18, 0xfa, 0x06, 0xd0, 0x6c, 0x41,
-	0x99, 0xc5, 0x41, 0x4b, 0x08, 0xa5, 0xe7, 0xca, 0x6a, 0x2a, 0xe3, 0x1a, 0x47, 0xf8, 0x52, 0xf1,
-	0x58, 0x86, 0x4b, 0xd1, 0x0d, 0x28, 0xb0, 0xa5, 0xe5, 0xb9, 0x82, 0xb1, 0x8c, 0xf3, 0x6c, 0x69,
-	0xb8, 0xe8, 0x39, 0x5c, 0x77, 0x1d, 0x2b, 0x98, 0x13, 0x99, 0x05, 0xad, 0xe7, 0x44, 0x61, 0xa0,
-	0x78, 0x6a, 0xfa, 0x11, 0x84, 0x77, 0x5d, 0x27, 0xfe, 0x10, 0xa5, 0x58, 0x49, 0xa0, 0xe8, 0x16,
-	0x94, 0x5c, 0xc7, 0xf2, 0xed, 0x99, 0x3c, 0x4f, 0xca, 0xb8, 0xe8, 0x3a, 0x3d, 0x7b, 0x46, 0x50,
-	0x03, 0x40, 0x9c, 0x5c, 0x21, 0xb1, 0x5d, 0x5a, 0xcf, 0x0b, 0xfa, 0x5a, 0xa2, 0xee, 0x30, 0xb1,
-	0x5d, 0x5c, 0x76, 0x55, 0x8b, 0xa2, 0x5f, 0x42, 0x45, 0xd8, 0x7f, 0x08, 0x3d, 0x46, 0xa8, 0xda,
-	0x67, 0x5a, 0xc2, 0xe1, 0x1d, 0x07, 0xb0, 0x20, 0x15, 0x4d, 0x8a, 0xbe, 0x83, 0x5d, 0xe1, 0xe2,
-	0x92, 0x29, 0xe1, 0x3e, 0x45, 0xe1, 0xb3, 0x97, 0xf0, 0xe9, 0x08, 0x04, 0x0b, 0x66, 0xd9, 0xa6,
-	0xfa, 0x4b, 0xd8, 0x89, 0xe2, 0x6f, 0x28, 0xe1, 0x47, 0x50, 0x3a, 0x23, 0x21, 0xf5, 0x02, 0x5f,
-	0x1d, 0xb3, 0xd5, 0x68, 0xab, 0xcb, 0x5e, 0x1c, 0xc1, 0xfa, 0x4f, 0x50, 0x8e, 0xd3, 0xfa, 0xd4,
-	0xbd, 0x80, 0xbe, 0x84, 0x9c, 0x3d, 0x9c, 0xaa, 0xa3, 0x77, 0x5f, 0x51, 0x37, 0x87, 0x43, 0x42,
-	0x69, 0x3b, 0xf0, 0x59, 0x18, 0x4c, 0x31, 0x37, 0xd0, 0x7f, 0x01, 0xb0, 0xca, 0xff, 0x22, 0xbb,
-	0xfe, 0xaf, 0x0c, 0xec, 0x44, 0xdb, 0x84, 0xaf, 0x81, 0x2a, 0x02, 0x65, 0x52, 0x5c, 0x88, 0xb5,
-	0xdf, 0xbc, 0xf4, 0x5d, 0xb8, 0xc5, 0xd7, 0xc4, 0x0a, 0xa6, 0xae, 0xa5, 0x6e, 0x05, 0xd1, 0x88,
-	0x73, 0x1b, 0x47, 0xbc, 0xcf, 0xcd, 0xfb, 0x53, 0x57, 0xc6, 0x53, 0xbd, 0xe8, 0x19, 0x80, 0x4f,
-	0x3e, 0x28, 0x06, 0x75, 0xee, 0x47, 0x03, 0x6a, 0x4f, 0x17, 0x94, 0x91, 0x50, 0x3a, 0xe0, 0xb2,
-	0x4f, 0x3e, 0xc8, 0xa6, 0xfe, 0xf7, 0x2c, 0xa0, 0x8b, 0xdb, 0xee, 0x8a, 0x03, 0xb8, 0x07, 0x30,
-	0x0c, 0x09, 0x17, 0x75, 0xd7, 0x91, 0x85, 0x5b, 0xc6, 0x65, 0xd9, 0xd3, 0x71, 0x28, 0x87, 0x65,
-	0x41, 0x08, 0x38, 0x2f, 0x61, 0xd9, 0xc3, 0xe1, 0x0e, 0x94, 0x5d, 0x87, 0x5a, 0x9e, 0xef, 0x92,
-	0xa5, 0xaa, 0xb2, 0xaf, 0xb6, 0x0a, 0x42, 0xa3, 0xe3, 0x50, 0x83, 0x5b, 0x4a, 0x41, 0xdc, 0x71,
-	0xd5, 0xe7, 0xe1, 0x6b, 0xb8, 0x9e, 0x82, 0x36, 0x14, 0xc0, 0xe7, 0xc9, 0x02, 0x58, 0xcd, 0x6a,
-	0xa7, 0x25, 0xbc, 0x92, 0xe2, 0xf8, 0xef, 0x0c, 0x94, 0x54, 0x37, 0xc2, 0x80, 0x6c, 0xc6, 0x42,
-	0xcf, 0x59, 0x30, 0x22, 0x6f, 0x99, 0xe7, 0x73, 0xa2, 0x0e, 0x8a, 0xcf, 0xd3, 0x14, 0x8d, 0x66,
-	0x64, 0xd8, 0xf4, 0x5d, 0xf3, 0x7c, 0x4e, 0x64, 0x92, 0x9a, 0xbd, 0xd6, 0x7d, 0xf8, 0x67, 0x38,
-	0xd8, 0x68, 0xba, 0x21, 0xe9, 0x27, 0xc9, 0xa4, 0xab, 0xb1, 0x54, 0x8a, 0x78, 0x31, 0x07, 0x27,
-	0x48, 0xe6, 0xff, 0xbf, 0x0c, 0xec, 0x6f, 0x52, 0xb6, 0x2b, 0xae, 0x6b, 0x03, 0x40, 0x58, 0x4b,
-	0xc5, 0xc8, 0xa5, 0x14, 0x83, 0xd3, 0x4b, 0xc5, 0x58, 0xa8, 0x96, 0x50, 0x0c, 0x61, 0xaf, 0x14,
-	0x23, 0x9f, 0x52, 0x0c, 0xee, 0xa0, 0x14, 0x63, 0x11, 0x35, 0x85, 0x62, 0x08, 0x97, 0x48, 0x31,
-	0x0a, 0x29, 0xc5, 0xe0, 0x3e, 0x91, 0x62, 0x2c, 0xe2, 0x36, 0xd5, 0x4f, 0x60, 0x27, 0x8a, 0xbf,
-	0x7d, 0x48, 0x9f, 0x2e, 0x1c, 0x26, 0x94, 0xe3, 0xec, 0xd0, 0x7d, 0xc8, 0x73, 0x02, 0x75, 0x4e,
-	0x54, 0x92, 0xc3, 0x15, 0x40, 0xa4, 0x18, 0xd9, 0x8f, 0x29, 0xc6, 0x17, 0x00, 0xab, 0xfc, 0xb7,
-	0xa6, 0xa9, 0xff, 0x05, 0x76, 0xa2, 0xeb, 0x6a, 0x32, 0xe5, 0xcc, 0xa5, 0x29, 0xa3, 0xdf, 0x41,
-	0xd5, 0x16, 0x21, 0xf9, 0x7e, 0xe7, 0x31, 0x2f, 0xcd, 0xe7, 0xba, 0x9d, 0xfc, 0xd4, 0x5f, 0x40,
-	0x29, 0x12, 0x8d, 0x3b, 0x50, 0x5e, 0x5d, 0x32, 0xe5, 0x25, 0x78, 0xc7, 0x51, 0xf7, 0x4a, 0x74,
-	0x00, 0x45, 0xb6, 0x14, 0x48, 0x56, 0x20, 0x05, 0xb6, 0xe4, 0xd7, 0xcd, 0x7f, 0xe4, 0xe0, 0x7a,
-	0x8a, 0x1f, 0xb5, 0x00, 0x84, 0x82, 0xf1, 0x21, 0x45, 0x97, 0xa8, 0x87, 0x9b, 0x32, 0x69, 0xf0,
-	0x25, 0xe3, 0xb3, 0xa2, 0x2e, 0x34, 0xe5, 0x30, 0xfa, 0x46, 0x18, 0x34, 0xc1, 0x21, 0x8a, 0x47,
-	0x31, 0xc9, 0xcb, 0xd1, 0xa3, 0xad, 0x4c, 0x62, 0xc5, 0x12, 0x74, 0xd5, 0x30, 0xd5, 0x89, 0x4c,
-	0x38, 0x10, 0x27, 0xf2, 0x3c, 0x98, 0x7a, 0xc3, 0x73, 0x6b, 0x14, 0xa8, 0xda, 0x14, 0xba, 0x5a,
-	0x8d, 0x5f, 0x45, 0x69, 0x62, 0x99, 0x80, 0x74, 0xc1, 0x88, 0xfb, 0xbf, 0x11, 0xed, 0x97, 0x81,
-	0xac, 0x90, 0xc3, 0x1f, 0xa0, 0x9a, 0x1e, 0xc6, 0xc7, 0x0e, 0x9b, 0x9d, 0xc4, 0xde, 0x3c, 0x6c,
-	0xc2, 0x8d, 0x0d, 0xa9, 0x5f, 0x85, 0x42, 0x7f, 0x00, 0xbb, 0xc9, 0x24, 0x51, 0x09, 0x72, 0xcd,
-	0xde, 0x8f, 0xda, 0x35, 0xd1, 0x38, 0x3e, 0xd6, 0x32, 0x3a, 0x81, 0xea, 0xeb, 0xd3, 0x77, 0x1e,
-	0x9b, 0xc4, 0xa5, 0xf5, 0xa9, 0xe7, 0xe1, 0x37, 0xb0, 0x13, 0x3f, 0xb8, 0x72, 0xa9, 0x4b, 0x60,
-	0xfc, 0xce, 0x8a, 0x0d, 0xf4, 0x53, 0xd8, 0x3b, 0xe5, 0x5e, 0xa9, 0x48, 0x31, 0x6f, 0x66, 0x1b,
-	0x6f, 0xf6, 0x63, 0xbc, 0x2f, 0xa0, 0xd8, 0xf1, 0xc6, 0x84, 0x32, 0x5e, 0x9f, 0xab, 0xc7, 0x81,
-	0x24, 0xdc, 0x09, 0xa3, 0xd7, 0xc0, 0x4d, 0xfe, 0x6e, 0xf7, 0xc6, 0x13, 0xa6, 0xea, 0x53, 0x7d,
-	0xe9, 0x7f, 0x82, 0x6a, 0xfa, 0x1d, 0xc0, 0x37, 0xf5, 0x68, 0x6a, 0x8f, 0x05, 0x43, 0x35, 0xde,
-	0xd4, 0x2f, 0xa7, 0xf6, 0x18, 0x0b, 0x00, 0x3d, 0x86, 0xbd, 0x90, 0xd8, 0x94, 0x3f, 0x2a, 0x46,
-	0x96, 0xe7, 0x8b, 0x67, 0x83, 0xd2, 0xc2, 0x9a, 0x04, 0x8c, 0x91, 0x21, 0xbb, 0x75, 0x03, 0x4a,
-	0xe6, 0xf2, 0x4d, 0x18, 0x04, 0xa3, 0x2b, 0xfd, 0x39, 0x40, 0x90, 0x9f, 0xdb, 0x6c, 0xa2, 0x1e,
-	0x54, 0xa2, 0xad, 0xbf, 0x03, 0x10, 0xa6, 0x92, 0xed, 0x33, 0xd8, 0x8d, 0x37, 0xe3, 0xea, 0x51,
-	0x5a, 0x89, 0xf6, 0xa3, 0x23, 0xc4, 0x67, 0x45, 0xb2, 0x39, 0x9c, 0x24, 0xc6, 0x50, 0x36, 0x97,
-	0x98, 0x0c, 0x89, 0x37, 0x67, 0x57, 0xca, 0xf2, 0x36, 0xec, 0xf0, 0x83, 0x40, 0x1c, 0xc6, 0x72,
-	0x56, 0x4b, 0x6c, 0x29, 0x4e, 0x1b, 0xbd, 0x0f, 0x7b, 0x17, 0x1e, 0xdd, 0x62, 0x81, 0xec, 0x11,
-	0xb3, 0x18, 0x09, 0x63, 0x01, 0xe1, 0x1d, 0x26, 0x09, 0x67, 0xfc, 0xe4, 0x17, 0x60, 0x92, 0x4e,
-	0x98, 0x4b, 0xc2, 0x1f, 0x61, 0xbf, 0xb9, 0x18, 0xcf, 0x88, 0x1f, 0x3f, 0x83, 0x65, 0x0e, 0x57,
-	0xc9, 0x57, 0x6a, 0x14, 0xbf, 0x6d, 0x67, 0xc5, 0xc5, 0xa2, 0xc0, 0x4f, 0x2e, 0xfa, 0xf8, 0xaf,
-	0x59, 0xc8, 0xf3, 0xe5, 0x45, 0x65, 0x28, 0x9c, 0x36, 0x8f, 0x8d, 0x8e, 0x76, 0x0d, 0x7d, 0x09,
-	0xba, 0xd1, 0x13, 0x1f, 0xd6, 0xc9, 0x69, 0xbb, 0x6d, 0xb5, 0xfb, 0xbd, 0x97, 0xc7, 0x46, 0xdb,
-	0xb4, 0xde, 0x19, 0xe6, 0x91, 0xd1, 0xb3, 0x5a, 0xc7, 0xfd, 0xf6, 0x6b, 0x2d, 0x83, 0x1a, 0xf0,
-	0x78, 0xbb, 0x9d, 0xd5, 0xee, 0x9f, 0x9c, 0x18, 0xa6, 0xd9, 0xed, 0x58, 0x03, 0xb3, 0x69, 0x76,
-	0xb5, 0x2c, 0x7a, 0x08, 0xf7, 0x23, 0xfb, 0x4e, 0xd3, 0x6c, 0xb6, 0x9a, 0x83, 0xae, 0xd5, 0xe9,
-	0x77, 0x07, 0x56, 0xaf, 0x6f, 0x5a, 0xdd, 0x3f, 0x18, 0x03, 0x53, 0xcb, 0xa1, 0xdb, 0x70, 0x10,
-	0x19, 0xf5, 0xfa, 0xd6, 0x9b, 0x2e, 0x3e, 0x31, 0x06, 0x03, 0xa3, 0xdf, 0xd3, 0xf2, 0xe8, 0x1e,
-	0xdc, 0x8e, 0x20, 0xa3, 0xd7, 0xee, 0x63, 0xdc, 0x6d, 0x9b, 0x56, 0xb7, 0x67, 0x62, 0xa3, 0x3b,
-	0xd0, 0x0a, 0xa8, 0x0e, 0xfb, 0x11, 0xfc, 0xb6, 0xd7, 0x7c, 0x6b, 0x1e, 0xf5, 0xb1, 0x31, 0xe8,
-	0x76, 0xb4, 0x62, 0xd2, 0x51, 0xb0, 0xf5, 0x5e, 0x59, 0x03, 0xe3, 0x55, 0xaf, 0x69, 0xbe, 0xc5,
-	0x5d, 0xad, 0xf4, 0xf8, 0x7b, 0x40, 0x17, 0xaf, 0x09, 0x08, 0xa0, 0xd8, 0x7b, 0x7b, 0xd2, 0xea,
-	0x62, 0xed, 0x1a, 0x6f, 0x0f, 0x4c, 0x6c, 0xf4, 0x5e, 0x69, 0x19, 0x54, 0x81, 0x52, 0xab, 0xdf,
-	0x3f, 0xee, 0x36, 0x7b, 0x5a, 0xb6, 0xf5, 0xdd, 0x1f, 0x9f, 0x8e, 0x3d, 0x36, 0x59, 0x38, 0x8d,
-	0x61, 0x30, 0x7b, 0x32, 0x39, 0x9f, 0x93, 0x70, 0x4a, 0xdc, 0x31, 0x09, 0xbf, 0x9d, 0xda, 0x0e,
-	0x7d, 0x12, 0x84, 0x5e, 0xe0, 0x7f, 0x4b, 0x49, 0x78, 0x46, 0xc2, 0x27, 0xf3, 0xf7, 0xe3, 0x27,
-	0x62, 0x7d, 0x9c, 0xa2, 0xf8, 0xb1, 0xf6, 0xec, 0xe7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x94, 0x5e,
-	0xa4, 0xe4, 0x93, 0x13, 0x00, 0x00,
+	// 2681 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x73, 0xdb, 0xc8,
+	0xf1, 0x37, 0xf8, 0x66, 0x53, 0xa2, 0xa8, 0xb1, 0x6c, 0xd3, 0x92, 0xf7, 0x6f, 0x2f, 0x76, 0xbd,
+	0xeb, 0xf5, 0xee, 0xca, 0xb5, 0xf6, 0xfe, 0xe3, 0x7d, 0x26, 0xc5, 0x07, 0x6c, 0x21, 0x96, 0x48,
+	0x79, 0x08, 0xc9, 0xde, 0xa4, 0x12, 0x14, 0x48, 0x0c, 0x25, 0xc4, 0x24, 0xc0, 0x00, 0x03, 0x99,
+	0x3c, 0xa6, 0x72, 0x48, 0x55, 0x2a, 0xa7, 0x54, 0x0e, 0x39, 0xa6, 0x2a, 0x55, 0xf9, 0x04, 0xb9,
+	0xa6, 0xf2, 0x0d, 0xf2, 0x09, 0x92, 0x53, 0x2e, 0xfb, 0x09, 0x72, 0x4e, 0xcd, 0x0c, 0x9e, 0x14,
+	0x49, 0x4b, 0x55, 0xf1, 0x6d, 0xa6, 0x1f, 0xbf, 0xe9, 0xee, 0xe9, 0x99, 0x6e, 0x0c, 0x60, 0xa7,
+	0x3f, 0x72, 0x06, 0xaf, 0x74, 0xc3, 0x36, 0x75, 0xea, 0x1a, 0xb6, 0x67, 0x0c, 0xa8, 0xe5, 0xd8,
+	0xbb, 0x13, 0xd7, 0xa1, 0x0e, 0xca, 0xd3, 0xd9, 0x84, 0x78, 0xdb, 0x57, 0x07, 0x8e, 0x3d, 0xb4,
+	0x4e, 0x7c, 0xd7, 0x88, 0x79, 0xf2, 0x6f, 0xf3, 0x90, 0x6f, 0x32, 0x5d, 0x74, 0x1f, 0x0a, 0xa7,
+	0xc4, 0x30, 0x89, 0x5b, 0x97, 0xee, 0x48, 0xf7, 0x2a, 0x0f, 0xd1, 0x2e, 0x57, 0xdb, 0xe5, 0xdc,
+	0x3d, 0xce, 0xc1, 0x81, 0x04, 0x6a, 0xc3, 0xa6, 0x69, 0x50, 0x43, 0xa7, 0x53, 0x9d, 0xd8, 0x67,
+	0x64, 0xe4, 0x4c, 0x88, 0x57, 0xcf, 0x70, 0xb5, 0xeb, 0x81, 0x5a, 0xdb, 0xa0, 0x86, 0x36, 0x55,
+	0x42, 0xee, 0xde, 0x15, 0xbc, 0x61, 0xa6, 0x49, 0xe8, 0x29, 0x20, 0x61, 0x52, 0x12, 0xa7, 0x9e,
+	0xe5, 0x30, 0x37, 0x02, 0x98, 0x16, 0x17, 0x88, 0xb5, 0xf6, 0xae, 0xe0, 0xda, 0x60, 0x8e, 0x86,
+	0x86, 0xf0, 0x8e, 0xd9, 0xd7, 0x0d, 0x73, 0x6c, 0xd9, 0x96, 0x47, 0x85, 0x7f, 0x29, 0xcc, 0x1c,
+	0xc7, 0x7c, 0x37, 0x34, 0xad, 0xd9, 0x48, 0x89, 0xa6, 0xd0, 0xb7, 0xcd, 0xfe, 0x32, 0x2e, 0x1a,
+	0xc1, 0x6d, 0xdf, 0x23, 0xee, 0xaa, 0x95, 0xf2, 0x7c, 0xa5, 0xf7, 0x82, 0x95, 0x8e, 0x3c, 0xe2,
+	0xae, 0x58, 0xeb, 0x96, 0xbf, 0x82, 0x8f, 0x7a, 0x70, 0x7d, 0xe2, 0x3a, 0x03, 0x62, 0xfa, 0x2e,
+	0x49, 0x47, 0xba, 0xc8, 0x17, 0xd9, 0x09, 0x16, 0x39, 0x0c, 0x85, 0xd2, 0xe1, 0xde, 0x9a, 0x2c,
+	0xa0, 0xb3, 0x98, 0x93, 0xe9, 0xc4, 0x72, 0x67, 0x29, 0xab, 0x4b, 0xa9, 0x98, 0x2b, 0x5c, 0x20,
+	0x1d, 0x73, 0x32, 0x47, 0x0b, 0x36, 0xcf, 0x23, 0xb6, 0xe7, 0x7b, 0xfa, 0x98, 0x50, 0x83, 0xed,
+	0x6e, 0xbd, 0xc0, 0x81, 0xea, 0xf1, 0xe6, 0x09, 0x81, 0x83, 0x80, 0x8f, 0x37, 0x07, 0xf3, 0xa4,
+	0x66, 0x19, 0x8a, 0x87, 0xc6, 0x6c, 0xe4, 0x18, 0xa6, 0xfc, 0x1f, 0x09, 0x36, 0x12, 0xe9, 0xd6,
+	0x34, 0x3c, 0x82, 0xae, 0x43, 0xc1, 0xf6, 0xc7, 0xfd, 0x20, 0x2d, 0x73, 0x38, 0x98, 0xa1, 0x2f,
+	0xe1, 0xe6, 0xc4, 0x25, 0x67, 0x96, 0xe3, 0x7b, 0x7a, 0xdf, 0xf0, 0x88, 0x2e, 0x52, 0x53, 0x3f,
+	0x35, 0xbc, 0x53, 0x9e, 0x8a, 0x6b, 0xf8, 0x7a, 0x28, 0xc0, 0x80, 0x04, 0xe4, 0x9e, 0xe1, 0x9d,
+	0x32, 0xd5, 0x91, 0xe1, 0x51, 0x7d, 0xe0, 0x8c, 0xc7, 0x16, 0xa5, 0xc4, 0xd4, 0xc5, 0xe9, 0xe1,
+	0xaa, 0x59, 0xa1, 0xca, 0x04, 0x5a, 0x21, 0x5f, 0xd8, 0xc4, 0x54, 0x1f, 0x43, 0x7d, 0xa1, 0xaa,
+	0xed, 0x8f, 0x79, 0x92, 0xe5, 0xf0, 0xb5, 0xf3, 0x9a, 0x1d, 0x7f, 0x8c, 0x6e, 0x41, 0x99, 0x5a,
+	0x63, 0xe2, 0x51, 0x63, 0x3c, 0xe1, 0x49, 0x92, 0xc5, 0x31, 0x41, 0xfe, 0x3e, 0x03, 0x95, 0x84,
+	0xe3, 0xe8, 0x31, 0x54, 0x12, 0x3e, 0xd5, 0xa5, 0xd4, 0xc9, 0x9a, 0x8b, 0x10, 0x86, 0x7e, 0xe4,
+	0x1e, 0xfa, 0x08, 0x6a, 0xde, 0x2b, 0x6b, 0x32, 0x38, 0x35, 0x2c, 0x9b, 0xfb, 0xc3, 0xcf, 0x65,
+	0xf6, 0xde, 0x1a, 0xde, 0x88, 0xe8, 0x7b, 0x9c, 0x8c, 0x7e, 0x00, 0x75, 0x3a, 0xd5, 0xc7, 0xc4,
+	0x7d, 0x45, 0x46, 0x3a, 0x75, 0x09, 0xd1, 0x5d, 0xc7, 0xa1, 0xc9, 0x20, 0x6c, 0xd1, 0xe9, 0x01,
+	0x67, 0x6b, 0x2e, 0x21, 0xd8, 0x71, 0x28, 0x0f, 0xc1, 0x37, 0xb0, 0xe3, 0x51, 0x83, 0x92, 0x25,
+	0xaa, 0x39, 0xae, 0x7a, 0x83, 0x8b, 0x2c, 0xd0, 0xfe, 0x21, 0x6c, 0x9c, 0x19, 0x23, 0xcb, 0x14,
+	0x27, 0xc7, 0xb2, 0x87, 0x4e, 0x3d, 0x7f, 0x27, 0x7b, 0xaf, 0xf2, 0xf0, 0x5a, 0xe0, 0xdd, 0x71,
+	0xc4, 0x55, 0xed, 0xa1, 0x83, 0xab, 0x67, 0xa9, 0x39, 0xfa, 0x11, 0x6c, 0xc6, 0x87, 0xc2, 0x25,
+	0x9e, 0x3f, 0xa2, 0x5e, 0xbd, 0x70, 0x27, 0x9b, 0xb8, 0xb0, 0xda, 0xcd, 0xee, 0x84, 0x88, 0xd3,
+	0x84, 0x6b, 0x91, 0x30, 0x16, 0xb2, 0xf2, 0x13, 0xd8, 0x98, 0xbb, 0x9a, 0xd0, 0x23, 0x28, 0xc7,
+	0x67, 0x4b, 0x4a, 0x59, 0x93, 0x16, 0xc5, 0xb1, 0x9c, 0xfc, 0x77, 0x09, 0xaa, 0x69, 0x2e, 0xfa,
+	0x10, 0x8a, 0x13, 0x91, 0xc9, 0xc1, 0x8e, 0xad, 0xa7, 0x50, 0x70, 0xc8, 0x45, 0x0a, 0x80, 0x67,
+	0x9d, 0xd8, 0x06, 0xf5, 0xdd, 0x60, 0x7f, 0x2a, 0x0f, 0xef, 0x2e, 0x5c, 0x71, 0xb7, 0x17, 0xc9,
+	0x29, 0x36, 0x75, 0x67, 0x38, 0xa1, 0xb8, 0xfd, 0x2d, 0x6c, 0xcc, 0xb1, 0x51, 0x0d, 0xb2, 0xaf,
+	0xc8, 0x8c, 0x2f, 0x5f, 0xc6, 0x6c, 0x88, 0xb6, 0x20, 0x7f, 0x66, 0x8c, 0x7c, 0x12, 0x9c, 0x09,
+	0x31, 0xf9, 0x2a, 0xf3, 0x85, 0x24, 0x1f, 0xc2, 0xd6, 0xa2, 0xab, 0x03, 0x7d, 0x71, 0x3e, 0x1c,
+	0xdb, 0xcb, 0xaf, 0x9a, 0x64, 0x4c, 0xfe, 0x21, 0xc1, 0xd5, 0x05, 0x22, 0xe8, 0x93, 0xf9, 0xc0,
+	0xa0, 0xf3, 0x78, 0x71, 0x74, 0x7e, 0xbc, 0x20, 0x3a, 0xf7, 0x97, 0x1b, 0xf0, 0x36, 0x43, 0xf4,
+	0x6f, 0x09, 0x6a, 0xf3, 0x15, 0x08, 0x7d, 0x34, 0xef, 0xcd, 0xc6, 0x5c, 0xad, 0x8a, 0x5d, 0xb9,
+	0x05, 0xe5, 0xc8, 0x98, 0x00, 0x3d, 0x26, 0xa0, 0xa7, 0x29, 0x47, 0xb3, 0xdc, 0xd1, 0x0f, 0x97,
+	0xd4, 0xbd, 0xb7, 0xe9, 0xe5, 0xaf, 0x32, 0xb0, 0xbd, 0xbc, 0x26, 0xa2, 0x47, 0xf3, 0xfe, 0xde,
+	0x5c, 0x5a, 0x47, 0x2f, 0xea, 0xf9, 0xf3, 0x05, 0x9e, 0x7f, 0xf6, 0xc6, 0xea, 0xfc, 0x36, 0x63,
+	0xf0, 0x9b, 0x0c, 0xdc, 0x5a, 0x55, 0xad, 0xd1, 0xff, 0xcf, 0x47, 0x61, 0x67, 0x45, 0x8d, 0xbf,
+	0x68, 0x1c, 0x7a, 0x0b, 0xe2, 0xf0, 0xe8, 0x02, 0xbd, 0xc3, 0xdb, 0x8c, 0xc4, 0x4f, 0xa1, 0x36,
+	0xdf, 0x00, 0x2c, 0x4f, 0xf9, 0x50, 0xf2, 0x82, 0x0e, 0xcb, 0xbf, 0x96, 0xa0, 0x20, 0x6e, 0x38,
+	0xf4, 0x31, 0xa0, 0xb1, 0xef, 0x51, 0x9d, 0x31, 0x75, 0xde, 0x56, 0x59, 0xa6, 0xb8, 0x6f, 0xca,
+	0x78, 0x83, 0x71, 0x98, 0x13, 0x2c, 0x08, 0xaa, 0xe9, 0xa1, 0xab, 0x90, 0xa7, 0x53, 0xdd, 0x32,
+	0x39, 0x62, 0x19, 0xe7, 0xe8, 0x54, 0x35, 0xd1, 0x63, 0x58, 0x37, 0xfb, 0xba, 0x13, 0x5e, 0xf6,
+	0x61, 0x00, 0x17, 0xd5, 0x81, 0x35, 0xb3, 0x1f, 0x4d, 0x3c, 0xf9, 0xcf, 0x12, 0x54, 0x12, 0x37,
+	0xc9, 0xff, 0xc0, 0x94, 0x1b, 0x50, 0x34, 0xfb, 0xba, 0x6d, 0x8c, 0x45, 0xff, 0x5a, 0xc6, 0x05,
+	0xb3, 0xdf, 0x31, 0xc6, 0x04, 0xdd, 0x85, 0x6a, 0x5c, 0xaf, 0x38, 0x3f, 0xc7, 0xf9, 0xeb, 0x11,
+	0x95, 0x8b, 0x21, 0xc8, 0x19, 0xee, 0x89, 0xc7, 0x6b, 0x61, 0x19, 0xf3, 0xb1, 0xfc, 0x3b, 0x09,
+	0x4a, 0x61, 0x7c, 0xe3, 0x55, 0xa5, 0xf4, 0xaa, 0xb6, 0x63, 0x92, 0xd8, 0x98, 0x02, 0x9b, 0xaa,
+	0x66, 0xba, 0xdb, 0xc8, 0xce, 0x75, 0x1b, 0xe8, 0x73, 0x58, 0xe3, 0xed, 0x1c, 0x31, 0xf5, 0x57,
+	0x64, 0xe6, 0xd5, 0x73, 0x3c, 0x6c, 0x9b, 0xc9, 0x2d, 0x25, 0xe6, 0x33, 0x32, 0xc3, 0x15, 0x12,
+	0x8d, 0x3d, 0xd9, 0x00, 0x88, 0x59, 0x49, 0x87, 0xa5, 0x94, 0xc3, 0x41, 0xaa, 0x65, 0xe2, 0x54,
+	0xbb, 0x07, 0xc5, 0x33, 0xe2, 0x7a, 0x96, 0x63, 0x07, 0xbd, 0x7d, 0x35, 0x2c, 0xf5, 0x82, 0x8a,
+	0x43, 0x36, 0xab, 0xa9, 0x95, 0xc4, 0xae, 0x2d, 0x8f, 0xea, 0x2e, 0x00, 0xff, 0xfe, 0x70, 0x89,
+	0x61, 0x86, 0xf6, 0x6f, 0x24, 0x0a, 0x28, 0x26, 0x86, 0x89, 0xcb, 0x66, 0x30, 0xf2, 0xd0, 0x67,
+	0x50, 0xe1, 0xf2, 0xaf, 0x5d, 0x8b, 0x12, 0x2f, 0xe8, 0x38, 0x6a, 0x09, 0x85, 0x17, 0x8c, 0x81,
+	0xc1, 0x0c, 0x87, 0x1e, 0x0b, 0x12, 0x57, 0x31, 0xc9, 0x88, 0x50, 0x12, 0xf6, 0x18, 0x9b, 0x09,
+	0x9d, 0x36, 0xe7, 0xe0, 0x8a, 0x19, 0x8d, 0x59, 0x77, 0x51, 0x0a, 0xd7, 0x5f, 0x70, 0xe8, 0x12,
+	0x91, 0xc8, 0xac, 0x8e, 0xc4, 0x0c, 0xca, 0x91, 0x59, 0x17, 0x3d, 0xbd, 0xe8, 0x03, 0xc8, 0x1a,
+	0x83, 0x51, 0x10, 0xe4, 0xad, 0x00, 0xba, 0x31, 0x18, 0x10, 0xcf, 0x6b, 0x39, 0x36, 0x75, 0x9d,
+	0x11, 0x66, 0x02, 0xe8, 0x16, 0x64, 0x29, 0x1d, 0x05, 0x1f, 0x45, 0x10, 0xc8, 0x69, 0xda, 0x3e,
+	0x66, 0x64, 0xf9, 0x31, 0x64, 0x35, 0x6d, 0x9f, 0xf5, 0xdd, 0xbc, 0xb5, 0xf5, 0xc2, 0xbe, 0x5b,
+	0xcc, 0x50, 0x1d, 0x8a, 0x1e, 0x19, 0x38, 0xb6, 0x29, 0x3e, 0xf8, 0x72, 0x38, 0x9c, 0xca, 0xff,
+	0x07, 0x10, 0x87, 0xe5, 0xbc, 0xd1, 0xf2, 0x5f, 0x25, 0x28, 0x85, 0x65, 0x8d, 0x6d, 0x6d, 0x70,
+	0xd0, 0xc2, 0xfc, 0xf1, 0xf9, 0xf9, 0x5a, 0x7c, 0xbc, 0x14, 0xb8, 0xc1, 0xb6, 0x5a, 0x77, 0x46,
+	0xa6, 0x1e, 0x7c, 0x32, 0xae, 0x4e, 0xa9, 0x2d, 0x26, 0xde, 0x1d, 0x99, 0x62, 0xbd, 0x80, 0x8a,
+	0x1e, 0x01, 0xd8, 0xe4, 0x75, 0x80, 0x50, 0xcf, 0xa5, 0xe2, 0xd4, 0x1a, 0xf9, 0x1e, 0x25, 0xae,
+	0x50, 0xc0, 0x65, 0x9b, 0xbc, 0x16, 0x43, 0xf9, 0xfb, 0x3c, 0xa0, 0xf3, 0x35, 0xe9, 0x92, 0x0e,
+	0xbc, 0x03, 0x30, 0x70, 0x09, 0xeb, 0x9a, 0xcd, 0xbe, 0xb8, 0xa7, 0xca, 0xb8, 0x2c, 0x28, 0xed,
+	0xbe, 0xc7, 0xd8, 0x22, 0xcf, 0x38, 0x3b, 0x27, 0xd8, 0x82, 0xc2, 0xd8, 0x6d, 0x28, 0x9b, 0x7d,
+	0x4f, 0xb7, 0x6c, 0x93, 0x4c, 0xeb, 0xf9, 0x54, 0x9f, 0x70, 0xde, 0xb2, 0xdd, 0x76, 0xdf, 0x53,
+	0x99, 0xa4, 0xa8, 0x0c, 0x25, 0x33, 0x98, 0xb2, 0x76, 0x83, 0xa1, 0x78, 0x83, 0x53, 0x32, 0x36,
+	0x82, 0x7c, 0xbe, 0xb7, 0x12, 0xa6, 0xc7, 0x45, 0x05, 0x4e, 0xd9, 0x0c, 0xe7, 0xa8, 0x07, 0x55,
+	0x06, 0x14, 0xdd, 0x60, 0xec, 0x83, 0x94, 0x81, 0x7d, 0xb2, 0x12, 0x2c, 0xba, 0x70, 0x83, 0x92,
+	0xb5, 0x6e, 0x26, 0x69, 0xa1, 0x8f, 0xbf, 0xf4, 0x1d, 0x6a, 0xd4, 0x4b, 0x17, 0xf0, 0xf1, 0x39,
+	0x93, 0x8c, 0x7d, 0xe4, 0xd3, 0xed, 0x67, 0xb0, 0x9e, 0x72, 0x7f, 0xc1, 0xd9, 0x79, 0x3f, 0x79,
+	0x76, 0xe2, 0xcc, 0x69, 0x37, 0xb9, 0x56, 0xa2, 0x12, 0x6e, 0x1f, 0x40, 0x35, 0x1d, 0x84, 0x05,
+	0x68, 0x77, 0xd3, 0x68, 0xd1, 0x25, 0xd4, 0x14, 0x6a, 0x49, 0xb8, 0x23, 0x40, 0xe7, 0xc3, 0xb0,
+	0x00, 0xf2, 0xa3, 0x34, 0xe4, 0xd5, 0x08, 0x32, 0x56, 0x4d, 0xc2, 0xaa, 0xdc, 0xe5, 0x38, 0x1a,
+	0x0b, 0x10, 0xe5, 0x34, 0xe2, 0x5a, 0x80, 0xc8, 0x75, 0x92, 0xa5, 0xff, 0x6f, 0x12, 0x14, 0x83,
+	0x38, 0x20, 0x0c, 0xc8, 0xa0, 0xd4, 0xb5, 0xfa, 0x3e, 0x25, 0xe2, 0x5d, 0x69, 0x36, 0x21, 0xc1,
+	0xe7, 0xc0, 0xfb, 0xe9, 0x98, 0xed, 0x36, 0x42, 0xc1, 0x86, 0x6d, 0x6a, 0xb3, 0x09, 0x11, 0xbb,
+	0x52, 0x33, 0xe6, 0xc8, 0xdb, 0x3f, 0x87, 0x6b, 0x0b, 0x45, 0x17, 0x98, 0xfc, 0x20, 0x69, 0x72,
+	0x35, 0x6a, 0x39, 0xf9, 0x7a, 0x11, 0x06, 0x03, 0x48, 0xda, 0xff, 0x31, 0x94, 0xc2, 0xc0, 0xa3,
+	0xdb, 0x50, 0xf9, 0x85, 0xe7, 0xd8, 0x61, 0xba, 0x0b, 0x68, 0x60, 0x24, 0x21, 0x20, 0xff, 0x5e,
+	0x82, 0xb5, 0x64, 0x4c, 0x51, 0x0b, 0x20, 0x91, 0xd2, 0xc2, 0xd3, 0xf7, 0x16, 0x04, 0x7f, 0x77,
+	0x3e, 0x93, 0x13, 0x6a, 0xac, 0xf9, 0x7a, 0xf3, 0x0e, 0xa7, 0xae, 0xef, 0x72, 0xd2, 0x83, 0x7f,
+	0x49, 0xb0, 0xb5, 0xa8, 0xf1, 0xbb, 0xe4, 0x75, 0xb3, 0x0b, 0xc0, 0xa5, 0x45, 0x7d, 0xcc, 0xa6,
+	0xea, 0x23, 0x83, 0x17, 0xf5, 0xd1, 0x0f, 0x46, 0xbc, 0x3e, 0x72, 0xf9, 0xa0, 0x3e, 0xe6, 0x52,
+	0xf5, 0x91, 0x29, 0x04, 0xf5, 0xd1, 0x0f, 0x87, 0xbc, 0x3e, 0x72, 0x95, 0xb0, 0x3e, 0xe6, 0x53,
+	0xf5, 0x91, 0xe9, 0x84, 0xf5, 0xd1, 0x8f, 0xc6, 0x9e, 0x7c, 0x00, 0xa5, 0x70, 0xfd, 0xe5, 0x2e,
+	0x5d, 0xbc, 0x4c, 0x6a, 0x50, 0x8e, 0xac, 0x43, 0xb7, 0x21, 0xc7, 0x00, 0x82, 0x0e, 0xb5, 0x92,
+	0x74, 0x97, 0x33, 0xc2, 0xfa, 0x98, 0x79, 0x43, 0x7d, 0x94, 0xef, 0x02, 0xc4, 0xf6, 0x2f, 0x35,
+	0x53, 0xfe, 0xa3, 0x04, 0xa5, 0xf0, 0x15, 0x2b, 0x69, 0xb3, 0xb4, 0xd2, 0x66, 0xf4, 0x35, 0x54,
+	0x0d, 0xbe, 0xa6, 0x3e, 0x10, 0x8b, 0xae, 0x34, 0x68, 0xdd, 0x48, 0x4e, 0xd1, 0x5d, 0x28, 0x88,
+	0x97, 0xb8, 0x7a, 0x36, 0xf5, 0xc2, 0x20, 0xfa, 0x44, 0x1c, 0x30, 0xe5, 0x26, 0x14, 0x04, 0x05,
+	0xed, 0x40, 0x39, 0x7e, 0xa1, 0x12, 0x95, 0xbc, 0xd4, 0x0f, 0x1f, 0xa5, 0x76, 0xa0, 0xec, 0xdb,
+	0xd6, 0x54, 0x67, 0xad, 0x21, 0xb7, 0x22, 0x8b, 0x4b, 0x8c, 0xa0, 0x59, 0x63, 0x22, 0x7f, 0x0b,
+	0xc5, 0xb0, 0x6e, 0xae, 0x04, 0xb9, 0x06, 0x05, 0x3a, 0xe5, 0x1c, 0xd1, 0x0f, 0xe4, 0xe9, 0xb4,
+	0xe3, 0x8f, 0xe5, 0x3f, 0x65, 0x61, 0x3d, 0xe5, 0x0a, 0x6a, 0x02, 0xf0, 0x22, 0xce, 0xc2, 0x37,
+	0x7f, 0xbe, 0x52, 0x92, 0xbb, 0x2c, 0x3d, 0xd8, 0x0e, 0x04, 0xe7, 0xab, 0xec, 0x86, 0x73, 0x84,
+	0xa1, 0xc6, 0x31, 0x78, 0xa2, 0x06, 0x48, 0x99, 0x54, 0x25, 0x3b, 0x8f, 0xc4, 0xb3, 0x23, 0x01,
+	0x57, 0x75, 0x53, 0x44, 0xa4, 0xc1, 0x35, 0xde, 0xf8, 0x4f, 0x9c, 0x91, 0x35, 0x98, 0xe9, 0x43,
+	0x27, 0x38, 0x07, 0x3c, 0xc4, 0xd5, 0x87, 0xef, 0x2e, 0x04, 0x16, 0x06, 0x08, 0x15, 0x8c, 0x98,
+	0xfe, 0x21, 0x1f, 0x3f, 0x71, 0x44, 0x36, 0x6e, 0x7f, 0x03, 0xd5, 0xb4, 0x1b, 0x6f, 0xba, 0x07,
+	0x4a, 0xc9, 0x4b, 0xbd, 0x01, 0x57, 0x17, 0x98, 0x7e, 0x19, 0x08, 0xf9, 0x0e, 0xac, 0x25, 0x8d,
+	0x44, 0x45, 0xc8, 0x36, 0x3a, 0xdf, 0xd5, 0xae, 0xf0, 0xc1, 0xfe, 0x7e, 0x4d, 0x92, 0xf7, 0x01,
+	0x78, 0x09, 0x38, 0xf2, 0x8c, 0x13, 0xfe, 0x09, 0xc2, 0xbf, 0x06, 0xc4, 0xfe, 0xf2, 0x31, 0xba,
+	0x0f, 0x9b, 0xd4, 0xa1, 0xc6, 0x48, 0xe7, 0xb0, 0x7a, 0x7f, 0x46, 0x49, 0xd8, 0xf6, 0x6d, 0x70,
+	0xc6, 0x31, 0xa3, 0x37, 0x19, 0x59, 0x26, 0x50, 0x7d, 0x76, 0xfc, 0xc2, 0xa2, 0xa7, 0xd1, 0x99,
+	0xb8, 0x68, 0xdf, 0xfa, 0x31, 0x94, 0xa2, 0x07, 0xe4, 0x6c, 0xaa, 0x8c, 0x86, 0x50, 0x38, 0x12,
+	0x90, 0x8f, 0x61, 0x93, 0x2f, 0x9a, 0x5a, 0x29, 0xc2, 0x95, 0x96, 0xe1, 0x66, 0xde, 0x84, 0xfb,
+	0x2d, 0x14, 0xda, 0xd6, 0x09, 0xf1, 0x28, 0xcb, 0xf6, 0xf8, 0x39, 0x53, 0x00, 0x96, 0xdc, 0xf0,
+	0xfd, 0xf2, 0x3a, 0xfb, 0x4b, 0x62, 0x9d, 0x9c, 0xd2, 0x20, 0x0c, 0xc1, 0x4c, 0xfe, 0x19, 0x54,
+	0xd3, 0x2f, 0x97, 0xec, 0x3a, 0x1a, 0x8e, 0x8c, 0x13, 0x8e, 0x50, 0x8d, 0xae, 0xa3, 0x27, 0x23,
+	0xe3, 0x04, 0x73, 0x06, 0x0b, 0xae, 0x4b, 0x0c, 0x56, 0xa3, 0xac, 0xa1, 0x6e, 0xd9, 0xfc, 0xa1,
+	0x33, 0xb8, 0xc5, 0x37, 0x04, 0x43, 0x1d, 0xaa, 0x82, 0x2c, 0xab, 0x50, 0xd4, 0xa6, 0x87, 0xae,
+	0xe3, 0x0c, 0x2f, 0xf5, 0x9f, 0x06, 0x41, 0x6e, 0x62, 0xd0, 0xd3, 0xe0, 0x09, 0x98, 0x8f, 0xe5,
+	0x17, 0x00, 0x5c, 0x54, 0xa0, 0xbd, 0x0b, 0x6b, 0xd1, 0xd1, 0x8e, 0x1f, 0xd9, 0x2b, 0xe1, 0xe9,
+	0xee, 0xf3, 0x6b, 0x33, 0x06, 0x59, 0xbc, 0x9c, 0x00, 0xc6, 0x50, 0xd6, 0xa6, 0x98, 0x0c, 0x88,
+	0x35, 0xa1, 0x97, 0xb2, 0xf2, 0x26, 0x94, 0x58, 0x09, 0xe3, 0xdd, 0x6d, 0xf0, 0x4d, 0x41, 0xa7,
+	0xbc, 0xd2, 0xcb, 0x7f, 0x91, 0x60, 0xf3, 0xdc, 0x5f, 0x04, 0xbe, 0x43, 0xc6, 0x90, 0xea, 0x94,
+	0xb8, 0xd1, 0x7d, 0xc4, 0x08, 0x1a, 0x71, 0xc7, 0xac, 0x97, 0xe6, 0xcc, 0x24, 0x1e, 0x17, 0xe7,
+	0x88, 0x6c, 0xb1, 0xfe, 0x90, 0xea, 0x67, 0x16, 0x79, 0xcd, 0x93, 0x2d, 0x87, 0x8b, 0xfd, 0x21,
+	0x3d, 0xb6, 0xc8, 0x6b, 0xf4, 0x15, 0x54, 0x19, 0x2b, 0xf1, 0x22, 0x23, 0x0a, 0x61, 0xd8, 0x81,
+	0x35, 0x9f, 0x68, 0xd1, 0xc3, 0x0a, 0x5e, 0xef, 0x0f, 0x69, 0x34, 0xf3, 0x64, 0x05, 0xd6, 0x92,
+	0xec, 0xe4, 0xa7, 0xb9, 0x34, 0xff, 0x69, 0xbe, 0xe2, 0x7d, 0x64, 0x04, 0x35, 0x1e, 0xa1, 0x06,
+	0xa5, 0xc4, 0xa3, 0xe2, 0x2b, 0xf8, 0x02, 0x5b, 0xb4, 0xea, 0x21, 0x20, 0x5e, 0x2d, 0x3b, 0xbf,
+	0xda, 0x1f, 0x24, 0xd8, 0x7c, 0xee, 0x3b, 0xae, 0x3f, 0x6e, 0x11, 0x97, 0x5a, 0x43, 0x6b, 0x60,
+	0x50, 0x72, 0x91, 0xf5, 0x6e, 0x43, 0xe5, 0xfc, 0xef, 0x16, 0x38, 0x8d, 0x7f, 0xb1, 0x7c, 0x0d,
+	0x6b, 0x46, 0xec, 0x42, 0xd8, 0x82, 0xdc, 0x48, 0x26, 0x41, 0xc2, 0x45, 0x9c, 0x12, 0x96, 0xbf,
+	0x83, 0xad, 0x86, 0x7f, 0x32, 0x26, 0x76, 0xf4, 0xeb, 0x45, 0xe4, 0xc9, 0x65, 0x72, 0x4a, 0x54,
+	0x25, 0xcb, 0x14, 0xe5, 0xa1, 0xcc, 0xaa, 0x92, 0x6a, 0x7a, 0xf7, 0xff, 0x99, 0x81, 0x1c, 0x3b,
+	0x82, 0xa8, 0x0c, 0xf9, 0xe3, 0xc6, 0xbe, 0xda, 0xae, 0x5d, 0x41, 0x1f, 0x80, 0xac, 0x76, 0xf8,
+	0x44, 0x3f, 0x38, 0x6e, 0xb5, 0xf4, 0x56, 0xb7, 0xf3, 0x64, 0x5f, 0x6d, 0x69, 0xfa, 0x0b, 0x55,
+	0xdb, 0x53, 0x3b, 0x7a, 0x73, 0xbf, 0xdb, 0x7a, 0x56, 0x93, 0xd0, 0x2e, 0xdc, 0x5f, 0x2e, 0xa7,
+	0xb7, 0xba, 0x07, 0x07, 0xaa, 0xa6, 0x29, 0x6d, 0xbd, 0xa7, 0x35, 0x34, 0xa5, 0x96, 0x41, 0xef,
+	0xc1, 0xed, 0x50, 0xbe, 0xdd, 0xd0, 0x1a, 0xcd, 0x46, 0x4f, 0xd1, 0xdb, 0x5d, 0xa5, 0xa7, 0x77,
+	0xba, 0x9a, 0xae, 0xbc, 0x54, 0x7b, 0x5a, 0x2d, 0x8b, 0x6e, 0xc2, 0xb5, 0x50, 0xa8, 0xd3, 0xd5,
+	0x0f, 0x15, 0x7c, 0xa0, 0xf6, 0x7a, 0x6a, 0xb7, 0x53, 0xcb, 0xa1, 0x77, 0xe0, 0x66, 0xc8, 0x52,
+	0x3b, 0xad, 0x2e, 0xc6, 0x4a, 0x4b, 0xd3, 0x95, 0x8e, 0x86, 0x55, 0xa5, 0x57, 0xcb, 0xa3, 0x3a,
+	0x6c, 0x85, 0xec, 0xa3, 0x4e, 0xe3, 0x48, 0xdb, 0xeb, 0x62, 0xb5, 0xa7, 0xb4, 0x6b, 0x85, 0xa4,
+	0x22, 0x47, 0xeb, 0x3c, 0xd5, 0x7b, 0xea, 0xd3, 0x4e, 0x43, 0x3b, 0xc2, 0x4a, 0xad, 0x88, 0x6e,
+	0x41, 0x3d, 0x64, 0xf7, 0x5a, 0x7b, 0xca, 0x41, 0x43, 0x3f, 0x56, 0xbb, 0xfb, 0x0d, 0x8d, 0xad,
+	0x5a, 0x42, 0xb7, 0x61, 0x27, 0xe4, 0x1e, 0xe2, 0x6e, 0x4b, 0x69, 0x1f, 0x61, 0x45, 0x57, 0x5e,
+	0x2a, 0xad, 0x23, 0x2e, 0x50, 0x46, 0xdb, 0x70, 0x3d, 0x14, 0x78, 0x7e, 0xd4, 0xd5, 0x1a, 0xba,
+	0xf2, 0xb2, 0xa5, 0x28, 0x6d, 0xa5, 0x5d, 0x83, 0xfb, 0x5f, 0x02, 0x3a, 0xdf, 0xa1, 0x23, 0x80,
+	0x42, 0xe7, 0xe8, 0xa0, 0xa9, 0xe0, 0xda, 0x15, 0x36, 0xee, 0x69, 0x58, 0xed, 0x3c, 0xad, 0x49,
+	0xa8, 0x02, 0xc5, 0x66, 0xb7, 0xbb, 0xaf, 0x34, 0x3a, 0xb5, 0x4c, 0xf3, 0xf3, 0x9f, 0x3c, 0x3c,
+	0xb1, 0xe8, 0xa9, 0xdf, 0xdf, 0x1d, 0x38, 0xe3, 0x07, 0xa7, 0xb3, 0x09, 0x71, 0x47, 0xc4, 0x3c,
+	0x21, 0xee, 0xa7, 0x23, 0xa3, 0xef, 0x3d, 0x70, 0x5c, 0xcb, 0xb1, 0x3f, 0xf5, 0x88, 0x7b, 0x46,
+	0xdc, 0x07, 0x93, 0x57, 0x27, 0x0f, 0xf8, 0xd6, 0xf7, 0x0b, 0xfc, 0x2f, 0xf6, 0xa3, 0xff, 0x0e,
+	0x00, 0x91, 0x8b, 0x15, 0xf3, 0x00, 0x1f, 0x00, 0x00,
 }
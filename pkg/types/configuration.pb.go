@@ -42,15 +42,16 @@ func (x Privilege_Access) String() string {
 }
 
 func (Privilege_Access) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{9, 0}
+	return fileDescriptor_415c9e57263f32ab, []int{11, 0}
 }
 
 // ClusterConfig holds the shared configuration of a blockchain database cluster.
 // This includes:
 // - a set of nodes that server client requests,
 // - a set of admins,
-// - the certificate authority configuration, including root and intermediate certificates, and
-// - the consensus configuration.
+// - the certificate authority configuration, including root and intermediate certificates,
+// - the consensus configuration, and
+// - the governance policy.
 //
 // This part of the configuration is replicated and is common to all nodes.
 // After the initial bootstrap, this part of the configuration can change only through configuration transactions.
@@ -64,10 +65,12 @@ type ClusterConfig struct {
 	// transactions and blocks.
 	CertAuthConfig *CAConfig `protobuf:"bytes,3,opt,name=cert_auth_config,json=certAuthConfig,proto3" json:"cert_auth_config,omitempty"`
 	// The consensus configuration.
-	ConsensusConfig      *ConsensusConfig `protobuf:"bytes,4,opt,name=consensus_config,json=consensusConfig,proto3" json:"consensus_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	ConsensusConfig *ConsensusConfig `protobuf:"bytes,4,opt,name=consensus_config,json=consensusConfig,proto3" json:"consensus_config,omitempty"`
+	// The number of admins that must approve administrative transactions.
+	GovernancePolicy     *GovernancePolicy `protobuf:"bytes,5,opt,name=governance_policy,json=governancePolicy,proto3" json:"governance_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ClusterConfig) Reset()         { *m = ClusterConfig{} }
@@ -123,6 +126,13 @@ func (m *ClusterConfig) GetConsensusConfig() *ConsensusConfig {
 	return nil
 }
 
+func (m *ClusterConfig) GetGovernancePolicy() *GovernancePolicy {
+	if m != nil {
+		return m.GovernancePolicy
+	}
+	return nil
+}
+
 // NodeConfig holds the information about a database node in the cluster.
 // This information is exposed to the clients.
 // The address and port (see below) define the HTTP/REST endpoint that clients connect to,
@@ -246,6 +256,65 @@ func (m *Admin) GetCertificate() []byte {
 	return nil
 }
 
+// GovernancePolicy holds the minimal number of cluster administrators that must sign each kind of administrative
+// transaction, e.g., 3 out of the 5 admins for configuration transactions. The submitter of a transaction counts as
+// one of the signers and the other admins sign through the signatures map of the transaction envelope. A value of zero
+// or one means that the signature of the submitter is sufficient. A value cannot exceed the number of admins.
+type GovernancePolicy struct {
+	ConfigTxMinAdmins    uint32   `protobuf:"varint,1,opt,name=config_tx_min_admins,json=configTxMinAdmins,proto3" json:"config_tx_min_admins,omitempty"`
+	UserAdminTxMinAdmins uint32   `protobuf:"varint,2,opt,name=user_admin_tx_min_admins,json=userAdminTxMinAdmins,proto3" json:"user_admin_tx_min_admins,omitempty"`
+	DbAdminTxMinAdmins   uint32   `protobuf:"varint,3,opt,name=db_admin_tx_min_admins,json=dbAdminTxMinAdmins,proto3" json:"db_admin_tx_min_admins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GovernancePolicy) Reset()         { *m = GovernancePolicy{} }
+func (m *GovernancePolicy) String() string { return proto.CompactTextString(m) }
+func (*GovernancePolicy) ProtoMessage()    {}
+func (*GovernancePolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{3}
+}
+
+func (m *GovernancePolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GovernancePolicy.Unmarshal(m, b)
+}
+func (m *GovernancePolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GovernancePolicy.Marshal(b, m, deterministic)
+}
+func (m *GovernancePolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GovernancePolicy.Merge(m, src)
+}
+func (m *GovernancePolicy) XXX_Size() int {
+	return xxx_messageInfo_GovernancePolicy.Size(m)
+}
+func (m *GovernancePolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_GovernancePolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GovernancePolicy proto.InternalMessageInfo
+
+func (m *GovernancePolicy) GetConfigTxMinAdmins() uint32 {
+	if m != nil {
+		return m.ConfigTxMinAdmins
+	}
+	return 0
+}
+
+func (m *GovernancePolicy) GetUserAdminTxMinAdmins() uint32 {
+	if m != nil {
+		return m.UserAdminTxMinAdmins
+	}
+	return 0
+}
+
+func (m *GovernancePolicy) GetDbAdminTxMinAdmins() uint32 {
+	if m != nil {
+		return m.DbAdminTxMinAdmins
+	}
+	return 0
+}
+
 type CAConfig struct {
 	Roots                [][]byte `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
 	Intermediates        [][]byte `protobuf:"bytes,2,rep,name=intermediates,proto3" json:"intermediates,omitempty"`
@@ -258,7 +327,7 @@ func (m *CAConfig) Reset()         { *m = CAConfig{} }
 func (m *CAConfig) String() string { return proto.CompactTextString(m) }
 func (*CAConfig) ProtoMessage()    {}
 func (*CAConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{3}
+	return fileDescriptor_415c9e57263f32ab, []int{4}
 }
 
 func (m *CAConfig) XXX_Unmarshal(b []byte) error {
@@ -295,24 +364,26 @@ func (m *CAConfig) GetIntermediates() [][]byte {
 
 // The definitions of the clustered consensus algorithm, members, and parameters.
 type ConsensusConfig struct {
-	// The consensus algorithm, currently only "raft" is supported.
+	// The consensus algorithm: "raft" or "bft".
 	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
 	// Peers that take part in consensus.
 	Members []*PeerConfig `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
 	// Peers that are allowed to connect and fetch the ledger from members, but do not take part in consensus.
 	Observers []*PeerConfig `protobuf:"bytes,3,rep,name=observers,proto3" json:"observers,omitempty"`
-	// Raft protocol parameters.
-	RaftConfig           *RaftConfig `protobuf:"bytes,4,opt,name=raft_config,json=raftConfig,proto3" json:"raft_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	// Raft protocol parameters. The BFT algorithm uses the compression of the catch-up responses from these as well.
+	RaftConfig *RaftConfig `protobuf:"bytes,4,opt,name=raft_config,json=raftConfig,proto3" json:"raft_config,omitempty"`
+	// BFT protocol parameters, used when the algorithm is "bft".
+	BftConfig            *BFTConfig `protobuf:"bytes,5,opt,name=bft_config,json=bftConfig,proto3" json:"bft_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *ConsensusConfig) Reset()         { *m = ConsensusConfig{} }
 func (m *ConsensusConfig) String() string { return proto.CompactTextString(m) }
 func (*ConsensusConfig) ProtoMessage()    {}
 func (*ConsensusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{4}
+	return fileDescriptor_415c9e57263f32ab, []int{5}
 }
 
 func (m *ConsensusConfig) XXX_Unmarshal(b []byte) error {
@@ -361,11 +432,19 @@ func (m *ConsensusConfig) GetRaftConfig() *RaftConfig {
 	return nil
 }
 
+func (m *ConsensusConfig) GetBftConfig() *BFTConfig {
+	if m != nil {
+		return m.BftConfig
+	}
+	return nil
+}
+
 // PeerConfig defines a server that takes part in consensus, or an observer.
 type PeerConfig struct {
 	// The node ID correlates the peer definition here with the NodeConfig.ID field.
 	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	// Raft ID must be >0 for members, or =0 for observers.
+	// Raft ID must be >0 and unique across members and observers. An observer with Raft ID 0, as required before
+	// observers replicated the ledger as Raft learners, does not replicate until it is assigned a Raft ID.
 	RaftId uint64 `protobuf:"varint,2,opt,name=raft_id,json=raftId,proto3" json:"raft_id,omitempty"`
 	// The host name or IP address that is used by other peers to connect to this peer.
 	PeerHost string `protobuf:"bytes,3,opt,name=peer_host,json=peerHost,proto3" json:"peer_host,omitempty"`
@@ -380,7 +459,7 @@ func (m *PeerConfig) Reset()         { *m = PeerConfig{} }
 func (m *PeerConfig) String() string { return proto.CompactTextString(m) }
 func (*PeerConfig) ProtoMessage()    {}
 func (*PeerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{5}
+	return fileDescriptor_415c9e57263f32ab, []int{6}
 }
 
 func (m *PeerConfig) XXX_Unmarshal(b []byte) error {
@@ -454,7 +533,17 @@ type RaftConfig struct {
 	// requirement, we require that the Raft ID of a new peer added to the cluster must be higher than 'max_raft_id'.
 	// We recommend to start a cluster with low ID numbers, e.g. (1,2,3) => 'max_raft_id'=3,
 	// and then set the Raft ID of a new peer added to the cluster to 'max_raft_id'+1.
-	MaxRaftId            uint64   `protobuf:"varint,6,opt,name=max_raft_id,json=maxRaftId,proto3" json:"max_raft_id,omitempty"`
+	MaxRaftId uint64 `protobuf:"varint,6,opt,name=max_raft_id,json=maxRaftId,proto3" json:"max_raft_id,omitempty"`
+	// The compression of the blocks in Raft entries and in catch-up responses: "none", "gzip" or "zstd".
+	// Empty means "none". Raft entries are compressed only if 'entry_format_version' is at least 1, whereas the
+	// catch-up responses are compressed at the request of the client only.
+	Compression string `protobuf:"bytes,7,opt,name=compression,proto3" json:"compression,omitempty"`
+	// The format of the data in the Raft entries, which all the servers of the cluster must be able to read:
+	// 0 - the entries carry marshaled blocks, which any server version reads.
+	// 1 - the entries may carry compressed blocks, according to 'compression'.
+	// Servers that predate this field ignore it and fail on compressed entries. Therefore, all the servers, members
+	// and observers alike, must be upgraded before the version is raised to 1.
+	EntryFormatVersion   uint32   `protobuf:"varint,8,opt,name=entry_format_version,json=entryFormatVersion,proto3" json:"entry_format_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -464,7 +553,7 @@ func (m *RaftConfig) Reset()         { *m = RaftConfig{} }
 func (m *RaftConfig) String() string { return proto.CompactTextString(m) }
 func (*RaftConfig) ProtoMessage()    {}
 func (*RaftConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{6}
+	return fileDescriptor_415c9e57263f32ab, []int{7}
 }
 
 func (m *RaftConfig) XXX_Unmarshal(b []byte) error {
@@ -527,6 +616,62 @@ func (m *RaftConfig) GetMaxRaftId() uint64 {
 	return 0
 }
 
+func (m *RaftConfig) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *RaftConfig) GetEntryFormatVersion() uint32 {
+	if m != nil {
+		return m.EntryFormatVersion
+	}
+	return 0
+}
+
+type BFTConfig struct {
+	// The time a member waits for the primary to make progress before it asks to change the view, e.g. 2s.
+	// Any duration string parsable by ParseDuration(). Empty means the default of 2s.
+	// The primary sends heartbeats to the other members every quarter of this time when it has nothing to propose.
+	ViewChangeTimeout    string   `protobuf:"bytes,1,opt,name=view_change_timeout,json=viewChangeTimeout,proto3" json:"view_change_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BFTConfig) Reset()         { *m = BFTConfig{} }
+func (m *BFTConfig) String() string { return proto.CompactTextString(m) }
+func (*BFTConfig) ProtoMessage()    {}
+func (*BFTConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{8}
+}
+
+func (m *BFTConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BFTConfig.Unmarshal(m, b)
+}
+func (m *BFTConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BFTConfig.Marshal(b, m, deterministic)
+}
+func (m *BFTConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BFTConfig.Merge(m, src)
+}
+func (m *BFTConfig) XXX_Size() int {
+	return xxx_messageInfo_BFTConfig.Size(m)
+}
+func (m *BFTConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_BFTConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BFTConfig proto.InternalMessageInfo
+
+func (m *BFTConfig) GetViewChangeTimeout() string {
+	if m != nil {
+		return m.ViewChangeTimeout
+	}
+	return ""
+}
+
 // Database configuration. Stores default read/write ACLs
 // Stored as value in _dbs system database under key 'name'
 type DatabaseConfig struct {
@@ -542,7 +687,7 @@ func (m *DatabaseConfig) Reset()         { *m = DatabaseConfig{} }
 func (m *DatabaseConfig) String() string { return proto.CompactTextString(m) }
 func (*DatabaseConfig) ProtoMessage()    {}
 func (*DatabaseConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{7}
+	return fileDescriptor_415c9e57263f32ab, []int{9}
 }
 
 func (m *DatabaseConfig) XXX_Unmarshal(b []byte) error {
@@ -599,7 +744,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{8}
+	return fileDescriptor_415c9e57263f32ab, []int{10}
 }
 
 func (m *User) XXX_Unmarshal(b []byte) error {
@@ -654,7 +799,10 @@ type Privilege struct {
 	// from any database provided that the state has no ACL defined. If
 	// a state has a read and write ACL, the admin can read or write to
 	// the state only if the admin is listed in the read or write ACL list.
-	Admin                bool     `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	Admin bool `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	// quota limits the data owned by the user across all databases. A user
+	// owns the keys it has written last.
+	Quota                *Quota   `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -664,7 +812,7 @@ func (m *Privilege) Reset()         { *m = Privilege{} }
 func (m *Privilege) String() string { return proto.CompactTextString(m) }
 func (*Privilege) ProtoMessage()    {}
 func (*Privilege) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{9}
+	return fileDescriptor_415c9e57263f32ab, []int{11}
 }
 
 func (m *Privilege) XXX_Unmarshal(b []byte) error {
@@ -699,74 +847,157 @@ func (m *Privilege) GetAdmin() bool {
 	return false
 }
 
+func (m *Privilege) GetQuota() *Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+// Quota limits the amount of data stored in a database or owned by a user.
+// A zero value denotes that the respective resource is not limited.
+type Quota struct {
+	MaxKeys              uint64   `protobuf:"varint,1,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
+	MaxTotalValueBytes   uint64   `protobuf:"varint,2,opt,name=max_total_value_bytes,json=maxTotalValueBytes,proto3" json:"max_total_value_bytes,omitempty"`
+	MaxValueSize         uint64   `protobuf:"varint,3,opt,name=max_value_size,json=maxValueSize,proto3" json:"max_value_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Quota) Reset()         { *m = Quota{} }
+func (m *Quota) String() string { return proto.CompactTextString(m) }
+func (*Quota) ProtoMessage()    {}
+func (*Quota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{12}
+}
+
+func (m *Quota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quota.Unmarshal(m, b)
+}
+func (m *Quota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quota.Marshal(b, m, deterministic)
+}
+func (m *Quota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quota.Merge(m, src)
+}
+func (m *Quota) XXX_Size() int {
+	return xxx_messageInfo_Quota.Size(m)
+}
+func (m *Quota) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quota proto.InternalMessageInfo
+
+func (m *Quota) GetMaxKeys() uint64 {
+	if m != nil {
+		return m.MaxKeys
+	}
+	return 0
+}
+
+func (m *Quota) GetMaxTotalValueBytes() uint64 {
+	if m != nil {
+		return m.MaxTotalValueBytes
+	}
+	return 0
+}
+
+func (m *Quota) GetMaxValueSize() uint64 {
+	if m != nil {
+		return m.MaxValueSize
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("types.Privilege_Access", Privilege_Access_name, Privilege_Access_value)
 	proto.RegisterType((*ClusterConfig)(nil), "types.ClusterConfig")
 	proto.RegisterType((*NodeConfig)(nil), "types.NodeConfig")
 	proto.RegisterType((*Admin)(nil), "types.Admin")
+	proto.RegisterType((*GovernancePolicy)(nil), "types.GovernancePolicy")
 	proto.RegisterType((*CAConfig)(nil), "types.CAConfig")
 	proto.RegisterType((*ConsensusConfig)(nil), "types.ConsensusConfig")
 	proto.RegisterType((*PeerConfig)(nil), "types.PeerConfig")
 	proto.RegisterType((*RaftConfig)(nil), "types.RaftConfig")
+	proto.RegisterType((*BFTConfig)(nil), "types.BFTConfig")
 	proto.RegisterType((*DatabaseConfig)(nil), "types.DatabaseConfig")
 	proto.RegisterType((*User)(nil), "types.User")
 	proto.RegisterType((*Privilege)(nil), "types.Privilege")
 	proto.RegisterMapType((map[string]Privilege_Access)(nil), "types.Privilege.DbPermissionEntry")
+	proto.RegisterType((*Quota)(nil), "types.Quota")
 }
 
 func init() { proto.RegisterFile("configuration.proto", fileDescriptor_415c9e57263f32ab) }
 
 var fileDescriptor_415c9e57263f32ab = []byte{
-	// 802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x4d, 0x6f, 0xe4, 0x34,
-	0x18, 0x26, 0xf3, 0xd5, 0xe6, 0x9d, 0xcf, 0x7a, 0x57, 0xbb, 0x23, 0x40, 0xa8, 0x84, 0x45, 0x5b,
-	0x01, 0x9d, 0x91, 0x86, 0x3d, 0xb0, 0xdc, 0x66, 0xbb, 0x7c, 0xf4, 0x82, 0x2a, 0x03, 0x02, 0x71,
-	0x89, 0x9c, 0xe4, 0x9d, 0x89, 0xd5, 0x24, 0x8e, 0x6c, 0xa7, 0xb4, 0x7b, 0xe0, 0xca, 0xef, 0xe2,
-	0x7f, 0x70, 0xe7, 0x6f, 0x20, 0xdb, 0xf1, 0x4c, 0xdb, 0x11, 0x07, 0x6e, 0xf6, 0xf3, 0x3c, 0xaf,
-	0xfd, 0xf8, 0x79, 0xed, 0x04, 0x9e, 0xa4, 0xa2, 0xda, 0xf0, 0x6d, 0x23, 0x99, 0xe6, 0xa2, 0x5a,
-	0xd4, 0x52, 0x68, 0x41, 0xfa, 0xfa, 0xae, 0x46, 0x15, 0xfd, 0x1d, 0xc0, 0xf8, 0xa2, 0x68, 0x94,
-	0x46, 0x79, 0x61, 0x55, 0xe4, 0x25, 0xf4, 0x2b, 0x91, 0xa1, 0x9a, 0x07, 0xa7, 0xdd, 0xb3, 0xe1,
-	0xea, 0x64, 0x61, 0x85, 0x8b, 0x1f, 0x44, 0x86, 0x4e, 0x41, 0x1d, 0x4f, 0x5e, 0xc0, 0x80, 0x65,
-	0x25, 0xaf, 0xd4, 0xbc, 0x63, 0x95, 0xa3, 0x56, 0xb9, 0x36, 0x20, 0x6d, 0x39, 0xf2, 0x1a, 0x66,
-	0x29, 0x4a, 0x1d, 0xb3, 0x46, 0xe7, 0xb1, 0x33, 0x32, 0xef, 0x9e, 0x06, 0x67, 0xc3, 0xd5, 0xb4,
-	0xd5, 0x5f, 0xac, 0xdb, 0x75, 0x27, 0x46, 0xb8, 0x6e, 0x74, 0xde, 0x3a, 0x59, 0xc3, 0x2c, 0x15,
-	0x95, 0xc2, 0x4a, 0x35, 0xca, 0x97, 0xf6, 0x6c, 0xe9, 0x33, 0x5f, 0xea, 0xe9, 0x76, 0x85, 0x69,
-	0xfa, 0x10, 0x88, 0x0a, 0x80, 0xbd, 0x71, 0x32, 0x81, 0x0e, 0xcf, 0xe6, 0xc1, 0x69, 0x70, 0x16,
-	0xd2, 0x0e, 0xcf, 0xc8, 0x1c, 0x8e, 0x58, 0x96, 0x49, 0x54, 0xe6, 0x08, 0x06, 0xf4, 0x53, 0x42,
-	0xa0, 0x57, 0x0b, 0xa9, 0xad, 0xd3, 0x31, 0xb5, 0x63, 0x72, 0x0a, 0x43, 0x63, 0x90, 0x6f, 0x78,
-	0xca, 0x34, 0x5a, 0x27, 0x23, 0x7a, 0x1f, 0x8a, 0x5e, 0x43, 0xdf, 0x1e, 0xfe, 0x60, 0xa3, 0x47,
-	0xa5, 0x9d, 0xc3, 0xd2, 0x6f, 0xe1, 0xd8, 0xe7, 0x40, 0x9e, 0x42, 0x5f, 0x0a, 0xa1, 0x5d, 0x07,
-	0x46, 0xd4, 0x4d, 0xc8, 0x0b, 0x18, 0xf3, 0x4a, 0xa3, 0x2c, 0x31, 0xe3, 0x4c, 0xa3, 0x4b, 0x7d,
-	0x44, 0x1f, 0x82, 0xd1, 0x5f, 0x01, 0x4c, 0x1f, 0xa5, 0x42, 0x3e, 0x84, 0x90, 0x15, 0x5b, 0x21,
-	0xb9, 0xce, 0xcb, 0xd6, 0xd4, 0x1e, 0x20, 0x9f, 0xc3, 0x51, 0x89, 0x65, 0x82, 0xd2, 0xf7, 0xd1,
-	0x77, 0xfc, 0x0a, 0xfd, 0x9d, 0xa0, 0x5e, 0x41, 0x96, 0x10, 0x8a, 0x44, 0xa1, 0xbc, 0x31, 0xf2,
-	0xee, 0x7f, 0xc9, 0xf7, 0x1a, 0xb2, 0x82, 0xa1, 0x64, 0x1b, 0xfd, 0xb0, 0x7d, 0xbe, 0x84, 0xb2,
-	0x8d, 0x6e, 0x4b, 0x40, 0xee, 0xc6, 0xd1, 0x2d, 0xc0, 0x7e, 0x31, 0xf2, 0x1c, 0x8e, 0xcc, 0x7d,
-	0x8b, 0x77, 0x81, 0x0e, 0xcc, 0xf4, 0x32, 0x33, 0x84, 0x5d, 0x9a, 0x67, 0x36, 0xd0, 0x1e, 0x1d,
-	0x98, 0xe9, 0x65, 0x46, 0x3e, 0x80, 0xb0, 0x46, 0x94, 0x71, 0x2e, 0x94, 0xeb, 0x60, 0x48, 0x8f,
-	0x0d, 0xf0, 0xbd, 0x50, 0x7a, 0x47, 0xda, 0xf6, 0xf6, 0x6c, 0x7b, 0x2d, 0x79, 0x25, 0xa4, 0x8e,
-	0xfe, 0xec, 0x00, 0xec, 0x4d, 0x91, 0x4f, 0x60, 0xac, 0x79, 0x7a, 0x1d, 0xdb, 0x88, 0x6f, 0x58,
-	0xd1, 0x1a, 0x18, 0x19, 0xf0, 0xb2, 0xc5, 0xc8, 0xa7, 0x30, 0xc1, 0x02, 0x53, 0xf3, 0xb4, 0x62,
-	0x43, 0xb8, 0xbb, 0x34, 0xa6, 0x63, 0x8f, 0xfe, 0x64, 0x40, 0xf2, 0x12, 0xa6, 0x39, 0x32, 0xa9,
-	0x13, 0x64, 0xba, 0xd5, 0xb9, 0xcb, 0x35, 0xd9, 0xc1, 0x4e, 0xb8, 0x80, 0x27, 0x25, 0xbb, 0x8d,
-	0x79, 0xb5, 0x29, 0xf8, 0x36, 0xd7, 0x71, 0x52, 0x08, 0x23, 0x76, 0x56, 0x4f, 0x4a, 0x76, 0x7b,
-	0xd9, 0x32, 0x6f, 0x2c, 0x41, 0x5e, 0xc1, 0x33, 0x55, 0xb1, 0x5a, 0xe5, 0x42, 0xef, 0x8c, 0xc6,
-	0x8a, 0xbf, 0xc3, 0x79, 0xdf, 0xa6, 0xf2, 0xd4, 0xb3, 0xde, 0xf1, 0x8f, 0xfc, 0x1d, 0x92, 0x8f,
-	0x60, 0x68, 0x76, 0xf1, 0x01, 0x0e, 0xac, 0x34, 0x2c, 0xd9, 0x2d, 0xb5, 0x19, 0x46, 0x7f, 0xc0,
-	0xe4, 0x2d, 0xd3, 0x2c, 0x61, 0xca, 0x3f, 0x1e, 0x02, 0xbd, 0x8a, 0x95, 0xd8, 0x66, 0x60, 0xc7,
-	0xe4, 0x33, 0x38, 0x91, 0xc8, 0xb2, 0x98, 0xa5, 0x29, 0x2a, 0x15, 0x37, 0xca, 0xdf, 0xa2, 0x90,
-	0x4e, 0x0d, 0xb1, 0xb6, 0xf8, 0xcf, 0x06, 0x26, 0x5f, 0x00, 0xf9, 0x5d, 0x72, 0x8d, 0x0f, 0xc5,
-	0x5d, 0x2b, 0x9e, 0x59, 0xe6, 0x9e, 0x3a, 0xca, 0xa1, 0x67, 0x06, 0xff, 0xff, 0x25, 0x91, 0x05,
-	0x84, 0xb5, 0xe4, 0x37, 0xbc, 0xc0, 0x2d, 0xb6, 0x5f, 0x9a, 0x99, 0xbf, 0xa2, 0x1e, 0xa7, 0x7b,
-	0x49, 0xf4, 0x4f, 0x00, 0xe1, 0x8e, 0x20, 0xdf, 0xc1, 0x38, 0x4b, 0xe2, 0x1a, 0x65, 0xc9, 0x95,
-	0xe2, 0xa2, 0x6a, 0xbf, 0x82, 0xd1, 0xe3, 0x15, 0x16, 0x6f, 0x93, 0xab, 0x9d, 0xe8, 0x9b, 0x4a,
-	0xcb, 0x3b, 0x3a, 0xca, 0xee, 0x41, 0xe6, 0x11, 0xdb, 0x2f, 0xa0, 0xb5, 0x78, 0x4c, 0xdd, 0xe4,
-	0xfd, 0x5f, 0xe1, 0xe4, 0xa0, 0x90, 0xcc, 0xa0, 0x7b, 0x8d, 0x77, 0xed, 0x21, 0xcd, 0x90, 0x9c,
-	0x43, 0xff, 0x86, 0x15, 0x8d, 0x3b, 0xdf, 0x64, 0xf5, 0xfc, 0x60, 0x77, 0x17, 0x15, 0x75, 0xaa,
-	0xaf, 0x3b, 0x5f, 0x05, 0xd1, 0xc7, 0x30, 0x70, 0x20, 0x39, 0x86, 0x1e, 0x45, 0x96, 0xcd, 0xde,
-	0x23, 0x63, 0x08, 0xcd, 0xe8, 0x17, 0x13, 0xee, 0x2c, 0x78, 0xf3, 0xea, 0xb7, 0xd5, 0x96, 0xeb,
-	0xbc, 0x49, 0x16, 0xa9, 0x28, 0x97, 0xf9, 0x5d, 0x8d, 0xb2, 0xc0, 0x6c, 0x8b, 0xf2, 0xbc, 0x60,
-	0x89, 0x5a, 0x0a, 0xc9, 0x45, 0x75, 0xee, 0x1e, 0xee, 0xb2, 0xbe, 0xde, 0x2e, 0xed, 0xa6, 0xc9,
-	0xc0, 0xfe, 0x2f, 0xbe, 0xfc, 0x37, 0x00, 0x00, 0xff, 0xff, 0x43, 0x90, 0xf6, 0xbd, 0x46, 0x06,
-	0x00, 0x00,
+	// 1068 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdb, 0x6e, 0x23, 0x35,
+	0x18, 0x26, 0xc7, 0x26, 0x7f, 0x93, 0x34, 0xf1, 0x96, 0x36, 0x1c, 0x84, 0xca, 0x50, 0xb4, 0x15,
+	0xd0, 0x04, 0xc2, 0x0a, 0xb1, 0x70, 0x95, 0xb6, 0x74, 0xa9, 0x10, 0xa8, 0x98, 0xb2, 0x20, 0x6e,
+	0x2c, 0xcf, 0xcc, 0x9f, 0xc4, 0xea, 0xcc, 0x78, 0xf0, 0x38, 0xd9, 0x64, 0x91, 0x78, 0x01, 0xde,
+	0x82, 0x17, 0xe0, 0x81, 0x78, 0x0b, 0x9e, 0x00, 0xd9, 0x9e, 0x49, 0xda, 0x14, 0x2e, 0xf6, 0xce,
+	0xfe, 0x0e, 0x9e, 0xdf, 0xff, 0xc1, 0x09, 0x3c, 0x0a, 0x64, 0x32, 0x11, 0xd3, 0xb9, 0xe2, 0x5a,
+	0xc8, 0x64, 0x90, 0x2a, 0xa9, 0x25, 0xa9, 0xe9, 0x55, 0x8a, 0x99, 0xf7, 0x67, 0x19, 0xda, 0xe7,
+	0xd1, 0x3c, 0xd3, 0xa8, 0xce, 0xad, 0x8a, 0x3c, 0x86, 0x5a, 0x22, 0x43, 0xcc, 0xfa, 0xa5, 0xa3,
+	0xca, 0xc9, 0xee, 0xa8, 0x37, 0xb0, 0xc2, 0xc1, 0x77, 0x32, 0x44, 0xa7, 0xa0, 0x8e, 0x27, 0xc7,
+	0x50, 0xe7, 0x61, 0x2c, 0x92, 0xac, 0x5f, 0xb6, 0xca, 0x56, 0xae, 0x1c, 0x1b, 0x90, 0xe6, 0x1c,
+	0x79, 0x0a, 0xdd, 0x00, 0x95, 0x66, 0x7c, 0xae, 0x67, 0xcc, 0x05, 0xd2, 0xaf, 0x1c, 0x95, 0x4e,
+	0x76, 0x47, 0x7b, 0xb9, 0xfe, 0x7c, 0x9c, 0x9f, 0xdb, 0x31, 0xc2, 0xf1, 0x5c, 0xcf, 0xf2, 0x48,
+	0xc6, 0xd0, 0x0d, 0x64, 0x92, 0x61, 0x92, 0xcd, 0xb3, 0xc2, 0x5a, 0xb5, 0xd6, 0x83, 0xc2, 0x5a,
+	0xd0, 0xf9, 0x09, 0x7b, 0xc1, 0x7d, 0x80, 0x5c, 0x40, 0x6f, 0x2a, 0x17, 0xa8, 0x12, 0x9e, 0x04,
+	0xc8, 0x52, 0x19, 0x89, 0x60, 0xd5, 0xaf, 0xd9, 0x33, 0x0e, 0xf3, 0x33, 0x9e, 0xad, 0xf9, 0x6b,
+	0x4b, 0xd3, 0xee, 0x74, 0x0b, 0xf1, 0x22, 0x80, 0xcd, 0xf5, 0x49, 0x07, 0xca, 0x22, 0xec, 0x97,
+	0x8e, 0x4a, 0x27, 0x4d, 0x5a, 0x16, 0x21, 0xe9, 0xc3, 0x0e, 0x0f, 0x43, 0x85, 0x99, 0x49, 0x84,
+	0x01, 0x8b, 0x2d, 0x21, 0x50, 0x4d, 0xa5, 0xd2, 0xf6, 0xbe, 0x6d, 0x6a, 0xd7, 0xe4, 0x08, 0x76,
+	0xcd, 0x35, 0xc5, 0x44, 0x04, 0x5c, 0xa3, 0xbd, 0x4f, 0x8b, 0xde, 0x85, 0xbc, 0xa7, 0x50, 0xb3,
+	0x29, 0x7c, 0xf0, 0xa1, 0x2d, 0x6b, 0xf9, 0xa1, 0xf5, 0xaf, 0x12, 0x74, 0xb7, 0xef, 0x43, 0x86,
+	0xb0, 0xef, 0x92, 0xc7, 0xf4, 0x92, 0xc5, 0x22, 0x61, 0x79, 0xd5, 0x4a, 0x36, 0xaa, 0x9e, 0xe3,
+	0x6e, 0x96, 0xdf, 0x8a, 0x64, 0xec, 0x4a, 0xf6, 0x19, 0xf4, 0xe7, 0x19, 0x2a, 0xa7, 0xdb, 0x32,
+	0x95, 0xad, 0x69, 0xdf, 0xf0, 0x56, 0x7d, 0xd7, 0x37, 0x82, 0x83, 0xd0, 0xff, 0x4f, 0x97, 0x4b,
+	0x00, 0x09, 0xfd, 0x6d, 0x8f, 0x77, 0x09, 0x8d, 0xa2, 0xfe, 0x64, 0x1f, 0x6a, 0x4a, 0x4a, 0xed,
+	0x3a, 0xaf, 0x45, 0xdd, 0x86, 0x1c, 0x43, 0x5b, 0x24, 0x1a, 0x55, 0x8c, 0xa1, 0xe0, 0x1a, 0x5d,
+	0xb7, 0xb5, 0xe8, 0x7d, 0xd0, 0xfb, 0xa7, 0x04, 0x7b, 0x5b, 0xdd, 0x40, 0xde, 0x86, 0x26, 0x8f,
+	0xa6, 0x52, 0x09, 0x3d, 0x8b, 0xf3, 0x34, 0x6e, 0x00, 0xf2, 0x21, 0xec, 0xc4, 0x18, 0xfb, 0xa8,
+	0x8a, 0xfe, 0x2d, 0x3a, 0xfd, 0x1a, 0x8b, 0x59, 0xa0, 0x85, 0x82, 0x0c, 0xa1, 0x29, 0xfd, 0x0c,
+	0xd5, 0xc2, 0xc8, 0x2b, 0xff, 0x27, 0xdf, 0x68, 0xc8, 0x08, 0x76, 0x15, 0x9f, 0xe8, 0xfb, 0x6d,
+	0x5b, 0x58, 0x28, 0x9f, 0xe8, 0xdc, 0x02, 0x6a, 0xbd, 0x26, 0x43, 0x00, 0x7f, 0x63, 0x71, 0x5d,
+	0xda, 0xcd, 0x2d, 0x67, 0x97, 0x37, 0xc5, 0x47, 0xfc, 0xc2, 0xe0, 0x2d, 0x01, 0x36, 0x5f, 0x27,
+	0x87, 0xb0, 0x63, 0x06, 0x93, 0xad, 0x7b, 0xa6, 0x6e, 0xb6, 0x57, 0xa1, 0x21, 0x6c, 0x2c, 0x22,
+	0xb4, 0xe5, 0xab, 0xd2, 0xba, 0xd9, 0x5e, 0x85, 0xe4, 0x2d, 0x68, 0xa6, 0x88, 0x8a, 0xcd, 0x64,
+	0xe6, 0x9a, 0xb4, 0x49, 0x1b, 0x06, 0xf8, 0x5a, 0x66, 0x7a, 0x4d, 0xda, 0x0e, 0xae, 0xda, 0x02,
+	0x5a, 0xf2, 0x5a, 0x2a, 0xed, 0xfd, 0x5d, 0x06, 0xd8, 0xdc, 0x82, 0xbc, 0x07, 0x6d, 0x2d, 0x82,
+	0x5b, 0x66, 0x6b, 0xb2, 0xe0, 0x51, 0x1e, 0x40, 0xcb, 0x80, 0x57, 0x39, 0x46, 0xde, 0x87, 0x0e,
+	0x46, 0x18, 0x98, 0x37, 0x88, 0x19, 0xa2, 0x68, 0xa6, 0x76, 0x81, 0xde, 0x18, 0x90, 0x3c, 0x86,
+	0xbd, 0x19, 0x72, 0xa5, 0x7d, 0xe4, 0x3a, 0xd7, 0xb9, 0xf6, 0xe9, 0xac, 0x61, 0x27, 0x1c, 0xc0,
+	0xa3, 0x98, 0x2f, 0x99, 0x48, 0x26, 0x91, 0x98, 0xce, 0x34, 0xf3, 0x23, 0x69, 0xc4, 0x2e, 0xd4,
+	0x5e, 0xcc, 0x97, 0x57, 0x39, 0x73, 0x66, 0x09, 0xf2, 0x04, 0x0e, 0xb2, 0x84, 0xa7, 0xd9, 0x4c,
+	0xea, 0x75, 0xa0, 0x2c, 0x13, 0x2f, 0xd1, 0xa6, 0xba, 0x4a, 0xf7, 0x0b, 0xb6, 0x88, 0xf8, 0x07,
+	0xf1, 0x12, 0xc9, 0x3b, 0xb0, 0x6b, 0xbe, 0x52, 0x24, 0xb0, 0x6e, 0xa5, 0xcd, 0x98, 0x2f, 0xa9,
+	0xcb, 0xa1, 0x19, 0x4a, 0x19, 0xa7, 0x66, 0xde, 0x85, 0x4c, 0xfa, 0x3b, 0xf6, 0xe2, 0x77, 0x21,
+	0xf2, 0x31, 0xec, 0x63, 0xa2, 0xd5, 0x8a, 0x4d, 0xa4, 0x8a, 0xb9, 0x66, 0xa6, 0x3f, 0x8c, 0xb4,
+	0xe1, 0x86, 0xc2, 0x72, 0x97, 0x96, 0x7a, 0xee, 0x18, 0xef, 0x4b, 0x68, 0xae, 0xeb, 0x6d, 0xae,
+	0xb9, 0x10, 0xf8, 0x82, 0x05, 0x33, 0x9e, 0x4c, 0x91, 0x69, 0x11, 0xa3, 0x9c, 0xeb, 0x3c, 0xc3,
+	0x3d, 0x43, 0x9d, 0x5b, 0xe6, 0xc6, 0x11, 0xde, 0xef, 0xd0, 0xb9, 0xe0, 0x9a, 0xfb, 0x3c, 0x2b,
+	0x1e, 0x2c, 0x02, 0xd5, 0x84, 0xc7, 0x98, 0x5b, 0xec, 0x9a, 0x7c, 0x00, 0x3d, 0x85, 0x3c, 0x64,
+	0x3c, 0x08, 0x30, 0xcb, 0x98, 0x99, 0x67, 0x37, 0x07, 0x4d, 0xba, 0x67, 0x88, 0xb1, 0xc5, 0x7f,
+	0x34, 0x30, 0xf9, 0x08, 0xc8, 0x0b, 0x25, 0x34, 0xde, 0x17, 0x57, 0xac, 0xb8, 0x6b, 0x99, 0x3b,
+	0x6a, 0x6f, 0x06, 0x55, 0xb3, 0x78, 0xf5, 0xd7, 0x8b, 0x0c, 0xa0, 0x99, 0x2a, 0xb1, 0x10, 0x11,
+	0x4e, 0xb1, 0x5f, 0xb9, 0xd7, 0xfe, 0xd7, 0x05, 0x4e, 0x37, 0x12, 0xef, 0x8f, 0x32, 0x34, 0xd7,
+	0x04, 0x79, 0x06, 0xed, 0xd0, 0x67, 0x29, 0xaa, 0x58, 0xb8, 0x52, 0xb8, 0xdf, 0x2f, 0x6f, 0xfb,
+	0x84, 0xc1, 0x85, 0x7f, 0xbd, 0x16, 0x7d, 0x65, 0x92, 0x4f, 0x5b, 0xe1, 0x1d, 0xc8, 0x3c, 0x43,
+	0xf6, 0xd9, 0xb2, 0x21, 0x36, 0xa8, 0xdb, 0x10, 0x0f, 0x6a, 0xbf, 0xce, 0xa5, 0xe6, 0x79, 0x60,
+	0xc5, 0x8f, 0xdd, 0xf7, 0x06, 0xa3, 0x8e, 0x7a, 0xf3, 0x67, 0xe8, 0x3d, 0x38, 0x9c, 0x74, 0xa1,
+	0x72, 0x8b, 0xab, 0x3c, 0x11, 0x66, 0x49, 0x4e, 0xa1, 0xb6, 0xe0, 0xd1, 0xdc, 0xe5, 0xa0, 0x33,
+	0x3a, 0x7c, 0x10, 0xa1, 0x4b, 0x27, 0x75, 0xaa, 0x2f, 0xca, 0x9f, 0x97, 0xbc, 0x77, 0xa1, 0xee,
+	0x40, 0xd2, 0x80, 0x2a, 0x45, 0x1e, 0x76, 0x5f, 0x23, 0x6d, 0x68, 0x9a, 0xd5, 0x4f, 0xa6, 0x00,
+	0xdd, 0x92, 0xf7, 0x1b, 0xd4, 0x6c, 0x30, 0xe4, 0x0d, 0x68, 0x98, 0x8e, 0xbd, 0xc5, 0x95, 0x7b,
+	0xe3, 0xab, 0x74, 0x27, 0xe6, 0xcb, 0x6f, 0x70, 0x95, 0x91, 0x4f, 0xe0, 0x75, 0x43, 0x69, 0xa9,
+	0x79, 0xc4, 0xec, 0xe9, 0xcc, 0x5f, 0xb9, 0x37, 0xd5, 0xe8, 0x48, 0xcc, 0x97, 0x37, 0x86, 0x7b,
+	0x6e, 0xa8, 0x33, 0xc3, 0x90, 0x63, 0xe8, 0x18, 0x8b, 0x13, 0xdb, 0x69, 0xa9, 0x58, 0x6d, 0x2b,
+	0xe6, 0x4b, 0x2b, 0x33, 0x53, 0x72, 0xf6, 0xe4, 0x97, 0xd1, 0x54, 0xe8, 0xd9, 0xdc, 0x1f, 0x04,
+	0x32, 0x1e, 0xce, 0x56, 0x29, 0xaa, 0x08, 0xc3, 0x29, 0xaa, 0xd3, 0x88, 0xfb, 0xd9, 0x50, 0x2a,
+	0x21, 0x93, 0x53, 0xf7, 0x36, 0x0e, 0xd3, 0xdb, 0xe9, 0xd0, 0xde, 0xd8, 0xaf, 0xdb, 0xbf, 0x22,
+	0x9f, 0xfe, 0x3b, 0x00, 0x85, 0xd4, 0x98, 0x3b, 0xa1, 0x08, 0x00, 0x00,
 }
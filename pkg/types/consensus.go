@@ -0,0 +1,17 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+// RaftObservers returns the observers that replicate the ledger as Raft learners, that is, the observers with a Raft
+// ID. Before observers replicated the ledger, their Raft ID had to be 0. Such observers are kept in the config, but
+// take no part in the replication until a config transaction assigns them a Raft ID, which is larger than the
+// MaxRaftId, and adds them to the nodes of the cluster.
+func (m *ConsensusConfig) RaftObservers() []*PeerConfig {
+	var observers []*PeerConfig
+	for _, o := range m.GetObservers() {
+		if o.GetRaftId() != 0 {
+			observers = append(observers, o)
+		}
+	}
+	return observers
+}
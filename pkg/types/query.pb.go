@@ -42,7 +42,7 @@ func (x GetMostRecentUserOrNodeQuery_Type) String() string {
 }
 
 func (GetMostRecentUserOrNodeQuery_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{43, 0}
+	return fileDescriptor_5c6ac9b241082464, []int{54, 0}
 }
 
 type GetDBStatusQueryEnvelope struct {
@@ -281,9 +281,12 @@ func (m *GetDataQueryEnvelope) GetSignature() []byte {
 }
 
 type GetDataQuery struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key    string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// When set, the query is served only after the node had committed all the blocks the leader committed when the
+	// query arrived, as determined by a Raft ReadIndex round.
+	Linearizable         bool     `protobuf:"varint,4,opt,name=linearizable,proto3" json:"linearizable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -335,12 +338,22 @@ func (m *GetDataQuery) GetKey() string {
 	return ""
 }
 
+func (m *GetDataQuery) GetLinearizable() bool {
+	if m != nil {
+		return m.Linearizable
+	}
+	return false
+}
+
 type GetDataRangeQuery struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	StartKey             string   `protobuf:"bytes,3,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
-	EndKey               string   `protobuf:"bytes,4,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
-	Limit                uint64   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName   string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	StartKey string `protobuf:"bytes,3,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey   string `protobuf:"bytes,4,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	Limit    uint64 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// When set, the query is served only after the node had committed all the blocks the leader committed when the
+	// query arrived, as determined by a Raft ReadIndex round.
+	Linearizable         bool     `protobuf:"varint,6,opt,name=linearizable,proto3" json:"linearizable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -406,6 +419,13 @@ func (m *GetDataRangeQuery) GetLimit() uint64 {
 	return 0
 }
 
+func (m *GetDataRangeQuery) GetLinearizable() bool {
+	if m != nil {
+		return m.Linearizable
+	}
+	return false
+}
+
 type GetUserQueryEnvelope struct {
 	Payload              *GetUserQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature            []byte        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -868,6 +888,305 @@ func (m *GetClusterStatusQuery) GetNoCertificates() bool {
 	return false
 }
 
+type TransferLeadershipRequestEnvelope struct {
+	Payload              *TransferLeadershipRequest `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *TransferLeadershipRequestEnvelope) Reset()         { *m = TransferLeadershipRequestEnvelope{} }
+func (m *TransferLeadershipRequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipRequestEnvelope) ProtoMessage()    {}
+func (*TransferLeadershipRequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{17}
+}
+
+func (m *TransferLeadershipRequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipRequestEnvelope.Unmarshal(m, b)
+}
+func (m *TransferLeadershipRequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipRequestEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipRequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipRequestEnvelope.Merge(m, src)
+}
+func (m *TransferLeadershipRequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipRequestEnvelope.Size(m)
+}
+func (m *TransferLeadershipRequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipRequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipRequestEnvelope proto.InternalMessageInfo
+
+func (m *TransferLeadershipRequestEnvelope) GetPayload() *TransferLeadershipRequest {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *TransferLeadershipRequestEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TransferLeadershipRequest asks the current leader to hand over the leadership of the
+// cluster to the given consensus member.
+type TransferLeadershipRequest struct {
+	UserId       string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetNodeId string `protobuf:"bytes,2,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	// Unix time, in nanoseconds, at which the request was created. A request
+	// which is stale, or is not newer than the last request of the user served
+	// by the node, is rejected, so that a signed request cannot be replayed.
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferLeadershipRequest) Reset()         { *m = TransferLeadershipRequest{} }
+func (m *TransferLeadershipRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipRequest) ProtoMessage()    {}
+func (*TransferLeadershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{18}
+}
+
+func (m *TransferLeadershipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipRequest.Unmarshal(m, b)
+}
+func (m *TransferLeadershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipRequest.Merge(m, src)
+}
+func (m *TransferLeadershipRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipRequest.Size(m)
+}
+func (m *TransferLeadershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipRequest proto.InternalMessageInfo
+
+func (m *TransferLeadershipRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *TransferLeadershipRequest) GetTargetNodeId() string {
+	if m != nil {
+		return m.TargetNodeId
+	}
+	return ""
+}
+
+func (m *TransferLeadershipRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type SetNetworkFaultsRequestEnvelope struct {
+	Payload              *SetNetworkFaultsRequest `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SetNetworkFaultsRequestEnvelope) Reset()         { *m = SetNetworkFaultsRequestEnvelope{} }
+func (m *SetNetworkFaultsRequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SetNetworkFaultsRequestEnvelope) ProtoMessage()    {}
+func (*SetNetworkFaultsRequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{19}
+}
+
+func (m *SetNetworkFaultsRequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNetworkFaultsRequestEnvelope.Unmarshal(m, b)
+}
+func (m *SetNetworkFaultsRequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNetworkFaultsRequestEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SetNetworkFaultsRequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNetworkFaultsRequestEnvelope.Merge(m, src)
+}
+func (m *SetNetworkFaultsRequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SetNetworkFaultsRequestEnvelope.Size(m)
+}
+func (m *SetNetworkFaultsRequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNetworkFaultsRequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNetworkFaultsRequestEnvelope proto.InternalMessageInfo
+
+func (m *SetNetworkFaultsRequestEnvelope) GetPayload() *SetNetworkFaultsRequest {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SetNetworkFaultsRequestEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// SetNetworkFaultsRequest replaces the network faults injected by a server
+// into the messages it exchanges with the other servers. An empty list of
+// faults heals the network. The seed makes the random drops and duplicates
+// reproducible.
+type SetNetworkFaultsRequest struct {
+	UserId               string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Faults               []*NetworkFault `protobuf:"bytes,2,rep,name=faults,proto3" json:"faults,omitempty"`
+	Seed                 int64           `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetNetworkFaultsRequest) Reset()         { *m = SetNetworkFaultsRequest{} }
+func (m *SetNetworkFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetNetworkFaultsRequest) ProtoMessage()    {}
+func (*SetNetworkFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{20}
+}
+
+func (m *SetNetworkFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNetworkFaultsRequest.Unmarshal(m, b)
+}
+func (m *SetNetworkFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNetworkFaultsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetNetworkFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNetworkFaultsRequest.Merge(m, src)
+}
+func (m *SetNetworkFaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetNetworkFaultsRequest.Size(m)
+}
+func (m *SetNetworkFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNetworkFaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNetworkFaultsRequest proto.InternalMessageInfo
+
+func (m *SetNetworkFaultsRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SetNetworkFaultsRequest) GetFaults() []*NetworkFault {
+	if m != nil {
+		return m.Faults
+	}
+	return nil
+}
+
+func (m *SetNetworkFaultsRequest) GetSeed() int64 {
+	if m != nil {
+		return m.Seed
+	}
+	return 0
+}
+
+// NetworkFault is a fault injected into the messages a node sends to another
+// node over the intra-cluster transport, in order to test the cluster under
+// network failures. An empty node ID matches any node.
+type NetworkFault struct {
+	FromNodeId string `protobuf:"bytes,1,opt,name=from_node_id,json=fromNodeId,proto3" json:"from_node_id,omitempty"`
+	ToNodeId   string `protobuf:"bytes,2,opt,name=to_node_id,json=toNodeId,proto3" json:"to_node_id,omitempty"`
+	// partition drops all the messages.
+	Partition bool `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	// drop_rate is the probability, in [0,1], that a message is dropped.
+	DropRate float64 `protobuf:"fixed64,4,opt,name=drop_rate,json=dropRate,proto3" json:"drop_rate,omitempty"`
+	// duplicate_rate is the probability, in [0,1], that a message is sent twice.
+	DuplicateRate float64 `protobuf:"fixed64,5,opt,name=duplicate_rate,json=duplicateRate,proto3" json:"duplicate_rate,omitempty"`
+	// delay_ms delays every message by the given number of milliseconds.
+	DelayMs              uint64   `protobuf:"varint,6,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkFault) Reset()         { *m = NetworkFault{} }
+func (m *NetworkFault) String() string { return proto.CompactTextString(m) }
+func (*NetworkFault) ProtoMessage()    {}
+func (*NetworkFault) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{21}
+}
+
+func (m *NetworkFault) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkFault.Unmarshal(m, b)
+}
+func (m *NetworkFault) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkFault.Marshal(b, m, deterministic)
+}
+func (m *NetworkFault) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkFault.Merge(m, src)
+}
+func (m *NetworkFault) XXX_Size() int {
+	return xxx_messageInfo_NetworkFault.Size(m)
+}
+func (m *NetworkFault) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkFault.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkFault proto.InternalMessageInfo
+
+func (m *NetworkFault) GetFromNodeId() string {
+	if m != nil {
+		return m.FromNodeId
+	}
+	return ""
+}
+
+func (m *NetworkFault) GetToNodeId() string {
+	if m != nil {
+		return m.ToNodeId
+	}
+	return ""
+}
+
+func (m *NetworkFault) GetPartition() bool {
+	if m != nil {
+		return m.Partition
+	}
+	return false
+}
+
+func (m *NetworkFault) GetDropRate() float64 {
+	if m != nil {
+		return m.DropRate
+	}
+	return 0
+}
+
+func (m *NetworkFault) GetDuplicateRate() float64 {
+	if m != nil {
+		return m.DuplicateRate
+	}
+	return 0
+}
+
+func (m *NetworkFault) GetDelayMs() uint64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
 type GetBlockQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
@@ -881,7 +1200,7 @@ func (m *GetBlockQuery) Reset()         { *m = GetBlockQuery{} }
 func (m *GetBlockQuery) String() string { return proto.CompactTextString(m) }
 func (*GetBlockQuery) ProtoMessage()    {}
 func (*GetBlockQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{17}
+	return fileDescriptor_5c6ac9b241082464, []int{22}
 }
 
 func (m *GetBlockQuery) XXX_Unmarshal(b []byte) error {
@@ -935,7 +1254,7 @@ func (m *GetBlockQueryEnvelope) Reset()         { *m = GetBlockQueryEnvelope{} }
 func (m *GetBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetBlockQueryEnvelope) ProtoMessage()    {}
 func (*GetBlockQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{18}
+	return fileDescriptor_5c6ac9b241082464, []int{23}
 }
 
 func (m *GetBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -981,7 +1300,7 @@ func (m *GetLastBlockQuery) Reset()         { *m = GetLastBlockQuery{} }
 func (m *GetLastBlockQuery) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockQuery) ProtoMessage()    {}
 func (*GetLastBlockQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{19}
+	return fileDescriptor_5c6ac9b241082464, []int{24}
 }
 
 func (m *GetLastBlockQuery) XXX_Unmarshal(b []byte) error {
@@ -1021,7 +1340,7 @@ func (m *GetLastBlockQueryEnvelope) Reset()         { *m = GetLastBlockQueryEnve
 func (m *GetLastBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockQueryEnvelope) ProtoMessage()    {}
 func (*GetLastBlockQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{20}
+	return fileDescriptor_5c6ac9b241082464, []int{25}
 }
 
 func (m *GetLastBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1069,7 +1388,7 @@ func (m *GetLedgerPathQuery) Reset()         { *m = GetLedgerPathQuery{} }
 func (m *GetLedgerPathQuery) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathQuery) ProtoMessage()    {}
 func (*GetLedgerPathQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{21}
+	return fileDescriptor_5c6ac9b241082464, []int{26}
 }
 
 func (m *GetLedgerPathQuery) XXX_Unmarshal(b []byte) error {
@@ -1123,7 +1442,7 @@ func (m *GetLedgerPathQueryEnvelope) Reset()         { *m = GetLedgerPathQueryEn
 func (m *GetLedgerPathQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathQueryEnvelope) ProtoMessage()    {}
 func (*GetLedgerPathQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{22}
+	return fileDescriptor_5c6ac9b241082464, []int{27}
 }
 
 func (m *GetLedgerPathQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1158,6 +1477,112 @@ func (m *GetLedgerPathQueryEnvelope) GetSignature() []byte {
 	return nil
 }
 
+// GetLedgerConsistencyProofQuery is a query for the proof that the ledger at the end block extends the ledger at the
+// start block, i.e., that the end block links, through the skip list, to the start block.
+type GetLedgerConsistencyProofQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartBlockNumber     uint64   `protobuf:"varint,2,opt,name=start_block_number,json=startBlockNumber,proto3" json:"start_block_number,omitempty"`
+	EndBlockNumber       uint64   `protobuf:"varint,3,opt,name=end_block_number,json=endBlockNumber,proto3" json:"end_block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLedgerConsistencyProofQuery) Reset()         { *m = GetLedgerConsistencyProofQuery{} }
+func (m *GetLedgerConsistencyProofQuery) String() string { return proto.CompactTextString(m) }
+func (*GetLedgerConsistencyProofQuery) ProtoMessage()    {}
+func (*GetLedgerConsistencyProofQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{28}
+}
+
+func (m *GetLedgerConsistencyProofQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerConsistencyProofQuery.Unmarshal(m, b)
+}
+func (m *GetLedgerConsistencyProofQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerConsistencyProofQuery.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerConsistencyProofQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerConsistencyProofQuery.Merge(m, src)
+}
+func (m *GetLedgerConsistencyProofQuery) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerConsistencyProofQuery.Size(m)
+}
+func (m *GetLedgerConsistencyProofQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerConsistencyProofQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerConsistencyProofQuery proto.InternalMessageInfo
+
+func (m *GetLedgerConsistencyProofQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetLedgerConsistencyProofQuery) GetStartBlockNumber() uint64 {
+	if m != nil {
+		return m.StartBlockNumber
+	}
+	return 0
+}
+
+func (m *GetLedgerConsistencyProofQuery) GetEndBlockNumber() uint64 {
+	if m != nil {
+		return m.EndBlockNumber
+	}
+	return 0
+}
+
+type GetLedgerConsistencyProofQueryEnvelope struct {
+	Payload              *GetLedgerConsistencyProofQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *GetLedgerConsistencyProofQueryEnvelope) Reset() {
+	*m = GetLedgerConsistencyProofQueryEnvelope{}
+}
+func (m *GetLedgerConsistencyProofQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetLedgerConsistencyProofQueryEnvelope) ProtoMessage()    {}
+func (*GetLedgerConsistencyProofQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{29}
+}
+
+func (m *GetLedgerConsistencyProofQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetLedgerConsistencyProofQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerConsistencyProofQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope.Merge(m, src)
+}
+func (m *GetLedgerConsistencyProofQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope.Size(m)
+}
+func (m *GetLedgerConsistencyProofQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerConsistencyProofQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetLedgerConsistencyProofQueryEnvelope) GetPayload() *GetLedgerConsistencyProofQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetLedgerConsistencyProofQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type GetTxProofQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
@@ -1171,7 +1596,7 @@ func (m *GetTxProofQuery) Reset()         { *m = GetTxProofQuery{} }
 func (m *GetTxProofQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofQuery) ProtoMessage()    {}
 func (*GetTxProofQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{23}
+	return fileDescriptor_5c6ac9b241082464, []int{30}
 }
 
 func (m *GetTxProofQuery) XXX_Unmarshal(b []byte) error {
@@ -1225,7 +1650,7 @@ func (m *GetTxProofQueryEnvelope) Reset()         { *m = GetTxProofQueryEnvelope
 func (m *GetTxProofQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofQueryEnvelope) ProtoMessage()    {}
 func (*GetTxProofQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{24}
+	return fileDescriptor_5c6ac9b241082464, []int{31}
 }
 
 func (m *GetTxProofQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1260,12 +1685,118 @@ func (m *GetTxProofQueryEnvelope) GetSignature() []byte {
 	return nil
 }
 
-type GetDataProofQuery struct {
+// GetTxProofsQuery is a query for the proof of the inclusion of several transactions of a block, given by their index
+// in the block.
+type GetTxProofsQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
-	DbName               string   `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	Key                  string   `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
-	IsDeleted            bool     `protobuf:"varint,5,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	TxIndexes            []uint64 `protobuf:"varint,3,rep,packed,name=tx_indexes,json=txIndexes,proto3" json:"tx_indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxProofsQuery) Reset()         { *m = GetTxProofsQuery{} }
+func (m *GetTxProofsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofsQuery) ProtoMessage()    {}
+func (*GetTxProofsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{32}
+}
+
+func (m *GetTxProofsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofsQuery.Unmarshal(m, b)
+}
+func (m *GetTxProofsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofsQuery.Merge(m, src)
+}
+func (m *GetTxProofsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofsQuery.Size(m)
+}
+func (m *GetTxProofsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofsQuery proto.InternalMessageInfo
+
+func (m *GetTxProofsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetTxProofsQuery) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *GetTxProofsQuery) GetTxIndexes() []uint64 {
+	if m != nil {
+		return m.TxIndexes
+	}
+	return nil
+}
+
+type GetTxProofsQueryEnvelope struct {
+	Payload              *GetTxProofsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte            `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetTxProofsQueryEnvelope) Reset()         { *m = GetTxProofsQueryEnvelope{} }
+func (m *GetTxProofsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofsQueryEnvelope) ProtoMessage()    {}
+func (*GetTxProofsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{33}
+}
+
+func (m *GetTxProofsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofsQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxProofsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofsQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofsQueryEnvelope.Merge(m, src)
+}
+func (m *GetTxProofsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofsQueryEnvelope.Size(m)
+}
+func (m *GetTxProofsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofsQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofsQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetTxProofsQueryEnvelope) GetPayload() *GetTxProofsQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetTxProofsQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataProofQuery struct {
+	UserId      string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	DbName      string `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key         string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	IsDeleted   bool   `protobuf:"varint,5,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	// When set, the response holds the proof that the key was never written in the state trie of the block.
+	IsAbsent             bool     `protobuf:"varint,6,opt,name=is_absent,json=isAbsent,proto3" json:"is_absent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1275,7 +1806,7 @@ func (m *GetDataProofQuery) Reset()         { *m = GetDataProofQuery{} }
 func (m *GetDataProofQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofQuery) ProtoMessage()    {}
 func (*GetDataProofQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{25}
+	return fileDescriptor_5c6ac9b241082464, []int{34}
 }
 
 func (m *GetDataProofQuery) XXX_Unmarshal(b []byte) error {
@@ -1331,6 +1862,13 @@ func (m *GetDataProofQuery) GetIsDeleted() bool {
 	return false
 }
 
+func (m *GetDataProofQuery) GetIsAbsent() bool {
+	if m != nil {
+		return m.IsAbsent
+	}
+	return false
+}
+
 type GetDataProofQueryEnvelope struct {
 	Payload              *GetDataProofQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -1343,7 +1881,7 @@ func (m *GetDataProofQueryEnvelope) Reset()         { *m = GetDataProofQueryEnve
 func (m *GetDataProofQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofQueryEnvelope) ProtoMessage()    {}
 func (*GetDataProofQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{26}
+	return fileDescriptor_5c6ac9b241082464, []int{35}
 }
 
 func (m *GetDataProofQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1378,6 +1916,118 @@ func (m *GetDataProofQueryEnvelope) GetSignature() []byte {
 	return nil
 }
 
+// GetDataProofsQuery is a query for the proof of the state of several keys of a database at a block, whether the keys
+// are written, deleted or were never written.
+type GetDataProofsQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	DbName               string   `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Keys                 []string `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDataProofsQuery) Reset()         { *m = GetDataProofsQuery{} }
+func (m *GetDataProofsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofsQuery) ProtoMessage()    {}
+func (*GetDataProofsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{36}
+}
+
+func (m *GetDataProofsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofsQuery.Unmarshal(m, b)
+}
+func (m *GetDataProofsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofsQuery.Merge(m, src)
+}
+func (m *GetDataProofsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofsQuery.Size(m)
+}
+func (m *GetDataProofsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofsQuery proto.InternalMessageInfo
+
+func (m *GetDataProofsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetDataProofsQuery) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *GetDataProofsQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *GetDataProofsQuery) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type GetDataProofsQueryEnvelope struct {
+	Payload              *GetDataProofsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetDataProofsQueryEnvelope) Reset()         { *m = GetDataProofsQueryEnvelope{} }
+func (m *GetDataProofsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofsQueryEnvelope) ProtoMessage()    {}
+func (*GetDataProofsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{37}
+}
+
+func (m *GetDataProofsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofsQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataProofsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofsQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofsQueryEnvelope.Merge(m, src)
+}
+func (m *GetDataProofsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofsQueryEnvelope.Size(m)
+}
+func (m *GetDataProofsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofsQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofsQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetDataProofsQueryEnvelope) GetPayload() *GetDataProofsQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetDataProofsQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type GetHistoricalDataQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
@@ -1395,7 +2045,7 @@ func (m *GetHistoricalDataQuery) Reset()         { *m = GetHistoricalDataQuery{}
 func (m *GetHistoricalDataQuery) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataQuery) ProtoMessage()    {}
 func (*GetHistoricalDataQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{27}
+	return fileDescriptor_5c6ac9b241082464, []int{38}
 }
 
 func (m *GetHistoricalDataQuery) XXX_Unmarshal(b []byte) error {
@@ -1477,7 +2127,7 @@ func (m *GetHistoricalDataQueryEnvelope) Reset()         { *m = GetHistoricalDat
 func (m *GetHistoricalDataQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataQueryEnvelope) ProtoMessage()    {}
 func (*GetHistoricalDataQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{28}
+	return fileDescriptor_5c6ac9b241082464, []int{39}
 }
 
 func (m *GetHistoricalDataQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1525,7 +2175,7 @@ func (m *GetDataReadersQuery) Reset()         { *m = GetDataReadersQuery{} }
 func (m *GetDataReadersQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersQuery) ProtoMessage()    {}
 func (*GetDataReadersQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{29}
+	return fileDescriptor_5c6ac9b241082464, []int{40}
 }
 
 func (m *GetDataReadersQuery) XXX_Unmarshal(b []byte) error {
@@ -1579,7 +2229,7 @@ func (m *GetDataReadersQueryEnvelope) Reset()         { *m = GetDataReadersQuery
 func (m *GetDataReadersQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersQueryEnvelope) ProtoMessage()    {}
 func (*GetDataReadersQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{30}
+	return fileDescriptor_5c6ac9b241082464, []int{41}
 }
 
 func (m *GetDataReadersQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1627,7 +2277,7 @@ func (m *GetDataWritersQuery) Reset()         { *m = GetDataWritersQuery{} }
 func (m *GetDataWritersQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersQuery) ProtoMessage()    {}
 func (*GetDataWritersQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{31}
+	return fileDescriptor_5c6ac9b241082464, []int{42}
 }
 
 func (m *GetDataWritersQuery) XXX_Unmarshal(b []byte) error {
@@ -1681,7 +2331,7 @@ func (m *GetDataWritersQueryEnvelope) Reset()         { *m = GetDataWritersQuery
 func (m *GetDataWritersQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersQueryEnvelope) ProtoMessage()    {}
 func (*GetDataWritersQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{32}
+	return fileDescriptor_5c6ac9b241082464, []int{43}
 }
 
 func (m *GetDataWritersQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1728,7 +2378,7 @@ func (m *GetDataReadByQuery) Reset()         { *m = GetDataReadByQuery{} }
 func (m *GetDataReadByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadByQuery) ProtoMessage()    {}
 func (*GetDataReadByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{33}
+	return fileDescriptor_5c6ac9b241082464, []int{44}
 }
 
 func (m *GetDataReadByQuery) XXX_Unmarshal(b []byte) error {
@@ -1775,7 +2425,7 @@ func (m *GetDataReadByQueryEnvelope) Reset()         { *m = GetDataReadByQueryEn
 func (m *GetDataReadByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataReadByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{34}
+	return fileDescriptor_5c6ac9b241082464, []int{45}
 }
 
 func (m *GetDataReadByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1822,7 +2472,7 @@ func (m *GetDataWrittenByQuery) Reset()         { *m = GetDataWrittenByQuery{} }
 func (m *GetDataWrittenByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataWrittenByQuery) ProtoMessage()    {}
 func (*GetDataWrittenByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{35}
+	return fileDescriptor_5c6ac9b241082464, []int{46}
 }
 
 func (m *GetDataWrittenByQuery) XXX_Unmarshal(b []byte) error {
@@ -1869,7 +2519,7 @@ func (m *GetDataDeletedByQuery) Reset()         { *m = GetDataDeletedByQuery{} }
 func (m *GetDataDeletedByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataDeletedByQuery) ProtoMessage()    {}
 func (*GetDataDeletedByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{36}
+	return fileDescriptor_5c6ac9b241082464, []int{47}
 }
 
 func (m *GetDataDeletedByQuery) XXX_Unmarshal(b []byte) error {
@@ -1916,7 +2566,7 @@ func (m *GetDataDeletedByQueryEnvelope) Reset()         { *m = GetDataDeletedByQ
 func (m *GetDataDeletedByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataDeletedByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataDeletedByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{37}
+	return fileDescriptor_5c6ac9b241082464, []int{48}
 }
 
 func (m *GetDataDeletedByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1963,7 +2613,7 @@ func (m *GetDataWrittenByQueryEnvelope) Reset()         { *m = GetDataWrittenByQ
 func (m *GetDataWrittenByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataWrittenByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataWrittenByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{38}
+	return fileDescriptor_5c6ac9b241082464, []int{49}
 }
 
 func (m *GetDataWrittenByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2010,7 +2660,7 @@ func (m *GetTxIDsSubmittedByQuery) Reset()         { *m = GetTxIDsSubmittedByQue
 func (m *GetTxIDsSubmittedByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxIDsSubmittedByQuery) ProtoMessage()    {}
 func (*GetTxIDsSubmittedByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{39}
+	return fileDescriptor_5c6ac9b241082464, []int{50}
 }
 
 func (m *GetTxIDsSubmittedByQuery) XXX_Unmarshal(b []byte) error {
@@ -2057,7 +2707,7 @@ func (m *GetTxIDsSubmittedByQueryEnvelope) Reset()         { *m = GetTxIDsSubmit
 func (m *GetTxIDsSubmittedByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxIDsSubmittedByQueryEnvelope) ProtoMessage()    {}
 func (*GetTxIDsSubmittedByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{40}
+	return fileDescriptor_5c6ac9b241082464, []int{51}
 }
 
 func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2104,7 +2754,7 @@ func (m *GetTxReceiptQuery) Reset()         { *m = GetTxReceiptQuery{} }
 func (m *GetTxReceiptQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxReceiptQuery) ProtoMessage()    {}
 func (*GetTxReceiptQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{41}
+	return fileDescriptor_5c6ac9b241082464, []int{52}
 }
 
 func (m *GetTxReceiptQuery) XXX_Unmarshal(b []byte) error {
@@ -2151,7 +2801,7 @@ func (m *GetTxReceiptQueryEnvelope) Reset()         { *m = GetTxReceiptQueryEnve
 func (m *GetTxReceiptQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxReceiptQueryEnvelope) ProtoMessage()    {}
 func (*GetTxReceiptQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{42}
+	return fileDescriptor_5c6ac9b241082464, []int{53}
 }
 
 func (m *GetTxReceiptQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2200,7 +2850,7 @@ func (m *GetMostRecentUserOrNodeQuery) Reset()         { *m = GetMostRecentUserO
 func (m *GetMostRecentUserOrNodeQuery) String() string { return proto.CompactTextString(m) }
 func (*GetMostRecentUserOrNodeQuery) ProtoMessage()    {}
 func (*GetMostRecentUserOrNodeQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{43}
+	return fileDescriptor_5c6ac9b241082464, []int{54}
 }
 
 func (m *GetMostRecentUserOrNodeQuery) XXX_Unmarshal(b []byte) error {
@@ -2262,7 +2912,7 @@ func (m *DataJSONQuery) Reset()         { *m = DataJSONQuery{} }
 func (m *DataJSONQuery) String() string { return proto.CompactTextString(m) }
 func (*DataJSONQuery) ProtoMessage()    {}
 func (*DataJSONQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{44}
+	return fileDescriptor_5c6ac9b241082464, []int{55}
 }
 
 func (m *DataJSONQuery) XXX_Unmarshal(b []byte) error {
@@ -2323,16 +2973,27 @@ func init() {
 	proto.RegisterType((*GetConfigBlockQuery)(nil), "types.GetConfigBlockQuery")
 	proto.RegisterType((*GetClusterStatusQueryEnvelope)(nil), "types.GetClusterStatusQueryEnvelope")
 	proto.RegisterType((*GetClusterStatusQuery)(nil), "types.GetClusterStatusQuery")
+	proto.RegisterType((*TransferLeadershipRequestEnvelope)(nil), "types.TransferLeadershipRequestEnvelope")
+	proto.RegisterType((*TransferLeadershipRequest)(nil), "types.TransferLeadershipRequest")
+	proto.RegisterType((*SetNetworkFaultsRequestEnvelope)(nil), "types.SetNetworkFaultsRequestEnvelope")
+	proto.RegisterType((*SetNetworkFaultsRequest)(nil), "types.SetNetworkFaultsRequest")
+	proto.RegisterType((*NetworkFault)(nil), "types.NetworkFault")
 	proto.RegisterType((*GetBlockQuery)(nil), "types.GetBlockQuery")
 	proto.RegisterType((*GetBlockQueryEnvelope)(nil), "types.GetBlockQueryEnvelope")
 	proto.RegisterType((*GetLastBlockQuery)(nil), "types.GetLastBlockQuery")
 	proto.RegisterType((*GetLastBlockQueryEnvelope)(nil), "types.GetLastBlockQueryEnvelope")
 	proto.RegisterType((*GetLedgerPathQuery)(nil), "types.GetLedgerPathQuery")
 	proto.RegisterType((*GetLedgerPathQueryEnvelope)(nil), "types.GetLedgerPathQueryEnvelope")
+	proto.RegisterType((*GetLedgerConsistencyProofQuery)(nil), "types.GetLedgerConsistencyProofQuery")
+	proto.RegisterType((*GetLedgerConsistencyProofQueryEnvelope)(nil), "types.GetLedgerConsistencyProofQueryEnvelope")
 	proto.RegisterType((*GetTxProofQuery)(nil), "types.GetTxProofQuery")
 	proto.RegisterType((*GetTxProofQueryEnvelope)(nil), "types.GetTxProofQueryEnvelope")
+	proto.RegisterType((*GetTxProofsQuery)(nil), "types.GetTxProofsQuery")
+	proto.RegisterType((*GetTxProofsQueryEnvelope)(nil), "types.GetTxProofsQueryEnvelope")
 	proto.RegisterType((*GetDataProofQuery)(nil), "types.GetDataProofQuery")
 	proto.RegisterType((*GetDataProofQueryEnvelope)(nil), "types.GetDataProofQueryEnvelope")
+	proto.RegisterType((*GetDataProofsQuery)(nil), "types.GetDataProofsQuery")
+	proto.RegisterType((*GetDataProofsQueryEnvelope)(nil), "types.GetDataProofsQueryEnvelope")
 	proto.RegisterType((*GetHistoricalDataQuery)(nil), "types.GetHistoricalDataQuery")
 	proto.RegisterType((*GetHistoricalDataQueryEnvelope)(nil), "types.GetHistoricalDataQueryEnvelope")
 	proto.RegisterType((*GetDataReadersQuery)(nil), "types.GetDataReadersQuery")
@@ -2356,77 +3017,100 @@ func init() {
 func init() { proto.RegisterFile("query.proto", fileDescriptor_5c6ac9b241082464) }
 
 var fileDescriptor_5c6ac9b241082464 = []byte{
-	// 1140 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xc6, 0x89, 0x9d, 0x9f, 0xe3, 0xd4, 0x18, 0x25, 0x6d, 0x9c, 0x3f, 0x1a, 0x34, 0x0c, 0x63,
-	0x66, 0x1a, 0x07, 0xd2, 0x0e, 0x0c, 0x33, 0xdc, 0x90, 0x1f, 0x42, 0xa0, 0x4d, 0x5a, 0x25, 0x69,
-	0x81, 0x1b, 0xcf, 0xda, 0x3a, 0x71, 0x76, 0x62, 0xaf, 0xdc, 0xdd, 0x75, 0xb0, 0x87, 0x6b, 0x1e,
-	0x80, 0x4b, 0x9e, 0x89, 0x17, 0xe1, 0x31, 0x98, 0x5d, 0xc9, 0x96, 0xb4, 0x96, 0xe9, 0x26, 0xb8,
-	0x77, 0xd1, 0xd1, 0xf9, 0xce, 0x7e, 0xdf, 0x27, 0xe9, 0x9c, 0x13, 0x43, 0xf1, 0x6d, 0x0f, 0xf9,
-	0xa0, 0xd6, 0xe5, 0x81, 0x0c, 0x9c, 0x82, 0x1c, 0x74, 0x51, 0xac, 0x6f, 0x34, 0xda, 0x41, 0xf3,
-	0xa6, 0x4e, 0x98, 0x5f, 0x97, 0x9c, 0x30, 0x41, 0x9a, 0x92, 0x06, 0x2c, 0xcc, 0x71, 0x6f, 0xa0,
-	0x72, 0x8c, 0xf2, 0x70, 0xff, 0x5c, 0x12, 0xd9, 0x13, 0xaf, 0x14, 0xfa, 0x88, 0xdd, 0x62, 0x3b,
-	0xe8, 0xa2, 0xf3, 0x25, 0xcc, 0x77, 0xc9, 0xa0, 0x1d, 0x10, 0xbf, 0x92, 0xdb, 0xce, 0x55, 0x8b,
-	0x7b, 0xab, 0x35, 0x5d, 0xb1, 0x66, 0x22, 0xbc, 0x61, 0x9e, 0xb3, 0x09, 0x8b, 0x82, 0xb6, 0x18,
-	0x91, 0x3d, 0x8e, 0x95, 0x99, 0xed, 0x5c, 0x75, 0xc9, 0x8b, 0x03, 0xee, 0x21, 0x94, 0x4d, 0xa8,
-	0xb3, 0x0a, 0xf3, 0x3d, 0x81, 0xbc, 0x4e, 0xc3, 0x43, 0x16, 0xbd, 0x39, 0x75, 0x79, 0xe2, 0xab,
-	0x1b, 0x7e, 0xa3, 0xce, 0x48, 0x27, 0x2c, 0xb4, 0xe8, 0xcd, 0xf9, 0x8d, 0x53, 0xd2, 0x41, 0x97,
-	0xc2, 0xaa, 0xae, 0x72, 0xc2, 0x7c, 0xec, 0xa7, 0x19, 0x7f, 0x61, 0x32, 0x7e, 0x94, 0x64, 0x1c,
-	0x03, 0x6c, 0x09, 0x1f, 0xc0, 0x87, 0x06, 0xf2, 0x1e, 0x7c, 0x9b, 0xb0, 0xa2, 0x8a, 0x10, 0x49,
-	0xd2, 0x64, 0x77, 0x4c, 0xb2, 0xcb, 0x09, 0xb2, 0xc3, 0x6c, 0x5b, 0xa6, 0x1e, 0x2c, 0x25, 0x61,
-	0x77, 0xa7, 0xe9, 0x94, 0x61, 0xf6, 0x06, 0x07, 0x95, 0x59, 0x1d, 0x54, 0x7f, 0xba, 0x7f, 0xe6,
-	0xe0, 0xa3, 0xa8, 0xa8, 0x47, 0x58, 0x0b, 0xef, 0x5b, 0x79, 0x03, 0x16, 0x85, 0x24, 0x5c, 0xd6,
-	0xe3, 0xfa, 0x0b, 0x3a, 0xf0, 0x13, 0xea, 0x72, 0xc8, 0x7c, 0x7d, 0x2b, 0x1f, 0xa2, 0x90, 0xf9,
-	0xea, 0xc6, 0x0a, 0x14, 0xda, 0xb4, 0x43, 0x65, 0xa5, 0xb0, 0x9d, 0xab, 0xe6, 0xbd, 0xf0, 0x22,
-	0x32, 0xf3, 0x52, 0x20, 0xb7, 0x37, 0x73, 0x94, 0x6d, 0x6b, 0xe6, 0x0b, 0x6d, 0xe6, 0x08, 0x36,
-	0x59, 0xf2, 0xa7, 0x50, 0x92, 0x84, 0xb7, 0x50, 0xd6, 0x87, 0xf7, 0x43, 0xe5, 0x4b, 0x61, 0xf4,
-	0x52, 0x67, 0xb9, 0x2d, 0x78, 0x74, 0x8c, 0xf2, 0x20, 0x60, 0x57, 0xb4, 0x95, 0x66, 0xbd, 0x6b,
-	0xb2, 0x7e, 0x18, 0xb3, 0x4e, 0xe4, 0xdb, 0xf2, 0xfe, 0x1c, 0x4a, 0x69, 0xe0, 0x44, 0xe6, 0x6e,
-	0x00, 0xeb, 0xc7, 0x28, 0x4f, 0x03, 0x1f, 0xb3, 0x78, 0x3d, 0x35, 0x79, 0xad, 0xc5, 0xbc, 0x0c,
-	0x8c, 0x2d, 0xb7, 0xef, 0xc1, 0x19, 0x07, 0xff, 0xe7, 0xcb, 0xc4, 0x02, 0x1f, 0x63, 0x4b, 0xe7,
-	0xd4, 0xe5, 0x89, 0xef, 0x76, 0x15, 0xf1, 0xb0, 0xc4, 0xbe, 0xea, 0x6b, 0x69, 0xe2, 0xcf, 0x4c,
-	0xe2, 0xeb, 0xa6, 0xa1, 0x31, 0xc8, 0x96, 0xf9, 0x2b, 0x58, 0xce, 0x40, 0x4f, 0xa6, 0xfe, 0x09,
-	0x2c, 0x85, 0x1d, 0x97, 0xf5, 0x3a, 0x0d, 0xe4, 0xba, 0x60, 0xde, 0x2b, 0xea, 0xd8, 0xa9, 0x0e,
-	0xb9, 0x3d, 0xd8, 0x52, 0x25, 0xdb, 0x3d, 0x21, 0x91, 0x67, 0xb5, 0xde, 0xaf, 0x4c, 0x1d, 0x9b,
-	0x09, 0x1d, 0x63, 0x30, 0x5b, 0x25, 0x3f, 0xc3, 0xc3, 0x4c, 0xfc, 0x64, 0x2d, 0x9f, 0x41, 0x89,
-	0x05, 0x07, 0xc8, 0x25, 0xbd, 0xa2, 0x4d, 0x22, 0x51, 0xe8, 0xa2, 0x0b, 0x9e, 0x11, 0x75, 0x29,
-	0x3c, 0x38, 0x46, 0x39, 0x1d, 0x77, 0x94, 0x08, 0xd2, 0x6b, 0x75, 0x90, 0x49, 0xf4, 0x75, 0xbf,
-	0x58, 0xf0, 0xe2, 0x80, 0x8b, 0x5a, 0x44, 0xc6, 0xb3, 0xaf, 0x99, 0x9e, 0xad, 0xc4, 0x9e, 0xdd,
-	0xfd, 0xa9, 0x3f, 0xd1, 0xbd, 0xef, 0x39, 0x11, 0x36, 0xaa, 0xdc, 0x0e, 0xac, 0x8d, 0x65, 0x8f,
-	0x88, 0xed, 0x99, 0xc4, 0x2a, 0x31, 0xb1, 0x34, 0xc4, 0x96, 0xdc, 0x1f, 0x39, 0xfd, 0x35, 0x3d,
-	0x47, 0xbf, 0x85, 0xfc, 0x25, 0x91, 0xd7, 0xef, 0x30, 0xfd, 0x09, 0x38, 0x61, 0x07, 0xce, 0xb0,
-	0xbe, 0xac, 0xef, 0xec, 0x27, 0xfc, 0xaf, 0x42, 0x59, 0xb5, 0xe4, 0x54, 0xee, 0xac, 0xce, 0x2d,
-	0x21, 0xf3, 0x13, 0x99, 0x51, 0x17, 0x31, 0x68, 0x58, 0x75, 0x11, 0x03, 0x63, 0x2b, 0xfc, 0x5a,
-	0x0f, 0xe4, 0x8b, 0xfe, 0x4b, 0x1e, 0x04, 0x57, 0xff, 0xff, 0x4d, 0x5b, 0x83, 0x05, 0xd9, 0xaf,
-	0x53, 0x35, 0xdd, 0x23, 0x85, 0xf3, 0xb2, 0xaf, 0x87, 0x7d, 0xb4, 0x65, 0x24, 0x4f, 0xb2, 0xda,
-	0x32, 0x92, 0x00, 0x5b, 0x51, 0x7f, 0xc5, 0x73, 0x76, 0x4a, 0xba, 0x12, 0xa3, 0x78, 0x36, 0x6b,
-	0xc8, 0xe7, 0x47, 0x43, 0xde, 0xd9, 0x02, 0xa0, 0xa2, 0xee, 0x63, 0x1b, 0xd5, 0xd7, 0x56, 0x08,
-	0xbf, 0x36, 0x2a, 0x0e, 0xc3, 0x40, 0xf4, 0x62, 0xa7, 0xa9, 0x59, 0xbd, 0xd8, 0x69, 0x88, 0xad,
-	0x15, 0xff, 0xe4, 0xf4, 0xac, 0xfc, 0x81, 0x0a, 0x19, 0x70, 0xda, 0x24, 0xed, 0xa9, 0x6e, 0x34,
-	0x4e, 0x15, 0xe6, 0x6f, 0x91, 0x0b, 0x1a, 0x30, 0x6d, 0x41, 0x71, 0xaf, 0x14, 0x11, 0x7e, 0x1d,
-	0x46, 0xbd, 0xe1, 0x6d, 0x45, 0xd3, 0xa7, 0x1c, 0xf5, 0xaa, 0xac, 0x5d, 0x59, 0xf4, 0xe2, 0x80,
-	0x7a, 0x04, 0x01, 0x6b, 0x0f, 0x22, 0xdb, 0x44, 0x65, 0x4e, 0xdb, 0x56, 0x54, 0xb1, 0xd0, 0x38,
-	0xe1, 0x3c, 0x86, 0x62, 0x27, 0x10, 0xb2, 0xce, 0xb1, 0x89, 0x4c, 0x56, 0xe6, 0x75, 0x06, 0xa8,
-	0x90, 0xa7, 0x23, 0xee, 0x6f, 0xf0, 0x71, 0xb6, 0xd2, 0x91, 0xbd, 0x5f, 0x9b, 0xf6, 0x6e, 0xc5,
-	0xf6, 0x66, 0xe0, 0x6c, 0x3d, 0xfe, 0x45, 0xcf, 0x33, 0xbd, 0xd5, 0x21, 0xf1, 0x91, 0x8b, 0xe9,
-	0x6d, 0x8c, 0x6f, 0x61, 0x23, 0xa3, 0xb4, 0xd5, 0x74, 0x36, 0x41, 0x77, 0x57, 0xf3, 0x86, 0x53,
-	0xf9, 0x9e, 0xd4, 0x24, 0x4b, 0x5b, 0xab, 0x49, 0x82, 0x6c, 0xd5, 0x9c, 0xeb, 0xbe, 0x3e, 0xf4,
-	0x62, 0x7f, 0x30, 0x95, 0xfd, 0x33, 0xec, 0xd2, 0x46, 0x51, 0xab, 0x2e, 0x6d, 0x60, 0x6c, 0x55,
-	0xbc, 0xd6, 0x23, 0x7a, 0xe8, 0x81, 0x44, 0x36, 0x25, 0x21, 0x71, 0xdd, 0xa8, 0x3d, 0x4d, 0xa9,
-	0x6e, 0xb8, 0x8e, 0x8d, 0xd7, 0xb5, 0x5a, 0xc7, 0xc6, 0x61, 0xb6, 0x36, 0xc5, 0xc7, 0xa6, 0x6d,
-	0xb2, 0x3e, 0x36, 0x0d, 0xb3, 0xff, 0x62, 0x2a, 0x7a, 0x50, 0x9d, 0x1c, 0x8a, 0xf3, 0x5e, 0xa3,
-	0xa3, 0x4a, 0x4c, 0xcb, 0xc8, 0xdf, 0x61, 0x7b, 0x52, 0xe9, 0x91, 0xa8, 0x6f, 0x4c, 0x51, 0x8f,
-	0x93, 0xd3, 0x33, 0x03, 0x69, 0xab, 0xeb, 0x3b, 0x3d, 0x45, 0x2f, 0xfa, 0xaa, 0xbf, 0xd2, 0xae,
-	0x7c, 0x87, 0xa0, 0x65, 0x28, 0xa8, 0xd1, 0x3f, 0xd4, 0x91, 0x97, 0xfd, 0xd1, 0x1a, 0x97, 0x2e,
-	0x61, 0x35, 0xed, 0xd2, 0x10, 0x5b, 0xc6, 0x7f, 0xe7, 0x60, 0xf3, 0x18, 0xe5, 0x8b, 0xd1, 0x50,
-	0x50, 0x36, 0x9e, 0x71, 0xf5, 0x4f, 0x52, 0xc8, 0xfe, 0x5b, 0xc8, 0xab, 0x23, 0xf4, 0x79, 0xa5,
-	0xbd, 0x6a, 0x7c, 0xde, 0x44, 0x48, 0xed, 0x62, 0xd0, 0x45, 0x4f, 0xa3, 0x92, 0xda, 0x67, 0x52,
-	0xda, 0x4b, 0x30, 0x43, 0xfd, 0xa8, 0xd3, 0xcd, 0x50, 0xdf, 0x7e, 0x2c, 0xba, 0xeb, 0x90, 0x57,
-	0x07, 0x38, 0x0b, 0x90, 0xbf, 0x3c, 0x3f, 0xf2, 0xca, 0x1f, 0xa8, 0xbf, 0x4e, 0xcf, 0x0e, 0x8f,
-	0xca, 0x39, 0xf7, 0x0d, 0x3c, 0x50, 0x2f, 0xe5, 0x8f, 0xe7, 0x67, 0xa7, 0xf7, 0xed, 0xc1, 0x2b,
-	0x50, 0xd0, 0x3f, 0x60, 0x45, 0xdc, 0xc2, 0x8b, 0xfd, 0x67, 0xbf, 0xee, 0xb5, 0xa8, 0xbc, 0xee,
-	0x35, 0x6a, 0xcd, 0xa0, 0xb3, 0x7b, 0x3d, 0xe8, 0x22, 0x6f, 0xeb, 0xf5, 0x71, 0xa7, 0x4d, 0x1a,
-	0x62, 0x37, 0xe0, 0x34, 0x60, 0x3b, 0x02, 0xf9, 0x2d, 0xf2, 0xdd, 0xee, 0x4d, 0x6b, 0x57, 0x73,
-	0x6f, 0xcc, 0xe9, 0x1f, 0xb8, 0x9e, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x9c, 0xa2, 0x2d, 0x9f,
-	0x13, 0x13, 0x00, 0x00,
+	// 1514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xed, 0x52, 0x14, 0x47,
+	0x17, 0x7e, 0x97, 0x1d, 0x60, 0x39, 0xac, 0xfb, 0x92, 0x01, 0x64, 0x41, 0xd1, 0x75, 0x2a, 0x5a,
+	0x9b, 0x8a, 0x42, 0x82, 0x56, 0xbe, 0x2a, 0x55, 0x29, 0x01, 0x25, 0x24, 0x8a, 0x3a, 0xa0, 0x26,
+	0xf9, 0xb3, 0xd5, 0xbb, 0x73, 0x58, 0xba, 0x98, 0x2f, 0xbb, 0x7b, 0x70, 0x37, 0x29, 0x7f, 0xa6,
+	0xf2, 0x3b, 0x17, 0x93, 0xdc, 0x84, 0x37, 0x92, 0xcb, 0x48, 0x75, 0xcf, 0xb0, 0xf3, 0xc1, 0xae,
+	0x36, 0xba, 0x56, 0xfe, 0xed, 0x9c, 0x39, 0xcf, 0xe9, 0xe7, 0x79, 0xa6, 0xbf, 0x0e, 0xc0, 0xec,
+	0x8b, 0x08, 0x59, 0x7f, 0x2d, 0x64, 0x81, 0x08, 0xcc, 0x49, 0xd1, 0x0f, 0x91, 0xaf, 0x5c, 0x6a,
+	0xbb, 0x41, 0xe7, 0xb8, 0x45, 0x7c, 0xa7, 0x25, 0x18, 0xf1, 0x39, 0xe9, 0x08, 0x1a, 0xf8, 0x71,
+	0xce, 0xca, 0x7c, 0x27, 0xf0, 0x0f, 0x69, 0x37, 0x62, 0x24, 0x0d, 0x5a, 0xc7, 0x50, 0xdf, 0x41,
+	0xb1, 0xbd, 0xb9, 0x2f, 0x88, 0x88, 0xf8, 0x13, 0x59, 0xf2, 0x9e, 0x7f, 0x82, 0x6e, 0x10, 0xa2,
+	0xf9, 0x39, 0x4c, 0x87, 0xa4, 0xef, 0x06, 0xc4, 0xa9, 0x97, 0x1a, 0xa5, 0xe6, 0xec, 0xc6, 0xd2,
+	0x9a, 0x1a, 0x66, 0xad, 0x88, 0xb0, 0x4f, 0xf3, 0xcc, 0xcb, 0x30, 0xc3, 0x69, 0xd7, 0x27, 0x22,
+	0x62, 0x58, 0x9f, 0x68, 0x94, 0x9a, 0x55, 0x3b, 0x0d, 0x58, 0xdb, 0x30, 0x57, 0x84, 0x9a, 0x4b,
+	0x30, 0x1d, 0x71, 0x64, 0x2d, 0x1a, 0x0f, 0x32, 0x63, 0x4f, 0xc9, 0xc7, 0x5d, 0x47, 0xbe, 0x70,
+	0xda, 0x2d, 0x9f, 0x78, 0x71, 0xa1, 0x19, 0x7b, 0xca, 0x69, 0xef, 0x11, 0x0f, 0x2d, 0x0a, 0x4b,
+	0xaa, 0xca, 0xae, 0xef, 0x60, 0x2f, 0xcf, 0xf8, 0xb3, 0x22, 0xe3, 0x8b, 0x59, 0xc6, 0x29, 0x40,
+	0x97, 0xf0, 0x16, 0xfc, 0xbf, 0x80, 0x7c, 0x07, 0xbe, 0x1d, 0x58, 0x90, 0x45, 0x88, 0x20, 0x79,
+	0xb2, 0xb7, 0x8a, 0x64, 0xe7, 0x33, 0x64, 0x4f, 0xb3, 0x75, 0x99, 0x9e, 0x40, 0x35, 0x0b, 0x3b,
+	0x3f, 0x4d, 0x73, 0x0e, 0xca, 0xc7, 0xd8, 0xaf, 0x97, 0x55, 0x50, 0xfe, 0x34, 0x2d, 0xa8, 0xba,
+	0xd4, 0x47, 0xc2, 0xe8, 0xaf, 0xa4, 0xed, 0x62, 0xdd, 0x68, 0x94, 0x9a, 0x15, 0x3b, 0x17, 0xb3,
+	0xfe, 0x2a, 0xc1, 0x47, 0xc9, 0xc0, 0x36, 0xf1, 0xbb, 0xf8, 0xae, 0xa3, 0x5f, 0x82, 0x19, 0x2e,
+	0x08, 0x13, 0xad, 0x94, 0x43, 0x45, 0x05, 0x7e, 0x44, 0x55, 0x0e, 0x7d, 0x47, 0xbd, 0x32, 0x62,
+	0x14, 0xfa, 0x8e, 0x7c, 0xb1, 0x00, 0x93, 0x2e, 0xf5, 0xa8, 0xa8, 0x4f, 0x36, 0x4a, 0x4d, 0xc3,
+	0x8e, 0x1f, 0xce, 0xf0, 0x9e, 0x1a, 0xc2, 0x3b, 0xfe, 0x28, 0x4f, 0x39, 0x32, 0xfd, 0x8f, 0x32,
+	0xc8, 0xd6, 0xfd, 0x28, 0x0f, 0xa1, 0x9a, 0x85, 0x8d, 0xb6, 0xe5, 0x63, 0xa8, 0x09, 0xc2, 0xba,
+	0x28, 0x5a, 0xa7, 0xef, 0x63, 0x77, 0xaa, 0x71, 0xf4, 0xa9, 0xca, 0xb2, 0xba, 0x70, 0x71, 0x07,
+	0xc5, 0x96, 0x5a, 0xc5, 0x79, 0xd6, 0xeb, 0x45, 0xd6, 0x8b, 0x29, 0xeb, 0x4c, 0xbe, 0x2e, 0xef,
+	0x4f, 0xa0, 0x96, 0x07, 0x8e, 0x64, 0x6e, 0x05, 0xb0, 0xb2, 0x83, 0x62, 0x2f, 0x70, 0x70, 0x18,
+	0xaf, 0xdb, 0x45, 0x5e, 0xcb, 0x29, 0xaf, 0x02, 0x46, 0x97, 0xdb, 0x7d, 0x30, 0xcf, 0x82, 0xdf,
+	0x38, 0xe1, 0xfc, 0xc0, 0xc1, 0xd4, 0xd2, 0x29, 0xf9, 0xb8, 0xeb, 0x58, 0xa1, 0x24, 0x1e, 0x97,
+	0xd8, 0x94, 0x9b, 0x66, 0x9e, 0xf8, 0x9d, 0x22, 0xf1, 0x95, 0xa2, 0xa1, 0x29, 0x48, 0x97, 0xf9,
+	0x13, 0x98, 0x1f, 0x82, 0x1e, 0x4d, 0xfd, 0x1a, 0x54, 0xe3, 0xed, 0xdc, 0x8f, 0xbc, 0x36, 0x32,
+	0x55, 0xd0, 0xb0, 0x67, 0x55, 0x6c, 0x4f, 0x85, 0xac, 0x08, 0x56, 0x65, 0x49, 0x37, 0xe2, 0x02,
+	0xd9, 0xb0, 0x2d, 0xfc, 0x8b, 0xa2, 0x8e, 0xcb, 0x19, 0x1d, 0x67, 0x60, 0xba, 0x4a, 0x7e, 0x82,
+	0xc5, 0xa1, 0xf8, 0xd1, 0x5a, 0x6e, 0x40, 0xcd, 0x0f, 0xb6, 0x90, 0x09, 0x7a, 0x48, 0x3b, 0x44,
+	0x20, 0x57, 0x45, 0x2b, 0x76, 0x21, 0x6a, 0xbd, 0x82, 0x6b, 0x07, 0xf2, 0xe0, 0x3a, 0x44, 0xf6,
+	0x00, 0x89, 0x83, 0x8c, 0x1f, 0xd1, 0xd0, 0xc6, 0x17, 0x11, 0x72, 0x31, 0x10, 0xf5, 0x4d, 0x51,
+	0x54, 0x23, 0x11, 0x35, 0x12, 0xaa, 0x2b, 0xac, 0x07, 0xcb, 0x23, 0x6b, 0xe8, 0xac, 0xde, 0xfc,
+	0x54, 0x4b, 0x56, 0xef, 0x9e, 0x9a, 0x70, 0x72, 0x64, 0x41, 0x3d, 0xe4, 0x82, 0x78, 0xa1, 0xda,
+	0xe1, 0xca, 0x76, 0x1a, 0xb0, 0xfa, 0x70, 0x75, 0x1f, 0xc5, 0x1e, 0x8a, 0x97, 0x01, 0x3b, 0xbe,
+	0x4f, 0x22, 0x57, 0xf0, 0xa2, 0xec, 0xaf, 0x8a, 0xb2, 0xaf, 0x24, 0xb2, 0x47, 0x00, 0x75, 0x45,
+	0x73, 0x58, 0x1a, 0x51, 0x61, 0xb4, 0xe4, 0x4f, 0x61, 0xea, 0x50, 0x65, 0xd6, 0x27, 0x1a, 0xe5,
+	0xcc, 0x2e, 0x99, 0xad, 0x62, 0x27, 0x29, 0xa6, 0x09, 0x06, 0x47, 0x74, 0x12, 0xd1, 0xea, 0xb7,
+	0xf5, 0xba, 0x04, 0xd5, 0x6c, 0xb2, 0xd9, 0x80, 0xea, 0x21, 0x0b, 0xbc, 0x81, 0x85, 0xf1, 0x78,
+	0x20, 0x63, 0x03, 0x03, 0x41, 0x04, 0x05, 0x8b, 0x2b, 0x22, 0x48, 0xed, 0x0d, 0x09, 0x13, 0x54,
+	0xde, 0x6d, 0xd4, 0x48, 0x15, 0x3b, 0x0d, 0xc8, 0xe3, 0xc5, 0x61, 0x41, 0xd8, 0x62, 0x44, 0xc4,
+	0xe7, 0x58, 0xc9, 0xae, 0xc8, 0x80, 0x4d, 0x04, 0x9a, 0xd7, 0xa1, 0xe6, 0x44, 0xa1, 0xab, 0xa6,
+	0x60, 0x9c, 0x31, 0xa9, 0x32, 0x2e, 0x0c, 0xa2, 0x2a, 0x6d, 0x19, 0x2a, 0x0e, 0xba, 0xa4, 0xdf,
+	0xf2, 0xb8, 0x3a, 0x52, 0x0c, 0x7b, 0x5a, 0x3d, 0x3f, 0xe4, 0x16, 0x85, 0x0b, 0x3b, 0x28, 0xc6,
+	0xb3, 0xa8, 0xa5, 0x12, 0x12, 0x75, 0x3d, 0xf4, 0x45, 0xe2, 0x59, 0xc5, 0x4e, 0x03, 0x16, 0xc2,
+	0x62, 0x6e, 0xa8, 0xc1, 0xf4, 0x58, 0x2b, 0x4e, 0x8f, 0x85, 0x74, 0xa9, 0x9f, 0x7f, 0xb3, 0xba,
+	0xa9, 0x8e, 0xf5, 0x07, 0x84, 0xeb, 0xa8, 0xb2, 0x3c, 0x58, 0x3e, 0x93, 0x3d, 0x20, 0xb6, 0x51,
+	0x24, 0x56, 0x4f, 0x89, 0xe5, 0x21, 0xba, 0xe4, 0x7e, 0x2f, 0xa9, 0x43, 0xe0, 0x01, 0x3a, 0x5d,
+	0x64, 0x8f, 0x89, 0x38, 0x7a, 0x8b, 0xe9, 0x37, 0xc1, 0x8c, 0x2f, 0x17, 0x43, 0xac, 0x9f, 0x53,
+	0x6f, 0x36, 0x33, 0xfe, 0x37, 0x61, 0x4e, 0xde, 0x36, 0x72, 0xb9, 0x65, 0x95, 0x5b, 0x43, 0xdf,
+	0xc9, 0x64, 0x26, 0x87, 0x5f, 0x81, 0x86, 0xd6, 0xe1, 0x57, 0xc0, 0xe8, 0x0a, 0xff, 0xb3, 0x04,
+	0x57, 0x06, 0xe8, 0xad, 0xc0, 0xe7, 0x94, 0x0b, 0xf4, 0x3b, 0xfd, 0xc7, 0x2c, 0x08, 0x0e, 0xff,
+	0x23, 0x13, 0xfe, 0x28, 0xc1, 0x8d, 0x37, 0x73, 0x1a, 0x38, 0xf2, 0x5d, 0xd1, 0x91, 0xeb, 0x45,
+	0x47, 0x86, 0xe2, 0x75, 0xdd, 0x39, 0x52, 0xb7, 0xf5, 0x83, 0x9e, 0x8e, 0x1b, 0x1a, 0xeb, 0x70,
+	0x19, 0x2a, 0xa2, 0xd7, 0xa2, 0xf2, 0xea, 0x9f, 0x48, 0x9f, 0x16, 0x3d, 0xd5, 0x09, 0x24, 0x2d,
+	0xc8, 0x41, 0x6f, 0x88, 0xc6, 0x37, 0xb5, 0x20, 0x07, 0xbd, 0xf3, 0x8b, 0xf2, 0x60, 0x2e, 0x45,
+	0xf2, 0xf7, 0x57, 0xb5, 0x0a, 0x70, 0xaa, 0x0a, 0x79, 0xbd, 0xdc, 0x28, 0x37, 0x0d, 0x7b, 0x26,
+	0xd1, 0x85, 0x3c, 0xe9, 0x07, 0x73, 0xc3, 0x69, 0xf5, 0x83, 0x39, 0x84, 0xae, 0xb6, 0xbf, 0xd3,
+	0xe6, 0x61, 0x4c, 0xdf, 0x2c, 0xd3, 0x5f, 0x94, 0x87, 0x75, 0x37, 0x46, 0xda, 0xdd, 0xac, 0x02,
+	0x50, 0xde, 0x72, 0xd0, 0x45, 0xb9, 0xcf, 0x4e, 0xc6, 0xfb, 0x2c, 0xe5, 0xdb, 0x71, 0x40, 0x9e,
+	0x18, 0x94, 0xb7, 0x48, 0x9b, 0xa3, 0x2f, 0x92, 0x0e, 0xa2, 0x42, 0xf9, 0x5d, 0xf5, 0x9c, 0xec,
+	0x77, 0x79, 0xde, 0x5a, 0xfb, 0x5d, 0x1e, 0xa2, 0xeb, 0xd3, 0x2b, 0x30, 0xb3, 0x58, 0xfe, 0x01,
+	0x7d, 0x32, 0xc1, 0x38, 0xc6, 0x3e, 0xaf, 0x1b, 0x8d, 0x72, 0x73, 0xc6, 0x56, 0xbf, 0x93, 0x6d,
+	0xae, 0x30, 0xbc, 0xd6, 0x36, 0x57, 0xc0, 0xe8, 0xea, 0xfd, 0xa7, 0xa4, 0x3a, 0x9d, 0xef, 0x29,
+	0x17, 0x01, 0xa3, 0x1d, 0xe2, 0x8e, 0xb7, 0xaf, 0x6d, 0xc2, 0xf4, 0x09, 0x32, 0x2e, 0x2f, 0x0a,
+	0x86, 0x62, 0x5c, 0x4b, 0x18, 0x3f, 0x8b, 0xa3, 0xf6, 0xe9, 0x6b, 0x49, 0xd3, 0xa1, 0x0c, 0xd5,
+	0x5f, 0x51, 0xd4, 0x14, 0x99, 0xb1, 0xd3, 0x80, 0xf4, 0x39, 0xf0, 0xdd, 0x7e, 0x32, 0x87, 0x78,
+	0x32, 0x4b, 0x66, 0x65, 0x2c, 0x9e, 0x45, 0xdc, 0xbc, 0x0a, 0xb3, 0x5e, 0xc0, 0x45, 0x8b, 0x61,
+	0x47, 0xce, 0xa3, 0x69, 0x95, 0x01, 0x32, 0x64, 0xab, 0x88, 0xf5, 0x12, 0xae, 0x0c, 0x57, 0x3a,
+	0xf0, 0xf7, 0xcb, 0xa2, 0xbf, 0xab, 0xa9, 0xbf, 0x43, 0x70, 0xba, 0x1e, 0xff, 0xac, 0xba, 0x11,
+	0x09, 0xb3, 0xe3, 0x9b, 0xee, 0xd8, 0xfc, 0xb5, 0x5e, 0xc0, 0xa5, 0x21, 0xa5, 0xb5, 0x7a, 0xab,
+	0x22, 0xe8, 0xfc, 0x6a, 0x9e, 0x33, 0x2a, 0x3e, 0x90, 0x9a, 0x6c, 0x69, 0x6d, 0x35, 0x59, 0x90,
+	0xae, 0x9a, 0x7d, 0x30, 0x33, 0x5e, 0x6c, 0xf6, 0xc7, 0xf2, 0xd7, 0x83, 0x74, 0x15, 0x67, 0x8a,
+	0x6a, 0xaf, 0xe2, 0x0c, 0x46, 0x57, 0xc5, 0x33, 0x58, 0x4c, 0xc0, 0xd2, 0x03, 0x81, 0xfe, 0x98,
+	0x84, 0xa4, 0x75, 0x93, 0xbd, 0x7a, 0x4c, 0x75, 0xe3, 0x66, 0xfa, 0x6c, 0x5d, 0xad, 0x66, 0xfa,
+	0x2c, 0x4c, 0xd7, 0xa6, 0x74, 0xd8, 0xbc, 0x4d, 0xda, 0xc3, 0xe6, 0x61, 0xfa, 0x2b, 0x26, 0x3e,
+	0xe8, 0x77, 0xb7, 0xf9, 0x7e, 0xd4, 0xf6, 0xa8, 0x48, 0x99, 0xbf, 0xaf, 0x91, 0xbf, 0x41, 0x63,
+	0x54, 0xe9, 0x81, 0xa8, 0xaf, 0x8b, 0xa2, 0xae, 0x66, 0xef, 0x12, 0x43, 0x90, 0xba, 0xba, 0xee,
+	0xaa, 0x2b, 0xc5, 0x41, 0x4f, 0xee, 0xaf, 0x34, 0x14, 0x6f, 0x11, 0x34, 0x0f, 0x93, 0xa2, 0x97,
+	0xea, 0x30, 0x44, 0x6f, 0xd0, 0xcd, 0xe4, 0x4b, 0x68, 0x9d, 0xee, 0x79, 0x88, 0x2e, 0xe3, 0xd7,
+	0x25, 0xb8, 0xbc, 0x83, 0xe2, 0xe1, 0xe0, 0x50, 0x90, 0x36, 0x3e, 0x62, 0xb2, 0xaf, 0x8d, 0xd9,
+	0x7f, 0x0b, 0x86, 0x1c, 0x42, 0x8d, 0x57, 0xdb, 0x68, 0xa6, 0xe3, 0x8d, 0x84, 0xac, 0x1d, 0xf4,
+	0x43, 0xb4, 0x15, 0x2a, 0xab, 0x7d, 0x22, 0xa7, 0xbd, 0x06, 0x13, 0xd4, 0x49, 0x76, 0xba, 0x09,
+	0xea, 0xe8, 0x1f, 0x8b, 0xd6, 0x0a, 0x18, 0x72, 0x00, 0xb3, 0x02, 0xc6, 0xd3, 0xfd, 0x7b, 0xf6,
+	0xdc, 0xff, 0xe4, 0xaf, 0xbd, 0x47, 0xdb, 0xf7, 0xe6, 0x4a, 0xd6, 0x73, 0xb8, 0x20, 0x27, 0xe5,
+	0x0f, 0xfb, 0x8f, 0xf6, 0xde, 0x75, 0x0f, 0x5e, 0x80, 0x49, 0xf5, 0xbf, 0x8d, 0x84, 0x5b, 0xfc,
+	0xb0, 0x79, 0xe7, 0x97, 0x8d, 0x2e, 0x15, 0x47, 0x51, 0x7b, 0xad, 0x13, 0x78, 0xeb, 0x47, 0xfd,
+	0x10, 0x99, 0xab, 0x7a, 0x86, 0x5b, 0x2e, 0x69, 0xf3, 0xf5, 0x80, 0xd1, 0xc0, 0xbf, 0xc5, 0x91,
+	0x9d, 0x20, 0x5b, 0x0f, 0x8f, 0xbb, 0xeb, 0x8a, 0x7b, 0x7b, 0x4a, 0xfd, 0x9b, 0xe3, 0xf6, 0xbf,
+	0x03, 0x00, 0xf7, 0xc2, 0x0b, 0x50, 0x2e, 0x19, 0x00, 0x00,
 }
@@ -20,8 +20,39 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type LeadershipTransfer_Status int32
+
+const (
+	LeadershipTransfer_IN_PROGRESS LeadershipTransfer_Status = 0
+	LeadershipTransfer_SUCCEEDED   LeadershipTransfer_Status = 1
+	LeadershipTransfer_FAILED      LeadershipTransfer_Status = 2
+)
+
+var LeadershipTransfer_Status_name = map[int32]string{
+	0: "IN_PROGRESS",
+	1: "SUCCEEDED",
+	2: "FAILED",
+}
+
+var LeadershipTransfer_Status_value = map[string]int32{
+	"IN_PROGRESS": 0,
+	"SUCCEEDED":   1,
+	"FAILED":      2,
+}
+
+func (x LeadershipTransfer_Status) String() string {
+	return proto.EnumName(LeadershipTransfer_Status_name, int32(x))
+}
+
+func (LeadershipTransfer_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{19, 0}
+}
+
 type ResponseHeader struct {
-	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The height of the world state a data query was served at. The data reflects at least all the blocks up to
+	// this height.
+	BlockHeight          uint64   `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -59,6 +90,13 @@ func (m *ResponseHeader) GetNodeId() string {
 	return ""
 }
 
+func (m *ResponseHeader) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
 // GetDBStatus
 type GetDBStatusResponseEnvelope struct {
 	Response             *GetDBStatusResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -915,10 +953,12 @@ type GetClusterStatusResponse struct {
 	// The leader ID, if it exists.
 	Leader string `protobuf:"bytes,4,opt,name=Leader,proto3" json:"Leader,omitempty"`
 	// The IDs of active nodes, including the leader.
-	Active               []string `protobuf:"bytes,5,rep,name=Active,proto3" json:"Active,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Active []string `protobuf:"bytes,5,rep,name=Active,proto3" json:"Active,omitempty"`
+	// The last leadership transfer requested on this node, if any.
+	LeadershipTransfer   *LeadershipTransfer `protobuf:"bytes,6,opt,name=leadership_transfer,json=leadershipTransfer,proto3" json:"leadership_transfer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *GetClusterStatusResponse) Reset()         { *m = GetClusterStatusResponse{} }
@@ -981,6 +1021,267 @@ func (m *GetClusterStatusResponse) GetActive() []string {
 	return nil
 }
 
+func (m *GetClusterStatusResponse) GetLeadershipTransfer() *LeadershipTransfer {
+	if m != nil {
+		return m.LeadershipTransfer
+	}
+	return nil
+}
+
+type LeadershipTransfer struct {
+	FromNodeId string                    `protobuf:"bytes,1,opt,name=from_node_id,json=fromNodeId,proto3" json:"from_node_id,omitempty"`
+	ToNodeId   string                    `protobuf:"bytes,2,opt,name=to_node_id,json=toNodeId,proto3" json:"to_node_id,omitempty"`
+	Status     LeadershipTransfer_Status `protobuf:"varint,3,opt,name=status,proto3,enum=types.LeadershipTransfer_Status" json:"status,omitempty"`
+	// The reason a transfer failed.
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeadershipTransfer) Reset()         { *m = LeadershipTransfer{} }
+func (m *LeadershipTransfer) String() string { return proto.CompactTextString(m) }
+func (*LeadershipTransfer) ProtoMessage()    {}
+func (*LeadershipTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{19}
+}
+
+func (m *LeadershipTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeadershipTransfer.Unmarshal(m, b)
+}
+func (m *LeadershipTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeadershipTransfer.Marshal(b, m, deterministic)
+}
+func (m *LeadershipTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeadershipTransfer.Merge(m, src)
+}
+func (m *LeadershipTransfer) XXX_Size() int {
+	return xxx_messageInfo_LeadershipTransfer.Size(m)
+}
+func (m *LeadershipTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeadershipTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeadershipTransfer proto.InternalMessageInfo
+
+func (m *LeadershipTransfer) GetFromNodeId() string {
+	if m != nil {
+		return m.FromNodeId
+	}
+	return ""
+}
+
+func (m *LeadershipTransfer) GetToNodeId() string {
+	if m != nil {
+		return m.ToNodeId
+	}
+	return ""
+}
+
+func (m *LeadershipTransfer) GetStatus() LeadershipTransfer_Status {
+	if m != nil {
+		return m.Status
+	}
+	return LeadershipTransfer_IN_PROGRESS
+}
+
+func (m *LeadershipTransfer) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// TransferLeadership
+type TransferLeadershipResponseEnvelope struct {
+	Response             *TransferLeadershipResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *TransferLeadershipResponseEnvelope) Reset()         { *m = TransferLeadershipResponseEnvelope{} }
+func (m *TransferLeadershipResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponseEnvelope) ProtoMessage()    {}
+func (*TransferLeadershipResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{20}
+}
+
+func (m *TransferLeadershipResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Unmarshal(m, b)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponseEnvelope.Merge(m, src)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Size(m)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipResponseEnvelope proto.InternalMessageInfo
+
+func (m *TransferLeadershipResponseEnvelope) GetResponse() *TransferLeadershipResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *TransferLeadershipResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type TransferLeadershipResponse struct {
+	Header               *ResponseHeader     `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	LeadershipTransfer   *LeadershipTransfer `protobuf:"bytes,2,opt,name=leadership_transfer,json=leadershipTransfer,proto3" json:"leadership_transfer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TransferLeadershipResponse) Reset()         { *m = TransferLeadershipResponse{} }
+func (m *TransferLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponse) ProtoMessage()    {}
+func (*TransferLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{21}
+}
+
+func (m *TransferLeadershipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipResponse.Unmarshal(m, b)
+}
+func (m *TransferLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipResponse.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponse.Merge(m, src)
+}
+func (m *TransferLeadershipResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipResponse.Size(m)
+}
+func (m *TransferLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipResponse proto.InternalMessageInfo
+
+func (m *TransferLeadershipResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *TransferLeadershipResponse) GetLeadershipTransfer() *LeadershipTransfer {
+	if m != nil {
+		return m.LeadershipTransfer
+	}
+	return nil
+}
+
+// SetNetworkFaults
+type SetNetworkFaultsResponseEnvelope struct {
+	Response             *SetNetworkFaultsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *SetNetworkFaultsResponseEnvelope) Reset()         { *m = SetNetworkFaultsResponseEnvelope{} }
+func (m *SetNetworkFaultsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SetNetworkFaultsResponseEnvelope) ProtoMessage()    {}
+func (*SetNetworkFaultsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{22}
+}
+
+func (m *SetNetworkFaultsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNetworkFaultsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *SetNetworkFaultsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNetworkFaultsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *SetNetworkFaultsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNetworkFaultsResponseEnvelope.Merge(m, src)
+}
+func (m *SetNetworkFaultsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SetNetworkFaultsResponseEnvelope.Size(m)
+}
+func (m *SetNetworkFaultsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNetworkFaultsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNetworkFaultsResponseEnvelope proto.InternalMessageInfo
+
+func (m *SetNetworkFaultsResponseEnvelope) GetResponse() *SetNetworkFaultsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SetNetworkFaultsResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type SetNetworkFaultsResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Faults               []*NetworkFault `protobuf:"bytes,2,rep,name=faults,proto3" json:"faults,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetNetworkFaultsResponse) Reset()         { *m = SetNetworkFaultsResponse{} }
+func (m *SetNetworkFaultsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetNetworkFaultsResponse) ProtoMessage()    {}
+func (*SetNetworkFaultsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{23}
+}
+
+func (m *SetNetworkFaultsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNetworkFaultsResponse.Unmarshal(m, b)
+}
+func (m *SetNetworkFaultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNetworkFaultsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetNetworkFaultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNetworkFaultsResponse.Merge(m, src)
+}
+func (m *SetNetworkFaultsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetNetworkFaultsResponse.Size(m)
+}
+func (m *SetNetworkFaultsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNetworkFaultsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNetworkFaultsResponse proto.InternalMessageInfo
+
+func (m *SetNetworkFaultsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetNetworkFaultsResponse) GetFaults() []*NetworkFault {
+	if m != nil {
+		return m.Faults
+	}
+	return nil
+}
+
 // GetBlock
 type GetBlockResponseEnvelope struct {
 	Response             *GetBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -994,7 +1295,7 @@ func (m *GetBlockResponseEnvelope) Reset()         { *m = GetBlockResponseEnvelo
 func (m *GetBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetBlockResponseEnvelope) ProtoMessage()    {}
 func (*GetBlockResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{19}
+	return fileDescriptor_0fbc901015fa5021, []int{24}
 }
 
 func (m *GetBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1030,18 +1331,20 @@ func (m *GetBlockResponseEnvelope) GetSignature() []byte {
 }
 
 type GetBlockResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	BlockHeader          *BlockHeader    `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Header      *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockHeader *BlockHeader    `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	// The quorum certificate of the block, if the consensus members have already attested it
+	QuorumCertificate    *QuorumCertificate `protobuf:"bytes,3,opt,name=quorum_certificate,json=quorumCertificate,proto3" json:"quorum_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
 func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockResponse) ProtoMessage()    {}
 func (*GetBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{20}
+	return fileDescriptor_0fbc901015fa5021, []int{25}
 }
 
 func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
@@ -1076,6 +1379,13 @@ func (m *GetBlockResponse) GetBlockHeader() *BlockHeader {
 	return nil
 }
 
+func (m *GetBlockResponse) GetQuorumCertificate() *QuorumCertificate {
+	if m != nil {
+		return m.QuorumCertificate
+	}
+	return nil
+}
+
 // GetAugmentedBlockHeader
 type GetAugmentedBlockHeaderResponseEnvelope struct {
 	Response             *GetAugmentedBlockHeaderResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1091,7 +1401,7 @@ func (m *GetAugmentedBlockHeaderResponseEnvelope) Reset() {
 func (m *GetAugmentedBlockHeaderResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetAugmentedBlockHeaderResponseEnvelope) ProtoMessage()    {}
 func (*GetAugmentedBlockHeaderResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{21}
+	return fileDescriptor_0fbc901015fa5021, []int{26}
 }
 
 func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1127,18 +1437,20 @@ func (m *GetAugmentedBlockHeaderResponseEnvelope) GetSignature() []byte {
 }
 
 type GetAugmentedBlockHeaderResponse struct {
-	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	BlockHeader          *AugmentedBlockHeader `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Header      *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockHeader *AugmentedBlockHeader `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	// The quorum certificate of the block, if the consensus members have already attested it
+	QuorumCertificate    *QuorumCertificate `protobuf:"bytes,3,opt,name=quorum_certificate,json=quorumCertificate,proto3" json:"quorum_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *GetAugmentedBlockHeaderResponse) Reset()         { *m = GetAugmentedBlockHeaderResponse{} }
 func (m *GetAugmentedBlockHeaderResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAugmentedBlockHeaderResponse) ProtoMessage()    {}
 func (*GetAugmentedBlockHeaderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{22}
+	return fileDescriptor_0fbc901015fa5021, []int{27}
 }
 
 func (m *GetAugmentedBlockHeaderResponse) XXX_Unmarshal(b []byte) error {
@@ -1173,6 +1485,13 @@ func (m *GetAugmentedBlockHeaderResponse) GetBlockHeader() *AugmentedBlockHeader
 	return nil
 }
 
+func (m *GetAugmentedBlockHeaderResponse) GetQuorumCertificate() *QuorumCertificate {
+	if m != nil {
+		return m.QuorumCertificate
+	}
+	return nil
+}
+
 // GetLedgerPath
 type GetLedgerPathResponseEnvelope struct {
 	Response             *GetLedgerPathResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1186,7 +1505,7 @@ func (m *GetLedgerPathResponseEnvelope) Reset()         { *m = GetLedgerPathResp
 func (m *GetLedgerPathResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathResponseEnvelope) ProtoMessage()    {}
 func (*GetLedgerPathResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{23}
+	return fileDescriptor_0fbc901015fa5021, []int{28}
 }
 
 func (m *GetLedgerPathResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1233,7 +1552,7 @@ func (m *GetLedgerPathResponse) Reset()         { *m = GetLedgerPathResponse{} }
 func (m *GetLedgerPathResponse) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathResponse) ProtoMessage()    {}
 func (*GetLedgerPathResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{24}
+	return fileDescriptor_0fbc901015fa5021, []int{29}
 }
 
 func (m *GetLedgerPathResponse) XXX_Unmarshal(b []byte) error {
@@ -1268,6 +1587,107 @@ func (m *GetLedgerPathResponse) GetBlockHeaders() []*BlockHeader {
 	return nil
 }
 
+// GetLedgerConsistencyProof
+type GetLedgerConsistencyProofResponseEnvelope struct {
+	Response             *GetLedgerConsistencyProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *GetLedgerConsistencyProofResponseEnvelope) Reset() {
+	*m = GetLedgerConsistencyProofResponseEnvelope{}
+}
+func (m *GetLedgerConsistencyProofResponseEnvelope) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetLedgerConsistencyProofResponseEnvelope) ProtoMessage() {}
+func (*GetLedgerConsistencyProofResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{30}
+}
+
+func (m *GetLedgerConsistencyProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetLedgerConsistencyProofResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerConsistencyProofResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope.Merge(m, src)
+}
+func (m *GetLedgerConsistencyProofResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope.Size(m)
+}
+func (m *GetLedgerConsistencyProofResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerConsistencyProofResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetLedgerConsistencyProofResponseEnvelope) GetResponse() *GetLedgerConsistencyProofResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetLedgerConsistencyProofResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetLedgerConsistencyProofResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The headers of the blocks in the skip list path from the end block down to the start block, both excluded, in
+	// descending order. The client holds the headers of the start and end blocks.
+	BlockHeaders         []*BlockHeader `protobuf:"bytes,2,rep,name=block_headers,json=blockHeaders,proto3" json:"block_headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetLedgerConsistencyProofResponse) Reset()         { *m = GetLedgerConsistencyProofResponse{} }
+func (m *GetLedgerConsistencyProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLedgerConsistencyProofResponse) ProtoMessage()    {}
+func (*GetLedgerConsistencyProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{31}
+}
+
+func (m *GetLedgerConsistencyProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponse.Unmarshal(m, b)
+}
+func (m *GetLedgerConsistencyProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerConsistencyProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerConsistencyProofResponse.Merge(m, src)
+}
+func (m *GetLedgerConsistencyProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerConsistencyProofResponse.Size(m)
+}
+func (m *GetLedgerConsistencyProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerConsistencyProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerConsistencyProofResponse proto.InternalMessageInfo
+
+func (m *GetLedgerConsistencyProofResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetLedgerConsistencyProofResponse) GetBlockHeaders() []*BlockHeader {
+	if m != nil {
+		return m.BlockHeaders
+	}
+	return nil
+}
+
 // GetTxProof
 type GetTxProofResponseEnvelope struct {
 	Response             *GetTxProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1281,7 +1701,7 @@ func (m *GetTxProofResponseEnvelope) Reset()         { *m = GetTxProofResponseEn
 func (m *GetTxProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofResponseEnvelope) ProtoMessage()    {}
 func (*GetTxProofResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{25}
+	return fileDescriptor_0fbc901015fa5021, []int{32}
 }
 
 func (m *GetTxProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1328,7 +1748,7 @@ func (m *GetTxProofResponse) Reset()         { *m = GetTxProofResponse{} }
 func (m *GetTxProofResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofResponse) ProtoMessage()    {}
 func (*GetTxProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{26}
+	return fileDescriptor_0fbc901015fa5021, []int{33}
 }
 
 func (m *GetTxProofResponse) XXX_Unmarshal(b []byte) error {
@@ -1363,6 +1783,104 @@ func (m *GetTxProofResponse) GetHashes() [][]byte {
 	return nil
 }
 
+// GetTxProofs
+type GetTxProofsResponseEnvelope struct {
+	Response             *GetTxProofsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetTxProofsResponseEnvelope) Reset()         { *m = GetTxProofsResponseEnvelope{} }
+func (m *GetTxProofsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofsResponseEnvelope) ProtoMessage()    {}
+func (*GetTxProofsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{34}
+}
+
+func (m *GetTxProofsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxProofsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofsResponseEnvelope.Merge(m, src)
+}
+func (m *GetTxProofsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofsResponseEnvelope.Size(m)
+}
+func (m *GetTxProofsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofsResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetTxProofsResponseEnvelope) GetResponse() *GetTxProofsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetTxProofsResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetTxProofsResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The intermediate hashes of the Merkle tree of the transactions of the block, each once, ordered level by level,
+	// from the leaves up to the root, and from left to right in each level. The hashes computed from the proven
+	// transactions are not part of it.
+	Hashes               [][]byte `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxProofsResponse) Reset()         { *m = GetTxProofsResponse{} }
+func (m *GetTxProofsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofsResponse) ProtoMessage()    {}
+func (*GetTxProofsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{35}
+}
+
+func (m *GetTxProofsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofsResponse.Unmarshal(m, b)
+}
+func (m *GetTxProofsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofsResponse.Merge(m, src)
+}
+func (m *GetTxProofsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofsResponse.Size(m)
+}
+func (m *GetTxProofsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofsResponse proto.InternalMessageInfo
+
+func (m *GetTxProofsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetTxProofsResponse) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
 // GetDataProof
 type GetDataProofResponseEnvelope struct {
 	Response             *GetDataProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1376,7 +1894,7 @@ func (m *GetDataProofResponseEnvelope) Reset()         { *m = GetDataProofRespon
 func (m *GetDataProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofResponseEnvelope) ProtoMessage()    {}
 func (*GetDataProofResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{27}
+	return fileDescriptor_0fbc901015fa5021, []int{36}
 }
 
 func (m *GetDataProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1412,18 +1930,20 @@ func (m *GetDataProofResponseEnvelope) GetSignature() []byte {
 }
 
 type GetDataProofResponse struct {
-	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Path                 []*MPTrieProofElement `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Header *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Path   []*MPTrieProofElement `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
+	// Set instead of the path in response to a query for the proof that the key was never written.
+	AbsenceProof         *MPTrieAbsenceProof `protobuf:"bytes,3,opt,name=absence_proof,json=absenceProof,proto3" json:"absence_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *GetDataProofResponse) Reset()         { *m = GetDataProofResponse{} }
 func (m *GetDataProofResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofResponse) ProtoMessage()    {}
 func (*GetDataProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{28}
+	return fileDescriptor_0fbc901015fa5021, []int{37}
 }
 
 func (m *GetDataProofResponse) XXX_Unmarshal(b []byte) error {
@@ -1458,6 +1978,13 @@ func (m *GetDataProofResponse) GetPath() []*MPTrieProofElement {
 	return nil
 }
 
+func (m *GetDataProofResponse) GetAbsenceProof() *MPTrieAbsenceProof {
+	if m != nil {
+		return m.AbsenceProof
+	}
+	return nil
+}
+
 type MPTrieProofElement struct {
 	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1469,7 +1996,7 @@ func (m *MPTrieProofElement) Reset()         { *m = MPTrieProofElement{} }
 func (m *MPTrieProofElement) String() string { return proto.CompactTextString(m) }
 func (*MPTrieProofElement) ProtoMessage()    {}
 func (*MPTrieProofElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{29}
+	return fileDescriptor_0fbc901015fa5021, []int{38}
 }
 
 func (m *MPTrieProofElement) XXX_Unmarshal(b []byte) error {
@@ -1497,6 +2024,260 @@ func (m *MPTrieProofElement) GetHashes() [][]byte {
 	return nil
 }
 
+// MPTrieAbsenceProof proves that a key was never written in the state trie. The path goes from the root down to the
+// node at which the path of the key ends: a branch node without a child at the next nibble of the key, an extension
+// node whose key diverges from the key, or a value node holding another key. As the hash of a branch node does not
+// commit to the positions of its children, the witnesses prove the position of every child of such a branch node, or
+// the key of such an extension or value node, by a path down to a value node and the hash of its value.
+type MPTrieAbsenceProof struct {
+	Path                 []*MPTrieProofElement `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	Witnesses            []*MPTrieWitness      `protobuf:"bytes,2,rep,name=witnesses,proto3" json:"witnesses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MPTrieAbsenceProof) Reset()         { *m = MPTrieAbsenceProof{} }
+func (m *MPTrieAbsenceProof) String() string { return proto.CompactTextString(m) }
+func (*MPTrieAbsenceProof) ProtoMessage()    {}
+func (*MPTrieAbsenceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{39}
+}
+
+func (m *MPTrieAbsenceProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MPTrieAbsenceProof.Unmarshal(m, b)
+}
+func (m *MPTrieAbsenceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MPTrieAbsenceProof.Marshal(b, m, deterministic)
+}
+func (m *MPTrieAbsenceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MPTrieAbsenceProof.Merge(m, src)
+}
+func (m *MPTrieAbsenceProof) XXX_Size() int {
+	return xxx_messageInfo_MPTrieAbsenceProof.Size(m)
+}
+func (m *MPTrieAbsenceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_MPTrieAbsenceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MPTrieAbsenceProof proto.InternalMessageInfo
+
+func (m *MPTrieAbsenceProof) GetPath() []*MPTrieProofElement {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *MPTrieAbsenceProof) GetWitnesses() []*MPTrieWitness {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
+}
+
+// MPTrieWitness is a path from a node down to a value node, along with the hash of the value of that node.
+type MPTrieWitness struct {
+	// nibble is the position of the child where the path starts, when the proof ends at a branch node.
+	Nibble               uint32                `protobuf:"varint,1,opt,name=nibble,proto3" json:"nibble,omitempty"`
+	Path                 []*MPTrieProofElement `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
+	ValueHash            []byte                `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MPTrieWitness) Reset()         { *m = MPTrieWitness{} }
+func (m *MPTrieWitness) String() string { return proto.CompactTextString(m) }
+func (*MPTrieWitness) ProtoMessage()    {}
+func (*MPTrieWitness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{40}
+}
+
+func (m *MPTrieWitness) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MPTrieWitness.Unmarshal(m, b)
+}
+func (m *MPTrieWitness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MPTrieWitness.Marshal(b, m, deterministic)
+}
+func (m *MPTrieWitness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MPTrieWitness.Merge(m, src)
+}
+func (m *MPTrieWitness) XXX_Size() int {
+	return xxx_messageInfo_MPTrieWitness.Size(m)
+}
+func (m *MPTrieWitness) XXX_DiscardUnknown() {
+	xxx_messageInfo_MPTrieWitness.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MPTrieWitness proto.InternalMessageInfo
+
+func (m *MPTrieWitness) GetNibble() uint32 {
+	if m != nil {
+		return m.Nibble
+	}
+	return 0
+}
+
+func (m *MPTrieWitness) GetPath() []*MPTrieProofElement {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *MPTrieWitness) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+// GetDataProofs
+type GetDataProofsResponseEnvelope struct {
+	Response             *GetDataProofsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetDataProofsResponseEnvelope) Reset()         { *m = GetDataProofsResponseEnvelope{} }
+func (m *GetDataProofsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofsResponseEnvelope) ProtoMessage()    {}
+func (*GetDataProofsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{41}
+}
+
+func (m *GetDataProofsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataProofsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofsResponseEnvelope.Merge(m, src)
+}
+func (m *GetDataProofsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofsResponseEnvelope.Size(m)
+}
+func (m *GetDataProofsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofsResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDataProofsResponseEnvelope) GetResponse() *GetDataProofsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDataProofsResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataProofsResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Proof                *MPTrieMultiProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataProofsResponse) Reset()         { *m = GetDataProofsResponse{} }
+func (m *GetDataProofsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofsResponse) ProtoMessage()    {}
+func (*GetDataProofsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{42}
+}
+
+func (m *GetDataProofsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofsResponse.Unmarshal(m, b)
+}
+func (m *GetDataProofsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofsResponse.Merge(m, src)
+}
+func (m *GetDataProofsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofsResponse.Size(m)
+}
+func (m *GetDataProofsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofsResponse proto.InternalMessageInfo
+
+func (m *GetDataProofsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDataProofsResponse) GetProof() *MPTrieMultiProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+// MPTrieMultiProof proves the state of several keys in the state trie. It holds, once each, the nodes of the paths of
+// all the keys from the root, along with the nodes of the witnesses of the keys that were never written, as held by
+// MPTrieAbsenceProof. The value hashes are the hashes of the values of the value nodes ending the witnesses.
+type MPTrieMultiProof struct {
+	Nodes                []*MPTrieProofElement `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	ValueHashes          [][]byte              `protobuf:"bytes,2,rep,name=value_hashes,json=valueHashes,proto3" json:"value_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MPTrieMultiProof) Reset()         { *m = MPTrieMultiProof{} }
+func (m *MPTrieMultiProof) String() string { return proto.CompactTextString(m) }
+func (*MPTrieMultiProof) ProtoMessage()    {}
+func (*MPTrieMultiProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{43}
+}
+
+func (m *MPTrieMultiProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MPTrieMultiProof.Unmarshal(m, b)
+}
+func (m *MPTrieMultiProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MPTrieMultiProof.Marshal(b, m, deterministic)
+}
+func (m *MPTrieMultiProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MPTrieMultiProof.Merge(m, src)
+}
+func (m *MPTrieMultiProof) XXX_Size() int {
+	return xxx_messageInfo_MPTrieMultiProof.Size(m)
+}
+func (m *MPTrieMultiProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_MPTrieMultiProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MPTrieMultiProof proto.InternalMessageInfo
+
+func (m *MPTrieMultiProof) GetNodes() []*MPTrieProofElement {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *MPTrieMultiProof) GetValueHashes() [][]byte {
+	if m != nil {
+		return m.ValueHashes
+	}
+	return nil
+}
+
 // GetHistoricalData
 type GetHistoricalDataResponseEnvelope struct {
 	Response             *GetHistoricalDataResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1510,7 +2291,7 @@ func (m *GetHistoricalDataResponseEnvelope) Reset()         { *m = GetHistorical
 func (m *GetHistoricalDataResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataResponseEnvelope) ProtoMessage()    {}
 func (*GetHistoricalDataResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{30}
+	return fileDescriptor_0fbc901015fa5021, []int{44}
 }
 
 func (m *GetHistoricalDataResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1557,7 +2338,7 @@ func (m *GetHistoricalDataResponse) Reset()         { *m = GetHistoricalDataResp
 func (m *GetHistoricalDataResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataResponse) ProtoMessage()    {}
 func (*GetHistoricalDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{31}
+	return fileDescriptor_0fbc901015fa5021, []int{45}
 }
 
 func (m *GetHistoricalDataResponse) XXX_Unmarshal(b []byte) error {
@@ -1605,7 +2386,7 @@ func (m *GetDataReadersResponseEnvelope) Reset()         { *m = GetDataReadersRe
 func (m *GetDataReadersResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersResponseEnvelope) ProtoMessage()    {}
 func (*GetDataReadersResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{32}
+	return fileDescriptor_0fbc901015fa5021, []int{46}
 }
 
 func (m *GetDataReadersResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1652,7 +2433,7 @@ func (m *GetDataReadersResponse) Reset()         { *m = GetDataReadersResponse{}
 func (m *GetDataReadersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersResponse) ProtoMessage()    {}
 func (*GetDataReadersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{33}
+	return fileDescriptor_0fbc901015fa5021, []int{47}
 }
 
 func (m *GetDataReadersResponse) XXX_Unmarshal(b []byte) error {
@@ -1700,7 +2481,7 @@ func (m *GetDataWritersResponseEnvelope) Reset()         { *m = GetDataWritersRe
 func (m *GetDataWritersResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersResponseEnvelope) ProtoMessage()    {}
 func (*GetDataWritersResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{34}
+	return fileDescriptor_0fbc901015fa5021, []int{48}
 }
 
 func (m *GetDataWritersResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1747,7 +2528,7 @@ func (m *GetDataWritersResponse) Reset()         { *m = GetDataWritersResponse{}
 func (m *GetDataWritersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersResponse) ProtoMessage()    {}
 func (*GetDataWritersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{35}
+	return fileDescriptor_0fbc901015fa5021, []int{49}
 }
 
 func (m *GetDataWritersResponse) XXX_Unmarshal(b []byte) error {
@@ -1795,7 +2576,7 @@ func (m *GetDataProvenanceResponseEnvelope) Reset()         { *m = GetDataProven
 func (m *GetDataProvenanceResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataProvenanceResponseEnvelope) ProtoMessage()    {}
 func (*GetDataProvenanceResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{36}
+	return fileDescriptor_0fbc901015fa5021, []int{50}
 }
 
 func (m *GetDataProvenanceResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1841,7 +2622,7 @@ func (m *KVsWithMetadata) Reset()         { *m = KVsWithMetadata{} }
 func (m *KVsWithMetadata) String() string { return proto.CompactTextString(m) }
 func (*KVsWithMetadata) ProtoMessage()    {}
 func (*KVsWithMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{37}
+	return fileDescriptor_0fbc901015fa5021, []int{51}
 }
 
 func (m *KVsWithMetadata) XXX_Unmarshal(b []byte) error {
@@ -1881,7 +2662,7 @@ func (m *GetDataProvenanceResponse) Reset()         { *m = GetDataProvenanceResp
 func (m *GetDataProvenanceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDataProvenanceResponse) ProtoMessage()    {}
 func (*GetDataProvenanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{38}
+	return fileDescriptor_0fbc901015fa5021, []int{52}
 }
 
 func (m *GetDataProvenanceResponse) XXX_Unmarshal(b []byte) error {
@@ -1929,7 +2710,7 @@ func (m *GetTxIDsSubmittedByResponseEnvelope) Reset()         { *m = GetTxIDsSub
 func (m *GetTxIDsSubmittedByResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxIDsSubmittedByResponseEnvelope) ProtoMessage()    {}
 func (*GetTxIDsSubmittedByResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{39}
+	return fileDescriptor_0fbc901015fa5021, []int{53}
 }
 
 func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1976,7 +2757,7 @@ func (m *GetTxIDsSubmittedByResponse) Reset()         { *m = GetTxIDsSubmittedBy
 func (m *GetTxIDsSubmittedByResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTxIDsSubmittedByResponse) ProtoMessage()    {}
 func (*GetTxIDsSubmittedByResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{40}
+	return fileDescriptor_0fbc901015fa5021, []int{54}
 }
 
 func (m *GetTxIDsSubmittedByResponse) XXX_Unmarshal(b []byte) error {
@@ -2023,7 +2804,7 @@ func (m *TxReceiptResponseEnvelope) Reset()         { *m = TxReceiptResponseEnve
 func (m *TxReceiptResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*TxReceiptResponseEnvelope) ProtoMessage()    {}
 func (*TxReceiptResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{41}
+	return fileDescriptor_0fbc901015fa5021, []int{55}
 }
 
 func (m *TxReceiptResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2070,7 +2851,7 @@ func (m *TxReceiptResponse) Reset()         { *m = TxReceiptResponse{} }
 func (m *TxReceiptResponse) String() string { return proto.CompactTextString(m) }
 func (*TxReceiptResponse) ProtoMessage()    {}
 func (*TxReceiptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{42}
+	return fileDescriptor_0fbc901015fa5021, []int{56}
 }
 
 func (m *TxReceiptResponse) XXX_Unmarshal(b []byte) error {
@@ -2117,7 +2898,7 @@ func (m *DataQueryResponseEnvelope) Reset()         { *m = DataQueryResponseEnve
 func (m *DataQueryResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DataQueryResponseEnvelope) ProtoMessage()    {}
 func (*DataQueryResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{43}
+	return fileDescriptor_0fbc901015fa5021, []int{57}
 }
 
 func (m *DataQueryResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2164,7 +2945,7 @@ func (m *DataQueryResponse) Reset()         { *m = DataQueryResponse{} }
 func (m *DataQueryResponse) String() string { return proto.CompactTextString(m) }
 func (*DataQueryResponse) ProtoMessage()    {}
 func (*DataQueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{44}
+	return fileDescriptor_0fbc901015fa5021, []int{58}
 }
 
 func (m *DataQueryResponse) XXX_Unmarshal(b []byte) error {
@@ -2200,6 +2981,7 @@ func (m *DataQueryResponse) GetKVs() []*KVWithMetadata {
 }
 
 func init() {
+	proto.RegisterEnum("types.LeadershipTransfer_Status", LeadershipTransfer_Status_name, LeadershipTransfer_Status_value)
 	proto.RegisterType((*ResponseHeader)(nil), "types.ResponseHeader")
 	proto.RegisterType((*GetDBStatusResponseEnvelope)(nil), "types.GetDBStatusResponseEnvelope")
 	proto.RegisterType((*GetDBStatusResponse)(nil), "types.GetDBStatusResponse")
@@ -2219,17 +3001,31 @@ func init() {
 	proto.RegisterType((*GetConfigBlockResponse)(nil), "types.GetConfigBlockResponse")
 	proto.RegisterType((*GetClusterStatusResponseEnvelope)(nil), "types.GetClusterStatusResponseEnvelope")
 	proto.RegisterType((*GetClusterStatusResponse)(nil), "types.GetClusterStatusResponse")
+	proto.RegisterType((*LeadershipTransfer)(nil), "types.LeadershipTransfer")
+	proto.RegisterType((*TransferLeadershipResponseEnvelope)(nil), "types.TransferLeadershipResponseEnvelope")
+	proto.RegisterType((*TransferLeadershipResponse)(nil), "types.TransferLeadershipResponse")
+	proto.RegisterType((*SetNetworkFaultsResponseEnvelope)(nil), "types.SetNetworkFaultsResponseEnvelope")
+	proto.RegisterType((*SetNetworkFaultsResponse)(nil), "types.SetNetworkFaultsResponse")
 	proto.RegisterType((*GetBlockResponseEnvelope)(nil), "types.GetBlockResponseEnvelope")
 	proto.RegisterType((*GetBlockResponse)(nil), "types.GetBlockResponse")
 	proto.RegisterType((*GetAugmentedBlockHeaderResponseEnvelope)(nil), "types.GetAugmentedBlockHeaderResponseEnvelope")
 	proto.RegisterType((*GetAugmentedBlockHeaderResponse)(nil), "types.GetAugmentedBlockHeaderResponse")
 	proto.RegisterType((*GetLedgerPathResponseEnvelope)(nil), "types.GetLedgerPathResponseEnvelope")
 	proto.RegisterType((*GetLedgerPathResponse)(nil), "types.GetLedgerPathResponse")
+	proto.RegisterType((*GetLedgerConsistencyProofResponseEnvelope)(nil), "types.GetLedgerConsistencyProofResponseEnvelope")
+	proto.RegisterType((*GetLedgerConsistencyProofResponse)(nil), "types.GetLedgerConsistencyProofResponse")
 	proto.RegisterType((*GetTxProofResponseEnvelope)(nil), "types.GetTxProofResponseEnvelope")
 	proto.RegisterType((*GetTxProofResponse)(nil), "types.GetTxProofResponse")
+	proto.RegisterType((*GetTxProofsResponseEnvelope)(nil), "types.GetTxProofsResponseEnvelope")
+	proto.RegisterType((*GetTxProofsResponse)(nil), "types.GetTxProofsResponse")
 	proto.RegisterType((*GetDataProofResponseEnvelope)(nil), "types.GetDataProofResponseEnvelope")
 	proto.RegisterType((*GetDataProofResponse)(nil), "types.GetDataProofResponse")
 	proto.RegisterType((*MPTrieProofElement)(nil), "types.MPTrieProofElement")
+	proto.RegisterType((*MPTrieAbsenceProof)(nil), "types.MPTrieAbsenceProof")
+	proto.RegisterType((*MPTrieWitness)(nil), "types.MPTrieWitness")
+	proto.RegisterType((*GetDataProofsResponseEnvelope)(nil), "types.GetDataProofsResponseEnvelope")
+	proto.RegisterType((*GetDataProofsResponse)(nil), "types.GetDataProofsResponse")
+	proto.RegisterType((*MPTrieMultiProof)(nil), "types.MPTrieMultiProof")
 	proto.RegisterType((*GetHistoricalDataResponseEnvelope)(nil), "types.GetHistoricalDataResponseEnvelope")
 	proto.RegisterType((*GetHistoricalDataResponse)(nil), "types.GetHistoricalDataResponse")
 	proto.RegisterType((*GetDataReadersResponseEnvelope)(nil), "types.GetDataReadersResponseEnvelope")
@@ -2253,88 +3049,118 @@ func init() {
 func init() { proto.RegisterFile("response.proto", fileDescriptor_0fbc901015fa5021) }
 
 var fileDescriptor_0fbc901015fa5021 = []byte{
-	// 1315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x85, 0x9a, 0xc6, 0x4d, 0xae, 0x53, 0x37, 0x51, 0xdb, 0xd4, 0x75, 0xd2, 0xc5, 0xd3, 0x3e,
-	0x9a, 0x6e, 0x89, 0xb3, 0xa5, 0xed, 0xfa, 0xb1, 0xa2, 0x40, 0xdd, 0x04, 0x69, 0xe0, 0x76, 0xc8,
-	0x94, 0xcc, 0xc1, 0x3a, 0x0c, 0x86, 0x6c, 0xdd, 0xda, 0x42, 0x1c, 0xc9, 0xa3, 0x28, 0xc7, 0x1e,
-	0x36, 0xf4, 0xa1, 0x8f, 0x03, 0x86, 0xfd, 0x81, 0xfd, 0x92, 0xbd, 0xef, 0x69, 0x4f, 0xfb, 0x33,
-	0x7b, 0x1d, 0x44, 0x51, 0x36, 0x6d, 0x2a, 0x89, 0xe8, 0x61, 0x6f, 0x26, 0x75, 0xcf, 0x31, 0xcf,
-	0xb9, 0x97, 0xd4, 0xa5, 0x20, 0x47, 0xd0, 0xef, 0x78, 0xae, 0x8f, 0xa5, 0x0e, 0xf1, 0xa8, 0xa7,
-	0x4f, 0xd3, 0x7e, 0x07, 0xfd, 0xc2, 0xd5, 0x86, 0xe7, 0xbe, 0x71, 0x9a, 0x01, 0xb1, 0xa8, 0xe3,
-	0xb9, 0xd1, 0xb3, 0xc2, 0x52, 0xbd, 0xed, 0x35, 0x8e, 0x6a, 0x96, 0x6b, 0xd7, 0x28, 0xb1, 0x5c,
-	0xdf, 0x6a, 0x0c, 0x1f, 0x1a, 0x77, 0x20, 0x67, 0x72, 0xaa, 0x17, 0x68, 0xd9, 0x48, 0xf4, 0x1b,
-	0x70, 0xc9, 0xf5, 0x6c, 0xac, 0x39, 0x76, 0x5e, 0x2b, 0x6a, 0xab, 0xb3, 0x66, 0x26, 0x1c, 0xee,
-	0xda, 0x86, 0x0f, 0x4b, 0x3b, 0x48, 0xb7, 0xca, 0xfb, 0xd4, 0xa2, 0x81, 0x1f, 0xa3, 0xb6, 0xdd,
-	0x2e, 0xb6, 0xbd, 0x0e, 0xea, 0x5f, 0xc0, 0x4c, 0xbc, 0x28, 0x06, 0xcc, 0x6e, 0x16, 0x4a, 0x6c,
-	0x55, 0xa5, 0x04, 0x94, 0x39, 0x88, 0xd5, 0x97, 0x61, 0xd6, 0x77, 0x9a, 0xae, 0x45, 0x03, 0x82,
-	0xf9, 0x0b, 0x45, 0x6d, 0x75, 0xce, 0x1c, 0x4e, 0x18, 0xaf, 0xe1, 0x6a, 0x02, 0x5c, 0x5f, 0x87,
-	0x4c, 0x8b, 0x2d, 0x97, 0xff, 0xd5, 0x75, 0xfe, 0x57, 0xa3, 0x5a, 0x4c, 0x1e, 0xa4, 0x5f, 0x83,
-	0x69, 0xec, 0x39, 0x3e, 0x65, 0xfc, 0x33, 0x66, 0x34, 0x30, 0x7e, 0x80, 0x02, 0xe3, 0xde, 0x75,
-	0x6d, 0xec, 0x49, 0x7a, 0xee, 0x4b, 0x7a, 0x6e, 0x8a, 0x7a, 0x46, 0x40, 0xa9, 0xe5, 0x7c, 0x0b,
-	0xba, 0x8c, 0x9e, 0x40, 0x8d, 0x13, 0xe2, 0x19, 0xfd, 0xac, 0x19, 0x0d, 0x8c, 0x23, 0xb8, 0x11,
-	0x52, 0x5b, 0xd4, 0x92, 0xa4, 0x6c, 0x4a, 0x52, 0x16, 0x05, 0x29, 0x02, 0x22, 0xb5, 0x8e, 0x77,
-	0x1a, 0x5c, 0x19, 0xc3, 0x4e, 0xa0, 0xa2, 0x6b, 0xb5, 0x83, 0x98, 0x3c, 0x1a, 0xe8, 0x9f, 0xc2,
-	0xcc, 0x31, 0x52, 0xcb, 0xb6, 0xa8, 0x95, 0x9f, 0x62, 0x34, 0x57, 0x38, 0xcd, 0x2b, 0x3e, 0x6d,
-	0x0e, 0x02, 0x8c, 0x00, 0x96, 0xe3, 0x45, 0x58, 0x6e, 0x13, 0x25, 0xdd, 0x0f, 0x24, 0xdd, 0x4b,
-	0x63, 0xba, 0x45, 0x58, 0x6a, 0xf1, 0x7f, 0x68, 0x70, 0x2d, 0x89, 0x40, 0xd5, 0x81, 0xdb, 0x30,
-	0x55, 0xa9, 0xfa, 0xf9, 0x0b, 0xc5, 0x29, 0x21, 0xb6, 0x52, 0x3d, 0x74, 0x68, 0x6b, 0x20, 0x36,
-	0x8c, 0xd0, 0x3f, 0x82, 0x5c, 0x07, 0x5d, 0xdb, 0x71, 0x9b, 0x35, 0x82, 0x7e, 0xd0, 0xa6, 0xcc,
-	0x9a, 0x19, 0xf3, 0x32, 0x9f, 0x35, 0xd9, 0xa4, 0xfe, 0x21, 0xe4, 0x5c, 0xec, 0xd1, 0x9a, 0x4f,
-	0x2d, 0x42, 0x6b, 0x47, 0xd8, 0xcf, 0x5f, 0x64, 0x05, 0x32, 0x17, 0xce, 0xee, 0x87, 0x93, 0x15,
-	0xec, 0xf3, 0x3a, 0xf9, 0xc6, 0x47, 0xa2, 0x56, 0x27, 0x22, 0x22, 0xb5, 0x55, 0xbf, 0x46, 0x75,
-	0x22, 0x62, 0x55, 0x5d, 0x5a, 0x81, 0x8b, 0x81, 0x8f, 0x84, 0x71, 0x67, 0x37, 0xb3, 0x3c, 0x98,
-	0x31, 0xb2, 0x07, 0x6a, 0x25, 0xe3, 0xc1, 0xcd, 0x1d, 0xa4, 0xcf, 0xd9, 0x31, 0x29, 0xe9, 0xbf,
-	0x27, 0xe9, 0xcf, 0x0f, 0xf5, 0x8f, 0x62, 0x52, 0x3b, 0xf0, 0xbb, 0x06, 0x0b, 0x12, 0x5a, 0xd5,
-	0x83, 0x35, 0xc8, 0x44, 0x27, 0x3b, 0x77, 0xe1, 0x1a, 0x0f, 0x7f, 0xde, 0x0e, 0x7c, 0x8a, 0x84,
-	0x93, 0xf3, 0x18, 0x35, 0x43, 0x4e, 0xe0, 0xd6, 0x0e, 0xd2, 0xaf, 0x3c, 0x1b, 0x4f, 0x31, 0xe5,
-	0xa1, 0x64, 0xca, 0xf2, 0xd0, 0x14, 0x19, 0x97, 0xda, 0x98, 0x1f, 0xe1, 0x7a, 0x22, 0x81, 0xaa,
-	0x37, 0x9b, 0x90, 0x65, 0xef, 0xab, 0x11, 0x83, 0x16, 0x38, 0x46, 0xa0, 0x07, 0x77, 0xf0, 0xdb,
-	0xe8, 0xc3, 0x7b, 0x83, 0x9c, 0x94, 0xc3, 0xb7, 0xa3, 0xa4, 0xfa, 0x91, 0xa4, 0xfa, 0xd6, 0x78,
-	0x29, 0x8c, 0x00, 0x53, 0xcb, 0xfe, 0x1e, 0x16, 0x93, 0x19, 0x26, 0x38, 0x3f, 0xd9, 0x8b, 0x3d,
-	0x3e, 0x3f, 0xd9, 0xc0, 0xf8, 0x19, 0x8a, 0x21, 0x7d, 0x54, 0x17, 0xa7, 0xbc, 0xa9, 0xbf, 0x94,
-	0xb4, 0xad, 0x08, 0xda, 0x92, 0xa0, 0xa9, 0xd5, 0xfd, 0xa5, 0x41, 0xfe, 0x34, 0x12, 0xf5, 0xe3,
-	0x71, 0x3a, 0x4c, 0x59, 0x7c, 0x40, 0x26, 0xa4, 0x34, 0x7a, 0xae, 0xaf, 0xc2, 0xa5, 0x2e, 0x12,
-	0xdf, 0xf1, 0x5c, 0x5e, 0xee, 0x39, 0x1e, 0x5a, 0x8d, 0x66, 0xcd, 0xf8, 0xb1, 0xbe, 0x08, 0x99,
-	0x97, 0xd1, 0x0a, 0xa2, 0x93, 0x91, 0x8f, 0xc2, 0xf9, 0x67, 0x0d, 0xea, 0x74, 0x31, 0x3f, 0x5d,
-	0x9c, 0x0a, 0xe7, 0xa3, 0x91, 0x71, 0xcc, 0xd4, 0x24, 0x57, 0xc8, 0x5d, 0xc9, 0xc5, 0x1b, 0x43,
-	0x17, 0x27, 0xab, 0x8d, 0x1e, 0xcc, 0x8f, 0x63, 0x55, 0x4d, 0xbb, 0x0f, 0x73, 0x51, 0xbb, 0xc7,
-	0x41, 0xd1, 0x76, 0xd0, 0x39, 0x88, 0x51, 0x73, 0x44, 0xb6, 0x3e, 0x1c, 0x18, 0xbf, 0x68, 0x70,
-	0x7b, 0x07, 0xe9, 0xb3, 0xa0, 0x79, 0x8c, 0x2e, 0x45, 0x5b, 0x0c, 0x1c, 0x17, 0x5e, 0x96, 0x84,
-	0x7f, 0x3c, 0x14, 0x7e, 0x16, 0x43, 0x6a, 0x1f, 0x7e, 0xd3, 0x60, 0xe5, 0x1c, 0x2e, 0x55, 0x5f,
-	0x9e, 0x26, 0xfa, 0x12, 0xb7, 0x03, 0x89, 0xff, 0x34, 0x62, 0x50, 0x74, 0x4c, 0xbe, 0x44, 0xbb,
-	0x89, 0x64, 0xcf, 0xa2, 0x2d, 0xb5, 0x63, 0x52, 0xc6, 0xa5, 0xf6, 0xe2, 0x2d, 0x3b, 0x26, 0x65,
-	0x02, 0x55, 0x03, 0x1e, 0xc0, 0x65, 0xd1, 0x80, 0x78, 0x57, 0x25, 0x55, 0xc6, 0x9c, 0x20, 0xdc,
-	0xe7, 0x5d, 0xf2, 0x41, 0x6f, 0x8f, 0x78, 0xde, 0x1b, 0xb5, 0x2e, 0x79, 0x0c, 0x94, 0x5a, 0xf3,
-	0x77, 0xac, 0x4b, 0x1e, 0x43, 0xab, 0x0a, 0x5e, 0x84, 0x4c, 0xcb, 0xf2, 0x5b, 0xfc, 0xfc, 0x98,
-	0x33, 0xf9, 0x48, 0x68, 0x1a, 0x93, 0x15, 0x9d, 0xdb, 0x34, 0x4e, 0xa6, 0x89, 0x0e, 0x7a, 0xc6,
-	0xff, 0xa4, 0x6a, 0x1d, 0x2e, 0x76, 0x2c, 0xda, 0xe2, 0xd9, 0x8b, 0xbd, 0x7e, 0xb5, 0x77, 0x40,
-	0x1c, 0x64, 0xc4, 0xdb, 0x6d, 0x0c, 0x4b, 0xd9, 0x64, 0x61, 0xc6, 0x1a, 0xe8, 0xf2, 0x33, 0xc1,
-	0x1a, 0x6d, 0xc4, 0x9a, 0xb7, 0xf0, 0xfe, 0x0e, 0xd2, 0x17, 0x8e, 0x4f, 0x3d, 0xe2, 0x34, 0xac,
-	0x76, 0xe2, 0x65, 0xe2, 0x89, 0xe4, 0x4f, 0x71, 0xe8, 0x4f, 0x32, 0x36, 0xb5, 0x49, 0x3f, 0xb1,
-	0xee, 0x2c, 0x99, 0x44, 0xd5, 0xa9, 0xcf, 0x20, 0xc3, 0xae, 0x14, 0x71, 0xa5, 0xc7, 0xad, 0x5c,
-	0x35, 0x9c, 0x1c, 0xe9, 0xb1, 0x79, 0x1c, 0xef, 0x0a, 0xa2, 0xff, 0x64, 0xb5, 0xaf, 0xd6, 0x15,
-	0x24, 0x00, 0x53, 0x0b, 0xff, 0x53, 0x63, 0x6d, 0x41, 0x02, 0x85, 0xaa, 0xec, 0x32, 0x5c, 0x22,
-	0x68, 0xd9, 0xb5, 0x7a, 0x9f, 0xeb, 0xbe, 0x73, 0xe6, 0x0a, 0x4b, 0xe1, 0xb8, 0xdc, 0xdf, 0x76,
-	0x29, 0xe9, 0x9b, 0x19, 0xc2, 0x06, 0x85, 0x47, 0x90, 0x15, 0xa6, 0xf5, 0x79, 0x98, 0x0a, 0x2f,
-	0x13, 0xd1, 0xd7, 0x80, 0xf0, 0xe7, 0xe8, 0xdd, 0xed, 0x32, 0xbf, 0xbb, 0x3d, 0xbe, 0xf0, 0x50,
-	0x13, 0x3c, 0x3c, 0x24, 0x0e, 0x9d, 0xc8, 0xc3, 0x31, 0x60, 0x6a, 0x0f, 0xff, 0x1e, 0x7a, 0x38,
-	0x46, 0xa1, 0xea, 0x61, 0x05, 0xe0, 0x84, 0x38, 0x94, 0xa2, 0x3b, 0xb4, 0x71, 0xed, 0xcc, 0x45,
-	0x96, 0x0e, 0xa3, 0xf8, 0xd8, 0xc9, 0xd9, 0x93, 0x78, 0x5c, 0x78, 0x02, 0xb9, 0xd1, 0x87, 0x4a,
-	0x7e, 0x46, 0x5b, 0x92, 0x1f, 0x1b, 0x5d, 0x74, 0x2d, 0xb7, 0x81, 0x6a, 0x5b, 0x32, 0x19, 0x9b,
-	0xda, 0xd5, 0xc7, 0x70, 0xa5, 0x52, 0xf5, 0xc5, 0xfd, 0x12, 0xdf, 0x5b, 0xb5, 0xf3, 0xee, 0xad,
-	0xc6, 0x3f, 0x1a, 0xdb, 0xcf, 0xc9, 0x2b, 0x50, 0x4d, 0xca, 0x3e, 0x64, 0xb7, 0xca, 0x15, 0xec,
-	0x57, 0xc5, 0x4d, 0xfd, 0xf9, 0x79, 0x3a, 0x4b, 0x02, 0x26, 0x4a, 0x8d, 0xc8, 0x52, 0xa8, 0xc2,
-	0xfc, 0x78, 0x40, 0x42, 0x7a, 0xd6, 0xc4, 0xf4, 0x0c, 0x2f, 0xc5, 0x63, 0xbe, 0x88, 0x69, 0x7b,
-	0xa7, 0xc1, 0x07, 0xec, 0x15, 0xb6, 0xbb, 0xe5, 0xef, 0x07, 0xf5, 0xe3, 0x30, 0xff, 0x76, 0xb9,
-	0x2f, 0x65, 0xee, 0xa9, 0x94, 0x39, 0x43, 0x7c, 0x7d, 0x26, 0xa3, 0x53, 0xe7, 0xae, 0xce, 0xbe,
-	0xd8, 0x9d, 0x46, 0x33, 0xc1, 0x85, 0x83, 0x86, 0x54, 0xcc, 0xfa, 0x59, 0x33, 0x1a, 0x84, 0x17,
-	0xea, 0x83, 0x9e, 0x89, 0x0d, 0x74, 0x3a, 0x54, 0xe1, 0x42, 0x2d, 0x61, 0x52, 0x8b, 0x72, 0x61,
-	0x41, 0x02, 0xab, 0x4a, 0xf9, 0x24, 0x3c, 0x24, 0x19, 0x03, 0x4f, 0xe9, 0xbc, 0xb4, 0xac, 0x38,
-	0x20, 0x14, 0x18, 0x96, 0xd6, 0xd7, 0x01, 0x92, 0xbe, 0x82, 0x40, 0x09, 0x93, 0x5a, 0xe0, 0x11,
-	0x2c, 0x48, 0xe0, 0xff, 0xeb, 0xd3, 0x52, 0xf9, 0xde, 0xeb, 0xcd, 0xa6, 0x43, 0x5b, 0x41, 0xbd,
-	0xd4, 0xf0, 0x8e, 0x37, 0x5a, 0xfd, 0x0e, 0x92, 0x36, 0xeb, 0x35, 0xd7, 0xdb, 0x56, 0xdd, 0xdf,
-	0xf0, 0x88, 0xe3, 0xb9, 0xeb, 0x3e, 0x92, 0x2e, 0x92, 0x8d, 0xce, 0x51, 0x73, 0x83, 0x31, 0xd5,
-	0x33, 0xec, 0xe3, 0xf1, 0xdd, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xb2, 0xca, 0xb5, 0x8d, 0x87,
-	0x16, 0x00, 0x00,
+	// 1806 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x5d, 0x53, 0xdb, 0xca,
+	0x19, 0xae, 0xf8, 0x70, 0xf0, 0x6b, 0x03, 0x46, 0x7c, 0xc4, 0x18, 0xd2, 0x38, 0xea, 0x47, 0x48,
+	0x03, 0xa6, 0x25, 0x49, 0xf3, 0xd1, 0x34, 0x33, 0x18, 0x1c, 0xa0, 0x10, 0x4a, 0x64, 0x02, 0xd3,
+	0xb4, 0x1d, 0x8f, 0x6c, 0x2f, 0xb6, 0x06, 0x23, 0x19, 0xed, 0x0a, 0x70, 0xa7, 0x99, 0x4c, 0x27,
+	0x97, 0x99, 0x69, 0x2f, 0xdb, 0x9b, 0xfe, 0x8b, 0xde, 0xf5, 0xaa, 0x37, 0xfd, 0x01, 0xbd, 0xeb,
+	0xcc, 0xf9, 0x1f, 0xe7, 0xf6, 0x8c, 0x76, 0x57, 0x96, 0xe4, 0x95, 0x41, 0xf2, 0x39, 0x39, 0x77,
+	0xde, 0xdd, 0xf7, 0x79, 0xb4, 0xcf, 0xf3, 0xbe, 0x5a, 0xed, 0xae, 0x61, 0xc2, 0x42, 0xb8, 0x6d,
+	0x1a, 0x18, 0x15, 0xda, 0x96, 0x49, 0x4c, 0x79, 0x94, 0x74, 0xda, 0x08, 0xe7, 0xa6, 0x6b, 0xa6,
+	0x71, 0xa2, 0x37, 0x6c, 0x4b, 0x23, 0xba, 0x69, 0xb0, 0xb1, 0xdc, 0x42, 0xb5, 0x65, 0xd6, 0x4e,
+	0x2b, 0x9a, 0x51, 0xaf, 0x10, 0x4b, 0x33, 0xb0, 0x56, 0xf3, 0x0d, 0xa6, 0xce, 0x6d, 0x64, 0x75,
+	0x58, 0x43, 0xd9, 0x83, 0x09, 0x95, 0xf3, 0x6e, 0x23, 0xad, 0x8e, 0x2c, 0xf9, 0x36, 0xdc, 0x32,
+	0xcc, 0x3a, 0xaa, 0xe8, 0xf5, 0xac, 0x94, 0x97, 0x96, 0x92, 0x6a, 0xc2, 0x69, 0xee, 0xd4, 0xe5,
+	0x7b, 0x90, 0x66, 0xb4, 0x4d, 0xa4, 0x37, 0x9a, 0x24, 0x3b, 0x94, 0x97, 0x96, 0x46, 0xd4, 0x14,
+	0xed, 0xdb, 0xa6, 0x5d, 0x0a, 0x86, 0x85, 0x2d, 0x44, 0x36, 0x8b, 0x65, 0xa2, 0x11, 0x1b, 0xbb,
+	0xc4, 0x25, 0xe3, 0x02, 0xb5, 0xcc, 0x36, 0x92, 0x7f, 0x09, 0x63, 0xae, 0x08, 0xca, 0x9d, 0x5a,
+	0xcb, 0x15, 0xa8, 0x8a, 0x42, 0x08, 0x4a, 0xed, 0xc6, 0xca, 0x8b, 0x90, 0xc4, 0x7a, 0xc3, 0xd0,
+	0x88, 0x6d, 0x21, 0xfa, 0xd8, 0xb4, 0xea, 0x75, 0x28, 0xef, 0x61, 0x3a, 0x04, 0x2e, 0xaf, 0x40,
+	0xa2, 0x49, 0x15, 0xf1, 0x47, 0xcd, 0xf2, 0x47, 0x05, 0xe5, 0xaa, 0x3c, 0x48, 0x9e, 0x81, 0x51,
+	0x74, 0xa5, 0x63, 0x26, 0x6b, 0x4c, 0x65, 0x0d, 0xe5, 0x1c, 0x72, 0x94, 0x7b, 0xc7, 0xa8, 0xa3,
+	0x2b, 0x41, 0xcf, 0x13, 0x41, 0xcf, 0xbc, 0x5f, 0x4f, 0x00, 0x14, 0x59, 0xce, 0xef, 0x40, 0x16,
+	0xd1, 0x03, 0xa8, 0xd1, 0x1d, 0x3c, 0xa5, 0x4f, 0xaa, 0xac, 0xa1, 0x9c, 0xc2, 0x6d, 0x87, 0x5a,
+	0x23, 0x9a, 0x20, 0x65, 0x4d, 0x90, 0x32, 0xe7, 0x93, 0xe2, 0x43, 0x44, 0xd6, 0xf1, 0x49, 0x82,
+	0xc9, 0x1e, 0xec, 0x00, 0x2a, 0x2e, 0xb4, 0x96, 0xed, 0x92, 0xb3, 0x86, 0xfc, 0x10, 0xc6, 0xce,
+	0x10, 0xd1, 0xea, 0x1a, 0xd1, 0xb2, 0xc3, 0x94, 0x66, 0x92, 0xd3, 0xbc, 0xe1, 0xdd, 0x6a, 0x37,
+	0x40, 0xb1, 0x61, 0xd1, 0x9d, 0x84, 0x66, 0x34, 0x90, 0xa0, 0xfb, 0xa9, 0xa0, 0x7b, 0xa1, 0x47,
+	0xb7, 0x1f, 0x16, 0x59, 0xfc, 0xbf, 0x25, 0x98, 0x09, 0x23, 0x88, 0xeb, 0xc0, 0x7d, 0x18, 0xde,
+	0x3d, 0xc2, 0xd9, 0xa1, 0xfc, 0xb0, 0x2f, 0x76, 0xf7, 0xe8, 0x58, 0x27, 0xcd, 0xae, 0x58, 0x27,
+	0x42, 0xfe, 0x09, 0x4c, 0xb4, 0x91, 0x51, 0xd7, 0x8d, 0x46, 0xc5, 0x42, 0xd8, 0x6e, 0x11, 0x6a,
+	0xcd, 0x98, 0x3a, 0xce, 0x7b, 0x55, 0xda, 0x29, 0xff, 0x18, 0x26, 0x0c, 0x74, 0x45, 0x2a, 0x98,
+	0x68, 0x16, 0xa9, 0x9c, 0xa2, 0x4e, 0x76, 0x84, 0x16, 0x48, 0xda, 0xe9, 0x2d, 0x3b, 0x9d, 0xbb,
+	0xa8, 0xc3, 0xeb, 0xe4, 0x1d, 0x46, 0x56, 0xbc, 0x3a, 0xf1, 0x23, 0x22, 0x5b, 0xf5, 0x57, 0x56,
+	0x27, 0x7e, 0x6c, 0x5c, 0x97, 0xee, 0xc2, 0x88, 0x8d, 0x91, 0x45, 0xb9, 0x53, 0x6b, 0x29, 0x1e,
+	0x4c, 0x19, 0xe9, 0x40, 0xbc, 0x92, 0x31, 0x61, 0x7e, 0x0b, 0x91, 0x0d, 0xba, 0xac, 0x0a, 0xfa,
+	0x1f, 0x0b, 0xfa, 0xb3, 0x9e, 0xfe, 0x20, 0x26, 0xb2, 0x03, 0xff, 0x94, 0x60, 0x4a, 0x40, 0xc7,
+	0xf5, 0x60, 0x19, 0x12, 0xec, 0x4b, 0xc0, 0x5d, 0x98, 0xe1, 0xe1, 0x1b, 0x2d, 0x1b, 0x13, 0x64,
+	0x71, 0x72, 0x1e, 0x13, 0xcf, 0x90, 0x4b, 0xb8, 0xb3, 0x85, 0xc8, 0xbe, 0x59, 0x47, 0x7d, 0x4c,
+	0x79, 0x26, 0x98, 0xb2, 0xe8, 0x99, 0x22, 0xe2, 0x22, 0x1b, 0xf3, 0x27, 0x98, 0x0d, 0x25, 0x88,
+	0xeb, 0xcd, 0x1a, 0xa4, 0xe8, 0x27, 0x2d, 0x60, 0xd0, 0x14, 0xc7, 0xf8, 0xe8, 0xc1, 0xe8, 0xfe,
+	0x56, 0x3a, 0xf0, 0xc3, 0x6e, 0x4e, 0x8a, 0xce, 0x27, 0x4e, 0x50, 0xfd, 0x5c, 0x50, 0x7d, 0xa7,
+	0xb7, 0x14, 0x02, 0xc0, 0xc8, 0xb2, 0xff, 0x08, 0x73, 0xe1, 0x0c, 0x03, 0xac, 0x9f, 0xf4, 0xeb,
+	0xec, 0xae, 0x9f, 0xb4, 0xa1, 0x7c, 0x80, 0xbc, 0x43, 0xcf, 0xea, 0xa2, 0xcf, 0x97, 0xfa, 0x57,
+	0x82, 0xb6, 0xbb, 0x3e, 0x6d, 0x61, 0xd0, 0xc8, 0xea, 0xfe, 0x31, 0x04, 0xd9, 0x7e, 0x24, 0xf1,
+	0x97, 0xc7, 0x51, 0x27, 0x65, 0xee, 0x02, 0x19, 0x92, 0x52, 0x36, 0x2e, 0x2f, 0xc1, 0xad, 0x0b,
+	0x64, 0x61, 0xdd, 0x34, 0x78, 0xb9, 0x4f, 0xf0, 0xd0, 0x23, 0xd6, 0xab, 0xba, 0xc3, 0xf2, 0x1c,
+	0x24, 0xf6, 0xd8, 0x0c, 0xd8, 0xca, 0xc8, 0x5b, 0x4e, 0xff, 0x7a, 0x8d, 0xe8, 0x17, 0x28, 0x3b,
+	0x9a, 0x1f, 0x76, 0xfa, 0x59, 0x4b, 0xfe, 0x0d, 0x4c, 0xb7, 0x68, 0x04, 0x6e, 0xea, 0x6d, 0xb6,
+	0xdb, 0x3a, 0x41, 0x56, 0x36, 0x11, 0xd8, 0x0e, 0xec, 0x75, 0x23, 0x0e, 0x79, 0x80, 0x2a, 0xb7,
+	0x84, 0x3e, 0xe5, 0x2b, 0x09, 0x64, 0x31, 0x54, 0xce, 0x43, 0xfa, 0xc4, 0x32, 0xcf, 0x2a, 0xc1,
+	0x6d, 0x19, 0x38, 0x7d, 0xfb, 0x6c, 0x6b, 0xb6, 0x08, 0x40, 0xcc, 0xee, 0x38, 0xfb, 0xe6, 0x8f,
+	0x11, 0x93, 0x8f, 0x3e, 0x83, 0x04, 0xa6, 0x36, 0x53, 0xed, 0x13, 0x6b, 0xf9, 0xbe, 0xb3, 0x2a,
+	0xf0, 0x74, 0xf0, 0x78, 0x47, 0xb4, 0x85, 0x34, 0x6c, 0x1a, 0xae, 0x19, 0xac, 0xa5, 0x3c, 0x86,
+	0x04, 0x8b, 0x94, 0x27, 0x21, 0xb5, 0xb3, 0x5f, 0x39, 0x50, 0x7f, 0xbb, 0xa5, 0x96, 0xca, 0xe5,
+	0xcc, 0x0f, 0xe4, 0x71, 0x48, 0x96, 0xdf, 0x6d, 0x6c, 0x94, 0x4a, 0x9b, 0xa5, 0xcd, 0x8c, 0x24,
+	0x03, 0x24, 0x5e, 0xaf, 0xef, 0xec, 0x95, 0x36, 0x33, 0x43, 0xca, 0x5f, 0x24, 0x50, 0xdc, 0x27,
+	0x79, 0xcf, 0x16, 0x6a, 0xef, 0xd7, 0x42, 0xed, 0xdd, 0xe3, 0x13, 0xee, 0x0f, 0x8e, 0x5c, 0x7d,
+	0x7f, 0x97, 0x20, 0xd7, 0x9f, 0x26, 0x6e, 0xfd, 0xf5, 0x49, 0xfe, 0xd0, 0x20, 0xc9, 0xff, 0x00,
+	0xf9, 0x32, 0x22, 0xfb, 0x88, 0x5c, 0x9a, 0xd6, 0xe9, 0x6b, 0xcd, 0x6e, 0x91, 0x38, 0xaf, 0x65,
+	0x3f, 0x68, 0x64, 0x63, 0x2e, 0x20, 0xdb, 0x8f, 0x23, 0xae, 0x2b, 0x0f, 0x21, 0x71, 0x42, 0x09,
+	0xf8, 0x6b, 0x39, 0xed, 0xbe, 0x96, 0x3e, 0x72, 0x95, 0x87, 0x28, 0x67, 0x74, 0x35, 0x08, 0x5f,
+	0x61, 0x1f, 0x09, 0x72, 0x6f, 0x7b, 0xab, 0xd0, 0x60, 0x6b, 0xeb, 0x7f, 0x24, 0xc8, 0xf4, 0x82,
+	0xe3, 0xea, 0x7b, 0xe2, 0x1d, 0x84, 0x28, 0x88, 0xa5, 0x5b, 0xe6, 0xa0, 0x22, 0x3b, 0x0f, 0x51,
+	0x44, 0xaa, 0xea, 0x35, 0xe4, 0x2d, 0x90, 0xcf, 0x6d, 0xd3, 0xb2, 0xcf, 0x2a, 0x35, 0x64, 0x11,
+	0xfd, 0x44, 0xaf, 0x69, 0x04, 0x65, 0x87, 0x03, 0x9b, 0x88, 0xb7, 0x34, 0x60, 0xc3, 0x1b, 0x57,
+	0xa7, 0xce, 0x7b, 0xbb, 0x94, 0xcf, 0x12, 0xdc, 0xdf, 0x42, 0x64, 0xdd, 0x6e, 0x9c, 0x21, 0x83,
+	0xa0, 0xba, 0xff, 0x89, 0xbd, 0x16, 0x16, 0x05, 0x0b, 0x7f, 0xea, 0x59, 0x78, 0x1d, 0x43, 0x64,
+	0x47, 0xff, 0x2f, 0xc1, 0xdd, 0x1b, 0xb8, 0xe2, 0x1a, 0xfc, 0x2a, 0xd4, 0x60, 0x77, 0x63, 0x1e,
+	0xfa, 0xa4, 0x2f, 0xe3, 0x34, 0xdb, 0xf9, 0xec, 0xa1, 0x7a, 0x03, 0x59, 0x07, 0x1a, 0x69, 0xc6,
+	0xdb, 0xf9, 0x88, 0xb8, 0xc8, 0xa6, 0x7e, 0x84, 0xd9, 0x50, 0x82, 0xb8, 0x4e, 0x3e, 0x85, 0x71,
+	0xbf, 0x93, 0xee, 0x1b, 0x19, 0x56, 0xab, 0x69, 0x9f, 0x83, 0x58, 0xf9, 0x9b, 0x04, 0x0f, 0xba,
+	0x33, 0xd8, 0x30, 0x0d, 0xac, 0x63, 0x82, 0x8c, 0x5a, 0xe7, 0xc0, 0x32, 0xcd, 0x13, 0xc1, 0x86,
+	0x4d, 0xc1, 0x86, 0xa5, 0x5e, 0x1b, 0xfa, 0x71, 0x44, 0xb6, 0xe4, 0xb3, 0x04, 0xf7, 0x6e, 0x64,
+	0xfb, 0xde, 0xfc, 0x61, 0x17, 0x03, 0x87, 0x57, 0xe1, 0x7e, 0x5c, 0x7b, 0x31, 0x70, 0x78, 0x35,
+	0x98, 0x01, 0xbf, 0x07, 0x59, 0x44, 0xc7, 0x15, 0x3c, 0x07, 0x89, 0xa6, 0x86, 0x9b, 0x7c, 0xcb,
+	0x94, 0x56, 0x79, 0x8b, 0xdf, 0xdc, 0x70, 0xf2, 0x98, 0x37, 0x37, 0xbd, 0xa8, 0xc8, 0x8a, 0xfe,
+	0x00, 0xd3, 0x21, 0xf0, 0xef, 0x4a, 0x92, 0x77, 0xf4, 0x0f, 0x4f, 0xd2, 0x8d, 0x47, 0xff, 0xc1,
+	0xd2, 0xf4, 0x2f, 0xef, 0xe8, 0xff, 0xad, 0x32, 0xb5, 0x02, 0x23, 0x6d, 0x8d, 0x34, 0x79, 0x45,
+	0xba, 0xf5, 0xf3, 0xe6, 0xe0, 0xd0, 0xd2, 0x11, 0x25, 0x2e, 0xb5, 0x90, 0xb3, 0x0e, 0xaa, 0x34,
+	0x4c, 0x7e, 0x05, 0xe3, 0x5a, 0x15, 0x23, 0xa3, 0x86, 0x2a, 0x6d, 0x67, 0x94, 0x2f, 0x77, 0x41,
+	0xdc, 0x3a, 0x8b, 0x60, 0xf3, 0x4a, 0x6b, 0xbe, 0x96, 0xb2, 0x0c, 0xb2, 0xc8, 0xed, 0xf3, 0x56,
+	0x0a, 0x78, 0x7b, 0x09, 0xb2, 0xc8, 0xd8, 0x9d, 0xb2, 0x14, 0x6d, 0xca, 0x6b, 0x90, 0xbc, 0xd4,
+	0x89, 0x81, 0x30, 0xee, 0xee, 0xe0, 0x67, 0x02, 0x98, 0x63, 0x36, 0xaa, 0x7a, 0x61, 0x8a, 0x0d,
+	0xe3, 0x81, 0x31, 0x67, 0x86, 0x86, 0x5e, 0xad, 0xb6, 0x58, 0x0e, 0xc7, 0x55, 0xde, 0x8a, 0x6b,
+	0xdf, 0x1d, 0x00, 0x7a, 0xbb, 0x54, 0x71, 0x04, 0x52, 0xef, 0xd2, 0x6a, 0x92, 0xf6, 0x6c, 0x6b,
+	0xb8, 0xc9, 0x3f, 0x04, 0xdd, 0x9c, 0xe2, 0x78, 0x1f, 0x02, 0x11, 0x17, 0xb9, 0x9a, 0x6c, 0x98,
+	0x0d, 0x25, 0x88, 0x5f, 0x4d, 0xa3, 0xac, 0x2c, 0x86, 0x02, 0xfb, 0x28, 0xe6, 0xc7, 0x1b, 0xbb,
+	0x45, 0x74, 0x56, 0x14, 0x2c, 0x4a, 0x39, 0x81, 0x4c, 0xef, 0x90, 0xbc, 0xea, 0x1e, 0xb6, 0x6e,
+	0x4c, 0x2f, 0x8b, 0x73, 0x2e, 0x8c, 0x3d, 0x4f, 0xbb, 0xaf, 0x67, 0xaa, 0xeb, 0x2a, 0xc2, 0xca,
+	0x47, 0xba, 0xa6, 0x6f, 0xeb, 0x98, 0x98, 0x96, 0x5e, 0xd3, 0x5a, 0xa1, 0x77, 0x93, 0x2f, 0x05,
+	0x6f, 0xf3, 0x9e, 0xb7, 0xe1, 0xd8, 0xc8, 0xfe, 0xfe, 0x19, 0xe6, 0xfb, 0x92, 0xc4, 0xf5, 0xf8,
+	0xe7, 0x90, 0xa0, 0xda, 0xdc, 0x62, 0x76, 0xb7, 0x1a, 0x47, 0x4e, 0x67, 0xe0, 0xca, 0x8e, 0xc7,
+	0xf1, 0x4b, 0x06, 0xf6, 0x4c, 0x87, 0x02, 0xc7, 0xbb, 0x64, 0x08, 0x01, 0x46, 0x16, 0xfe, 0x5f,
+	0x09, 0xe6, 0xc2, 0x29, 0xe2, 0xca, 0x2e, 0xc2, 0x2d, 0x0b, 0x69, 0xf5, 0x4a, 0xb5, 0xc3, 0x75,
+	0x3f, 0xb8, 0x76, 0x86, 0x05, 0xa7, 0x5d, 0xec, 0x94, 0x0c, 0x62, 0x75, 0xe8, 0x81, 0xb2, 0x5e,
+	0xec, 0xe4, 0x9e, 0x43, 0xca, 0xd7, 0x2d, 0x67, 0x60, 0xd8, 0xb9, 0x9b, 0x64, 0x07, 0x5d, 0xe7,
+	0x67, 0xf0, 0x2a, 0x78, 0x9c, 0x5f, 0x05, 0xbf, 0x18, 0x7a, 0x26, 0xf9, 0x3c, 0x3c, 0xb6, 0x74,
+	0x32, 0x90, 0x87, 0x3d, 0xc0, 0xc8, 0x1e, 0xfe, 0xcf, 0xf3, 0xb0, 0x87, 0x22, 0xae, 0x87, 0xbb,
+	0x00, 0x97, 0x96, 0x4e, 0x08, 0x32, 0x3c, 0x1b, 0x97, 0xaf, 0x9d, 0x64, 0xe1, 0x98, 0xc5, 0xbb,
+	0x4e, 0x26, 0x2f, 0xdd, 0x76, 0xee, 0x25, 0x4c, 0x04, 0x07, 0x63, 0xf9, 0xc9, 0x5e, 0x49, 0xbe,
+	0xe2, 0x5c, 0x20, 0x43, 0x33, 0x6a, 0x28, 0xde, 0x2b, 0x19, 0x8e, 0x8d, 0xec, 0xea, 0x0b, 0x98,
+	0xdc, 0x3d, 0xc2, 0xfe, 0xf7, 0xc5, 0xbd, 0x06, 0x97, 0x6e, 0xba, 0x06, 0x57, 0xbe, 0x96, 0x60,
+	0xbe, 0xef, 0x0c, 0xe2, 0x26, 0xa5, 0x0c, 0xa9, 0xcd, 0xe2, 0x2e, 0xea, 0x1c, 0xf9, 0x5f, 0xea,
+	0x5f, 0xdc, 0xa4, 0xb3, 0xe0, 0xc3, 0xb0, 0xd4, 0xf8, 0x59, 0x72, 0x47, 0x90, 0xe9, 0x0d, 0x08,
+	0x49, 0xcf, 0xb2, 0x3f, 0x3d, 0xde, 0x1d, 0x7b, 0x8f, 0x2f, 0xfe, 0xb4, 0x7d, 0x92, 0xe0, 0x47,
+	0x74, 0x33, 0xb5, 0xb3, 0x89, 0xcb, 0x76, 0xf5, 0xcc, 0xc9, 0x7f, 0xbd, 0xd8, 0x11, 0x32, 0xf7,
+	0x4a, 0xc8, 0x9c, 0xe2, 0xdf, 0xc9, 0x85, 0xa3, 0x23, 0xe7, 0xae, 0x0a, 0x0b, 0xd7, 0xd0, 0x0c,
+	0x70, 0x7f, 0x49, 0x1c, 0x2a, 0x6a, 0x7d, 0x52, 0x65, 0x0d, 0xe7, 0x7e, 0xfe, 0xf0, 0x4a, 0x45,
+	0x35, 0xa4, 0xb7, 0x49, 0x8c, 0xfb, 0x79, 0x01, 0x13, 0x59, 0x94, 0x01, 0x53, 0x02, 0x38, 0xae,
+	0x94, 0x9f, 0x39, 0x8b, 0x24, 0x65, 0xe0, 0x29, 0xcd, 0x08, 0xd3, 0x72, 0x03, 0x1c, 0x81, 0x4e,
+	0x69, 0xbd, 0x75, 0xfe, 0xa6, 0x8d, 0x21, 0x50, 0xc0, 0x44, 0x16, 0x78, 0x0a, 0x53, 0x02, 0xf8,
+	0x4b, 0xfd, 0x53, 0x55, 0x7c, 0xfc, 0x7e, 0xad, 0xa1, 0x93, 0xa6, 0x5d, 0x2d, 0xd4, 0xcc, 0xb3,
+	0xd5, 0x66, 0xa7, 0x8d, 0xac, 0x16, 0x3d, 0xd3, 0xad, 0xb4, 0xb4, 0x2a, 0x5e, 0x35, 0x2d, 0xdd,
+	0x34, 0x56, 0x30, 0xb2, 0x2e, 0x90, 0xb5, 0xda, 0x3e, 0x6d, 0xac, 0x52, 0xa6, 0x6a, 0x82, 0xfe,
+	0x5d, 0xfd, 0xe8, 0x9b, 0x01, 0x00, 0xbb, 0xdc, 0x98, 0xe8, 0x06, 0x1f, 0x00, 0x00,
 }
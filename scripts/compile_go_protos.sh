@@ -13,3 +13,10 @@ for protos in $(find . -name '*.proto' -exec dirname {} \; | sort -u); do
     $protos/*.proto
 done
 
+
+# internal protos, which define gRPC services as well
+cd ../internal/comm/peerpb
+protoc \
+  --proto_path . \
+  --go_out=plugins=grpc,paths=source_relative:. \
+  peer.proto
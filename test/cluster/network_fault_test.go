@@ -0,0 +1,174 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/hyperledger-labs/orion-server/test/setup"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func startFaultyCluster(t *testing.T) *setup.Cluster {
+	dir, err := ioutil.TempDir("", "int-test")
+	require.NoError(t, err)
+
+	nPort, pPort := getPorts(3)
+	setupConfig := &setup.Config{
+		NumberOfServers:     3,
+		TestDirAbsolutePath: dir,
+		BDBBinaryPath:       "../../bin/bdb",
+		CmdTimeout:          10 * time.Second,
+		BaseNodePort:        nPort,
+		BasePeerPort:        pPort,
+		CheckRedirectFunc: func(req *http.Request, via []*http.Request) error {
+			return errors.Errorf("Redirect blocked in test client: url: '%s', referrer: '%s', #via: %d", req.URL, req.Referer(), len(via))
+		},
+	}
+	c, err := setup.NewCluster(setupConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.ShutdownAndCleanup() })
+
+	require.NoError(t, c.Start())
+	return c
+}
+
+func agreedLeader(t *testing.T, c *setup.Cluster, servers ...int) int {
+	leaderIndex := -1
+	require.Eventually(t, func() bool {
+		leaderIndex = c.AgreedLeader(t, servers...)
+		return leaderIndex >= 0
+	}, 30*time.Second, 100*time.Millisecond)
+	return leaderIndex
+}
+
+func writeKey(t *testing.T, c *setup.Cluster, leaderIndex int, key string) {
+	txID, rcpt, _, err := c.Servers[leaderIndex].WriteDataTx(t, worldstate.DefaultDBName, key, []byte(key+"-data"))
+	require.NoError(t, err)
+	require.NotNil(t, rcpt)
+	require.Equal(t, types.Flag_VALID, rcpt.Header.ValidationInfo[rcpt.TxIndex].Flag)
+	t.Logf("tx submitted: %s, %+v", txID, rcpt)
+}
+
+func hasKey(t *testing.T, c *setup.Cluster, server int, key string) bool {
+	dataEnv, err := c.Servers[server].QueryData(t, worldstate.DefaultDBName, key, "admin")
+	return err == nil && string(dataEnv.GetResponse().GetValue()) == key+"-data"
+}
+
+// Scenario: split-brain
+// - start 3 servers in a cluster, wait for a leader, submit a tx.
+// - partition the leader from the two followers.
+// - the followers elect a new leader, a tx submitted to it is committed by the majority.
+// - the old leader, on the minority side, does not get the tx.
+// - heal the network: all servers agree on a single leader and on the ledger height.
+func TestNetworkPartitionSplitBrain(t *testing.T) {
+	c := startFaultyCluster(t)
+
+	leaderIndex := agreedLeader(t, c, 0, 1, 2)
+	writeKey(t, c, leaderIndex, "alice")
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, 2, 0, 1, 2)
+	}, 30*time.Second, 100*time.Millisecond)
+
+	majority := []int{(leaderIndex + 1) % 3, (leaderIndex + 2) % 3}
+	require.NoError(t, c.Partition(t, []int{leaderIndex}, majority))
+
+	newLeader := agreedLeader(t, c, majority...)
+	require.NotEqual(t, leaderIndex, newLeader)
+	writeKey(t, c, newLeader, "bob")
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, 3, majority...)
+	}, 30*time.Second, 100*time.Millisecond)
+	require.False(t, hasKey(t, c, leaderIndex, "bob"))
+
+	require.NoError(t, c.HealNetwork(t))
+	agreedLeader(t, c, 0, 1, 2)
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, 3, 0, 1, 2) && hasKey(t, c, leaderIndex, "bob")
+	}, 30*time.Second, 100*time.Millisecond)
+}
+
+// Scenario: asymmetric partition
+// - start 3 servers in a cluster, wait for a leader.
+// - a follower cannot reach the other servers, but they reach it.
+// - the follower does not disrupt the leader, and a tx submitted to the leader is committed by the majority.
+// - heal the network: the follower catches up, and all servers agree on the ledger height.
+func TestNetworkPartitionAsymmetric(t *testing.T) {
+	c := startFaultyCluster(t)
+
+	leaderIndex := agreedLeader(t, c, 0, 1, 2)
+	victim := (leaderIndex + 1) % 3
+	others := []int{leaderIndex, (leaderIndex + 2) % 3}
+	require.NoError(t, c.PartitionOneWay(t, []int{victim}, others))
+
+	leaderIndex = agreedLeader(t, c, others...)
+	writeKey(t, c, leaderIndex, "alice")
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, 2, others...)
+	}, 30*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, c.HealNetwork(t))
+	leaderIndex = agreedLeader(t, c, 0, 1, 2)
+	writeKey(t, c, leaderIndex, "bob")
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, 3, 0, 1, 2) && hasKey(t, c, victim, "alice")
+	}, 30*time.Second, 100*time.Millisecond)
+}
+
+// Scenario: leader flapping
+// - start 3 servers in a cluster.
+// - repeatedly isolate the current leader, wait for a new leader among the other servers, and submit a tx to it.
+// - heal the network: all servers agree on a single leader and on the ledger height.
+func TestNetworkPartitionLeaderFlapping(t *testing.T) {
+	c := startFaultyCluster(t)
+
+	leaderIndex := agreedLeader(t, c, 0, 1, 2)
+	keys := []string{"alice", "bob", "charlie", "dan"}
+	for _, key := range keys {
+		others := []int{(leaderIndex + 1) % 3, (leaderIndex + 2) % 3}
+		require.NoError(t, c.Partition(t, []int{leaderIndex}, others))
+
+		newLeader := agreedLeader(t, c, others...)
+		require.NotEqual(t, leaderIndex, newLeader)
+		writeKey(t, c, newLeader, key)
+
+		require.NoError(t, c.HealNetwork(t))
+		leaderIndex = agreedLeader(t, c, 0, 1, 2)
+	}
+
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, uint64(len(keys)+1), 0, 1, 2)
+	}, 30*time.Second, 100*time.Millisecond)
+	for _, key := range keys {
+		for i := range c.Servers {
+			require.True(t, hasKey(t, c, i, key))
+		}
+	}
+}
+
+// Scenario: lossy network
+// - start 3 servers in a cluster, with a seeded drop, duplicate and delay of the messages between all servers.
+// - the txs submitted to the leader are eventually committed by all the servers.
+func TestNetworkLossyLinks(t *testing.T) {
+	c := startFaultyCluster(t)
+
+	agreedLeader(t, c, 0, 1, 2)
+	require.NoError(t, c.SetNetworkFaults(t, 42, &types.NetworkFault{DropRate: 0.1, DuplicateRate: 0.1, DelayMs: 20}))
+
+	keys := []string{"alice", "bob", "charlie"}
+	for _, key := range keys {
+		writeKey(t, c, agreedLeader(t, c, 0, 1, 2), key)
+	}
+
+	require.Eventually(t, func() bool {
+		return c.AgreedHeight(t, uint64(len(keys)+1), 0, 1, 2)
+	}, 60*time.Second, 100*time.Millisecond)
+	require.NoError(t, c.HealNetwork(t))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
 )
 
@@ -45,6 +46,9 @@ type Config struct {
 	BaseNodePort        uint32
 	BasePeerPort        uint32
 	CheckRedirectFunc   func(req *http.Request, via []*http.Request) error // rest client checks redirects
+	// StateDB is the state database engine of the servers, "leveldb" if empty. With "memory", the servers keep the
+	// whole ledger in memory, which is faster, but a restarted server starts with an empty ledger.
+	StateDB string
 }
 
 // NewCluster creates a new cluster environment for the blockchain database
@@ -96,6 +100,7 @@ func NewCluster(conf *Config) (*Cluster, error) {
 		if err != nil {
 			return nil, err
 		}
+		cluster.Servers[i].stateDB = conf.StateDB
 	}
 
 	if err := cluster.createCryptoMaterials(); err != nil {
@@ -341,6 +346,58 @@ func (c *Cluster) AgreedHeight(t *testing.T, expectedBlockHeight uint64, activeS
 	return true
 }
 
+// SetNetworkFaults sets the same network faults in all the servers of the cluster, which must be running. The faults
+// replace the faults that were set before, and are lost when a server restarts. The seed makes the random drops and
+// duplicates reproducible.
+func (c *Cluster) SetNetworkFaults(t *testing.T, seed int64, faults ...*types.NetworkFault) error {
+	for _, s := range c.Servers {
+		if _, err := s.SetNetworkFaults(t, faults, seed); err != nil {
+			return errors.WithMessagef(err, "failed to set network faults in server [%s]", s.ID())
+		}
+	}
+	return nil
+}
+
+// Partition splits the servers, given by their index, into groups that cannot reach each other. The servers in a
+// group, and the servers that are not in any group, keep reaching each other.
+func (c *Cluster) Partition(t *testing.T, groups ...[]int) error {
+	var faults []*types.NetworkFault
+	for i, from := range groups {
+		for j, to := range groups {
+			if i == j {
+				continue
+			}
+			faults = append(faults, c.oneWayPartition(from, to)...)
+		}
+	}
+	return c.SetNetworkFaults(t, 0, faults...)
+}
+
+// PartitionOneWay makes the servers `from` unable to reach the servers `to`, while the servers `to` keep reaching the
+// servers `from`.
+func (c *Cluster) PartitionOneWay(t *testing.T, from, to []int) error {
+	return c.SetNetworkFaults(t, 0, c.oneWayPartition(from, to)...)
+}
+
+func (c *Cluster) oneWayPartition(from, to []int) []*types.NetworkFault {
+	var faults []*types.NetworkFault
+	for _, f := range from {
+		for _, t := range to {
+			faults = append(faults, &types.NetworkFault{
+				FromNodeId: c.Servers[f].ID(),
+				ToNodeId:   c.Servers[t].ID(),
+				Partition:  true,
+			})
+		}
+	}
+	return faults
+}
+
+// HealNetwork removes the network faults from all the servers of the cluster.
+func (c *Cluster) HealNetwork(t *testing.T) error {
+	return c.SetNetworkFaults(t, 0)
+}
+
 func (c *Cluster) AddNewServerToCluster(server *Server) {
 	c.Servers = append(c.Servers, server)
 }
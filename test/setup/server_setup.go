@@ -43,6 +43,7 @@ type Server struct {
 	nodePort             uint32
 	peerPort             uint32
 	configDir            string
+	stateDB              string
 	configFilePath       string
 	bootstrapFilePath    string
 	method               string
@@ -329,6 +330,29 @@ func (s *Server) QueryClusterStatus(t *testing.T) (*types.GetClusterStatusRespon
 	return response, err
 }
 
+// SetNetworkFaults replaces the network faults the server injects into the messages it sends to the other servers.
+// An empty list of faults heals the network.
+func (s *Server) SetNetworkFaults(t *testing.T, faults []*types.NetworkFault, seed int64) (*types.SetNetworkFaultsResponseEnvelope, error) {
+	client, err := s.NewRESTClient(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &types.SetNetworkFaultsRequest{
+		UserId: s.AdminID(),
+		Faults: faults,
+		Seed:   seed,
+	}
+	response, err := client.SetNetworkFaults(
+		&types.SetNetworkFaultsRequestEnvelope{
+			Payload:   request,
+			Signature: testutils.SignatureFromQuery(t, s.AdminSigner(), request),
+		},
+	)
+
+	return response, err
+}
+
 func (s *Server) QueryConfig(t *testing.T, user string) (*types.GetConfigResponseEnvelope, error) {
 	client, err := s.NewRESTClient(nil)
 	if err != nil {
@@ -1019,6 +1043,11 @@ func (s *Server) CreateConfigFile() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	stateDB := s.stateDB
+	if stateDB == "" {
+		stateDB = "leveldb"
+	}
+
 	localCofig := &config.LocalConfiguration{
 		Server: config.ServerConf{
 			Identity: config.IdentityConf{
@@ -1031,7 +1060,7 @@ func (s *Server) CreateConfigFile() error {
 				Port:    uint32(s.nodePort),
 			},
 			Database: config.DatabaseConf{
-				Name:            "leveldb",
+				Name:            stateDB,
 				LedgerDirectory: filepath.Join(s.configDir, "ledger"),
 			},
 			QueueLength: config.QueueLengthConf{
@@ -1047,8 +1076,9 @@ func (s *Server) CreateConfigFile() error {
 			BlockTimeout:                50 * time.Millisecond,
 		},
 		Replication: config.ReplicationConf{
-			WALDir:  filepath.Join(s.configDir, "etcdraft", "wal"),
-			SnapDir: filepath.Join(s.configDir, "etcdraft", "snap"),
+			WALDir:         filepath.Join(s.configDir, "etcdraft", "wal"),
+			SnapDir:        filepath.Join(s.configDir, "etcdraft", "snap"),
+			FaultInjection: true,
 			Network: config.NetworkConf{
 				Address: s.address,
 				Port:    uint32(s.peerPort),